@@ -0,0 +1,130 @@
+package picker
+
+// AdjacencyMatrixAggParams creates an AdjacencyMatrixAgg, a bucket aggregation
+// returning a form of adjacency matrix. The request provides a collection of
+// named filter expressions, similar to the filters aggregation request. Each
+// bucket in the response represents a non-empty cell in the matrix of
+// intersecting filters.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-adjacency-matrix-aggregation.html
+type AdjacencyMatrixAggParams struct {
+	// Named filters (Required)
+	Filters map[string]Querier
+	// The separator used to concatenate filter names. Defaults to "&".
+	Separator string
+	// Sub-aggregations
+	Aggregations Aggs
+	Meta         map[string]interface{}
+}
+
+func (AdjacencyMatrixAggParams) Kind() AggKind {
+	return AggKindAdjacencyMatrix
+}
+
+func (p AdjacencyMatrixAggParams) Clause() (AggClause, error) {
+	return p.AdjacencyMatrix()
+}
+
+func (p AdjacencyMatrixAggParams) AdjacencyMatrix() (*AdjacencyMatrixAgg, error) {
+	a := &AdjacencyMatrixAgg{}
+	err := a.SetFilters(p.Filters)
+	if err != nil {
+		return a, newAggError(err, AggKindAdjacencyMatrix)
+	}
+	a.SetSeparator(p.Separator)
+	err = a.SetAggregations(p.Aggregations)
+	if err != nil {
+		return a, newAggError(err, AggKindAdjacencyMatrix)
+	}
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// AdjacencyMatrixAgg is a bucket aggregation returning a form of adjacency
+// matrix.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-adjacency-matrix-aggregation.html
+type AdjacencyMatrixAgg struct {
+	filters   map[string]*Query
+	separator string
+	aggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*AdjacencyMatrixAgg)(nil)
+
+func (AdjacencyMatrixAgg) Kind() AggKind {
+	return AggKindAdjacencyMatrix
+}
+
+func (a *AdjacencyMatrixAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// Filters are the named filters of the matrix
+func (a AdjacencyMatrixAgg) Filters() map[string]*Query {
+	return a.filters
+}
+
+// SetFilters sets the named filters of the matrix to v
+func (a *AdjacencyMatrixAgg) SetFilters(v map[string]Querier) error {
+	if len(v) == 0 {
+		return ErrFiltersRequired
+	}
+	filters := make(map[string]*Query, len(v))
+	for k, f := range v {
+		if f == nil {
+			return newFieldError(ErrQueryRequired, k)
+		}
+		q, err := f.Query()
+		if err != nil {
+			return newFieldError(err, k)
+		}
+		filters[k] = q
+	}
+	a.filters = filters
+	return nil
+}
+
+// Separator is used to concatenate filter names. Defaults to "&".
+func (a AdjacencyMatrixAgg) Separator() string {
+	return a.separator
+}
+
+// SetSeparator sets separator to v
+func (a *AdjacencyMatrixAgg) SetSeparator(v string) {
+	a.separator = v
+}
+
+func (a AdjacencyMatrixAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a AdjacencyMatrixAgg) MarshalJSON() ([]byte, error) {
+	return adjacencyMatrixAgg{
+		Filters:   a.filters,
+		Separator: a.separator,
+	}.MarshalJSON()
+}
+
+func (a *AdjacencyMatrixAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *AdjacencyMatrixAgg) UnmarshalJSON(data []byte) error {
+	*a = AdjacencyMatrixAgg{}
+	p := adjacencyMatrixAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.filters = p.Filters
+	a.SetSeparator(p.Separator)
+	return nil
+}
+
+//easyjson:json
+type adjacencyMatrixAgg struct {
+	Filters   map[string]*Query `json:"filters"`
+	Separator string            `json:"separator,omitempty"`
+}
@@ -2,6 +2,15 @@ package picker
 
 type AggKind string
 
+func (k AggKind) String() string {
+	return string(k)
+}
+
+func (k AggKind) IsValid() bool {
+	_, ok := aggKindHandlers[k]
+	return ok
+}
+
 const (
 	AggKindAdjacencyMatrix           AggKind = "adjacency_matrix"
 	AggKindAutoIntervalDateHistogram AggKind = "auto_date_histogram"
@@ -73,3 +82,70 @@ const (
 	AggKindStatsBucket               AggKind = "stats_bucket"
 	AggKindSumBucket                 AggKind = "sum_bucket"
 )
+
+var aggKindHandlers = map[AggKind]func() AggClause{
+	AggKindAdjacencyMatrix:           func() AggClause { return &AdjacencyMatrixAgg{} },
+	AggKindAutoIntervalDateHistogram: func() AggClause { return &AutoDateHistogramAgg{} },
+	AggKindChildren:                  func() AggClause { return &ChildrenAgg{} },
+	AggKindComposite:                 func() AggClause { return &CompositeAgg{} },
+	AggKindDateHistogram:             func() AggClause { return &DateHistogramAgg{} },
+	AggKindDateRange:                 func() AggClause { return &DateRangeAgg{} },
+	AggKindDiversifiedSampler:        func() AggClause { return &DiversifiedSamplerAgg{} },
+	AggKindFilter:                    func() AggClause { return &FilterAgg{} },
+	AggKindFilters:                   func() AggClause { return &FiltersAgg{} },
+	AggKindGeoDistance:               func() AggClause { return &GeoDistanceAgg{} },
+	AggKindGeohashGrid:               func() AggClause { return &GeohashGridAgg{} },
+	AggKindGeotileGrid:               func() AggClause { return &GeotileGridAgg{} },
+	AggKindGlobal:                    func() AggClause { return &GlobalAgg{} },
+	AggKindHistogram:                 func() AggClause { return &HistogramAgg{} },
+	AggKindIPPange:                   func() AggClause { return &IPRangeAgg{} },
+	AggKindMissing:                   func() AggClause { return &MissingAgg{} },
+	AggKindMultiTerms:                func() AggClause { return &MultiTermsAgg{} },
+	AggKindNested:                    func() AggClause { return &NestedAgg{} },
+	AggKindParent:                    func() AggClause { return &ParentAgg{} },
+	AggKindRange:                     func() AggClause { return &RangeAgg{} },
+	AggKindRareTerms:                 func() AggClause { return &RareTermsAgg{} },
+	AggKindReverseNested:             func() AggClause { return &ReverseNestedAgg{} },
+	AggKindSampler:                   func() AggClause { return &SamplerAgg{} },
+	AggKindSignificantTerms:          func() AggClause { return &SignificantTermsAgg{} },
+	AggKindSignificantText:           func() AggClause { return &SignificantTextAgg{} },
+	AggKindTerms:                     func() AggClause { return &TermsAgg{} },
+	AggKindVariableWidthHistogram:    func() AggClause { return &VariableWidthHistogramAgg{} },
+	AggKindAvg:                       func() AggClause { return &AvgAgg{} },
+	AggKindBoxplot:                   func() AggClause { return &BoxplotAgg{} },
+	AggKindCardinality:               func() AggClause { return &CardinalityAgg{} },
+	AggKindExtendedStats:             func() AggClause { return &ExtendedStatsAgg{} },
+	AggKindMax:                       func() AggClause { return &MaxAgg{} },
+	AggKindMedianAbsoluteDeviation:   func() AggClause { return &MedianAbsoluteDeviationAgg{} },
+	AggKindMin:                       func() AggClause { return &MinAgg{} },
+	AggKindPercentileRanks:           func() AggClause { return &PercentileRanksAgg{} },
+	AggKindPercentiles:               func() AggClause { return &PercentilesAgg{} },
+	AggKindRate:                      func() AggClause { return &RateAgg{} },
+	AggKindStats:                     func() AggClause { return &StatsAgg{} },
+	AggKindStringStats:               func() AggClause { return &StringStatsAgg{} },
+	AggKindSum:                       func() AggClause { return &SumAgg{} },
+	AggKindTTest:                     func() AggClause { return &TTestAgg{} },
+	AggKindTopHits:                   func() AggClause { return &TopHitsAgg{} },
+	AggKindTopMetrics:                func() AggClause { return &TopMetricsAgg{} },
+	AggKindValueCount:                func() AggClause { return &ValueCountAgg{} },
+	AggKindWeightedAvg:               func() AggClause { return &WeightedAvgAgg{} },
+	AggKindAverageBucket:             func() AggClause { return &AvgBucketAgg{} },
+	AggKindBucketScript:              func() AggClause { return &BucketScriptAgg{} },
+	AggKindBucketSelector:            func() AggClause { return &BucketSelectorAgg{} },
+	AggKindBucketSort:                func() AggClause { return &BucketSortAgg{} },
+	AggKindCumulativeCardinality:     func() AggClause { return &CumulativeCardinalityAgg{} },
+	AggKindCumulativeSum:             func() AggClause { return &CumulativeSumAgg{} },
+	AggKindDerivative:                func() AggClause { return &DerivativeAgg{} },
+	AggKindExtendedStatsBucket:       func() AggClause { return &ExtendedStatsBucketAgg{} },
+	AggKindInferenceBucket:           func() AggClause { return &InferenceAgg{} },
+	AggKindMaxBucket:                 func() AggClause { return &MaxBucketAgg{} },
+	AggKindMinBucket:                 func() AggClause { return &MinBucketAgg{} },
+	AggKindMovingAverage:             func() AggClause { return &MovingAvgAgg{} },
+	AggKindMovingFunction:            func() AggClause { return &MovingFnAgg{} },
+	AggKindMovingPercentiles:         func() AggClause { return &MovingPercentilesAgg{} },
+	AggKindNormalize:                 func() AggClause { return &NormalizeAgg{} },
+	AggKindPercentilesBucket:         func() AggClause { return &PercentilesBucketAgg{} },
+	AggKindSerialDifferencing:        func() AggClause { return &SerialDiffAgg{} },
+	AggKindStatsBucket:               func() AggClause { return &StatsBucketAgg{} },
+	AggKindSumBucket:                 func() AggClause { return &SumBucketAgg{} },
+}
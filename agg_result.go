@@ -0,0 +1,152 @@
+package picker
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/chanced/dynamic"
+)
+
+// AggResult is the result of an aggregation, decoded from the aggregations of
+// a search response.
+//
+// The concrete type of an AggResult is determined by the Kind of the
+// aggregation it was requested with:
+//
+//  *MultiBucketAggResult   terms, histogram, range, filters, composite, etc.
+//  *SingleBucketAggResult  filter, global, nested, missing, etc.
+//  *ValueAggResult         avg, sum, min, max, cardinality, derivative, etc.
+//  *StatsAggResult         stats, stats_bucket
+//  *ExtendedStatsAggResult extended_stats, extended_stats_bucket
+//  *PercentilesAggResult   percentiles, percentile_ranks, percentiles_bucket
+//  *TopHitsAggResult       top_hits
+//  *RawAggResult           all others
+type AggResult interface {
+	Kind() AggKind
+	decodeAggResult(agg AggClause, data dynamic.JSON) error
+}
+
+// AggResults is a map of aggregation names to their results
+type AggResults map[string]AggResult
+
+// Len returns the number of results in r
+func (r AggResults) Len() int {
+	return len(r)
+}
+
+// Has returns true if r has a result with the given name
+func (r AggResults) Has(name string) bool {
+	_, ok := r[name]
+	return ok
+}
+
+// Get returns the result with the given name or nil
+func (r AggResults) Get(name string) AggResult {
+	return r[name]
+}
+
+// DecodeResults decodes data, the aggregations section of a search response,
+// into AggResults. Each result is matched to the aggregation of a with the same
+// name, which determines the type of the result. As such, typed_keys is not
+// required, though it is supported.
+//
+// Results which do not correspond to an aggregation of a are ignored.
+func (a Aggregations) DecodeResults(data []byte) (AggResults, error) {
+	return decodeAggResults(a, data)
+}
+
+func decodeAggResults(aggs Aggregations, data dynamic.JSON) (AggResults, error) {
+	res := AggResults{}
+	if len(data) == 0 || data.IsNull() || len(aggs) == 0 {
+		return res, nil
+	}
+	var obj dynamic.JSONObject
+	err := json.Unmarshal(data, &obj)
+	if err != nil {
+		return res, err
+	}
+	return decodeAggResultsObject(aggs, obj)
+}
+
+// decodeAggResultsObject decodes the results of aggs from the keys of obj
+func decodeAggResultsObject(aggs Aggregations, obj dynamic.JSONObject) (AggResults, error) {
+	res := AggResults{}
+	var err error
+	for key, d := range obj {
+		name := key
+		agg, ok := aggs[name]
+		if !ok {
+			// typed_keys prefixes names with the type, e.g. "sterms#genres"
+			if i := strings.IndexByte(key, '#'); i >= 0 {
+				name = key[i+1:]
+				agg, ok = aggs[name]
+			}
+		}
+		if !ok || agg == nil {
+			continue
+		}
+		r := newAggResult(agg.Kind())
+		err = r.decodeAggResult(agg, d)
+		if err != nil {
+			return res, newAggError(err, agg.Kind(), name)
+		}
+		res[name] = r
+	}
+	return res, nil
+}
+
+func newAggResult(kind AggKind) AggResult {
+	switch kind {
+	case AggKindAdjacencyMatrix, AggKindAutoIntervalDateHistogram,
+		AggKindComposite, AggKindDateHistogram, AggKindDateRange, AggKindFilters,
+		AggKindGeoDistance, AggKindGeohashGrid, AggKindGeotileGrid,
+		AggKindHistogram, AggKindIPPange, AggKindMultiTerms, AggKindRange,
+		AggKindRareTerms, AggKindSignificantTerms, AggKindSignificantText,
+		AggKindTerms, AggKindVariableWidthHistogram:
+		return &MultiBucketAggResult{kind: kind}
+	case AggKindChildren, AggKindDiversifiedSampler, AggKindFilter,
+		AggKindGlobal, AggKindMissing, AggKindNested, AggKindParent,
+		AggKindReverseNested, AggKindSampler:
+		return &SingleBucketAggResult{kind: kind}
+	case AggKindAvg, AggKindCardinality, AggKindMax,
+		AggKindMedianAbsoluteDeviation, AggKindMin, AggKindRate, AggKindSum,
+		AggKindTTest, AggKindValueCount, AggKindWeightedAvg,
+		AggKindAverageBucket, AggKindBucketScript,
+		AggKindCumulativeCardinality, AggKindCumulativeSum,
+		AggKindDerivative, AggKindMaxBucket, AggKindMinBucket,
+		AggKindMovingAverage, AggKindMovingFunction, AggKindNormalize,
+		AggKindSerialDifferencing, AggKindSumBucket:
+		return &ValueAggResult{kind: kind}
+	case AggKindStats, AggKindStatsBucket:
+		return &StatsAggResult{kind: kind}
+	case AggKindExtendedStats, AggKindExtendedStatsBucket:
+		return &ExtendedStatsAggResult{kind: kind}
+	case AggKindPercentiles, AggKindPercentileRanks,
+		AggKindPercentilesBucket, AggKindMovingPercentiles:
+		return &PercentilesAggResult{kind: kind}
+	case AggKindTopHits:
+		return &TopHitsAggResult{}
+	}
+	return &RawAggResult{kind: kind}
+}
+
+// RawAggResult is the result of an aggregation which does not have a typed
+// result. Data contains the result as-is.
+type RawAggResult struct {
+	Data dynamic.JSON
+	kind AggKind
+}
+
+func (r RawAggResult) Kind() AggKind {
+	return r.kind
+}
+
+// Unmarshal decodes the result into v
+func (r RawAggResult) Unmarshal(v interface{}) error {
+	return json.Unmarshal(r.Data, v)
+}
+
+func (r *RawAggResult) decodeAggResult(agg AggClause, data dynamic.JSON) error {
+	r.Data = data
+	return nil
+}
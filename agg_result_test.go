@@ -0,0 +1,220 @@
+package picker_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/chanced/picker"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggResults(t *testing.T) {
+	assert := require.New(t)
+	s, err := picker.NewSearch(picker.SearchParams{
+		Aggregations: picker.Aggs{
+			"genres": picker.TermsAggParams{
+				Field: "genre",
+				Aggregations: picker.Aggs{
+					"load_time": picker.PercentilesAggParams{Field: "load_time"},
+					"price":     picker.StatsAggParams{Field: "price"},
+					"top":       picker.TopHitsAggParams{Size: 1},
+				},
+			},
+			"price_ranges": picker.RangeAggParams{
+				Field:  "price",
+				Keyed:  true,
+				Ranges: []picker.AggRange{{To: 100}, {From: 100}},
+			},
+			"published": picker.FilterAggParams{
+				Query: &picker.QueryParams{Term: picker.TermQueryParams{Field: "status", Value: "published"}},
+				Aggregations: picker.Aggs{
+					"avg_price": picker.AvgAggParams{Field: "price"},
+				},
+			},
+			"ranks": picker.PercentileRanksAggParams{Field: "load_time", Values: []float64{500, 600}, Keyed: false},
+			"tags":  picker.StringStatsAggParams{Field: "tags"},
+		},
+	})
+	assert.NoError(err)
+
+	data := []byte(`{
+		"genres": {
+			"doc_count_error_upper_bound": 0,
+			"sum_other_doc_count": 10,
+			"buckets": [
+				{
+					"key": "rock",
+					"doc_count": 3,
+					"load_time": { "values": { "1.0": 5.0, "99.0": 15.0, "99.0_as_string": "15ms" } },
+					"price": { "count": 3, "min": 10, "max": 30, "avg": 20, "sum": 60 },
+					"top": {
+						"hits": {
+							"total": { "value": 3, "relation": "eq" },
+							"max_score": 1.5,
+							"hits": [{ "_index": "music", "_id": "1", "_score": 1.5, "_source": { "title": "song" } }]
+						}
+					}
+				}
+			]
+		},
+		"sterms#not_requested": { "buckets": [] },
+		"range#price_ranges": {
+			"buckets": {
+				"*-100.0": { "to": 100.0, "doc_count": 2 },
+				"100.0-*": { "from": 100.0, "doc_count": 4 }
+			}
+		},
+		"published": { "doc_count": 7, "avg_price": { "value": null } },
+		"ranks": { "values": [{ "key": 500.0, "value": 55.0 }, { "key": 600.0, "value": 64.0 }] },
+		"tags": { "count": 5, "min_length": 3 }
+	}`)
+	res, err := s.DecodeAggregations(data)
+	assert.NoError(err)
+	assert.Equal(5, res.Len())
+	assert.False(res.Has("not_requested"))
+
+	genres, ok := res.Get("genres").(*picker.MultiBucketAggResult)
+	assert.True(ok)
+	assert.Equal(picker.AggKindTerms, genres.Kind())
+	assert.Equal(int64(10), genres.SumOtherDocCount)
+	rock := genres.Bucket("rock")
+	assert.NotNil(rock)
+	assert.Equal(int64(3), rock.DocCount)
+
+	lt, ok := rock.Aggregations.Get("load_time").(*picker.PercentilesAggResult)
+	assert.True(ok)
+	assert.Len(lt.Values, 2)
+	v, ok := lt.Value(99)
+	assert.True(ok)
+	assert.Equal(15.0, v)
+	assert.Equal("15ms", lt.Values[1].ValueAsString)
+
+	price, ok := rock.Aggregations.Get("price").(*picker.StatsAggResult)
+	assert.True(ok)
+	assert.Equal(int64(3), price.Count)
+	assert.Equal(20.0, *price.Avg)
+
+	top, ok := rock.Aggregations.Get("top").(*picker.TopHitsAggResult)
+	assert.True(ok)
+	assert.Equal(int64(3), top.Hits.Total.Value)
+	assert.Equal(picker.HitsTotalRelationEq, top.Hits.Total.Relation)
+	assert.Equal("1", top.Hits.Hits[0].ID)
+	assert.JSONEq(`{ "title": "song" }`, string(top.Hits.Hits[0].Source))
+
+	ranges, ok := res.Get("price_ranges").(*picker.MultiBucketAggResult)
+	assert.True(ok)
+	assert.True(ranges.Keyed)
+	assert.Equal("*-100.0", ranges.Buckets[0].Key)
+	assert.Equal(int64(4), ranges.Bucket("100.0-*").DocCount)
+
+	published, ok := res.Get("published").(*picker.SingleBucketAggResult)
+	assert.True(ok)
+	assert.Equal(int64(7), published.DocCount)
+	avg, ok := published.Aggregations.Get("avg_price").(*picker.ValueAggResult)
+	assert.True(ok)
+	assert.Nil(avg.Value)
+
+	ranks, ok := res.Get("ranks").(*picker.PercentilesAggResult)
+	assert.True(ok)
+	v, ok = ranks.Value(600)
+	assert.True(ok)
+	assert.Equal(64.0, v)
+
+	tags, ok := res.Get("tags").(*picker.RawAggResult)
+	assert.True(ok)
+	assert.Equal(picker.AggKindStringStats, tags.Kind())
+	var ss struct {
+		Count int `json:"count"`
+	}
+	assert.NoError(tags.Unmarshal(&ss))
+	assert.Equal(5, ss.Count)
+
+	_, err = s.DecodeAggregations([]byte(`{"genres": {"buckets": [{"key": "rock", "price": {"count": "x"}}]}}`))
+	var aggErr *picker.AggError
+	assert.True(errors.As(err, &aggErr))
+	assert.Equal("genres>price", aggErr.Path)
+}
+
+func TestAggResultsBucketKeys(t *testing.T) {
+	assert := require.New(t)
+	s, err := picker.NewSearch(picker.SearchParams{
+		Aggregations: picker.Aggs{
+			"genres": picker.TermsAggParams{
+				Field: "genre",
+				Aggregations: picker.Aggs{
+					"key":       picker.AvgAggParams{Field: "price"},
+					"doc_count": picker.SumAggParams{Field: "sales"},
+				},
+			},
+			"published": picker.FilterAggParams{
+				Query: &picker.QueryParams{Term: picker.TermQueryParams{Field: "status", Value: "published"}},
+				Aggregations: picker.Aggs{
+					"doc_count": picker.SumAggParams{Field: "sales"},
+				},
+			},
+		},
+	})
+	assert.NoError(err)
+
+	data := []byte(`{
+		"genres": {
+			"buckets": [
+				{ "key": "rock", "doc_count": 3 }
+			]
+		},
+		"published": { "doc_count": 7 }
+	}`)
+	res, err := s.DecodeAggregations(data)
+	assert.NoError(err)
+
+	genres, ok := res.Get("genres").(*picker.MultiBucketAggResult)
+	assert.True(ok)
+	rock := genres.Bucket("rock")
+	assert.NotNil(rock)
+	assert.Equal(int64(3), rock.DocCount)
+	assert.False(rock.Aggregations.Has("key"))
+	assert.False(rock.Aggregations.Has("doc_count"))
+
+	published, ok := res.Get("published").(*picker.SingleBucketAggResult)
+	assert.True(ok)
+	assert.Equal(int64(7), published.DocCount)
+	assert.False(published.Aggregations.Has("doc_count"))
+}
+
+func TestAggResultsLongBucketKeys(t *testing.T) {
+	assert := require.New(t)
+	s, err := picker.NewSearch(picker.SearchParams{
+		Aggregations: picker.Aggs{
+			"ids": picker.TermsAggParams{Field: "account_id"},
+			"by_account": picker.CompositeAggParams{
+				Sources: []picker.CompositeSource{
+					{Name: "account", Terms: &picker.CompositeTermsSource{Field: "account_id"}},
+				},
+			},
+		},
+	})
+	assert.NoError(err)
+
+	data := []byte(`{
+		"ids": {
+			"buckets": [
+				{ "key": 12345678901234567, "doc_count": 2 }
+			]
+		},
+		"by_account": {
+			"buckets": [
+				{ "key": { "account": 12345678901234567 }, "doc_count": 2 }
+			]
+		}
+	}`)
+	res, err := s.DecodeAggregations(data)
+	assert.NoError(err)
+
+	ids := res.Get("ids").(*picker.MultiBucketAggResult)
+	assert.Equal(json.Number("12345678901234567"), ids.Buckets[0].Key)
+	assert.NotNil(ids.Bucket("12345678901234567"))
+
+	accounts := res.Get("by_account").(*picker.MultiBucketAggResult)
+	assert.Equal(map[string]interface{}{"account": json.Number("12345678901234567")}, accounts.Buckets[0].Key)
+}
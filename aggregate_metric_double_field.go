@@ -0,0 +1,163 @@
+package picker
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type AggregateMetricDoubleFieldParams struct {
+	// Metrics is an array of metric sub-fields to store. Each value
+	// corresponds to a metric aggregation. Valid values are min, max, sum, and
+	// value_count. You must specify at least one value. (Required)
+	Metrics []string `json:"metrics,omitempty"`
+	// DefaultMetric is the default metric sub-field to use for queries,
+	// scripts, and aggregations that don't use a sub-field. Must be a value
+	// of Metrics. Required if Metrics has more than one value.
+	DefaultMetric string `json:"default_metric,omitempty"`
+	// Metadata about the field.
+	Meta Meta `json:"meta,omitempty"`
+}
+
+func (AggregateMetricDoubleFieldParams) Type() FieldType {
+	return FieldTypeAggregateMetricDouble
+}
+
+func (p AggregateMetricDoubleFieldParams) Field() (Field, error) {
+	return p.AggregateMetricDouble()
+}
+
+func (p AggregateMetricDoubleFieldParams) AggregateMetricDouble() (*AggregateMetricDoubleField, error) {
+	f := &AggregateMetricDoubleField{}
+	e := &MappingError{}
+	err := f.SetMetrics(p.Metrics)
+	if err != nil {
+		e.Append(err)
+	}
+	err = f.SetDefaultMetric(p.DefaultMetric)
+	if err != nil {
+		e.Append(err)
+	}
+	err = f.SetMeta(p.Meta)
+	if err != nil {
+		e.Append(err)
+	}
+	return f, e.ErrorOrNil()
+}
+
+func NewAggregateMetricDoubleField(params AggregateMetricDoubleFieldParams) (*AggregateMetricDoubleField, error) {
+	return params.AggregateMetricDouble()
+}
+
+// An AggregateMetricDoubleField stores pre-aggregated numeric values for
+// metric aggregations. An aggregate_metric_double field is an object
+// containing one or more of the following metric sub-fields: min, max, sum,
+// and value_count.
+//
+// When you run certain metric aggregations on an aggregate_metric_double
+// field, the aggregation uses the related sub-field’s values. For example, a
+// min aggregation on an aggregate_metric_double field returns the minimum
+// value of all min sub-fields.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/aggregate-metric-double.html
+type AggregateMetricDoubleField struct {
+	metrics       []string
+	defaultMetric string
+	metaParam
+}
+
+func (a *AggregateMetricDoubleField) Field() (Field, error) {
+	return a, nil
+}
+
+func (AggregateMetricDoubleField) Type() FieldType {
+	return FieldTypeAggregateMetricDouble
+}
+
+// Metrics are the metric sub-fields stored by the field. Each value
+// corresponds to a metric aggregation: min, max, sum, or value_count.
+func (a AggregateMetricDoubleField) Metrics() []string {
+	return a.metrics
+}
+
+// SetMetrics sets the metric sub-fields of the field to v. At least one
+// metric is required.
+func (a *AggregateMetricDoubleField) SetMetrics(v []string) error {
+	if len(v) == 0 {
+		return ErrMetricsRequired
+	}
+	for _, m := range v {
+		switch m {
+		case "min", "max", "sum", "value_count":
+		default:
+			return fmt.Errorf("%w <%s>; expected one of min, max, sum, or value_count", ErrInvalidMetric, m)
+		}
+	}
+	a.metrics = v
+	return nil
+}
+
+// DefaultMetric is the default metric sub-field to use for queries, scripts,
+// and aggregations that don't use a sub-field. If not set and the field has
+// a single metric, that metric is returned.
+func (a AggregateMetricDoubleField) DefaultMetric() string {
+	if len(a.defaultMetric) == 0 && len(a.metrics) == 1 {
+		return a.metrics[0]
+	}
+	return a.defaultMetric
+}
+
+// SetDefaultMetric sets the default metric to v, which must be one of the
+// metrics of the field. It is required if the field has more than one
+// metric.
+func (a *AggregateMetricDoubleField) SetDefaultMetric(v string) error {
+	if len(v) == 0 {
+		if len(a.metrics) > 1 {
+			return ErrDefaultMetricRequired
+		}
+		a.defaultMetric = ""
+		return nil
+	}
+	for _, m := range a.metrics {
+		if m == v {
+			a.defaultMetric = v
+			return nil
+		}
+	}
+	return fmt.Errorf("%w <%s>; default_metric must be one of the metrics", ErrInvalidMetric, v)
+}
+
+func (a AggregateMetricDoubleField) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a AggregateMetricDoubleField) MarshalJSON() ([]byte, error) {
+	return json.Marshal(aggregateMetricDoubleField{
+		Metrics:       a.metrics,
+		DefaultMetric: a.defaultMetric,
+		Meta:          a.meta,
+		Type:          a.Type(),
+	})
+}
+
+func (a *AggregateMetricDoubleField) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *AggregateMetricDoubleField) UnmarshalJSON(data []byte) error {
+	var p AggregateMetricDoubleFieldParams
+	err := json.Unmarshal(data, &p)
+	if err != nil {
+		return err
+	}
+	n, err := p.AggregateMetricDouble()
+	*a = *n
+	return err
+}
+
+//easyjson:json
+type aggregateMetricDoubleField struct {
+	Metrics       []string  `json:"metrics"`
+	DefaultMetric string    `json:"default_metric,omitempty"`
+	Meta          Meta      `json:"meta,omitempty"`
+	Type          FieldType `json:"type"`
+}
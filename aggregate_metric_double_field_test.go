@@ -1,6 +1,7 @@
 package picker_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/chanced/cmpjson"
@@ -13,15 +14,20 @@ func TestAggregateMetricDoubleField(t *testing.T) {
 	data := []byte(`{
 		"mappings": {
 		  "properties": {
-			"blob": {
-			  "type": "binary"
+			"my-agg-metric-field": {
+			  "type": "aggregate_metric_double",
+			  "metrics": ["min", "max", "sum", "value_count"],
+			  "default_metric": "max"
 			}
 		  }
 		}
 	  }`)
 	i, err := picker.NewIndex(picker.IndexParams{Mappings: picker.Mappings{
 		Properties: picker.FieldMap{
-			"blob": picker.BinaryFieldParams{},
+			"my-agg-metric-field": picker.AggregateMetricDoubleFieldParams{
+				Metrics:       []string{"min", "max", "sum", "value_count"},
+				DefaultMetric: "max",
+			},
 		},
 	}})
 	assert.NoError(err)
@@ -31,5 +37,19 @@ func TestAggregateMetricDoubleField(t *testing.T) {
 	i2 := picker.Index{}
 	err = i2.UnmarshalJSON(data)
 	assert.NoError(err)
+	f := i2.Mappings.Properties["my-agg-metric-field"].(*picker.AggregateMetricDoubleField)
+	assert.Equal("max", f.DefaultMetric())
 
+	f, err = picker.AggregateMetricDoubleFieldParams{Metrics: []string{"sum"}}.AggregateMetricDouble()
+	assert.NoError(err)
+	assert.Equal("sum", f.DefaultMetric())
+
+	_, err = picker.AggregateMetricDoubleFieldParams{}.AggregateMetricDouble()
+	assert.True(errors.Is(err, picker.ErrMetricsRequired))
+	_, err = picker.AggregateMetricDoubleFieldParams{Metrics: []string{"avg"}}.AggregateMetricDouble()
+	assert.True(errors.Is(err, picker.ErrInvalidMetric))
+	_, err = picker.AggregateMetricDoubleFieldParams{Metrics: []string{"min", "max"}}.AggregateMetricDouble()
+	assert.True(errors.Is(err, picker.ErrDefaultMetricRequired))
+	_, err = picker.AggregateMetricDoubleFieldParams{Metrics: []string{"min", "max"}, DefaultMetric: "sum"}.AggregateMetricDouble()
+	assert.True(errors.Is(err, picker.ErrInvalidMetric))
 }
@@ -0,0 +1,321 @@
+package picker
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/chanced/dynamic"
+)
+
+// AggClauser is implemented by aggregation params as well as aggregations
+// themselves. Calling Clause returns the validated AggClause.
+type AggClauser interface {
+	Kind() AggKind
+	Clause() (AggClause, error)
+}
+
+// AggClause is a validated aggregation.
+//
+// An aggregation summarizes your data as metrics, statistics, or other
+// analytics.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations.html
+type AggClause interface {
+	AggClauser
+	// Aggregations are the sub-aggregations of the aggregation
+	Aggregations() Aggregations
+	SetAggregations(v Aggregationset) error
+	// Meta is a set of arbitrary key/values attached to the aggregation
+	// which are returned, as-is, in the response.
+	Meta() map[string]interface{}
+	SetMeta(v map[string]interface{})
+	json.Marshaler
+	json.Unmarshaler
+}
+
+// Aggregationset is implemented by Aggs and Aggregations
+type Aggregationset interface {
+	Aggregations() (Aggregations, error)
+}
+
+// Aggs is a map of aggregation names to AggClausers. It is used in params to
+// define aggregations.
+//
+//  picker.Aggs{
+//      "genres": picker.TermsAggParams{Field: "genre"},
+//  }
+//
+// Aggregation names can contain any character except '[', ']', and '>'.
+type Aggs map[string]AggClauser
+
+// Aggregations returns the validated Aggregations of a
+func (a Aggs) Aggregations() (Aggregations, error) {
+	res := make(Aggregations, len(a))
+	for name, p := range a {
+		if p == nil {
+			continue
+		}
+		err := checkAggName(name)
+		if err != nil {
+			return res, newAggError(err, p.Kind(), name)
+		}
+		c, err := p.Clause()
+		if err != nil {
+			return res, newAggError(err, p.Kind(), name)
+		}
+		res[name] = c
+	}
+	return res, nil
+}
+
+// Aggregations is a map of aggregation names to AggClause.
+type Aggregations map[string]AggClause
+
+// Aggregations returns a, satisfying Aggregationset
+func (a Aggregations) Aggregations() (Aggregations, error) {
+	return a, nil
+}
+
+// Len returns the number of aggregations in a
+func (a Aggregations) Len() int {
+	return len(a)
+}
+
+// Has returns true if a has an aggregation with the given name
+func (a Aggregations) Has(name string) bool {
+	_, ok := a[name]
+	return ok
+}
+
+// Get returns the aggregation with the given name or nil
+func (a Aggregations) Get(name string) AggClause {
+	return a[name]
+}
+
+// Set validates agg and assigns it to name
+func (a Aggregations) Set(name string, agg AggClauser) (AggClause, error) {
+	if agg == nil {
+		delete(a, name)
+		return nil, nil
+	}
+	err := checkAggName(name)
+	if err != nil {
+		return nil, newAggError(err, agg.Kind(), name)
+	}
+	c, err := agg.Clause()
+	if err != nil {
+		return c, newAggError(err, agg.Kind(), name)
+	}
+	a[name] = c
+	return c, nil
+}
+
+// Remove deletes and returns the aggregation with the given name
+func (a Aggregations) Remove(name string) AggClause {
+	v := a[name]
+	delete(a, name)
+	return v
+}
+
+func (a Aggregations) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a Aggregations) MarshalJSON() ([]byte, error) {
+	data := make(dynamic.JSONObject, len(a))
+	for name, agg := range a {
+		if agg == nil {
+			continue
+		}
+		d, err := marshalAggregation(agg)
+		if err != nil {
+			return nil, newAggError(err, agg.Kind(), name)
+		}
+		data[name] = d
+	}
+	return json.Marshal(data)
+}
+
+func (a *Aggregations) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *Aggregations) UnmarshalJSON(data []byte) error {
+	*a = Aggregations{}
+	if len(data) == 0 || dynamic.JSON(data).IsNull() {
+		return nil
+	}
+	var obj dynamic.JSONObject
+	err := json.Unmarshal(data, &obj)
+	if err != nil {
+		return err
+	}
+	for name, d := range obj {
+		agg, err := unmarshalAggregation(d)
+		if err != nil {
+			var kind AggKind
+			if agg != nil {
+				kind = agg.Kind()
+			}
+			return newAggError(err, kind, name)
+		}
+		(*a)[name] = agg
+	}
+	return nil
+}
+
+func marshalAggregation(agg AggClause) (dynamic.JSON, error) {
+	body, err := agg.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	obj := dynamic.JSONObject{agg.Kind().String(): body}
+	if aggs := agg.Aggregations(); len(aggs) > 0 {
+		d, err := aggs.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		obj["aggs"] = d
+	}
+	if meta := agg.Meta(); len(meta) > 0 {
+		d, err := json.Marshal(meta)
+		if err != nil {
+			return nil, err
+		}
+		obj["meta"] = d
+	}
+	return json.Marshal(obj)
+}
+
+func unmarshalAggregation(data dynamic.JSON) (AggClause, error) {
+	var obj dynamic.JSONObject
+	err := json.Unmarshal(data, &obj)
+	if err != nil {
+		return nil, err
+	}
+	var agg AggClause
+	var aggs Aggregations
+	var meta map[string]interface{}
+	for key, d := range obj {
+		switch key {
+		case "aggs", "aggregations":
+			err = aggs.UnmarshalJSON(d)
+			if err != nil {
+				return nil, err
+			}
+		case "meta":
+			err = json.Unmarshal(d, &meta)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			if agg != nil {
+				return agg, fmt.Errorf("%w; found <%s> and <%s>", ErrMultipleAggKinds, agg.Kind(), key)
+			}
+			handler, ok := aggKindHandlers[AggKind(key)]
+			if !ok {
+				return nil, fmt.Errorf("%w <%s>", ErrUnsupportedType, key)
+			}
+			agg = handler()
+			err = agg.UnmarshalJSON(d)
+			if err != nil {
+				return agg, err
+			}
+		}
+	}
+	if agg == nil {
+		return nil, ErrAggKindRequired
+	}
+	if len(aggs) > 0 {
+		err = agg.SetAggregations(aggs)
+		if err != nil {
+			return agg, err
+		}
+	}
+	agg.SetMeta(meta)
+	return agg, nil
+}
+
+func checkAggName(name string) error {
+	if len(name) == 0 {
+		return ErrAggNameRequired
+	}
+	if strings.ContainsAny(name, "[]>") {
+		return fmt.Errorf("%w <%s>", ErrInvalidAggName, name)
+	}
+	return nil
+}
+
+// aggregationsParam is a mixin for aggregations which accept
+// sub-aggregations
+type aggregationsParam struct {
+	aggregations Aggregations
+}
+
+// Aggregations are the sub-aggregations of the aggregation
+func (a aggregationsParam) Aggregations() Aggregations {
+	return a.aggregations
+}
+
+// SetAggregations sets the sub-aggregations to v
+func (a *aggregationsParam) SetAggregations(v Aggregationset) error {
+	if v == nil {
+		a.aggregations = nil
+		return nil
+	}
+	aggs, err := v.Aggregations()
+	if err != nil {
+		return err
+	}
+	if len(aggs) == 0 {
+		a.aggregations = nil
+		return nil
+	}
+	a.aggregations = aggs
+	return nil
+}
+
+// aggMetaParam is a mixin that adds the meta field to aggregations
+type aggMetaParam struct {
+	meta map[string]interface{}
+}
+
+// Meta is a set of arbitrary key/values attached to the aggregation which are
+// returned, as-is, in the response.
+func (m aggMetaParam) Meta() map[string]interface{} {
+	return m.meta
+}
+
+// SetMeta sets the meta of the aggregation to v
+func (m *aggMetaParam) SetMeta(v map[string]interface{}) {
+	if len(v) == 0 {
+		m.meta = nil
+		return
+	}
+	m.meta = v
+}
+
+// noAggregationsParam is a mixin for aggregations which do not accept
+// sub-aggregations, such as metric aggregations
+type noAggregationsParam struct{}
+
+// Aggregations always returns nil as sub-aggregations are not supported
+func (noAggregationsParam) Aggregations() Aggregations {
+	return nil
+}
+
+// SetAggregations returns ErrSubAggsNotSupported if v is not empty
+func (noAggregationsParam) SetAggregations(v Aggregationset) error {
+	if v == nil {
+		return nil
+	}
+	aggs, err := v.Aggregations()
+	if err != nil {
+		return err
+	}
+	if len(aggs) > 0 {
+		return ErrSubAggsNotSupported
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package picker_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/chanced/cmpjson"
+	"github.com/chanced/picker"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregations(t *testing.T) {
+	assert := require.New(t)
+	data := []byte(`{
+		"query": { "term": { "status": { "value": "published" } } },
+		"aggs": {
+			"by_genre": {
+				"terms": { "field": "genre", "size": 5, "order": { "_count": "asc" } },
+				"meta": { "color": "blue" },
+				"aggs": {
+					"over_time": {
+						"date_histogram": { "field": "published_at", "calendar_interval": "month" },
+						"aggs": {
+							"prices": {
+								"histogram": { "field": "price", "interval": 50 }
+							}
+						}
+					},
+					"comments": {
+						"nested": { "path": "comments" },
+						"aggs": {
+							"back": { "reverse_nested": {} }
+						}
+					}
+				}
+			},
+			"everything": {
+				"global": {},
+				"aggs": {
+					"price_ranges": {
+						"range": {
+							"field": "price",
+							"ranges": [{ "to": 100 }, { "from": 100, "to": 200 }, { "from": 200 }]
+						}
+					}
+				}
+			},
+			"messages": {
+				"filters": {
+					"filters": {
+						"errors": { "match": { "body": { "query": "error" } } },
+						"warnings": { "match": { "body": { "query": "warning" } } }
+					},
+					"other_bucket_key": "other_messages"
+				}
+			}
+		}
+	}`)
+	var s picker.Search
+	err := json.Unmarshal(data, &s)
+	assert.NoError(err)
+	aggs := s.Aggregations()
+	assert.Equal(3, aggs.Len())
+	genre, ok := aggs.Get("by_genre").(*picker.TermsAgg)
+	assert.True(ok)
+	assert.Equal("genre", genre.Field())
+	assert.Equal(5, genre.Size())
+	assert.Equal("blue", genre.Meta()["color"])
+	assert.True(genre.Aggregations().Has("over_time"))
+	nested, ok := genre.Aggregations().Get("comments").(*picker.NestedAgg)
+	assert.True(ok)
+	assert.Equal("comments", nested.Path())
+	messages, ok := aggs.Get("messages").(*picker.FiltersAgg)
+	assert.True(ok)
+	assert.True(messages.OtherBucket())
+	assert.Len(messages.Filters(), 2)
+
+	sd, err := json.Marshal(s)
+	assert.NoError(err)
+	assert.True(cmpjson.Equal(data, sd), cmpjson.Diff(data, sd))
+
+	s2, err := picker.NewSearch(picker.SearchParams{
+		Query: &picker.QueryParams{Term: picker.TermQueryParams{Field: "status", Value: "published"}},
+		Aggregations: picker.Aggs{
+			"by_genre": picker.TermsAggParams{
+				Field: "genre",
+				Size:  5,
+				Order: picker.BucketOrder{{Key: picker.BucketOrderKeyCount, Order: picker.SortOrderAscending}},
+				Meta:  map[string]interface{}{"color": "blue"},
+				Aggregations: picker.Aggs{
+					"over_time": picker.DateHistogramAggParams{
+						Field:            "published_at",
+						CalendarInterval: "month",
+						Aggregations: picker.Aggs{
+							"prices": picker.HistogramAggParams{Field: "price", Interval: 50},
+						},
+					},
+					"comments": picker.NestedAggParams{
+						Path: "comments",
+						Aggregations: picker.Aggs{
+							"back": picker.ReverseNestedAggParams{},
+						},
+					},
+				},
+			},
+			"everything": picker.GlobalAggParams{
+				Aggregations: picker.Aggs{
+					"price_ranges": picker.RangeAggParams{
+						Field: "price",
+						Ranges: []picker.AggRange{
+							{To: 100},
+							{From: 100, To: 200},
+							{From: 200},
+						},
+					},
+				},
+			},
+			"messages": picker.FiltersAggParams{
+				Filters: map[string]picker.Querier{
+					"errors":   &picker.QueryParams{Match: picker.MatchQueryParams{Field: "body", Query: "error"}},
+					"warnings": &picker.QueryParams{Match: picker.MatchQueryParams{Field: "body", Query: "warning"}},
+				},
+				OtherBucketKey: "other_messages",
+			},
+		},
+	})
+	assert.NoError(err)
+	sd2, err := json.Marshal(s2)
+	assert.NoError(err)
+	assert.True(cmpjson.Equal(data, sd2), cmpjson.Diff(data, sd2))
+}
+
+func TestAggregationErrors(t *testing.T) {
+	assert := require.New(t)
+	_, err := picker.NewSearch(picker.SearchParams{
+		Aggregations: picker.Aggs{
+			"by_genre": picker.TermsAggParams{
+				Field: "genre",
+				Aggregations: picker.Aggs{
+					"comments": picker.NestedAggParams{},
+				},
+			},
+		},
+	})
+	assert.Error(err)
+	assert.True(errors.Is(err, picker.ErrPathRequired))
+	var aggErr *picker.AggError
+	assert.True(errors.As(err, &aggErr))
+	assert.Equal("by_genre>comments", aggErr.Path)
+	assert.Equal(picker.AggKindNested, aggErr.Kind)
+
+	_, err = picker.NewSearch(picker.SearchParams{
+		Aggregations: picker.Aggs{
+			"a>b": picker.TermsAggParams{Field: "genre"},
+		},
+	})
+	assert.True(errors.Is(err, picker.ErrInvalidAggName))
+
+	var s picker.Search
+	err = json.Unmarshal([]byte(`{"aggs":{"x":{"terms":{"field":"a"},"avg":{"field":"b"}}}}`), &s)
+	assert.True(errors.Is(err, picker.ErrMultipleAggKinds))
+	err = json.Unmarshal([]byte(`{"aggs":{"x":{"aggs":{}}}}`), &s)
+	assert.True(errors.Is(err, picker.ErrAggKindRequired))
+}
@@ -0,0 +1,310 @@
+package picker
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/chanced/dynamic"
+)
+
+// Analysis is the analysis section of the settings of an index. It defines
+// the custom analyzers, normalizers, tokenizers, token filters, and character
+// filters which can be referenced by name in mappings.
+//
+//	picker.Analysis{
+//	    Analyzers: picker.Analyzers{
+//	        "my_analyzer": &picker.CustomAnalyzer{
+//	            Tokenizer: "standard",
+//	            Filter:    []string{"lowercase", "my_stop"},
+//	        },
+//	    },
+//	    Filters: picker.TokenFilters{
+//	        "my_stop": &picker.StopTokenFilter{Stopwords: picker.Stopwords{"_english_"}},
+//	    },
+//	}
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis.html
+//
+//easyjson:json
+type Analysis struct {
+	Analyzers   Analyzers    `json:"analyzer,omitempty"`
+	Normalizers Normalizers  `json:"normalizer,omitempty"`
+	Tokenizers  Tokenizers   `json:"tokenizer,omitempty"`
+	Filters     TokenFilters `json:"filter,omitempty"`
+	CharFilters CharFilters  `json:"char_filter,omitempty"`
+}
+
+// Validate checks each of the components of a which implement Validate
+func (a Analysis) Validate() error {
+	err := a.Analyzers.Validate()
+	if err != nil {
+		return newFieldError(err, "analyzer")
+	}
+	err = a.Normalizers.Validate()
+	if err != nil {
+		return newFieldError(err, "normalizer")
+	}
+	err = a.Tokenizers.Validate()
+	if err != nil {
+		return newFieldError(err, "tokenizer")
+	}
+	err = a.Filters.Validate()
+	if err != nil {
+		return newFieldError(err, "filter")
+	}
+	err = a.CharFilters.Validate()
+	if err != nil {
+		return newFieldError(err, "char_filter")
+	}
+	return nil
+}
+
+// IsEmpty returns true if a does not define any components
+func (a *Analysis) IsEmpty() bool {
+	return a == nil || len(a.Analyzers) == 0 && len(a.Normalizers) == 0 &&
+		len(a.Tokenizers) == 0 && len(a.Filters) == 0 && len(a.CharFilters) == 0
+}
+
+// HasAnalyzer returns true if name is either a built-in analyzer or a custom
+// analyzer of a. Analyzer types registered in AnalyzerTypeHandlers, such as
+// those of plugins, are considered built-in.
+func (a *Analysis) HasAnalyzer(name string) bool {
+	if a != nil {
+		if _, ok := a.Analyzers[name]; ok {
+			return true
+		}
+	}
+	if AnalyzerType(name) == AnalyzerTypeCustom {
+		return false
+	}
+	_, ok := AnalyzerTypeHandlers[AnalyzerType(name)]
+	return ok
+}
+
+// HasNormalizer returns true if name is either the built-in lowercase
+// normalizer or a custom normalizer of a
+func (a *Analysis) HasNormalizer(name string) bool {
+	if name == NormalizerLowercase {
+		return true
+	}
+	if a == nil {
+		return false
+	}
+	_, ok := a.Normalizers[name]
+	return ok
+}
+
+// validateReferences checks that each analyzer, search_analyzer,
+// search_quote_analyzer, and normalizer referenced by props, including those
+// of sub-fields, resolves to either a built-in component or a custom component
+// of a.
+func (a *Analysis) validateReferences(props Fields) error {
+	merr := &MappingError{}
+	checkAnalyzer := func(path, param, name string) {
+		if len(name) > 0 && !a.HasAnalyzer(name) {
+			err := fmt.Errorf("%w <%s>; referenced by the %s of <%s>", ErrAnalyzerNotFound, name, param, path)
+			merr.Append(newFieldError(err, path))
+		}
+	}
+	props.walk(func(path string, f Field) bool {
+		if v, ok := f.(WithAnalyzer); ok {
+			checkAnalyzer(path, "analyzer", v.Analyzer())
+		}
+		if v, ok := f.(WithSearchAnalyzer); ok {
+			checkAnalyzer(path, "search_analyzer", v.SearchAnalyzer())
+		}
+		if v, ok := f.(WithSearchQuoteAnalyzer); ok {
+			checkAnalyzer(path, "search_quote_analyzer", v.SearchQuoteAnalyzer())
+		}
+		if v, ok := f.(WithNormalizer); ok {
+			if name := v.Normalizer(); len(name) > 0 && !a.HasNormalizer(name) {
+				err := fmt.Errorf("%w <%s>; referenced by the normalizer of <%s>", ErrNormalizerNotFound, name, path)
+				merr.Append(newFieldError(err, path))
+			}
+		}
+		return true
+	})
+	return merr.ErrorOrNil()
+}
+
+// NormalizerLowercase is the only built-in normalizer
+const NormalizerLowercase = "lowercase"
+
+// Normalizers are custom normalizers, keyed by name
+type Normalizers map[string]Normalizer
+
+// Validate checks each Normalizer
+func (m Normalizers) Validate() error {
+	for name, v := range m {
+		err := v.Validate()
+		if err != nil {
+			return newFieldError(err, name)
+		}
+	}
+	return nil
+}
+
+// Normalizer is similar to an analyzer except that it may only emit a single
+// token. As a consequence, it does not have a tokenizer and only accepts a
+// subset of the available char filters and token filters.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-normalizers.html
+type Normalizer struct {
+	// Character filters to apply
+	CharFilter []string `json:"char_filter,omitempty"`
+	// Token filters to apply
+	Filter []string `json:"filter,omitempty"`
+}
+
+// Validate checks that the normalizer has at least one filter
+func (n Normalizer) Validate() error {
+	if len(n.CharFilter) == 0 && len(n.Filter) == 0 {
+		return ErrNormalizerFilterRequired
+	}
+	return nil
+}
+
+func (n Normalizer) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(AnalyzerTypeCustom), normalizer(n))
+}
+
+func (n *Normalizer) UnmarshalJSON(data []byte) error {
+	var v normalizer
+	err := unmarshalAnalysisComponent(data, &v)
+	*n = Normalizer(v)
+	return err
+}
+
+//easyjson:json
+type normalizer Normalizer
+
+// Stopwords are either a pre-defined stop words list, such as "_english_" or
+// "_none_", or a list of stop words.
+type Stopwords []string
+
+func (s Stopwords) MarshalBSON() ([]byte, error) {
+	return s.MarshalJSON()
+}
+
+// MarshalJSON encodes s as a string if it consists of a single pre-defined
+// list, such as "_english_", otherwise as an array
+func (s Stopwords) MarshalJSON() ([]byte, error) {
+	if len(s) == 1 && strings.HasPrefix(s[0], "_") && strings.HasSuffix(s[0], "_") {
+		return json.Marshal(s[0])
+	}
+	return json.Marshal([]string(s))
+}
+
+func (s *Stopwords) UnmarshalBSON(data []byte) error {
+	return s.UnmarshalJSON(data)
+}
+
+// UnmarshalJSON accepts either a string or an array of strings
+func (s *Stopwords) UnmarshalJSON(data []byte) error {
+	*s = nil
+	d := dynamic.JSON(data)
+	if len(d) == 0 || d.IsNull() {
+		return nil
+	}
+	if d.IsString() {
+		var v string
+		err := json.Unmarshal(d, &v)
+		if err != nil {
+			return err
+		}
+		*s = Stopwords{v}
+		return nil
+	}
+	var v []string
+	err := json.Unmarshal(d, &v)
+	*s = v
+	return err
+}
+
+// TokenChar is a character class used by the ngram and edge_ngram tokenizers
+type TokenChar string
+
+const (
+	// TokenCharLetter is the letter class, e.g. a, b, ï or 京
+	TokenCharLetter TokenChar = "letter"
+	// TokenCharDigit is the digit class, e.g. 3 or 7
+	TokenCharDigit TokenChar = "digit"
+	// TokenCharWhitespace is the whitespace class, e.g. " " or "\n"
+	TokenCharWhitespace TokenChar = "whitespace"
+	// TokenCharPunctuation is the punctuation class, e.g. ! or "
+	TokenCharPunctuation TokenChar = "punctuation"
+	// TokenCharSymbol is the symbol class, e.g. $ or √
+	TokenCharSymbol TokenChar = "symbol"
+	// TokenCharCustom is the class of the characters set with
+	// custom_token_chars
+	TokenCharCustom TokenChar = "custom"
+)
+
+// marshalAnalysisComponent encodes v with its type
+func marshalAnalysisComponent(typ string, v json.Marshaler) ([]byte, error) {
+	data, err := v.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	obj := dynamic.JSONObject{}
+	d := dynamic.JSON(data)
+	if len(d) > 0 && !d.IsNull() {
+		err = json.Unmarshal(d, &obj)
+		if err != nil {
+			return nil, err
+		}
+	}
+	obj["type"], err = json.Marshal(typ)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(obj)
+}
+
+// unmarshalAnalysisComponent decodes data into v, which must be a pointer to
+// a struct. The get index settings API returns every setting as a string, so
+// quoted numbers and booleans are accepted for numeric and boolean fields. A
+// single string is accepted for fields which are lists of strings.
+func unmarshalAnalysisComponent(data []byte, v interface{}) error {
+	var obj dynamic.JSONObject
+	err := json.Unmarshal(data, &obj)
+	if err != nil {
+		return err
+	}
+	rt := reflect.TypeOf(v).Elem()
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		d, ok := obj[name]
+		if !ok || !d.IsString() {
+			continue
+		}
+		ft := f.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Slice && ft.Elem().Kind() == reflect.String {
+			obj[name] = dynamic.JSON("[" + string(d) + "]")
+			continue
+		}
+		s := d.UnquotedString()
+		switch ft.Kind() {
+		case reflect.Int, reflect.Int64, reflect.Float64:
+			if _, err := strconv.ParseFloat(s, 64); err == nil {
+				obj[name] = dynamic.JSON(s)
+			}
+		case reflect.Bool:
+			if b, err := strconv.ParseBool(s); err == nil {
+				obj[name] = dynamic.JSON(strconv.FormatBool(b))
+			}
+		}
+	}
+	data, err = json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
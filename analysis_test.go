@@ -0,0 +1,264 @@
+package picker_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/chanced/cmpjson"
+	"github.com/chanced/picker"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalysis(t *testing.T) {
+	assert := require.New(t)
+	data := []byte(`{
+		"analyzer": {
+			"my_custom_analyzer": {
+				"type": "custom",
+				"char_filter": ["emoticons"],
+				"tokenizer": "punctuation",
+				"filter": ["lowercase", "english_stop"],
+				"position_increment_gap": 10
+			},
+			"std_english": { "type": "standard", "stopwords": "_english_", "max_token_length": 5 },
+			"my_english": { "type": "english", "stem_exclusion": ["organization", "organizations"] },
+			"my_fingerprint": { "type": "fingerprint", "stopwords": ["a", "the"] }
+		},
+		"normalizer": {
+			"my_normalizer": { "type": "custom", "char_filter": ["quote"], "filter": ["lowercase", "asciifolding"] }
+		},
+		"tokenizer": {
+			"punctuation": { "type": "pattern", "pattern": "[ .,!?]" },
+			"autocomplete": { "type": "edge_ngram", "min_gram": 2, "max_gram": 10, "token_chars": ["letter", "digit"] },
+			"my_icu": { "type": "icu_tokenizer", "rule_files": "Latn:my.rbbi" }
+		},
+		"filter": {
+			"english_stop": { "type": "stop", "stopwords": "_english_" },
+			"my_synonyms": { "type": "synonym_graph", "synonyms": ["ny, new york"], "lenient": true },
+			"my_shingle": { "type": "shingle", "min_shingle_size": 2, "max_shingle_size": 3, "output_unigrams": false },
+			"my_delimiter": { "type": "word_delimiter_graph", "preserve_original": true, "protected_words": ["wi-fi"] }
+		},
+		"char_filter": {
+			"emoticons": { "type": "mapping", "mappings": [":) => _happy_", ":( => _sad_"] },
+			"quote": { "type": "html_strip", "escaped_tags": ["b"] }
+		}
+	}`)
+	var a picker.Analysis
+	err := json.Unmarshal(data, &a)
+	assert.NoError(err)
+	assert.NoError(a.Validate())
+
+	custom, ok := a.Analyzers["my_custom_analyzer"].(*picker.CustomAnalyzer)
+	assert.True(ok)
+	assert.Equal("punctuation", custom.Tokenizer)
+	assert.Equal(10, *custom.PositionIncrementGap)
+	std, ok := a.Analyzers["std_english"].(*picker.StandardAnalyzer)
+	assert.True(ok)
+	assert.Equal(picker.Stopwords{"_english_"}, std.Stopwords)
+	english, ok := a.Analyzers["my_english"].(*picker.LanguageAnalyzer)
+	assert.True(ok)
+	assert.Equal(picker.AnalyzerTypeEnglish, english.Type())
+	assert.Equal([]string{"a", "the"}, []string(a.Analyzers["my_fingerprint"].(*picker.FingerprintAnalyzer).Stopwords))
+
+	edge, ok := a.Tokenizers["autocomplete"].(*picker.EdgeNGramTokenizer)
+	assert.True(ok)
+	assert.Equal(10, edge.MaxGram)
+	assert.Equal([]picker.TokenChar{picker.TokenCharLetter, picker.TokenCharDigit}, edge.TokenChars)
+	icu, ok := a.Tokenizers["my_icu"].(*picker.RawTokenizer)
+	assert.True(ok)
+	assert.Equal(picker.TokenizerType("icu_tokenizer"), icu.Type())
+
+	syn, ok := a.Filters["my_synonyms"].(*picker.SynonymGraphTokenFilter)
+	assert.True(ok)
+	assert.True(*syn.Lenient)
+	assert.False(*a.Filters["my_shingle"].(*picker.ShingleTokenFilter).OutputUnigrams)
+	assert.IsType(&picker.WordDelimiterGraphTokenFilter{}, a.Filters["my_delimiter"])
+	assert.IsType(&picker.HTMLStripCharFilter{}, a.CharFilters["quote"])
+	assert.Equal([]string{"lowercase", "asciifolding"}, a.Normalizers["my_normalizer"].Filter)
+
+	ad, err := json.Marshal(a)
+	assert.NoError(err)
+	assert.True(cmpjson.Equal(data, ad), cmpjson.Diff(data, ad))
+
+	// custom analyzers can omit the type
+	var analyzers picker.Analyzers
+	err = json.Unmarshal([]byte(`{"my_analyzer": {"tokenizer": "standard", "filter": "lowercase"}}`), &analyzers)
+	assert.NoError(err)
+	assert.IsType(&picker.CustomAnalyzer{}, analyzers["my_analyzer"])
+
+	err = json.Unmarshal([]byte(`{"my_analyzer": {"filter": ["lowercase"]}}`), &analyzers)
+	assert.True(errors.Is(err, picker.ErrMissingType))
+}
+
+func TestAnalysisValidation(t *testing.T) {
+	assert := require.New(t)
+	err := picker.Analysis{Analyzers: picker.Analyzers{"a": &picker.CustomAnalyzer{}}}.Validate()
+	assert.True(errors.Is(err, picker.ErrTokenizerRequired))
+	err = picker.Analysis{Normalizers: picker.Normalizers{"n": {}}}.Validate()
+	assert.True(errors.Is(err, picker.ErrNormalizerFilterRequired))
+	err = picker.Analysis{Tokenizers: picker.Tokenizers{"t": &picker.EdgeNGramTokenizer{MinGram: 3, MaxGram: 2}}}.Validate()
+	assert.True(errors.Is(err, picker.ErrInvalidGramSize))
+	err = picker.Analysis{Filters: picker.TokenFilters{"f": &picker.SynonymTokenFilter{}}}.Validate()
+	assert.True(errors.Is(err, picker.ErrSynonymsRequired))
+	err = picker.Analysis{Filters: picker.TokenFilters{"f": &picker.ShingleTokenFilter{MinShingleSize: 4, MaxShingleSize: 3}}}.Validate()
+	assert.True(errors.Is(err, picker.ErrInvalidShingleSize))
+	err = picker.Analysis{CharFilters: picker.CharFilters{"c": &picker.RawCharFilter{}}}.Validate()
+	assert.True(errors.Is(err, picker.ErrMissingType))
+}
+
+func TestIndexSettingsAnalysis(t *testing.T) {
+	assert := require.New(t)
+	// the get index settings API returns every value as a string
+	data := []byte(`{
+		"index": {
+			"number_of_shards": "1",
+			"analysis": {
+				"analyzer": {
+					"autocomplete": { "type": "custom", "tokenizer": "autocomplete", "filter": ["lowercase"] }
+				},
+				"tokenizer": {
+					"autocomplete": { "type": "edge_ngram", "min_gram": "2", "max_gram": "10", "token_chars": ["letter"] }
+				},
+				"filter": {
+					"my_unique": { "type": "unique", "only_on_same_position": "true" }
+				}
+			}
+		}
+	}`)
+	var s picker.IndexSettings
+	err := json.Unmarshal(data, &s)
+	assert.NoError(err)
+	a, err := s.Analysis()
+	assert.NoError(err)
+	assert.NotNil(a)
+	edge := a.Tokenizers["autocomplete"].(*picker.EdgeNGramTokenizer)
+	assert.Equal(2, edge.MinGram)
+	assert.Equal(10, edge.MaxGram)
+	assert.True(*a.Filters["my_unique"].(*picker.UniqueTokenFilter).OnlyOnSamePosition)
+
+	err = json.Unmarshal([]byte(`{"index.analysis.tokenizer.bad.type": "edge_ngram", "index.analysis.tokenizer.bad.min_gram": "two"}`), &s)
+	assert.Error(err)
+
+	settings, err := picker.NewIndexSettings(picker.IndexSettingsParams{
+		NumberOfShards: 1,
+		Analysis: &picker.Analysis{
+			Analyzers: picker.Analyzers{
+				"my_analyzer": &picker.CustomAnalyzer{
+					Tokenizer: "standard",
+					Filter:    []string{"lowercase", "my_stop"},
+				},
+			},
+			Filters: picker.TokenFilters{
+				"my_stop": &picker.StopTokenFilter{Stopwords: picker.Stopwords{"_english_"}},
+			},
+		},
+	})
+	assert.NoError(err)
+	sd, err := json.Marshal(settings)
+	assert.NoError(err)
+	expected := []byte(`{
+		"index": {
+			"number_of_shards": 1,
+			"analysis": {
+				"analyzer": {
+					"my_analyzer": { "type": "custom", "tokenizer": "standard", "filter": ["lowercase", "my_stop"] }
+				},
+				"filter": {
+					"my_stop": { "type": "stop", "stopwords": "_english_" }
+				}
+			}
+		}
+	}`)
+	assert.True(cmpjson.Equal(expected, sd), cmpjson.Diff(expected, sd))
+
+	err = settings.SetAnalysis(&picker.Analysis{Analyzers: picker.Analyzers{"bad": &picker.CustomAnalyzer{}}})
+	assert.True(errors.Is(err, picker.ErrTokenizerRequired))
+	err = settings.SetAnalysis(nil)
+	assert.NoError(err)
+	a, err = settings.Analysis()
+	assert.NoError(err)
+	assert.Nil(a)
+}
+
+func TestIndexValidate(t *testing.T) {
+	assert := require.New(t)
+	params := picker.IndexParams{
+		Mappings: picker.Mappings{
+			Properties: picker.FieldMap{
+				"title": picker.TextFieldParams{
+					Analyzer:            "my_analyzer",
+					SearchAnalyzer:      "standard",
+					SearchQuoteAnalyzer: "my_analyzer",
+					Fields: picker.FieldMap{
+						"english": picker.TextFieldParams{Analyzer: "english"},
+					},
+				},
+				"tag":     picker.KeywordFieldParams{Normalizer: "lowercase"},
+				"sku":     picker.KeywordFieldParams{Normalizer: "my_normalizer"},
+				"suggest": picker.CompletionFieldParams{Analyzer: "simple"},
+				"words":   picker.TokenCountFieldParams{Analyzer: "whitespace"},
+				"author": picker.ObjectFieldParams{
+					Properties: picker.FieldMap{
+						"name": picker.SearchAsYouTypeFieldParams{Analyzer: "my_analyzer"},
+					},
+				},
+			},
+		},
+		Settings: picker.IndexSettingsParams{
+			Analysis: &picker.Analysis{
+				Analyzers: picker.Analyzers{
+					"my_analyzer": &picker.CustomAnalyzer{Tokenizer: "standard", Filter: []string{"lowercase"}},
+				},
+				Normalizers: picker.Normalizers{
+					"my_normalizer": {Filter: []string{"lowercase", "asciifolding"}},
+				},
+			},
+		},
+	}
+	idx, err := picker.NewIndex(params)
+	assert.NoError(err)
+	assert.NoError(idx.Validate())
+
+	params.Mappings.Properties["title"] = picker.TextFieldParams{
+		Analyzer:       "my_analyzer",
+		SearchAnalyzer: "my_anlyzer",
+		Fields: picker.FieldMap{
+			"english": picker.TextFieldParams{Analyzer: "englsh"},
+		},
+	}
+	params.Mappings.Properties["author"] = picker.ObjectFieldParams{
+		Properties: picker.FieldMap{
+			"name": picker.KeywordFieldParams{Normalizer: "my_normaliser"},
+		},
+	}
+	params.Mappings.Properties["words"] = picker.TokenCountFieldParams{Analyzer: "custom"}
+	idx, err = picker.NewIndex(params)
+	assert.NoError(err)
+	err = idx.Validate()
+	var merr *picker.MappingError
+	assert.True(errors.As(err, &merr))
+	assert.Len(merr.Errors, 4)
+	fields := map[string]error{}
+	for _, e := range merr.Errors {
+		var fe *picker.FieldError
+		assert.True(errors.As(e, &fe))
+		fields[fe.Field] = fe.Err
+	}
+	assert.True(errors.Is(fields["title"], picker.ErrAnalyzerNotFound))
+	assert.Contains(fields["title"].Error(), "search_analyzer")
+	assert.True(errors.Is(fields["title.english"], picker.ErrAnalyzerNotFound))
+	assert.True(errors.Is(fields["author.name"], picker.ErrNormalizerNotFound))
+	assert.True(errors.Is(fields["words"], picker.ErrAnalyzerNotFound))
+
+	// without analysis settings, only built-in components resolve
+	idx, err = picker.NewIndex(picker.IndexParams{
+		Mappings: picker.Mappings{
+			Properties: picker.FieldMap{
+				"title": picker.TextFieldParams{Analyzer: "my_analyzer"},
+			},
+		},
+	})
+	assert.NoError(err)
+	assert.True(errors.Is(idx.Validate(), picker.ErrAnalyzerNotFound))
+}
@@ -0,0 +1,322 @@
+package picker
+
+import (
+	"fmt"
+)
+
+// DefaultAnalyzer is the analyzer used by text fields which do not set one
+const DefaultAnalyzer = "standard"
+
+// AnalyzeToken is a token produced by analysis, in the form returned by the
+// analyze API
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-analyze.html
+//
+//easyjson:json
+type AnalyzeToken struct {
+	Token       string `json:"token"`
+	StartOffset int    `json:"start_offset"`
+	EndOffset   int    `json:"end_offset"`
+	Type        string `json:"type"`
+	Position    int    `json:"position"`
+	// The number of positions the token spans. It is only set when it is
+	// greater than 1, such as for shingles.
+	PositionLength int `json:"positionLength,omitempty"`
+}
+
+// AnalyzeResponse is the response of the analyze API
+//
+//easyjson:json
+type AnalyzeResponse struct {
+	Tokens []AnalyzeToken `json:"tokens"`
+}
+
+// Analyze runs text through the analyzer with the given name, which is either
+// a custom analyzer of a or a built-in analyzer, and returns the tokens the
+// analyze API would return. Each text is treated as a value of an array,
+// separated by the position_increment_gap of the analyzer.
+//
+// Analysis is simulated locally and supports a subset of Elasticsearch's
+// analysis components:
+//
+//	Analyzers: standard, simple, whitespace, keyword, stop, and custom
+//	Tokenizers: standard, whitespace, keyword, letter, lowercase
+//	Token filters: lowercase, asciifolding, stop, edge_ngram, shingle
+//
+// Only the _english_ and _none_ pre-defined stop words lists are supported.
+// Analyzers which use any other component return ErrAnalysisNotSupported.
+func (a *Analysis) Analyze(analyzer string, text ...string) ([]AnalyzeToken, error) {
+	chain, err := a.chain(analyzer)
+	if err != nil {
+		return nil, err
+	}
+	return chain.analyze(text), nil
+}
+
+// AnalyzeIndex runs text through the analysis f uses at index time. Text
+// fields use their analyzer, falling back to the "default" analyzer of a if
+// defined and otherwise the standard analyzer. Keyword fields emit each
+// value as a single token, run through their normalizer if set.
+func (a *Analysis) AnalyzeIndex(f Field, text ...string) ([]AnalyzeToken, error) {
+	return a.analyzeField(f, false, text)
+}
+
+// AnalyzeSearch runs text through the analysis f uses at search time, such as
+// for the query of a match or match_phrase query. Text fields use their
+// search_analyzer, falling back to the "default_search" analyzer of a if
+// defined and otherwise the index analyzer of the field. Keyword fields emit
+// each value as a single token, run through their normalizer if set.
+//
+// Term-level queries, such as the term query, are not analyzed.
+func (a *Analysis) AnalyzeSearch(f Field, text ...string) ([]AnalyzeToken, error) {
+	return a.analyzeField(f, true, text)
+}
+
+func (a *Analysis) analyzeField(f Field, search bool, text []string) ([]AnalyzeToken, error) {
+	if f == nil {
+		return nil, ErrFieldRequired
+	}
+	if f.Type() == FieldTypeKeyword {
+		chain := &analysisChain{tokenizer: keywordTokenize(), gap: int(DefaultPositionIncrementGap)}
+		if n, ok := f.(WithNormalizer); ok && len(n.Normalizer()) > 0 {
+			filters, err := a.normalizerFilters(n.Normalizer())
+			if err != nil {
+				return nil, err
+			}
+			chain.filters = filters
+		}
+		return chain.analyze(text), nil
+	}
+	wa, ok := f.(WithAnalyzer)
+	if !ok {
+		return nil, fmt.Errorf("%w; fields of type <%s> are not analyzed", ErrAnalysisNotSupported, f.Type())
+	}
+	name := wa.Analyzer()
+	if len(name) == 0 {
+		switch {
+		case f.Type() == FieldTypeCompletion:
+			name = string(AnalyzerTypeSimple)
+		case a.hasCustomAnalyzer("default"):
+			name = "default"
+		default:
+			name = DefaultAnalyzer
+		}
+	}
+	if search {
+		if sa, ok := f.(WithSearchAnalyzer); ok && len(sa.SearchAnalyzer()) > 0 {
+			name = sa.SearchAnalyzer()
+		} else if a.hasCustomAnalyzer("default_search") {
+			name = "default_search"
+		}
+	}
+	chain, err := a.chain(name)
+	if err != nil {
+		return nil, err
+	}
+	if g, ok := f.(interface{ PositionIncrementGap() float64 }); ok && g.PositionIncrementGap() != DefaultPositionIncrementGap {
+		chain.gap = int(g.PositionIncrementGap())
+	}
+	return chain.analyze(text), nil
+}
+
+func (a *Analysis) hasCustomAnalyzer(name string) bool {
+	if a == nil {
+		return false
+	}
+	_, ok := a.Analyzers[name]
+	return ok
+}
+
+// analysisChain is a tokenizer followed by token filters
+type analysisChain struct {
+	tokenizer tokenizerFunc
+	filters   []tokenFilterFunc
+	gap       int
+}
+
+// analyze mirrors the analyze API: the positions and offsets of each text
+// continue from those of the previous text, separated by the position
+// increment gap and an offset gap of 1.
+func (c *analysisChain) analyze(text []string) []AnalyzeToken {
+	res := []AnalyzeToken{}
+	lastPosition := -1
+	lastOffset := 0
+	for _, t := range text {
+		stream := c.tokenizer(t)
+		for _, filter := range c.filters {
+			stream = filter(stream)
+		}
+		for _, tok := range stream.tokens {
+			if tok.posInc > 0 {
+				lastPosition += tok.posInc
+			}
+			at := AnalyzeToken{
+				Token:       tok.term,
+				StartOffset: lastOffset + tok.start,
+				EndOffset:   lastOffset + tok.end,
+				Type:        tok.typ,
+				Position:    lastPosition,
+			}
+			if tok.posLen > 1 {
+				at.PositionLength = tok.posLen
+			}
+			res = append(res, at)
+		}
+		lastOffset += stream.end
+		lastPosition += stream.finalPosInc
+		lastPosition += c.gap
+		lastOffset++
+	}
+	return res
+}
+
+func (a *Analysis) chain(name string) (*analysisChain, error) {
+	if a != nil {
+		if v, ok := a.Analyzers[name]; ok {
+			chain, err := a.customChain(v)
+			if err != nil {
+				return nil, newFieldError(err, name)
+			}
+			return chain, nil
+		}
+	}
+	switch AnalyzerType(name) {
+	case AnalyzerTypeStandard, AnalyzerTypeSimple, AnalyzerTypeWhitespace, AnalyzerTypeKeyword, AnalyzerTypeStop:
+		return a.customChain(AnalyzerTypeHandlers[AnalyzerType(name)]())
+	}
+	if a.HasAnalyzer(name) {
+		return nil, fmt.Errorf("%w; analyzer <%s>", ErrAnalysisNotSupported, name)
+	}
+	return nil, fmt.Errorf("%w <%s>", ErrAnalyzerNotFound, name)
+}
+
+func (a *Analysis) customChain(v Analyzer) (*analysisChain, error) {
+	chain := &analysisChain{gap: int(DefaultPositionIncrementGap)}
+	switch v := v.(type) {
+	case *StandardAnalyzer:
+		stop, err := stopFilter(v.Stopwords, v.StopwordsPath, stopwordsNone, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		chain.tokenizer = standardTokenize(v.MaxTokenLength)
+		chain.filters = []tokenFilterFunc{lowercaseFilter, stop}
+	case *SimpleAnalyzer:
+		chain.tokenizer = letterTokenize(true, 0)
+	case *WhitespaceAnalyzer:
+		chain.tokenizer = whitespaceTokenize(0)
+	case *KeywordAnalyzer:
+		chain.tokenizer = keywordTokenize()
+	case *StopAnalyzer:
+		stop, err := stopFilter(v.Stopwords, v.StopwordsPath, stopwordsEnglish, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		chain.tokenizer = letterTokenize(true, 0)
+		chain.filters = []tokenFilterFunc{stop}
+	case *CustomAnalyzer:
+		if len(v.CharFilter) > 0 {
+			return nil, fmt.Errorf("%w; char filters are not supported", ErrAnalysisNotSupported)
+		}
+		t, err := a.tokenizer(v.Tokenizer)
+		if err != nil {
+			return nil, newFieldError(err, "tokenizer")
+		}
+		chain.tokenizer = t
+		for _, name := range v.Filter {
+			f, err := a.tokenFilter(name)
+			if err != nil {
+				return nil, newFieldError(err, "filter")
+			}
+			chain.filters = append(chain.filters, f)
+		}
+		if v.PositionIncrementGap != nil {
+			chain.gap = *v.PositionIncrementGap
+		}
+	default:
+		return nil, fmt.Errorf("%w; analyzer type <%s>", ErrAnalysisNotSupported, v.Type())
+	}
+	return chain, nil
+}
+
+func (a *Analysis) tokenizer(name string) (tokenizerFunc, error) {
+	var t Tokenizer
+	if a != nil {
+		t = a.Tokenizers[name]
+	}
+	if t == nil {
+		handler, ok := TokenizerTypeHandlers[TokenizerType(name)]
+		if !ok {
+			return nil, fmt.Errorf("%w; tokenizer <%s> not found", ErrAnalysisNotSupported, name)
+		}
+		t = handler()
+	}
+	switch t := t.(type) {
+	case *StandardTokenizer:
+		return standardTokenize(t.MaxTokenLength), nil
+	case *WhitespaceTokenizer:
+		return whitespaceTokenize(t.MaxTokenLength), nil
+	case *KeywordTokenizer:
+		return keywordTokenize(), nil
+	case *LetterTokenizer:
+		return letterTokenize(false, 0), nil
+	case *LowercaseTokenizer:
+		return letterTokenize(true, 0), nil
+	}
+	return nil, fmt.Errorf("%w; tokenizer type <%s>", ErrAnalysisNotSupported, t.Type())
+}
+
+func (a *Analysis) tokenFilter(name string) (tokenFilterFunc, error) {
+	var f TokenFilter
+	if a != nil {
+		f = a.Filters[name]
+	}
+	if f == nil {
+		handler, ok := TokenFilterTypeHandlers[TokenFilterType(name)]
+		if !ok {
+			return nil, fmt.Errorf("%w; token filter <%s> not found", ErrAnalysisNotSupported, name)
+		}
+		f = handler()
+	}
+	switch f := f.(type) {
+	case *LowercaseTokenFilter:
+		if len(f.Language) > 0 {
+			return nil, fmt.Errorf("%w; lowercase language <%s>", ErrAnalysisNotSupported, f.Language)
+		}
+		return lowercaseFilter, nil
+	case *ASCIIFoldingTokenFilter:
+		return asciiFoldingFilter(f.PreserveOriginal != nil && *f.PreserveOriginal), nil
+	case *StopTokenFilter:
+		return stopFilter(f.Stopwords, f.StopwordsPath, stopwordsEnglish, f.IgnoreCase, f.RemoveTrailing)
+	case *EdgeNGramTokenFilter:
+		return edgeNGramFilter(*f), nil
+	case *ShingleTokenFilter:
+		return shingleFilter(*f), nil
+	}
+	return nil, fmt.Errorf("%w; token filter type <%s>", ErrAnalysisNotSupported, f.Type())
+}
+
+func (a *Analysis) normalizerFilters(name string) ([]tokenFilterFunc, error) {
+	if name == NormalizerLowercase {
+		return []tokenFilterFunc{lowercaseFilter}, nil
+	}
+	var n Normalizer
+	ok := false
+	if a != nil {
+		n, ok = a.Normalizers[name]
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w <%s>", ErrNormalizerNotFound, name)
+	}
+	if len(n.CharFilter) > 0 {
+		return nil, fmt.Errorf("%w; char filters are not supported", ErrAnalysisNotSupported)
+	}
+	var res []tokenFilterFunc
+	for _, fn := range n.Filter {
+		f, err := a.tokenFilter(fn)
+		if err != nil {
+			return nil, newFieldError(err, name)
+		}
+		res = append(res, f)
+	}
+	return res, nil
+}
@@ -0,0 +1,326 @@
+package picker
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	stopwordsNone    = "_none_"
+	stopwordsEnglish = "_english_"
+)
+
+// englishStopwords is Lucene's default English stop words set
+var englishStopwords = []string{
+	"a", "an", "and", "are", "as", "at", "be", "but", "by", "for", "if", "in",
+	"into", "is", "it", "no", "not", "of", "on", "or", "such", "that", "the",
+	"their", "then", "there", "these", "they", "this", "to", "was", "will",
+	"with",
+}
+
+func lowercaseFilter(s tokenStream) tokenStream {
+	for i, t := range s.tokens {
+		s.tokens[i].term = strings.ToLower(t.term)
+	}
+	return s
+}
+
+// asciiFoldingFilter folds characters which are not in the Basic Latin
+// Unicode block to their ASCII equivalent. Only the Latin-1 Supplement and
+// Latin Extended-A blocks, ligatures and common punctuation are folded.
+func asciiFoldingFilter(preserveOriginal bool) tokenFilterFunc {
+	return func(s tokenStream) tokenStream {
+		tokens := make([]analysisToken, 0, len(s.tokens))
+		for _, t := range s.tokens {
+			folded := foldToASCII(t.term)
+			if folded == t.term {
+				tokens = append(tokens, t)
+				continue
+			}
+			orig := t
+			t.term = folded
+			tokens = append(tokens, t)
+			if preserveOriginal {
+				orig.posInc = 0
+				tokens = append(tokens, orig)
+			}
+		}
+		s.tokens = tokens
+		return s
+	}
+}
+
+func foldToASCII(term string) string {
+	var b strings.Builder
+	for i, r := range term {
+		if r < utf8.RuneSelf {
+			if b.Len() > 0 {
+				b.WriteRune(r)
+			}
+			continue
+		}
+		f, ok := asciiFolding[r]
+		if !ok {
+			if b.Len() > 0 {
+				b.WriteRune(r)
+			}
+			continue
+		}
+		if b.Len() == 0 {
+			b.WriteString(term[:i])
+		}
+		b.WriteString(f)
+	}
+	if b.Len() == 0 {
+		return term
+	}
+	return b.String()
+}
+
+var asciiFolding = func() map[rune]string {
+	groups := []struct {
+		to   string
+		from string
+	}{
+		{"A", "ÀÁÂÃÄÅĀĂĄǍǞǠǺȀȂȦ"}, {"a", "àáâãäåāăąǎǟǡǻȁȃȧ"},
+		{"AE", "ÆǢǼ"}, {"ae", "æǣǽ"},
+		{"C", "ÇĆĈĊČ"}, {"c", "çćĉċč"},
+		{"D", "ÐĎĐ"}, {"d", "ðďđ"},
+		{"E", "ÈÉÊËĒĔĖĘĚȄȆȨ"}, {"e", "èéêëēĕėęěȅȇȩ"},
+		{"G", "ĜĞĠĢǦǴ"}, {"g", "ĝğġģǧǵ"},
+		{"H", "ĤĦ"}, {"h", "ĥħ"},
+		{"I", "ÌÍÎÏĨĪĬĮİǏȈȊ"}, {"i", "ìíîïĩīĭįıǐȉȋ"},
+		{"IJ", "Ĳ"}, {"ij", "ĳ"},
+		{"J", "Ĵ"}, {"j", "ĵ"},
+		{"K", "ĶǨ"}, {"k", "ķǩ"}, {"q", "ĸ"},
+		{"L", "ĹĻĽĿŁ"}, {"l", "ĺļľŀł"},
+		{"N", "ÑŃŅŇŊǸ"}, {"n", "ñńņňŉŋǹ"},
+		{"O", "ÒÓÔÕÖØŌŎŐƠǑǾȌȎȪȬȮȰ"}, {"o", "òóôõöøōŏőơǒǿȍȏȫȭȯȱ"},
+		{"OE", "Œ"}, {"oe", "œ"},
+		{"R", "ŔŖŘȐȒ"}, {"r", "ŕŗřȑȓ"},
+		{"S", "ŚŜŞŠȘ"}, {"s", "śŝşšșſ"}, {"ss", "ß"},
+		{"T", "ŢŤŦȚ"}, {"t", "ţťŧț"},
+		{"TH", "Þ"}, {"th", "þ"},
+		{"U", "ÙÚÛÜŨŪŬŮŰŲƯǓǕǗǙǛȔȖ"}, {"u", "ùúûüũūŭůűųưǔǖǘǚǜȕȗ"},
+		{"W", "Ŵ"}, {"w", "ŵ"},
+		{"Y", "ÝŶŸ"}, {"y", "ýÿŷ"},
+		{"Z", "ŹŻŽ"}, {"z", "źżž"},
+		{"ff", "ﬀ"}, {"fi", "ﬁ"}, {"fl", "ﬂ"}, {"ffi", "ﬃ"}, {"ffl", "ﬄ"}, {"st", "ﬆ"},
+		{"\"", "«»“”„″"}, {"'", "‘’‚‛‹›′"}, {"-", "‐‑‒–—"},
+	}
+	res := map[rune]string{}
+	for _, g := range groups {
+		for _, r := range g.from {
+			res[r] = g.to
+		}
+	}
+	return res
+}()
+
+// stopWords resolves the stop words of a stop filter or analyzer
+func stopWords(words Stopwords, path string, def string) ([]string, error) {
+	if len(path) > 0 {
+		return nil, fmt.Errorf("%w; stopwords_path", ErrAnalysisNotSupported)
+	}
+	if len(words) == 0 {
+		words = Stopwords{def}
+	}
+	var res []string
+	for _, w := range words {
+		switch {
+		case w == stopwordsNone:
+		case w == stopwordsEnglish:
+			res = append(res, englishStopwords...)
+		case len(w) > 1 && strings.HasPrefix(w, "_") && strings.HasSuffix(w, "_"):
+			return nil, fmt.Errorf("%w; stop words <%s>", ErrAnalysisNotSupported, w)
+		default:
+			res = append(res, w)
+		}
+	}
+	return res, nil
+}
+
+// stopFilter removes stop words, adding their position increments to the
+// next token
+func stopFilter(words Stopwords, path string, def string, ignoreCase, removeTrailing *bool) (tokenFilterFunc, error) {
+	list, err := stopWords(words, path, def)
+	if err != nil {
+		return nil, err
+	}
+	ic := ignoreCase != nil && *ignoreCase
+	set := make(map[string]struct{}, len(list))
+	for _, w := range list {
+		if ic {
+			w = strings.ToLower(w)
+		}
+		set[w] = struct{}{}
+	}
+	keepTrailing := removeTrailing != nil && !*removeTrailing
+	return func(s tokenStream) tokenStream {
+		tokens := make([]analysisToken, 0, len(s.tokens))
+		skipped := 0
+		for i, t := range s.tokens {
+			term := t.term
+			if ic {
+				term = strings.ToLower(term)
+			}
+			_, isStop := set[term]
+			// with remove_trailing disabled, a stop word at the end of the
+			// text is kept as the user may still be typing it
+			if isStop && keepTrailing && i == len(s.tokens)-1 && t.end == s.end {
+				isStop = false
+			}
+			if isStop {
+				skipped += t.posInc
+				continue
+			}
+			t.posInc += skipped
+			skipped = 0
+			tokens = append(tokens, t)
+		}
+		s.tokens = tokens
+		s.finalPosInc += skipped
+		return s
+	}, nil
+}
+
+// edgeNGramFilter emits the prefixes of each token between min_gram and
+// max_gram characters long at the position of the token
+func edgeNGramFilter(f EdgeNGramTokenFilter) tokenFilterFunc {
+	minGram, maxGram := f.MinGram, f.MaxGram
+	if minGram <= 0 {
+		minGram = 1
+	}
+	if maxGram <= 0 {
+		maxGram = 2
+	}
+	preserve := f.PreserveOriginal != nil && *f.PreserveOriginal
+	return func(s tokenStream) tokenStream {
+		tokens := make([]analysisToken, 0, len(s.tokens))
+		posInc := 0
+		for _, t := range s.tokens {
+			posInc += t.posInc
+			runes := []rune(t.term)
+			for n := minGram; n <= maxGram && n <= len(runes); n++ {
+				g := t
+				if f.Side == "back" {
+					g.term = string(runes[len(runes)-n:])
+				} else {
+					g.term = string(runes[:n])
+				}
+				g.posInc = posInc
+				posInc = 0
+				tokens = append(tokens, g)
+			}
+			if preserve && (len(runes) < minGram || len(runes) > maxGram) {
+				t.posInc = posInc
+				posInc = 0
+				tokens = append(tokens, t)
+			}
+		}
+		s.tokens = tokens
+		s.finalPosInc += posInc
+		return s
+	}
+}
+
+// shingleFilter emits the tokens of the stream as well as shingles, which are
+// adjacent tokens concatenated, between min_shingle_size and
+// max_shingle_size tokens long. Positions which are missing, such as those of
+// removed stop words, are filled with the filler token.
+func shingleFilter(f ShingleTokenFilter) tokenFilterFunc {
+	minSize, maxSize := f.MinShingleSize, f.MaxShingleSize
+	if minSize <= 0 {
+		minSize = 2
+	}
+	if maxSize <= 0 {
+		maxSize = 2
+	}
+	unigrams := f.OutputUnigrams == nil || *f.OutputUnigrams
+	unigramsIfNoShingles := f.OutputUnigramsIfNoShingles != nil && *f.OutputUnigramsIfNoShingles
+	separator, filler := " ", "_"
+	if f.TokenSeparator != nil {
+		separator = *f.TokenSeparator
+	}
+	if f.FillerToken != nil {
+		filler = *f.FillerToken
+	}
+	return func(s tokenStream) tokenStream {
+		type slot struct {
+			token  analysisToken
+			pos    int
+			filler bool
+		}
+		var slots []slot
+		pos := -1
+		for _, t := range s.tokens {
+			for i := 1; i < t.posInc; i++ {
+				pos++
+				slots = append(slots, slot{pos: pos, filler: true})
+			}
+			if t.posInc > 0 {
+				pos++
+			}
+			slots = append(slots, slot{token: t, pos: pos})
+		}
+		type output struct {
+			token analysisToken
+			pos   int
+		}
+		var shingles, all []output
+		for i, sl := range slots {
+			if unigrams && !sl.filler {
+				all = append(all, output{sl.token, sl.pos})
+			}
+			for n := minSize; n <= maxSize && i+n <= len(slots); n++ {
+				parts := make([]string, n)
+				var first, last *analysisToken
+				for k := 0; k < n; k++ {
+					x := slots[i+k]
+					if x.filler {
+						parts[k] = filler
+						continue
+					}
+					parts[k] = x.token.term
+					if first == nil {
+						first = &slots[i+k].token
+					}
+					last = &slots[i+k].token
+				}
+				if first == nil {
+					continue
+				}
+				o := output{
+					token: analysisToken{
+						term:   strings.Join(parts, separator),
+						start:  first.start,
+						end:    last.end,
+						typ:    "shingle",
+						posLen: n,
+					},
+					pos: sl.pos,
+				}
+				shingles = append(shingles, o)
+				all = append(all, o)
+			}
+		}
+		if !unigrams && len(shingles) == 0 && unigramsIfNoShingles {
+			for _, sl := range slots {
+				if !sl.filler {
+					all = append(all, output{sl.token, sl.pos})
+				}
+			}
+		}
+		tokens := make([]analysisToken, 0, len(all))
+		last := -1
+		for _, o := range all {
+			t := o.token
+			t.posInc = o.pos - last
+			last = o.pos
+			tokens = append(tokens, t)
+		}
+		s.tokens = tokens
+		return s
+	}
+}
@@ -0,0 +1,169 @@
+package picker_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/chanced/picker"
+	"github.com/stretchr/testify/require"
+)
+
+func analyzedTerms(tokens []picker.AnalyzeToken) []string {
+	res := make([]string, len(tokens))
+	for i, t := range tokens {
+		res[i] = t.Token
+	}
+	return res
+}
+
+func TestAnalyzeBuiltIn(t *testing.T) {
+	assert := require.New(t)
+	var a *picker.Analysis
+	text := "The 2 QUICK Brown-Foxes jumped over the lazy dog's bone."
+
+	tokens, err := a.Analyze("standard", text)
+	assert.NoError(err)
+	expected := []picker.AnalyzeToken{
+		{Token: "the", StartOffset: 0, EndOffset: 3, Type: "<ALPHANUM>", Position: 0},
+		{Token: "2", StartOffset: 4, EndOffset: 5, Type: "<NUM>", Position: 1},
+		{Token: "quick", StartOffset: 6, EndOffset: 11, Type: "<ALPHANUM>", Position: 2},
+		{Token: "brown", StartOffset: 12, EndOffset: 17, Type: "<ALPHANUM>", Position: 3},
+		{Token: "foxes", StartOffset: 18, EndOffset: 23, Type: "<ALPHANUM>", Position: 4},
+		{Token: "jumped", StartOffset: 24, EndOffset: 30, Type: "<ALPHANUM>", Position: 5},
+		{Token: "over", StartOffset: 31, EndOffset: 35, Type: "<ALPHANUM>", Position: 6},
+		{Token: "the", StartOffset: 36, EndOffset: 39, Type: "<ALPHANUM>", Position: 7},
+		{Token: "lazy", StartOffset: 40, EndOffset: 44, Type: "<ALPHANUM>", Position: 8},
+		{Token: "dog's", StartOffset: 45, EndOffset: 50, Type: "<ALPHANUM>", Position: 9},
+		{Token: "bone", StartOffset: 51, EndOffset: 55, Type: "<ALPHANUM>", Position: 10},
+	}
+	assert.Equal(expected, tokens)
+
+	tokens, err = a.Analyze("whitespace", text)
+	assert.NoError(err)
+	assert.Equal([]string{"The", "2", "QUICK", "Brown-Foxes", "jumped", "over", "the", "lazy", "dog's", "bone."}, analyzedTerms(tokens))
+
+	tokens, err = a.Analyze("simple", text)
+	assert.NoError(err)
+	assert.Equal([]string{"the", "quick", "brown", "foxes", "jumped", "over", "the", "lazy", "dog", "s", "bone"}, analyzedTerms(tokens))
+
+	tokens, err = a.Analyze("keyword", text)
+	assert.NoError(err)
+	assert.Equal([]picker.AnalyzeToken{{Token: text, EndOffset: 56, Type: "word"}}, tokens)
+
+	tokens, err = a.Analyze("stop", text)
+	assert.NoError(err)
+	assert.Equal([]string{"quick", "brown", "foxes", "jumped", "over", "lazy", "dog", "s", "bone"}, analyzedTerms(tokens))
+	assert.Equal(1, tokens[0].Position)
+	assert.Equal(7, tokens[5].Position)
+
+	tokens, err = a.Analyze("standard", "U.S.A. costs $1,000.50 or 3.14 in 京都 for foo_bar")
+	assert.NoError(err)
+	assert.Equal([]string{"u.s.a", "costs", "1,000.50", "or", "3.14", "in", "京", "都", "for", "foo_bar"}, analyzedTerms(tokens))
+	assert.Equal("<IDEOGRAPHIC>", tokens[6].Type)
+
+	// each value is separated by the position_increment_gap
+	tokens, err = a.Analyze("standard", "John Abraham", "Lincoln Smith")
+	assert.NoError(err)
+	assert.Equal(picker.AnalyzeToken{Token: "lincoln", StartOffset: 13, EndOffset: 20, Type: "<ALPHANUM>", Position: 102}, tokens[2])
+
+	_, err = a.Analyze("english", text)
+	assert.True(errors.Is(err, picker.ErrAnalysisNotSupported))
+	_, err = a.Analyze("my_analyzer", text)
+	assert.True(errors.Is(err, picker.ErrAnalyzerNotFound))
+}
+
+func TestAnalyzeTokenFilters(t *testing.T) {
+	assert := require.New(t)
+	a := &picker.Analysis{
+		Analyzers: picker.Analyzers{
+			"shingles":     &picker.CustomAnalyzer{Tokenizer: "whitespace", Filter: []string{"shingle"}},
+			"edge":         &picker.CustomAnalyzer{Tokenizer: "standard", Filter: []string{"edge_ngram"}},
+			"folding":      &picker.CustomAnalyzer{Tokenizer: "standard", Filter: []string{"my_folding"}},
+			"stop":         &picker.CustomAnalyzer{Tokenizer: "standard", Filter: []string{"stop"}},
+			"stop_shingle": &picker.CustomAnalyzer{Tokenizer: "standard", Filter: []string{"stop", "shingle"}},
+			"html":         &picker.CustomAnalyzer{Tokenizer: "standard", CharFilter: []string{"html_strip"}},
+		},
+		Filters: picker.TokenFilters{
+			"my_folding": &picker.ASCIIFoldingTokenFilter{PreserveOriginal: &[]bool{true}[0]},
+		},
+	}
+
+	// the response of the analyze API from the shingle token filter docs
+	data := []byte(`{
+		"tokens": [
+			{ "token": "quick", "start_offset": 0, "end_offset": 5, "type": "word", "position": 0 },
+			{ "token": "quick brown", "start_offset": 0, "end_offset": 11, "type": "shingle", "position": 0, "positionLength": 2 },
+			{ "token": "brown", "start_offset": 6, "end_offset": 11, "type": "word", "position": 1 },
+			{ "token": "brown fox", "start_offset": 6, "end_offset": 15, "type": "shingle", "position": 1, "positionLength": 2 },
+			{ "token": "fox", "start_offset": 12, "end_offset": 15, "type": "word", "position": 2 },
+			{ "token": "fox jumps", "start_offset": 12, "end_offset": 21, "type": "shingle", "position": 2, "positionLength": 2 },
+			{ "token": "jumps", "start_offset": 16, "end_offset": 21, "type": "word", "position": 3 }
+		]
+	}`)
+	var res picker.AnalyzeResponse
+	assert.NoError(json.Unmarshal(data, &res))
+	tokens, err := a.Analyze("shingles", "quick brown fox jumps")
+	assert.NoError(err)
+	assert.Equal(res.Tokens, tokens)
+
+	tokens, err = a.Analyze("edge", "the quick brown fox jumps")
+	assert.NoError(err)
+	assert.Equal([]string{"t", "th", "q", "qu", "b", "br", "f", "fo", "j", "ju"}, analyzedTerms(tokens))
+	assert.Equal(1, tokens[2].Position)
+	assert.Equal(4, tokens[2].StartOffset)
+	assert.Equal(9, tokens[3].EndOffset)
+
+	tokens, err = a.Analyze("folding", "açaí à la carte")
+	assert.NoError(err)
+	assert.Equal([]string{"acai", "açaí", "a", "à", "la", "carte"}, analyzedTerms(tokens))
+	assert.Equal(0, tokens[1].Position)
+	assert.Equal(1, tokens[3].Position)
+
+	tokens, err = a.Analyze("stop", "a quick fox jumps over the lazy dog")
+	assert.NoError(err)
+	assert.Equal([]string{"quick", "fox", "jumps", "over", "lazy", "dog"}, analyzedTerms(tokens))
+	assert.Equal(6, tokens[4].Position)
+
+	tokens, err = a.Analyze("stop_shingle", "the quick fox")
+	assert.NoError(err)
+	assert.Equal([]string{"_ quick", "quick", "quick fox", "fox"}, analyzedTerms(tokens))
+
+	_, err = a.Analyze("html", "<b>quick</b>")
+	assert.True(errors.Is(err, picker.ErrAnalysisNotSupported))
+}
+
+func TestAnalyzeField(t *testing.T) {
+	assert := require.New(t)
+	a := &picker.Analysis{
+		Analyzers: picker.Analyzers{
+			"autocomplete": &picker.CustomAnalyzer{Tokenizer: "standard", Filter: []string{"lowercase", "autocomplete"}},
+		},
+		Filters: picker.TokenFilters{
+			"autocomplete": &picker.EdgeNGramTokenFilter{MinGram: 1, MaxGram: 10},
+		},
+	}
+	title, err := picker.NewTextField(picker.TextFieldParams{Analyzer: "autocomplete", SearchAnalyzer: "standard"})
+	assert.NoError(err)
+	tokens, err := a.AnalyzeIndex(title, "Quick")
+	assert.NoError(err)
+	assert.Equal([]string{"q", "qu", "qui", "quic", "quick"}, analyzedTerms(tokens))
+	tokens, err = a.AnalyzeSearch(title, "Quick")
+	assert.NoError(err)
+	assert.Equal([]string{"quick"}, analyzedTerms(tokens))
+
+	body, err := picker.NewTextField(picker.TextFieldParams{})
+	assert.NoError(err)
+	tokens, err = a.AnalyzeSearch(body, "Quick Brown")
+	assert.NoError(err)
+	assert.Equal([]string{"quick", "brown"}, analyzedTerms(tokens))
+
+	tag, err := picker.NewKeywordField(picker.KeywordFieldParams{Normalizer: "lowercase"})
+	assert.NoError(err)
+	tokens, err = a.AnalyzeIndex(tag, "Quick Brown")
+	assert.NoError(err)
+	assert.Equal([]string{"quick brown"}, analyzedTerms(tokens))
+
+	_, err = a.AnalyzeIndex(&picker.DateField{}, "2021-01-01")
+	assert.True(errors.Is(err, picker.ErrAnalysisNotSupported))
+}
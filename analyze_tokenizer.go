@@ -0,0 +1,321 @@
+package picker
+
+import (
+	"unicode"
+	"unicode/utf16"
+)
+
+const defaultMaxTokenLength = 255
+
+// analysisToken is a token within a tokenStream. Offsets are in UTF-16 code
+// units, as they are in Elasticsearch.
+type analysisToken struct {
+	term   string
+	start  int
+	end    int
+	typ    string
+	posInc int
+	posLen int
+}
+
+// tokenStream is the output of a tokenizer or token filter
+type tokenStream struct {
+	tokens []analysisToken
+	// end is the final offset of the text
+	end int
+	// finalPosInc is the position increment of tokens removed from the end
+	// of the stream
+	finalPosInc int
+}
+
+type tokenizerFunc func(text string) tokenStream
+
+type tokenFilterFunc func(s tokenStream) tokenStream
+
+// textRune is a rune of the text with its UTF-16 offsets
+type textRune struct {
+	r     rune
+	start int
+	end   int
+}
+
+func textRunes(text string) ([]textRune, int) {
+	res := make([]textRune, 0, len(text))
+	offset := 0
+	for _, r := range text {
+		n := 1
+		if r >= 0x10000 {
+			n = 2
+		}
+		res = append(res, textRune{r: r, start: offset, end: offset + n})
+		offset += n
+	}
+	return res, offset
+}
+
+func runesTerm(runes []textRune) string {
+	b := make([]rune, len(runes))
+	for i, r := range runes {
+		b[i] = r.r
+	}
+	return string(b)
+}
+
+func utf16Len(s string) int {
+	return len(utf16.Encode([]rune(s)))
+}
+
+// charTokenizer emits runs of runes for which isTokenChar returns true, split
+// at maxTokenLength, mirroring Lucene's CharTokenizer
+func charTokenizer(isTokenChar func(rune) bool, normalize func(rune) rune, maxTokenLength int) tokenizerFunc {
+	if maxTokenLength <= 0 {
+		maxTokenLength = defaultMaxTokenLength
+	}
+	return func(text string) tokenStream {
+		runes, end := textRunes(text)
+		s := tokenStream{end: end}
+		var cur []rune
+		start := 0
+		emit := func(endOffset int) {
+			if len(cur) == 0 {
+				return
+			}
+			s.tokens = append(s.tokens, analysisToken{term: string(cur), start: start, end: endOffset, typ: "word", posInc: 1})
+			cur = nil
+		}
+		for i, r := range runes {
+			if !isTokenChar(r.r) {
+				emit(r.start)
+				continue
+			}
+			if len(cur) == 0 {
+				start = r.start
+			}
+			cur = append(cur, normalize(r.r))
+			if len(cur) == maxTokenLength {
+				emit(runes[i].end)
+			}
+		}
+		emit(end)
+		return s
+	}
+}
+
+func whitespaceTokenize(maxTokenLength int) tokenizerFunc {
+	return charTokenizer(func(r rune) bool { return !isJavaWhitespace(r) }, identityRune, maxTokenLength)
+}
+
+func letterTokenize(lowercase bool, maxTokenLength int) tokenizerFunc {
+	normalize := identityRune
+	if lowercase {
+		normalize = unicode.ToLower
+	}
+	return charTokenizer(unicode.IsLetter, normalize, maxTokenLength)
+}
+
+func keywordTokenize() tokenizerFunc {
+	return func(text string) tokenStream {
+		end := utf16Len(text)
+		return tokenStream{
+			tokens: []analysisToken{{term: text, start: 0, end: end, typ: "word", posInc: 1}},
+			end:    end,
+		}
+	}
+}
+
+func identityRune(r rune) rune {
+	return r
+}
+
+// isJavaWhitespace mirrors Java's Character.isWhitespace
+func isJavaWhitespace(r rune) bool {
+	switch r {
+	case '\u00A0', '\u2007', '\u202F':
+		return false
+	case '\t', '\n', '\u000B', '\f', '\r', '\u001C', '\u001D', '\u001E', '\u001F':
+		return true
+	}
+	return unicode.In(r, unicode.Zs, unicode.Zl, unicode.Zp)
+}
+
+// wordBreakClass is a simplification of the Word_Break property of Unicode
+// Standard Annex #29
+type wordBreakClass int
+
+const (
+	wbOther wordBreakClass = iota
+	wbLetter
+	wbNumeric
+	wbKatakana
+	wbHiragana
+	wbIdeographic
+	wbHangul
+	wbSoutheastAsian
+	wbExtend
+	wbExtendNumLet
+	wbMidLetter
+	wbMidNum
+	wbMidNumLet
+)
+
+func wordBreakClassOf(r rune) wordBreakClass {
+	switch r {
+	case ':', '\u00B7', '\u0387', '\u05F4', '\u2027', '\uFE13', '\uFE55', '\uFF1A':
+		return wbMidLetter
+	case ',', ';', '\u037E', '\u0589', '\u060C', '\u060D', '\u066C', '\u07F8', '\u2044', '\uFE10', '\uFE14', '\uFE50', '\uFE54', '\uFF0C', '\uFF1B':
+		return wbMidNum
+	case '.', '\'', '\u2018', '\u2019', '\u2024', '\uFE52', '\uFF07', '\uFF0E':
+		return wbMidNumLet
+	case '_', '\u203F', '\u2040', '\u2054', '\uFE33', '\uFE34', '\uFE4D', '\uFE4E', '\uFE4F', '\uFF3F':
+		return wbExtendNumLet
+	case '\u200C', '\u200D':
+		return wbExtend
+	}
+	switch {
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc):
+		return wbExtend
+	case unicode.Is(unicode.Nd, r):
+		return wbNumeric
+	case unicode.Is(unicode.Katakana, r):
+		return wbKatakana
+	case unicode.Is(unicode.Hiragana, r):
+		return wbHiragana
+	case unicode.Is(unicode.Han, r):
+		return wbIdeographic
+	case unicode.Is(unicode.Hangul, r):
+		return wbHangul
+	case unicode.In(r, unicode.Thai, unicode.Lao, unicode.Myanmar, unicode.Khmer):
+		return wbSoutheastAsian
+	case unicode.IsLetter(r):
+		return wbLetter
+	}
+	return wbOther
+}
+
+// standardTokenize approximates the grammar of Lucene's StandardTokenizer,
+// which implements the word break rules of Unicode Standard Annex #29:
+//
+// Letters and digits, including those joined by underscores, form a single
+// token. Letters joined by an apostrophe, period or colon (e.g. "dog's",
+// "U.S.A") and digits joined by a period, comma or semicolon (e.g. "3.14",
+// "1,000") are kept together. Each ideographic and hiragana character is a
+// token of its own while runs of katakana, hangul and southeast asian
+// characters form a single token.
+//
+// Emoji are not emitted.
+func standardTokenize(maxTokenLength int) tokenizerFunc {
+	if maxTokenLength <= 0 {
+		maxTokenLength = defaultMaxTokenLength
+	}
+	return func(text string) tokenStream {
+		runes, end := textRunes(text)
+		classes := make([]wordBreakClass, len(runes))
+		for i, r := range runes {
+			classes[i] = wordBreakClassOf(r.r)
+		}
+		// next returns the index of the first rune at or after i which is
+		// not an extend rune
+		next := func(i int) int {
+			for i < len(runes) && classes[i] == wbExtend {
+				i++
+			}
+			return i
+		}
+		s := tokenStream{end: end}
+		emit := func(from, to int, typ string) {
+			for from < to {
+				n := to - from
+				if n > maxTokenLength {
+					n = maxTokenLength
+				}
+				s.tokens = append(s.tokens, analysisToken{
+					term:   runesTerm(runes[from : from+n]),
+					start:  runes[from].start,
+					end:    runes[from+n-1].end,
+					typ:    typ,
+					posInc: 1,
+				})
+				from += n
+			}
+		}
+		for i := 0; i < len(runes); {
+			c := classes[i]
+			switch c {
+			case wbIdeographic, wbHiragana:
+				j := i + 1
+				for j < len(runes) && classes[j] == wbExtend {
+					j++
+				}
+				if c == wbIdeographic {
+					emit(i, j, "<IDEOGRAPHIC>")
+				} else {
+					emit(i, j, "<HIRAGANA>")
+				}
+				i = j
+				continue
+			case wbKatakana, wbHangul, wbSoutheastAsian:
+				j := i + 1
+				for j < len(runes) && (classes[j] == c || classes[j] == wbExtend || (c == wbKatakana && classes[j] == wbExtendNumLet)) {
+					j++
+				}
+				typ := map[wordBreakClass]string{wbKatakana: "<KATAKANA>", wbHangul: "<HANGUL>", wbSoutheastAsian: "<SOUTHEAST_ASIAN>"}[c]
+				emit(i, j, typ)
+				i = j
+				continue
+			case wbLetter, wbNumeric, wbExtendNumLet:
+			default:
+				i++
+				continue
+			}
+			// a word of letters and digits
+			j := i
+			hasLetter, hasCore := false, false
+			prev := wbOther
+		word:
+			for j < len(runes) {
+				cj := classes[j]
+				switch cj {
+				case wbLetter, wbNumeric:
+					if cj == wbLetter {
+						hasLetter = true
+					}
+					hasCore = true
+					prev = cj
+					j++
+					continue
+				case wbExtendNumLet:
+					prev = cj
+					j++
+					continue
+				case wbExtend:
+					j++
+					continue
+				case wbMidLetter, wbMidNum, wbMidNumLet:
+					k := next(j + 1)
+					if k < len(runes) {
+						nc := classes[k]
+						joinLetters := prev == wbLetter && nc == wbLetter && cj != wbMidNum
+						joinDigits := prev == wbNumeric && nc == wbNumeric && cj != wbMidLetter
+						if joinLetters || joinDigits {
+							j = k
+							continue
+						}
+					}
+				}
+				break word
+			}
+			if hasCore {
+				if hasLetter {
+					emit(i, j, "<ALPHANUM>")
+				} else {
+					emit(i, j, "<NUM>")
+				}
+			}
+			if j == i {
+				j++
+			}
+			i = j
+		}
+		return s
+	}
+}
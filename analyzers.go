@@ -0,0 +1,518 @@
+package picker
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/chanced/dynamic"
+	"github.com/tidwall/gjson"
+)
+
+// AnalyzerType is the type of an Analyzer
+type AnalyzerType string
+
+func (t AnalyzerType) String() string {
+	return string(t)
+}
+
+const (
+	// AnalyzerTypeStandard is the standard analyzer. It is the default analyzer.
+	AnalyzerTypeStandard    AnalyzerType = "standard"
+	AnalyzerTypeSimple      AnalyzerType = "simple"
+	AnalyzerTypeWhitespace  AnalyzerType = "whitespace"
+	AnalyzerTypeStop        AnalyzerType = "stop"
+	AnalyzerTypeKeyword     AnalyzerType = "keyword"
+	AnalyzerTypePattern     AnalyzerType = "pattern"
+	AnalyzerTypeFingerprint AnalyzerType = "fingerprint"
+	// AnalyzerTypeCustom is the type of a CustomAnalyzer. Analyzers which have a
+	// tokenizer but no type are custom analyzers.
+	AnalyzerTypeCustom AnalyzerType = "custom"
+
+	AnalyzerTypeArabic     AnalyzerType = "arabic"
+	AnalyzerTypeArmenian   AnalyzerType = "armenian"
+	AnalyzerTypeBasque     AnalyzerType = "basque"
+	AnalyzerTypeBengali    AnalyzerType = "bengali"
+	AnalyzerTypeBrazilian  AnalyzerType = "brazilian"
+	AnalyzerTypeBulgarian  AnalyzerType = "bulgarian"
+	AnalyzerTypeCatalan    AnalyzerType = "catalan"
+	AnalyzerTypeCJK        AnalyzerType = "cjk"
+	AnalyzerTypeCzech      AnalyzerType = "czech"
+	AnalyzerTypeDanish     AnalyzerType = "danish"
+	AnalyzerTypeDutch      AnalyzerType = "dutch"
+	AnalyzerTypeEnglish    AnalyzerType = "english"
+	AnalyzerTypeEstonian   AnalyzerType = "estonian"
+	AnalyzerTypeFinnish    AnalyzerType = "finnish"
+	AnalyzerTypeFrench     AnalyzerType = "french"
+	AnalyzerTypeGalician   AnalyzerType = "galician"
+	AnalyzerTypeGerman     AnalyzerType = "german"
+	AnalyzerTypeGreek      AnalyzerType = "greek"
+	AnalyzerTypeHindi      AnalyzerType = "hindi"
+	AnalyzerTypeHungarian  AnalyzerType = "hungarian"
+	AnalyzerTypeIndonesian AnalyzerType = "indonesian"
+	AnalyzerTypeIrish      AnalyzerType = "irish"
+	AnalyzerTypeItalian    AnalyzerType = "italian"
+	AnalyzerTypeLatvian    AnalyzerType = "latvian"
+	AnalyzerTypeLithuanian AnalyzerType = "lithuanian"
+	AnalyzerTypeNorwegian  AnalyzerType = "norwegian"
+	AnalyzerTypePersian    AnalyzerType = "persian"
+	AnalyzerTypePortuguese AnalyzerType = "portuguese"
+	AnalyzerTypeRomanian   AnalyzerType = "romanian"
+	AnalyzerTypeRussian    AnalyzerType = "russian"
+	AnalyzerTypeSorani     AnalyzerType = "sorani"
+	AnalyzerTypeSpanish    AnalyzerType = "spanish"
+	AnalyzerTypeSwedish    AnalyzerType = "swedish"
+	AnalyzerTypeThai       AnalyzerType = "thai"
+	AnalyzerTypeTurkish    AnalyzerType = "turkish"
+)
+
+// AnalyzerTypeHandlers is a map of analyzer types to funcs which return an
+// Analyzer of that type, used when unmarshaling Analyzers. Analyzers provided
+// by plugins can be registered here; those which are not registered are
+// unmarshaled as a *RawAnalyzer.
+var AnalyzerTypeHandlers = map[AnalyzerType]func() Analyzer{
+	AnalyzerTypeStandard:    func() Analyzer { return &StandardAnalyzer{} },
+	AnalyzerTypeSimple:      func() Analyzer { return &SimpleAnalyzer{} },
+	AnalyzerTypeWhitespace:  func() Analyzer { return &WhitespaceAnalyzer{} },
+	AnalyzerTypeStop:        func() Analyzer { return &StopAnalyzer{} },
+	AnalyzerTypeKeyword:     func() Analyzer { return &KeywordAnalyzer{} },
+	AnalyzerTypePattern:     func() Analyzer { return &PatternAnalyzer{} },
+	AnalyzerTypeFingerprint: func() Analyzer { return &FingerprintAnalyzer{} },
+	AnalyzerTypeCustom:      func() Analyzer { return &CustomAnalyzer{} },
+	AnalyzerTypeArabic:      func() Analyzer { return &LanguageAnalyzer{Language: AnalyzerTypeArabic} },
+	AnalyzerTypeArmenian:    func() Analyzer { return &LanguageAnalyzer{Language: AnalyzerTypeArmenian} },
+	AnalyzerTypeBasque:      func() Analyzer { return &LanguageAnalyzer{Language: AnalyzerTypeBasque} },
+	AnalyzerTypeBengali:     func() Analyzer { return &LanguageAnalyzer{Language: AnalyzerTypeBengali} },
+	AnalyzerTypeBrazilian:   func() Analyzer { return &LanguageAnalyzer{Language: AnalyzerTypeBrazilian} },
+	AnalyzerTypeBulgarian:   func() Analyzer { return &LanguageAnalyzer{Language: AnalyzerTypeBulgarian} },
+	AnalyzerTypeCatalan:     func() Analyzer { return &LanguageAnalyzer{Language: AnalyzerTypeCatalan} },
+	AnalyzerTypeCJK:         func() Analyzer { return &LanguageAnalyzer{Language: AnalyzerTypeCJK} },
+	AnalyzerTypeCzech:       func() Analyzer { return &LanguageAnalyzer{Language: AnalyzerTypeCzech} },
+	AnalyzerTypeDanish:      func() Analyzer { return &LanguageAnalyzer{Language: AnalyzerTypeDanish} },
+	AnalyzerTypeDutch:       func() Analyzer { return &LanguageAnalyzer{Language: AnalyzerTypeDutch} },
+	AnalyzerTypeEnglish:     func() Analyzer { return &LanguageAnalyzer{Language: AnalyzerTypeEnglish} },
+	AnalyzerTypeEstonian:    func() Analyzer { return &LanguageAnalyzer{Language: AnalyzerTypeEstonian} },
+	AnalyzerTypeFinnish:     func() Analyzer { return &LanguageAnalyzer{Language: AnalyzerTypeFinnish} },
+	AnalyzerTypeFrench:      func() Analyzer { return &LanguageAnalyzer{Language: AnalyzerTypeFrench} },
+	AnalyzerTypeGalician:    func() Analyzer { return &LanguageAnalyzer{Language: AnalyzerTypeGalician} },
+	AnalyzerTypeGerman:      func() Analyzer { return &LanguageAnalyzer{Language: AnalyzerTypeGerman} },
+	AnalyzerTypeGreek:       func() Analyzer { return &LanguageAnalyzer{Language: AnalyzerTypeGreek} },
+	AnalyzerTypeHindi:       func() Analyzer { return &LanguageAnalyzer{Language: AnalyzerTypeHindi} },
+	AnalyzerTypeHungarian:   func() Analyzer { return &LanguageAnalyzer{Language: AnalyzerTypeHungarian} },
+	AnalyzerTypeIndonesian:  func() Analyzer { return &LanguageAnalyzer{Language: AnalyzerTypeIndonesian} },
+	AnalyzerTypeIrish:       func() Analyzer { return &LanguageAnalyzer{Language: AnalyzerTypeIrish} },
+	AnalyzerTypeItalian:     func() Analyzer { return &LanguageAnalyzer{Language: AnalyzerTypeItalian} },
+	AnalyzerTypeLatvian:     func() Analyzer { return &LanguageAnalyzer{Language: AnalyzerTypeLatvian} },
+	AnalyzerTypeLithuanian:  func() Analyzer { return &LanguageAnalyzer{Language: AnalyzerTypeLithuanian} },
+	AnalyzerTypeNorwegian:   func() Analyzer { return &LanguageAnalyzer{Language: AnalyzerTypeNorwegian} },
+	AnalyzerTypePersian:     func() Analyzer { return &LanguageAnalyzer{Language: AnalyzerTypePersian} },
+	AnalyzerTypePortuguese:  func() Analyzer { return &LanguageAnalyzer{Language: AnalyzerTypePortuguese} },
+	AnalyzerTypeRomanian:    func() Analyzer { return &LanguageAnalyzer{Language: AnalyzerTypeRomanian} },
+	AnalyzerTypeRussian:     func() Analyzer { return &LanguageAnalyzer{Language: AnalyzerTypeRussian} },
+	AnalyzerTypeSorani:      func() Analyzer { return &LanguageAnalyzer{Language: AnalyzerTypeSorani} },
+	AnalyzerTypeSpanish:     func() Analyzer { return &LanguageAnalyzer{Language: AnalyzerTypeSpanish} },
+	AnalyzerTypeSwedish:     func() Analyzer { return &LanguageAnalyzer{Language: AnalyzerTypeSwedish} },
+	AnalyzerTypeThai:        func() Analyzer { return &LanguageAnalyzer{Language: AnalyzerTypeThai} },
+	AnalyzerTypeTurkish:     func() Analyzer { return &LanguageAnalyzer{Language: AnalyzerTypeTurkish} },
+}
+
+// Analyzer is a built-in or custom analyzer, defined in the analysis settings
+// of an index. An analyzer is made up of zero or more character filters, a
+// tokenizer, and zero or more token filters.
+//
+// An Analyzer can optionally implement Validate() error.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-analyzers.html
+type Analyzer interface {
+	// Type is the type of the analyzer, e.g. "standard"
+	Type() AnalyzerType
+	json.Marshaler
+	json.Unmarshaler
+}
+
+// Analyzers are custom analyzers, keyed by name
+type Analyzers map[string]Analyzer
+
+// Validate checks each Analyzer which implements Validate
+func (m Analyzers) Validate() error {
+	for name, v := range m {
+		if v == nil {
+			return newFieldError(ErrMissingType, name)
+		}
+		if vv, ok := v.(interface{ Validate() error }); ok {
+			if err := vv.Validate(); err != nil {
+				return newFieldError(err, name)
+			}
+		}
+	}
+	return nil
+}
+
+func (m Analyzers) MarshalBSON() ([]byte, error) {
+	return m.MarshalJSON()
+}
+
+func (m Analyzers) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]Analyzer(m))
+}
+
+func (m *Analyzers) UnmarshalBSON(data []byte) error {
+	return m.UnmarshalJSON(data)
+}
+
+func (m *Analyzers) UnmarshalJSON(data []byte) error {
+	*m = nil
+	var obj dynamic.JSONObject
+	err := json.Unmarshal(data, &obj)
+	if err != nil {
+		return err
+	}
+	if len(obj) == 0 {
+		return nil
+	}
+	res := make(Analyzers, len(obj))
+	for name, d := range obj {
+		var v Analyzer
+		err = UnmarshalAnalyzerJSON(d, &v)
+		if err != nil {
+			return newFieldError(err, name)
+		}
+		res[name] = v
+	}
+	*m = res
+	return nil
+}
+
+// UnmarshalAnalyzerJSON decodes data into the Analyzer registered in
+// AnalyzerTypeHandlers for its type, or a *RawAnalyzer if the type is unknown.
+func UnmarshalAnalyzerJSON(data []byte, v *Analyzer) error {
+	t := gjson.GetBytes(data, "type").String()
+	if len(t) == 0 && gjson.GetBytes(data, "tokenizer").Exists() {
+		// the type of custom analyzers can be omitted
+		t = string(AnalyzerTypeCustom)
+	}
+	if len(t) == 0 {
+		return fmt.Errorf("%w; can not unmarshal analyzer", ErrMissingType)
+	}
+	var res Analyzer
+	if handler, ok := AnalyzerTypeHandlers[AnalyzerType(t)]; ok {
+		res = handler()
+	} else {
+		res = &RawAnalyzer{AnalyzerType: AnalyzerType(t)}
+	}
+	err := res.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	*v = res
+	return nil
+}
+
+// RawAnalyzer is an analyzer which is kept as-is. It is used for analyzers which
+// are not registered in AnalyzerTypeHandlers, such as those provided by plugins.
+type RawAnalyzer struct {
+	// The type of the analyzer (Required)
+	AnalyzerType AnalyzerType
+	// The settings of the analyzer, other than type
+	Data dynamic.JSON
+}
+
+func (a RawAnalyzer) Type() AnalyzerType {
+	return a.AnalyzerType
+}
+
+// Validate checks that the type is set
+func (a RawAnalyzer) Validate() error {
+	if len(a.AnalyzerType) == 0 {
+		return ErrMissingType
+	}
+	return nil
+}
+
+func (a RawAnalyzer) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(a.AnalyzerType), a.Data)
+}
+
+func (a *RawAnalyzer) UnmarshalJSON(data []byte) error {
+	a.AnalyzerType = AnalyzerType(gjson.GetBytes(data, "type").String())
+	a.Data = append(dynamic.JSON(nil), data...)
+	return nil
+}
+
+// StandardAnalyzer divides text into terms on word boundaries, as defined by the
+// Unicode Text Segmentation algorithm. It removes most punctuation, lowercases
+// terms, and supports removing stop words. It is the default analyzer.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-standard-analyzer.html
+type StandardAnalyzer struct {
+	// The maximum token length. Tokens which exceed it are split. Defaults to 255.
+	MaxTokenLength int `json:"max_token_length,omitempty"`
+	// A pre-defined stop words list, such as _english_, or an array of stop
+	// words. Defaults to _none_.
+	Stopwords Stopwords `json:"stopwords,omitempty"`
+	// The path to a file containing stop words
+	StopwordsPath string `json:"stopwords_path,omitempty"`
+}
+
+func (StandardAnalyzer) Type() AnalyzerType {
+	return AnalyzerTypeStandard
+}
+
+func (a StandardAnalyzer) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(a.Type()), standardAnalyzer(a))
+}
+
+func (a *StandardAnalyzer) UnmarshalJSON(data []byte) error {
+	var v standardAnalyzer
+	err := unmarshalAnalysisComponent(data, &v)
+	*a = StandardAnalyzer(v)
+	return err
+}
+
+//easyjson:json
+type standardAnalyzer StandardAnalyzer
+
+// SimpleAnalyzer breaks text into tokens at any non-letter character, such as
+// numbers, spaces, hyphens and apostrophes, discards non-letter characters, and
+// changes uppercase to lowercase.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-simple-analyzer.html
+type SimpleAnalyzer struct{}
+
+func (SimpleAnalyzer) Type() AnalyzerType {
+	return AnalyzerTypeSimple
+}
+
+func (a SimpleAnalyzer) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(a.Type()), simpleAnalyzer(a))
+}
+
+func (a *SimpleAnalyzer) UnmarshalJSON(data []byte) error {
+	var v simpleAnalyzer
+	err := unmarshalAnalysisComponent(data, &v)
+	*a = SimpleAnalyzer(v)
+	return err
+}
+
+//easyjson:json
+type simpleAnalyzer SimpleAnalyzer
+
+// WhitespaceAnalyzer breaks text into terms whenever it encounters a whitespace
+// character. It does not lowercase terms.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-whitespace-analyzer.html
+type WhitespaceAnalyzer struct{}
+
+func (WhitespaceAnalyzer) Type() AnalyzerType {
+	return AnalyzerTypeWhitespace
+}
+
+func (a WhitespaceAnalyzer) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(a.Type()), whitespaceAnalyzer(a))
+}
+
+func (a *WhitespaceAnalyzer) UnmarshalJSON(data []byte) error {
+	var v whitespaceAnalyzer
+	err := unmarshalAnalysisComponent(data, &v)
+	*a = WhitespaceAnalyzer(v)
+	return err
+}
+
+//easyjson:json
+type whitespaceAnalyzer WhitespaceAnalyzer
+
+// StopAnalyzer is the same as the SimpleAnalyzer but adds support for removing
+// stop words. It defaults to using the _english_ stop words.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-stop-analyzer.html
+type StopAnalyzer struct {
+	// A pre-defined stop words list, such as _english_, or an array of stop words
+	Stopwords Stopwords `json:"stopwords,omitempty"`
+	// The path to a file containing stop words
+	StopwordsPath string `json:"stopwords_path,omitempty"`
+}
+
+func (StopAnalyzer) Type() AnalyzerType {
+	return AnalyzerTypeStop
+}
+
+func (a StopAnalyzer) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(a.Type()), stopAnalyzer(a))
+}
+
+func (a *StopAnalyzer) UnmarshalJSON(data []byte) error {
+	var v stopAnalyzer
+	err := unmarshalAnalysisComponent(data, &v)
+	*a = StopAnalyzer(v)
+	return err
+}
+
+//easyjson:json
+type stopAnalyzer StopAnalyzer
+
+// KeywordAnalyzer is a "noop" analyzer which returns the entire input string as
+// a single token.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-keyword-analyzer.html
+type KeywordAnalyzer struct{}
+
+func (KeywordAnalyzer) Type() AnalyzerType {
+	return AnalyzerTypeKeyword
+}
+
+func (a KeywordAnalyzer) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(a.Type()), keywordAnalyzer(a))
+}
+
+func (a *KeywordAnalyzer) UnmarshalJSON(data []byte) error {
+	var v keywordAnalyzer
+	err := unmarshalAnalysisComponent(data, &v)
+	*a = KeywordAnalyzer(v)
+	return err
+}
+
+//easyjson:json
+type keywordAnalyzer KeywordAnalyzer
+
+// PatternAnalyzer uses a regular expression to split the text into terms.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-pattern-analyzer.html
+type PatternAnalyzer struct {
+	// A Java regular expression. Defaults to \W+.
+	Pattern string `json:"pattern,omitempty"`
+	// Java regular expression flags, pipe-separated, e.g. "CASE_INSENSITIVE|COMMENTS".
+	Flags string `json:"flags,omitempty"`
+	// Whether terms should be lowercased. Defaults to true.
+	Lowercase *bool `json:"lowercase,omitempty"`
+	// A pre-defined stop words list, such as _english_, or an array of stop words
+	Stopwords Stopwords `json:"stopwords,omitempty"`
+	// The path to a file containing stop words
+	StopwordsPath string `json:"stopwords_path,omitempty"`
+}
+
+func (PatternAnalyzer) Type() AnalyzerType {
+	return AnalyzerTypePattern
+}
+
+func (a PatternAnalyzer) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(a.Type()), patternAnalyzer(a))
+}
+
+func (a *PatternAnalyzer) UnmarshalJSON(data []byte) error {
+	var v patternAnalyzer
+	err := unmarshalAnalysisComponent(data, &v)
+	*a = PatternAnalyzer(v)
+	return err
+}
+
+//easyjson:json
+type patternAnalyzer PatternAnalyzer
+
+// FingerprintAnalyzer lowercases, normalizes, sorts, and deduplicates the input
+// into a single token, which can be used for duplicate detection.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-fingerprint-analyzer.html
+type FingerprintAnalyzer struct {
+	// The character to use to concatenate the terms. Defaults to a space.
+	Separator string `json:"separator,omitempty"`
+	// The maximum token size to emit. Larger tokens are discarded. Defaults to
+	// 255.
+	MaxOutputSize int `json:"max_output_size,omitempty"`
+	// A pre-defined stop words list, such as _english_, or an array of stop words
+	Stopwords Stopwords `json:"stopwords,omitempty"`
+	// The path to a file containing stop words
+	StopwordsPath string `json:"stopwords_path,omitempty"`
+}
+
+func (FingerprintAnalyzer) Type() AnalyzerType {
+	return AnalyzerTypeFingerprint
+}
+
+func (a FingerprintAnalyzer) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(a.Type()), fingerprintAnalyzer(a))
+}
+
+func (a *FingerprintAnalyzer) UnmarshalJSON(data []byte) error {
+	var v fingerprintAnalyzer
+	err := unmarshalAnalysisComponent(data, &v)
+	*a = FingerprintAnalyzer(v)
+	return err
+}
+
+//easyjson:json
+type fingerprintAnalyzer FingerprintAnalyzer
+
+// CustomAnalyzer combines a tokenizer with zero or more token filters and zero
+// or more character filters.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-custom-analyzer.html
+type CustomAnalyzer struct {
+	// A built-in or customised tokenizer (Required)
+	Tokenizer string `json:"tokenizer"`
+	// An optional array of built-in or customised character filters
+	CharFilter []string `json:"char_filter,omitempty"`
+	// An optional array of built-in or customised token filters
+	Filter []string `json:"filter,omitempty"`
+	// The gap inserted between the values of an array of text values.
+	// Defaults to 100.
+	PositionIncrementGap *int `json:"position_increment_gap,omitempty"`
+}
+
+func (CustomAnalyzer) Type() AnalyzerType {
+	return AnalyzerTypeCustom
+}
+
+// Validate checks that the tokenizer is set
+func (a CustomAnalyzer) Validate() error {
+	if len(a.Tokenizer) == 0 {
+		return ErrTokenizerRequired
+	}
+	return nil
+}
+
+func (a CustomAnalyzer) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(a.Type()), customAnalyzer(a))
+}
+
+func (a *CustomAnalyzer) UnmarshalJSON(data []byte) error {
+	var v customAnalyzer
+	err := unmarshalAnalysisComponent(data, &v)
+	*a = CustomAnalyzer(v)
+	return err
+}
+
+//easyjson:json
+type customAnalyzer CustomAnalyzer
+
+// LanguageAnalyzer is one of the analyzers aimed at analyzing text in a
+// specific language, such as english or french.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-lang-analyzer.html
+type LanguageAnalyzer struct {
+	// The language of the analyzer, e.g. AnalyzerTypeEnglish (Required)
+	Language AnalyzerType `json:"-"`
+	// A pre-defined stop words list, such as _english_, or an array of stop words
+	Stopwords Stopwords `json:"stopwords,omitempty"`
+	// The path to a file containing stop words
+	StopwordsPath string `json:"stopwords_path,omitempty"`
+	// Words which should not be stemmed
+	StemExclusion []string `json:"stem_exclusion,omitempty"`
+}
+
+func (a LanguageAnalyzer) Type() AnalyzerType {
+	return a.Language
+}
+
+func (a LanguageAnalyzer) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(a.Type()), languageAnalyzer(a))
+}
+
+func (a *LanguageAnalyzer) UnmarshalJSON(data []byte) error {
+	var v languageAnalyzer
+	err := unmarshalAnalysisComponent(data, &v)
+	*a = LanguageAnalyzer(v)
+	a.Language = AnalyzerType(gjson.GetBytes(data, "type").String())
+	return err
+}
+
+//easyjson:json
+type languageAnalyzer LanguageAnalyzer
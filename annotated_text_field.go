@@ -0,0 +1,204 @@
+package picker
+
+import "encoding/json"
+
+type AnnotatedTextFieldParams struct {
+	// The analyzer which should be used for the field, both at index-time and
+	// at search-time (unless overridden by the search_analyzer). Defaults to
+	// the default index analyzer, or the standard analyzer.
+	Analyzer string `json:"analyzer,omitempty"`
+	// Multi-fields allow the same string value to be indexed in multiple ways
+	// for different purposes, such as one field for search and a multi-field
+	// for sorting and aggregations.
+	Fields FieldMap `json:"fields,omitempty"`
+	// Should the field be searchable? Accepts true (default) or false.
+	Index interface{} `json:"index,omitempty"`
+	// What information should be stored in the index, for search and
+	// highlighting purposes. Defaults to positions
+	IndexOptions IndexOptions `json:"index_options,omitempty"`
+	// Whether field-length should be taken into account when scoring queries.
+	// Accepts true (default) or false.
+	Norms interface{} `json:"norms,omitempty"`
+	// The number of fake term position which should be inserted between each
+	// element of an array of strings. Defaults to the position_increment_gap
+	// configured on the analyzer which defaults to 100.
+	PositionIncrementGap interface{} `json:"position_increment_gap,omitempty"`
+	// Whether the field value should be stored and retrievable separately from
+	// the _source field. Accepts true or false (default).
+	Store interface{} `json:"store,omitempty"`
+	// The analyzer that should be used at search time on the field. Defaults
+	// to the analyzer setting.
+	SearchAnalyzer string `json:"search_analyzer,omitempty"`
+	// The analyzer that should be used at search time when a phrase is
+	// encountered. Defaults to the search_analyzer setting.
+	SearchQuoteAnalyzer string `json:"search_quote_analyzer,omitempty"`
+	// Which scoring algorithm or similarity should be used. Defaults to BM25.
+	Similarity Similarity `json:"similarity,omitempty"`
+	// Whether term vectors should be stored for the field. Defaults to no.
+	TermVector TermVector `json:"term_vector,omitempty"`
+	// Metadata about the field.
+	Meta Meta `json:"meta,omitempty"`
+}
+
+func (AnnotatedTextFieldParams) Type() FieldType {
+	return FieldTypeAnnotatedText
+}
+
+func (p AnnotatedTextFieldParams) Field() (Field, error) {
+	return p.AnnotatedText()
+}
+
+func (p AnnotatedTextFieldParams) AnnotatedText() (*AnnotatedTextField, error) {
+	f := &AnnotatedTextField{}
+	e := &MappingError{}
+	f.SetAnalyzer(p.Analyzer)
+	f.SetSearchAnalyzer(p.SearchAnalyzer)
+	f.SetSearchQuoteAnalyzer(p.SearchQuoteAnalyzer)
+	err := f.SetFields(p.Fields)
+	if err != nil {
+		e.Append(err)
+	}
+	err = f.SetIndex(p.Index)
+	if err != nil {
+		e.Append(err)
+	}
+	err = f.SetIndexOptions(p.IndexOptions)
+	if err != nil {
+		e.Append(err)
+	}
+	err = f.SetMeta(p.Meta)
+	if err != nil {
+		e.Append(err)
+	}
+	err = f.SetNorms(p.Norms)
+	if err != nil {
+		e.Append(err)
+	}
+	err = f.SetPositionIncrementGap(p.PositionIncrementGap)
+	if err != nil {
+		e.Append(err)
+	}
+	err = f.SetSimilarity(p.Similarity)
+	if err != nil {
+		e.Append(err)
+	}
+	err = f.SetStore(p.Store)
+	if err != nil {
+		e.Append(err)
+	}
+	err = f.SetTermVector(p.TermVector)
+	if err != nil {
+		e.Append(err)
+	}
+	return f, e.ErrorOrNil()
+}
+
+func NewAnnotatedTextField(params AnnotatedTextFieldParams) (*AnnotatedTextField, error) {
+	return params.AnnotatedText()
+}
+
+// An AnnotatedTextField indexes text containing special markup, typically used
+// for identifying named entities. Annotations are written in the text in the
+// form of markdown-like links, e.g. "[Beck](Beck) plays guitar", and are
+// injected as additional tokens at the same position as the text they
+// annotate.
+//
+// The field supports the same mapping settings as a text field, with the
+// exception of fielddata, index_prefixes and index_phrases.
+//
+// ! Requires the mapper-annotated-text plugin
+//
+// https://www.elastic.co/guide/en/elasticsearch/plugins/current/mapper-annotated-text.html
+type AnnotatedTextField struct {
+	fieldsParam
+	indexParam
+	indexOptionsParam
+	normsParam
+	positionIncrementGapParam
+	storeParam
+	analyzerParam
+	searchAnalyzerParam
+	searchQuoteAnalyzerParam
+	similarityParam
+	termVectorParam
+	metaParam
+}
+
+func (a *AnnotatedTextField) Field() (Field, error) {
+	return a, nil
+}
+
+func (AnnotatedTextField) Type() FieldType {
+	return FieldTypeAnnotatedText
+}
+
+func (a AnnotatedTextField) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a AnnotatedTextField) MarshalJSON() ([]byte, error) {
+	return json.Marshal(annotatedTextField{
+		Analyzer:             a.analyzer,
+		Fields:               a.fields,
+		Index:                a.index.Value(),
+		IndexOptions:         a.indexOptions,
+		Norms:                a.norms.Value(),
+		PositionIncrementGap: a.positionIncrementGap.Value(),
+		Store:                a.store.Value(),
+		SearchAnalyzer:       a.searchAnalyzer,
+		SearchQuoteAnalyzer:  a.searchQuoteAnalyzer,
+		Similarity:           a.similarity,
+		TermVector:           a.termVector,
+		Meta:                 a.meta,
+		Type:                 a.Type(),
+	})
+}
+
+func (a *AnnotatedTextField) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *AnnotatedTextField) UnmarshalJSON(data []byte) error {
+	// multi-fields are decoded as Fields, which unmarshals each field by
+	// its type, rather than the FieldMap of the params
+	var p annotatedTextField
+	err := json.Unmarshal(data, &p)
+	if err != nil {
+		return err
+	}
+	n, err := AnnotatedTextFieldParams{
+		Analyzer:             p.Analyzer,
+		Index:                p.Index,
+		IndexOptions:         p.IndexOptions,
+		Norms:                p.Norms,
+		PositionIncrementGap: p.PositionIncrementGap,
+		Store:                p.Store,
+		SearchAnalyzer:       p.SearchAnalyzer,
+		SearchQuoteAnalyzer:  p.SearchQuoteAnalyzer,
+		Similarity:           p.Similarity,
+		TermVector:           p.TermVector,
+		Meta:                 p.Meta,
+	}.AnnotatedText()
+	if err != nil {
+		return err
+	}
+	err = n.SetFields(p.Fields)
+	*a = *n
+	return err
+}
+
+type annotatedTextField struct {
+	Analyzer             string       `json:"analyzer,omitempty"`
+	Fields               Fields       `json:"fields,omitempty"`
+	Index                interface{}  `json:"index,omitempty"`
+	IndexOptions         IndexOptions `json:"index_options,omitempty"`
+	Norms                interface{}  `json:"norms,omitempty"`
+	PositionIncrementGap interface{}  `json:"position_increment_gap,omitempty"`
+	Store                interface{}  `json:"store,omitempty"`
+	SearchAnalyzer       string       `json:"search_analyzer,omitempty"`
+	SearchQuoteAnalyzer  string       `json:"search_quote_analyzer,omitempty"`
+	Similarity           Similarity   `json:"similarity,omitempty"`
+	TermVector           TermVector   `json:"term_vector,omitempty"`
+	Meta                 Meta         `json:"meta,omitempty"`
+	Type                 FieldType    `json:"type"`
+}
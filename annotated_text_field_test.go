@@ -2,28 +2,41 @@ package picker_test
 
 import (
 	"testing"
+
+	"github.com/chanced/cmpjson"
+	"github.com/chanced/picker"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAnnotatedTextField(t *testing.T) {
-	// assert := require.New(t)
-	// data := []byte(`{
-	// 	"mappings": {
-	// 	  "properties": {
-	// 		"my_field": {
-	// 		  "type": "annotated_text"
-	// 		}
-	// 	  }
-	// 	}
-	//   }`)
-	// i, err := picker.NewIndex(picker.IndexParams{Mappings: picker.Mappings{
-	// 	Properties: picker.FieldMap{"my_field": picker.AnnotatedTextParams{}},
-	// }})
-	// assert.NoError(err)
-	// ixd, err := i.MarshalJSON()
-	// assert.NoError(err)
-	// assert.True(cmpjson.Equal(data, ixd), cmpjson.Diff(data, ixd))
-	// i2 := picker.Index{}
-	// err = i2.UnmarshalJSON(data)
-	// assert.NoError(err)
-
+	assert := require.New(t)
+	data := []byte(`{
+		"mappings": {
+		  "properties": {
+			"my_field": {
+			  "type": "annotated_text",
+			  "analyzer": "standard",
+			  "fields": {
+				"raw": { "type": "keyword" }
+			  }
+			}
+		  }
+		}
+	  }`)
+	i, err := picker.NewIndex(picker.IndexParams{Mappings: picker.Mappings{
+		Properties: picker.FieldMap{"my_field": picker.AnnotatedTextFieldParams{
+			Analyzer: "standard",
+			Fields:   picker.FieldMap{"raw": picker.KeywordFieldParams{}},
+		}},
+	}})
+	assert.NoError(err)
+	ixd, err := i.MarshalJSON()
+	assert.NoError(err)
+	assert.True(cmpjson.Equal(data, ixd), cmpjson.Diff(data, ixd))
+	i2 := picker.Index{}
+	err = i2.UnmarshalJSON(data)
+	assert.NoError(err)
+	f := i2.Mappings.Properties["my_field"].(*picker.AnnotatedTextField)
+	assert.Equal("standard", f.Analyzer())
+	assert.Equal(picker.FieldTypeKeyword, f.Fields()["raw"].Type())
 }
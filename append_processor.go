@@ -0,0 +1,188 @@
+package picker
+
+import "github.com/chanced/dynamic"
+
+// AppendProcessorParams creates a AppendProcessor, which appends one or more
+// values to an existing array if the field already exists and it is an array.
+// Converts a scalar to an array and appends one or more values to it if the
+// field exists and it is a scalar. Creates an array containing the provided
+// values if the field doesn't exist.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/append-processor.html
+type AppendProcessorParams struct {
+	// Field is the field to be appended to. Supports template snippets.
+	Field string
+	// Value is the value to be appended. Supports template snippets.
+	Value interface{}
+	// AllowDuplicates indicates whether values already present in the field are
+	// appended. Defaults to true.
+	AllowDuplicates interface{}
+	// MediaType is the media type for encoding value. Applies only when value
+	// is a template snippet. Defaults to "application/json".
+	MediaType string
+	// Description of the processor. Useful for describing the purpose of the
+	// processor or its configuration.
+	Description string
+	// Conditionally execute the processor
+	If string
+	// Ignore failures for the processor
+	IgnoreFailure bool
+	// Handle failures for the processor
+	OnFailure Procs
+	// Identifier for the processor. Useful for debugging and metrics.
+	Tag string
+}
+
+func (AppendProcessorParams) Kind() ProcessorKind {
+	return ProcessorKindAppend
+}
+
+func (p AppendProcessorParams) Processor() (Processor, error) {
+	return p.Append()
+}
+
+func (p AppendProcessorParams) Append() (*AppendProcessor, error) {
+	proc := &AppendProcessor{}
+	err := proc.SetField(p.Field)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindAppend)
+	}
+	err = proc.SetValue(p.Value)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindAppend)
+	}
+	err = proc.SetAllowDuplicates(p.AllowDuplicates)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindAppend)
+	}
+	proc.SetMediaType(p.MediaType)
+	proc.SetDescription(p.Description)
+	proc.SetIf(p.If)
+	proc.SetIgnoreFailure(p.IgnoreFailure)
+	err = proc.SetOnFailure(p.OnFailure)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindAppend)
+	}
+	proc.SetTag(p.Tag)
+	return proc, nil
+}
+
+// AppendProcessor appends one or more values to an existing array if the field
+// already exists and it is an array. Converts a scalar to an array and appends
+// one or more values to it if the field exists and it is a scalar. Creates an
+// array containing the provided values if the field doesn't exist.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/append-processor.html
+type AppendProcessor struct {
+	fieldParam
+	value           interface{}
+	allowDuplicates dynamic.Bool
+	mediaType       string
+	processorParams
+}
+
+var _ Processor = (*AppendProcessor)(nil)
+
+func (AppendProcessor) Kind() ProcessorKind {
+	return ProcessorKindAppend
+}
+
+func (p *AppendProcessor) Processor() (Processor, error) {
+	return p, nil
+}
+
+// Value is the value to be appended. Supports template snippets.
+func (p AppendProcessor) Value() interface{} {
+	return p.value
+}
+
+// SetValue sets value to v
+func (p *AppendProcessor) SetValue(v interface{}) error {
+	if v == nil {
+		return ErrValueRequired
+	}
+	p.value = v
+	return nil
+}
+
+// AllowDuplicates indicates whether values already present in the field are
+// appended. Defaults to true.
+func (p AppendProcessor) AllowDuplicates() bool {
+	if v, ok := p.allowDuplicates.Bool(); ok {
+		return v
+	}
+	return true
+}
+
+// SetAllowDuplicates sets allow_duplicates to v
+func (p *AppendProcessor) SetAllowDuplicates(v interface{}) error {
+	return p.allowDuplicates.Set(v)
+}
+
+// MediaType is the media type for encoding value. Applies only when value is a
+// template snippet. Defaults to "application/json".
+func (p AppendProcessor) MediaType() string {
+	return p.mediaType
+}
+
+// SetMediaType sets media_type to v
+func (p *AppendProcessor) SetMediaType(v string) {
+	p.mediaType = v
+}
+
+func (p AppendProcessor) MarshalBSON() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+func (p AppendProcessor) MarshalJSON() ([]byte, error) {
+	return appendProcessor{
+		Field:           p.field,
+		Value:           p.value,
+		AllowDuplicates: p.allowDuplicates.Value(),
+		MediaType:       p.mediaType,
+		Description:     p.description,
+		If:              p.ifCond,
+		IgnoreFailure:   p.ignoreFailure,
+		OnFailure:       p.onFailure,
+		Tag:             p.tag,
+	}.MarshalJSON()
+}
+
+func (p *AppendProcessor) UnmarshalBSON(data []byte) error {
+	return p.UnmarshalJSON(data)
+}
+
+func (p *AppendProcessor) UnmarshalJSON(data []byte) error {
+	*p = AppendProcessor{}
+	var v appendProcessor
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	p.field = v.Field
+	p.value = v.Value
+	err = p.allowDuplicates.Set(v.AllowDuplicates)
+	if err != nil {
+		return err
+	}
+	p.mediaType = v.MediaType
+	p.description = v.Description
+	p.ifCond = v.If
+	p.ignoreFailure = v.IgnoreFailure
+	p.onFailure = v.OnFailure
+	p.tag = v.Tag
+	return nil
+}
+
+//easyjson:json
+type appendProcessor struct {
+	Field           string      `json:"field,omitempty"`
+	Value           interface{} `json:"value"`
+	AllowDuplicates interface{} `json:"allow_duplicates,omitempty"`
+	MediaType       string      `json:"media_type,omitempty"`
+	Description     string      `json:"description,omitempty"`
+	If              string      `json:"if,omitempty"`
+	IgnoreFailure   bool        `json:"ignore_failure,omitempty"`
+	OnFailure       Processors  `json:"on_failure,omitempty"`
+	Tag             string      `json:"tag,omitempty"`
+}
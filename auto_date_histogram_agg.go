@@ -0,0 +1,162 @@
+package picker
+
+const DefaultAutoDateHistogramBuckets = 10
+
+// AutoDateHistogramAggParams creates an AutoDateHistogramAgg, a multi-bucket
+// aggregation similar to the date histogram except instead of providing an
+// interval to use as the width of each bucket, a target number of buckets is
+// provided indicating the number of buckets needed and the interval of the
+// buckets is automatically chosen to best achieve that target.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-autodatehistogram-aggregation.html
+type AutoDateHistogramAggParams struct {
+	// The field to aggregate on. Either Field or Script is required.
+	Field   string
+	Script  *Script
+	Missing interface{}
+	Format  string
+	// The target number of buckets. Defaults to 10.
+	Buckets int
+	// Time zone used for bucketing and rounding. Defaults to UTC.
+	TimeZone string
+	// The minimum rounding interval that should be used. Accepted units are
+	// year, month, day, hour, minute, second.
+	MinimumInterval string
+	// Sub-aggregations
+	Aggregations Aggs
+	Meta         map[string]interface{}
+}
+
+func (AutoDateHistogramAggParams) Kind() AggKind {
+	return AggKindAutoIntervalDateHistogram
+}
+
+func (p AutoDateHistogramAggParams) Clause() (AggClause, error) {
+	return p.AutoDateHistogram()
+}
+
+func (p AutoDateHistogramAggParams) AutoDateHistogram() (*AutoDateHistogramAgg, error) {
+	a := &AutoDateHistogramAgg{}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	err := a.checkValueSource()
+	if err != nil {
+		return a, newAggError(err, AggKindAutoIntervalDateHistogram)
+	}
+	a.SetMissing(p.Missing)
+	a.SetFormat(p.Format)
+	a.SetBuckets(p.Buckets)
+	a.SetTimeZone(p.TimeZone)
+	a.SetMinimumInterval(p.MinimumInterval)
+	err = a.SetAggregations(p.Aggregations)
+	if err != nil {
+		return a, newAggError(err, AggKindAutoIntervalDateHistogram)
+	}
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// AutoDateHistogramAgg is a multi-bucket aggregation similar to the date
+// histogram except instead of providing an interval to use as the width of
+// each bucket, a target number of buckets is provided.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-autodatehistogram-aggregation.html
+type AutoDateHistogramAgg struct {
+	valueSourceParam
+	buckets         int
+	timeZone        string
+	minimumInterval string
+	aggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*AutoDateHistogramAgg)(nil)
+
+func (AutoDateHistogramAgg) Kind() AggKind {
+	return AggKindAutoIntervalDateHistogram
+}
+
+func (a *AutoDateHistogramAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// Buckets is the target number of buckets. Defaults to 10.
+func (a AutoDateHistogramAgg) Buckets() int {
+	if a.buckets == 0 {
+		return DefaultAutoDateHistogramBuckets
+	}
+	return a.buckets
+}
+
+// SetBuckets sets the target number of buckets to v
+func (a *AutoDateHistogramAgg) SetBuckets(v int) {
+	a.buckets = v
+}
+
+// TimeZone used for bucketing and rounding. Defaults to UTC.
+func (a AutoDateHistogramAgg) TimeZone() string {
+	return a.timeZone
+}
+
+// SetTimeZone sets time_zone to v
+func (a *AutoDateHistogramAgg) SetTimeZone(v string) {
+	a.timeZone = v
+}
+
+// MinimumInterval is the minimum rounding interval that should be used.
+func (a AutoDateHistogramAgg) MinimumInterval() string {
+	return a.minimumInterval
+}
+
+// SetMinimumInterval sets minimum_interval to v
+func (a *AutoDateHistogramAgg) SetMinimumInterval(v string) {
+	a.minimumInterval = v
+}
+
+func (a AutoDateHistogramAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a AutoDateHistogramAgg) MarshalJSON() ([]byte, error) {
+	return autoDateHistogramAgg{
+		Field:           a.field,
+		Script:          a.script,
+		Missing:         a.missing,
+		Format:          a.format,
+		Buckets:         a.buckets,
+		TimeZone:        a.timeZone,
+		MinimumInterval: a.minimumInterval,
+	}.MarshalJSON()
+}
+
+func (a *AutoDateHistogramAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *AutoDateHistogramAgg) UnmarshalJSON(data []byte) error {
+	*a = AutoDateHistogramAgg{}
+	p := autoDateHistogramAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	a.SetMissing(p.Missing)
+	a.SetFormat(p.Format)
+	a.SetBuckets(p.Buckets)
+	a.SetTimeZone(p.TimeZone)
+	a.SetMinimumInterval(p.MinimumInterval)
+	return nil
+}
+
+//easyjson:json
+type autoDateHistogramAgg struct {
+	Field           string      `json:"field,omitempty"`
+	Script          *Script     `json:"script,omitempty"`
+	Missing         interface{} `json:"missing,omitempty"`
+	Format          string      `json:"format,omitempty"`
+	Buckets         int         `json:"buckets,omitempty"`
+	TimeZone        string      `json:"time_zone,omitempty"`
+	MinimumInterval string      `json:"minimum_interval,omitempty"`
+}
@@ -0,0 +1,106 @@
+package picker
+
+// AvgAggParams creates a AvgAgg, a single-value metrics aggregation that
+// computes the average of numeric values that are extracted from the aggregated
+// documents.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-avg-aggregation.html
+type AvgAggParams struct {
+	// The field to aggregate on. Either Field or Script is required.
+	Field string
+	// Script to generate values with. If Field is also set, Script is a value
+	// script.
+	Script *Script
+	// Missing is the value used for documents which do not have a value
+	Missing interface{}
+	// Hint as to the type of value produced by Script
+	ValueType string
+	// Format of the value_as_string in the response
+	Format string
+	Meta   map[string]interface{}
+}
+
+func (AvgAggParams) Kind() AggKind {
+	return AggKindAvg
+}
+
+func (p AvgAggParams) Clause() (AggClause, error) {
+	return p.Avg()
+}
+
+func (p AvgAggParams) Avg() (*AvgAgg, error) {
+	a := &AvgAgg{}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	err := a.checkValueSource()
+	if err != nil {
+		return a, newAggError(err, AggKindAvg)
+	}
+	a.SetMissing(p.Missing)
+	a.SetValueType(p.ValueType)
+	a.SetFormat(p.Format)
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// AvgAgg is a single-value metrics aggregation that computes the average of
+// numeric values that are extracted from the aggregated documents.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-avg-aggregation.html
+type AvgAgg struct {
+	valueSourceParam
+	noAggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*AvgAgg)(nil)
+
+func (AvgAgg) Kind() AggKind {
+	return AggKindAvg
+}
+
+func (a *AvgAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+func (a AvgAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a AvgAgg) MarshalJSON() ([]byte, error) {
+	return avgAgg{
+		Field:     a.field,
+		Script:    a.script,
+		Missing:   a.missing,
+		ValueType: a.valueType,
+		Format:    a.format,
+	}.MarshalJSON()
+}
+
+func (a *AvgAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *AvgAgg) UnmarshalJSON(data []byte) error {
+	*a = AvgAgg{}
+	p := avgAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	a.SetMissing(p.Missing)
+	a.SetValueType(p.ValueType)
+	a.SetFormat(p.Format)
+	return nil
+}
+
+//easyjson:json
+type avgAgg struct {
+	Field     string      `json:"field,omitempty"`
+	Script    *Script     `json:"script,omitempty"`
+	Missing   interface{} `json:"missing,omitempty"`
+	ValueType string      `json:"value_type,omitempty"`
+	Format    string      `json:"format,omitempty"`
+}
@@ -0,0 +1,98 @@
+package picker
+
+// AvgBucketAggParams creates a AvgBucketAgg, a sibling pipeline aggregation
+// which calculates the mean value of a specified metric in a sibling
+// aggregation. The specified metric must be numeric and the sibling aggregation
+// must be a multi-bucket aggregation.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-avg-bucket-aggregation.html
+type AvgBucketAggParams struct {
+	// Path to the buckets to aggregate (Required)
+	BucketsPath string
+	// Policy to apply when gaps are found in the data. Defaults to "skip".
+	GapPolicy GapPolicy
+	// Format of the value_as_string in the response
+	Format string
+	Meta   map[string]interface{}
+}
+
+func (AvgBucketAggParams) Kind() AggKind {
+	return AggKindAverageBucket
+}
+
+func (p AvgBucketAggParams) Clause() (AggClause, error) {
+	return p.AvgBucket()
+}
+
+func (p AvgBucketAggParams) AvgBucket() (*AvgBucketAgg, error) {
+	a := &AvgBucketAgg{}
+	err := a.SetBucketsPath(p.BucketsPath)
+	if err != nil {
+		return a, newAggError(err, AggKindAverageBucket)
+	}
+	err = a.SetGapPolicy(p.GapPolicy)
+	if err != nil {
+		return a, newAggError(err, AggKindAverageBucket)
+	}
+	a.SetFormat(p.Format)
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// AvgBucketAgg is a sibling pipeline aggregation which calculates the mean
+// value of a specified metric in a sibling aggregation.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-avg-bucket-aggregation.html
+type AvgBucketAgg struct {
+	bucketsPathParam
+	gapPolicyParam
+	pipelineFormatParam
+	noAggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*AvgBucketAgg)(nil)
+
+func (AvgBucketAgg) Kind() AggKind {
+	return AggKindAverageBucket
+}
+
+func (a *AvgBucketAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+func (a AvgBucketAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a AvgBucketAgg) MarshalJSON() ([]byte, error) {
+	return avgBucketAgg{
+		BucketsPath: a.bucketsPath,
+		GapPolicy:   a.gapPolicy,
+		Format:      a.format,
+	}.MarshalJSON()
+}
+
+func (a *AvgBucketAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *AvgBucketAgg) UnmarshalJSON(data []byte) error {
+	*a = AvgBucketAgg{}
+	p := avgBucketAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.bucketsPath = p.BucketsPath
+	a.gapPolicy = p.GapPolicy
+	a.SetFormat(p.Format)
+	return nil
+}
+
+//easyjson:json
+type avgBucketAgg struct {
+	BucketsPath string    `json:"buckets_path"`
+	GapPolicy   GapPolicy `json:"gap_policy,omitempty"`
+	Format      string    `json:"format,omitempty"`
+}
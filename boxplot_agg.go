@@ -0,0 +1,118 @@
+package picker
+
+const DefaultBoxplotCompression = 100
+
+// BoxplotAggParams creates a BoxplotAgg, a boxplot metrics aggregation that
+// computes boxplot of numeric values extracted from the aggregated documents.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-boxplot-aggregation.html
+type BoxplotAggParams struct {
+	// The field to aggregate on. Either Field or Script is required.
+	Field string
+	// Script to generate values with. If Field is also set, Script is a value
+	// script.
+	Script *Script
+	// Missing is the value used for documents which do not have a value
+	Missing interface{}
+	// The tradeoff between resource usage and accuracy of the TDigest. Defaults
+	// to 100.
+	Compression float64
+	Meta        map[string]interface{}
+}
+
+func (BoxplotAggParams) Kind() AggKind {
+	return AggKindBoxplot
+}
+
+func (p BoxplotAggParams) Clause() (AggClause, error) {
+	return p.Boxplot()
+}
+
+func (p BoxplotAggParams) Boxplot() (*BoxplotAgg, error) {
+	a := &BoxplotAgg{}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	err := a.checkValueSource()
+	if err != nil {
+		return a, newAggError(err, AggKindBoxplot)
+	}
+	a.SetMissing(p.Missing)
+	a.SetCompression(p.Compression)
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// BoxplotAgg is a metrics aggregation that computes the min, max, median, first
+// quartile and third quartile of numeric values extracted from the aggregated
+// documents.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-boxplot-aggregation.html
+type BoxplotAgg struct {
+	valueSourceParam
+	compression float64
+	noAggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*BoxplotAgg)(nil)
+
+func (BoxplotAgg) Kind() AggKind {
+	return AggKindBoxplot
+}
+
+func (a *BoxplotAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// Compression is the tradeoff between resource usage and accuracy. Defaults to
+// 100.
+func (a BoxplotAgg) Compression() float64 {
+	if a.compression == 0 {
+		return DefaultBoxplotCompression
+	}
+	return a.compression
+}
+
+// SetCompression sets compression to v
+func (a *BoxplotAgg) SetCompression(v float64) {
+	a.compression = v
+}
+
+func (a BoxplotAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a BoxplotAgg) MarshalJSON() ([]byte, error) {
+	return boxplotAgg{
+		Field:       a.field,
+		Script:      a.script,
+		Missing:     a.missing,
+		Compression: a.compression,
+	}.MarshalJSON()
+}
+
+func (a *BoxplotAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *BoxplotAgg) UnmarshalJSON(data []byte) error {
+	*a = BoxplotAgg{}
+	p := boxplotAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	a.SetMissing(p.Missing)
+	a.compression = p.Compression
+	return nil
+}
+
+//easyjson:json
+type boxplotAgg struct {
+	Field       string      `json:"field,omitempty"`
+	Script      *Script     `json:"script,omitempty"`
+	Missing     interface{} `json:"missing,omitempty"`
+	Compression float64     `json:"compression,omitempty"`
+}
@@ -0,0 +1,256 @@
+package picker
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/chanced/dynamic"
+)
+
+// Bucket is a bucket of a multi-bucket aggregation result
+type Bucket struct {
+	// Key of the bucket. Depending on the aggregation, Key is either a
+	// string, a json.Number, a []interface{} for multi_terms, or a
+	// map[string]interface{} for composite. Numbers are json.Number so that
+	// long and unsigned_long keys keep their precision.
+	//
+	// For keyed results, such as named filters, Key is the bucket's name.
+	Key         interface{}
+	KeyAsString string
+	DocCount    int64
+	// DocCountErrorUpperBound is the upper bound of the error on the
+	// doc_count of a terms bucket, if requested with show_term_doc_count_error
+	DocCountErrorUpperBound int64
+	// Score and BgCount are set for significant_terms and significant_text
+	Score   float64
+	BgCount int64
+	// From and To are set for range, date_range, ip_range and geo_distance
+	From         interface{}
+	FromAsString string
+	To           interface{}
+	ToAsString   string
+	// Aggregations are the results of the sub-aggregations of the bucket
+	Aggregations AggResults
+}
+
+func (b *Bucket) decode(agg AggClause, data dynamic.JSON) error {
+	p := bucket{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	b.Key = nil
+	if len(p.Key) > 0 && !p.Key.IsNull() {
+		err = unmarshalUseNumber(p.Key, &b.Key)
+		if err != nil {
+			return err
+		}
+	}
+	b.KeyAsString = p.KeyAsString
+	b.DocCount = p.DocCount
+	b.DocCountErrorUpperBound = p.DocCountErrorUpperBound
+	b.Score = p.Score
+	b.BgCount = p.BgCount
+	b.From = p.From
+	b.FromAsString = p.FromAsString
+	b.To = p.To
+	b.ToAsString = p.ToAsString
+	b.Aggregations, err = decodeSubAggResults(agg.Aggregations(), data, bucketKeys)
+	return err
+}
+
+// bucketKeys are the keys of the fields of a bucket
+var bucketKeys = []string{
+	"key", "key_as_string", "doc_count", "doc_count_error_upper_bound", "score",
+	"bg_count", "from", "from_as_string", "to", "to_as_string",
+}
+
+// singleBucketKeys are the keys of the fields of a single bucket aggregation
+// result
+var singleBucketKeys = []string{"doc_count", "meta"}
+
+// decodeSubAggResults decodes the results of the sub-aggregations aggs from
+// data, the object of a bucket. The keys of the fields of the bucket are
+// excluded so that they are not mistaken for the result of a sub-aggregation
+// which shares their name.
+func decodeSubAggResults(aggs Aggregations, data dynamic.JSON, keys []string) (AggResults, error) {
+	if len(aggs) == 0 {
+		return AggResults{}, nil
+	}
+	var obj dynamic.JSONObject
+	err := json.Unmarshal(data, &obj)
+	if err != nil {
+		return AggResults{}, err
+	}
+	for _, k := range keys {
+		delete(obj, k)
+	}
+	return decodeAggResultsObject(aggs, obj)
+}
+
+//easyjson:json
+type bucket struct {
+	Key                     dynamic.JSON `json:"key"`
+	KeyAsString             string       `json:"key_as_string"`
+	DocCount                int64        `json:"doc_count"`
+	DocCountErrorUpperBound int64        `json:"doc_count_error_upper_bound"`
+	Score                   float64      `json:"score"`
+	BgCount                 int64        `json:"bg_count"`
+	From                    interface{}  `json:"from"`
+	FromAsString            string       `json:"from_as_string"`
+	To                      interface{}  `json:"to"`
+	ToAsString              string       `json:"to_as_string"`
+}
+
+// MultiBucketAggResult is the result of a multi-bucket aggregation, such as
+// terms, histogram, range, or filters.
+type MultiBucketAggResult struct {
+	Buckets []Bucket
+	// Keyed is true if the buckets of the response were keyed by name rather
+	// than returned as an array. The buckets of keyed results retain the order
+	// of the response.
+	Keyed bool
+	// DocCountErrorUpperBound is an upper bound of the error on the document
+	// counts for each term of a terms aggregation
+	DocCountErrorUpperBound int64
+	// SumOtherDocCount is the sum of the document counts of all buckets that
+	// are not part of a terms response
+	SumOtherDocCount int64
+	// Interval is the interval chosen by an auto_date_histogram
+	Interval string
+	// AfterKey is the composite key of the last bucket of a composite
+	// response, used to request the next page. Numeric values are
+	// json.Number so that long keys keep their precision.
+	AfterKey map[string]interface{}
+	Meta     map[string]interface{}
+	kind     AggKind
+}
+
+func (r MultiBucketAggResult) Kind() AggKind {
+	return r.kind
+}
+
+// Bucket returns the first bucket with a key or key_as_string equal to key,
+// or nil if none exists. Numeric keys are compared by their JSON text.
+func (r *MultiBucketAggResult) Bucket(key string) *Bucket {
+	for i, b := range r.Buckets {
+		if b.KeyAsString == key {
+			return &r.Buckets[i]
+		}
+		switch k := b.Key.(type) {
+		case string:
+			if k == key {
+				return &r.Buckets[i]
+			}
+		case json.Number:
+			if string(k) == key {
+				return &r.Buckets[i]
+			}
+		}
+	}
+	return nil
+}
+
+func (r *MultiBucketAggResult) decodeAggResult(agg AggClause, data dynamic.JSON) error {
+	r.kind = agg.Kind()
+	p := multiBucketAggResult{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	r.DocCountErrorUpperBound = p.DocCountErrorUpperBound
+	r.SumOtherDocCount = p.SumOtherDocCount
+	r.Interval = p.Interval
+	r.Meta = p.Meta
+	r.AfterKey = nil
+	if len(p.AfterKey) > 0 && !p.AfterKey.IsNull() {
+		err = unmarshalUseNumber(p.AfterKey, &r.AfterKey)
+		if err != nil {
+			return err
+		}
+	}
+	switch {
+	case p.Buckets.IsArray():
+		var buckets []dynamic.JSON
+		err = json.Unmarshal(p.Buckets, &buckets)
+		if err != nil {
+			return err
+		}
+		r.Buckets = make([]Bucket, len(buckets))
+		for i, d := range buckets {
+			err = r.Buckets[i].decode(agg, d)
+			if err != nil {
+				return err
+			}
+		}
+	case p.Buckets.IsObject():
+		keys, buckets, err := unmarshalOrderedObject(p.Buckets)
+		if err != nil {
+			return err
+		}
+		r.Keyed = true
+		r.Buckets = make([]Bucket, len(keys))
+		for i, k := range keys {
+			b := &r.Buckets[i]
+			err = b.decode(agg, buckets[k])
+			if err != nil {
+				return err
+			}
+			if b.Key == nil {
+				b.Key = k
+			}
+		}
+	}
+	return nil
+}
+
+// unmarshalUseNumber unmarshals data into v, decoding numbers as json.Number
+// rather than float64
+func unmarshalUseNumber(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+//easyjson:json
+type multiBucketAggResult struct {
+	Buckets                 dynamic.JSON           `json:"buckets"`
+	DocCountErrorUpperBound int64                  `json:"doc_count_error_upper_bound"`
+	SumOtherDocCount        int64                  `json:"sum_other_doc_count"`
+	Interval                string                 `json:"interval"`
+	AfterKey                dynamic.JSON           `json:"after_key"`
+	Meta                    map[string]interface{} `json:"meta"`
+}
+
+// SingleBucketAggResult is the result of a single bucket aggregation, such as
+// filter, global, or nested.
+type SingleBucketAggResult struct {
+	DocCount int64
+	// Aggregations are the results of the sub-aggregations
+	Aggregations AggResults
+	Meta         map[string]interface{}
+	kind         AggKind
+}
+
+func (r SingleBucketAggResult) Kind() AggKind {
+	return r.kind
+}
+
+func (r *SingleBucketAggResult) decodeAggResult(agg AggClause, data dynamic.JSON) error {
+	r.kind = agg.Kind()
+	p := singleBucketAggResult{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	r.DocCount = p.DocCount
+	r.Meta = p.Meta
+	r.Aggregations, err = decodeSubAggResults(agg.Aggregations(), data, singleBucketKeys)
+	return err
+}
+
+//easyjson:json
+type singleBucketAggResult struct {
+	DocCount int64                  `json:"doc_count"`
+	Meta     map[string]interface{} `json:"meta"`
+}
@@ -0,0 +1,93 @@
+package picker
+
+import (
+	"encoding/json"
+
+	"github.com/chanced/dynamic"
+)
+
+const (
+	// BucketOrderKeyCount orders buckets by their doc_count
+	BucketOrderKeyCount = "_count"
+	// BucketOrderKeyKey orders buckets by their key
+	BucketOrderKeyKey = "_key"
+)
+
+// BucketOrderEntry orders buckets by Key, which can be "_count", "_key", or
+// the path to a single-value metric sub-aggregation (e.g. "sales>avg_price" or
+// "stats.max")
+type BucketOrderEntry struct {
+	Key   string
+	Order SortOrder
+}
+
+// BucketOrder is the order of buckets returned by multi-bucket aggregations
+// such as terms and histogram. When more than one entry is present, ties are
+// broken by subsequent entries.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-terms-aggregation.html#search-aggregations-bucket-terms-aggregation-order
+type BucketOrder []BucketOrderEntry
+
+func (o BucketOrder) Validate() error {
+	for _, e := range o {
+		if len(e.Key) == 0 {
+			return ErrFieldRequired
+		}
+		if !e.Order.IsValid() {
+			return ErrInvalidSortOrder
+		}
+	}
+	return nil
+}
+
+func (o BucketOrder) MarshalBSON() ([]byte, error) {
+	return o.MarshalJSON()
+}
+
+// MarshalJSON marshals o into an object if o has a single entry, otherwise an
+// array of objects.
+func (o BucketOrder) MarshalJSON() ([]byte, error) {
+	if len(o) == 0 {
+		return dynamic.Null, nil
+	}
+	res := make([]map[string]SortOrder, len(o))
+	for i, e := range o {
+		res[i] = map[string]SortOrder{e.Key: e.Order}
+	}
+	if len(res) == 1 {
+		return json.Marshal(res[0])
+	}
+	return json.Marshal(res)
+}
+
+func (o *BucketOrder) UnmarshalBSON(data []byte) error {
+	return o.UnmarshalJSON(data)
+}
+
+func (o *BucketOrder) UnmarshalJSON(data []byte) error {
+	*o = BucketOrder{}
+	d := dynamic.JSON(data)
+	if len(d) == 0 || d.IsNull() {
+		return nil
+	}
+	var entries []dynamic.JSON
+	if d.IsArray() {
+		err := json.Unmarshal(d, &entries)
+		if err != nil {
+			return err
+		}
+	} else {
+		entries = []dynamic.JSON{d}
+	}
+	for _, ed := range entries {
+		var m map[string]SortOrder
+		err := json.Unmarshal(ed, &m)
+		if err != nil {
+			return err
+		}
+		for k, v := range m {
+			*o = append(*o, BucketOrderEntry{Key: k, Order: v})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package picker
+
+// BucketScriptAggParams creates a BucketScriptAgg, a parent pipeline
+// aggregation which executes a script which can perform per bucket computations
+// on specified metrics in the parent multi-bucket aggregation. The specified
+// metric must be numeric and the script must return a numeric value.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-bucket-script-aggregation.html
+type BucketScriptAggParams struct {
+	// Map of script variables to the buckets_path of the metric they reference
+	// (Required)
+	BucketsPath map[string]string
+	// The script to run for this aggregation. Variables of BucketsPath are
+	// available as params. (Required)
+	Script *Script
+	// Policy to apply when gaps are found in the data. Defaults to "skip".
+	GapPolicy GapPolicy
+	// Format of the value_as_string in the response
+	Format string
+	Meta   map[string]interface{}
+}
+
+func (BucketScriptAggParams) Kind() AggKind {
+	return AggKindBucketScript
+}
+
+func (p BucketScriptAggParams) Clause() (AggClause, error) {
+	return p.BucketScript()
+}
+
+func (p BucketScriptAggParams) BucketScript() (*BucketScriptAgg, error) {
+	a := &BucketScriptAgg{}
+	err := a.SetBucketsPath(p.BucketsPath)
+	if err != nil {
+		return a, newAggError(err, AggKindBucketScript)
+	}
+	err = a.SetScript(p.Script)
+	if err != nil {
+		return a, newAggError(err, AggKindBucketScript)
+	}
+	err = a.SetGapPolicy(p.GapPolicy)
+	if err != nil {
+		return a, newAggError(err, AggKindBucketScript)
+	}
+	a.SetFormat(p.Format)
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// BucketScriptAgg is a parent pipeline aggregation which executes a script
+// which can perform per bucket computations on specified metrics in the parent
+// multi-bucket aggregation.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-bucket-script-aggregation.html
+type BucketScriptAgg struct {
+	bucketsPathsParam
+	gapPolicyParam
+	pipelineFormatParam
+	script *Script
+	noAggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*BucketScriptAgg)(nil)
+
+func (BucketScriptAgg) Kind() AggKind {
+	return AggKindBucketScript
+}
+
+func (a *BucketScriptAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// Script is the script to run for this aggregation
+func (a BucketScriptAgg) Script() *Script {
+	return a.script
+}
+
+// SetScript sets script to v
+func (a *BucketScriptAgg) SetScript(v *Script) error {
+	if v == nil {
+		return ErrScriptRequired
+	}
+	a.script = v
+	return nil
+}
+
+func (a BucketScriptAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a BucketScriptAgg) MarshalJSON() ([]byte, error) {
+	return bucketScriptAgg{
+		BucketsPath: a.bucketsPath,
+		Script:      a.script,
+		GapPolicy:   a.gapPolicy,
+		Format:      a.format,
+	}.MarshalJSON()
+}
+
+func (a *BucketScriptAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *BucketScriptAgg) UnmarshalJSON(data []byte) error {
+	*a = BucketScriptAgg{}
+	p := bucketScriptAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.bucketsPath = p.BucketsPath
+	a.script = p.Script
+	a.gapPolicy = p.GapPolicy
+	a.SetFormat(p.Format)
+	return nil
+}
+
+//easyjson:json
+type bucketScriptAgg struct {
+	BucketsPath map[string]string `json:"buckets_path"`
+	Script      *Script           `json:"script"`
+	GapPolicy   GapPolicy         `json:"gap_policy,omitempty"`
+	Format      string            `json:"format,omitempty"`
+}
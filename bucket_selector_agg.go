@@ -0,0 +1,118 @@
+package picker
+
+// BucketSelectorAggParams creates a BucketSelectorAgg, a parent pipeline
+// aggregation which executes a script which determines whether the current
+// bucket will be retained in the parent multi-bucket aggregation. The specified
+// metric must be numeric and the script must return a boolean value.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-bucket-selector-aggregation.html
+type BucketSelectorAggParams struct {
+	// Map of script variables to the buckets_path of the metric they reference
+	// (Required)
+	BucketsPath map[string]string
+	// The script to run for this aggregation. Variables of BucketsPath are
+	// available as params. (Required)
+	Script *Script
+	// Policy to apply when gaps are found in the data. Defaults to "skip".
+	GapPolicy GapPolicy
+	Meta      map[string]interface{}
+}
+
+func (BucketSelectorAggParams) Kind() AggKind {
+	return AggKindBucketSelector
+}
+
+func (p BucketSelectorAggParams) Clause() (AggClause, error) {
+	return p.BucketSelector()
+}
+
+func (p BucketSelectorAggParams) BucketSelector() (*BucketSelectorAgg, error) {
+	a := &BucketSelectorAgg{}
+	err := a.SetBucketsPath(p.BucketsPath)
+	if err != nil {
+		return a, newAggError(err, AggKindBucketSelector)
+	}
+	err = a.SetScript(p.Script)
+	if err != nil {
+		return a, newAggError(err, AggKindBucketSelector)
+	}
+	err = a.SetGapPolicy(p.GapPolicy)
+	if err != nil {
+		return a, newAggError(err, AggKindBucketSelector)
+	}
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// BucketSelectorAgg is a parent pipeline aggregation which executes a script
+// which determines whether the current bucket will be retained in the parent
+// multi-bucket aggregation.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-bucket-selector-aggregation.html
+type BucketSelectorAgg struct {
+	bucketsPathsParam
+	gapPolicyParam
+	script *Script
+	noAggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*BucketSelectorAgg)(nil)
+
+func (BucketSelectorAgg) Kind() AggKind {
+	return AggKindBucketSelector
+}
+
+func (a *BucketSelectorAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// Script is the script to run for this aggregation
+func (a BucketSelectorAgg) Script() *Script {
+	return a.script
+}
+
+// SetScript sets script to v
+func (a *BucketSelectorAgg) SetScript(v *Script) error {
+	if v == nil {
+		return ErrScriptRequired
+	}
+	a.script = v
+	return nil
+}
+
+func (a BucketSelectorAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a BucketSelectorAgg) MarshalJSON() ([]byte, error) {
+	return bucketSelectorAgg{
+		BucketsPath: a.bucketsPath,
+		Script:      a.script,
+		GapPolicy:   a.gapPolicy,
+	}.MarshalJSON()
+}
+
+func (a *BucketSelectorAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *BucketSelectorAgg) UnmarshalJSON(data []byte) error {
+	*a = BucketSelectorAgg{}
+	p := bucketSelectorAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.bucketsPath = p.BucketsPath
+	a.script = p.Script
+	a.gapPolicy = p.GapPolicy
+	return nil
+}
+
+//easyjson:json
+type bucketSelectorAgg struct {
+	BucketsPath map[string]string `json:"buckets_path"`
+	Script      *Script           `json:"script"`
+	GapPolicy   GapPolicy         `json:"gap_policy,omitempty"`
+}
@@ -0,0 +1,148 @@
+package picker
+
+// BucketSortAggParams creates a BucketSortAgg, a parent pipeline aggregation
+// which sorts the buckets of its parent multi-bucket aggregation. Zero or more
+// sort fields may be specified together with the corresponding sort order. Each
+// bucket may be sorted based on its _key, _count or its sub-aggregations. In
+// addition, parameters from and size may be set in order to truncate the result
+// buckets.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-bucket-sort-aggregation.html
+type BucketSortAggParams struct {
+	// The list of fields to sort on. Fields can be _key, _count, or the path of
+	// a sub-aggregation.
+	Sort Sort
+	// Policy to apply when gaps are found in the data. Defaults to "skip".
+	GapPolicy GapPolicy
+	// Buckets in positions prior to the set value will be truncated. Defaults
+	// to 0.
+	From int
+	// The number of buckets to return. Defaults to all buckets of the parent
+	// aggregation.
+	Size int
+	Meta map[string]interface{}
+}
+
+func (BucketSortAggParams) Kind() AggKind {
+	return AggKindBucketSort
+}
+
+func (p BucketSortAggParams) Clause() (AggClause, error) {
+	return p.BucketSort()
+}
+
+func (p BucketSortAggParams) BucketSort() (*BucketSortAgg, error) {
+	a := &BucketSortAgg{}
+	a.SetSort(p.Sort)
+	err := a.SetGapPolicy(p.GapPolicy)
+	if err != nil {
+		return a, newAggError(err, AggKindBucketSort)
+	}
+	a.SetFrom(p.From)
+	a.SetSize(p.Size)
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// BucketSortAgg is a parent pipeline aggregation which sorts the buckets of its
+// parent multi-bucket aggregation.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-bucket-sort-aggregation.html
+type BucketSortAgg struct {
+	gapPolicyParam
+	sort Sort
+	from int
+	size int
+	noAggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*BucketSortAgg)(nil)
+
+func (BucketSortAgg) Kind() AggKind {
+	return AggKindBucketSort
+}
+
+func (a *BucketSortAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// Sort is the list of fields to sort on
+func (a BucketSortAgg) Sort() Sort {
+	return a.sort
+}
+
+// SetSort sets sort to v
+func (a *BucketSortAgg) SetSort(v Sort) {
+	a.sort = v
+}
+
+// From is the offset of the buckets to return
+func (a BucketSortAgg) From() int {
+	return a.from
+}
+
+// SetFrom sets from to v
+func (a *BucketSortAgg) SetFrom(v int) {
+	a.from = v
+}
+
+// Size is the number of buckets to return
+func (a BucketSortAgg) Size() int {
+	return a.size
+}
+
+// SetSize sets size to v
+func (a *BucketSortAgg) SetSize(v int) {
+	a.size = v
+}
+
+// bucketsPaths returns the sort fields of a, each of which is a buckets_path
+func (a BucketSortAgg) bucketsPaths() []string {
+	paths := make([]string, 0, len(a.sort))
+	for _, s := range a.sort {
+		if len(s.Field) > 0 {
+			paths = append(paths, s.Field)
+		}
+	}
+	return paths
+}
+
+func (a BucketSortAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a BucketSortAgg) MarshalJSON() ([]byte, error) {
+	return bucketSortAgg{
+		Sort:      a.sort,
+		GapPolicy: a.gapPolicy,
+		From:      a.from,
+		Size:      a.size,
+	}.MarshalJSON()
+}
+
+func (a *BucketSortAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *BucketSortAgg) UnmarshalJSON(data []byte) error {
+	*a = BucketSortAgg{}
+	p := bucketSortAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.sort = p.Sort
+	a.gapPolicy = p.GapPolicy
+	a.from = p.From
+	a.size = p.Size
+	return nil
+}
+
+//easyjson:json
+type bucketSortAgg struct {
+	Sort      Sort      `json:"sort,omitempty"`
+	GapPolicy GapPolicy `json:"gap_policy,omitempty"`
+	From      int       `json:"from,omitempty"`
+	Size      int       `json:"size,omitempty"`
+}
@@ -0,0 +1,126 @@
+package picker
+
+// BytesProcessorParams creates a BytesProcessor, which converts a human
+// readable byte value (e.g. 1kb) to its value in bytes (e.g. 1024).
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/bytes-processor.html
+type BytesProcessorParams struct {
+	// Field is the field to convert
+	Field string
+	// TargetField is the field to assign the output to. By default, field is
+	// updated in-place.
+	TargetField string
+	// If IgnoreMissing is true and field does not exist or is null, the
+	// processor quietly exits without modifying the document
+	IgnoreMissing bool
+	// Description of the processor. Useful for describing the purpose of the
+	// processor or its configuration.
+	Description string
+	// Conditionally execute the processor
+	If string
+	// Ignore failures for the processor
+	IgnoreFailure bool
+	// Handle failures for the processor
+	OnFailure Procs
+	// Identifier for the processor. Useful for debugging and metrics.
+	Tag string
+}
+
+func (BytesProcessorParams) Kind() ProcessorKind {
+	return ProcessorKindBytes
+}
+
+func (p BytesProcessorParams) Processor() (Processor, error) {
+	return p.Bytes()
+}
+
+func (p BytesProcessorParams) Bytes() (*BytesProcessor, error) {
+	proc := &BytesProcessor{}
+	err := proc.SetField(p.Field)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindBytes)
+	}
+	proc.SetTargetField(p.TargetField)
+	proc.SetIgnoreMissing(p.IgnoreMissing)
+	proc.SetDescription(p.Description)
+	proc.SetIf(p.If)
+	proc.SetIgnoreFailure(p.IgnoreFailure)
+	err = proc.SetOnFailure(p.OnFailure)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindBytes)
+	}
+	proc.SetTag(p.Tag)
+	return proc, nil
+}
+
+// BytesProcessor converts a human readable byte value (e.g. 1kb) to its value
+// in bytes (e.g. 1024).
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/bytes-processor.html
+type BytesProcessor struct {
+	fieldParam
+	targetFieldParam
+	ignoreMissingParam
+	processorParams
+}
+
+var _ Processor = (*BytesProcessor)(nil)
+
+func (BytesProcessor) Kind() ProcessorKind {
+	return ProcessorKindBytes
+}
+
+func (p *BytesProcessor) Processor() (Processor, error) {
+	return p, nil
+}
+
+func (p BytesProcessor) MarshalBSON() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+func (p BytesProcessor) MarshalJSON() ([]byte, error) {
+	return bytesProcessor{
+		Field:         p.field,
+		TargetField:   p.targetField,
+		IgnoreMissing: p.ignoreMissing,
+		Description:   p.description,
+		If:            p.ifCond,
+		IgnoreFailure: p.ignoreFailure,
+		OnFailure:     p.onFailure,
+		Tag:           p.tag,
+	}.MarshalJSON()
+}
+
+func (p *BytesProcessor) UnmarshalBSON(data []byte) error {
+	return p.UnmarshalJSON(data)
+}
+
+func (p *BytesProcessor) UnmarshalJSON(data []byte) error {
+	*p = BytesProcessor{}
+	var v bytesProcessor
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	p.field = v.Field
+	p.targetField = v.TargetField
+	p.ignoreMissing = v.IgnoreMissing
+	p.description = v.Description
+	p.ifCond = v.If
+	p.ignoreFailure = v.IgnoreFailure
+	p.onFailure = v.OnFailure
+	p.tag = v.Tag
+	return nil
+}
+
+//easyjson:json
+type bytesProcessor struct {
+	Field         string     `json:"field,omitempty"`
+	TargetField   string     `json:"target_field,omitempty"`
+	IgnoreMissing bool       `json:"ignore_missing,omitempty"`
+	Description   string     `json:"description,omitempty"`
+	If            string     `json:"if,omitempty"`
+	IgnoreFailure bool       `json:"ignore_failure,omitempty"`
+	OnFailure     Processors `json:"on_failure,omitempty"`
+	Tag           string     `json:"tag,omitempty"`
+}
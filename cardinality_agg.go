@@ -0,0 +1,147 @@
+package picker
+
+const (
+	DefaultCardinalityPrecisionThreshold = 3000
+	MaxCardinalityPrecisionThreshold     = 40000
+)
+
+// CardinalityAggParams creates a CardinalityAgg, a single-value metrics
+// aggregation that calculates an approximate count of distinct values.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-cardinality-aggregation.html
+type CardinalityAggParams struct {
+	// The field to aggregate on. Either Field or Script is required.
+	Field string
+	// Script to generate values with. If Field is also set, Script is a value
+	// script.
+	Script *Script
+	// Missing is the value used for documents which do not have a value
+	Missing interface{}
+	// Hint as to the type of value produced by Script
+	ValueType string
+	// Allows to trade memory for accuracy, and defines a unique count below
+	// which counts are expected to be close to accurate. The maximum supported
+	// value is 40000, thresholds above this number will have the same effect as
+	// a threshold of 40000. Defaults to 3000.
+	PrecisionThreshold int
+	// Mechanism by which cardinality aggregations is run. One of
+	// "global_ordinals", "segment_ordinals", "direct", "save_memory_heuristic"
+	// or "save_time_heuristic".
+	ExecutionHint string
+	Meta          map[string]interface{}
+}
+
+func (CardinalityAggParams) Kind() AggKind {
+	return AggKindCardinality
+}
+
+func (p CardinalityAggParams) Clause() (AggClause, error) {
+	return p.Cardinality()
+}
+
+func (p CardinalityAggParams) Cardinality() (*CardinalityAgg, error) {
+	a := &CardinalityAgg{}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	err := a.checkValueSource()
+	if err != nil {
+		return a, newAggError(err, AggKindCardinality)
+	}
+	a.SetMissing(p.Missing)
+	a.SetValueType(p.ValueType)
+	a.SetPrecisionThreshold(p.PrecisionThreshold)
+	a.SetExecutionHint(p.ExecutionHint)
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// CardinalityAgg is a single-value metrics aggregation that calculates an
+// approximate count of distinct values.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-cardinality-aggregation.html
+type CardinalityAgg struct {
+	valueSourceParam
+	precisionThreshold int
+	executionHint      string
+	noAggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*CardinalityAgg)(nil)
+
+func (CardinalityAgg) Kind() AggKind {
+	return AggKindCardinality
+}
+
+func (a *CardinalityAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// PrecisionThreshold defines a unique count below which counts are expected to
+// be close to accurate. Defaults to 3000.
+func (a CardinalityAgg) PrecisionThreshold() int {
+	if a.precisionThreshold == 0 {
+		return DefaultCardinalityPrecisionThreshold
+	}
+	return a.precisionThreshold
+}
+
+// SetPrecisionThreshold sets precision_threshold to v
+func (a *CardinalityAgg) SetPrecisionThreshold(v int) {
+	a.precisionThreshold = v
+}
+
+// ExecutionHint is the mechanism by which the cardinality aggregation is run.
+func (a CardinalityAgg) ExecutionHint() string {
+	return a.executionHint
+}
+
+// SetExecutionHint sets execution_hint to v
+func (a *CardinalityAgg) SetExecutionHint(v string) {
+	a.executionHint = v
+}
+
+func (a CardinalityAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a CardinalityAgg) MarshalJSON() ([]byte, error) {
+	return cardinalityAgg{
+		Field:              a.field,
+		Script:             a.script,
+		Missing:            a.missing,
+		ValueType:          a.valueType,
+		PrecisionThreshold: a.precisionThreshold,
+		ExecutionHint:      a.executionHint,
+	}.MarshalJSON()
+}
+
+func (a *CardinalityAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *CardinalityAgg) UnmarshalJSON(data []byte) error {
+	*a = CardinalityAgg{}
+	p := cardinalityAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	a.SetMissing(p.Missing)
+	a.SetValueType(p.ValueType)
+	a.precisionThreshold = p.PrecisionThreshold
+	a.executionHint = p.ExecutionHint
+	return nil
+}
+
+//easyjson:json
+type cardinalityAgg struct {
+	Field              string      `json:"field,omitempty"`
+	Script             *Script     `json:"script,omitempty"`
+	Missing            interface{} `json:"missing,omitempty"`
+	ValueType          string      `json:"value_type,omitempty"`
+	PrecisionThreshold int         `json:"precision_threshold,omitempty"`
+	ExecutionHint      string      `json:"execution_hint,omitempty"`
+}
@@ -0,0 +1,239 @@
+package picker
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/chanced/dynamic"
+	"github.com/tidwall/gjson"
+)
+
+// CharFilterType is the type of a CharFilter
+type CharFilterType string
+
+func (t CharFilterType) String() string {
+	return string(t)
+}
+
+const (
+	CharFilterTypeHTMLStrip      CharFilterType = "html_strip"
+	CharFilterTypeMapping        CharFilterType = "mapping"
+	CharFilterTypePatternReplace CharFilterType = "pattern_replace"
+)
+
+// CharFilterTypeHandlers is a map of char filter types to funcs which return a
+// CharFilter of that type, used when unmarshaling CharFilters. Char filters
+// provided by plugins can be registered here; those which are not registered
+// are unmarshaled as a *RawCharFilter.
+var CharFilterTypeHandlers = map[CharFilterType]func() CharFilter{
+	CharFilterTypeHTMLStrip:      func() CharFilter { return &HTMLStripCharFilter{} },
+	CharFilterTypeMapping:        func() CharFilter { return &MappingCharFilter{} },
+	CharFilterTypePatternReplace: func() CharFilter { return &PatternReplaceCharFilter{} },
+}
+
+// CharFilter receives the original text as a stream of characters and can
+// transform the stream by adding, removing, or changing characters, before it
+// is passed to the tokenizer.
+//
+// A CharFilter can optionally implement Validate() error.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-charfilters.html
+type CharFilter interface {
+	// Type is the type of the char filter, e.g. "html_strip"
+	Type() CharFilterType
+	json.Marshaler
+	json.Unmarshaler
+}
+
+// CharFilters are custom char filters, keyed by name
+type CharFilters map[string]CharFilter
+
+// Validate checks each CharFilter which implements Validate
+func (m CharFilters) Validate() error {
+	for name, v := range m {
+		if v == nil {
+			return newFieldError(ErrMissingType, name)
+		}
+		if vv, ok := v.(interface{ Validate() error }); ok {
+			if err := vv.Validate(); err != nil {
+				return newFieldError(err, name)
+			}
+		}
+	}
+	return nil
+}
+
+func (m CharFilters) MarshalBSON() ([]byte, error) {
+	return m.MarshalJSON()
+}
+
+func (m CharFilters) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]CharFilter(m))
+}
+
+func (m *CharFilters) UnmarshalBSON(data []byte) error {
+	return m.UnmarshalJSON(data)
+}
+
+func (m *CharFilters) UnmarshalJSON(data []byte) error {
+	*m = nil
+	var obj dynamic.JSONObject
+	err := json.Unmarshal(data, &obj)
+	if err != nil {
+		return err
+	}
+	if len(obj) == 0 {
+		return nil
+	}
+	res := make(CharFilters, len(obj))
+	for name, d := range obj {
+		var v CharFilter
+		err = UnmarshalCharFilterJSON(d, &v)
+		if err != nil {
+			return newFieldError(err, name)
+		}
+		res[name] = v
+	}
+	*m = res
+	return nil
+}
+
+// UnmarshalCharFilterJSON decodes data into the CharFilter registered in
+// CharFilterTypeHandlers for its type, or a *RawCharFilter if the type is unknown.
+func UnmarshalCharFilterJSON(data []byte, v *CharFilter) error {
+	t := gjson.GetBytes(data, "type").String()
+	if len(t) == 0 {
+		return fmt.Errorf("%w; can not unmarshal char filter", ErrMissingType)
+	}
+	var res CharFilter
+	if handler, ok := CharFilterTypeHandlers[CharFilterType(t)]; ok {
+		res = handler()
+	} else {
+		res = &RawCharFilter{CharFilterType: CharFilterType(t)}
+	}
+	err := res.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	*v = res
+	return nil
+}
+
+// RawCharFilter is a char filter which is kept as-is. It is used for char filters which
+// are not registered in CharFilterTypeHandlers, such as those provided by plugins.
+type RawCharFilter struct {
+	// The type of the char filter (Required)
+	CharFilterType CharFilterType
+	// The settings of the char filter, other than type
+	Data dynamic.JSON
+}
+
+func (c RawCharFilter) Type() CharFilterType {
+	return c.CharFilterType
+}
+
+// Validate checks that the type is set
+func (c RawCharFilter) Validate() error {
+	if len(c.CharFilterType) == 0 {
+		return ErrMissingType
+	}
+	return nil
+}
+
+func (c RawCharFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(c.CharFilterType), c.Data)
+}
+
+func (c *RawCharFilter) UnmarshalJSON(data []byte) error {
+	c.CharFilterType = CharFilterType(gjson.GetBytes(data, "type").String())
+	c.Data = append(dynamic.JSON(nil), data...)
+	return nil
+}
+
+// HTMLStripCharFilter strips HTML elements from a text and replaces HTML
+// entities with their decoded value.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-htmlstrip-charfilter.html
+type HTMLStripCharFilter struct {
+	// HTML elements without enclosing angle brackets which are not stripped
+	EscapedTags []string `json:"escaped_tags,omitempty"`
+}
+
+func (HTMLStripCharFilter) Type() CharFilterType {
+	return CharFilterTypeHTMLStrip
+}
+
+func (c HTMLStripCharFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(c.Type()), htmlStripCharFilter(c))
+}
+
+func (c *HTMLStripCharFilter) UnmarshalJSON(data []byte) error {
+	var v htmlStripCharFilter
+	err := unmarshalAnalysisComponent(data, &v)
+	*c = HTMLStripCharFilter(v)
+	return err
+}
+
+//easyjson:json
+type htmlStripCharFilter HTMLStripCharFilter
+
+// MappingCharFilter replaces any occurrences of the specified strings with
+// the specified replacements.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-mapping-charfilter.html
+type MappingCharFilter struct {
+	// Mappings, each of the form "key => value"
+	Mappings []string `json:"mappings,omitempty"`
+	// The path to a file containing mappings
+	MappingsPath string `json:"mappings_path,omitempty"`
+}
+
+func (MappingCharFilter) Type() CharFilterType {
+	return CharFilterTypeMapping
+}
+
+func (c MappingCharFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(c.Type()), mappingCharFilter(c))
+}
+
+func (c *MappingCharFilter) UnmarshalJSON(data []byte) error {
+	var v mappingCharFilter
+	err := unmarshalAnalysisComponent(data, &v)
+	*c = MappingCharFilter(v)
+	return err
+}
+
+//easyjson:json
+type mappingCharFilter MappingCharFilter
+
+// PatternReplaceCharFilter uses a regular expression to match characters
+// which should be replaced with the specified replacement string.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-pattern-replace-charfilter.html
+type PatternReplaceCharFilter struct {
+	// A Java regular expression (Required)
+	Pattern string `json:"pattern"`
+	// The replacement string, which can reference capture groups using the
+	// $1..$9 syntax.
+	Replacement string `json:"replacement,omitempty"`
+	// Java regular expression flags, pipe-separated, e.g. "CASE_INSENSITIVE|COMMENTS".
+	Flags string `json:"flags,omitempty"`
+}
+
+func (PatternReplaceCharFilter) Type() CharFilterType {
+	return CharFilterTypePatternReplace
+}
+
+func (c PatternReplaceCharFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(c.Type()), patternReplaceCharFilter(c))
+}
+
+func (c *PatternReplaceCharFilter) UnmarshalJSON(data []byte) error {
+	var v patternReplaceCharFilter
+	err := unmarshalAnalysisComponent(data, &v)
+	*c = PatternReplaceCharFilter(v)
+	return err
+}
+
+//easyjson:json
+type patternReplaceCharFilter PatternReplaceCharFilter
@@ -0,0 +1,100 @@
+package picker
+
+// ChildrenAggParams creates a ChildrenAgg, a special single bucket aggregation
+// that selects child documents that have the specified type, as defined in a
+// join field.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-children-aggregation.html
+type ChildrenAggParams struct {
+	// The child type that should be selected (Required)
+	Type string
+	// Sub-aggregations
+	Aggregations Aggs
+	Meta         map[string]interface{}
+}
+
+func (ChildrenAggParams) Kind() AggKind {
+	return AggKindChildren
+}
+
+func (p ChildrenAggParams) Clause() (AggClause, error) {
+	return p.Children()
+}
+
+func (p ChildrenAggParams) Children() (*ChildrenAgg, error) {
+	a := &ChildrenAgg{}
+	err := a.SetType(p.Type)
+	if err != nil {
+		return a, newAggError(err, AggKindChildren)
+	}
+	err = a.SetAggregations(p.Aggregations)
+	if err != nil {
+		return a, newAggError(err, AggKindChildren)
+	}
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// ChildrenAgg is a special single bucket aggregation that selects child
+// documents that have the specified type, as defined in a join field.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-children-aggregation.html
+type ChildrenAgg struct {
+	typ string
+	aggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*ChildrenAgg)(nil)
+
+func (ChildrenAgg) Kind() AggKind {
+	return AggKindChildren
+}
+
+func (a *ChildrenAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// Type is the child type that should be selected
+func (a ChildrenAgg) Type() string {
+	return a.typ
+}
+
+// SetType sets the type to v
+func (a *ChildrenAgg) SetType(v string) error {
+	if len(v) == 0 {
+		return ErrTypeRequired
+	}
+	a.typ = v
+	return nil
+}
+
+func (a ChildrenAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a ChildrenAgg) MarshalJSON() ([]byte, error) {
+	return childrenAgg{
+		Type: a.typ,
+	}.MarshalJSON()
+}
+
+func (a *ChildrenAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *ChildrenAgg) UnmarshalJSON(data []byte) error {
+	*a = ChildrenAgg{}
+	p := childrenAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.typ = p.Type
+	return nil
+}
+
+//easyjson:json
+type childrenAgg struct {
+	Type string `json:"type"`
+}
@@ -0,0 +1,181 @@
+package picker
+
+// CircleProcessorParams creates a CircleProcessor, which converts circle
+// definitions of shapes to regular polygons which approximate them.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/ingest-circle-processor.html
+type CircleProcessorParams struct {
+	// Field is the field to interpret as a circle. Either a string in WKT
+	// format or a map for GeoJSON.
+	Field string
+	// TargetField is the field to assign the output to. By default, field is
+	// updated in-place.
+	TargetField string
+	// If IgnoreMissing is true and field does not exist or is null, the
+	// processor quietly exits without modifying the document
+	IgnoreMissing bool
+	// ErrorDistance is the difference between the resulting inscribed distance
+	// from center to side and the circle's radius, measured in meters for
+	// geo_shape and unit-less for shape.
+	ErrorDistance float64
+	// ShapeType is the field mapping type to use when processing the circle,
+	// either "geo_shape" or "shape"
+	ShapeType string
+	// Description of the processor. Useful for describing the purpose of the
+	// processor or its configuration.
+	Description string
+	// Conditionally execute the processor
+	If string
+	// Ignore failures for the processor
+	IgnoreFailure bool
+	// Handle failures for the processor
+	OnFailure Procs
+	// Identifier for the processor. Useful for debugging and metrics.
+	Tag string
+}
+
+func (CircleProcessorParams) Kind() ProcessorKind {
+	return ProcessorKindCircle
+}
+
+func (p CircleProcessorParams) Processor() (Processor, error) {
+	return p.Circle()
+}
+
+func (p CircleProcessorParams) Circle() (*CircleProcessor, error) {
+	proc := &CircleProcessor{}
+	err := proc.SetField(p.Field)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindCircle)
+	}
+	proc.SetTargetField(p.TargetField)
+	proc.SetIgnoreMissing(p.IgnoreMissing)
+	err = proc.SetErrorDistance(p.ErrorDistance)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindCircle)
+	}
+	err = proc.SetShapeType(p.ShapeType)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindCircle)
+	}
+	proc.SetDescription(p.Description)
+	proc.SetIf(p.If)
+	proc.SetIgnoreFailure(p.IgnoreFailure)
+	err = proc.SetOnFailure(p.OnFailure)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindCircle)
+	}
+	proc.SetTag(p.Tag)
+	return proc, nil
+}
+
+// CircleProcessor converts circle definitions of shapes to regular polygons
+// which approximate them.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/ingest-circle-processor.html
+type CircleProcessor struct {
+	fieldParam
+	targetFieldParam
+	ignoreMissingParam
+	errorDistance float64
+	shapeType     string
+	processorParams
+}
+
+var _ Processor = (*CircleProcessor)(nil)
+
+func (CircleProcessor) Kind() ProcessorKind {
+	return ProcessorKindCircle
+}
+
+func (p *CircleProcessor) Processor() (Processor, error) {
+	return p, nil
+}
+
+// ErrorDistance is the difference between the resulting inscribed distance from
+// center to side and the circle's radius, measured in meters for geo_shape and
+// unit-less for shape.
+func (p CircleProcessor) ErrorDistance() float64 {
+	return p.errorDistance
+}
+
+// SetErrorDistance sets error_distance to v
+func (p *CircleProcessor) SetErrorDistance(v float64) error {
+	if v <= 0 {
+		return ErrErrorDistanceRequired
+	}
+	p.errorDistance = v
+	return nil
+}
+
+// ShapeType is the field mapping type to use when processing the circle, either
+// "geo_shape" or "shape"
+func (p CircleProcessor) ShapeType() string {
+	return p.shapeType
+}
+
+// SetShapeType sets shape_type to v
+func (p *CircleProcessor) SetShapeType(v string) error {
+	if len(v) == 0 {
+		return ErrShapeTypeRequired
+	}
+	p.shapeType = v
+	return nil
+}
+
+func (p CircleProcessor) MarshalBSON() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+func (p CircleProcessor) MarshalJSON() ([]byte, error) {
+	return circleProcessor{
+		Field:         p.field,
+		TargetField:   p.targetField,
+		IgnoreMissing: p.ignoreMissing,
+		ErrorDistance: p.errorDistance,
+		ShapeType:     p.shapeType,
+		Description:   p.description,
+		If:            p.ifCond,
+		IgnoreFailure: p.ignoreFailure,
+		OnFailure:     p.onFailure,
+		Tag:           p.tag,
+	}.MarshalJSON()
+}
+
+func (p *CircleProcessor) UnmarshalBSON(data []byte) error {
+	return p.UnmarshalJSON(data)
+}
+
+func (p *CircleProcessor) UnmarshalJSON(data []byte) error {
+	*p = CircleProcessor{}
+	var v circleProcessor
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	p.field = v.Field
+	p.targetField = v.TargetField
+	p.ignoreMissing = v.IgnoreMissing
+	p.errorDistance = v.ErrorDistance
+	p.shapeType = v.ShapeType
+	p.description = v.Description
+	p.ifCond = v.If
+	p.ignoreFailure = v.IgnoreFailure
+	p.onFailure = v.OnFailure
+	p.tag = v.Tag
+	return nil
+}
+
+//easyjson:json
+type circleProcessor struct {
+	Field         string     `json:"field,omitempty"`
+	TargetField   string     `json:"target_field,omitempty"`
+	IgnoreMissing bool       `json:"ignore_missing,omitempty"`
+	ErrorDistance float64    `json:"error_distance,omitempty"`
+	ShapeType     string     `json:"shape_type,omitempty"`
+	Description   string     `json:"description,omitempty"`
+	If            string     `json:"if,omitempty"`
+	IgnoreFailure bool       `json:"ignore_failure,omitempty"`
+	OnFailure     Processors `json:"on_failure,omitempty"`
+	Tag           string     `json:"tag,omitempty"`
+}
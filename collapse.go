@@ -0,0 +1,186 @@
+package picker
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/chanced/dynamic"
+)
+
+// Collapse collapses search results based on field values. The collapsing is
+// done by selecting only the top sorted document per collapse key.
+//
+// The field used for collapsing must be a single valued keyword or numeric
+// field with doc_values activated.
+//
+//	picker.Collapse{
+//	    Field: "user.id",
+//	    InnerHits: []picker.InnerHits{
+//	        {Name: "most_recent", Size: 5, Sort: picker.Sort{{Field: "@timestamp", Order: picker.SortOrderDescending}}},
+//	    },
+//	    MaxConcurrentGroupSearches: 4,
+//	}
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/collapse-search-results.html
+type Collapse struct {
+	// The field to collapse the result set on (Required)
+	Field string
+	// Expands each collapsed top hit with the inner hits.
+	InnerHits []InnerHits
+	// The number of concurrent requests allowed to retrieve the inner hits per
+	// group.
+	MaxConcurrentGroupSearches int
+}
+
+// Validate checks that field is set and that the names of InnerHits are
+// unique, including those of nested collapses.
+func (c Collapse) Validate() error {
+	if len(c.Field) == 0 {
+		return ErrFieldRequired
+	}
+	if c.MaxConcurrentGroupSearches < 0 {
+		return fmt.Errorf("%w; received <%d>", ErrInvalidGroupSearches, c.MaxConcurrentGroupSearches)
+	}
+	names := map[string]bool{}
+	for _, ih := range c.InnerHits {
+		if names[ih.Name] {
+			return fmt.Errorf("%w <%s>", ErrDuplicateInnerHitsName, ih.Name)
+		}
+		names[ih.Name] = true
+		if ih.Collapse != nil {
+			err := ih.Collapse.Validate()
+			if err != nil {
+				return newFieldError(err, "inner_hits")
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateMappings checks that the field of c, as well as those of nested
+// collapses, are keyword or numeric fields with doc_values enabled.
+func (c Collapse) ValidateMappings(m Mappings) error {
+	fm, err := m.FieldMappings()
+	if err != nil {
+		return err
+	}
+	return c.validateFields(fm.Properties)
+}
+
+func (c Collapse) validateFields(props Fields) error {
+	f, ok := props.Lookup(c.Field)
+	if !ok {
+		return fmt.Errorf("%w <%s>", ErrFieldNotFound, c.Field)
+	}
+	switch f.Type() {
+	case FieldTypeKeyword, FieldTypeLong, FieldTypeInteger, FieldTypeShort,
+		FieldTypeByte, FieldTypeDouble, FieldTypeFloat, FieldTypeHalfFloat,
+		FieldTypeScaledFloat, FieldTypeUnsignedLong:
+	default:
+		return fmt.Errorf("%w; <%s> is of type <%s>", ErrInvalidCollapseField, c.Field, f.Type())
+	}
+	if dv, ok := f.(WithDocValues); ok && !dv.DocValues() {
+		return fmt.Errorf("%w <%s>", ErrDocValuesRequired, c.Field)
+	}
+	for _, ih := range c.InnerHits {
+		if ih.Collapse == nil {
+			continue
+		}
+		if err := ih.Collapse.validateFields(props); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c Collapse) MarshalBSON() ([]byte, error) {
+	return c.MarshalJSON()
+}
+
+func (c Collapse) MarshalJSON() ([]byte, error) {
+	return collapse{
+		Field:                      c.Field,
+		InnerHits:                  collapseInnerHits(c.InnerHits),
+		MaxConcurrentGroupSearches: c.MaxConcurrentGroupSearches,
+	}.MarshalJSON()
+}
+
+func (c *Collapse) UnmarshalBSON(data []byte) error {
+	return c.UnmarshalJSON(data)
+}
+
+func (c *Collapse) UnmarshalJSON(data []byte) error {
+	*c = Collapse{}
+	var v collapse
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	c.Field = v.Field
+	c.InnerHits = []InnerHits(v.InnerHits)
+	c.MaxConcurrentGroupSearches = v.MaxConcurrentGroupSearches
+	return nil
+}
+
+//easyjson:json
+type collapse struct {
+	Field                      string            `json:"field"`
+	InnerHits                  collapseInnerHits `json:"inner_hits,omitempty"`
+	MaxConcurrentGroupSearches int               `json:"max_concurrent_group_searches,omitempty"`
+}
+
+// collapseInnerHits is encoded as an object if there is a single InnerHits,
+// otherwise as an array
+type collapseInnerHits []InnerHits
+
+func (ih collapseInnerHits) MarshalJSON() ([]byte, error) {
+	if len(ih) == 1 {
+		return ih[0].MarshalJSON()
+	}
+	return json.Marshal([]InnerHits(ih))
+}
+
+func (ih *collapseInnerHits) UnmarshalJSON(data []byte) error {
+	*ih = nil
+	d := dynamic.JSON(data)
+	if len(d) == 0 || d.IsNull() {
+		return nil
+	}
+	if d.IsArray() {
+		var v []InnerHits
+		err := json.Unmarshal(d, &v)
+		*ih = v
+		return err
+	}
+	var v InnerHits
+	err := v.UnmarshalJSON(d)
+	if err != nil {
+		return err
+	}
+	*ih = collapseInnerHits{v}
+	return nil
+}
+
+// InnerHits expands collapsed hits with the top documents of each group.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/collapse-search-results.html#expand-collapse-results
+//
+//easyjson:json
+type InnerHits struct {
+	// The name to be used for the particular inner hit definition in the
+	// response. Useful when multiple inner hits have been defined.
+	Name string `json:"name,omitempty"`
+	// The offset from where the first hit to fetch for each inner_hits in the
+	// returned regular search hits.
+	From int `json:"from,omitempty"`
+	// The maximum number of hits to return per inner_hits. By default the top
+	// three matching hits are returned.
+	Size int `json:"size,omitempty"`
+	// How the inner hits should be sorted per inner_hits. By default the hits
+	// are sorted by the score.
+	Sort Sort `json:"sort,omitempty"`
+	// Indicates which source fields are returned for the inner hits
+	Source *SearchSource `json:"_source,omitempty"`
+	// Collapses the inner hits on a second field
+	Collapse *Collapse `json:"collapse,omitempty"`
+}
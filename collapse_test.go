@@ -0,0 +1,117 @@
+package picker_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/chanced/cmpjson"
+	"github.com/chanced/picker"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollapse(t *testing.T) {
+	assert := require.New(t)
+	data := []byte(`{
+		"query": { "match": { "message": { "query": "GET /search" } } },
+		"collapse": {
+			"field": "user.id",
+			"inner_hits": [
+				{
+					"name": "largest_responses",
+					"size": 3,
+					"sort": [{ "http.response.bytes": { "order": "desc" } }],
+					"_source": ["http.response.bytes", "@timestamp"]
+				},
+				{
+					"name": "most_recent",
+					"size": 3,
+					"sort": [{ "@timestamp": { "order": "desc" } }],
+					"collapse": { "field": "user.name" }
+				}
+			],
+			"max_concurrent_group_searches": 4
+		}
+	}`)
+	var s picker.Search
+	err := json.Unmarshal(data, &s)
+	assert.NoError(err)
+	c := s.Collapse()
+	assert.NotNil(c)
+	assert.Empty(s.Passthrough())
+	assert.Equal("user.id", c.Field)
+	assert.Len(c.InnerHits, 2)
+	assert.Equal("user.name", c.InnerHits[1].Collapse.Field)
+	assert.NoError(c.Validate())
+	sd, err := json.Marshal(s)
+	assert.NoError(err)
+	assert.True(cmpjson.Equal(data, sd), cmpjson.Diff(data, sd))
+
+	single := []byte(`{
+		"collapse": {
+			"field": "sku.family",
+			"inner_hits": { "name": "variants", "size": 5 }
+		}
+	}`)
+	s2, err := picker.NewSearch(picker.SearchParams{
+		Collapse: &picker.Collapse{
+			Field:     "sku.family",
+			InnerHits: []picker.InnerHits{{Name: "variants", Size: 5}},
+		},
+	})
+	assert.NoError(err)
+	sd2, err := json.Marshal(s2)
+	assert.NoError(err)
+	assert.True(cmpjson.Equal(single, sd2), cmpjson.Diff(single, sd2))
+	var s3 picker.Search
+	err = json.Unmarshal(single, &s3)
+	assert.NoError(err)
+	assert.Equal("variants", s3.Collapse().InnerHits[0].Name)
+
+	_, err = picker.NewSearch(picker.SearchParams{Collapse: &picker.Collapse{}})
+	assert.True(errors.Is(err, picker.ErrFieldRequired))
+	err = picker.Collapse{
+		Field:     "user.id",
+		InnerHits: []picker.InnerHits{{Size: 1}, {Size: 2}},
+	}.Validate()
+	assert.True(errors.Is(err, picker.ErrDuplicateInnerHitsName))
+	err = picker.Collapse{
+		Field:     "user.id",
+		InnerHits: []picker.InnerHits{{Collapse: &picker.Collapse{}}},
+	}.Validate()
+	assert.True(errors.Is(err, picker.ErrFieldRequired))
+}
+
+func TestCollapseValidateMappings(t *testing.T) {
+	assert := require.New(t)
+	mappings := picker.Mappings{
+		Properties: picker.FieldMap{
+			"sku": picker.ObjectFieldParams{
+				Properties: picker.FieldMap{
+					"family": picker.KeywordFieldParams{},
+					"code":   picker.KeywordFieldParams{DocValues: false},
+				},
+			},
+			"price":       picker.LongFieldParams{},
+			"description": picker.TextFieldParams{},
+			"title": picker.TextFieldParams{
+				Fields: picker.FieldMap{"raw": picker.KeywordFieldParams{}},
+			},
+		},
+	}
+	assert.NoError(picker.Collapse{Field: "sku.family"}.ValidateMappings(mappings))
+	assert.NoError(picker.Collapse{Field: "price"}.ValidateMappings(mappings))
+	assert.NoError(picker.Collapse{Field: "title.raw"}.ValidateMappings(mappings))
+
+	err := picker.Collapse{Field: "description"}.ValidateMappings(mappings)
+	assert.True(errors.Is(err, picker.ErrInvalidCollapseField))
+	err = picker.Collapse{Field: "sku.code"}.ValidateMappings(mappings)
+	assert.True(errors.Is(err, picker.ErrDocValuesRequired))
+	err = picker.Collapse{Field: "sku.missing"}.ValidateMappings(mappings)
+	assert.True(errors.Is(err, picker.ErrFieldNotFound))
+	err = picker.Collapse{
+		Field:     "sku.family",
+		InnerHits: []picker.InnerHits{{Collapse: &picker.Collapse{Field: "description"}}},
+	}.ValidateMappings(mappings)
+	assert.True(errors.Is(err, picker.ErrInvalidCollapseField))
+}
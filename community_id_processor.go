@@ -0,0 +1,282 @@
+package picker
+
+// CommunityIDProcessorParams creates a CommunityIDProcessor, which computes the
+// Community ID for network flow data as defined in the Community ID
+// Specification.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/community-id-processor.html
+type CommunityIDProcessorParams struct {
+	// SourceIP is the field containing the source IP address. Defaults to
+	// "source.ip".
+	SourceIP string
+	// SourcePort is the field containing the source port. Defaults to
+	// "source.port".
+	SourcePort string
+	// DestinationIP is the field containing the destination IP address.
+	// Defaults to "destination.ip".
+	DestinationIP string
+	// DestinationPort is the field containing the destination port. Defaults to
+	// "destination.port".
+	DestinationPort string
+	// IANANumber is the field containing the IANA number. Defaults to
+	// "network.iana_number".
+	IANANumber string
+	// ICMPType is the field containing the ICMP type. Defaults to "icmp.type".
+	ICMPType string
+	// ICMPCode is the field containing the ICMP code. Defaults to "icmp.code".
+	ICMPCode string
+	// Transport is the field containing the transport protocol. Used only when
+	// iana_number is not present. Defaults to "network.transport".
+	Transport string
+	// TargetField is the output field for the community ID. Defaults to
+	// "network.community_id".
+	TargetField string
+	// Seed for the community ID hash. Must be between 0 and 65535 (inclusive).
+	Seed int
+	// If IgnoreMissing is true and field does not exist or is null, the
+	// processor quietly exits without modifying the document
+	IgnoreMissing bool
+	// Description of the processor. Useful for describing the purpose of the
+	// processor or its configuration.
+	Description string
+	// Conditionally execute the processor
+	If string
+	// Ignore failures for the processor
+	IgnoreFailure bool
+	// Handle failures for the processor
+	OnFailure Procs
+	// Identifier for the processor. Useful for debugging and metrics.
+	Tag string
+}
+
+func (CommunityIDProcessorParams) Kind() ProcessorKind {
+	return ProcessorKindCommunityID
+}
+
+func (p CommunityIDProcessorParams) Processor() (Processor, error) {
+	return p.CommunityID()
+}
+
+func (p CommunityIDProcessorParams) CommunityID() (*CommunityIDProcessor, error) {
+	proc := &CommunityIDProcessor{}
+	proc.SetSourceIP(p.SourceIP)
+	proc.SetSourcePort(p.SourcePort)
+	proc.SetDestinationIP(p.DestinationIP)
+	proc.SetDestinationPort(p.DestinationPort)
+	proc.SetIANANumber(p.IANANumber)
+	proc.SetICMPType(p.ICMPType)
+	proc.SetICMPCode(p.ICMPCode)
+	proc.SetTransport(p.Transport)
+	proc.SetTargetField(p.TargetField)
+	proc.SetSeed(p.Seed)
+	proc.SetIgnoreMissing(p.IgnoreMissing)
+	proc.SetDescription(p.Description)
+	proc.SetIf(p.If)
+	proc.SetIgnoreFailure(p.IgnoreFailure)
+	err := proc.SetOnFailure(p.OnFailure)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindCommunityID)
+	}
+	proc.SetTag(p.Tag)
+	return proc, nil
+}
+
+// CommunityIDProcessor computes the Community ID for network flow data as
+// defined in the Community ID Specification.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/community-id-processor.html
+type CommunityIDProcessor struct {
+	sourceIP        string
+	sourcePort      string
+	destinationIP   string
+	destinationPort string
+	ianaNumber      string
+	icmpType        string
+	icmpCode        string
+	transport       string
+	targetFieldParam
+	seed int
+	ignoreMissingParam
+	processorParams
+}
+
+var _ Processor = (*CommunityIDProcessor)(nil)
+
+func (CommunityIDProcessor) Kind() ProcessorKind {
+	return ProcessorKindCommunityID
+}
+
+func (p *CommunityIDProcessor) Processor() (Processor, error) {
+	return p, nil
+}
+
+// SourceIP is the field containing the source IP address. Defaults to
+// "source.ip".
+func (p CommunityIDProcessor) SourceIP() string {
+	return p.sourceIP
+}
+
+// SetSourceIP sets source_ip to v
+func (p *CommunityIDProcessor) SetSourceIP(v string) {
+	p.sourceIP = v
+}
+
+// SourcePort is the field containing the source port. Defaults to
+// "source.port".
+func (p CommunityIDProcessor) SourcePort() string {
+	return p.sourcePort
+}
+
+// SetSourcePort sets source_port to v
+func (p *CommunityIDProcessor) SetSourcePort(v string) {
+	p.sourcePort = v
+}
+
+// DestinationIP is the field containing the destination IP address. Defaults to
+// "destination.ip".
+func (p CommunityIDProcessor) DestinationIP() string {
+	return p.destinationIP
+}
+
+// SetDestinationIP sets destination_ip to v
+func (p *CommunityIDProcessor) SetDestinationIP(v string) {
+	p.destinationIP = v
+}
+
+// DestinationPort is the field containing the destination port. Defaults to
+// "destination.port".
+func (p CommunityIDProcessor) DestinationPort() string {
+	return p.destinationPort
+}
+
+// SetDestinationPort sets destination_port to v
+func (p *CommunityIDProcessor) SetDestinationPort(v string) {
+	p.destinationPort = v
+}
+
+// IANANumber is the field containing the IANA number. Defaults to
+// "network.iana_number".
+func (p CommunityIDProcessor) IANANumber() string {
+	return p.ianaNumber
+}
+
+// SetIANANumber sets iana_number to v
+func (p *CommunityIDProcessor) SetIANANumber(v string) {
+	p.ianaNumber = v
+}
+
+// ICMPType is the field containing the ICMP type. Defaults to "icmp.type".
+func (p CommunityIDProcessor) ICMPType() string {
+	return p.icmpType
+}
+
+// SetICMPType sets icmp_type to v
+func (p *CommunityIDProcessor) SetICMPType(v string) {
+	p.icmpType = v
+}
+
+// ICMPCode is the field containing the ICMP code. Defaults to "icmp.code".
+func (p CommunityIDProcessor) ICMPCode() string {
+	return p.icmpCode
+}
+
+// SetICMPCode sets icmp_code to v
+func (p *CommunityIDProcessor) SetICMPCode(v string) {
+	p.icmpCode = v
+}
+
+// Transport is the field containing the transport protocol. Used only when
+// iana_number is not present. Defaults to "network.transport".
+func (p CommunityIDProcessor) Transport() string {
+	return p.transport
+}
+
+// SetTransport sets transport to v
+func (p *CommunityIDProcessor) SetTransport(v string) {
+	p.transport = v
+}
+
+// Seed for the community ID hash. Must be between 0 and 65535 (inclusive).
+func (p CommunityIDProcessor) Seed() int {
+	return p.seed
+}
+
+// SetSeed sets seed to v
+func (p *CommunityIDProcessor) SetSeed(v int) {
+	p.seed = v
+}
+
+func (p CommunityIDProcessor) MarshalBSON() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+func (p CommunityIDProcessor) MarshalJSON() ([]byte, error) {
+	return communityIDProcessor{
+		SourceIP:        p.sourceIP,
+		SourcePort:      p.sourcePort,
+		DestinationIP:   p.destinationIP,
+		DestinationPort: p.destinationPort,
+		IANANumber:      p.ianaNumber,
+		ICMPType:        p.icmpType,
+		ICMPCode:        p.icmpCode,
+		Transport:       p.transport,
+		TargetField:     p.targetField,
+		Seed:            p.seed,
+		IgnoreMissing:   p.ignoreMissing,
+		Description:     p.description,
+		If:              p.ifCond,
+		IgnoreFailure:   p.ignoreFailure,
+		OnFailure:       p.onFailure,
+		Tag:             p.tag,
+	}.MarshalJSON()
+}
+
+func (p *CommunityIDProcessor) UnmarshalBSON(data []byte) error {
+	return p.UnmarshalJSON(data)
+}
+
+func (p *CommunityIDProcessor) UnmarshalJSON(data []byte) error {
+	*p = CommunityIDProcessor{}
+	var v communityIDProcessor
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	p.sourceIP = v.SourceIP
+	p.sourcePort = v.SourcePort
+	p.destinationIP = v.DestinationIP
+	p.destinationPort = v.DestinationPort
+	p.ianaNumber = v.IANANumber
+	p.icmpType = v.ICMPType
+	p.icmpCode = v.ICMPCode
+	p.transport = v.Transport
+	p.targetField = v.TargetField
+	p.seed = v.Seed
+	p.ignoreMissing = v.IgnoreMissing
+	p.description = v.Description
+	p.ifCond = v.If
+	p.ignoreFailure = v.IgnoreFailure
+	p.onFailure = v.OnFailure
+	p.tag = v.Tag
+	return nil
+}
+
+//easyjson:json
+type communityIDProcessor struct {
+	SourceIP        string     `json:"source_ip,omitempty"`
+	SourcePort      string     `json:"source_port,omitempty"`
+	DestinationIP   string     `json:"destination_ip,omitempty"`
+	DestinationPort string     `json:"destination_port,omitempty"`
+	IANANumber      string     `json:"iana_number,omitempty"`
+	ICMPType        string     `json:"icmp_type,omitempty"`
+	ICMPCode        string     `json:"icmp_code,omitempty"`
+	Transport       string     `json:"transport,omitempty"`
+	TargetField     string     `json:"target_field,omitempty"`
+	Seed            int        `json:"seed,omitempty"`
+	IgnoreMissing   bool       `json:"ignore_missing,omitempty"`
+	Description     string     `json:"description,omitempty"`
+	If              string     `json:"if,omitempty"`
+	IgnoreFailure   bool       `json:"ignore_failure,omitempty"`
+	OnFailure       Processors `json:"on_failure,omitempty"`
+	Tag             string     `json:"tag,omitempty"`
+}
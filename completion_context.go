@@ -0,0 +1,57 @@
+package picker
+
+import "fmt"
+
+// CompletionContextType is the type of a completion context
+type CompletionContextType string
+
+const (
+	// CompletionContextTypeCategory associates one or more categories with
+	// suggestions at index time. Suggestions can then be filtered and boosted
+	// by category.
+	CompletionContextTypeCategory CompletionContextType = "category"
+	// CompletionContextTypeGeo associates one or more geo points with
+	// suggestions at index time. Suggestions can then be filtered and boosted
+	// by their proximity to a geo point.
+	CompletionContextTypeGeo CompletionContextType = "geo"
+)
+
+func (t CompletionContextType) String() string {
+	return string(t)
+}
+
+func (t CompletionContextType) IsValid() bool {
+	return t == CompletionContextTypeCategory || t == CompletionContextTypeGeo
+}
+
+// CompletionContext is a context mapping of a CompletionField.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-suggesters.html#context-suggester
+//
+//easyjson:json
+type CompletionContext struct {
+	// The name of the context (Required)
+	Name string `json:"name"`
+	// The type of the context, "category" or "geo" (Required)
+	Type CompletionContextType `json:"type"`
+	// The field of the document to read the context from. If not set, the
+	// context must be provided with each suggestion at index time.
+	Path string `json:"path,omitempty"`
+	// The precision of the geohash of a geo context, either a number between 1
+	// and 12 or a distance such as "1km". Defaults to 6.
+	Precision interface{} `json:"precision,omitempty"`
+}
+
+// Validate checks that c has a name and a valid type
+func (c CompletionContext) Validate() error {
+	if len(c.Name) == 0 {
+		return ErrContextNameRequired
+	}
+	if !c.Type.IsValid() {
+		return fmt.Errorf("%w <%s>", ErrInvalidContextType, c.Type)
+	}
+	if c.Precision != nil && c.Type != CompletionContextTypeGeo {
+		return fmt.Errorf("%w; precision is only supported by geo contexts <%s>", ErrInvalidContextType, c.Name)
+	}
+	return nil
+}
@@ -1,6 +1,9 @@
 package picker
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+)
 
 type Completioner interface {
 	Completion() (*CompletionField, error)
@@ -51,6 +54,11 @@ type CompletionFieldParams struct {
 	// influenced by the default value since prefix completions seldom grow
 	// beyond prefixes longer than a handful of characters.
 	MaxInputLength interface{} `json:"max_input_length,omitempty"`
+	// Contexts enable filtering and boosting of completion suggestions by
+	// category or geo location. (Optional)
+	//
+	// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-suggesters.html#context-suggester
+	Contexts []CompletionContext `json:"contexts,omitempty"`
 }
 
 func (CompletionFieldParams) Type() FieldType {
@@ -76,6 +84,10 @@ func (p CompletionFieldParams) Completion() (*CompletionField, error) {
 	if err != nil {
 		e.Append(err)
 	}
+	err = f.SetContexts(p.Contexts)
+	if err != nil {
+		e.Append(err)
+	}
 	f.SetAnalyzer(p.Analyzer)
 	f.SetSearchAnalyzer(p.SearchAnalyzer)
 	f.SetSearchQuoteAnalyzer(p.SearchQuoteAnalyzer)
@@ -102,6 +114,41 @@ type CompletionField struct {
 	preserveSeperatorsParam
 	preservePositionIncrementsParam
 	maxInputLengthParam
+	contexts []CompletionContext
+}
+
+// Contexts are the category and geo contexts of the field which completion
+// suggestions can be filtered and boosted by.
+func (c CompletionField) Contexts() []CompletionContext {
+	return c.contexts
+}
+
+// SetContexts sets the contexts to v. Each context must have a unique name
+// and a valid type.
+func (c *CompletionField) SetContexts(v []CompletionContext) error {
+	names := make(map[string]struct{}, len(v))
+	for _, ctx := range v {
+		err := ctx.Validate()
+		if err != nil {
+			return err
+		}
+		if _, exists := names[ctx.Name]; exists {
+			return fmt.Errorf("%w <%s>", ErrDuplicateContextName, ctx.Name)
+		}
+		names[ctx.Name] = struct{}{}
+	}
+	c.contexts = v
+	return nil
+}
+
+// Context returns the context with the given name
+func (c CompletionField) Context(name string) (CompletionContext, bool) {
+	for _, ctx := range c.contexts {
+		if ctx.Name == name {
+			return ctx, true
+		}
+	}
+	return CompletionContext{}, false
 }
 
 func (c *CompletionField) Field() (Field, error) {
@@ -127,6 +174,7 @@ func (c CompletionField) MarshalJSON() ([]byte, error) {
 		PreserveSeperators:         c.preserveSeperators.Value(),
 		PreservePositionIncrements: c.preservePositionIncrements.Value(),
 		MaxInputLength:             c.maxInputLength.Value(),
+		Contexts:                   c.contexts,
 		Type:                       c.Type(),
 	})
 }
@@ -148,11 +196,12 @@ func (c *CompletionField) UnmarshalJSON(data []byte) error {
 
 //easyjson:json
 type completionField struct {
-	Analyzer                   string      `json:"analyzer,omitempty"`
-	SearchAnalyzer             string      `json:"search_analyzer,omitempty"`
-	SearchQuoteAnalyzer        string      `json:"search_quote_analyzer,omitempty"`
-	PreserveSeperators         interface{} `json:"preserve_separators,omitempty"`
-	PreservePositionIncrements interface{} `json:"preserve_position_increments,omitempty"`
-	MaxInputLength             interface{} `json:"max_input_length,omitempty"`
-	Type                       FieldType   `json:"type"`
+	Analyzer                   string              `json:"analyzer,omitempty"`
+	SearchAnalyzer             string              `json:"search_analyzer,omitempty"`
+	SearchQuoteAnalyzer        string              `json:"search_quote_analyzer,omitempty"`
+	PreserveSeperators         interface{}         `json:"preserve_separators,omitempty"`
+	PreservePositionIncrements interface{}         `json:"preserve_position_increments,omitempty"`
+	MaxInputLength             interface{}         `json:"max_input_length,omitempty"`
+	Contexts                   []CompletionContext `json:"contexts,omitempty"`
+	Type                       FieldType           `json:"type"`
 }
@@ -0,0 +1,200 @@
+package picker
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/chanced/dynamic"
+)
+
+// CompletionSuggester provides auto-complete/search-as-you-type functionality
+// using a CompletionField. The text to complete is provided as the Prefix or
+// Regex of the Suggester.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-suggesters.html#completion-suggester
+//
+//easyjson:json
+type CompletionSuggester struct {
+	// The name of the completion field to search for suggestions (Required)
+	Field string `json:"field"`
+	// The number of suggestions to return. Defaults to 5.
+	Size int `json:"size,omitempty"`
+	// Whether duplicate suggestions should be filtered out. Defaults to false.
+	SkipDuplicates bool `json:"skip_duplicates,omitempty"`
+	// Enables typos in the prefix
+	Fuzzy *CompletionFuzzy `json:"fuzzy,omitempty"`
+	// Options of a regex completion
+	RegexOptions *CompletionRegexOptions `json:"regex_options,omitempty"`
+	// Filters and boosts suggestions by the category or geo contexts of the
+	// field, keyed by the name of the context.
+	Contexts map[string][]CompletionContextQuery `json:"contexts,omitempty"`
+}
+
+// Validate checks that the field is set
+func (s CompletionSuggester) Validate() error {
+	if len(s.Field) == 0 {
+		return ErrFieldRequired
+	}
+	return nil
+}
+
+// validateFields checks that the field of s is a CompletionField and that
+// each context query matches a context of the field.
+func (s CompletionSuggester) validateFields(props Fields) error {
+	f, ok := props.Lookup(s.Field)
+	if !ok {
+		return fmt.Errorf("%w <%s>", ErrFieldNotFound, s.Field)
+	}
+	cf, ok := f.(*CompletionField)
+	if !ok {
+		return fmt.Errorf("%w; <%s> is of type <%s>", ErrCompletionFieldRequired, s.Field, f.Type())
+	}
+	for name, queries := range s.Contexts {
+		ctx, ok := cf.Context(name)
+		if !ok {
+			return fmt.Errorf("%w <%s>", ErrContextNotFound, name)
+		}
+		for _, q := range queries {
+			err := q.validate(ctx)
+			if err != nil {
+				return newFieldError(err, name)
+			}
+		}
+	}
+	return nil
+}
+
+// CompletionFuzzy enables typos in the prefix of a CompletionSuggester
+//
+//easyjson:json
+type CompletionFuzzy struct {
+	// The fuzziness factor. Defaults to "AUTO".
+	Fuzziness interface{} `json:"fuzziness,omitempty"`
+	// If set to true, transpositions are counted as one change instead of
+	// two. Defaults to true.
+	Transpositions *bool `json:"transpositions,omitempty"`
+	// Minimum length of the input before fuzzy suggestions are returned.
+	// Defaults to 3.
+	MinLength int `json:"min_length,omitempty"`
+	// Minimum length of the input, which is not checked for fuzzy
+	// alternatives. Defaults to 1.
+	PrefixLength int `json:"prefix_length,omitempty"`
+	// If true, all measurements (like fuzzy edit distance, transpositions,
+	// and lengths) are measured in Unicode code points instead of in bytes.
+	UnicodeAware bool `json:"unicode_aware,omitempty"`
+}
+
+// CompletionRegexOptions are the options of a regex completion
+//
+//easyjson:json
+type CompletionRegexOptions struct {
+	// Possible flags are ALL (default), ANYSTRING, COMPLEMENT, EMPTY,
+	// INTERSECTION, INTERVAL, or NONE.
+	Flags string `json:"flags,omitempty"`
+	// Regular expressions are dangerous because it's easy to accidentally
+	// create an innocuous looking one that requires an exponential number of
+	// internal determinized automaton states (and corresponding RAM and CPU)
+	// for Lucene to execute. Defaults to 10000.
+	MaxDeterminizedStates int `json:"max_determinized_states,omitempty"`
+}
+
+// CompletionContextQuery filters and boosts completion suggestions by a
+// context.
+//
+// For a category context, Context is the category. For a geo context, Context
+// is a geo point, such as a map with lat and lon or a geohash.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-suggesters.html#context-suggester
+type CompletionContextQuery struct {
+	// The value of the context to filter or boost suggestions by (Required)
+	Context interface{}
+	// The factor by which the score of the suggestion should be boosted
+	Boost float64
+	// Whether the category value should be treated as a prefix or not. Only
+	// valid for category contexts.
+	Prefix bool
+	// The precision of the geohash to encode the query geo point. Only valid
+	// for geo contexts.
+	Precision interface{}
+	// Accepts an array of precision values at which neighbouring geohashes
+	// should be taken into account. Only valid for geo contexts.
+	Neighbours []interface{}
+}
+
+func (q CompletionContextQuery) validate(ctx CompletionContext) error {
+	if q.Context == nil {
+		return ErrContextRequired
+	}
+	switch ctx.Type {
+	case CompletionContextTypeCategory:
+		if q.Precision != nil || len(q.Neighbours) > 0 {
+			return fmt.Errorf("%w; precision and neighbours are only supported by geo contexts", ErrInvalidContextQuery)
+		}
+		switch q.Context.(type) {
+		case string, bool, float64, int, int64:
+		default:
+			return fmt.Errorf("%w; category contexts must be a string, number, or bool", ErrInvalidContextQuery)
+		}
+	case CompletionContextTypeGeo:
+		if q.Prefix {
+			return fmt.Errorf("%w; prefix is only supported by category contexts", ErrInvalidContextQuery)
+		}
+	}
+	return nil
+}
+
+func (q CompletionContextQuery) MarshalBSON() ([]byte, error) {
+	return q.MarshalJSON()
+}
+
+// MarshalJSON encodes q as its context value if no other options are set,
+// otherwise as an object.
+func (q CompletionContextQuery) MarshalJSON() ([]byte, error) {
+	if q.Boost == 0 && !q.Prefix && q.Precision == nil && len(q.Neighbours) == 0 {
+		return json.Marshal(q.Context)
+	}
+	return completionContextQuery{
+		Context:    q.Context,
+		Boost:      q.Boost,
+		Prefix:     q.Prefix,
+		Precision:  q.Precision,
+		Neighbours: q.Neighbours,
+	}.MarshalJSON()
+}
+
+func (q *CompletionContextQuery) UnmarshalBSON(data []byte) error {
+	return q.UnmarshalJSON(data)
+}
+
+// UnmarshalJSON accepts either an object with a context or a bare context
+// value
+func (q *CompletionContextQuery) UnmarshalJSON(data []byte) error {
+	*q = CompletionContextQuery{}
+	d := dynamic.JSON(data)
+	if d.IsObject() {
+		var obj dynamic.JSONObject
+		err := json.Unmarshal(d, &obj)
+		if err != nil {
+			return err
+		}
+		if _, ok := obj["context"]; ok {
+			var v completionContextQuery
+			err = v.UnmarshalJSON(d)
+			if err != nil {
+				return err
+			}
+			*q = CompletionContextQuery(v)
+			return nil
+		}
+	}
+	return json.Unmarshal(d, &q.Context)
+}
+
+//easyjson:json
+type completionContextQuery struct {
+	Context    interface{}   `json:"context"`
+	Boost      float64       `json:"boost,omitempty"`
+	Prefix     bool          `json:"prefix,omitempty"`
+	Precision  interface{}   `json:"precision,omitempty"`
+	Neighbours []interface{} `json:"neighbours,omitempty"`
+}
@@ -0,0 +1,385 @@
+package picker
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/chanced/dynamic"
+)
+
+const DefaultCompositeSize = 10
+
+// CompositeTermsSource is a terms value source of a composite aggregation.
+//
+//easyjson:json
+type CompositeTermsSource struct {
+	// The field to aggregate on. Either Field or Script is required.
+	Field  string  `json:"field,omitempty"`
+	Script *Script `json:"script,omitempty"`
+	// If true, documents without a value for Field are placed in a bucket
+	// with a null key.
+	MissingBucket bool `json:"missing_bucket,omitempty"`
+	// Sort order of the values. Defaults to "asc".
+	Order     SortOrder `json:"order,omitempty"`
+	ValueType string    `json:"value_type,omitempty"`
+}
+
+// CompositeHistogramSource is a histogram value source of a composite
+// aggregation.
+//
+//easyjson:json
+type CompositeHistogramSource struct {
+	// The field to aggregate on. Either Field or Script is required.
+	Field  string  `json:"field,omitempty"`
+	Script *Script `json:"script,omitempty"`
+	// The interval of the histogram (Required)
+	Interval      float64   `json:"interval"`
+	MissingBucket bool      `json:"missing_bucket,omitempty"`
+	Order         SortOrder `json:"order,omitempty"`
+}
+
+// CompositeDateHistogramSource is a date_histogram value source of a
+// composite aggregation.
+//
+// Exactly one of CalendarInterval or FixedInterval is required.
+//
+//easyjson:json
+type CompositeDateHistogramSource struct {
+	// The field to aggregate on. Either Field or Script is required.
+	Field  string  `json:"field,omitempty"`
+	Script *Script `json:"script,omitempty"`
+	// Calendar-aware interval, such as "1M" or "quarter"
+	CalendarInterval string `json:"calendar_interval,omitempty"`
+	// Fixed interval of SI units, such as "90m" or "2d"
+	FixedInterval string `json:"fixed_interval,omitempty"`
+	// Format of the key. Keys are returned as epoch milliseconds otherwise.
+	Format string `json:"format,omitempty"`
+	// Time zone used for bucketing and rounding. Defaults to UTC.
+	TimeZone string `json:"time_zone,omitempty"`
+	// Shifts the start value of each bucket by the specified positive (+) or
+	// negative (-) duration, such as "1h" or "-1d".
+	Offset        string    `json:"offset,omitempty"`
+	MissingBucket bool      `json:"missing_bucket,omitempty"`
+	Order         SortOrder `json:"order,omitempty"`
+}
+
+// CompositeGeotileGridSource is a geotile_grid value source of a composite
+// aggregation.
+//
+//easyjson:json
+type CompositeGeotileGridSource struct {
+	// The geo_point field to aggregate on (Required)
+	Field string `json:"field"`
+	// The integer zoom of the key used to define cells/buckets. Must be
+	// between 0 and 29. Defaults to 7.
+	Precision *int `json:"precision,omitempty"`
+	// Restricts the cells considered to those that intersect the bounds.
+	Bounds        interface{} `json:"bounds,omitempty"`
+	MissingBucket bool        `json:"missing_bucket,omitempty"`
+	Order         SortOrder   `json:"order,omitempty"`
+}
+
+// CompositeSource is a named value source of a composite aggregation. Exactly
+// one of Terms, Histogram, DateHistogram, or GeotileGrid is required.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-composite-aggregation.html#_values_source_2
+type CompositeSource struct {
+	// The name of the source, used as the key within each bucket's composite
+	// key (Required)
+	Name          string
+	Terms         *CompositeTermsSource
+	Histogram     *CompositeHistogramSource
+	DateHistogram *CompositeDateHistogramSource
+	GeotileGrid   *CompositeGeotileGridSource
+}
+
+// Kind returns the type of the source, e.g. "terms"
+func (cs CompositeSource) Kind() AggKind {
+	switch {
+	case cs.Terms != nil:
+		return AggKindTerms
+	case cs.Histogram != nil:
+		return AggKindHistogram
+	case cs.DateHistogram != nil:
+		return AggKindDateHistogram
+	case cs.GeotileGrid != nil:
+		return AggKindGeotileGrid
+	}
+	return ""
+}
+
+// Validate checks that cs has a name and exactly one valid source
+func (cs CompositeSource) Validate() error {
+	if len(cs.Name) == 0 {
+		return ErrSourceNameRequired
+	}
+	n := 0
+	var order SortOrder
+	var err error
+	if cs.Terms != nil {
+		n++
+		order = cs.Terms.Order
+		if len(cs.Terms.Field) == 0 && cs.Terms.Script == nil {
+			err = ErrFieldOrScriptRequired
+		}
+	}
+	if cs.Histogram != nil {
+		n++
+		order = cs.Histogram.Order
+		if len(cs.Histogram.Field) == 0 && cs.Histogram.Script == nil {
+			err = ErrFieldOrScriptRequired
+		} else if cs.Histogram.Interval <= 0 {
+			err = ErrInvalidInterval
+		}
+	}
+	if cs.DateHistogram != nil {
+		n++
+		dh := cs.DateHistogram
+		order = dh.Order
+		switch {
+		case len(dh.Field) == 0 && dh.Script == nil:
+			err = ErrFieldOrScriptRequired
+		case len(dh.CalendarInterval) > 0 && len(dh.FixedInterval) > 0:
+			err = ErrMultipleIntervals
+		case len(dh.CalendarInterval) == 0 && len(dh.FixedInterval) == 0:
+			err = ErrIntervalRequired
+		}
+	}
+	if cs.GeotileGrid != nil {
+		n++
+		order = cs.GeotileGrid.Order
+		if len(cs.GeotileGrid.Field) == 0 {
+			err = ErrFieldRequired
+		} else if p := cs.GeotileGrid.Precision; p != nil && (*p < 0 || *p > 29) {
+			err = fmt.Errorf("%w <%d>; expected a value between 0 and 29", ErrInvalidPrecision, *p)
+		}
+	}
+	if n == 0 {
+		return newFieldError(ErrCompositeSourceRequired, cs.Name)
+	}
+	if n > 1 {
+		return newFieldError(ErrMultipleCompositeSources, cs.Name)
+	}
+	if err == nil && len(order) > 0 && !order.IsValid() {
+		err = fmt.Errorf("%w <%s>", ErrInvalidSortOrder, order)
+	}
+	if err != nil {
+		return newFieldError(err, cs.Name)
+	}
+	return nil
+}
+
+func (cs CompositeSource) MarshalBSON() ([]byte, error) {
+	return cs.MarshalJSON()
+}
+
+func (cs CompositeSource) MarshalJSON() ([]byte, error) {
+	var body dynamic.JSON
+	var err error
+	switch {
+	case cs.Terms != nil:
+		body, err = cs.Terms.MarshalJSON()
+	case cs.Histogram != nil:
+		body, err = cs.Histogram.MarshalJSON()
+	case cs.DateHistogram != nil:
+		body, err = cs.DateHistogram.MarshalJSON()
+	case cs.GeotileGrid != nil:
+		body, err = cs.GeotileGrid.MarshalJSON()
+	default:
+		return nil, newFieldError(ErrCompositeSourceRequired, cs.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(map[string]dynamic.JSONObject{
+		cs.Name: {cs.Kind().String(): body},
+	})
+}
+
+func (cs *CompositeSource) UnmarshalBSON(data []byte) error {
+	return cs.UnmarshalJSON(data)
+}
+
+func (cs *CompositeSource) UnmarshalJSON(data []byte) error {
+	*cs = CompositeSource{}
+	name, d, err := unmarshalField(data)
+	if err != nil {
+		return err
+	}
+	cs.Name = name
+	kind, body, err := unmarshalField(d)
+	if err != nil {
+		return err
+	}
+	switch AggKind(kind) {
+	case AggKindTerms:
+		cs.Terms = &CompositeTermsSource{}
+		return cs.Terms.UnmarshalJSON(body)
+	case AggKindHistogram:
+		cs.Histogram = &CompositeHistogramSource{}
+		return cs.Histogram.UnmarshalJSON(body)
+	case AggKindDateHistogram:
+		cs.DateHistogram = &CompositeDateHistogramSource{}
+		return cs.DateHistogram.UnmarshalJSON(body)
+	case AggKindGeotileGrid:
+		cs.GeotileGrid = &CompositeGeotileGridSource{}
+		return cs.GeotileGrid.UnmarshalJSON(body)
+	}
+	return newFieldError(fmt.Errorf("%w <%s>", ErrUnsupportedType, kind), name)
+}
+
+// CompositeAggParams creates a CompositeAgg, a multi-bucket aggregation that
+// creates composite buckets from different sources.
+//
+// Unlike the other multi-bucket aggregations, you can use the composite
+// aggregation to paginate all buckets from a multi-level aggregation
+// efficiently. See CompositePager.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-composite-aggregation.html
+type CompositeAggParams struct {
+	// The value sources of the composite buckets (Required)
+	Sources []CompositeSource
+	// The number of composite buckets that should be returned. Defaults to 10.
+	Size int
+	// The composite key of the bucket to paginate after, typically the
+	// after_key of the previous response
+	After map[string]interface{}
+	// Sub-aggregations
+	Aggregations Aggs
+	Meta         map[string]interface{}
+}
+
+func (CompositeAggParams) Kind() AggKind {
+	return AggKindComposite
+}
+
+func (p CompositeAggParams) Clause() (AggClause, error) {
+	return p.Composite()
+}
+
+func (p CompositeAggParams) Composite() (*CompositeAgg, error) {
+	a := &CompositeAgg{}
+	err := a.SetSources(p.Sources)
+	if err != nil {
+		return a, newAggError(err, AggKindComposite)
+	}
+	a.SetSize(p.Size)
+	a.SetAfter(p.After)
+	err = a.SetAggregations(p.Aggregations)
+	if err != nil {
+		return a, newAggError(err, AggKindComposite)
+	}
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// CompositeAgg is a multi-bucket aggregation that creates composite buckets
+// from different sources.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-composite-aggregation.html
+type CompositeAgg struct {
+	sources []CompositeSource
+	size    int
+	after   map[string]interface{}
+	aggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*CompositeAgg)(nil)
+
+func (CompositeAgg) Kind() AggKind {
+	return AggKindComposite
+}
+
+func (a *CompositeAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// Sources are the value sources of the composite buckets
+func (a CompositeAgg) Sources() []CompositeSource {
+	return a.sources
+}
+
+// SetSources sets the sources to v. Each source must have a unique name.
+func (a *CompositeAgg) SetSources(v []CompositeSource) error {
+	if len(v) == 0 {
+		return ErrSourcesRequired
+	}
+	names := make(map[string]struct{}, len(v))
+	for _, s := range v {
+		err := s.Validate()
+		if err != nil {
+			return err
+		}
+		if _, exists := names[s.Name]; exists {
+			return newFieldError(ErrDuplicateSourceName, s.Name)
+		}
+		names[s.Name] = struct{}{}
+	}
+	a.sources = v
+	return nil
+}
+
+// Size is the number of composite buckets that should be returned. Defaults
+// to 10.
+func (a CompositeAgg) Size() int {
+	if a.size == 0 {
+		return DefaultCompositeSize
+	}
+	return a.size
+}
+
+// SetSize sets size to v
+func (a *CompositeAgg) SetSize(v int) {
+	a.size = v
+}
+
+// After is the composite key of the bucket to paginate after
+func (a CompositeAgg) After() map[string]interface{} {
+	return a.after
+}
+
+// SetAfter sets after to v
+func (a *CompositeAgg) SetAfter(v map[string]interface{}) {
+	if len(v) == 0 {
+		a.after = nil
+		return
+	}
+	a.after = v
+}
+
+func (a CompositeAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a CompositeAgg) MarshalJSON() ([]byte, error) {
+	return compositeAgg{
+		Sources: a.sources,
+		Size:    a.size,
+		After:   a.after,
+	}.MarshalJSON()
+}
+
+func (a *CompositeAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *CompositeAgg) UnmarshalJSON(data []byte) error {
+	*a = CompositeAgg{}
+	p := compositeAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.sources = p.Sources
+	a.SetSize(p.Size)
+	a.SetAfter(p.After)
+	return nil
+}
+
+//easyjson:json
+type compositeAgg struct {
+	Sources []CompositeSource      `json:"sources"`
+	Size    int                    `json:"size,omitempty"`
+	After   map[string]interface{} `json:"after,omitempty"`
+}
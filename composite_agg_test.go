@@ -0,0 +1,232 @@
+package picker_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/chanced/cmpjson"
+	"github.com/chanced/picker"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompositeAgg(t *testing.T) {
+	assert := require.New(t)
+	data := []byte(`{
+		"aggs": {
+			"my_buckets": {
+				"composite": {
+					"size": 2,
+					"sources": [
+						{ "user": { "terms": { "field": "user.id", "missing_bucket": true } } },
+						{ "price": { "histogram": { "field": "price", "interval": 5 } } },
+						{ "date": { "date_histogram": { "field": "timestamp", "calendar_interval": "1d", "order": "desc" } } },
+						{ "tile": { "geotile_grid": { "field": "location", "precision": 8 } } }
+					],
+					"after": { "user": "kimchy" }
+				},
+				"aggs": {
+					"avg_price": { "avg": { "field": "price" } }
+				}
+			}
+		}
+	}`)
+	var s picker.Search
+	err := json.Unmarshal(data, &s)
+	assert.NoError(err)
+	c, ok := s.Aggregations().Get("my_buckets").(*picker.CompositeAgg)
+	assert.True(ok)
+	assert.Len(c.Sources(), 4)
+	assert.Equal(picker.AggKindDateHistogram, c.Sources()[2].Kind())
+	assert.Equal("kimchy", c.After()["user"])
+	sd, err := json.Marshal(s)
+	assert.NoError(err)
+	assert.True(cmpjson.Equal(data, sd), cmpjson.Diff(data, sd))
+
+	precision := 8
+	s2, err := picker.NewSearch(picker.SearchParams{
+		Aggregations: picker.Aggs{
+			"my_buckets": picker.CompositeAggParams{
+				Size: 2,
+				Sources: []picker.CompositeSource{
+					{Name: "user", Terms: &picker.CompositeTermsSource{Field: "user.id", MissingBucket: true}},
+					{Name: "price", Histogram: &picker.CompositeHistogramSource{Field: "price", Interval: 5}},
+					{Name: "date", DateHistogram: &picker.CompositeDateHistogramSource{Field: "timestamp", CalendarInterval: "1d", Order: picker.SortOrderDescending}},
+					{Name: "tile", GeotileGrid: &picker.CompositeGeotileGridSource{Field: "location", Precision: &precision}},
+				},
+				After: map[string]interface{}{"user": "kimchy"},
+				Aggregations: picker.Aggs{
+					"avg_price": picker.AvgAggParams{Field: "price"},
+				},
+			},
+		},
+	})
+	assert.NoError(err)
+	sd2, err := json.Marshal(s2)
+	assert.NoError(err)
+	assert.True(cmpjson.Equal(data, sd2), cmpjson.Diff(data, sd2))
+
+	_, err = picker.CompositeAggParams{}.Composite()
+	assert.True(errors.Is(err, picker.ErrSourcesRequired))
+	_, err = picker.CompositeAggParams{Sources: []picker.CompositeSource{{Name: "user"}}}.Composite()
+	assert.True(errors.Is(err, picker.ErrCompositeSourceRequired))
+	_, err = picker.CompositeAggParams{Sources: []picker.CompositeSource{{
+		Name:      "user",
+		Terms:     &picker.CompositeTermsSource{Field: "user.id"},
+		Histogram: &picker.CompositeHistogramSource{Field: "price", Interval: 5},
+	}}}.Composite()
+	assert.True(errors.Is(err, picker.ErrMultipleCompositeSources))
+	_, err = picker.CompositeAggParams{Sources: []picker.CompositeSource{
+		{Name: "user", Terms: &picker.CompositeTermsSource{Field: "user.id"}},
+		{Name: "user", Terms: &picker.CompositeTermsSource{Field: "user.name"}},
+	}}.Composite()
+	assert.True(errors.Is(err, picker.ErrDuplicateSourceName))
+	_, err = picker.CompositeAggParams{Sources: []picker.CompositeSource{
+		{Name: "date", DateHistogram: &picker.CompositeDateHistogramSource{Field: "timestamp"}},
+	}}.Composite()
+	assert.True(errors.Is(err, picker.ErrIntervalRequired))
+}
+
+func TestCompositePager(t *testing.T) {
+	assert := require.New(t)
+	users := []string{"a", "b", "c", "d", "e"}
+	s, err := picker.NewSearch(picker.SearchParams{
+		Aggregations: picker.Aggs{
+			"by_user": picker.CompositeAggParams{
+				Size: 2,
+				Sources: []picker.CompositeSource{
+					{Name: "user", Terms: &picker.CompositeTermsSource{Field: "user"}},
+				},
+			},
+		},
+	})
+	assert.NoError(err)
+
+	var requests []string
+	fetch := func(ctx context.Context, s *picker.Search) ([]byte, error) {
+		body, err := json.Marshal(s)
+		if err != nil {
+			return nil, err
+		}
+		var req struct {
+			Aggs struct {
+				ByUser struct {
+					Composite struct {
+						Size  int               `json:"size"`
+						After map[string]string `json:"after"`
+					} `json:"composite"`
+				} `json:"by_user"`
+			} `json:"aggs"`
+		}
+		err = json.Unmarshal(body, &req)
+		if err != nil {
+			return nil, err
+		}
+		c := req.Aggs.ByUser.Composite
+		requests = append(requests, c.After["user"])
+		start := 0
+		for i, u := range users {
+			if u == c.After["user"] {
+				start = i + 1
+			}
+		}
+		end := start + c.Size
+		if end > len(users) {
+			end = len(users)
+		}
+		buckets := []map[string]interface{}{}
+		for _, u := range users[start:end] {
+			buckets = append(buckets, map[string]interface{}{"key": map[string]string{"user": u}, "doc_count": 1})
+		}
+		res := map[string]interface{}{"buckets": buckets}
+		if len(buckets) > 0 {
+			res["after_key"] = map[string]string{"user": users[end-1]}
+		}
+		return json.Marshal(map[string]interface{}{
+			"took":         1,
+			"aggregations": map[string]interface{}{"by_user": res},
+		})
+	}
+
+	pager, err := picker.NewCompositePager(s, "by_user", fetch)
+	assert.NoError(err)
+	var seen []string
+	for pager.Next(context.Background()) {
+		for _, b := range pager.Buckets() {
+			seen = append(seen, fmt.Sprint(b.Key.(map[string]interface{})["user"]))
+		}
+	}
+	assert.NoError(pager.Err())
+	assert.Equal(users, seen)
+	assert.Equal([]string{"", "b", "d", "e"}, requests)
+	assert.Equal(4, pager.Pages())
+	assert.False(pager.Next(context.Background()))
+
+	failing := func(ctx context.Context, s *picker.Search) ([]byte, error) {
+		return nil, errors.New("boom")
+	}
+	pager, err = picker.NewCompositePager(s, "by_user", failing)
+	assert.NoError(err)
+	assert.False(pager.Next(context.Background()))
+	assert.EqualError(pager.Err(), "boom")
+
+	_, err = picker.NewCompositePager(s, "missing", fetch)
+	assert.True(errors.Is(err, picker.ErrCompositeNotFound))
+}
+
+func TestCompositePagerLongKeys(t *testing.T) {
+	assert := require.New(t)
+	s, err := picker.NewSearch(picker.SearchParams{
+		Aggregations: picker.Aggs{
+			"by_user": picker.CompositeAggParams{
+				Sources: []picker.CompositeSource{
+					{Name: "user", Terms: &picker.CompositeTermsSource{Field: "user_id"}},
+				},
+			},
+		},
+	})
+	assert.NoError(err)
+
+	var requests []string
+	fetch := func(ctx context.Context, s *picker.Search) ([]byte, error) {
+		body, err := json.Marshal(s)
+		if err != nil {
+			return nil, err
+		}
+		var req struct {
+			Aggs struct {
+				ByUser struct {
+					Composite struct {
+						After json.RawMessage `json:"after"`
+					} `json:"composite"`
+				} `json:"by_user"`
+			} `json:"aggs"`
+		}
+		err = json.Unmarshal(body, &req)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, string(req.Aggs.ByUser.Composite.After))
+		if len(requests) > 1 {
+			return []byte(`{"aggregations": {"by_user": {"buckets": []}}}`), nil
+		}
+		return []byte(`{
+			"aggregations": {
+				"by_user": {
+					"after_key": { "user": 9007199254740993 },
+					"buckets": [{ "key": { "user": 9007199254740993 }, "doc_count": 1 }]
+				}
+			}
+		}`), nil
+	}
+	pager, err := picker.NewCompositePager(s, "by_user", fetch)
+	assert.NoError(err)
+	for pager.Next(context.Background()) {
+	}
+	assert.NoError(pager.Err())
+	assert.Len(requests, 2)
+	assert.Equal(`{"user":9007199254740993}`, requests[1])
+	assert.Equal(json.Number("9007199254740993"), pager.AfterKey()["user"])
+}
@@ -0,0 +1,139 @@
+package picker
+
+import (
+	"context"
+	"fmt"
+)
+
+// CompositeFetchFunc executes search s and returns the body of the response
+type CompositeFetchFunc func(ctx context.Context, s *Search) ([]byte, error)
+
+// CompositePager pages through the buckets of a top-level composite
+// aggregation, setting after to the after_key of the previous response before
+// fetching the next page.
+//
+//	pager, err := picker.NewCompositePager(search, "by_user", fetch)
+//	if err != nil {
+//	    return err
+//	}
+//	for pager.Next(ctx) {
+//	    for _, bucket := range pager.Buckets() {
+//	        // ...
+//	    }
+//	}
+//	if err := pager.Err(); err != nil {
+//	    return err
+//	}
+//
+// Note that the pager modifies the after of the composite aggregation of the
+// Search.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-composite-aggregation.html#_pagination
+type CompositePager struct {
+	search *Search
+	name   string
+	agg    *CompositeAgg
+	fetch  CompositeFetchFunc
+	after  map[string]interface{}
+	result *MultiBucketAggResult
+	pages  int
+	done   bool
+	err    error
+}
+
+// NewCompositePager returns a CompositePager for the top-level composite
+// aggregation of s with the given name. Paging starts after the after of the
+// aggregation, if set.
+func NewCompositePager(s *Search, name string, fetch CompositeFetchFunc) (*CompositePager, error) {
+	if s == nil {
+		return nil, ErrSearchRequired
+	}
+	if fetch == nil {
+		return nil, ErrFetchRequired
+	}
+	agg, ok := s.Aggregations().Get(name).(*CompositeAgg)
+	if !ok {
+		return nil, fmt.Errorf("%w <%s>", ErrCompositeNotFound, name)
+	}
+	return &CompositePager{
+		search: s,
+		name:   name,
+		agg:    agg,
+		fetch:  fetch,
+		after:  agg.After(),
+	}, nil
+}
+
+// Next fetches the next page of buckets. It returns false when there are no
+// more buckets or an error occurred, which is available from Err.
+func (p *CompositePager) Next(ctx context.Context) bool {
+	if p.done || p.err != nil {
+		return false
+	}
+	if err := ctx.Err(); err != nil {
+		p.err = err
+		return false
+	}
+	p.agg.SetAfter(p.after)
+	data, err := p.fetch(ctx, p.search)
+	if err != nil {
+		p.err = err
+		return false
+	}
+	var res SearchResponse
+	err = res.UnmarshalJSON(data)
+	if err != nil {
+		p.err = err
+		return false
+	}
+	results, err := res.DecodeAggregations(p.search)
+	if err != nil {
+		p.err = err
+		return false
+	}
+	r, ok := results.Get(p.name).(*MultiBucketAggResult)
+	if !ok {
+		p.err = fmt.Errorf("%w in response <%s>", ErrCompositeNotFound, p.name)
+		return false
+	}
+	p.pages++
+	p.result = r
+	if len(r.Buckets) == 0 {
+		p.done = true
+		return false
+	}
+	if len(r.AfterKey) == 0 {
+		p.done = true
+	}
+	p.after = r.AfterKey
+	return true
+}
+
+// Buckets are the buckets of the current page
+func (p *CompositePager) Buckets() []Bucket {
+	if p.result == nil {
+		return nil
+	}
+	return p.result.Buckets
+}
+
+// Result is the composite result of the current page
+func (p *CompositePager) Result() *MultiBucketAggResult {
+	return p.result
+}
+
+// AfterKey is the after_key of the current page. It can be used to resume
+// paging by setting the After of the CompositeAgg.
+func (p *CompositePager) AfterKey() map[string]interface{} {
+	return p.after
+}
+
+// Pages is the number of pages fetched
+func (p *CompositePager) Pages() int {
+	return p.pages
+}
+
+// Err returns the first error encountered while paging
+func (p *CompositePager) Err() error {
+	return p.err
+}
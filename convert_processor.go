@@ -0,0 +1,154 @@
+package picker
+
+// ConvertProcessorParams creates a ConvertProcessor, which converts a field in
+// the currently ingested document to a different type, such as converting a
+// string to an integer.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/convert-processor.html
+type ConvertProcessorParams struct {
+	// Field is the field whose value is to be converted
+	Field string
+	// TargetField is the field to assign the output to. By default, field is
+	// updated in-place.
+	TargetField string
+	// Type is the type to convert the existing value to. Valid values are
+	// "integer", "long", "float", "double", "string", "boolean", "ip", and
+	// "auto".
+	Type string
+	// If IgnoreMissing is true and field does not exist or is null, the
+	// processor quietly exits without modifying the document
+	IgnoreMissing bool
+	// Description of the processor. Useful for describing the purpose of the
+	// processor or its configuration.
+	Description string
+	// Conditionally execute the processor
+	If string
+	// Ignore failures for the processor
+	IgnoreFailure bool
+	// Handle failures for the processor
+	OnFailure Procs
+	// Identifier for the processor. Useful for debugging and metrics.
+	Tag string
+}
+
+func (ConvertProcessorParams) Kind() ProcessorKind {
+	return ProcessorKindConvert
+}
+
+func (p ConvertProcessorParams) Processor() (Processor, error) {
+	return p.Convert()
+}
+
+func (p ConvertProcessorParams) Convert() (*ConvertProcessor, error) {
+	proc := &ConvertProcessor{}
+	err := proc.SetField(p.Field)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindConvert)
+	}
+	proc.SetTargetField(p.TargetField)
+	err = proc.SetType(p.Type)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindConvert)
+	}
+	proc.SetIgnoreMissing(p.IgnoreMissing)
+	proc.SetDescription(p.Description)
+	proc.SetIf(p.If)
+	proc.SetIgnoreFailure(p.IgnoreFailure)
+	err = proc.SetOnFailure(p.OnFailure)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindConvert)
+	}
+	proc.SetTag(p.Tag)
+	return proc, nil
+}
+
+// ConvertProcessor converts a field in the currently ingested document to a
+// different type, such as converting a string to an integer.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/convert-processor.html
+type ConvertProcessor struct {
+	fieldParam
+	targetFieldParam
+	typ string
+	ignoreMissingParam
+	processorParams
+}
+
+var _ Processor = (*ConvertProcessor)(nil)
+
+func (ConvertProcessor) Kind() ProcessorKind {
+	return ProcessorKindConvert
+}
+
+func (p *ConvertProcessor) Processor() (Processor, error) {
+	return p, nil
+}
+
+// Type is the type to convert the existing value to. Valid values are
+// "integer", "long", "float", "double", "string", "boolean", "ip", and "auto".
+func (p ConvertProcessor) Type() string {
+	return p.typ
+}
+
+// SetType sets type to v
+func (p *ConvertProcessor) SetType(v string) error {
+	if len(v) == 0 {
+		return ErrTypeRequired
+	}
+	p.typ = v
+	return nil
+}
+
+func (p ConvertProcessor) MarshalBSON() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+func (p ConvertProcessor) MarshalJSON() ([]byte, error) {
+	return convertProcessor{
+		Field:         p.field,
+		TargetField:   p.targetField,
+		Type:          p.typ,
+		IgnoreMissing: p.ignoreMissing,
+		Description:   p.description,
+		If:            p.ifCond,
+		IgnoreFailure: p.ignoreFailure,
+		OnFailure:     p.onFailure,
+		Tag:           p.tag,
+	}.MarshalJSON()
+}
+
+func (p *ConvertProcessor) UnmarshalBSON(data []byte) error {
+	return p.UnmarshalJSON(data)
+}
+
+func (p *ConvertProcessor) UnmarshalJSON(data []byte) error {
+	*p = ConvertProcessor{}
+	var v convertProcessor
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	p.field = v.Field
+	p.targetField = v.TargetField
+	p.typ = v.Type
+	p.ignoreMissing = v.IgnoreMissing
+	p.description = v.Description
+	p.ifCond = v.If
+	p.ignoreFailure = v.IgnoreFailure
+	p.onFailure = v.OnFailure
+	p.tag = v.Tag
+	return nil
+}
+
+//easyjson:json
+type convertProcessor struct {
+	Field         string     `json:"field,omitempty"`
+	TargetField   string     `json:"target_field,omitempty"`
+	Type          string     `json:"type,omitempty"`
+	IgnoreMissing bool       `json:"ignore_missing,omitempty"`
+	Description   string     `json:"description,omitempty"`
+	If            string     `json:"if,omitempty"`
+	IgnoreFailure bool       `json:"ignore_failure,omitempty"`
+	OnFailure     Processors `json:"on_failure,omitempty"`
+	Tag           string     `json:"tag,omitempty"`
+}
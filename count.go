@@ -3,6 +3,7 @@ package picker
 import (
 	"bytes"
 	"encoding/json"
+	"io"
 )
 
 type Counter interface {
@@ -76,3 +77,21 @@ func (c Count) MarshalBSON() ([]byte, error) {
 func (c *Count) UnmarshalBSON(data []byte) error {
 	return c.UnmarshalJSON(data)
 }
+
+// CountResponse is the response of a count request
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-count.html#search-count-api-example
+//
+//easyjson:json
+type CountResponse struct {
+	Count           int64      `json:"count"`
+	TerminatedEarly *bool      `json:"terminated_early,omitempty"`
+	Shards          ShardStats `json:"_shards"`
+}
+
+func DecodeCountResponse(body io.Reader) (*CountResponse, error) {
+	dec := json.NewDecoder(body)
+	var res CountResponse
+	err := dec.Decode(&res)
+	return &res, err
+}
@@ -0,0 +1,214 @@
+package picker
+
+// CSVProcessorParams creates a CSVProcessor, which extracts fields from a CSV
+// line out of a single text field within a document. Any empty field in CSV
+// will be skipped.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/csv-processor.html
+type CSVProcessorParams struct {
+	// Field is the field to extract data from
+	Field string
+	// TargetFields are the fields to assign extracted values to
+	TargetFields []string
+	// Separator used in CSV, has to be single character string. Defaults to
+	// ",".
+	Separator string
+	// Quote used in CSV, has to be single character string. Defaults to "\"".
+	Quote string
+	// If IgnoreMissing is true and field does not exist or is null, the
+	// processor quietly exits without modifying the document
+	IgnoreMissing bool
+	// Trim indicates whether whitespace in unquoted fields is trimmed
+	Trim bool
+	// EmptyValue is the value used to fill empty fields. Empty fields are
+	// skipped if this is not provided.
+	EmptyValue interface{}
+	// Description of the processor. Useful for describing the purpose of the
+	// processor or its configuration.
+	Description string
+	// Conditionally execute the processor
+	If string
+	// Ignore failures for the processor
+	IgnoreFailure bool
+	// Handle failures for the processor
+	OnFailure Procs
+	// Identifier for the processor. Useful for debugging and metrics.
+	Tag string
+}
+
+func (CSVProcessorParams) Kind() ProcessorKind {
+	return ProcessorKindCSV
+}
+
+func (p CSVProcessorParams) Processor() (Processor, error) {
+	return p.CSV()
+}
+
+func (p CSVProcessorParams) CSV() (*CSVProcessor, error) {
+	proc := &CSVProcessor{}
+	err := proc.SetField(p.Field)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindCSV)
+	}
+	err = proc.SetTargetFields(p.TargetFields)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindCSV)
+	}
+	proc.SetSeparator(p.Separator)
+	proc.SetQuote(p.Quote)
+	proc.SetIgnoreMissing(p.IgnoreMissing)
+	proc.SetTrim(p.Trim)
+	proc.SetEmptyValue(p.EmptyValue)
+	proc.SetDescription(p.Description)
+	proc.SetIf(p.If)
+	proc.SetIgnoreFailure(p.IgnoreFailure)
+	err = proc.SetOnFailure(p.OnFailure)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindCSV)
+	}
+	proc.SetTag(p.Tag)
+	return proc, nil
+}
+
+// CSVProcessor extracts fields from a CSV line out of a single text field
+// within a document. Any empty field in CSV will be skipped.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/csv-processor.html
+type CSVProcessor struct {
+	fieldParam
+	targetFields []string
+	separator    string
+	quote        string
+	ignoreMissingParam
+	trim       bool
+	emptyValue interface{}
+	processorParams
+}
+
+var _ Processor = (*CSVProcessor)(nil)
+
+func (CSVProcessor) Kind() ProcessorKind {
+	return ProcessorKindCSV
+}
+
+func (p *CSVProcessor) Processor() (Processor, error) {
+	return p, nil
+}
+
+// TargetFields are the fields to assign extracted values to
+func (p CSVProcessor) TargetFields() []string {
+	return p.targetFields
+}
+
+// SetTargetFields sets target_fields to v
+func (p *CSVProcessor) SetTargetFields(v []string) error {
+	if len(v) == 0 {
+		return ErrTargetFieldsRequired
+	}
+	p.targetFields = v
+	return nil
+}
+
+// Separator used in CSV, has to be single character string. Defaults to ",".
+func (p CSVProcessor) Separator() string {
+	return p.separator
+}
+
+// SetSeparator sets separator to v
+func (p *CSVProcessor) SetSeparator(v string) {
+	p.separator = v
+}
+
+// Quote used in CSV, has to be single character string. Defaults to "\"".
+func (p CSVProcessor) Quote() string {
+	return p.quote
+}
+
+// SetQuote sets quote to v
+func (p *CSVProcessor) SetQuote(v string) {
+	p.quote = v
+}
+
+// Trim indicates whether whitespace in unquoted fields is trimmed
+func (p CSVProcessor) Trim() bool {
+	return p.trim
+}
+
+// SetTrim sets trim to v
+func (p *CSVProcessor) SetTrim(v bool) {
+	p.trim = v
+}
+
+// EmptyValue is the value used to fill empty fields. Empty fields are skipped
+// if this is not provided.
+func (p CSVProcessor) EmptyValue() interface{} {
+	return p.emptyValue
+}
+
+// SetEmptyValue sets empty_value to v
+func (p *CSVProcessor) SetEmptyValue(v interface{}) {
+	p.emptyValue = v
+}
+
+func (p CSVProcessor) MarshalBSON() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+func (p CSVProcessor) MarshalJSON() ([]byte, error) {
+	return csvProcessor{
+		Field:         p.field,
+		TargetFields:  p.targetFields,
+		Separator:     p.separator,
+		Quote:         p.quote,
+		IgnoreMissing: p.ignoreMissing,
+		Trim:          p.trim,
+		EmptyValue:    p.emptyValue,
+		Description:   p.description,
+		If:            p.ifCond,
+		IgnoreFailure: p.ignoreFailure,
+		OnFailure:     p.onFailure,
+		Tag:           p.tag,
+	}.MarshalJSON()
+}
+
+func (p *CSVProcessor) UnmarshalBSON(data []byte) error {
+	return p.UnmarshalJSON(data)
+}
+
+func (p *CSVProcessor) UnmarshalJSON(data []byte) error {
+	*p = CSVProcessor{}
+	var v csvProcessor
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	p.field = v.Field
+	p.targetFields = v.TargetFields
+	p.separator = v.Separator
+	p.quote = v.Quote
+	p.ignoreMissing = v.IgnoreMissing
+	p.trim = v.Trim
+	p.emptyValue = v.EmptyValue
+	p.description = v.Description
+	p.ifCond = v.If
+	p.ignoreFailure = v.IgnoreFailure
+	p.onFailure = v.OnFailure
+	p.tag = v.Tag
+	return nil
+}
+
+//easyjson:json
+type csvProcessor struct {
+	Field         string      `json:"field,omitempty"`
+	TargetFields  []string    `json:"target_fields,omitempty"`
+	Separator     string      `json:"separator,omitempty"`
+	Quote         string      `json:"quote,omitempty"`
+	IgnoreMissing bool        `json:"ignore_missing,omitempty"`
+	Trim          bool        `json:"trim,omitempty"`
+	EmptyValue    interface{} `json:"empty_value,omitempty"`
+	Description   string      `json:"description,omitempty"`
+	If            string      `json:"if,omitempty"`
+	IgnoreFailure bool        `json:"ignore_failure,omitempty"`
+	OnFailure     Processors  `json:"on_failure,omitempty"`
+	Tag           string      `json:"tag,omitempty"`
+}
@@ -0,0 +1,90 @@
+package picker
+
+// CumulativeCardinalityAggParams creates a CumulativeCardinalityAgg, a parent
+// pipeline aggregation which calculates the cumulative cardinality in a parent
+// histogram (or date_histogram) aggregation. The specified metric must be a
+// cardinality aggregation and the enclosing histogram must have min_doc_count
+// set to 0 (default for histogram aggregations).
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-cumulative-cardinality-aggregation.html
+type CumulativeCardinalityAggParams struct {
+	// Path to the buckets to aggregate (Required)
+	BucketsPath string
+	// Format of the value_as_string in the response
+	Format string
+	Meta   map[string]interface{}
+}
+
+func (CumulativeCardinalityAggParams) Kind() AggKind {
+	return AggKindCumulativeCardinality
+}
+
+func (p CumulativeCardinalityAggParams) Clause() (AggClause, error) {
+	return p.CumulativeCardinality()
+}
+
+func (p CumulativeCardinalityAggParams) CumulativeCardinality() (*CumulativeCardinalityAgg, error) {
+	a := &CumulativeCardinalityAgg{}
+	err := a.SetBucketsPath(p.BucketsPath)
+	if err != nil {
+		return a, newAggError(err, AggKindCumulativeCardinality)
+	}
+	a.SetFormat(p.Format)
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// CumulativeCardinalityAgg is a parent pipeline aggregation which calculates
+// the cumulative cardinality in a parent histogram (or date_histogram)
+// aggregation.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-cumulative-cardinality-aggregation.html
+type CumulativeCardinalityAgg struct {
+	bucketsPathParam
+	pipelineFormatParam
+	noAggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*CumulativeCardinalityAgg)(nil)
+
+func (CumulativeCardinalityAgg) Kind() AggKind {
+	return AggKindCumulativeCardinality
+}
+
+func (a *CumulativeCardinalityAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+func (a CumulativeCardinalityAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a CumulativeCardinalityAgg) MarshalJSON() ([]byte, error) {
+	return cumulativeCardinalityAgg{
+		BucketsPath: a.bucketsPath,
+		Format:      a.format,
+	}.MarshalJSON()
+}
+
+func (a *CumulativeCardinalityAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *CumulativeCardinalityAgg) UnmarshalJSON(data []byte) error {
+	*a = CumulativeCardinalityAgg{}
+	p := cumulativeCardinalityAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.bucketsPath = p.BucketsPath
+	a.SetFormat(p.Format)
+	return nil
+}
+
+//easyjson:json
+type cumulativeCardinalityAgg struct {
+	BucketsPath string `json:"buckets_path"`
+	Format      string `json:"format,omitempty"`
+}
@@ -0,0 +1,90 @@
+package picker
+
+// CumulativeSumAggParams creates a CumulativeSumAgg, a parent pipeline
+// aggregation which calculates the cumulative sum of a specified metric in a
+// parent histogram (or date_histogram) aggregation. The specified metric must
+// be numeric and the enclosing histogram must have min_doc_count set to 0
+// (default for histogram aggregations).
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-cumulative-sum-aggregation.html
+type CumulativeSumAggParams struct {
+	// Path to the buckets to aggregate (Required)
+	BucketsPath string
+	// Format of the value_as_string in the response
+	Format string
+	Meta   map[string]interface{}
+}
+
+func (CumulativeSumAggParams) Kind() AggKind {
+	return AggKindCumulativeSum
+}
+
+func (p CumulativeSumAggParams) Clause() (AggClause, error) {
+	return p.CumulativeSum()
+}
+
+func (p CumulativeSumAggParams) CumulativeSum() (*CumulativeSumAgg, error) {
+	a := &CumulativeSumAgg{}
+	err := a.SetBucketsPath(p.BucketsPath)
+	if err != nil {
+		return a, newAggError(err, AggKindCumulativeSum)
+	}
+	a.SetFormat(p.Format)
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// CumulativeSumAgg is a parent pipeline aggregation which calculates the
+// cumulative sum of a specified metric in a parent histogram (or
+// date_histogram) aggregation.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-cumulative-sum-aggregation.html
+type CumulativeSumAgg struct {
+	bucketsPathParam
+	pipelineFormatParam
+	noAggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*CumulativeSumAgg)(nil)
+
+func (CumulativeSumAgg) Kind() AggKind {
+	return AggKindCumulativeSum
+}
+
+func (a *CumulativeSumAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+func (a CumulativeSumAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a CumulativeSumAgg) MarshalJSON() ([]byte, error) {
+	return cumulativeSumAgg{
+		BucketsPath: a.bucketsPath,
+		Format:      a.format,
+	}.MarshalJSON()
+}
+
+func (a *CumulativeSumAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *CumulativeSumAgg) UnmarshalJSON(data []byte) error {
+	*a = CumulativeSumAgg{}
+	p := cumulativeSumAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.bucketsPath = p.BucketsPath
+	a.SetFormat(p.Format)
+	return nil
+}
+
+//easyjson:json
+type cumulativeSumAgg struct {
+	BucketsPath string `json:"buckets_path"`
+	Format      string `json:"format,omitempty"`
+}
@@ -0,0 +1,318 @@
+package picker
+
+import (
+	"github.com/chanced/dynamic"
+)
+
+// DateHistogramAggParams creates a DateHistogramAgg, a multi-bucket aggregation
+// similar to the histogram except it can only be used with date or date range
+// values.
+//
+// Exactly one of CalendarInterval or FixedInterval is required.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-datehistogram-aggregation.html
+type DateHistogramAggParams struct {
+	// The field to aggregate on. Either Field or Script is required.
+	Field   string
+	Script  *Script
+	Missing interface{}
+	// Format of the key_as_string of each bucket
+	Format string
+	// Calendar-aware interval, such as "1M" or "quarter"
+	CalendarInterval string
+	// Fixed interval of SI units, such as "90m" or "2d"
+	FixedInterval string
+	// Deprecated interval. Use CalendarInterval or FixedInterval instead.
+	Interval string
+	// Time zone used for bucketing and rounding. Defaults to UTC.
+	TimeZone string
+	// Shifts the start value of each bucket by the specified positive (+) or
+	// negative (-) duration, such as "1h" or "-1d".
+	Offset string
+	// The minimum doc_count a bucket must have to be returned. Defaults to 0.
+	MinDocCount    interface{}
+	ExtendedBounds *HistogramBounds
+	HardBounds     *HistogramBounds
+	// The order of the buckets. Defaults to ascending _key.
+	Order BucketOrder
+	Keyed bool
+	// Sub-aggregations
+	Aggregations Aggs
+	Meta         map[string]interface{}
+}
+
+func (DateHistogramAggParams) Kind() AggKind {
+	return AggKindDateHistogram
+}
+
+func (p DateHistogramAggParams) Clause() (AggClause, error) {
+	return p.DateHistogram()
+}
+
+func (p DateHistogramAggParams) DateHistogram() (*DateHistogramAgg, error) {
+	a := &DateHistogramAgg{}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	err := a.checkValueSource()
+	if err != nil {
+		return a, newAggError(err, AggKindDateHistogram)
+	}
+	a.SetMissing(p.Missing)
+	a.SetFormat(p.Format)
+	a.SetCalendarInterval(p.CalendarInterval)
+	a.SetFixedInterval(p.FixedInterval)
+	a.SetInterval(p.Interval)
+	err = a.checkInterval()
+	if err != nil {
+		return a, newAggError(err, AggKindDateHistogram)
+	}
+	a.SetTimeZone(p.TimeZone)
+	a.SetOffset(p.Offset)
+	err = a.SetMinDocCount(p.MinDocCount)
+	if err != nil {
+		return a, newAggError(err, AggKindDateHistogram)
+	}
+	a.SetExtendedBounds(p.ExtendedBounds)
+	a.SetHardBounds(p.HardBounds)
+	err = a.SetOrder(p.Order)
+	if err != nil {
+		return a, newAggError(err, AggKindDateHistogram)
+	}
+	a.SetKeyed(p.Keyed)
+	err = a.SetAggregations(p.Aggregations)
+	if err != nil {
+		return a, newAggError(err, AggKindDateHistogram)
+	}
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// DateHistogramAgg is a multi-bucket aggregation similar to the histogram
+// except it can only be used with date or date range values.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-datehistogram-aggregation.html
+type DateHistogramAgg struct {
+	valueSourceParam
+	calendarInterval string
+	fixedInterval    string
+	interval         string
+	timeZone         string
+	offset           string
+	minDocCount      dynamic.Number
+	extendedBounds   *HistogramBounds
+	hardBounds       *HistogramBounds
+	order            BucketOrder
+	keyed            bool
+	aggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*DateHistogramAgg)(nil)
+
+func (DateHistogramAgg) Kind() AggKind {
+	return AggKindDateHistogram
+}
+
+func (a *DateHistogramAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+func (a DateHistogramAgg) checkInterval() error {
+	n := 0
+	for _, v := range []string{a.calendarInterval, a.fixedInterval, a.interval} {
+		if len(v) > 0 {
+			n++
+		}
+	}
+	if n == 0 {
+		return ErrIntervalRequired
+	}
+	if n > 1 {
+		return ErrMultipleIntervals
+	}
+	return nil
+}
+
+// CalendarInterval is a calendar-aware interval, such as "1M" or "quarter"
+func (a DateHistogramAgg) CalendarInterval() string {
+	return a.calendarInterval
+}
+
+// SetCalendarInterval sets calendar_interval to v
+func (a *DateHistogramAgg) SetCalendarInterval(v string) {
+	a.calendarInterval = v
+}
+
+// FixedInterval is a fixed interval of SI units, such as "90m" or "2d"
+func (a DateHistogramAgg) FixedInterval() string {
+	return a.fixedInterval
+}
+
+// SetFixedInterval sets fixed_interval to v
+func (a *DateHistogramAgg) SetFixedInterval(v string) {
+	a.fixedInterval = v
+}
+
+// Interval is the deprecated interval parameter
+func (a DateHistogramAgg) Interval() string {
+	return a.interval
+}
+
+// SetInterval sets the deprecated interval parameter to v
+func (a *DateHistogramAgg) SetInterval(v string) {
+	a.interval = v
+}
+
+// TimeZone used for bucketing and rounding. Defaults to UTC.
+func (a DateHistogramAgg) TimeZone() string {
+	return a.timeZone
+}
+
+// SetTimeZone sets time_zone to v
+func (a *DateHistogramAgg) SetTimeZone(v string) {
+	a.timeZone = v
+}
+
+// Offset shifts the start value of each bucket by the specified positive (+)
+// or negative (-) duration, such as "1h" or "-1d".
+func (a DateHistogramAgg) Offset() string {
+	return a.offset
+}
+
+// SetOffset sets offset to v
+func (a *DateHistogramAgg) SetOffset(v string) {
+	a.offset = v
+}
+
+// MinDocCount is the minimum doc_count a bucket must have to be returned.
+// Defaults to 0.
+func (a DateHistogramAgg) MinDocCount() int {
+	if i, ok := a.minDocCount.Int(); ok {
+		return i
+	}
+	return 0
+}
+
+// SetMinDocCount sets min_doc_count to v
+func (a *DateHistogramAgg) SetMinDocCount(v interface{}) error {
+	return a.minDocCount.Set(v)
+}
+
+// ExtendedBounds forces the histogram to start building buckets on a specific
+// min value and keep building buckets up to a max value, even if there are no
+// documents.
+func (a DateHistogramAgg) ExtendedBounds() *HistogramBounds {
+	return a.extendedBounds
+}
+
+// SetExtendedBounds sets extended_bounds to v
+func (a *DateHistogramAgg) SetExtendedBounds(v *HistogramBounds) {
+	a.extendedBounds = v
+}
+
+// HardBounds limits the range of buckets in the histogram.
+func (a DateHistogramAgg) HardBounds() *HistogramBounds {
+	return a.hardBounds
+}
+
+// SetHardBounds sets hard_bounds to v
+func (a *DateHistogramAgg) SetHardBounds(v *HistogramBounds) {
+	a.hardBounds = v
+}
+
+// Order of the buckets. Defaults to ascending _key.
+func (a DateHistogramAgg) Order() BucketOrder {
+	return a.order
+}
+
+// SetOrder sets the order of the buckets to v
+func (a *DateHistogramAgg) SetOrder(v BucketOrder) error {
+	err := v.Validate()
+	if err != nil {
+		return err
+	}
+	a.order = v
+	return nil
+}
+
+// Keyed indicates whether buckets are returned as a hash rather than an array
+func (a DateHistogramAgg) Keyed() bool {
+	return a.keyed
+}
+
+// SetKeyed sets keyed to v
+func (a *DateHistogramAgg) SetKeyed(v bool) {
+	a.keyed = v
+}
+
+func (a DateHistogramAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a DateHistogramAgg) MarshalJSON() ([]byte, error) {
+	return dateHistogramAgg{
+		Field:            a.field,
+		Script:           a.script,
+		Missing:          a.missing,
+		Format:           a.format,
+		CalendarInterval: a.calendarInterval,
+		FixedInterval:    a.fixedInterval,
+		Interval:         a.interval,
+		TimeZone:         a.timeZone,
+		Offset:           a.offset,
+		MinDocCount:      a.minDocCount.Value(),
+		ExtendedBounds:   a.extendedBounds,
+		HardBounds:       a.hardBounds,
+		Order:            a.order,
+		Keyed:            a.keyed,
+	}.MarshalJSON()
+}
+
+func (a *DateHistogramAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *DateHistogramAgg) UnmarshalJSON(data []byte) error {
+	*a = DateHistogramAgg{}
+	p := dateHistogramAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	a.SetMissing(p.Missing)
+	a.SetFormat(p.Format)
+	a.SetCalendarInterval(p.CalendarInterval)
+	a.SetFixedInterval(p.FixedInterval)
+	a.SetInterval(p.Interval)
+	a.SetTimeZone(p.TimeZone)
+	a.SetOffset(p.Offset)
+	err = a.SetMinDocCount(p.MinDocCount)
+	if err != nil {
+		return err
+	}
+	a.SetExtendedBounds(p.ExtendedBounds)
+	a.SetHardBounds(p.HardBounds)
+	a.order = p.Order
+	a.SetKeyed(p.Keyed)
+	return nil
+}
+
+//easyjson:json
+type dateHistogramAgg struct {
+	Field            string           `json:"field,omitempty"`
+	Script           *Script          `json:"script,omitempty"`
+	Missing          interface{}      `json:"missing,omitempty"`
+	Format           string           `json:"format,omitempty"`
+	CalendarInterval string           `json:"calendar_interval,omitempty"`
+	FixedInterval    string           `json:"fixed_interval,omitempty"`
+	Interval         string           `json:"interval,omitempty"`
+	TimeZone         string           `json:"time_zone,omitempty"`
+	Offset           string           `json:"offset,omitempty"`
+	MinDocCount      interface{}      `json:"min_doc_count,omitempty"`
+	ExtendedBounds   *HistogramBounds `json:"extended_bounds,omitempty"`
+	HardBounds       *HistogramBounds `json:"hard_bounds,omitempty"`
+	Order            BucketOrder      `json:"order,omitempty"`
+	Keyed            bool             `json:"keyed,omitempty"`
+}
@@ -0,0 +1,237 @@
+package picker
+
+// DateIndexNameProcessorParams creates a DateIndexNameProcessor, which points
+// documents to the right time based index based on a date or timestamp field in
+// a document by using the date math index name support.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/date-index-name-processor.html
+type DateIndexNameProcessorParams struct {
+	// Field is the field to get the date or timestamp from
+	Field string
+	// IndexNamePrefix is a prefix of the index name to be prepended before the
+	// printed date. Supports template snippets.
+	IndexNamePrefix string
+	// DateRounding is how to round the date when formatting the date into the
+	// index name. Valid values are: y (year), M (month), w (week), d (day), h
+	// (hour), m (minute) and s (second). Supports template snippets.
+	DateRounding string
+	// DateFormats are the expected date formats for parsing dates or timestamps
+	// in the document being preprocessed. Defaults to
+	// ["yyyy-MM-dd'T'HH:mm:ss.SSSXX"].
+	DateFormats []string
+	// Timezone to use when parsing the date and when date math index supports
+	// resolves expressions into concrete index names
+	Timezone string
+	// Locale to use when parsing the date from the document being preprocessed,
+	// relevant when parsing month names or week days
+	Locale string
+	// IndexNameFormat is the format to be used when printing the parsed date
+	// into the index name. Defaults to "yyyy-MM-dd". Supports template
+	// snippets.
+	IndexNameFormat string
+	// Description of the processor. Useful for describing the purpose of the
+	// processor or its configuration.
+	Description string
+	// Conditionally execute the processor
+	If string
+	// Ignore failures for the processor
+	IgnoreFailure bool
+	// Handle failures for the processor
+	OnFailure Procs
+	// Identifier for the processor. Useful for debugging and metrics.
+	Tag string
+}
+
+func (DateIndexNameProcessorParams) Kind() ProcessorKind {
+	return ProcessorKindDateIndexName
+}
+
+func (p DateIndexNameProcessorParams) Processor() (Processor, error) {
+	return p.DateIndexName()
+}
+
+func (p DateIndexNameProcessorParams) DateIndexName() (*DateIndexNameProcessor, error) {
+	proc := &DateIndexNameProcessor{}
+	err := proc.SetField(p.Field)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindDateIndexName)
+	}
+	proc.SetIndexNamePrefix(p.IndexNamePrefix)
+	err = proc.SetDateRounding(p.DateRounding)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindDateIndexName)
+	}
+	proc.SetDateFormats(p.DateFormats)
+	proc.SetTimezone(p.Timezone)
+	proc.SetLocale(p.Locale)
+	proc.SetIndexNameFormat(p.IndexNameFormat)
+	proc.SetDescription(p.Description)
+	proc.SetIf(p.If)
+	proc.SetIgnoreFailure(p.IgnoreFailure)
+	err = proc.SetOnFailure(p.OnFailure)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindDateIndexName)
+	}
+	proc.SetTag(p.Tag)
+	return proc, nil
+}
+
+// DateIndexNameProcessor points documents to the right time based index based
+// on a date or timestamp field in a document by using the date math index name
+// support.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/date-index-name-processor.html
+type DateIndexNameProcessor struct {
+	fieldParam
+	indexNamePrefix string
+	dateRounding    string
+	dateFormats     []string
+	timezone        string
+	locale          string
+	indexNameFormat string
+	processorParams
+}
+
+var _ Processor = (*DateIndexNameProcessor)(nil)
+
+func (DateIndexNameProcessor) Kind() ProcessorKind {
+	return ProcessorKindDateIndexName
+}
+
+func (p *DateIndexNameProcessor) Processor() (Processor, error) {
+	return p, nil
+}
+
+// IndexNamePrefix is a prefix of the index name to be prepended before the
+// printed date. Supports template snippets.
+func (p DateIndexNameProcessor) IndexNamePrefix() string {
+	return p.indexNamePrefix
+}
+
+// SetIndexNamePrefix sets index_name_prefix to v
+func (p *DateIndexNameProcessor) SetIndexNamePrefix(v string) {
+	p.indexNamePrefix = v
+}
+
+// DateRounding is how to round the date when formatting the date into the index
+// name. Valid values are: y (year), M (month), w (week), d (day), h (hour), m
+// (minute) and s (second). Supports template snippets.
+func (p DateIndexNameProcessor) DateRounding() string {
+	return p.dateRounding
+}
+
+// SetDateRounding sets date_rounding to v
+func (p *DateIndexNameProcessor) SetDateRounding(v string) error {
+	if len(v) == 0 {
+		return ErrDateRoundingRequired
+	}
+	p.dateRounding = v
+	return nil
+}
+
+// DateFormats are the expected date formats for parsing dates or timestamps in
+// the document being preprocessed. Defaults to ["yyyy-MM-dd'T'HH:mm:ss.SSSXX"].
+func (p DateIndexNameProcessor) DateFormats() []string {
+	return p.dateFormats
+}
+
+// SetDateFormats sets date_formats to v
+func (p *DateIndexNameProcessor) SetDateFormats(v []string) {
+	p.dateFormats = v
+}
+
+// Timezone to use when parsing the date and when date math index supports
+// resolves expressions into concrete index names
+func (p DateIndexNameProcessor) Timezone() string {
+	return p.timezone
+}
+
+// SetTimezone sets timezone to v
+func (p *DateIndexNameProcessor) SetTimezone(v string) {
+	p.timezone = v
+}
+
+// Locale to use when parsing the date from the document being preprocessed,
+// relevant when parsing month names or week days
+func (p DateIndexNameProcessor) Locale() string {
+	return p.locale
+}
+
+// SetLocale sets locale to v
+func (p *DateIndexNameProcessor) SetLocale(v string) {
+	p.locale = v
+}
+
+// IndexNameFormat is the format to be used when printing the parsed date into
+// the index name. Defaults to "yyyy-MM-dd". Supports template snippets.
+func (p DateIndexNameProcessor) IndexNameFormat() string {
+	return p.indexNameFormat
+}
+
+// SetIndexNameFormat sets index_name_format to v
+func (p *DateIndexNameProcessor) SetIndexNameFormat(v string) {
+	p.indexNameFormat = v
+}
+
+func (p DateIndexNameProcessor) MarshalBSON() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+func (p DateIndexNameProcessor) MarshalJSON() ([]byte, error) {
+	return dateIndexNameProcessor{
+		Field:           p.field,
+		IndexNamePrefix: p.indexNamePrefix,
+		DateRounding:    p.dateRounding,
+		DateFormats:     p.dateFormats,
+		Timezone:        p.timezone,
+		Locale:          p.locale,
+		IndexNameFormat: p.indexNameFormat,
+		Description:     p.description,
+		If:              p.ifCond,
+		IgnoreFailure:   p.ignoreFailure,
+		OnFailure:       p.onFailure,
+		Tag:             p.tag,
+	}.MarshalJSON()
+}
+
+func (p *DateIndexNameProcessor) UnmarshalBSON(data []byte) error {
+	return p.UnmarshalJSON(data)
+}
+
+func (p *DateIndexNameProcessor) UnmarshalJSON(data []byte) error {
+	*p = DateIndexNameProcessor{}
+	var v dateIndexNameProcessor
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	p.field = v.Field
+	p.indexNamePrefix = v.IndexNamePrefix
+	p.dateRounding = v.DateRounding
+	p.dateFormats = v.DateFormats
+	p.timezone = v.Timezone
+	p.locale = v.Locale
+	p.indexNameFormat = v.IndexNameFormat
+	p.description = v.Description
+	p.ifCond = v.If
+	p.ignoreFailure = v.IgnoreFailure
+	p.onFailure = v.OnFailure
+	p.tag = v.Tag
+	return nil
+}
+
+//easyjson:json
+type dateIndexNameProcessor struct {
+	Field           string     `json:"field,omitempty"`
+	IndexNamePrefix string     `json:"index_name_prefix,omitempty"`
+	DateRounding    string     `json:"date_rounding,omitempty"`
+	DateFormats     []string   `json:"date_formats,omitempty"`
+	Timezone        string     `json:"timezone,omitempty"`
+	Locale          string     `json:"locale,omitempty"`
+	IndexNameFormat string     `json:"index_name_format,omitempty"`
+	Description     string     `json:"description,omitempty"`
+	If              string     `json:"if,omitempty"`
+	IgnoreFailure   bool       `json:"ignore_failure,omitempty"`
+	OnFailure       Processors `json:"on_failure,omitempty"`
+	Tag             string     `json:"tag,omitempty"`
+}
@@ -0,0 +1,199 @@
+package picker
+
+// DateProcessorParams creates a DateProcessor, which parses dates from fields,
+// and then uses the date or timestamp as the timestamp for the document.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/date-processor.html
+type DateProcessorParams struct {
+	// Field is the field to get the date from
+	Field string
+	// TargetField is the field that will hold the parsed date. Defaults to
+	// "@timestamp".
+	TargetField string
+	// Formats are the expected date formats. Can be a java time pattern or one
+	// of the following formats: ISO8601, UNIX, UNIX_MS, or TAI64N.
+	Formats []string
+	// Timezone to use when parsing the date. Supports template snippets.
+	Timezone string
+	// Locale to use when parsing the date, relevant when parsing month names or
+	// week days. Supports template snippets.
+	Locale string
+	// OutputFormat is the format to use when writing the date to target_field.
+	// Defaults to "yyyy-MM-dd'T'HH:mm:ss.SSSXXX".
+	OutputFormat string
+	// Description of the processor. Useful for describing the purpose of the
+	// processor or its configuration.
+	Description string
+	// Conditionally execute the processor
+	If string
+	// Ignore failures for the processor
+	IgnoreFailure bool
+	// Handle failures for the processor
+	OnFailure Procs
+	// Identifier for the processor. Useful for debugging and metrics.
+	Tag string
+}
+
+func (DateProcessorParams) Kind() ProcessorKind {
+	return ProcessorKindDate
+}
+
+func (p DateProcessorParams) Processor() (Processor, error) {
+	return p.Date()
+}
+
+func (p DateProcessorParams) Date() (*DateProcessor, error) {
+	proc := &DateProcessor{}
+	err := proc.SetField(p.Field)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindDate)
+	}
+	proc.SetTargetField(p.TargetField)
+	err = proc.SetFormats(p.Formats)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindDate)
+	}
+	proc.SetTimezone(p.Timezone)
+	proc.SetLocale(p.Locale)
+	proc.SetOutputFormat(p.OutputFormat)
+	proc.SetDescription(p.Description)
+	proc.SetIf(p.If)
+	proc.SetIgnoreFailure(p.IgnoreFailure)
+	err = proc.SetOnFailure(p.OnFailure)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindDate)
+	}
+	proc.SetTag(p.Tag)
+	return proc, nil
+}
+
+// DateProcessor parses dates from fields, and then uses the date or timestamp
+// as the timestamp for the document.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/date-processor.html
+type DateProcessor struct {
+	fieldParam
+	targetFieldParam
+	formats      []string
+	timezone     string
+	locale       string
+	outputFormat string
+	processorParams
+}
+
+var _ Processor = (*DateProcessor)(nil)
+
+func (DateProcessor) Kind() ProcessorKind {
+	return ProcessorKindDate
+}
+
+func (p *DateProcessor) Processor() (Processor, error) {
+	return p, nil
+}
+
+// Formats are the expected date formats. Can be a java time pattern or one of
+// the following formats: ISO8601, UNIX, UNIX_MS, or TAI64N.
+func (p DateProcessor) Formats() []string {
+	return p.formats
+}
+
+// SetFormats sets formats to v
+func (p *DateProcessor) SetFormats(v []string) error {
+	if len(v) == 0 {
+		return ErrFormatsRequired
+	}
+	p.formats = v
+	return nil
+}
+
+// Timezone to use when parsing the date. Supports template snippets.
+func (p DateProcessor) Timezone() string {
+	return p.timezone
+}
+
+// SetTimezone sets timezone to v
+func (p *DateProcessor) SetTimezone(v string) {
+	p.timezone = v
+}
+
+// Locale to use when parsing the date, relevant when parsing month names or
+// week days. Supports template snippets.
+func (p DateProcessor) Locale() string {
+	return p.locale
+}
+
+// SetLocale sets locale to v
+func (p *DateProcessor) SetLocale(v string) {
+	p.locale = v
+}
+
+// OutputFormat is the format to use when writing the date to target_field.
+// Defaults to "yyyy-MM-dd'T'HH:mm:ss.SSSXXX".
+func (p DateProcessor) OutputFormat() string {
+	return p.outputFormat
+}
+
+// SetOutputFormat sets output_format to v
+func (p *DateProcessor) SetOutputFormat(v string) {
+	p.outputFormat = v
+}
+
+func (p DateProcessor) MarshalBSON() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+func (p DateProcessor) MarshalJSON() ([]byte, error) {
+	return dateProcessor{
+		Field:         p.field,
+		TargetField:   p.targetField,
+		Formats:       p.formats,
+		Timezone:      p.timezone,
+		Locale:        p.locale,
+		OutputFormat:  p.outputFormat,
+		Description:   p.description,
+		If:            p.ifCond,
+		IgnoreFailure: p.ignoreFailure,
+		OnFailure:     p.onFailure,
+		Tag:           p.tag,
+	}.MarshalJSON()
+}
+
+func (p *DateProcessor) UnmarshalBSON(data []byte) error {
+	return p.UnmarshalJSON(data)
+}
+
+func (p *DateProcessor) UnmarshalJSON(data []byte) error {
+	*p = DateProcessor{}
+	var v dateProcessor
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	p.field = v.Field
+	p.targetField = v.TargetField
+	p.formats = v.Formats
+	p.timezone = v.Timezone
+	p.locale = v.Locale
+	p.outputFormat = v.OutputFormat
+	p.description = v.Description
+	p.ifCond = v.If
+	p.ignoreFailure = v.IgnoreFailure
+	p.onFailure = v.OnFailure
+	p.tag = v.Tag
+	return nil
+}
+
+//easyjson:json
+type dateProcessor struct {
+	Field         string     `json:"field,omitempty"`
+	TargetField   string     `json:"target_field,omitempty"`
+	Formats       []string   `json:"formats,omitempty"`
+	Timezone      string     `json:"timezone,omitempty"`
+	Locale        string     `json:"locale,omitempty"`
+	OutputFormat  string     `json:"output_format,omitempty"`
+	Description   string     `json:"description,omitempty"`
+	If            string     `json:"if,omitempty"`
+	IgnoreFailure bool       `json:"ignore_failure,omitempty"`
+	OnFailure     Processors `json:"on_failure,omitempty"`
+	Tag           string     `json:"tag,omitempty"`
+}
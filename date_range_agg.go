@@ -0,0 +1,164 @@
+package picker
+
+// DateRangeAggParams creates a DateRangeAgg, a range aggregation that is
+// dedicated for date values. The main difference between this aggregation and
+// the normal range aggregation is that the from and to values can be expressed
+// in Date Math expressions, and it is also possible to specify a date format by
+// which the from and to response fields will be returned.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-daterange-aggregation.html
+type DateRangeAggParams struct {
+	// The field to aggregate on. Either Field or Script is required.
+	Field   string
+	Script  *Script
+	Missing interface{}
+	// Format of the from and to values, both in the request and the response
+	Format string
+	// Time zone used to convert dates from another time zone to UTC
+	TimeZone string
+	// The ranges of the buckets (Required)
+	Ranges []AggRange
+	// If true, buckets are returned as a hash rather than an array, keyed by
+	// the bucket key.
+	Keyed bool
+	// Sub-aggregations
+	Aggregations Aggs
+	Meta         map[string]interface{}
+}
+
+func (DateRangeAggParams) Kind() AggKind {
+	return AggKindDateRange
+}
+
+func (p DateRangeAggParams) Clause() (AggClause, error) {
+	return p.DateRange()
+}
+
+func (p DateRangeAggParams) DateRange() (*DateRangeAgg, error) {
+	a := &DateRangeAgg{}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	err := a.checkValueSource()
+	if err != nil {
+		return a, newAggError(err, AggKindDateRange)
+	}
+	a.SetMissing(p.Missing)
+	a.SetFormat(p.Format)
+	a.SetTimeZone(p.TimeZone)
+	err = a.SetRanges(p.Ranges)
+	if err != nil {
+		return a, newAggError(err, AggKindDateRange)
+	}
+	a.SetKeyed(p.Keyed)
+	err = a.SetAggregations(p.Aggregations)
+	if err != nil {
+		return a, newAggError(err, AggKindDateRange)
+	}
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// DateRangeAgg is a range aggregation that is dedicated for date values.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-daterange-aggregation.html
+type DateRangeAgg struct {
+	valueSourceParam
+	timeZone string
+	ranges   []AggRange
+	keyed    bool
+	aggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*DateRangeAgg)(nil)
+
+func (DateRangeAgg) Kind() AggKind {
+	return AggKindDateRange
+}
+
+func (a *DateRangeAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// TimeZone used to convert dates from another time zone to UTC
+func (a DateRangeAgg) TimeZone() string {
+	return a.timeZone
+}
+
+// SetTimeZone sets time_zone to v
+func (a *DateRangeAgg) SetTimeZone(v string) {
+	a.timeZone = v
+}
+
+// Ranges of the buckets
+func (a DateRangeAgg) Ranges() []AggRange {
+	return a.ranges
+}
+
+// SetRanges sets the ranges to v. At least one range is required.
+func (a *DateRangeAgg) SetRanges(v []AggRange) error {
+	err := checkAggRanges(v)
+	if err != nil {
+		return err
+	}
+	a.ranges = v
+	return nil
+}
+
+// Keyed indicates whether buckets are returned as a hash rather than an array
+func (a DateRangeAgg) Keyed() bool {
+	return a.keyed
+}
+
+// SetKeyed sets keyed to v
+func (a *DateRangeAgg) SetKeyed(v bool) {
+	a.keyed = v
+}
+
+func (a DateRangeAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a DateRangeAgg) MarshalJSON() ([]byte, error) {
+	return dateRangeAgg{
+		Field:    a.field,
+		Script:   a.script,
+		Missing:  a.missing,
+		Format:   a.format,
+		TimeZone: a.timeZone,
+		Ranges:   a.ranges,
+		Keyed:    a.keyed,
+	}.MarshalJSON()
+}
+
+func (a *DateRangeAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *DateRangeAgg) UnmarshalJSON(data []byte) error {
+	*a = DateRangeAgg{}
+	p := dateRangeAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	a.SetMissing(p.Missing)
+	a.SetFormat(p.Format)
+	a.SetTimeZone(p.TimeZone)
+	a.ranges = p.Ranges
+	a.SetKeyed(p.Keyed)
+	return nil
+}
+
+//easyjson:json
+type dateRangeAgg struct {
+	Field    string      `json:"field,omitempty"`
+	Script   *Script     `json:"script,omitempty"`
+	Missing  interface{} `json:"missing,omitempty"`
+	Format   string      `json:"format,omitempty"`
+	TimeZone string      `json:"time_zone,omitempty"`
+	Ranges   []AggRange  `json:"ranges"`
+	Keyed    bool        `json:"keyed,omitempty"`
+}
@@ -78,20 +78,40 @@ func NewDeleteByQuery(params DeleteByQueryParams) (*DeleteByQuery, error) {
 	return params.DeleteByQuery()
 }
 
+// DeleteByQueryResponse is the response of a delete by query request
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/docs-delete-by-query.html#docs-delete-by-query-api-response-body
+//
+//easyjson:json
 type DeleteByQueryResponse struct {
-	Took                 int64         `json:"took"`
-	TimedOut             bool          `json:"timed_out"`
-	Total                int64         `json:"total"`
-	Updated              int64         `json:"updated"`
-	Deleted              int64         `json:"deleted"`
-	Batches              int64         `json:"batches"`
-	VersionConflicts     int64         `json:"version_conflicts"`
-	Noops                int64         `json:"noops"`
-	Retries              Retries       `json:"retries"`
-	ThrottledMillis      int64         `json:"throttled_millis"`
-	RequestsPerSecond    float64       `json:"requests_per_second"`
-	Failures             []interface{} `json:"failures"`
-	ThrottledUntilMillis int64         `json:"throttled_until_millis"`
+	// Milliseconds from start to end of the whole operation
+	Took int64 `json:"took"`
+	// If true, a request executed during the operation timed out
+	TimedOut bool `json:"timed_out"`
+	// The number of documents that were successfully processed
+	Total int64 `json:"total"`
+	// The number of documents that were successfully updated
+	Updated int64 `json:"updated"`
+	// The number of documents that were successfully deleted
+	Deleted int64 `json:"deleted"`
+	// The number of scroll responses pulled back by the operation
+	Batches int64 `json:"batches"`
+	// The number of version conflicts that the operation hit
+	VersionConflicts int64 `json:"version_conflicts"`
+	// The number of documents that were ignored
+	Noops int64 `json:"noops"`
+	// The number of retries attempted by the operation
+	Retries Retries `json:"retries"`
+	// Milliseconds the request slept to conform to requests_per_second
+	ThrottledMillis int64 `json:"throttled_millis"`
+	// The number of requests per second effectively executed during the
+	// operation; -1 if unthrottled
+	RequestsPerSecond float64 `json:"requests_per_second"`
+	// Failures of the operation. Processing stops on the first unrecoverable
+	// failure.
+	Failures []ByQueryFailure `json:"failures"`
+	// Milliseconds until the next throttled request is executed
+	ThrottledUntilMillis int64 `json:"throttled_until_millis"`
 }
 
 func DecodeDeleteByQueryResponse(body io.Reader) (*DeleteByQueryResponse, error) {
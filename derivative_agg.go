@@ -0,0 +1,118 @@
+package picker
+
+// DerivativeAggParams creates a DerivativeAgg, a parent pipeline aggregation
+// which calculates the derivative of a specified metric in a parent histogram
+// (or date_histogram) aggregation. The specified metric must be numeric and the
+// enclosing histogram must have min_doc_count set to 0 (default for histogram
+// aggregations).
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-derivative-aggregation.html
+type DerivativeAggParams struct {
+	// Path to the buckets to aggregate (Required)
+	BucketsPath string
+	// Policy to apply when gaps are found in the data. Defaults to "skip".
+	GapPolicy GapPolicy
+	// Format of the value_as_string in the response
+	Format string
+	// The time unit of the x-axis, e.g. "1d". When set, the response includes a
+	// normalized_value.
+	Unit string
+	Meta map[string]interface{}
+}
+
+func (DerivativeAggParams) Kind() AggKind {
+	return AggKindDerivative
+}
+
+func (p DerivativeAggParams) Clause() (AggClause, error) {
+	return p.Derivative()
+}
+
+func (p DerivativeAggParams) Derivative() (*DerivativeAgg, error) {
+	a := &DerivativeAgg{}
+	err := a.SetBucketsPath(p.BucketsPath)
+	if err != nil {
+		return a, newAggError(err, AggKindDerivative)
+	}
+	err = a.SetGapPolicy(p.GapPolicy)
+	if err != nil {
+		return a, newAggError(err, AggKindDerivative)
+	}
+	a.SetFormat(p.Format)
+	a.SetUnit(p.Unit)
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// DerivativeAgg is a parent pipeline aggregation which calculates the
+// derivative of a specified metric in a parent histogram (or date_histogram)
+// aggregation.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-derivative-aggregation.html
+type DerivativeAgg struct {
+	bucketsPathParam
+	gapPolicyParam
+	pipelineFormatParam
+	unit string
+	noAggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*DerivativeAgg)(nil)
+
+func (DerivativeAgg) Kind() AggKind {
+	return AggKindDerivative
+}
+
+func (a *DerivativeAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// Unit is the time unit of the x-axis, e.g. "1d"
+func (a DerivativeAgg) Unit() string {
+	return a.unit
+}
+
+// SetUnit sets unit to v
+func (a *DerivativeAgg) SetUnit(v string) {
+	a.unit = v
+}
+
+func (a DerivativeAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a DerivativeAgg) MarshalJSON() ([]byte, error) {
+	return derivativeAgg{
+		BucketsPath: a.bucketsPath,
+		GapPolicy:   a.gapPolicy,
+		Format:      a.format,
+		Unit:        a.unit,
+	}.MarshalJSON()
+}
+
+func (a *DerivativeAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *DerivativeAgg) UnmarshalJSON(data []byte) error {
+	*a = DerivativeAgg{}
+	p := derivativeAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.bucketsPath = p.BucketsPath
+	a.gapPolicy = p.GapPolicy
+	a.SetFormat(p.Format)
+	a.unit = p.Unit
+	return nil
+}
+
+//easyjson:json
+type derivativeAgg struct {
+	BucketsPath string    `json:"buckets_path"`
+	GapPolicy   GapPolicy `json:"gap_policy,omitempty"`
+	Format      string    `json:"format,omitempty"`
+	Unit        string    `json:"unit,omitempty"`
+}
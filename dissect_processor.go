@@ -0,0 +1,162 @@
+package picker
+
+// DissectProcessorParams creates a DissectProcessor, which extracts structured
+// fields out of a single text field within a document. Unlike grok, dissect
+// does not use regular expressions.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/dissect-processor.html
+type DissectProcessorParams struct {
+	// Field is the field to dissect
+	Field string
+	// Pattern to apply to the field
+	Pattern string
+	// AppendSeparator is the character(s) that separate the appended fields.
+	// Defaults to "".
+	AppendSeparator string
+	// If IgnoreMissing is true and field does not exist or is null, the
+	// processor quietly exits without modifying the document
+	IgnoreMissing bool
+	// Description of the processor. Useful for describing the purpose of the
+	// processor or its configuration.
+	Description string
+	// Conditionally execute the processor
+	If string
+	// Ignore failures for the processor
+	IgnoreFailure bool
+	// Handle failures for the processor
+	OnFailure Procs
+	// Identifier for the processor. Useful for debugging and metrics.
+	Tag string
+}
+
+func (DissectProcessorParams) Kind() ProcessorKind {
+	return ProcessorKindDissect
+}
+
+func (p DissectProcessorParams) Processor() (Processor, error) {
+	return p.Dissect()
+}
+
+func (p DissectProcessorParams) Dissect() (*DissectProcessor, error) {
+	proc := &DissectProcessor{}
+	err := proc.SetField(p.Field)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindDissect)
+	}
+	err = proc.SetPattern(p.Pattern)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindDissect)
+	}
+	proc.SetAppendSeparator(p.AppendSeparator)
+	proc.SetIgnoreMissing(p.IgnoreMissing)
+	proc.SetDescription(p.Description)
+	proc.SetIf(p.If)
+	proc.SetIgnoreFailure(p.IgnoreFailure)
+	err = proc.SetOnFailure(p.OnFailure)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindDissect)
+	}
+	proc.SetTag(p.Tag)
+	return proc, nil
+}
+
+// DissectProcessor extracts structured fields out of a single text field within
+// a document. Unlike grok, dissect does not use regular expressions.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/dissect-processor.html
+type DissectProcessor struct {
+	fieldParam
+	pattern         string
+	appendSeparator string
+	ignoreMissingParam
+	processorParams
+}
+
+var _ Processor = (*DissectProcessor)(nil)
+
+func (DissectProcessor) Kind() ProcessorKind {
+	return ProcessorKindDissect
+}
+
+func (p *DissectProcessor) Processor() (Processor, error) {
+	return p, nil
+}
+
+// Pattern to apply to the field
+func (p DissectProcessor) Pattern() string {
+	return p.pattern
+}
+
+// SetPattern sets pattern to v
+func (p *DissectProcessor) SetPattern(v string) error {
+	if len(v) == 0 {
+		return ErrPatternRequired
+	}
+	p.pattern = v
+	return nil
+}
+
+// AppendSeparator is the character(s) that separate the appended fields.
+// Defaults to "".
+func (p DissectProcessor) AppendSeparator() string {
+	return p.appendSeparator
+}
+
+// SetAppendSeparator sets append_separator to v
+func (p *DissectProcessor) SetAppendSeparator(v string) {
+	p.appendSeparator = v
+}
+
+func (p DissectProcessor) MarshalBSON() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+func (p DissectProcessor) MarshalJSON() ([]byte, error) {
+	return dissectProcessor{
+		Field:           p.field,
+		Pattern:         p.pattern,
+		AppendSeparator: p.appendSeparator,
+		IgnoreMissing:   p.ignoreMissing,
+		Description:     p.description,
+		If:              p.ifCond,
+		IgnoreFailure:   p.ignoreFailure,
+		OnFailure:       p.onFailure,
+		Tag:             p.tag,
+	}.MarshalJSON()
+}
+
+func (p *DissectProcessor) UnmarshalBSON(data []byte) error {
+	return p.UnmarshalJSON(data)
+}
+
+func (p *DissectProcessor) UnmarshalJSON(data []byte) error {
+	*p = DissectProcessor{}
+	var v dissectProcessor
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	p.field = v.Field
+	p.pattern = v.Pattern
+	p.appendSeparator = v.AppendSeparator
+	p.ignoreMissing = v.IgnoreMissing
+	p.description = v.Description
+	p.ifCond = v.If
+	p.ignoreFailure = v.IgnoreFailure
+	p.onFailure = v.OnFailure
+	p.tag = v.Tag
+	return nil
+}
+
+//easyjson:json
+type dissectProcessor struct {
+	Field           string     `json:"field,omitempty"`
+	Pattern         string     `json:"pattern,omitempty"`
+	AppendSeparator string     `json:"append_separator,omitempty"`
+	IgnoreMissing   bool       `json:"ignore_missing,omitempty"`
+	Description     string     `json:"description,omitempty"`
+	If              string     `json:"if,omitempty"`
+	IgnoreFailure   bool       `json:"ignore_failure,omitempty"`
+	OnFailure       Processors `json:"on_failure,omitempty"`
+	Tag             string     `json:"tag,omitempty"`
+}
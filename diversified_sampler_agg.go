@@ -0,0 +1,159 @@
+package picker
+
+const DefaultDiversifiedSamplerMaxDocsPerValue = 1
+
+// DiversifiedSamplerAggParams creates a DiversifiedSamplerAgg. Like the
+// sampler aggregation, this is a filtering aggregation used to limit any sub
+// aggregations' processing to a sample of the top-scoring documents. The
+// diversified sampler aggregation adds the ability to limit the number of
+// matches that share a common value such as an "author".
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-diversified-sampler-aggregation.html
+type DiversifiedSamplerAggParams struct {
+	// The field used to de-duplicate the sample. Either Field or Script is
+	// required.
+	Field  string
+	Script *Script
+	// Limits how many top-scoring documents are collected in the sample
+	// processed on each shard. Defaults to 100.
+	ShardSize int
+	// The maximum number of documents collected on any one shard which share a
+	// common value. Defaults to 1.
+	MaxDocsPerValue int
+	// One of "map", "global_ordinals", or "bytes_hash"
+	ExecutionHint string
+	// Sub-aggregations
+	Aggregations Aggs
+	Meta         map[string]interface{}
+}
+
+func (DiversifiedSamplerAggParams) Kind() AggKind {
+	return AggKindDiversifiedSampler
+}
+
+func (p DiversifiedSamplerAggParams) Clause() (AggClause, error) {
+	return p.DiversifiedSampler()
+}
+
+func (p DiversifiedSamplerAggParams) DiversifiedSampler() (*DiversifiedSamplerAgg, error) {
+	a := &DiversifiedSamplerAgg{}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	err := a.checkValueSource()
+	if err != nil {
+		return a, newAggError(err, AggKindDiversifiedSampler)
+	}
+	a.SetShardSize(p.ShardSize)
+	a.SetMaxDocsPerValue(p.MaxDocsPerValue)
+	a.SetExecutionHint(p.ExecutionHint)
+	err = a.SetAggregations(p.Aggregations)
+	if err != nil {
+		return a, newAggError(err, AggKindDiversifiedSampler)
+	}
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// DiversifiedSamplerAgg is a filtering aggregation used to limit any sub
+// aggregations' processing to a sample of the top-scoring documents, limiting
+// the number of matches that share a common value.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-diversified-sampler-aggregation.html
+type DiversifiedSamplerAgg struct {
+	valueSourceParam
+	shardSize       int
+	maxDocsPerValue int
+	executionHint   string
+	aggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*DiversifiedSamplerAgg)(nil)
+
+func (DiversifiedSamplerAgg) Kind() AggKind {
+	return AggKindDiversifiedSampler
+}
+
+func (a *DiversifiedSamplerAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// ShardSize limits how many top-scoring documents are collected in the sample
+// processed on each shard. Defaults to 100.
+func (a DiversifiedSamplerAgg) ShardSize() int {
+	if a.shardSize == 0 {
+		return DefaultSamplerShardSize
+	}
+	return a.shardSize
+}
+
+// SetShardSize sets shard_size to v
+func (a *DiversifiedSamplerAgg) SetShardSize(v int) {
+	a.shardSize = v
+}
+
+// MaxDocsPerValue is the maximum number of documents collected on any one
+// shard which share a common value. Defaults to 1.
+func (a DiversifiedSamplerAgg) MaxDocsPerValue() int {
+	if a.maxDocsPerValue == 0 {
+		return DefaultDiversifiedSamplerMaxDocsPerValue
+	}
+	return a.maxDocsPerValue
+}
+
+// SetMaxDocsPerValue sets max_docs_per_value to v
+func (a *DiversifiedSamplerAgg) SetMaxDocsPerValue(v int) {
+	a.maxDocsPerValue = v
+}
+
+// ExecutionHint is one of "map", "global_ordinals", or "bytes_hash"
+func (a DiversifiedSamplerAgg) ExecutionHint() string {
+	return a.executionHint
+}
+
+// SetExecutionHint sets execution_hint to v
+func (a *DiversifiedSamplerAgg) SetExecutionHint(v string) {
+	a.executionHint = v
+}
+
+func (a DiversifiedSamplerAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a DiversifiedSamplerAgg) MarshalJSON() ([]byte, error) {
+	return diversifiedSamplerAgg{
+		Field:           a.field,
+		Script:          a.script,
+		ShardSize:       a.shardSize,
+		MaxDocsPerValue: a.maxDocsPerValue,
+		ExecutionHint:   a.executionHint,
+	}.MarshalJSON()
+}
+
+func (a *DiversifiedSamplerAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *DiversifiedSamplerAgg) UnmarshalJSON(data []byte) error {
+	*a = DiversifiedSamplerAgg{}
+	p := diversifiedSamplerAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	a.SetShardSize(p.ShardSize)
+	a.SetMaxDocsPerValue(p.MaxDocsPerValue)
+	a.SetExecutionHint(p.ExecutionHint)
+	return nil
+}
+
+//easyjson:json
+type diversifiedSamplerAgg struct {
+	Field           string  `json:"field,omitempty"`
+	Script          *Script `json:"script,omitempty"`
+	ShardSize       int     `json:"shard_size,omitempty"`
+	MaxDocsPerValue int     `json:"max_docs_per_value,omitempty"`
+	ExecutionHint   string  `json:"execution_hint,omitempty"`
+}
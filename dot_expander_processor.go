@@ -0,0 +1,134 @@
+package picker
+
+// DotExpanderProcessorParams creates a DotExpanderProcessor, which expands a
+// field with dots into an object field. This processor allows fields with dots
+// in the name to be accessible by other processors in the pipeline.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/dot-expand-processor.html
+type DotExpanderProcessorParams struct {
+	// Field is the field to expand into an object field. If set to "*", all
+	// top-level fields will be expanded.
+	Field string
+	// Path is the field that contains the field to expand. Only required if the
+	// field to expand is part another object field, because the field option
+	// can only understand leaf fields.
+	Path string
+	// Description of the processor. Useful for describing the purpose of the
+	// processor or its configuration.
+	Description string
+	// Conditionally execute the processor
+	If string
+	// Ignore failures for the processor
+	IgnoreFailure bool
+	// Handle failures for the processor
+	OnFailure Procs
+	// Identifier for the processor. Useful for debugging and metrics.
+	Tag string
+}
+
+func (DotExpanderProcessorParams) Kind() ProcessorKind {
+	return ProcessorKindDotExpander
+}
+
+func (p DotExpanderProcessorParams) Processor() (Processor, error) {
+	return p.DotExpander()
+}
+
+func (p DotExpanderProcessorParams) DotExpander() (*DotExpanderProcessor, error) {
+	proc := &DotExpanderProcessor{}
+	err := proc.SetField(p.Field)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindDotExpander)
+	}
+	proc.SetPath(p.Path)
+	proc.SetDescription(p.Description)
+	proc.SetIf(p.If)
+	proc.SetIgnoreFailure(p.IgnoreFailure)
+	err = proc.SetOnFailure(p.OnFailure)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindDotExpander)
+	}
+	proc.SetTag(p.Tag)
+	return proc, nil
+}
+
+// DotExpanderProcessor expands a field with dots into an object field. This
+// processor allows fields with dots in the name to be accessible by other
+// processors in the pipeline.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/dot-expand-processor.html
+type DotExpanderProcessor struct {
+	fieldParam
+	path string
+	processorParams
+}
+
+var _ Processor = (*DotExpanderProcessor)(nil)
+
+func (DotExpanderProcessor) Kind() ProcessorKind {
+	return ProcessorKindDotExpander
+}
+
+func (p *DotExpanderProcessor) Processor() (Processor, error) {
+	return p, nil
+}
+
+// Path is the field that contains the field to expand. Only required if the
+// field to expand is part another object field, because the field option can
+// only understand leaf fields.
+func (p DotExpanderProcessor) Path() string {
+	return p.path
+}
+
+// SetPath sets path to v
+func (p *DotExpanderProcessor) SetPath(v string) {
+	p.path = v
+}
+
+func (p DotExpanderProcessor) MarshalBSON() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+func (p DotExpanderProcessor) MarshalJSON() ([]byte, error) {
+	return dotExpanderProcessor{
+		Field:         p.field,
+		Path:          p.path,
+		Description:   p.description,
+		If:            p.ifCond,
+		IgnoreFailure: p.ignoreFailure,
+		OnFailure:     p.onFailure,
+		Tag:           p.tag,
+	}.MarshalJSON()
+}
+
+func (p *DotExpanderProcessor) UnmarshalBSON(data []byte) error {
+	return p.UnmarshalJSON(data)
+}
+
+func (p *DotExpanderProcessor) UnmarshalJSON(data []byte) error {
+	*p = DotExpanderProcessor{}
+	var v dotExpanderProcessor
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	p.field = v.Field
+	p.path = v.Path
+	p.description = v.Description
+	p.ifCond = v.If
+	p.ignoreFailure = v.IgnoreFailure
+	p.onFailure = v.OnFailure
+	p.tag = v.Tag
+	return nil
+}
+
+//easyjson:json
+type dotExpanderProcessor struct {
+	Field         string     `json:"field,omitempty"`
+	Path          string     `json:"path,omitempty"`
+	Description   string     `json:"description,omitempty"`
+	If            string     `json:"if,omitempty"`
+	IgnoreFailure bool       `json:"ignore_failure,omitempty"`
+	OnFailure     Processors `json:"on_failure,omitempty"`
+	Tag           string     `json:"tag,omitempty"`
+}
@@ -0,0 +1,101 @@
+package picker
+
+// DropProcessorParams creates a DropProcessor, which drops the document without
+// raising any errors. This is useful to prevent the document from getting
+// indexed based on some condition.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/drop-processor.html
+type DropProcessorParams struct {
+	// Description of the processor. Useful for describing the purpose of the
+	// processor or its configuration.
+	Description string
+	// Conditionally execute the processor
+	If string
+	// Ignore failures for the processor
+	IgnoreFailure bool
+	// Handle failures for the processor
+	OnFailure Procs
+	// Identifier for the processor. Useful for debugging and metrics.
+	Tag string
+}
+
+func (DropProcessorParams) Kind() ProcessorKind {
+	return ProcessorKindDrop
+}
+
+func (p DropProcessorParams) Processor() (Processor, error) {
+	return p.Drop()
+}
+
+func (p DropProcessorParams) Drop() (*DropProcessor, error) {
+	proc := &DropProcessor{}
+	proc.SetDescription(p.Description)
+	proc.SetIf(p.If)
+	proc.SetIgnoreFailure(p.IgnoreFailure)
+	err := proc.SetOnFailure(p.OnFailure)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindDrop)
+	}
+	proc.SetTag(p.Tag)
+	return proc, nil
+}
+
+// DropProcessor drops the document without raising any errors. This is useful
+// to prevent the document from getting indexed based on some condition.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/drop-processor.html
+type DropProcessor struct {
+	processorParams
+}
+
+var _ Processor = (*DropProcessor)(nil)
+
+func (DropProcessor) Kind() ProcessorKind {
+	return ProcessorKindDrop
+}
+
+func (p *DropProcessor) Processor() (Processor, error) {
+	return p, nil
+}
+
+func (p DropProcessor) MarshalBSON() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+func (p DropProcessor) MarshalJSON() ([]byte, error) {
+	return dropProcessor{
+		Description:   p.description,
+		If:            p.ifCond,
+		IgnoreFailure: p.ignoreFailure,
+		OnFailure:     p.onFailure,
+		Tag:           p.tag,
+	}.MarshalJSON()
+}
+
+func (p *DropProcessor) UnmarshalBSON(data []byte) error {
+	return p.UnmarshalJSON(data)
+}
+
+func (p *DropProcessor) UnmarshalJSON(data []byte) error {
+	*p = DropProcessor{}
+	var v dropProcessor
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	p.description = v.Description
+	p.ifCond = v.If
+	p.ignoreFailure = v.IgnoreFailure
+	p.onFailure = v.OnFailure
+	p.tag = v.Tag
+	return nil
+}
+
+//easyjson:json
+type dropProcessor struct {
+	Description   string     `json:"description,omitempty"`
+	If            string     `json:"if,omitempty"`
+	IgnoreFailure bool       `json:"ignore_failure,omitempty"`
+	OnFailure     Processors `json:"on_failure,omitempty"`
+	Tag           string     `json:"tag,omitempty"`
+}
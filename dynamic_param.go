@@ -1,7 +1,9 @@
 package picker
 
 import (
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -21,6 +23,22 @@ func (d Dynamic) String() string {
 	return string(d)
 }
 
+// UnmarshalJSON accepts both strings and booleans
+func (d *Dynamic) UnmarshalJSON(data []byte) error {
+	var b bool
+	if json.Unmarshal(data, &b) == nil {
+		*d = Dynamic(strconv.FormatBool(b))
+		return nil
+	}
+	var s string
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return err
+	}
+	*d = Dynamic(s)
+	return nil
+}
+
 func (d *Dynamic) Validate() error {
 	if !d.IsValid() {
 		strs := make([]string, len(dynamicOpts)+1)
@@ -0,0 +1,670 @@
+package picker
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/chanced/dynamic"
+)
+
+// DynamicMappingType is the type of a JSON value as detected by dynamic
+// mapping. It is used by the match_mapping_type of dynamic templates.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/dynamic-templates.html#match-mapping-type
+type DynamicMappingType string
+
+const (
+	// DynamicMappingTypeAll matches values of any type
+	DynamicMappingTypeAll     DynamicMappingType = "*"
+	DynamicMappingTypeObject  DynamicMappingType = "object"
+	DynamicMappingTypeString  DynamicMappingType = "string"
+	DynamicMappingTypeLong    DynamicMappingType = "long"
+	DynamicMappingTypeDouble  DynamicMappingType = "double"
+	DynamicMappingTypeBoolean DynamicMappingType = "boolean"
+	DynamicMappingTypeDate    DynamicMappingType = "date"
+	DynamicMappingTypeBinary  DynamicMappingType = "binary"
+)
+
+func (t DynamicMappingType) String() string {
+	return string(t)
+}
+
+func (t DynamicMappingType) IsValid() bool {
+	switch t {
+	case DynamicMappingTypeAll, DynamicMappingTypeObject, DynamicMappingTypeString,
+		DynamicMappingTypeLong, DynamicMappingTypeDouble, DynamicMappingTypeBoolean,
+		DynamicMappingTypeDate, DynamicMappingTypeBinary:
+		return true
+	}
+	return false
+}
+
+// FieldType is the type of field dynamic mapping creates for values of type t
+// when the mapping of a dynamic template does not specify one
+func (t DynamicMappingType) FieldType() FieldType {
+	switch t {
+	case DynamicMappingTypeString:
+		return FieldTypeText
+	case DynamicMappingTypeDouble:
+		return FieldTypeFloat
+	}
+	return FieldType(t)
+}
+
+// MatchPattern determines how the match and unmatch patterns of a dynamic
+// template are interpreted
+type MatchPattern string
+
+const (
+	// MatchPatternSimple patterns support the * wildcard (default)
+	MatchPatternSimple MatchPattern = "simple"
+	// MatchPatternRegex patterns are regular expressions
+	MatchPatternRegex MatchPattern = "regex"
+)
+
+func (mp MatchPattern) String() string {
+	return string(mp)
+}
+
+func (mp MatchPattern) IsValid() bool {
+	return mp == MatchPatternSimple || mp == MatchPatternRegex
+}
+
+// DynamicTemplater is implemented by DynamicTemplateParams and
+// *DynamicTemplate
+type DynamicTemplater interface {
+	DynamicTemplate() (*DynamicTemplate, error)
+}
+
+// DynamicTemplateParams creates a DynamicTemplate
+//
+//	picker.DynamicTemplateParams{
+//	    Name:             "strings_as_keywords",
+//	    MatchMappingType: picker.DynamicMappingTypeString,
+//	    Unmatch:          []string{"*_text"},
+//	    Mapping:          picker.KeywordFieldParams{},
+//	}
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/dynamic-templates.html
+type DynamicTemplateParams struct {
+	// Name of the template (Required)
+	Name string
+	// Match patterns which the name of the field must match
+	Match []string
+	// Unmatch patterns which exclude fields by name
+	Unmatch []string
+	// PathMatch patterns which the full dotted path of the field must match
+	PathMatch []string
+	// PathUnmatch patterns which exclude fields by their full dotted path
+	PathUnmatch []string
+	// MatchMappingType is the type of JSON value, as detected by dynamic
+	// mapping, the template applies to
+	MatchMappingType DynamicMappingType
+	// MatchPattern determines whether Match and Unmatch are simple wildcard
+	// patterns (default) or regular expressions
+	MatchPattern MatchPattern
+	// Mapping is the field the template maps matching fields to. One of
+	// Mapping, MappingTemplate, or Runtime is required.
+	Mapping Fielder
+	// MappingTemplate is the mapping of the template as JSON. Use it for
+	// mappings which contain the {name} or {dynamic_type} placeholders or
+	// which omit the type.
+	MappingTemplate dynamic.JSON
+	// Runtime is the runtime field the template maps matching fields to
+	Runtime *RuntimeMappingField
+}
+
+// DynamicTemplate returns the validated DynamicTemplate of p
+func (p DynamicTemplateParams) DynamicTemplate() (*DynamicTemplate, error) {
+	t := &DynamicTemplate{}
+	err := t.SetName(p.Name)
+	if err != nil {
+		return t, err
+	}
+	t.SetMatch(p.Match)
+	t.SetUnmatch(p.Unmatch)
+	t.SetPathMatch(p.PathMatch)
+	t.SetPathUnmatch(p.PathUnmatch)
+	err = t.SetMatchMappingType(p.MatchMappingType)
+	if err != nil {
+		return t, newFieldError(err, p.Name)
+	}
+	err = t.SetMatchPattern(p.MatchPattern)
+	if err != nil {
+		return t, newFieldError(err, p.Name)
+	}
+	set := 0
+	if p.Mapping != nil {
+		set++
+		err = t.SetMapping(p.Mapping)
+		if err != nil {
+			return t, newFieldError(err, p.Name)
+		}
+	}
+	if len(p.MappingTemplate) > 0 {
+		set++
+		t.SetMappingTemplate(p.MappingTemplate)
+	}
+	if p.Runtime != nil {
+		set++
+		t.SetRuntime(p.Runtime)
+	}
+	switch {
+	case set == 0:
+		return t, newFieldError(ErrTemplateMappingRequired, p.Name)
+	case set > 1:
+		return t, newFieldError(ErrMultipleTemplateMappings, p.Name)
+	}
+	return t, nil
+}
+
+// NewDynamicTemplate returns a new DynamicTemplate from params
+func NewDynamicTemplate(params DynamicTemplateParams) (*DynamicTemplate, error) {
+	return params.DynamicTemplate()
+}
+
+// DynamicTemplate defines a custom mapping which is applied to dynamically
+// added fields based on the detected data type, the name of the field, or
+// its full dotted path.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/dynamic-templates.html
+type DynamicTemplate struct {
+	name             string
+	match            []string
+	unmatch          []string
+	pathMatch        []string
+	pathUnmatch      []string
+	matchMappingType DynamicMappingType
+	matchPattern     MatchPattern
+	mapping          Field
+	mappingTemplate  dynamic.JSON
+	runtime          *RuntimeMappingField
+}
+
+// DynamicTemplate returns t, satisfying DynamicTemplater
+func (t *DynamicTemplate) DynamicTemplate() (*DynamicTemplate, error) {
+	return t, nil
+}
+
+// Name of the template
+func (t DynamicTemplate) Name() string {
+	return t.name
+}
+
+// SetName sets the name of the template to v
+func (t *DynamicTemplate) SetName(v string) error {
+	if len(v) == 0 {
+		return ErrTemplateNameRequired
+	}
+	t.name = v
+	return nil
+}
+
+// Match patterns which the name of the field must match
+func (t DynamicTemplate) Match() []string {
+	return t.match
+}
+
+// SetMatch sets the match patterns to v
+func (t *DynamicTemplate) SetMatch(v []string) {
+	t.match = v
+}
+
+// Unmatch patterns which exclude fields by name
+func (t DynamicTemplate) Unmatch() []string {
+	return t.unmatch
+}
+
+// SetUnmatch sets the unmatch patterns to v
+func (t *DynamicTemplate) SetUnmatch(v []string) {
+	t.unmatch = v
+}
+
+// PathMatch patterns which the full dotted path of the field must match
+func (t DynamicTemplate) PathMatch() []string {
+	return t.pathMatch
+}
+
+// SetPathMatch sets the path_match patterns to v
+func (t *DynamicTemplate) SetPathMatch(v []string) {
+	t.pathMatch = v
+}
+
+// PathUnmatch patterns which exclude fields by their full dotted path
+func (t DynamicTemplate) PathUnmatch() []string {
+	return t.pathUnmatch
+}
+
+// SetPathUnmatch sets the path_unmatch patterns to v
+func (t *DynamicTemplate) SetPathUnmatch(v []string) {
+	t.pathUnmatch = v
+}
+
+// MatchMappingType is the type of JSON value, as detected by dynamic mapping,
+// the template applies to
+func (t DynamicTemplate) MatchMappingType() DynamicMappingType {
+	return t.matchMappingType
+}
+
+// SetMatchMappingType sets match_mapping_type to v
+func (t *DynamicTemplate) SetMatchMappingType(v DynamicMappingType) error {
+	if len(v) > 0 && !v.IsValid() {
+		return fmt.Errorf("%w <%s>", ErrInvalidMappingType, v)
+	}
+	t.matchMappingType = v
+	return nil
+}
+
+// MatchPattern determines whether match and unmatch are simple wildcard
+// patterns or regular expressions. Defaults to simple.
+func (t DynamicTemplate) MatchPattern() MatchPattern {
+	if len(t.matchPattern) == 0 {
+		return MatchPatternSimple
+	}
+	return t.matchPattern
+}
+
+// SetMatchPattern sets match_pattern to v
+func (t *DynamicTemplate) SetMatchPattern(v MatchPattern) error {
+	if len(v) > 0 && !v.IsValid() {
+		return fmt.Errorf("%w <%s>", ErrInvalidMatchPattern, v)
+	}
+	t.matchPattern = v
+	return nil
+}
+
+// Mapping is the field the template maps matching fields to. It is nil if the
+// template uses a mapping template or a runtime field.
+func (t DynamicTemplate) Mapping() Field {
+	return t.mapping
+}
+
+// SetMapping sets the mapping of the template to v, clearing the mapping
+// template and runtime field
+func (t *DynamicTemplate) SetMapping(v Fielder) error {
+	t.mapping, t.mappingTemplate, t.runtime = nil, nil, nil
+	if v == nil {
+		return nil
+	}
+	f, err := v.Field()
+	if err != nil {
+		return err
+	}
+	t.mapping = f
+	return nil
+}
+
+// MappingTemplate is the mapping of the template as JSON when it contains the
+// {name} or {dynamic_type} placeholders or omits the type
+func (t DynamicTemplate) MappingTemplate() dynamic.JSON {
+	return t.mappingTemplate
+}
+
+// SetMappingTemplate sets the mapping template to v, clearing the mapping and
+// runtime field
+func (t *DynamicTemplate) SetMappingTemplate(v dynamic.JSON) {
+	t.mapping, t.mappingTemplate, t.runtime = nil, nil, nil
+	if len(v) > 0 {
+		t.mappingTemplate = v
+	}
+}
+
+// Runtime is the runtime field the template maps matching fields to
+func (t DynamicTemplate) Runtime() *RuntimeMappingField {
+	return t.runtime
+}
+
+// SetRuntime sets the runtime field of the template to v, clearing the
+// mapping and mapping template
+func (t *DynamicTemplate) SetRuntime(v *RuntimeMappingField) {
+	t.mapping, t.mappingTemplate, t.runtime = nil, nil, v
+}
+
+// Matches reports whether t applies to the field at the dotted path with a
+// value of type typ
+func (t DynamicTemplate) Matches(path string, typ DynamicMappingType) (bool, error) {
+	if t.runtime != nil && typ == DynamicMappingTypeObject {
+		return false, nil
+	}
+	if len(t.matchMappingType) > 0 && t.matchMappingType != DynamicMappingTypeAll && t.matchMappingType != typ {
+		return false, nil
+	}
+	name := path
+	if i := strings.LastIndex(path, "."); i > -1 {
+		name = path[i+1:]
+	}
+	regex := t.MatchPattern() == MatchPatternRegex
+	checks := []struct {
+		patterns []string
+		value    string
+		regex    bool
+		want     bool
+	}{
+		{t.match, name, regex, true},
+		{t.unmatch, name, regex, false},
+		{t.pathMatch, path, false, true},
+		{t.pathUnmatch, path, false, false},
+	}
+	for _, c := range checks {
+		if len(c.patterns) == 0 {
+			continue
+		}
+		matched, err := matchesAny(c.patterns, c.value, c.regex)
+		if err != nil {
+			return false, newFieldError(err, t.name)
+		}
+		if matched != c.want {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// resolve returns the field t maps the field at path with a value of type typ
+// to, substituting the {name} and {dynamic_type} placeholders of the mapping
+// template
+func (t DynamicTemplate) resolve(path string, typ DynamicMappingType) (Field, error) {
+	if t.mapping != nil {
+		return t.mapping, nil
+	}
+	if len(t.mappingTemplate) == 0 {
+		return nil, nil
+	}
+	var m map[string]interface{}
+	err := json.Unmarshal(t.mappingTemplate, &m)
+	if err != nil {
+		return nil, err
+	}
+	name := path
+	if i := strings.LastIndex(path, "."); i > -1 {
+		name = path[i+1:]
+	}
+	replacer := strings.NewReplacer("{name}", name, "{dynamic_type}", string(typ.FieldType()))
+	resolved, _ := replacePlaceholders(m, replacer).(map[string]interface{})
+	if _, ok := resolved["type"]; !ok {
+		resolved["type"] = string(typ.FieldType())
+	}
+	data, err := json.Marshal(resolved)
+	if err != nil {
+		return nil, err
+	}
+	var f Field
+	err = UnmarshalFieldJSON(data, &f)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func replacePlaceholders(v interface{}, r *strings.Replacer) interface{} {
+	switch t := v.(type) {
+	case string:
+		return r.Replace(t)
+	case []interface{}:
+		for i, e := range t {
+			t[i] = replacePlaceholders(e, r)
+		}
+		return t
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(t))
+		for key, e := range t {
+			res[r.Replace(key)] = replacePlaceholders(e, r)
+		}
+		return res
+	}
+	return v
+}
+
+func matchesAny(patterns []string, value string, regex bool) (bool, error) {
+	for _, p := range patterns {
+		if !regex {
+			if simpleMatch(p, value) {
+				return true, nil
+			}
+			continue
+		}
+		re, err := regexp.Compile("^(?:" + p + ")$")
+		if err != nil {
+			return false, fmt.Errorf("%w; %v", ErrInvalidMatchPattern, err)
+		}
+		if re.MatchString(value) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// simpleMatch reports whether value matches pattern, in which * matches any
+// sequence of characters
+func simpleMatch(pattern, value string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == value
+	}
+	if !strings.HasPrefix(value, parts[0]) {
+		return false
+	}
+	value = value[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(value, part)
+		if i < 0 {
+			return false
+		}
+		value = value[i+len(part):]
+	}
+	return strings.HasSuffix(value, parts[len(parts)-1])
+}
+
+func (t DynamicTemplate) MarshalBSON() ([]byte, error) {
+	return t.MarshalJSON()
+}
+
+// MarshalJSON encodes t as an object keyed by its name
+func (t DynamicTemplate) MarshalJSON() ([]byte, error) {
+	v := dynamicTemplate{
+		MatchMappingType: t.matchMappingType,
+		MatchPattern:     t.matchPattern,
+		Mapping:          t.mappingTemplate,
+		Runtime:          t.runtime,
+	}
+	var err error
+	for _, p := range []struct {
+		dst *dynamic.JSON
+		src []string
+	}{
+		{&v.Match, t.match},
+		{&v.Unmatch, t.unmatch},
+		{&v.PathMatch, t.pathMatch},
+		{&v.PathUnmatch, t.pathUnmatch},
+	} {
+		if len(p.src) == 0 {
+			continue
+		}
+		*p.dst, err = marshalStringOrArrayOfStrings(p.src)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if t.mapping != nil {
+		v.Mapping, err = t.mapping.MarshalJSON()
+		if err != nil {
+			return nil, newFieldError(err, t.name)
+		}
+	}
+	data, err := v.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(dynamic.JSONObject{t.name: data})
+}
+
+func (t *DynamicTemplate) UnmarshalBSON(data []byte) error {
+	return t.UnmarshalJSON(data)
+}
+
+// UnmarshalJSON decodes an object with a single key, the name of the
+// template
+func (t *DynamicTemplate) UnmarshalJSON(data []byte) error {
+	*t = DynamicTemplate{}
+	var obj dynamic.JSONObject
+	err := json.Unmarshal(data, &obj)
+	if err != nil {
+		return err
+	}
+	if len(obj) != 1 {
+		return fmt.Errorf("%w; dynamic templates must be an object with a single key, the name of the template", ErrTemplateNameRequired)
+	}
+	for name, d := range obj {
+		t.name = name
+		var v dynamicTemplate
+		err = v.UnmarshalJSON(d)
+		if err != nil {
+			return newFieldError(err, name)
+		}
+		for _, p := range []struct {
+			dst *[]string
+			src dynamic.JSON
+		}{
+			{&t.match, v.Match},
+			{&t.unmatch, v.Unmatch},
+			{&t.pathMatch, v.PathMatch},
+			{&t.pathUnmatch, v.PathUnmatch},
+		} {
+			if len(p.src) == 0 || p.src.IsNull() {
+				continue
+			}
+			var s dynamic.StringOrArrayOfStrings
+			err = json.Unmarshal(p.src, &s)
+			if err != nil {
+				return newFieldError(err, name)
+			}
+			*p.dst = s
+		}
+		t.matchMappingType = v.MatchMappingType
+		t.matchPattern = v.MatchPattern
+		t.runtime = v.Runtime
+		if len(v.Mapping) > 0 && !v.Mapping.IsNull() {
+			err = t.unmarshalMapping(v.Mapping)
+			if err != nil {
+				return newFieldError(err, name)
+			}
+		}
+	}
+	return nil
+}
+
+// unmarshalMapping decodes the mapping as a Field unless it contains
+// placeholders or omits the type, in which case it is kept as a mapping
+// template
+func (t *DynamicTemplate) unmarshalMapping(data dynamic.JSON) error {
+	var typed struct {
+		Type string `json:"type"`
+	}
+	err := json.Unmarshal(data, &typed)
+	if err != nil {
+		return err
+	}
+	if len(typed.Type) == 0 || strings.Contains(string(data), "{name}") || strings.Contains(string(data), "{dynamic_type}") {
+		t.mappingTemplate = data
+		return nil
+	}
+	var f Field
+	err = UnmarshalFieldJSON(data, &f)
+	if err != nil {
+		return err
+	}
+	t.mapping = f
+	return nil
+}
+
+//easyjson:json
+type dynamicTemplate struct {
+	Match            dynamic.JSON         `json:"match,omitempty"`
+	Unmatch          dynamic.JSON         `json:"unmatch,omitempty"`
+	PathMatch        dynamic.JSON         `json:"path_match,omitempty"`
+	PathUnmatch      dynamic.JSON         `json:"path_unmatch,omitempty"`
+	MatchMappingType DynamicMappingType   `json:"match_mapping_type,omitempty"`
+	MatchPattern     MatchPattern         `json:"match_pattern,omitempty"`
+	Mapping          dynamic.JSON         `json:"mapping,omitempty"`
+	Runtime          *RuntimeMappingField `json:"runtime,omitempty"`
+}
+
+// DynamicTemplates are the ordered dynamic templates of a mapping. The first
+// template which matches a field is applied.
+type DynamicTemplates []*DynamicTemplate
+
+// Resolve returns the first template of t which applies to the field at the
+// dotted path with a value of type typ, along with the field the template
+// maps it to. The {name} and {dynamic_type} placeholders of mapping templates
+// are substituted and, if the mapping omits the type, the type dynamic
+// mapping would use for typ is applied.
+//
+// The returned Field is nil when no template applies, in which case the
+// default dynamic mapping is used by Elasticsearch, or when the template maps
+// to a runtime field.
+func (t DynamicTemplates) Resolve(path string, typ DynamicMappingType) (*DynamicTemplate, Field, error) {
+	for _, tmpl := range t {
+		if tmpl == nil {
+			continue
+		}
+		ok, err := tmpl.Matches(path, typ)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !ok {
+			continue
+		}
+		f, err := tmpl.resolve(path, typ)
+		if err != nil {
+			return tmpl, nil, newFieldError(err, tmpl.name)
+		}
+		return tmpl, f, nil
+	}
+	return nil, nil, nil
+}
+
+func (t DynamicTemplates) MarshalBSON() ([]byte, error) {
+	return t.MarshalJSON()
+}
+
+func (t DynamicTemplates) MarshalJSON() ([]byte, error) {
+	data := make([]dynamic.JSON, 0, len(t))
+	for _, tmpl := range t {
+		if tmpl == nil {
+			continue
+		}
+		d, err := tmpl.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, d)
+	}
+	return json.Marshal(data)
+}
+
+func (t *DynamicTemplates) UnmarshalBSON(data []byte) error {
+	return t.UnmarshalJSON(data)
+}
+
+func (t *DynamicTemplates) UnmarshalJSON(data []byte) error {
+	*t = DynamicTemplates{}
+	if len(data) == 0 || dynamic.JSON(data).IsNull() {
+		return nil
+	}
+	var raw []dynamic.JSON
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+	for _, d := range raw {
+		tmpl := &DynamicTemplate{}
+		err = tmpl.UnmarshalJSON(d)
+		if err != nil {
+			return err
+		}
+		*t = append(*t, tmpl)
+	}
+	return nil
+}
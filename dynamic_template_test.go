@@ -0,0 +1,188 @@
+package picker_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/chanced/cmpjson"
+	"github.com/chanced/picker"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDynamicTemplates(t *testing.T) {
+	assert := require.New(t)
+	data := []byte(`{
+		"mappings": {
+		  "dynamic_templates": [
+			{
+			  "integers": {
+				"match_mapping_type": "long",
+				"mapping": { "type": "integer" }
+			  }
+			},
+			{
+			  "strings_as_keywords": {
+				"match_mapping_type": "string",
+				"unmatch": "*_text",
+				"mapping": { "type": "keyword" }
+			  }
+			},
+			{
+			  "full_name": {
+				"path_match": ["name.*", "person.*"],
+				"path_unmatch": "*.middle",
+				"mapping": { "type": "text", "index": false }
+			  }
+			},
+			{
+			  "ip_fields": {
+				"match": "^ip_.*$",
+				"match_pattern": "regex",
+				"runtime": { "type": "ip" }
+			  }
+			}
+		  ],
+		  "properties": {
+			"message": { "type": "text" }
+		  }
+		}
+	  }`)
+	i, err := picker.NewIndex(picker.IndexParams{Mappings: picker.Mappings{
+		DynamicTemplates: []picker.DynamicTemplater{
+			picker.DynamicTemplateParams{
+				Name:             "integers",
+				MatchMappingType: picker.DynamicMappingTypeLong,
+				Mapping:          picker.IntegerFieldParams{},
+			},
+			picker.DynamicTemplateParams{
+				Name:             "strings_as_keywords",
+				MatchMappingType: picker.DynamicMappingTypeString,
+				Unmatch:          []string{"*_text"},
+				Mapping:          picker.KeywordFieldParams{},
+			},
+			picker.DynamicTemplateParams{
+				Name:        "full_name",
+				PathMatch:   []string{"name.*", "person.*"},
+				PathUnmatch: []string{"*.middle"},
+				Mapping:     picker.TextFieldParams{Index: false},
+			},
+			picker.DynamicTemplateParams{
+				Name:         "ip_fields",
+				Match:        []string{"^ip_.*$"},
+				MatchPattern: picker.MatchPatternRegex,
+				Runtime:      &picker.RuntimeMappingField{Kind: picker.RMTIP},
+			},
+		},
+		Properties: picker.FieldMap{"message": picker.TextFieldParams{}},
+	}})
+	assert.NoError(err)
+	ixd, err := i.MarshalJSON()
+	assert.NoError(err)
+	assert.True(cmpjson.Equal(data, ixd), cmpjson.Diff(data, ixd))
+
+	i2 := picker.Index{}
+	err = i2.UnmarshalJSON(data)
+	assert.NoError(err)
+	tmpls := i2.Mappings.DynamicTemplates
+	assert.Len(tmpls, 4)
+	assert.Equal("integers", tmpls[0].Name())
+	assert.Equal(picker.FieldTypeInteger, tmpls[0].Mapping().Type())
+	assert.Equal([]string{"*_text"}, tmpls[1].Unmatch())
+	assert.Equal([]string{"name.*", "person.*"}, tmpls[2].PathMatch())
+	assert.Equal(picker.MatchPatternRegex, tmpls[3].MatchPattern())
+	assert.Equal(picker.RMTIP, tmpls[3].Runtime().Kind)
+	i2d, err := i2.MarshalJSON()
+	assert.NoError(err)
+	assert.True(cmpjson.Equal(data, i2d), cmpjson.Diff(data, i2d))
+
+	md := []byte(`{"dynamic_templates":[{"strings":{"match_mapping_type":"string","mapping":{"type":"keyword"}}}],"properties":{}}`)
+	var m picker.Mappings
+	err = json.Unmarshal(md, &m)
+	assert.NoError(err)
+	assert.Len(m.DynamicTemplates, 1)
+	md2, err := json.Marshal(m)
+	assert.NoError(err)
+	assert.True(cmpjson.Equal(md, md2), cmpjson.Diff(md, md2))
+
+	_, err = picker.DynamicTemplateParams{Name: "missing"}.DynamicTemplate()
+	assert.ErrorIs(err, picker.ErrTemplateMappingRequired)
+	_, err = picker.DynamicTemplateParams{
+		Name:    "multiple",
+		Mapping: picker.KeywordFieldParams{},
+		Runtime: &picker.RuntimeMappingField{Kind: picker.RMTKeyword},
+	}.DynamicTemplate()
+	assert.ErrorIs(err, picker.ErrMultipleTemplateMappings)
+	_, err = picker.DynamicTemplateParams{Mapping: picker.KeywordFieldParams{}}.DynamicTemplate()
+	assert.ErrorIs(err, picker.ErrTemplateNameRequired)
+	_, err = picker.DynamicTemplateParams{
+		Name:         "invalid",
+		MatchPattern: "glob",
+		Mapping:      picker.KeywordFieldParams{},
+	}.DynamicTemplate()
+	assert.ErrorIs(err, picker.ErrInvalidMatchPattern)
+}
+
+func TestDynamicTemplatesResolve(t *testing.T) {
+	assert := require.New(t)
+	var tmpls picker.DynamicTemplates
+	err := json.Unmarshal([]byte(`[
+		{ "geo": { "match": "*_geo", "match_mapping_type": "object", "mapping": { "type": "geo_point" } } },
+		{ "ip_fields": { "match": "^ip_[a-z]+$", "match_pattern": "regex", "runtime": { "type": "ip" } } },
+		{ "labels": {
+			"path_match": "labels.*",
+			"mapping": { "type": "match_only_text", "fields": { "{name}_keyword": { "type": "keyword" } } }
+		} },
+		{ "named": { "match_mapping_type": "*", "match": "*_named", "mapping": { "type": "{dynamic_type}" } } },
+		{ "untyped": { "match": "*_untyped", "mapping": { "index": false } } }
+	]`), &tmpls)
+	assert.NoError(err)
+	assert.Len(tmpls, 5)
+
+	tmpl, f, err := tmpls.Resolve("location_geo", picker.DynamicMappingTypeObject)
+	assert.NoError(err)
+	assert.Equal("geo", tmpl.Name())
+	assert.Equal(picker.FieldTypeGeoPoint, f.Type())
+
+	tmpl, f, err = tmpls.Resolve("client.ip_source", picker.DynamicMappingTypeString)
+	assert.NoError(err)
+	assert.Equal("ip_fields", tmpl.Name())
+	assert.Nil(f)
+	assert.Equal(picker.RMTIP, tmpl.Runtime().Kind)
+
+	tmpl, _, err = tmpls.Resolve("ip_source", picker.DynamicMappingTypeObject)
+	assert.NoError(err)
+	assert.Nil(tmpl)
+
+	tmpl, f, err = tmpls.Resolve("labels.env", picker.DynamicMappingTypeString)
+	assert.NoError(err)
+	assert.Equal("labels", tmpl.Name())
+	assert.Equal(picker.FieldTypeMatchOnlyText, f.Type())
+	fd, err := f.MarshalJSON()
+	assert.NoError(err)
+	expected := []byte(`{"type":"match_only_text","fields":{"env_keyword":{"type":"keyword"}}}`)
+	assert.True(cmpjson.Equal(expected, fd), cmpjson.Diff(expected, fd))
+
+	_, f, err = tmpls.Resolve("count_named", picker.DynamicMappingTypeLong)
+	assert.NoError(err)
+	assert.Equal(picker.FieldTypeLong, f.Type())
+	_, f, err = tmpls.Resolve("message_named", picker.DynamicMappingTypeString)
+	assert.NoError(err)
+	assert.Equal(picker.FieldTypeText, f.Type())
+
+	_, f, err = tmpls.Resolve("ratio_untyped", picker.DynamicMappingTypeDouble)
+	assert.NoError(err)
+	assert.Equal(picker.FieldTypeFloat, f.Type())
+
+	tmpl, f, err = tmpls.Resolve("message", picker.DynamicMappingTypeString)
+	assert.NoError(err)
+	assert.Nil(tmpl)
+	assert.Nil(f)
+
+	d, err := json.Marshal(tmpls)
+	assert.NoError(err)
+	var rt picker.DynamicTemplates
+	assert.NoError(json.Unmarshal(d, &rt))
+	d2, err := json.Marshal(rt)
+	assert.NoError(err)
+	assert.True(cmpjson.Equal(d, d2), cmpjson.Diff(d, d2))
+}
@@ -0,0 +1,239 @@
+package picker
+
+import "github.com/chanced/dynamic"
+
+// EnrichProcessorParams creates a EnrichProcessor, which enriches documents
+// with data from another index.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/enrich-processor.html
+type EnrichProcessorParams struct {
+	// PolicyName is the name of the enrich policy to use
+	PolicyName string
+	// Field is the field in the input document that matches the policies
+	// match_field used to retrieve the enrichment data. Supports template
+	// snippets.
+	Field string
+	// TargetField is the field added to incoming documents to contain enrich
+	// data. Supports template snippets.
+	TargetField string
+	// If IgnoreMissing is true and field does not exist or is null, the
+	// processor quietly exits without modifying the document
+	IgnoreMissing bool
+	// Override indicates whether the processor will update fields with
+	// pre-existing non-null-valued field. Defaults to true.
+	Override interface{}
+	// MaxMatches is the maximum number of matched documents to include under
+	// the configured target field. Defaults to 1.
+	MaxMatches int
+	// ShapeRelation is the spatial relation operator used to match the geoshape
+	// of incoming documents to documents in the enrich index. Only used for
+	// geo_match enrich policy types.
+	ShapeRelation string
+	// Description of the processor. Useful for describing the purpose of the
+	// processor or its configuration.
+	Description string
+	// Conditionally execute the processor
+	If string
+	// Ignore failures for the processor
+	IgnoreFailure bool
+	// Handle failures for the processor
+	OnFailure Procs
+	// Identifier for the processor. Useful for debugging and metrics.
+	Tag string
+}
+
+func (EnrichProcessorParams) Kind() ProcessorKind {
+	return ProcessorKindEnrich
+}
+
+func (p EnrichProcessorParams) Processor() (Processor, error) {
+	return p.Enrich()
+}
+
+func (p EnrichProcessorParams) Enrich() (*EnrichProcessor, error) {
+	proc := &EnrichProcessor{}
+	err := proc.SetPolicyName(p.PolicyName)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindEnrich)
+	}
+	err = proc.SetField(p.Field)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindEnrich)
+	}
+	err = proc.SetTargetField(p.TargetField)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindEnrich)
+	}
+	proc.SetIgnoreMissing(p.IgnoreMissing)
+	err = proc.SetOverride(p.Override)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindEnrich)
+	}
+	proc.SetMaxMatches(p.MaxMatches)
+	proc.SetShapeRelation(p.ShapeRelation)
+	proc.SetDescription(p.Description)
+	proc.SetIf(p.If)
+	proc.SetIgnoreFailure(p.IgnoreFailure)
+	err = proc.SetOnFailure(p.OnFailure)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindEnrich)
+	}
+	proc.SetTag(p.Tag)
+	return proc, nil
+}
+
+// EnrichProcessor enriches documents with data from another index.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/enrich-processor.html
+type EnrichProcessor struct {
+	policyName string
+	fieldParam
+	targetField string
+	ignoreMissingParam
+	override      dynamic.Bool
+	maxMatches    int
+	shapeRelation string
+	processorParams
+}
+
+var _ Processor = (*EnrichProcessor)(nil)
+
+func (EnrichProcessor) Kind() ProcessorKind {
+	return ProcessorKindEnrich
+}
+
+func (p *EnrichProcessor) Processor() (Processor, error) {
+	return p, nil
+}
+
+// PolicyName is the name of the enrich policy to use
+func (p EnrichProcessor) PolicyName() string {
+	return p.policyName
+}
+
+// SetPolicyName sets policy_name to v
+func (p *EnrichProcessor) SetPolicyName(v string) error {
+	if len(v) == 0 {
+		return ErrPolicyNameRequired
+	}
+	p.policyName = v
+	return nil
+}
+
+// TargetField is the field added to incoming documents to contain enrich data.
+// Supports template snippets.
+func (p EnrichProcessor) TargetField() string {
+	return p.targetField
+}
+
+// SetTargetField sets target_field to v
+func (p *EnrichProcessor) SetTargetField(v string) error {
+	if len(v) == 0 {
+		return ErrTargetFieldRequired
+	}
+	p.targetField = v
+	return nil
+}
+
+// Override indicates whether the processor will update fields with pre-existing
+// non-null-valued field. Defaults to true.
+func (p EnrichProcessor) Override() bool {
+	if v, ok := p.override.Bool(); ok {
+		return v
+	}
+	return true
+}
+
+// SetOverride sets override to v
+func (p *EnrichProcessor) SetOverride(v interface{}) error {
+	return p.override.Set(v)
+}
+
+// MaxMatches is the maximum number of matched documents to include under the
+// configured target field. Defaults to 1.
+func (p EnrichProcessor) MaxMatches() int {
+	return p.maxMatches
+}
+
+// SetMaxMatches sets max_matches to v
+func (p *EnrichProcessor) SetMaxMatches(v int) {
+	p.maxMatches = v
+}
+
+// ShapeRelation is the spatial relation operator used to match the geoshape of
+// incoming documents to documents in the enrich index. Only used for geo_match
+// enrich policy types.
+func (p EnrichProcessor) ShapeRelation() string {
+	return p.shapeRelation
+}
+
+// SetShapeRelation sets shape_relation to v
+func (p *EnrichProcessor) SetShapeRelation(v string) {
+	p.shapeRelation = v
+}
+
+func (p EnrichProcessor) MarshalBSON() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+func (p EnrichProcessor) MarshalJSON() ([]byte, error) {
+	return enrichProcessor{
+		PolicyName:    p.policyName,
+		Field:         p.field,
+		TargetField:   p.targetField,
+		IgnoreMissing: p.ignoreMissing,
+		Override:      p.override.Value(),
+		MaxMatches:    p.maxMatches,
+		ShapeRelation: p.shapeRelation,
+		Description:   p.description,
+		If:            p.ifCond,
+		IgnoreFailure: p.ignoreFailure,
+		OnFailure:     p.onFailure,
+		Tag:           p.tag,
+	}.MarshalJSON()
+}
+
+func (p *EnrichProcessor) UnmarshalBSON(data []byte) error {
+	return p.UnmarshalJSON(data)
+}
+
+func (p *EnrichProcessor) UnmarshalJSON(data []byte) error {
+	*p = EnrichProcessor{}
+	var v enrichProcessor
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	p.policyName = v.PolicyName
+	p.field = v.Field
+	p.targetField = v.TargetField
+	p.ignoreMissing = v.IgnoreMissing
+	err = p.override.Set(v.Override)
+	if err != nil {
+		return err
+	}
+	p.maxMatches = v.MaxMatches
+	p.shapeRelation = v.ShapeRelation
+	p.description = v.Description
+	p.ifCond = v.If
+	p.ignoreFailure = v.IgnoreFailure
+	p.onFailure = v.OnFailure
+	p.tag = v.Tag
+	return nil
+}
+
+//easyjson:json
+type enrichProcessor struct {
+	PolicyName    string      `json:"policy_name,omitempty"`
+	Field         string      `json:"field,omitempty"`
+	TargetField   string      `json:"target_field,omitempty"`
+	IgnoreMissing bool        `json:"ignore_missing,omitempty"`
+	Override      interface{} `json:"override,omitempty"`
+	MaxMatches    int         `json:"max_matches,omitempty"`
+	ShapeRelation string      `json:"shape_relation,omitempty"`
+	Description   string      `json:"description,omitempty"`
+	If            string      `json:"if,omitempty"`
+	IgnoreFailure bool        `json:"ignore_failure,omitempty"`
+	OnFailure     Processors  `json:"on_failure,omitempty"`
+	Tag           string      `json:"tag,omitempty"`
+}
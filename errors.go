@@ -69,6 +69,136 @@ var (
 	ErrInvalidExponent            = errors.New("picker: exponent must be > 0")
 	ErrExponentRequired           = errors.New("picker: exponent is required")
 	ErrMultiRankFeatureFunctions  = errors.New("picker: only one function saturation, log, sigmoid or linear can be provided")
+	ErrFieldOrScriptRequired      = errors.New("picker: field or script is required")
+	ErrAggKindRequired            = errors.New("picker: aggregation type is required")
+	ErrMultipleAggKinds           = errors.New("picker: aggregation can only be of one type")
+	ErrAggNameRequired            = errors.New("picker: aggregation name is required")
+	ErrInvalidAggName             = errors.New("picker: aggregation names can not contain '[', ']', or '>'")
+	ErrIntervalRequired           = errors.New("picker: interval is required")
+	ErrInvalidInterval            = errors.New("picker: interval must be > 0")
+	ErrMultipleIntervals          = errors.New("picker: only one of calendar_interval or fixed_interval can be provided")
+	ErrRangesRequired             = errors.New("picker: ranges are required")
+	ErrFiltersRequired            = errors.New("picker: filters are required")
+	ErrMixedFilters               = errors.New("picker: filters can either be named or anonymous but not both")
+	ErrInvalidPrecision           = errors.New("picker: invalid value for precision")
+	ErrInvalidCollectMode         = errors.New("picker: invalid value for collect_mode")
+	ErrInvalidSortOrder           = errors.New("picker: invalid sort order; expected \"asc\" or \"desc\"")
+	ErrInvalidExclude             = errors.New("picker: exclude does not support partitions")
+	ErrInvalidMaxDocCount         = errors.New("picker: max_doc_count must be between 1 and 100")
+	ErrSubAggsNotSupported        = errors.New("picker: aggregation does not support sub-aggregations")
+	ErrInvalidRateMode            = errors.New("picker: invalid rate mode; expected \"sum\" or \"value_count\"")
+	ErrInvalidTTestType           = errors.New("picker: invalid t_test type")
+	ErrMetricsRequired            = errors.New("picker: metrics are required")
+	ErrInvalidMetric              = errors.New("picker: invalid metric")
+	ErrDefaultMetricRequired      = errors.New("picker: default_metric is required when there is more than one metric")
+	ErrSortRequired               = errors.New("picker: sort is required")
+	ErrMultiplePercentilesMethods = errors.New("picker: only one of tdigest or hdr can be provided")
+	ErrInvalidHeuristic           = errors.New("picker: invalid significance heuristic")
+	ErrBucketsPathRequired        = errors.New("picker: buckets_path is required")
+	ErrInvalidBucketsPath         = errors.New("picker: invalid buckets_path")
+	ErrInvalidGapPolicy           = errors.New("picker: invalid gap_policy; expected \"skip\", \"insert_zeros\", or \"keep_values\"")
+	ErrWindowRequired             = errors.New("picker: window is required")
+	ErrInvalidLag                 = errors.New("picker: lag must be > 0")
+	ErrInvalidNormalizeMethod     = errors.New("picker: invalid normalize method")
+	ErrModelIDRequired            = errors.New("picker: model_id is required")
+	ErrMethodRequired             = errors.New("picker: method is required")
+	ErrSourcesRequired            = errors.New("picker: sources are required")
+	ErrSourceNameRequired         = errors.New("picker: source name is required")
+	ErrCompositeSourceRequired    = errors.New("picker: one of terms, histogram, date_histogram, or geotile_grid is required")
+	ErrMultipleCompositeSources   = errors.New("picker: composite source can only be of one type")
+	ErrDuplicateSourceName        = errors.New("picker: source names must be unique")
+	ErrCompositeNotFound          = errors.New("picker: composite aggregation not found")
+	ErrSearchRequired             = errors.New("picker: search is required")
+	ErrFetchRequired              = errors.New("picker: fetch is required")
+	ErrSourceMissing              = errors.New("picker: hit does not contain _source")
+	ErrInvalidSourceTarget        = errors.New("picker: invalid target for decoding sources")
+	ErrInvalidTimeValue           = errors.New("picker: invalid time value")
+	ErrInvalidHighlighterType     = errors.New("picker: invalid highlighter type; expected \"unified\", \"plain\", or \"fvh\"")
+	ErrFastVectorRequired         = errors.New("picker: option requires the fvh highlighter")
+	ErrPlainHighlighterRequired   = errors.New("picker: option requires the plain highlighter")
+	ErrInvalidBoundaryScanner     = errors.New("picker: invalid boundary_scanner; expected \"chars\", \"sentence\", or \"word\"")
+	ErrInvalidHighlightOrder      = errors.New("picker: invalid highlight order; expected \"none\" or \"score\"")
+	ErrTermVectorRequired         = errors.New("picker: fvh requires term_vector to be with_positions_offsets")
+	ErrPointInTimeRequired        = errors.New("picker: point in time is required")
+	ErrSearchAfterFrom            = errors.New("picker: from must be 0 when using search_after")
+	ErrRescoreWithSearchAfter     = errors.New("picker: rescore can not be combined with search_after as the _shard_doc tiebreaker is a sort")
+	ErrSortValuesMissing          = errors.New("picker: hit does not contain sort values")
+	ErrInvalidGroupSearches       = errors.New("picker: max_concurrent_group_searches must be >= 0")
+	ErrDuplicateInnerHitsName     = errors.New("picker: inner_hits names must be unique")
+	ErrInvalidCollapseField       = errors.New("picker: collapse field must be a keyword or numeric field")
+	ErrDocValuesRequired          = errors.New("picker: doc_values must be enabled")
+	ErrContextNameRequired        = errors.New("picker: context name is required")
+	ErrInvalidContextType         = errors.New("picker: invalid context type; expected \"category\" or \"geo\"")
+	ErrDuplicateContextName       = errors.New("picker: context names must be unique")
+	ErrSuggesterRequired          = errors.New("picker: one of term, phrase, or completion is required")
+	ErrMultipleSuggesters         = errors.New("picker: suggester can only be of one type")
+	ErrSuggestTextRequired        = errors.New("picker: one of text, prefix, or regex is required")
+	ErrInvalidSuggestText         = errors.New("picker: invalid suggest text")
+	ErrInvalidSuggestMode         = errors.New("picker: invalid suggest_mode; expected \"missing\", \"popular\", or \"always\"")
+	ErrInvalidSuggestSort         = errors.New("picker: invalid suggest sort; expected \"score\" or \"frequency\"")
+	ErrInvalidStringDistance      = errors.New("picker: invalid string_distance")
+	ErrInvalidMaxEdits            = errors.New("picker: invalid max_edits")
+	ErrSmoothingModelRequired     = errors.New("picker: one of stupid_backoff, laplace, or linear_interpolation is required")
+	ErrMultipleSmoothingModels    = errors.New("picker: smoothing can only be of one model")
+	ErrInvalidSmoothingLambdas    = errors.New("picker: linear_interpolation lambdas must add up to 1")
+	ErrCompletionFieldRequired    = errors.New("picker: completion suggester requires a completion field")
+	ErrContextNotFound            = errors.New("picker: context not found")
+	ErrContextRequired            = errors.New("picker: context is required")
+	ErrInvalidContextQuery        = errors.New("picker: invalid context query")
+	ErrRescorerRequired           = errors.New("picker: rescorer is required")
+	ErrRescorerNameRequired       = errors.New("picker: rescorer name is required")
+	ErrMultipleRescorers          = errors.New("picker: rescore can only have one rescorer")
+	ErrInvalidWindowSize          = errors.New("picker: window_size must be greater than or equal to 0")
+	ErrInvalidRescoreScoreMode    = errors.New("picker: invalid rescore score_mode; expected \"total\", \"multiply\", \"avg\", \"max\", or \"min\"")
+	ErrRescoreWithSort            = errors.New("picker: rescore can not be combined with a sort other than _score")
+	ErrInvalidTrackTotalHits      = errors.New("picker: invalid track_total_hits")
+	ErrInvalidSearchType          = errors.New("picker: invalid search_type; expected \"query_then_fetch\" or \"dfs_query_then_fetch\"")
+	ErrInvalidSort                = errors.New("picker: invalid sort")
+	ErrInvalidScriptSortType      = errors.New("picker: invalid script sort type; expected \"number\" or \"string\"")
+	ErrPointsRequired             = errors.New("picker: at least one point is required")
+	ErrInvalidDistanceType        = errors.New("picker: invalid distance_type; expected \"arc\" or \"plane\"")
+	ErrInvalidIndexSetting        = errors.New("picker: invalid index setting")
+	ErrTokenizerRequired          = errors.New("picker: tokenizer is required")
+	ErrNormalizerFilterRequired   = errors.New("picker: normalizer requires at least one char_filter or filter")
+	ErrInvalidGramSize            = errors.New("picker: invalid gram size")
+	ErrInvalidShingleSize         = errors.New("picker: invalid shingle size")
+	ErrSynonymsRequired           = errors.New("picker: one of synonyms, synonyms_path, or synonyms_set is required")
+	ErrAnalyzerNotFound           = errors.New("picker: analyzer not found")
+	ErrNormalizerNotFound         = errors.New("picker: normalizer not found")
+	ErrAnalysisNotSupported       = errors.New("picker: analysis is not supported")
+	ErrTargetFieldRequired        = errors.New("picker: target_field is required")
+	ErrTargetFieldsRequired       = errors.New("picker: target_fields is required")
+	ErrPatternRequired            = errors.New("picker: pattern is required")
+	ErrPatternsRequired           = errors.New("picker: patterns is required")
+	ErrFormatsRequired            = errors.New("picker: formats is required")
+	ErrDateRoundingRequired       = errors.New("picker: date_rounding is required")
+	ErrMessageRequired            = errors.New("picker: message is required")
+	ErrSeparatorRequired          = errors.New("picker: separator is required")
+	ErrFieldSplitRequired         = errors.New("picker: field_split is required")
+	ErrValueSplitRequired         = errors.New("picker: value_split is required")
+	ErrPolicyNameRequired         = errors.New("picker: policy_name is required")
+	ErrErrorDistanceRequired      = errors.New("picker: error_distance is required")
+	ErrShapeTypeRequired          = errors.New("picker: shape_type is required")
+	ErrPipelineNameRequired       = errors.New("picker: pipeline name is required")
+	ErrValueOrCopyFromRequired    = errors.New("picker: one of value or copy_from is required")
+	ErrInternalNetworksRequired   = errors.New("picker: one of internal_networks or internal_networks_field is required")
+	ErrProcessorRequired          = errors.New("picker: processor is required")
+	ErrProcessorKindRequired      = errors.New("picker: processor type is required")
+	ErrMultipleProcessorKinds     = errors.New("picker: processor can only be of one type")
+	ErrPipelineNotFound           = errors.New("picker: pipeline not found")
+	ErrPipelineCycle              = errors.New("picker: pipelines reference each other in a cycle")
+	ErrSimulationNotSupported     = errors.New("picker: simulation is not supported")
+	ErrProcessorFailed            = errors.New("picker: processor failed")
+	ErrTemplateNameRequired       = errors.New("picker: dynamic template name is required")
+	ErrTemplateMappingRequired    = errors.New("picker: one of mapping or runtime is required for dynamic templates")
+	ErrMultipleTemplateMappings   = errors.New("picker: dynamic template can only have one of mapping or runtime")
+	ErrInvalidMatchPattern        = errors.New("picker: invalid match_pattern")
+	ErrInvalidMappingType         = errors.New("picker: invalid match_mapping_type")
+	ErrInvalidRuntimeType         = errors.New("picker: invalid runtime field type")
+	ErrInvalidRuntimeParam        = errors.New("picker: invalid runtime field param")
+	ErrIncompatibleRuntimeField   = errors.New("picker: runtime field shadows a field of an incompatible type")
+	ErrMultipleAggregationKeys    = errors.New("picker: only one of aggs or aggregations may be specified")
+	ErrInvalidValidationMethod    = errors.New("picker: invalid validation_method; expected \"STRICT\", \"IGNORE_MALFORMED\", or \"COERCE\"")
 )
 
 type FieldError struct {
@@ -147,6 +277,100 @@ func (s QueryError) Unwrap() error {
 	return s.Err
 }
 
+// AggError is an error which occurred while building, encoding, or decoding
+// an aggregation.
+//
+// Path is the full path to the aggregation, with each level separated by '>',
+// the same as a buckets_path.
+type AggError struct {
+	Path string
+	Kind AggKind
+	Err  error
+}
+
+func newAggError(err error, kind AggKind, name ...string) *AggError {
+	var n string
+	if len(name) > 0 {
+		n = name[0]
+	}
+	var ae *AggError
+	if errors.As(err, &ae) {
+		c := *ae
+		if len(n) > 0 {
+			if len(c.Path) == 0 {
+				c.Path = n
+			} else {
+				c.Path = n + ">" + c.Path
+			}
+		}
+		if len(c.Kind) == 0 {
+			c.Kind = kind
+		}
+		return &c
+	}
+	return &AggError{
+		Err:  err,
+		Kind: kind,
+		Path: n,
+	}
+}
+
+func (e AggError) Error() string {
+	b := strings.Builder{}
+	b.WriteString(e.Err.Error())
+	if len(e.Kind) > 0 {
+		b.WriteString(" for ")
+		b.WriteString(e.Kind.String())
+		b.WriteString(" aggregation")
+	}
+	if e.Path != "" {
+		b.WriteString(" <")
+		b.WriteString(e.Path)
+		b.WriteRune('>')
+	}
+	return b.String()
+}
+
+func (e AggError) Unwrap() error {
+	return e.Err
+}
+
+// ProcessorError is an error which occurred while building, encoding, or
+// decoding an ingest processor.
+type ProcessorError struct {
+	Kind ProcessorKind
+	Err  error
+}
+
+func newProcessorError(err error, kind ProcessorKind) *ProcessorError {
+	var pe *ProcessorError
+	if errors.As(err, &pe) {
+		if len(pe.Kind) == 0 {
+			pe.Kind = kind
+		}
+		return pe
+	}
+	return &ProcessorError{
+		Err:  err,
+		Kind: kind,
+	}
+}
+
+func (e ProcessorError) Error() string {
+	b := strings.Builder{}
+	b.WriteString(e.Err.Error())
+	if len(e.Kind) > 0 {
+		b.WriteString(" for ")
+		b.WriteString(e.Kind.String())
+		b.WriteString(" processor")
+	}
+	return b.String()
+}
+
+func (e ProcessorError) Unwrap() error {
+	return e.Err
+}
+
 // Implementation of hashicorp's multierror.
 
 type MappingError struct {
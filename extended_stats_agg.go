@@ -0,0 +1,133 @@
+package picker
+
+const DefaultExtendedStatsSigma = 2
+
+// ExtendedStatsAggParams creates a ExtendedStatsAgg, a multi-value metrics
+// aggregation that computes stats over numeric values extracted from the
+// aggregated documents. It is an extended version of the stats aggregation,
+// where additional metrics are added such as sum_of_squares, variance,
+// std_deviation and std_deviation_bounds.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-extendedstats-aggregation.html
+type ExtendedStatsAggParams struct {
+	// The field to aggregate on. Either Field or Script is required.
+	Field string
+	// Script to generate values with. If Field is also set, Script is a value
+	// script.
+	Script *Script
+	// Missing is the value used for documents which do not have a value
+	Missing interface{}
+	// Hint as to the type of value produced by Script
+	ValueType string
+	// Format of the value_as_string in the response
+	Format string
+	// The number of standard deviations above/below the mean to display in
+	// std_deviation_bounds. Can be any non-negative double. Defaults to 2.
+	Sigma float64
+	Meta  map[string]interface{}
+}
+
+func (ExtendedStatsAggParams) Kind() AggKind {
+	return AggKindExtendedStats
+}
+
+func (p ExtendedStatsAggParams) Clause() (AggClause, error) {
+	return p.ExtendedStats()
+}
+
+func (p ExtendedStatsAggParams) ExtendedStats() (*ExtendedStatsAgg, error) {
+	a := &ExtendedStatsAgg{}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	err := a.checkValueSource()
+	if err != nil {
+		return a, newAggError(err, AggKindExtendedStats)
+	}
+	a.SetMissing(p.Missing)
+	a.SetValueType(p.ValueType)
+	a.SetFormat(p.Format)
+	a.SetSigma(p.Sigma)
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// ExtendedStatsAgg is an extended version of the stats aggregation, where
+// additional metrics are added such as sum_of_squares, variance, std_deviation
+// and std_deviation_bounds.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-extendedstats-aggregation.html
+type ExtendedStatsAgg struct {
+	valueSourceParam
+	sigma float64
+	noAggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*ExtendedStatsAgg)(nil)
+
+func (ExtendedStatsAgg) Kind() AggKind {
+	return AggKindExtendedStats
+}
+
+func (a *ExtendedStatsAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// Sigma is the number of standard deviations above/below the mean to display in
+// std_deviation_bounds. Defaults to 2.
+func (a ExtendedStatsAgg) Sigma() float64 {
+	if a.sigma == 0 {
+		return DefaultExtendedStatsSigma
+	}
+	return a.sigma
+}
+
+// SetSigma sets sigma to v
+func (a *ExtendedStatsAgg) SetSigma(v float64) {
+	a.sigma = v
+}
+
+func (a ExtendedStatsAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a ExtendedStatsAgg) MarshalJSON() ([]byte, error) {
+	return extendedStatsAgg{
+		Field:     a.field,
+		Script:    a.script,
+		Missing:   a.missing,
+		ValueType: a.valueType,
+		Format:    a.format,
+		Sigma:     a.sigma,
+	}.MarshalJSON()
+}
+
+func (a *ExtendedStatsAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *ExtendedStatsAgg) UnmarshalJSON(data []byte) error {
+	*a = ExtendedStatsAgg{}
+	p := extendedStatsAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	a.SetMissing(p.Missing)
+	a.SetValueType(p.ValueType)
+	a.SetFormat(p.Format)
+	a.sigma = p.Sigma
+	return nil
+}
+
+//easyjson:json
+type extendedStatsAgg struct {
+	Field     string      `json:"field,omitempty"`
+	Script    *Script     `json:"script,omitempty"`
+	Missing   interface{} `json:"missing,omitempty"`
+	ValueType string      `json:"value_type,omitempty"`
+	Format    string      `json:"format,omitempty"`
+	Sigma     float64     `json:"sigma,omitempty"`
+}
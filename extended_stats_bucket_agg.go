@@ -0,0 +1,123 @@
+package picker
+
+// ExtendedStatsBucketAggParams creates a ExtendedStatsBucketAgg, a sibling
+// pipeline aggregation which calculates a variety of stats across all bucket of
+// a specified metric in a sibling aggregation. The specified metric must be
+// numeric and the sibling aggregation must be a multi-bucket aggregation. It
+// provides a few more statistics (sum of squares, standard deviation, etc) than
+// the stats_bucket aggregation.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-extended-stats-bucket-aggregation.html
+type ExtendedStatsBucketAggParams struct {
+	// Path to the buckets to aggregate (Required)
+	BucketsPath string
+	// Policy to apply when gaps are found in the data. Defaults to "skip".
+	GapPolicy GapPolicy
+	// Format of the value_as_string in the response
+	Format string
+	// The number of standard deviations above/below the mean to display.
+	// Defaults to 2.
+	Sigma float64
+	Meta  map[string]interface{}
+}
+
+func (ExtendedStatsBucketAggParams) Kind() AggKind {
+	return AggKindExtendedStatsBucket
+}
+
+func (p ExtendedStatsBucketAggParams) Clause() (AggClause, error) {
+	return p.ExtendedStatsBucket()
+}
+
+func (p ExtendedStatsBucketAggParams) ExtendedStatsBucket() (*ExtendedStatsBucketAgg, error) {
+	a := &ExtendedStatsBucketAgg{}
+	err := a.SetBucketsPath(p.BucketsPath)
+	if err != nil {
+		return a, newAggError(err, AggKindExtendedStatsBucket)
+	}
+	err = a.SetGapPolicy(p.GapPolicy)
+	if err != nil {
+		return a, newAggError(err, AggKindExtendedStatsBucket)
+	}
+	a.SetFormat(p.Format)
+	a.SetSigma(p.Sigma)
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// ExtendedStatsBucketAgg is a sibling pipeline aggregation which calculates an
+// extended set of stats across all bucket of a specified metric in a sibling
+// aggregation.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-extended-stats-bucket-aggregation.html
+type ExtendedStatsBucketAgg struct {
+	bucketsPathParam
+	gapPolicyParam
+	pipelineFormatParam
+	sigma float64
+	noAggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*ExtendedStatsBucketAgg)(nil)
+
+func (ExtendedStatsBucketAgg) Kind() AggKind {
+	return AggKindExtendedStatsBucket
+}
+
+func (a *ExtendedStatsBucketAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// Sigma is the number of standard deviations above/below the mean to display.
+// Defaults to 2.
+func (a ExtendedStatsBucketAgg) Sigma() float64 {
+	if a.sigma == 0 {
+		return DefaultExtendedStatsSigma
+	}
+	return a.sigma
+}
+
+// SetSigma sets sigma to v
+func (a *ExtendedStatsBucketAgg) SetSigma(v float64) {
+	a.sigma = v
+}
+
+func (a ExtendedStatsBucketAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a ExtendedStatsBucketAgg) MarshalJSON() ([]byte, error) {
+	return extendedStatsBucketAgg{
+		BucketsPath: a.bucketsPath,
+		GapPolicy:   a.gapPolicy,
+		Format:      a.format,
+		Sigma:       a.sigma,
+	}.MarshalJSON()
+}
+
+func (a *ExtendedStatsBucketAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *ExtendedStatsBucketAgg) UnmarshalJSON(data []byte) error {
+	*a = ExtendedStatsBucketAgg{}
+	p := extendedStatsBucketAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.bucketsPath = p.BucketsPath
+	a.gapPolicy = p.GapPolicy
+	a.SetFormat(p.Format)
+	a.sigma = p.Sigma
+	return nil
+}
+
+//easyjson:json
+type extendedStatsBucketAgg struct {
+	BucketsPath string    `json:"buckets_path"`
+	GapPolicy   GapPolicy `json:"gap_policy,omitempty"`
+	Format      string    `json:"format,omitempty"`
+	Sigma       float64   `json:"sigma,omitempty"`
+}
@@ -0,0 +1,127 @@
+package picker
+
+// FailProcessorParams creates a FailProcessor, which raises an exception. This
+// is useful for when you expect a pipeline to fail and want to relay a specific
+// message to the requester.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/fail-processor.html
+type FailProcessorParams struct {
+	// Message is the error message thrown by the processor. Supports template
+	// snippets.
+	Message string
+	// Description of the processor. Useful for describing the purpose of the
+	// processor or its configuration.
+	Description string
+	// Conditionally execute the processor
+	If string
+	// Ignore failures for the processor
+	IgnoreFailure bool
+	// Handle failures for the processor
+	OnFailure Procs
+	// Identifier for the processor. Useful for debugging and metrics.
+	Tag string
+}
+
+func (FailProcessorParams) Kind() ProcessorKind {
+	return ProcessorKindFail
+}
+
+func (p FailProcessorParams) Processor() (Processor, error) {
+	return p.Fail()
+}
+
+func (p FailProcessorParams) Fail() (*FailProcessor, error) {
+	proc := &FailProcessor{}
+	err := proc.SetMessage(p.Message)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindFail)
+	}
+	proc.SetDescription(p.Description)
+	proc.SetIf(p.If)
+	proc.SetIgnoreFailure(p.IgnoreFailure)
+	err = proc.SetOnFailure(p.OnFailure)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindFail)
+	}
+	proc.SetTag(p.Tag)
+	return proc, nil
+}
+
+// FailProcessor raises an exception. This is useful for when you expect a
+// pipeline to fail and want to relay a specific message to the requester.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/fail-processor.html
+type FailProcessor struct {
+	message string
+	processorParams
+}
+
+var _ Processor = (*FailProcessor)(nil)
+
+func (FailProcessor) Kind() ProcessorKind {
+	return ProcessorKindFail
+}
+
+func (p *FailProcessor) Processor() (Processor, error) {
+	return p, nil
+}
+
+// Message is the error message thrown by the processor. Supports template
+// snippets.
+func (p FailProcessor) Message() string {
+	return p.message
+}
+
+// SetMessage sets message to v
+func (p *FailProcessor) SetMessage(v string) error {
+	if len(v) == 0 {
+		return ErrMessageRequired
+	}
+	p.message = v
+	return nil
+}
+
+func (p FailProcessor) MarshalBSON() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+func (p FailProcessor) MarshalJSON() ([]byte, error) {
+	return failProcessor{
+		Message:       p.message,
+		Description:   p.description,
+		If:            p.ifCond,
+		IgnoreFailure: p.ignoreFailure,
+		OnFailure:     p.onFailure,
+		Tag:           p.tag,
+	}.MarshalJSON()
+}
+
+func (p *FailProcessor) UnmarshalBSON(data []byte) error {
+	return p.UnmarshalJSON(data)
+}
+
+func (p *FailProcessor) UnmarshalJSON(data []byte) error {
+	*p = FailProcessor{}
+	var v failProcessor
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	p.message = v.Message
+	p.description = v.Description
+	p.ifCond = v.If
+	p.ignoreFailure = v.IgnoreFailure
+	p.onFailure = v.OnFailure
+	p.tag = v.Tag
+	return nil
+}
+
+//easyjson:json
+type failProcessor struct {
+	Message       string     `json:"message,omitempty"`
+	Description   string     `json:"description,omitempty"`
+	If            string     `json:"if,omitempty"`
+	IgnoreFailure bool       `json:"ignore_failure,omitempty"`
+	OnFailure     Processors `json:"on_failure,omitempty"`
+	Tag           string     `json:"tag,omitempty"`
+}
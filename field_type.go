@@ -215,7 +215,13 @@ const (
 	// FieldTypeAnnotatedText is text containing special markup. Used for identifying named entities.
 	//
 	// https://www.elastic.co/guide/en/elasticsearch/plugins/7.11/mapper-annotated-text.html
-	FieldTypeAnnotatedText FieldType = "annotated-text"
+	FieldTypeAnnotatedText FieldType = "annotated_text"
+
+	// FieldTypeMatchOnlyText is a space-optimized variant of text that disables
+	// scoring and performs slower on queries that need positions.
+	//
+	// https://www.elastic.co/guide/en/elasticsearch/reference/current/text.html#match-only-text-field-type
+	FieldTypeMatchOnlyText FieldType = "match_only_text"
 
 	// FieldTypeCompletion is used for auto-complete suggestions.
 	//
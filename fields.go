@@ -69,6 +69,66 @@ func (f Fields) Has(key string) bool {
 	return exists
 }
 
+// Lookup returns the Field at path, which is the dot-delimited name of a
+// field. Lookup traverses the properties of object and nested fields as well
+// as multi-fields.
+func (f Fields) Lookup(path string) (Field, bool) {
+	if v, ok := f[path]; ok && v != nil {
+		return v, true
+	}
+	for i := 0; i < len(path); i++ {
+		if path[i] != '.' {
+			continue
+		}
+		v, ok := f[path[:i]]
+		if !ok || v == nil {
+			continue
+		}
+		for _, sub := range subfields(v) {
+			if res, ok := sub.Lookup(path[i+1:]); ok {
+				return res, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// walk calls fn with the full path of each field, including the properties
+// of object and nested fields as well as multi-fields, until fn returns false
+func (f Fields) walk(fn func(path string, field Field) bool) bool {
+	return f.walkPrefix("", fn)
+}
+
+func (f Fields) walkPrefix(prefix string, fn func(path string, field Field) bool) bool {
+	for k, v := range f {
+		if v == nil {
+			continue
+		}
+		p := prefix + k
+		if !fn(p, v) {
+			return false
+		}
+		for _, sub := range subfields(v) {
+			if !sub.walkPrefix(p+".", fn) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// subfields returns the properties and multi-fields of f
+func subfields(f Field) []Fields {
+	var res []Fields
+	if p, ok := f.(interface{ Properties() Fields }); ok && len(p.Properties()) > 0 {
+		res = append(res, p.Properties())
+	}
+	if mf, ok := f.(interface{ Fields() Fields }); ok && len(mf.Fields()) > 0 {
+		res = append(res, mf.Fields())
+	}
+	return res
+}
+
 func (f Fields) Set(key string, field Fielder) (Field, error) {
 	fld, err := field.Field()
 	if err != nil {
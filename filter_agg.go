@@ -0,0 +1,109 @@
+package picker
+
+import (
+	"github.com/chanced/dynamic"
+)
+
+// FilterAggParams creates a FilterAgg, a single bucket aggregation that
+// narrows the set of documents to those that match a query.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-filter-aggregation.html
+type FilterAggParams struct {
+	// The query documents must match to fall into the bucket (Required)
+	Query Querier
+	// Sub-aggregations
+	Aggregations Aggs
+	Meta         map[string]interface{}
+}
+
+func (FilterAggParams) Kind() AggKind {
+	return AggKindFilter
+}
+
+func (p FilterAggParams) Clause() (AggClause, error) {
+	return p.Filter()
+}
+
+func (p FilterAggParams) Filter() (*FilterAgg, error) {
+	a := &FilterAgg{}
+	err := a.SetQuery(p.Query)
+	if err != nil {
+		return a, newAggError(err, AggKindFilter)
+	}
+	err = a.SetAggregations(p.Aggregations)
+	if err != nil {
+		return a, newAggError(err, AggKindFilter)
+	}
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// FilterAgg is a single bucket aggregation that narrows the set of documents to
+// those that match a query.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-filter-aggregation.html
+type FilterAgg struct {
+	query *Query
+	aggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*FilterAgg)(nil)
+
+func (FilterAgg) Kind() AggKind {
+	return AggKindFilter
+}
+
+func (a *FilterAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// Query is the query documents must match to fall into the bucket
+func (a *FilterAgg) Query() *Query {
+	if a.query == nil {
+		a.query = &Query{}
+	}
+	return a.query
+}
+
+// SetQuery sets the query to v
+func (a *FilterAgg) SetQuery(v Querier) error {
+	if v == nil {
+		return ErrQueryRequired
+	}
+	q, err := v.Query()
+	if err != nil {
+		return err
+	}
+	if q.IsEmpty() {
+		return ErrQueryRequired
+	}
+	a.query = q
+	return nil
+}
+
+func (a FilterAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a FilterAgg) MarshalJSON() ([]byte, error) {
+	if a.query == nil {
+		return dynamic.JSON("{}"), nil
+	}
+	return a.query.MarshalJSON()
+}
+
+func (a *FilterAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *FilterAgg) UnmarshalJSON(data []byte) error {
+	*a = FilterAgg{}
+	q := &Query{}
+	err := q.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.query = q
+	return nil
+}
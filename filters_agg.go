@@ -0,0 +1,226 @@
+package picker
+
+import (
+	"encoding/json"
+
+	"github.com/chanced/dynamic"
+)
+
+// FiltersAggParams creates a FiltersAgg, a multi-bucket aggregation where each
+// bucket contains the documents that match a query.
+//
+// Either Filters or AnonymousFilters is required.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-filters-aggregation.html
+type FiltersAggParams struct {
+	// Named filters. Each filter creates a bucket keyed by its name.
+	Filters map[string]Querier
+	// Anonymous filters. Buckets are returned in the same order as the filters
+	// are provided.
+	AnonymousFilters []Querier
+	// If true, adds a bucket to the response which contains all documents that
+	// do not match any of the given filters.
+	OtherBucket bool
+	// The key of the other bucket. Defaults to "_other_". Setting
+	// OtherBucketKey implies OtherBucket.
+	OtherBucketKey string
+	// Sub-aggregations
+	Aggregations Aggs
+	Meta         map[string]interface{}
+}
+
+func (FiltersAggParams) Kind() AggKind {
+	return AggKindFilters
+}
+
+func (p FiltersAggParams) Clause() (AggClause, error) {
+	return p.FiltersAgg()
+}
+
+func (p FiltersAggParams) FiltersAgg() (*FiltersAgg, error) {
+	a := &FiltersAgg{}
+	if len(p.Filters) > 0 && len(p.AnonymousFilters) > 0 {
+		return a, newAggError(ErrMixedFilters, AggKindFilters)
+	}
+	if len(p.AnonymousFilters) > 0 {
+		err := a.SetAnonymousFilters(p.AnonymousFilters)
+		if err != nil {
+			return a, newAggError(err, AggKindFilters)
+		}
+	} else {
+		err := a.SetFilters(p.Filters)
+		if err != nil {
+			return a, newAggError(err, AggKindFilters)
+		}
+	}
+	a.SetOtherBucket(p.OtherBucket)
+	a.SetOtherBucketKey(p.OtherBucketKey)
+	err := a.SetAggregations(p.Aggregations)
+	if err != nil {
+		return a, newAggError(err, AggKindFilters)
+	}
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// FiltersAgg is a multi-bucket aggregation where each bucket contains the
+// documents that match a query.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-filters-aggregation.html
+type FiltersAgg struct {
+	filters          map[string]*Query
+	anonymousFilters Queries
+	otherBucket      bool
+	otherBucketKey   string
+	aggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*FiltersAgg)(nil)
+
+func (FiltersAgg) Kind() AggKind {
+	return AggKindFilters
+}
+
+func (a *FiltersAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// Filters are the named filters of the aggregation
+func (a FiltersAgg) Filters() map[string]*Query {
+	return a.filters
+}
+
+// SetFilters sets the named filters to v, clearing any anonymous filters.
+func (a *FiltersAgg) SetFilters(v map[string]Querier) error {
+	if len(v) == 0 {
+		return ErrFiltersRequired
+	}
+	filters := make(map[string]*Query, len(v))
+	for k, f := range v {
+		if f == nil {
+			return newFieldError(ErrQueryRequired, k)
+		}
+		q, err := f.Query()
+		if err != nil {
+			return newFieldError(err, k)
+		}
+		filters[k] = q
+	}
+	a.filters = filters
+	a.anonymousFilters = nil
+	return nil
+}
+
+// AnonymousFilters are the unnamed filters of the aggregation
+func (a FiltersAgg) AnonymousFilters() Queries {
+	return a.anonymousFilters
+}
+
+// SetAnonymousFilters sets the anonymous filters to v, clearing any named
+// filters.
+func (a *FiltersAgg) SetAnonymousFilters(v []Querier) error {
+	if len(v) == 0 {
+		return ErrFiltersRequired
+	}
+	filters := make(Queries, len(v))
+	for i, f := range v {
+		if f == nil {
+			return ErrQueryRequired
+		}
+		q, err := f.Query()
+		if err != nil {
+			return err
+		}
+		filters[i] = q
+	}
+	a.anonymousFilters = filters
+	a.filters = nil
+	return nil
+}
+
+// IsAnonymous returns true if the filters of a are anonymous
+func (a FiltersAgg) IsAnonymous() bool {
+	return len(a.anonymousFilters) > 0
+}
+
+// OtherBucket indicates whether a bucket containing all documents that do not
+// match any of the filters is added to the response.
+func (a FiltersAgg) OtherBucket() bool {
+	return a.otherBucket || len(a.otherBucketKey) > 0
+}
+
+// SetOtherBucket sets other_bucket to v
+func (a *FiltersAgg) SetOtherBucket(v bool) {
+	a.otherBucket = v
+}
+
+// OtherBucketKey is the key of the other bucket. Defaults to "_other_".
+func (a FiltersAgg) OtherBucketKey() string {
+	return a.otherBucketKey
+}
+
+// SetOtherBucketKey sets other_bucket_key to v
+func (a *FiltersAgg) SetOtherBucketKey(v string) {
+	a.otherBucketKey = v
+}
+
+func (a FiltersAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a FiltersAgg) MarshalJSON() ([]byte, error) {
+	var filters dynamic.JSON
+	var err error
+	if a.IsAnonymous() {
+		filters, err = json.Marshal(a.anonymousFilters)
+	} else {
+		filters, err = json.Marshal(a.filters)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return filtersAgg{
+		Filters:        filters,
+		OtherBucket:    a.otherBucket,
+		OtherBucketKey: a.otherBucketKey,
+	}.MarshalJSON()
+}
+
+func (a *FiltersAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *FiltersAgg) UnmarshalJSON(data []byte) error {
+	*a = FiltersAgg{}
+	p := filtersAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	if p.Filters.IsArray() {
+		var filters Queries
+		err = json.Unmarshal(p.Filters, &filters)
+		if err != nil {
+			return err
+		}
+		a.anonymousFilters = filters
+	} else if p.Filters.IsObject() {
+		var filters map[string]*Query
+		err = json.Unmarshal(p.Filters, &filters)
+		if err != nil {
+			return err
+		}
+		a.filters = filters
+	}
+	a.SetOtherBucket(p.OtherBucket)
+	a.SetOtherBucketKey(p.OtherBucketKey)
+	return nil
+}
+
+//easyjson:json
+type filtersAgg struct {
+	Filters        dynamic.JSON `json:"filters"`
+	OtherBucket    bool         `json:"other_bucket,omitempty"`
+	OtherBucketKey string       `json:"other_bucket_key,omitempty"`
+}
@@ -0,0 +1,179 @@
+package picker
+
+// FingerprintProcessorParams creates a FingerprintProcessor, which computes a
+// hash of the document's content. You can use this hash for content
+// fingerprinting.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/fingerprint-processor.html
+type FingerprintProcessorParams struct {
+	// Fields to include in the fingerprint
+	Fields []string
+	// TargetField is the output field for the fingerprint. Defaults to
+	// "fingerprint".
+	TargetField string
+	// Salt value for the hash function
+	Salt string
+	// Method is the hash method used to compute the fingerprint. Must be one of
+	// "MD5", "SHA-1", "SHA-256", "SHA-512", or "MurmurHash3". Defaults to
+	// "SHA-1".
+	Method string
+	// If IgnoreMissing is true, the processor ignores any missing fields. If
+	// all fields are missing, the processor silently exits without modifying
+	// the document.
+	IgnoreMissing bool
+	// Description of the processor. Useful for describing the purpose of the
+	// processor or its configuration.
+	Description string
+	// Conditionally execute the processor
+	If string
+	// Ignore failures for the processor
+	IgnoreFailure bool
+	// Handle failures for the processor
+	OnFailure Procs
+	// Identifier for the processor. Useful for debugging and metrics.
+	Tag string
+}
+
+func (FingerprintProcessorParams) Kind() ProcessorKind {
+	return ProcessorKindFingerprint
+}
+
+func (p FingerprintProcessorParams) Processor() (Processor, error) {
+	return p.Fingerprint()
+}
+
+func (p FingerprintProcessorParams) Fingerprint() (*FingerprintProcessor, error) {
+	proc := &FingerprintProcessor{}
+	err := proc.SetFields(p.Fields)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindFingerprint)
+	}
+	proc.SetTargetField(p.TargetField)
+	proc.SetSalt(p.Salt)
+	proc.SetMethod(p.Method)
+	proc.SetIgnoreMissing(p.IgnoreMissing)
+	proc.SetDescription(p.Description)
+	proc.SetIf(p.If)
+	proc.SetIgnoreFailure(p.IgnoreFailure)
+	err = proc.SetOnFailure(p.OnFailure)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindFingerprint)
+	}
+	proc.SetTag(p.Tag)
+	return proc, nil
+}
+
+// FingerprintProcessor computes a hash of the document's content. You can use
+// this hash for content fingerprinting.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/fingerprint-processor.html
+type FingerprintProcessor struct {
+	fields []string
+	targetFieldParam
+	salt   string
+	method string
+	ignoreMissingParam
+	processorParams
+}
+
+var _ Processor = (*FingerprintProcessor)(nil)
+
+func (FingerprintProcessor) Kind() ProcessorKind {
+	return ProcessorKindFingerprint
+}
+
+func (p *FingerprintProcessor) Processor() (Processor, error) {
+	return p, nil
+}
+
+// Fields to include in the fingerprint
+func (p FingerprintProcessor) Fields() []string {
+	return p.fields
+}
+
+// SetFields sets fields to v
+func (p *FingerprintProcessor) SetFields(v []string) error {
+	if len(v) == 0 {
+		return ErrFieldsRequired
+	}
+	p.fields = v
+	return nil
+}
+
+// Salt value for the hash function
+func (p FingerprintProcessor) Salt() string {
+	return p.salt
+}
+
+// SetSalt sets salt to v
+func (p *FingerprintProcessor) SetSalt(v string) {
+	p.salt = v
+}
+
+// Method is the hash method used to compute the fingerprint. Must be one of
+// "MD5", "SHA-1", "SHA-256", "SHA-512", or "MurmurHash3". Defaults to "SHA-1".
+func (p FingerprintProcessor) Method() string {
+	return p.method
+}
+
+// SetMethod sets method to v
+func (p *FingerprintProcessor) SetMethod(v string) {
+	p.method = v
+}
+
+func (p FingerprintProcessor) MarshalBSON() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+func (p FingerprintProcessor) MarshalJSON() ([]byte, error) {
+	return fingerprintProcessor{
+		Fields:        p.fields,
+		TargetField:   p.targetField,
+		Salt:          p.salt,
+		Method:        p.method,
+		IgnoreMissing: p.ignoreMissing,
+		Description:   p.description,
+		If:            p.ifCond,
+		IgnoreFailure: p.ignoreFailure,
+		OnFailure:     p.onFailure,
+		Tag:           p.tag,
+	}.MarshalJSON()
+}
+
+func (p *FingerprintProcessor) UnmarshalBSON(data []byte) error {
+	return p.UnmarshalJSON(data)
+}
+
+func (p *FingerprintProcessor) UnmarshalJSON(data []byte) error {
+	*p = FingerprintProcessor{}
+	var v fingerprintProcessor
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	p.fields = v.Fields
+	p.targetField = v.TargetField
+	p.salt = v.Salt
+	p.method = v.Method
+	p.ignoreMissing = v.IgnoreMissing
+	p.description = v.Description
+	p.ifCond = v.If
+	p.ignoreFailure = v.IgnoreFailure
+	p.onFailure = v.OnFailure
+	p.tag = v.Tag
+	return nil
+}
+
+//easyjson:json
+type fingerprintProcessor struct {
+	Fields        []string   `json:"fields,omitempty"`
+	TargetField   string     `json:"target_field,omitempty"`
+	Salt          string     `json:"salt,omitempty"`
+	Method        string     `json:"method,omitempty"`
+	IgnoreMissing bool       `json:"ignore_missing,omitempty"`
+	Description   string     `json:"description,omitempty"`
+	If            string     `json:"if,omitempty"`
+	IgnoreFailure bool       `json:"ignore_failure,omitempty"`
+	OnFailure     Processors `json:"on_failure,omitempty"`
+	Tag           string     `json:"tag,omitempty"`
+}
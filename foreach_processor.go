@@ -0,0 +1,161 @@
+package picker
+
+import "github.com/chanced/dynamic"
+
+// ForeachProcessorParams creates a ForeachProcessor, which runs an ingest
+// processor on each element of an array or object.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/foreach-processor.html
+type ForeachProcessorParams struct {
+	// Field is the array or object field to process
+	Field string
+	// If IgnoreMissing is true and field does not exist or is null, the
+	// processor quietly exits without modifying the document
+	IgnoreMissing bool
+	// ItemProcessor is the ingest processor to run on each element
+	ItemProcessor Processorer
+	// Description of the processor. Useful for describing the purpose of the
+	// processor or its configuration.
+	Description string
+	// Conditionally execute the processor
+	If string
+	// Ignore failures for the processor
+	IgnoreFailure bool
+	// Handle failures for the processor
+	OnFailure Procs
+	// Identifier for the processor. Useful for debugging and metrics.
+	Tag string
+}
+
+func (ForeachProcessorParams) Kind() ProcessorKind {
+	return ProcessorKindForeach
+}
+
+func (p ForeachProcessorParams) Processor() (Processor, error) {
+	return p.Foreach()
+}
+
+func (p ForeachProcessorParams) Foreach() (*ForeachProcessor, error) {
+	proc := &ForeachProcessor{}
+	err := proc.SetField(p.Field)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindForeach)
+	}
+	proc.SetIgnoreMissing(p.IgnoreMissing)
+	err = proc.SetItemProcessor(p.ItemProcessor)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindForeach)
+	}
+	proc.SetDescription(p.Description)
+	proc.SetIf(p.If)
+	proc.SetIgnoreFailure(p.IgnoreFailure)
+	err = proc.SetOnFailure(p.OnFailure)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindForeach)
+	}
+	proc.SetTag(p.Tag)
+	return proc, nil
+}
+
+// ForeachProcessor runs an ingest processor on each element of an array or
+// object.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/foreach-processor.html
+type ForeachProcessor struct {
+	fieldParam
+	ignoreMissingParam
+	itemProcessor Processor
+	processorParams
+}
+
+var _ Processor = (*ForeachProcessor)(nil)
+
+func (ForeachProcessor) Kind() ProcessorKind {
+	return ProcessorKindForeach
+}
+
+func (p *ForeachProcessor) Processor() (Processor, error) {
+	return p, nil
+}
+
+// ItemProcessor is the ingest processor to run on each element
+func (p ForeachProcessor) ItemProcessor() Processor {
+	return p.itemProcessor
+}
+
+// SetItemProcessor sets processor to v
+func (p *ForeachProcessor) SetItemProcessor(v Processorer) error {
+	if v == nil {
+		return ErrProcessorRequired
+	}
+	proc, err := v.Processor()
+	if err != nil {
+		return newProcessorError(err, v.Kind())
+	}
+	p.itemProcessor = proc
+	return nil
+}
+
+func (p ForeachProcessor) MarshalBSON() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+func (p ForeachProcessor) MarshalJSON() ([]byte, error) {
+	var proc dynamic.JSON
+	if p.itemProcessor != nil {
+		var err error
+		proc, err = marshalProcessor(p.itemProcessor)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return foreachProcessor{
+		Field:         p.field,
+		IgnoreMissing: p.ignoreMissing,
+		ItemProcessor: proc,
+		Description:   p.description,
+		If:            p.ifCond,
+		IgnoreFailure: p.ignoreFailure,
+		OnFailure:     p.onFailure,
+		Tag:           p.tag,
+	}.MarshalJSON()
+}
+
+func (p *ForeachProcessor) UnmarshalBSON(data []byte) error {
+	return p.UnmarshalJSON(data)
+}
+
+func (p *ForeachProcessor) UnmarshalJSON(data []byte) error {
+	*p = ForeachProcessor{}
+	var v foreachProcessor
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	p.field = v.Field
+	p.ignoreMissing = v.IgnoreMissing
+	if len(v.ItemProcessor) > 0 && !v.ItemProcessor.IsNull() {
+		p.itemProcessor, err = unmarshalProcessor(v.ItemProcessor)
+		if err != nil {
+			return err
+		}
+	}
+	p.description = v.Description
+	p.ifCond = v.If
+	p.ignoreFailure = v.IgnoreFailure
+	p.onFailure = v.OnFailure
+	p.tag = v.Tag
+	return nil
+}
+
+//easyjson:json
+type foreachProcessor struct {
+	Field         string       `json:"field,omitempty"`
+	IgnoreMissing bool         `json:"ignore_missing,omitempty"`
+	ItemProcessor dynamic.JSON `json:"processor,omitempty"`
+	Description   string       `json:"description,omitempty"`
+	If            string       `json:"if,omitempty"`
+	IgnoreFailure bool         `json:"ignore_failure,omitempty"`
+	OnFailure     Processors   `json:"on_failure,omitempty"`
+	Tag           string       `json:"tag,omitempty"`
+}
@@ -0,0 +1,192 @@
+package picker
+
+// GeoDistanceAggParams creates a GeoDistanceAgg, a multi-bucket aggregation
+// that works on geo_point fields and conceptually works very similar to the
+// range aggregation. The user can define a point of origin and a set of
+// distance range buckets.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-geodistance-aggregation.html
+type GeoDistanceAggParams struct {
+	// The geo_point field to aggregate on (Required)
+	Field string
+	// The point of origin distances are calculated from. Accepts the same
+	// formats as a geo_point (Required)
+	Origin interface{}
+	// The distance unit of the ranges, such as "km" or "mi". Defaults to "m".
+	Unit string
+	// Either "arc" (default) or "plane"
+	DistanceType DistanceType
+	// The ranges of the buckets (Required)
+	Ranges []AggRange
+	// If true, buckets are returned as a hash rather than an array, keyed by
+	// the bucket key.
+	Keyed bool
+	// Sub-aggregations
+	Aggregations Aggs
+	Meta         map[string]interface{}
+}
+
+func (GeoDistanceAggParams) Kind() AggKind {
+	return AggKindGeoDistance
+}
+
+func (p GeoDistanceAggParams) Clause() (AggClause, error) {
+	return p.GeoDistance()
+}
+
+func (p GeoDistanceAggParams) GeoDistance() (*GeoDistanceAgg, error) {
+	a := &GeoDistanceAgg{}
+	err := a.SetField(p.Field)
+	if err != nil {
+		return a, newAggError(err, AggKindGeoDistance)
+	}
+	err = a.SetOrigin(p.Origin)
+	if err != nil {
+		return a, newAggError(err, AggKindGeoDistance)
+	}
+	a.SetUnit(p.Unit)
+	a.SetDistanceType(p.DistanceType)
+	err = a.SetRanges(p.Ranges)
+	if err != nil {
+		return a, newAggError(err, AggKindGeoDistance)
+	}
+	a.SetKeyed(p.Keyed)
+	err = a.SetAggregations(p.Aggregations)
+	if err != nil {
+		return a, newAggError(err, AggKindGeoDistance)
+	}
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// GeoDistanceAgg is a multi-bucket aggregation that works on geo_point fields
+// and conceptually works very similar to the range aggregation.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-geodistance-aggregation.html
+type GeoDistanceAgg struct {
+	fieldParam
+	origin       interface{}
+	unit         string
+	distanceType DistanceType
+	ranges       []AggRange
+	keyed        bool
+	aggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*GeoDistanceAgg)(nil)
+
+func (GeoDistanceAgg) Kind() AggKind {
+	return AggKindGeoDistance
+}
+
+func (a *GeoDistanceAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// Origin is the point distances are calculated from
+func (a GeoDistanceAgg) Origin() interface{} {
+	return a.origin
+}
+
+// SetOrigin sets the origin to v
+func (a *GeoDistanceAgg) SetOrigin(v interface{}) error {
+	if v == nil {
+		return ErrOriginRequired
+	}
+	a.origin = v
+	return nil
+}
+
+// Unit is the distance unit of the ranges. Defaults to "m".
+func (a GeoDistanceAgg) Unit() string {
+	return a.unit
+}
+
+// SetUnit sets the unit to v
+func (a *GeoDistanceAgg) SetUnit(v string) {
+	a.unit = v
+}
+
+// DistanceType is either "arc" (default) or "plane"
+func (a GeoDistanceAgg) DistanceType() DistanceType {
+	if len(a.distanceType) == 0 {
+		return DefaultDistanceType
+	}
+	return a.distanceType
+}
+
+// SetDistanceType sets distance_type to v
+func (a *GeoDistanceAgg) SetDistanceType(v DistanceType) {
+	a.distanceType = v
+}
+
+// Ranges of the buckets
+func (a GeoDistanceAgg) Ranges() []AggRange {
+	return a.ranges
+}
+
+// SetRanges sets the ranges to v. At least one range is required.
+func (a *GeoDistanceAgg) SetRanges(v []AggRange) error {
+	err := checkAggRanges(v)
+	if err != nil {
+		return err
+	}
+	a.ranges = v
+	return nil
+}
+
+// Keyed indicates whether buckets are returned as a hash rather than an array
+func (a GeoDistanceAgg) Keyed() bool {
+	return a.keyed
+}
+
+// SetKeyed sets keyed to v
+func (a *GeoDistanceAgg) SetKeyed(v bool) {
+	a.keyed = v
+}
+
+func (a GeoDistanceAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a GeoDistanceAgg) MarshalJSON() ([]byte, error) {
+	return geoDistanceAgg{
+		Field:        a.field,
+		Origin:       a.origin,
+		Unit:         a.unit,
+		DistanceType: a.distanceType,
+		Ranges:       a.ranges,
+		Keyed:        a.keyed,
+	}.MarshalJSON()
+}
+
+func (a *GeoDistanceAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *GeoDistanceAgg) UnmarshalJSON(data []byte) error {
+	*a = GeoDistanceAgg{}
+	p := geoDistanceAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.field = p.Field
+	a.origin = p.Origin
+	a.SetUnit(p.Unit)
+	a.SetDistanceType(p.DistanceType)
+	a.ranges = p.Ranges
+	a.SetKeyed(p.Keyed)
+	return nil
+}
+
+//easyjson:json
+type geoDistanceAgg struct {
+	Field        string       `json:"field"`
+	Origin       interface{}  `json:"origin"`
+	Unit         string       `json:"unit,omitempty"`
+	DistanceType DistanceType `json:"distance_type,omitempty"`
+	Ranges       []AggRange   `json:"ranges"`
+	Keyed        bool         `json:"keyed,omitempty"`
+}
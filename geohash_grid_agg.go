@@ -0,0 +1,220 @@
+package picker
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/chanced/dynamic"
+)
+
+const (
+	DefaultGeohashGridPrecision = 5
+	DefaultGeohashGridSize      = 10000
+)
+
+// GeohashGridAggParams creates a GeohashGridAgg, a multi-bucket aggregation
+// that groups geo_point and geo_shape values into buckets that represent a
+// grid. Each cell is labeled using a geohash which is of user-definable
+// precision.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-geohashgrid-aggregation.html
+type GeohashGridAggParams struct {
+	// The geo_point or geo_shape field to aggregate on (Required)
+	Field string
+	// The string length of the geohashes used to define cells/buckets. Must be
+	// between 1 and 12 or a distance, such as "1km". Defaults to 5.
+	Precision interface{}
+	// Restricts the cells considered to those that intersect the bounds.
+	Bounds BoundingBoxer
+	// The maximum number of buckets to return. Defaults to 10,000.
+	Size int
+	// The maximum number of buckets returned from each shard.
+	ShardSize int
+	// Sub-aggregations
+	Aggregations Aggs
+	Meta         map[string]interface{}
+}
+
+func (GeohashGridAggParams) Kind() AggKind {
+	return AggKindGeohashGrid
+}
+
+func (p GeohashGridAggParams) Clause() (AggClause, error) {
+	return p.GeohashGrid()
+}
+
+func (p GeohashGridAggParams) GeohashGrid() (*GeohashGridAgg, error) {
+	a := &GeohashGridAgg{}
+	err := a.SetField(p.Field)
+	if err != nil {
+		return a, newAggError(err, AggKindGeohashGrid)
+	}
+	err = a.SetPrecision(p.Precision)
+	if err != nil {
+		return a, newAggError(err, AggKindGeohashGrid)
+	}
+	a.SetBounds(p.Bounds)
+	a.SetSize(p.Size)
+	a.SetShardSize(p.ShardSize)
+	err = a.SetAggregations(p.Aggregations)
+	if err != nil {
+		return a, newAggError(err, AggKindGeohashGrid)
+	}
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// GeohashGridAgg is a multi-bucket aggregation that groups geo_point and
+// geo_shape values into buckets that represent a geohash grid.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-geohashgrid-aggregation.html
+type GeohashGridAgg struct {
+	fieldParam
+	precision interface{}
+	bounds    interface{}
+	size      int
+	shardSize int
+	aggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*GeohashGridAgg)(nil)
+
+func (GeohashGridAgg) Kind() AggKind {
+	return AggKindGeohashGrid
+}
+
+func (a *GeohashGridAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// Precision is the string length of the geohashes used to define
+// cells/buckets or a distance, such as "1km". Defaults to 5.
+func (a GeohashGridAgg) Precision() interface{} {
+	if a.precision == nil {
+		return DefaultGeohashGridPrecision
+	}
+	return a.precision
+}
+
+// SetPrecision sets the precision to v. Numeric values must be between 1 and
+// 12. Distances such as "1km" are also accepted.
+func (a *GeohashGridAgg) SetPrecision(v interface{}) error {
+	if v == nil {
+		a.precision = nil
+		return nil
+	}
+	if s, ok := v.(string); ok {
+		if len(s) == 0 {
+			a.precision = nil
+			return nil
+		}
+		if _, err := dynamic.NewNumber(s); err != nil {
+			// distance
+			a.precision = s
+			return nil
+		}
+	}
+	n, err := dynamic.NewNumber(v)
+	if err != nil {
+		return err
+	}
+	i, ok := n.Int()
+	if !ok || i < 1 || i > 12 {
+		return fmt.Errorf("%w <%v>; expected a value between 1 and 12 or a distance", ErrInvalidPrecision, v)
+	}
+	a.precision = i
+	return nil
+}
+
+// Bounds restricts the cells considered to those that intersect the bounds.
+func (a GeohashGridAgg) Bounds() interface{} {
+	return a.bounds
+}
+
+// SetBounds sets the bounds to v
+func (a *GeohashGridAgg) SetBounds(v BoundingBoxer) {
+	if v == nil {
+		a.bounds = nil
+		return
+	}
+	a.bounds = v.BoundingBox()
+}
+
+// Size is the maximum number of buckets to return. Defaults to 10,000.
+func (a GeohashGridAgg) Size() int {
+	if a.size == 0 {
+		return DefaultGeohashGridSize
+	}
+	return a.size
+}
+
+// SetSize sets size to v
+func (a *GeohashGridAgg) SetSize(v int) {
+	a.size = v
+}
+
+// ShardSize is the maximum number of buckets returned from each shard.
+func (a GeohashGridAgg) ShardSize() int {
+	return a.shardSize
+}
+
+// SetShardSize sets shard_size to v
+func (a *GeohashGridAgg) SetShardSize(v int) {
+	a.shardSize = v
+}
+
+func (a GeohashGridAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a GeohashGridAgg) MarshalJSON() ([]byte, error) {
+	var bounds dynamic.JSON
+	if a.bounds != nil {
+		b, err := json.Marshal(a.bounds)
+		if err != nil {
+			return nil, err
+		}
+		bounds = b
+	}
+	return geohashGridAgg{
+		Field:     a.field,
+		Precision: a.precision,
+		Bounds:    bounds,
+		Size:      a.size,
+		ShardSize: a.shardSize,
+	}.MarshalJSON()
+}
+
+func (a *GeohashGridAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *GeohashGridAgg) UnmarshalJSON(data []byte) error {
+	*a = GeohashGridAgg{}
+	p := geohashGridAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.field = p.Field
+	err = a.SetPrecision(p.Precision)
+	if err != nil {
+		return err
+	}
+	if len(p.Bounds) > 0 && !p.Bounds.IsNull() {
+		a.bounds = p.Bounds
+	}
+	a.SetSize(p.Size)
+	a.SetShardSize(p.ShardSize)
+	return nil
+}
+
+//easyjson:json
+type geohashGridAgg struct {
+	Field     string       `json:"field"`
+	Precision interface{}  `json:"precision,omitempty"`
+	Bounds    dynamic.JSON `json:"bounds,omitempty"`
+	Size      int          `json:"size,omitempty"`
+	ShardSize int          `json:"shard_size,omitempty"`
+}
@@ -0,0 +1,196 @@
+package picker
+
+import "github.com/chanced/dynamic"
+
+// GeoIPProcessorParams creates a GeoIPProcessor, which adds information about
+// the geographical location of an IPv4 or IPv6 address.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/geoip-processor.html
+type GeoIPProcessorParams struct {
+	// Field is the field to get the ip address from for the geographical lookup
+	Field string
+	// TargetField is the field that will hold the geographical information
+	// looked up from the MaxMind database. Defaults to "geoip".
+	TargetField string
+	// DatabaseFile is the database filename referring to a database the module
+	// ships with or a custom database in the ingest-geoip config directory.
+	// Defaults to "GeoLite2-City.mmdb".
+	DatabaseFile string
+	// Properties controls which properties are added to target_field based on
+	// the geoip lookup
+	Properties []string
+	// If IgnoreMissing is true and field does not exist or is null, the
+	// processor quietly exits without modifying the document
+	IgnoreMissing bool
+	// FirstOnly indicates whether only the first found geoip data is returned,
+	// even if field contains an array. Defaults to true.
+	FirstOnly interface{}
+	// Description of the processor. Useful for describing the purpose of the
+	// processor or its configuration.
+	Description string
+	// Conditionally execute the processor
+	If string
+	// Ignore failures for the processor
+	IgnoreFailure bool
+	// Handle failures for the processor
+	OnFailure Procs
+	// Identifier for the processor. Useful for debugging and metrics.
+	Tag string
+}
+
+func (GeoIPProcessorParams) Kind() ProcessorKind {
+	return ProcessorKindGeoIP
+}
+
+func (p GeoIPProcessorParams) Processor() (Processor, error) {
+	return p.GeoIP()
+}
+
+func (p GeoIPProcessorParams) GeoIP() (*GeoIPProcessor, error) {
+	proc := &GeoIPProcessor{}
+	err := proc.SetField(p.Field)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindGeoIP)
+	}
+	proc.SetTargetField(p.TargetField)
+	proc.SetDatabaseFile(p.DatabaseFile)
+	proc.SetProperties(p.Properties)
+	proc.SetIgnoreMissing(p.IgnoreMissing)
+	err = proc.SetFirstOnly(p.FirstOnly)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindGeoIP)
+	}
+	proc.SetDescription(p.Description)
+	proc.SetIf(p.If)
+	proc.SetIgnoreFailure(p.IgnoreFailure)
+	err = proc.SetOnFailure(p.OnFailure)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindGeoIP)
+	}
+	proc.SetTag(p.Tag)
+	return proc, nil
+}
+
+// GeoIPProcessor adds information about the geographical location of an IPv4 or
+// IPv6 address.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/geoip-processor.html
+type GeoIPProcessor struct {
+	fieldParam
+	targetFieldParam
+	databaseFile string
+	properties   []string
+	ignoreMissingParam
+	firstOnly dynamic.Bool
+	processorParams
+}
+
+var _ Processor = (*GeoIPProcessor)(nil)
+
+func (GeoIPProcessor) Kind() ProcessorKind {
+	return ProcessorKindGeoIP
+}
+
+func (p *GeoIPProcessor) Processor() (Processor, error) {
+	return p, nil
+}
+
+// DatabaseFile is the database filename referring to a database the module
+// ships with or a custom database in the ingest-geoip config directory.
+// Defaults to "GeoLite2-City.mmdb".
+func (p GeoIPProcessor) DatabaseFile() string {
+	return p.databaseFile
+}
+
+// SetDatabaseFile sets database_file to v
+func (p *GeoIPProcessor) SetDatabaseFile(v string) {
+	p.databaseFile = v
+}
+
+// Properties controls which properties are added to target_field based on the
+// geoip lookup
+func (p GeoIPProcessor) Properties() []string {
+	return p.properties
+}
+
+// SetProperties sets properties to v
+func (p *GeoIPProcessor) SetProperties(v []string) {
+	p.properties = v
+}
+
+// FirstOnly indicates whether only the first found geoip data is returned, even
+// if field contains an array. Defaults to true.
+func (p GeoIPProcessor) FirstOnly() bool {
+	if v, ok := p.firstOnly.Bool(); ok {
+		return v
+	}
+	return true
+}
+
+// SetFirstOnly sets first_only to v
+func (p *GeoIPProcessor) SetFirstOnly(v interface{}) error {
+	return p.firstOnly.Set(v)
+}
+
+func (p GeoIPProcessor) MarshalBSON() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+func (p GeoIPProcessor) MarshalJSON() ([]byte, error) {
+	return geoIPProcessor{
+		Field:         p.field,
+		TargetField:   p.targetField,
+		DatabaseFile:  p.databaseFile,
+		Properties:    p.properties,
+		IgnoreMissing: p.ignoreMissing,
+		FirstOnly:     p.firstOnly.Value(),
+		Description:   p.description,
+		If:            p.ifCond,
+		IgnoreFailure: p.ignoreFailure,
+		OnFailure:     p.onFailure,
+		Tag:           p.tag,
+	}.MarshalJSON()
+}
+
+func (p *GeoIPProcessor) UnmarshalBSON(data []byte) error {
+	return p.UnmarshalJSON(data)
+}
+
+func (p *GeoIPProcessor) UnmarshalJSON(data []byte) error {
+	*p = GeoIPProcessor{}
+	var v geoIPProcessor
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	p.field = v.Field
+	p.targetField = v.TargetField
+	p.databaseFile = v.DatabaseFile
+	p.properties = v.Properties
+	p.ignoreMissing = v.IgnoreMissing
+	err = p.firstOnly.Set(v.FirstOnly)
+	if err != nil {
+		return err
+	}
+	p.description = v.Description
+	p.ifCond = v.If
+	p.ignoreFailure = v.IgnoreFailure
+	p.onFailure = v.OnFailure
+	p.tag = v.Tag
+	return nil
+}
+
+//easyjson:json
+type geoIPProcessor struct {
+	Field         string      `json:"field,omitempty"`
+	TargetField   string      `json:"target_field,omitempty"`
+	DatabaseFile  string      `json:"database_file,omitempty"`
+	Properties    []string    `json:"properties,omitempty"`
+	IgnoreMissing bool        `json:"ignore_missing,omitempty"`
+	FirstOnly     interface{} `json:"first_only,omitempty"`
+	Description   string      `json:"description,omitempty"`
+	If            string      `json:"if,omitempty"`
+	IgnoreFailure bool        `json:"ignore_failure,omitempty"`
+	OnFailure     Processors  `json:"on_failure,omitempty"`
+	Tag           string      `json:"tag,omitempty"`
+}
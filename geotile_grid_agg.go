@@ -0,0 +1,202 @@
+package picker
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/chanced/dynamic"
+)
+
+const (
+	DefaultGeotileGridPrecision = 7
+	DefaultGeotileGridSize      = 10000
+)
+
+// GeotileGridAggParams creates a GeotileGridAgg, a multi-bucket aggregation
+// that groups geo_point and geo_shape values into buckets that represent a
+// grid. Each cell corresponds to a map tile as used by many online map sites.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-geotilegrid-aggregation.html
+type GeotileGridAggParams struct {
+	// The geo_point or geo_shape field to aggregate on (Required)
+	Field string
+	// The integer zoom of the key used to define cells/buckets. Must be between
+	// 0 and 29. Defaults to 7.
+	Precision interface{}
+	// Restricts the cells considered to those that intersect the bounds.
+	Bounds BoundingBoxer
+	// The maximum number of buckets to return. Defaults to 10,000.
+	Size int
+	// The maximum number of buckets returned from each shard.
+	ShardSize int
+	// Sub-aggregations
+	Aggregations Aggs
+	Meta         map[string]interface{}
+}
+
+func (GeotileGridAggParams) Kind() AggKind {
+	return AggKindGeotileGrid
+}
+
+func (p GeotileGridAggParams) Clause() (AggClause, error) {
+	return p.GeotileGrid()
+}
+
+func (p GeotileGridAggParams) GeotileGrid() (*GeotileGridAgg, error) {
+	a := &GeotileGridAgg{}
+	err := a.SetField(p.Field)
+	if err != nil {
+		return a, newAggError(err, AggKindGeotileGrid)
+	}
+	err = a.SetPrecision(p.Precision)
+	if err != nil {
+		return a, newAggError(err, AggKindGeotileGrid)
+	}
+	a.SetBounds(p.Bounds)
+	a.SetSize(p.Size)
+	a.SetShardSize(p.ShardSize)
+	err = a.SetAggregations(p.Aggregations)
+	if err != nil {
+		return a, newAggError(err, AggKindGeotileGrid)
+	}
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// GeotileGridAgg is a multi-bucket aggregation that groups geo_point and
+// geo_shape values into buckets that represent map tiles.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-geotilegrid-aggregation.html
+type GeotileGridAgg struct {
+	fieldParam
+	precision dynamic.Number
+	bounds    interface{}
+	size      int
+	shardSize int
+	aggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*GeotileGridAgg)(nil)
+
+func (GeotileGridAgg) Kind() AggKind {
+	return AggKindGeotileGrid
+}
+
+func (a *GeotileGridAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// Precision is the integer zoom of the key used to define cells/buckets.
+// Defaults to 7.
+func (a GeotileGridAgg) Precision() int {
+	if i, ok := a.precision.Int(); ok {
+		return i
+	}
+	return DefaultGeotileGridPrecision
+}
+
+// SetPrecision sets the precision to v. Must be between 0 and 29.
+func (a *GeotileGridAgg) SetPrecision(v interface{}) error {
+	n, err := dynamic.NewNumber(v)
+	if err != nil {
+		return err
+	}
+	if i, ok := n.Int(); ok && (i < 0 || i > 29) {
+		return fmt.Errorf("%w <%d>; expected a value between 0 and 29", ErrInvalidPrecision, i)
+	}
+	a.precision = n
+	return nil
+}
+
+// Bounds restricts the cells considered to those that intersect the bounds.
+func (a GeotileGridAgg) Bounds() interface{} {
+	return a.bounds
+}
+
+// SetBounds sets the bounds to v
+func (a *GeotileGridAgg) SetBounds(v BoundingBoxer) {
+	if v == nil {
+		a.bounds = nil
+		return
+	}
+	a.bounds = v.BoundingBox()
+}
+
+// Size is the maximum number of buckets to return. Defaults to 10,000.
+func (a GeotileGridAgg) Size() int {
+	if a.size == 0 {
+		return DefaultGeotileGridSize
+	}
+	return a.size
+}
+
+// SetSize sets size to v
+func (a *GeotileGridAgg) SetSize(v int) {
+	a.size = v
+}
+
+// ShardSize is the maximum number of buckets returned from each shard.
+func (a GeotileGridAgg) ShardSize() int {
+	return a.shardSize
+}
+
+// SetShardSize sets shard_size to v
+func (a *GeotileGridAgg) SetShardSize(v int) {
+	a.shardSize = v
+}
+
+func (a GeotileGridAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a GeotileGridAgg) MarshalJSON() ([]byte, error) {
+	var bounds dynamic.JSON
+	if a.bounds != nil {
+		b, err := json.Marshal(a.bounds)
+		if err != nil {
+			return nil, err
+		}
+		bounds = b
+	}
+	return geotileGridAgg{
+		Field:     a.field,
+		Precision: a.precision.Value(),
+		Bounds:    bounds,
+		Size:      a.size,
+		ShardSize: a.shardSize,
+	}.MarshalJSON()
+}
+
+func (a *GeotileGridAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *GeotileGridAgg) UnmarshalJSON(data []byte) error {
+	*a = GeotileGridAgg{}
+	p := geotileGridAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.field = p.Field
+	err = a.precision.Set(p.Precision)
+	if err != nil {
+		return err
+	}
+	if len(p.Bounds) > 0 && !p.Bounds.IsNull() {
+		a.bounds = p.Bounds
+	}
+	a.SetSize(p.Size)
+	a.SetShardSize(p.ShardSize)
+	return nil
+}
+
+//easyjson:json
+type geotileGridAgg struct {
+	Field     string       `json:"field"`
+	Precision interface{}  `json:"precision,omitempty"`
+	Bounds    dynamic.JSON `json:"bounds,omitempty"`
+	Size      int          `json:"size,omitempty"`
+	ShardSize int          `json:"shard_size,omitempty"`
+}
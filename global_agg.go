@@ -0,0 +1,73 @@
+package picker
+
+import "github.com/chanced/dynamic"
+
+// GlobalAggParams creates a GlobalAgg, which defines a single bucket of all
+// the documents within the search execution context. This context is defined
+// by the indices and the document types you’re searching on, but is not
+// influenced by the search query itself.
+//
+// Global aggregators can only be placed as top level aggregators because it
+// doesn’t make sense to embed a global aggregator within another bucket
+// aggregator.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-global-aggregation.html
+type GlobalAggParams struct {
+	// Sub-aggregations
+	Aggregations Aggs
+	Meta         map[string]interface{}
+}
+
+func (GlobalAggParams) Kind() AggKind {
+	return AggKindGlobal
+}
+
+func (p GlobalAggParams) Clause() (AggClause, error) {
+	return p.Global()
+}
+
+func (p GlobalAggParams) Global() (*GlobalAgg, error) {
+	a := &GlobalAgg{}
+	err := a.SetAggregations(p.Aggregations)
+	if err != nil {
+		return a, newAggError(err, AggKindGlobal)
+	}
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// GlobalAgg defines a single bucket of all the documents within the search
+// execution context, regardless of the search query.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-global-aggregation.html
+type GlobalAgg struct {
+	aggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*GlobalAgg)(nil)
+
+func (GlobalAgg) Kind() AggKind {
+	return AggKindGlobal
+}
+
+func (a *GlobalAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+func (a GlobalAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a GlobalAgg) MarshalJSON() ([]byte, error) {
+	return dynamic.JSON("{}"), nil
+}
+
+func (a *GlobalAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *GlobalAgg) UnmarshalJSON(data []byte) error {
+	*a = GlobalAgg{}
+	return nil
+}
@@ -0,0 +1,205 @@
+package picker
+
+// GrokProcessorParams creates a GrokProcessor, which extracts structured fields
+// out of a single text field within a document by matching the field against
+// regular expressions.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/grok-processor.html
+type GrokProcessorParams struct {
+	// Field is the field to use for grok expression parsing
+	Field string
+	// Patterns is an ordered list of grok expressions to match and extract
+	// named captures with. Returns on the first expression in the list that
+	// matches.
+	Patterns []string
+	// PatternDefinitions is a map of pattern-name and pattern tuples defining
+	// custom patterns to be used by the current processor. Patterns matching
+	// existing names will override the pre-existing definition.
+	PatternDefinitions map[string]string
+	// ECSCompatibility must be "disabled" or "v1". If "v1", the processor uses
+	// patterns with Elastic Common Schema (ECS) field names.
+	ECSCompatibility string
+	// TraceMatch indicates whether metadata about the matched pattern is
+	// inserted into the document under _ingest._grok_match_index
+	TraceMatch bool
+	// If IgnoreMissing is true and field does not exist or is null, the
+	// processor quietly exits without modifying the document
+	IgnoreMissing bool
+	// Description of the processor. Useful for describing the purpose of the
+	// processor or its configuration.
+	Description string
+	// Conditionally execute the processor
+	If string
+	// Ignore failures for the processor
+	IgnoreFailure bool
+	// Handle failures for the processor
+	OnFailure Procs
+	// Identifier for the processor. Useful for debugging and metrics.
+	Tag string
+}
+
+func (GrokProcessorParams) Kind() ProcessorKind {
+	return ProcessorKindGrok
+}
+
+func (p GrokProcessorParams) Processor() (Processor, error) {
+	return p.Grok()
+}
+
+func (p GrokProcessorParams) Grok() (*GrokProcessor, error) {
+	proc := &GrokProcessor{}
+	err := proc.SetField(p.Field)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindGrok)
+	}
+	err = proc.SetPatterns(p.Patterns)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindGrok)
+	}
+	proc.SetPatternDefinitions(p.PatternDefinitions)
+	proc.SetECSCompatibility(p.ECSCompatibility)
+	proc.SetTraceMatch(p.TraceMatch)
+	proc.SetIgnoreMissing(p.IgnoreMissing)
+	proc.SetDescription(p.Description)
+	proc.SetIf(p.If)
+	proc.SetIgnoreFailure(p.IgnoreFailure)
+	err = proc.SetOnFailure(p.OnFailure)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindGrok)
+	}
+	proc.SetTag(p.Tag)
+	return proc, nil
+}
+
+// GrokProcessor extracts structured fields out of a single text field within a
+// document by matching the field against regular expressions.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/grok-processor.html
+type GrokProcessor struct {
+	fieldParam
+	patterns           []string
+	patternDefinitions map[string]string
+	ecsCompatibility   string
+	traceMatch         bool
+	ignoreMissingParam
+	processorParams
+}
+
+var _ Processor = (*GrokProcessor)(nil)
+
+func (GrokProcessor) Kind() ProcessorKind {
+	return ProcessorKindGrok
+}
+
+func (p *GrokProcessor) Processor() (Processor, error) {
+	return p, nil
+}
+
+// Patterns is an ordered list of grok expressions to match and extract named
+// captures with. Returns on the first expression in the list that matches.
+func (p GrokProcessor) Patterns() []string {
+	return p.patterns
+}
+
+// SetPatterns sets patterns to v
+func (p *GrokProcessor) SetPatterns(v []string) error {
+	if len(v) == 0 {
+		return ErrPatternsRequired
+	}
+	p.patterns = v
+	return nil
+}
+
+// PatternDefinitions is a map of pattern-name and pattern tuples defining
+// custom patterns to be used by the current processor. Patterns matching
+// existing names will override the pre-existing definition.
+func (p GrokProcessor) PatternDefinitions() map[string]string {
+	return p.patternDefinitions
+}
+
+// SetPatternDefinitions sets pattern_definitions to v
+func (p *GrokProcessor) SetPatternDefinitions(v map[string]string) {
+	p.patternDefinitions = v
+}
+
+// ECSCompatibility must be "disabled" or "v1". If "v1", the processor uses
+// patterns with Elastic Common Schema (ECS) field names.
+func (p GrokProcessor) ECSCompatibility() string {
+	return p.ecsCompatibility
+}
+
+// SetECSCompatibility sets ecs_compatibility to v
+func (p *GrokProcessor) SetECSCompatibility(v string) {
+	p.ecsCompatibility = v
+}
+
+// TraceMatch indicates whether metadata about the matched pattern is inserted
+// into the document under _ingest._grok_match_index
+func (p GrokProcessor) TraceMatch() bool {
+	return p.traceMatch
+}
+
+// SetTraceMatch sets trace_match to v
+func (p *GrokProcessor) SetTraceMatch(v bool) {
+	p.traceMatch = v
+}
+
+func (p GrokProcessor) MarshalBSON() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+func (p GrokProcessor) MarshalJSON() ([]byte, error) {
+	return grokProcessor{
+		Field:              p.field,
+		Patterns:           p.patterns,
+		PatternDefinitions: p.patternDefinitions,
+		ECSCompatibility:   p.ecsCompatibility,
+		TraceMatch:         p.traceMatch,
+		IgnoreMissing:      p.ignoreMissing,
+		Description:        p.description,
+		If:                 p.ifCond,
+		IgnoreFailure:      p.ignoreFailure,
+		OnFailure:          p.onFailure,
+		Tag:                p.tag,
+	}.MarshalJSON()
+}
+
+func (p *GrokProcessor) UnmarshalBSON(data []byte) error {
+	return p.UnmarshalJSON(data)
+}
+
+func (p *GrokProcessor) UnmarshalJSON(data []byte) error {
+	*p = GrokProcessor{}
+	var v grokProcessor
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	p.field = v.Field
+	p.patterns = v.Patterns
+	p.patternDefinitions = v.PatternDefinitions
+	p.ecsCompatibility = v.ECSCompatibility
+	p.traceMatch = v.TraceMatch
+	p.ignoreMissing = v.IgnoreMissing
+	p.description = v.Description
+	p.ifCond = v.If
+	p.ignoreFailure = v.IgnoreFailure
+	p.onFailure = v.OnFailure
+	p.tag = v.Tag
+	return nil
+}
+
+//easyjson:json
+type grokProcessor struct {
+	Field              string            `json:"field,omitempty"`
+	Patterns           []string          `json:"patterns,omitempty"`
+	PatternDefinitions map[string]string `json:"pattern_definitions,omitempty"`
+	ECSCompatibility   string            `json:"ecs_compatibility,omitempty"`
+	TraceMatch         bool              `json:"trace_match,omitempty"`
+	IgnoreMissing      bool              `json:"ignore_missing,omitempty"`
+	Description        string            `json:"description,omitempty"`
+	If                 string            `json:"if,omitempty"`
+	IgnoreFailure      bool              `json:"ignore_failure,omitempty"`
+	OnFailure          Processors        `json:"on_failure,omitempty"`
+	Tag                string            `json:"tag,omitempty"`
+}
@@ -0,0 +1,167 @@
+package picker
+
+// GsubProcessorParams creates a GsubProcessor, which converts a string field by
+// applying a regular expression and a replacement.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/gsub-processor.html
+type GsubProcessorParams struct {
+	// Field is the field to apply the replacement to
+	Field string
+	// Pattern to be replaced
+	Pattern string
+	// Replacement is the string to replace the matching patterns with
+	Replacement string
+	// TargetField is the field to assign the output to. By default, field is
+	// updated in-place.
+	TargetField string
+	// If IgnoreMissing is true and field does not exist or is null, the
+	// processor quietly exits without modifying the document
+	IgnoreMissing bool
+	// Description of the processor. Useful for describing the purpose of the
+	// processor or its configuration.
+	Description string
+	// Conditionally execute the processor
+	If string
+	// Ignore failures for the processor
+	IgnoreFailure bool
+	// Handle failures for the processor
+	OnFailure Procs
+	// Identifier for the processor. Useful for debugging and metrics.
+	Tag string
+}
+
+func (GsubProcessorParams) Kind() ProcessorKind {
+	return ProcessorKindGsub
+}
+
+func (p GsubProcessorParams) Processor() (Processor, error) {
+	return p.Gsub()
+}
+
+func (p GsubProcessorParams) Gsub() (*GsubProcessor, error) {
+	proc := &GsubProcessor{}
+	err := proc.SetField(p.Field)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindGsub)
+	}
+	err = proc.SetPattern(p.Pattern)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindGsub)
+	}
+	proc.SetReplacement(p.Replacement)
+	proc.SetTargetField(p.TargetField)
+	proc.SetIgnoreMissing(p.IgnoreMissing)
+	proc.SetDescription(p.Description)
+	proc.SetIf(p.If)
+	proc.SetIgnoreFailure(p.IgnoreFailure)
+	err = proc.SetOnFailure(p.OnFailure)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindGsub)
+	}
+	proc.SetTag(p.Tag)
+	return proc, nil
+}
+
+// GsubProcessor converts a string field by applying a regular expression and a
+// replacement.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/gsub-processor.html
+type GsubProcessor struct {
+	fieldParam
+	pattern     string
+	replacement string
+	targetFieldParam
+	ignoreMissingParam
+	processorParams
+}
+
+var _ Processor = (*GsubProcessor)(nil)
+
+func (GsubProcessor) Kind() ProcessorKind {
+	return ProcessorKindGsub
+}
+
+func (p *GsubProcessor) Processor() (Processor, error) {
+	return p, nil
+}
+
+// Pattern to be replaced
+func (p GsubProcessor) Pattern() string {
+	return p.pattern
+}
+
+// SetPattern sets pattern to v
+func (p *GsubProcessor) SetPattern(v string) error {
+	if len(v) == 0 {
+		return ErrPatternRequired
+	}
+	p.pattern = v
+	return nil
+}
+
+// Replacement is the string to replace the matching patterns with
+func (p GsubProcessor) Replacement() string {
+	return p.replacement
+}
+
+// SetReplacement sets replacement to v
+func (p *GsubProcessor) SetReplacement(v string) {
+	p.replacement = v
+}
+
+func (p GsubProcessor) MarshalBSON() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+func (p GsubProcessor) MarshalJSON() ([]byte, error) {
+	return gsubProcessor{
+		Field:         p.field,
+		Pattern:       p.pattern,
+		Replacement:   p.replacement,
+		TargetField:   p.targetField,
+		IgnoreMissing: p.ignoreMissing,
+		Description:   p.description,
+		If:            p.ifCond,
+		IgnoreFailure: p.ignoreFailure,
+		OnFailure:     p.onFailure,
+		Tag:           p.tag,
+	}.MarshalJSON()
+}
+
+func (p *GsubProcessor) UnmarshalBSON(data []byte) error {
+	return p.UnmarshalJSON(data)
+}
+
+func (p *GsubProcessor) UnmarshalJSON(data []byte) error {
+	*p = GsubProcessor{}
+	var v gsubProcessor
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	p.field = v.Field
+	p.pattern = v.Pattern
+	p.replacement = v.Replacement
+	p.targetField = v.TargetField
+	p.ignoreMissing = v.IgnoreMissing
+	p.description = v.Description
+	p.ifCond = v.If
+	p.ignoreFailure = v.IgnoreFailure
+	p.onFailure = v.OnFailure
+	p.tag = v.Tag
+	return nil
+}
+
+//easyjson:json
+type gsubProcessor struct {
+	Field         string     `json:"field,omitempty"`
+	Pattern       string     `json:"pattern,omitempty"`
+	Replacement   string     `json:"replacement"`
+	TargetField   string     `json:"target_field,omitempty"`
+	IgnoreMissing bool       `json:"ignore_missing,omitempty"`
+	Description   string     `json:"description,omitempty"`
+	If            string     `json:"if,omitempty"`
+	IgnoreFailure bool       `json:"ignore_failure,omitempty"`
+	OnFailure     Processors `json:"on_failure,omitempty"`
+	Tag           string     `json:"tag,omitempty"`
+}
@@ -0,0 +1,556 @@
+package picker
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/chanced/dynamic"
+)
+
+// HighlighterType is the highlighter used to highlight a field.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/highlighting.html#highlighter-types
+type HighlighterType string
+
+const (
+	HighlighterTypeUnspecified HighlighterType = ""
+	// HighlighterTypeUnified uses the Lucene Unified Highlighter. This is the
+	// default highlighter.
+	HighlighterTypeUnified HighlighterType = "unified"
+	// HighlighterTypePlain uses the standard Lucene highlighter.
+	HighlighterTypePlain HighlighterType = "plain"
+	// HighlighterTypeFastVector uses the Lucene Fast Vector highlighter. It
+	// requires term_vector to be set to with_positions_offsets on the field.
+	HighlighterTypeFastVector HighlighterType = "fvh"
+)
+
+func (ht HighlighterType) String() string {
+	return string(ht)
+}
+
+func (ht HighlighterType) IsValid() bool {
+	switch ht {
+	case HighlighterTypeUnspecified, HighlighterTypeUnified, HighlighterTypePlain, HighlighterTypeFastVector:
+		return true
+	}
+	return false
+}
+
+func (ht HighlighterType) Validate() error {
+	if !ht.IsValid() {
+		return fmt.Errorf("%w <%s>", ErrInvalidHighlighterType, ht)
+	}
+	return nil
+}
+
+// BoundaryScanner specifies how to break highlighted fragments
+type BoundaryScanner string
+
+const (
+	BoundaryScannerUnspecified BoundaryScanner = ""
+	// BoundaryScannerChars uses the characters specified by boundary_chars as
+	// highlighting boundaries. Only valid for the fvh highlighter.
+	BoundaryScannerChars BoundaryScanner = "chars"
+	// BoundaryScannerSentence breaks highlighted fragments at the next sentence
+	// boundary, as determined by Java’s BreakIterator.
+	BoundaryScannerSentence BoundaryScanner = "sentence"
+	// BoundaryScannerWord breaks highlighted fragments at the next word
+	// boundary, as determined by Java’s BreakIterator.
+	BoundaryScannerWord BoundaryScanner = "word"
+)
+
+func (bs BoundaryScanner) String() string {
+	return string(bs)
+}
+
+// HighlightOrder is the order of highlighted fragments
+type HighlightOrder string
+
+const (
+	// HighlightOrderNone returns fragments in the order they appear in the
+	// field. This is the default.
+	HighlightOrderNone HighlightOrder = "none"
+	// HighlightOrderScore sorts highlighted fragments by score
+	HighlightOrderScore HighlightOrder = "score"
+)
+
+func (ho HighlightOrder) String() string {
+	return string(ho)
+}
+
+// HighlightOptions are the settings which can be set either globally or per
+// field.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/highlighting.html#highlighting-settings
+type HighlightOptions struct {
+	// The highlighter to use: "unified", "plain", or "fvh". Defaults to
+	// "unified".
+	Type HighlighterType `json:"type,omitempty"`
+	// A string that contains each boundary character. Defaults to .,!? \t\n
+	BoundaryChars string `json:"boundary_chars,omitempty"`
+	// How far to scan for boundary characters. Defaults to 20.
+	BoundaryMaxScan int `json:"boundary_max_scan,omitempty"`
+	// Specifies how to break the highlighted fragments: "chars", "sentence",
+	// or "word".
+	// Only valid for the unified and fvh highlighters.
+	BoundaryScanner BoundaryScanner `json:"boundary_scanner,omitempty"`
+	// Controls which locale is used to search for sentence and word
+	// boundaries.
+	BoundaryScannerLocale string `json:"boundary_scanner_locale,omitempty"`
+	// Highlight based on the source even if the field is stored separately.
+	ForceSource bool `json:"force_source,omitempty"`
+	// Specifies how text should be broken up in highlight snippets: "simple"
+	// or "span". Only valid for the plain highlighter.
+	Fragmenter string `json:"fragmenter,omitempty"`
+	// Controls the margin from which you want to start highlighting. Only
+	// valid when using the fvh highlighter.
+	FragmentOffset int `json:"fragment_offset,omitempty"`
+	// The size of the highlighted fragment in characters. Defaults to 100.
+	FragmentSize *int `json:"fragment_size,omitempty"`
+	// Highlight matches for a query other than the search query.
+	HighlightQuery Querier `json:"-"`
+	// Combine matches on multiple fields to highlight a single field. Only
+	// valid for the fvh highlighter.
+	MatchedFields []string `json:"matched_fields,omitempty"`
+	// By default, the maximum number of characters analyzed for a highlight
+	// request is bounded by the value defined in the
+	// index.highlight.max_analyzed_offset setting.
+	MaxAnalyzedOffset int `json:"max_analyzed_offset,omitempty"`
+	// The amount of text you want to return from the beginning of the field if
+	// there are no matching fragments to highlight. Defaults to 0.
+	NoMatchSize int `json:"no_match_size,omitempty"`
+	// The maximum number of fragments to return. If set to 0, no fragments
+	// are returned and instead the entire field contents are highlighted and
+	// returned. Defaults to 5.
+	NumberOfFragments *int `json:"number_of_fragments,omitempty"`
+	// Sorts highlighted fragments by score when set to "score".
+	Order HighlightOrder `json:"order,omitempty"`
+	// Controls the number of matching phrases in a document that are
+	// considered. Defaults to 256. Only valid for the fvh highlighter.
+	PhraseLimit int `json:"phrase_limit,omitempty"`
+	// Tags inserted before highlighted text. Defaults to <em>.
+	PreTags []string `json:"pre_tags,omitempty"`
+	// Tags inserted after highlighted text. Defaults to </em>.
+	PostTags []string `json:"post_tags,omitempty"`
+	// By default, only fields that contains a query match are highlighted.
+	// Set RequireFieldMatch to false to highlight all fields.
+	RequireFieldMatch *bool `json:"require_field_match,omitempty"`
+	// Set to "styled" to use the built-in tag schema.
+	TagsSchema string `json:"tags_schema,omitempty"`
+}
+
+func (o HighlightOptions) MarshalBSON() ([]byte, error) {
+	return o.MarshalJSON()
+}
+
+func (o HighlightOptions) MarshalJSON() ([]byte, error) {
+	v, err := o.highlightOptions()
+	if err != nil {
+		return nil, err
+	}
+	return v.MarshalJSON()
+}
+
+func (o *HighlightOptions) UnmarshalBSON(data []byte) error {
+	return o.UnmarshalJSON(data)
+}
+
+func (o *HighlightOptions) UnmarshalJSON(data []byte) error {
+	var v highlightOptions
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	*o = v.options()
+	return nil
+}
+
+// Validate checks that the options are valid for the highlighter they are
+// used with. If Type is unspecified, the unified highlighter is assumed.
+func (o HighlightOptions) Validate() error {
+	return o.validate(o.Type)
+}
+
+func (o HighlightOptions) highlightOptions() (highlightOptions, error) {
+	v := highlightOptions{HighlightOptions: o}
+	if o.HighlightQuery == nil {
+		return v, nil
+	}
+	q, err := o.HighlightQuery.Query()
+	if err != nil {
+		return v, newFieldError(err, "highlight_query")
+	}
+	if !q.IsEmpty() {
+		v.HighlightQuery = q
+	}
+	return v, nil
+}
+
+// validate checks the options against ht, the highlighter which is in effect
+// for them
+func (o HighlightOptions) validate(ht HighlighterType) error {
+	if err := o.Type.Validate(); err != nil {
+		return err
+	}
+	if o.Type != HighlighterTypeUnspecified {
+		ht = o.Type
+	}
+	if ht == HighlighterTypeUnspecified {
+		ht = HighlighterTypeUnified
+	}
+	if ht != HighlighterTypeFastVector {
+		if len(o.MatchedFields) > 0 {
+			return fmt.Errorf("%w; matched_fields is not supported by <%s>", ErrFastVectorRequired, ht)
+		}
+		if o.FragmentOffset != 0 {
+			return fmt.Errorf("%w; fragment_offset is not supported by <%s>", ErrFastVectorRequired, ht)
+		}
+		if o.PhraseLimit != 0 {
+			return fmt.Errorf("%w; phrase_limit is not supported by <%s>", ErrFastVectorRequired, ht)
+		}
+		if o.BoundaryScanner == BoundaryScannerChars {
+			return fmt.Errorf("%w; boundary_scanner <chars> is not supported by <%s>", ErrFastVectorRequired, ht)
+		}
+	}
+	if len(o.Fragmenter) > 0 && ht != HighlighterTypePlain {
+		return fmt.Errorf("%w; fragmenter is not supported by <%s>", ErrPlainHighlighterRequired, ht)
+	}
+	switch o.BoundaryScanner {
+	case BoundaryScannerUnspecified:
+	case BoundaryScannerChars, BoundaryScannerSentence, BoundaryScannerWord:
+		if ht == HighlighterTypePlain {
+			return fmt.Errorf("%w; boundary_scanner is not supported by <%s>", ErrInvalidBoundaryScanner, ht)
+		}
+	default:
+		return fmt.Errorf("%w <%s>", ErrInvalidBoundaryScanner, o.BoundaryScanner)
+	}
+	switch o.Order {
+	case "", HighlightOrderNone, HighlightOrderScore:
+	default:
+		return fmt.Errorf("%w <%s>", ErrInvalidHighlightOrder, o.Order)
+	}
+	return nil
+}
+
+//easyjson:json
+type highlightOptions struct {
+	HighlightOptions
+	HighlightQuery *Query `json:"highlight_query,omitempty"`
+}
+
+func (v highlightOptions) options() HighlightOptions {
+	o := v.HighlightOptions
+	if v.HighlightQuery != nil {
+		o.HighlightQuery = v.HighlightQuery
+	}
+	return o
+}
+
+// HighlightField is the highlight settings of a field
+type HighlightField struct {
+	// Field is the name of the field, which supports wildcards
+	Field   string
+	Options HighlightOptions
+}
+
+// HighlightFields are the per-field highlight settings, in the order the
+// fields are highlighted.
+type HighlightFields []HighlightField
+
+// Get returns the options of field or nil if field is not highlighted
+func (hf HighlightFields) Get(field string) *HighlightOptions {
+	for i, f := range hf {
+		if f.Field == field {
+			return &hf[i].Options
+		}
+	}
+	return nil
+}
+
+// Set sets the options of field, appending field if it is not already
+// highlighted
+func (hf *HighlightFields) Set(field string, o HighlightOptions) {
+	if v := hf.Get(field); v != nil {
+		*v = o
+		return
+	}
+	*hf = append(*hf, HighlightField{Field: field, Options: o})
+}
+
+func (hf HighlightFields) MarshalBSON() ([]byte, error) {
+	return hf.MarshalJSON()
+}
+
+// MarshalJSON encodes hf in the object form, e.g. {"comment":{}}, retaining
+// the order of hf
+func (hf HighlightFields) MarshalJSON() ([]byte, error) {
+	buf := bytes.Buffer{}
+	buf.WriteByte('{')
+	for i, f := range hf {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(f.Field)
+		if err != nil {
+			return nil, err
+		}
+		o, err := f.Options.MarshalJSON()
+		if err != nil {
+			return nil, newFieldError(err, f.Field)
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(o)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// marshalArray encodes hf in the array form, e.g. [{"comment":{}}]
+func (hf HighlightFields) marshalArray() ([]byte, error) {
+	res := make([]dynamic.JSONObject, len(hf))
+	for i, f := range hf {
+		o, err := f.Options.MarshalJSON()
+		if err != nil {
+			return nil, newFieldError(err, f.Field)
+		}
+		res[i] = dynamic.JSONObject{f.Field: o}
+	}
+	return json.Marshal(res)
+}
+
+func (hf *HighlightFields) UnmarshalBSON(data []byte) error {
+	return hf.UnmarshalJSON(data)
+}
+
+// UnmarshalJSON accepts either an object keyed by field name or an array of
+// single-key objects, which Elasticsearch allows in order to control the
+// order fields are highlighted.
+func (hf *HighlightFields) UnmarshalJSON(data []byte) error {
+	_, err := hf.unmarshal(data)
+	return err
+}
+
+// unmarshal decodes data into hf, returning whether data was in the array
+// form
+func (hf *HighlightFields) unmarshal(data []byte) (bool, error) {
+	*hf = nil
+	d := dynamic.JSON(data)
+	if len(d) == 0 || d.IsNull() {
+		return false, nil
+	}
+	objs := []dynamic.JSON{d}
+	isArray := d.IsArray()
+	if isArray {
+		objs = nil
+		err := json.Unmarshal(d, &objs)
+		if err != nil {
+			return true, err
+		}
+	}
+	for _, obj := range objs {
+		keys, m, err := unmarshalOrderedObject(obj)
+		if err != nil {
+			return isArray, err
+		}
+		for _, k := range keys {
+			var o HighlightOptions
+			err = o.UnmarshalJSON(m[k])
+			if err != nil {
+				return isArray, newFieldError(err, k)
+			}
+			*hf = append(*hf, HighlightField{Field: k, Options: o})
+		}
+	}
+	return isArray, nil
+}
+
+// Highlight enables you to get highlighted snippets from one or more fields in
+// your search results so you can show users where the query matches are.
+//
+// The settings of HighlightOptions apply to all fields unless overridden by
+// the settings of the field. The highlighted fragments are returned in the
+// Highlight of each Hit, keyed by field name.
+//
+//	picker.Highlight{
+//	    HighlightOptions: picker.HighlightOptions{
+//	        PreTags:  []string{"<b>"},
+//	        PostTags: []string{"</b>"},
+//	    },
+//	    Fields: picker.HighlightFields{
+//	        {Field: "comment", Options: picker.HighlightOptions{Type: picker.HighlighterTypeFastVector}},
+//	    },
+//	}
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/highlighting.html
+type Highlight struct {
+	HighlightOptions
+	// Indicates if the snippet should be HTML encoded: "default" (no
+	// encoding) or "html".
+	Encoder string `json:"encoder,omitempty"`
+	// The fields to highlight (Required)
+	Fields HighlightFields `json:"fields"`
+	// fieldsArray is true if Fields were decoded from the array form
+	fieldsArray bool
+}
+
+func (h Highlight) MarshalBSON() ([]byte, error) {
+	return h.MarshalJSON()
+}
+
+func (h Highlight) MarshalJSON() ([]byte, error) {
+	o, err := h.HighlightOptions.highlightOptions()
+	if err != nil {
+		return nil, err
+	}
+	var fields []byte
+	if h.fieldsArray {
+		fields, err = h.Fields.marshalArray()
+	} else {
+		fields, err = h.Fields.MarshalJSON()
+	}
+	if err != nil {
+		return nil, newFieldError(err, "fields")
+	}
+	return highlight{
+		highlightOptions: o,
+		Encoder:          h.Encoder,
+		Fields:           fields,
+	}.MarshalJSON()
+}
+
+func (h *Highlight) UnmarshalBSON(data []byte) error {
+	return h.UnmarshalJSON(data)
+}
+
+func (h *Highlight) UnmarshalJSON(data []byte) error {
+	var v highlight
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	*h = Highlight{
+		HighlightOptions: v.highlightOptions.options(),
+		Encoder:          v.Encoder,
+	}
+	h.fieldsArray, err = h.Fields.unmarshal(v.Fields)
+	if err != nil {
+		return newFieldError(err, "fields")
+	}
+	return nil
+}
+
+// Validate checks that fields are specified and that the settings of each
+// field are supported by the highlighter in effect for it. Options exclusive
+// to the fvh highlighter, such as matched_fields, are only permitted where
+// fvh is requested.
+func (h Highlight) Validate() error {
+	if len(h.Fields) == 0 {
+		return ErrFieldsRequired
+	}
+	if err := h.Type.Validate(); err != nil {
+		return err
+	}
+	// the global options are permitted so long as they are supported by the
+	// highlighter of at least one field
+	var err error
+	for _, f := range h.Fields {
+		if err = f.Options.validate(h.Type); err != nil {
+			return newFieldError(err, f.Field)
+		}
+	}
+	for _, ht := range h.highlighterTypes() {
+		if err = h.HighlightOptions.validate(ht); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// ValidateMappings checks that each field highlighted with the fvh highlighter
+// is mapped with a term_vector of with_positions_offsets, which fvh requires.
+// Fields using wildcards are checked against each matching field which
+// supports term_vector.
+func (h Highlight) ValidateMappings(m Mappings) error {
+	fm, err := m.FieldMappings()
+	if err != nil {
+		return err
+	}
+	for _, f := range h.Fields {
+		if h.fieldHighlighterType(f.Options) != HighlighterTypeFastVector {
+			continue
+		}
+		names := append([]string{f.Field}, f.Options.MatchedFields...)
+		for _, name := range names {
+			err = checkFastVectorField(fm.Properties, name)
+			if err != nil {
+				return newFieldError(err, f.Field)
+			}
+		}
+	}
+	return nil
+}
+
+func (h Highlight) fieldHighlighterType(o HighlightOptions) HighlighterType {
+	if o.Type != HighlighterTypeUnspecified {
+		return o.Type
+	}
+	if h.Type != HighlighterTypeUnspecified {
+		return h.Type
+	}
+	return HighlighterTypeUnified
+}
+
+func (h Highlight) highlighterTypes() []HighlighterType {
+	seen := map[HighlighterType]bool{}
+	res := []HighlighterType{}
+	for _, f := range h.Fields {
+		ht := h.fieldHighlighterType(f.Options)
+		if !seen[ht] {
+			seen[ht] = true
+			res = append(res, ht)
+		}
+	}
+	return res
+}
+
+func checkFastVectorField(props Fields, name string) error {
+	if !strings.ContainsAny(name, "*?") {
+		f, ok := props.Lookup(name)
+		if !ok {
+			return fmt.Errorf("%w <%s>", ErrFieldNotFound, name)
+		}
+		return checkTermVector(name, f)
+	}
+	var err error
+	props.walk(func(p string, f Field) bool {
+		if _, ok := f.(WithTermVector); !ok {
+			return true
+		}
+		if ok, _ := path.Match(name, p); ok {
+			err = checkTermVector(p, f)
+		}
+		return err == nil
+	})
+	return err
+}
+
+func checkTermVector(name string, f Field) error {
+	if tv, ok := f.(WithTermVector); ok {
+		switch tv.TermVector() {
+		case TermVectorWithPositionsOffsets, TermVectorPositionsOffsetsPayloads:
+			return nil
+		}
+	}
+	return fmt.Errorf("%w <%s>", ErrTermVectorRequired, name)
+}
+
+//easyjson:json
+type highlight struct {
+	highlightOptions
+	Encoder string       `json:"encoder,omitempty"`
+	Fields  dynamic.JSON `json:"fields"`
+}
@@ -0,0 +1,225 @@
+package picker_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/chanced/cmpjson"
+	"github.com/chanced/picker"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHighlight(t *testing.T) {
+	assert := require.New(t)
+	data := []byte(`{
+		"query": { "match": { "comment": { "query": "foo bar" } } },
+		"highlight": {
+			"pre_tags": ["<b>"],
+			"post_tags": ["</b>"],
+			"fragment_size": 150,
+			"number_of_fragments": 3,
+			"order": "score",
+			"fields": {
+				"comment": {
+					"type": "fvh",
+					"matched_fields": ["comment", "comment.plain"],
+					"boundary_scanner": "chars",
+					"phrase_limit": 10
+				},
+				"title": {
+					"type": "plain",
+					"fragmenter": "span",
+					"require_field_match": false,
+					"highlight_query": { "match": { "title": { "query": "foo" } } }
+				}
+			}
+		}
+	}`)
+	var s picker.Search
+	err := json.Unmarshal(data, &s)
+	assert.NoError(err)
+	h := s.Highlight()
+	assert.NotNil(h)
+	assert.Empty(s.Passthrough())
+	assert.Equal(picker.HighlightOrderScore, h.Order)
+	assert.Equal(picker.HighlighterTypeFastVector, h.Fields.Get("comment").Type)
+	assert.NotNil(h.Fields.Get("title").HighlightQuery)
+	assert.NoError(h.Validate())
+	sd, err := json.Marshal(s)
+	assert.NoError(err)
+	assert.True(cmpjson.Equal(data, sd), cmpjson.Diff(data, sd))
+
+	fragmentSize := 150
+	numberOfFragments := 3
+	requireFieldMatch := false
+	s2, err := picker.NewSearch(picker.SearchParams{
+		Query: &picker.QueryParams{
+			Match: picker.MatchQueryParams{Field: "comment", Query: "foo bar"},
+		},
+		Highlight: &picker.Highlight{
+			HighlightOptions: picker.HighlightOptions{
+				PreTags:           []string{"<b>"},
+				PostTags:          []string{"</b>"},
+				FragmentSize:      &fragmentSize,
+				NumberOfFragments: &numberOfFragments,
+				Order:             picker.HighlightOrderScore,
+			},
+			Fields: picker.HighlightFields{
+				{Field: "comment", Options: picker.HighlightOptions{
+					Type:            picker.HighlighterTypeFastVector,
+					MatchedFields:   []string{"comment", "comment.plain"},
+					BoundaryScanner: picker.BoundaryScannerChars,
+					PhraseLimit:     10,
+				}},
+				{Field: "title", Options: picker.HighlightOptions{
+					Type:              picker.HighlighterTypePlain,
+					Fragmenter:        "span",
+					RequireFieldMatch: &requireFieldMatch,
+					HighlightQuery: &picker.QueryParams{
+						Match: picker.MatchQueryParams{Field: "title", Query: "foo"},
+					},
+				}},
+			},
+		},
+	})
+	assert.NoError(err)
+	sd2, err := json.Marshal(s2)
+	assert.NoError(err)
+	assert.True(cmpjson.Equal(data, sd2), cmpjson.Diff(data, sd2))
+
+	_, err = picker.NewSearch(picker.SearchParams{Highlight: &picker.Highlight{}})
+	assert.True(errors.Is(err, picker.ErrFieldsRequired))
+	err = picker.Highlight{Fields: picker.HighlightFields{
+		{Field: "comment", Options: picker.HighlightOptions{MatchedFields: []string{"comment.plain"}}},
+	}}.Validate()
+	assert.True(errors.Is(err, picker.ErrFastVectorRequired))
+	err = picker.Highlight{Fields: picker.HighlightFields{
+		{Field: "comment", Options: picker.HighlightOptions{Type: picker.HighlighterTypeFastVector, Fragmenter: "simple"}},
+	}}.Validate()
+	assert.True(errors.Is(err, picker.ErrPlainHighlighterRequired))
+	err = picker.Highlight{Fields: picker.HighlightFields{
+		{Field: "comment", Options: picker.HighlightOptions{Type: picker.HighlighterTypePlain, BoundaryScanner: picker.BoundaryScannerWord}},
+	}}.Validate()
+	assert.True(errors.Is(err, picker.ErrInvalidBoundaryScanner))
+	err = picker.Highlight{Fields: picker.HighlightFields{
+		{Field: "comment", Options: picker.HighlightOptions{Type: "fast"}},
+	}}.Validate()
+	assert.True(errors.Is(err, picker.ErrInvalidHighlighterType))
+	// global options need only be supported by the highlighter of one field
+	err = picker.Highlight{
+		HighlightOptions: picker.HighlightOptions{PhraseLimit: 10},
+		Fields: picker.HighlightFields{
+			{Field: "comment", Options: picker.HighlightOptions{Type: picker.HighlighterTypeFastVector}},
+			{Field: "title"},
+		},
+	}.Validate()
+	assert.NoError(err)
+	err = picker.Highlight{
+		HighlightOptions: picker.HighlightOptions{PhraseLimit: 10},
+		Fields:           picker.HighlightFields{{Field: "title"}},
+	}.Validate()
+	assert.True(errors.Is(err, picker.ErrFastVectorRequired))
+}
+
+func TestHighlightFieldOrder(t *testing.T) {
+	assert := require.New(t)
+	data := []byte(`{"fields":[{"title":{}},{"comment":{"type":"plain"}},{"body":{}}]}`)
+	var h picker.Highlight
+	err := json.Unmarshal(data, &h)
+	assert.NoError(err)
+	assert.Len(h.Fields, 3)
+	assert.Equal("title", h.Fields[0].Field)
+	assert.Equal("comment", h.Fields[1].Field)
+	assert.Equal(picker.HighlighterTypePlain, h.Fields[1].Options.Type)
+	assert.Equal("body", h.Fields[2].Field)
+	hd, err := json.Marshal(h)
+	assert.NoError(err)
+	assert.Equal(string(data), string(hd))
+
+	data = []byte(`{"fields":{"title":{},"comment":{"type":"plain"},"body":{}}}`)
+	err = json.Unmarshal(data, &h)
+	assert.NoError(err)
+	assert.Equal("title", h.Fields[0].Field)
+	assert.Equal("body", h.Fields[2].Field)
+	hd, err = json.Marshal(h)
+	assert.NoError(err)
+	assert.Equal(string(data), string(hd))
+
+	h.Fields.Set("title", picker.HighlightOptions{Type: picker.HighlighterTypeUnified})
+	h.Fields.Set("summary", picker.HighlightOptions{})
+	assert.Len(h.Fields, 4)
+	assert.Equal(picker.HighlighterTypeUnified, h.Fields.Get("title").Type)
+	assert.Equal("summary", h.Fields[3].Field)
+	assert.Nil(h.Fields.Get("missing"))
+}
+
+func TestHighlightValidateMappings(t *testing.T) {
+	assert := require.New(t)
+	mappings := picker.Mappings{
+		Properties: picker.FieldMap{
+			"comment": picker.TextFieldParams{
+				TermVector: picker.TermVectorWithPositionsOffsets,
+				Fields: picker.FieldMap{
+					"plain": picker.TextFieldParams{TermVector: picker.TermVectorWithPositionsOffsets},
+				},
+			},
+			"title": picker.TextFieldParams{},
+			"user": picker.ObjectFieldParams{
+				Properties: picker.FieldMap{
+					"bio": picker.TextFieldParams{TermVector: picker.TermVectorWithPositionsOffsets},
+				},
+			},
+		},
+	}
+	h := picker.Highlight{
+		HighlightOptions: picker.HighlightOptions{Type: picker.HighlighterTypeFastVector},
+		Fields: picker.HighlightFields{
+			{Field: "comment", Options: picker.HighlightOptions{MatchedFields: []string{"comment", "comment.plain"}}},
+			{Field: "user.bio"},
+			{Field: "title", Options: picker.HighlightOptions{Type: picker.HighlighterTypeUnified}},
+		},
+	}
+	assert.NoError(h.ValidateMappings(mappings))
+
+	h.Fields.Set("title", picker.HighlightOptions{})
+	err := h.ValidateMappings(mappings)
+	assert.True(errors.Is(err, picker.ErrTermVectorRequired))
+
+	h.Fields = picker.HighlightFields{{Field: "*"}}
+	err = h.ValidateMappings(mappings)
+	assert.True(errors.Is(err, picker.ErrTermVectorRequired))
+
+	h.Fields = picker.HighlightFields{{Field: "comment*"}}
+	assert.NoError(h.ValidateMappings(mappings))
+
+	h.Fields = picker.HighlightFields{{Field: "missing"}}
+	err = h.ValidateMappings(mappings)
+	assert.True(errors.Is(err, picker.ErrFieldNotFound))
+}
+
+func TestHighlightHits(t *testing.T) {
+	assert := require.New(t)
+	data := []byte(`{
+		"took": 2,
+		"timed_out": false,
+		"_shards": { "total": 1, "successful": 1, "skipped": 0, "failed": 0 },
+		"hits": {
+			"total": { "value": 1, "relation": "eq" },
+			"max_score": 1.6,
+			"hits": [
+				{
+					"_index": "my-index",
+					"_id": "1",
+					"_score": 1.6,
+					"_source": { "comment": "foo bar baz" },
+					"highlight": { "comment": ["<b>foo</b> <b>bar</b> baz"] }
+				}
+			]
+		}
+	}`)
+	var res picker.SearchResponse
+	err := json.Unmarshal(data, &res)
+	assert.NoError(err)
+	assert.Equal([]string{"<b>foo</b> <b>bar</b> baz"}, res.Hits.Hits[0].Highlight["comment"])
+}
@@ -0,0 +1,290 @@
+package picker
+
+import (
+	"github.com/chanced/dynamic"
+)
+
+// HistogramBounds are the min and max bounds of a histogram or date_histogram
+// aggregation. For date_histogram, Min and Max may be dates or date math
+// expressions.
+//easyjson:json
+type HistogramBounds struct {
+	Min interface{} `json:"min,omitempty"`
+	Max interface{} `json:"max,omitempty"`
+}
+
+// HistogramAggParams creates a HistogramAgg, a multi-bucket values source based
+// aggregation that can be applied on numeric values or numeric range values
+// extracted from the documents. It dynamically builds fixed size (a.k.a.
+// interval) buckets over the values.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-histogram-aggregation.html
+type HistogramAggParams struct {
+	// The field to aggregate on. Either Field or Script is required.
+	Field   string
+	Script  *Script
+	Missing interface{}
+	Format  string
+	// The size of each bucket. Must be a positive decimal. (Required)
+	Interval interface{}
+	// The minimum doc_count a bucket must have to be returned. Defaults to 0.
+	MinDocCount interface{}
+	// Shifts the bucket boundaries. Must be a decimal greater than or equal to
+	// 0 and less than interval.
+	Offset interface{}
+	// Forces the histogram to start building buckets at Min and keep building
+	// buckets up to Max, even if there are no documents.
+	ExtendedBounds *HistogramBounds
+	// Limits the range of buckets in the histogram.
+	HardBounds *HistogramBounds
+	// The order of the buckets. Defaults to ascending _key.
+	Order BucketOrder
+	// If true, buckets are returned as a hash rather than an array, keyed by
+	// the bucket key.
+	Keyed bool
+	// Sub-aggregations
+	Aggregations Aggs
+	Meta         map[string]interface{}
+}
+
+func (HistogramAggParams) Kind() AggKind {
+	return AggKindHistogram
+}
+
+func (p HistogramAggParams) Clause() (AggClause, error) {
+	return p.Histogram()
+}
+
+func (p HistogramAggParams) Histogram() (*HistogramAgg, error) {
+	a := &HistogramAgg{}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	err := a.checkValueSource()
+	if err != nil {
+		return a, newAggError(err, AggKindHistogram)
+	}
+	a.SetMissing(p.Missing)
+	a.SetFormat(p.Format)
+	err = a.SetInterval(p.Interval)
+	if err != nil {
+		return a, newAggError(err, AggKindHistogram)
+	}
+	err = a.SetMinDocCount(p.MinDocCount)
+	if err != nil {
+		return a, newAggError(err, AggKindHistogram)
+	}
+	err = a.SetOffset(p.Offset)
+	if err != nil {
+		return a, newAggError(err, AggKindHistogram)
+	}
+	a.SetExtendedBounds(p.ExtendedBounds)
+	a.SetHardBounds(p.HardBounds)
+	err = a.SetOrder(p.Order)
+	if err != nil {
+		return a, newAggError(err, AggKindHistogram)
+	}
+	a.SetKeyed(p.Keyed)
+	err = a.SetAggregations(p.Aggregations)
+	if err != nil {
+		return a, newAggError(err, AggKindHistogram)
+	}
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// HistogramAgg is a multi-bucket values source based aggregation that can be
+// applied on numeric values or numeric range values extracted from the
+// documents. It dynamically builds fixed size (a.k.a. interval) buckets over
+// the values.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-histogram-aggregation.html
+type HistogramAgg struct {
+	valueSourceParam
+	interval       dynamic.Number
+	minDocCount    dynamic.Number
+	offset         dynamic.Number
+	extendedBounds *HistogramBounds
+	hardBounds     *HistogramBounds
+	order          BucketOrder
+	keyed          bool
+	aggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*HistogramAgg)(nil)
+
+func (HistogramAgg) Kind() AggKind {
+	return AggKindHistogram
+}
+
+func (a *HistogramAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// Interval is the size of each bucket
+func (a HistogramAgg) Interval() float64 {
+	if f, ok := a.interval.Float64(); ok {
+		return f
+	}
+	return 0
+}
+
+// SetInterval sets the interval to v. v must be a number greater than 0.
+func (a *HistogramAgg) SetInterval(v interface{}) error {
+	n, err := dynamic.NewNumber(v)
+	if err != nil {
+		return err
+	}
+	f, ok := n.Float64()
+	if !ok {
+		return ErrIntervalRequired
+	}
+	if f <= 0 {
+		return ErrInvalidInterval
+	}
+	a.interval = n
+	return nil
+}
+
+// MinDocCount is the minimum doc_count a bucket must have to be returned.
+// Defaults to 0.
+func (a HistogramAgg) MinDocCount() int {
+	if i, ok := a.minDocCount.Int(); ok {
+		return i
+	}
+	return 0
+}
+
+// SetMinDocCount sets min_doc_count to v
+func (a *HistogramAgg) SetMinDocCount(v interface{}) error {
+	return a.minDocCount.Set(v)
+}
+
+// Offset shifts the bucket boundaries
+func (a HistogramAgg) Offset() float64 {
+	if f, ok := a.offset.Float64(); ok {
+		return f
+	}
+	return 0
+}
+
+// SetOffset sets the offset to v
+func (a *HistogramAgg) SetOffset(v interface{}) error {
+	return a.offset.Set(v)
+}
+
+// ExtendedBounds forces the histogram to start building buckets on a specific
+// min value and keep building buckets up to a max value, even if there are no
+// documents.
+func (a HistogramAgg) ExtendedBounds() *HistogramBounds {
+	return a.extendedBounds
+}
+
+// SetExtendedBounds sets extended_bounds to v
+func (a *HistogramAgg) SetExtendedBounds(v *HistogramBounds) {
+	a.extendedBounds = v
+}
+
+// HardBounds limits the range of buckets in the histogram.
+func (a HistogramAgg) HardBounds() *HistogramBounds {
+	return a.hardBounds
+}
+
+// SetHardBounds sets hard_bounds to v
+func (a *HistogramAgg) SetHardBounds(v *HistogramBounds) {
+	a.hardBounds = v
+}
+
+// Order of the buckets. Defaults to ascending _key.
+func (a HistogramAgg) Order() BucketOrder {
+	return a.order
+}
+
+// SetOrder sets the order of the buckets to v
+func (a *HistogramAgg) SetOrder(v BucketOrder) error {
+	err := v.Validate()
+	if err != nil {
+		return err
+	}
+	a.order = v
+	return nil
+}
+
+// Keyed indicates whether buckets are returned as a hash rather than an array
+func (a HistogramAgg) Keyed() bool {
+	return a.keyed
+}
+
+// SetKeyed sets keyed to v
+func (a *HistogramAgg) SetKeyed(v bool) {
+	a.keyed = v
+}
+
+func (a HistogramAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a HistogramAgg) MarshalJSON() ([]byte, error) {
+	return histogramAgg{
+		Field:          a.field,
+		Script:         a.script,
+		Missing:        a.missing,
+		Format:         a.format,
+		Interval:       a.interval.Value(),
+		MinDocCount:    a.minDocCount.Value(),
+		Offset:         a.offset.Value(),
+		ExtendedBounds: a.extendedBounds,
+		HardBounds:     a.hardBounds,
+		Order:          a.order,
+		Keyed:          a.keyed,
+	}.MarshalJSON()
+}
+
+func (a *HistogramAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *HistogramAgg) UnmarshalJSON(data []byte) error {
+	*a = HistogramAgg{}
+	p := histogramAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	a.SetMissing(p.Missing)
+	a.SetFormat(p.Format)
+	err = a.interval.Set(p.Interval)
+	if err != nil {
+		return err
+	}
+	err = a.SetMinDocCount(p.MinDocCount)
+	if err != nil {
+		return err
+	}
+	err = a.SetOffset(p.Offset)
+	if err != nil {
+		return err
+	}
+	a.SetExtendedBounds(p.ExtendedBounds)
+	a.SetHardBounds(p.HardBounds)
+	a.order = p.Order
+	a.SetKeyed(p.Keyed)
+	return nil
+}
+
+//easyjson:json
+type histogramAgg struct {
+	Field          string           `json:"field,omitempty"`
+	Script         *Script          `json:"script,omitempty"`
+	Missing        interface{}      `json:"missing,omitempty"`
+	Format         string           `json:"format,omitempty"`
+	Interval       interface{}      `json:"interval"`
+	MinDocCount    interface{}      `json:"min_doc_count,omitempty"`
+	Offset         interface{}      `json:"offset,omitempty"`
+	ExtendedBounds *HistogramBounds `json:"extended_bounds,omitempty"`
+	HardBounds     *HistogramBounds `json:"hard_bounds,omitempty"`
+	Order          BucketOrder      `json:"order,omitempty"`
+	Keyed          bool             `json:"keyed,omitempty"`
+}
@@ -0,0 +1,205 @@
+package picker
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/chanced/dynamic"
+)
+
+// HitsTotalRelation indicates whether the value of HitsTotal is accurate or a
+// lower bound
+type HitsTotalRelation string
+
+const (
+	// HitsTotalRelationEq indicates the total is accurate
+	HitsTotalRelationEq HitsTotalRelation = "eq"
+	// HitsTotalRelationGte indicates the total is a lower bound
+	HitsTotalRelationGte HitsTotalRelation = "gte"
+)
+
+// HitsTotal is the total number of documents matching a search
+type HitsTotal struct {
+	Value    int64             `json:"value"`
+	Relation HitsTotalRelation `json:"relation"`
+}
+
+func (t HitsTotal) MarshalJSON() ([]byte, error) {
+	return hitsTotal(t).MarshalJSON()
+}
+
+// UnmarshalJSON decodes either an object with value and relation or, for
+// responses of older versions of Elasticsearch, a number.
+func (t *HitsTotal) UnmarshalJSON(data []byte) error {
+	*t = HitsTotal{}
+	if dynamic.JSON(data).IsNull() {
+		return nil
+	}
+	if !dynamic.JSON(data).IsObject() {
+		t.Relation = HitsTotalRelationEq
+		return json.Unmarshal(data, &t.Value)
+	}
+	var p hitsTotal
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	*t = HitsTotal(p)
+	return nil
+}
+
+//easyjson:json
+type hitsTotal struct {
+	Value    int64             `json:"value"`
+	Relation HitsTotalRelation `json:"relation"`
+}
+
+// Hits are the matching documents of a search response
+//
+//easyjson:json
+type Hits struct {
+	// Total is nil if track_total_hits was false
+	Total    *HitsTotal `json:"total,omitempty"`
+	MaxScore *float64   `json:"max_score"`
+	Hits     []Hit      `json:"hits"`
+}
+
+// Len returns the number of hits
+func (h Hits) Len() int {
+	return len(h.Hits)
+}
+
+// DecodeSources decodes the _source of each hit into v, which must be a
+// pointer to a slice. The elements of the slice can be either values or
+// pointers.
+//
+//  var movies []Movie
+//  err := res.Hits.DecodeSources(&movies)
+func (h Hits) DecodeSources(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("%w; expected a pointer to a slice, received <%T>", ErrInvalidSourceTarget, v)
+	}
+	slice := rv.Elem()
+	et := slice.Type().Elem()
+	res := reflect.MakeSlice(slice.Type(), 0, len(h.Hits))
+	for _, hit := range h.Hits {
+		var ev reflect.Value
+		if et.Kind() == reflect.Ptr {
+			ev = reflect.New(et.Elem())
+		} else {
+			ev = reflect.New(et)
+		}
+		err := hit.DecodeSource(ev.Interface())
+		if err != nil {
+			return err
+		}
+		if et.Kind() != reflect.Ptr {
+			ev = ev.Elem()
+		}
+		res = reflect.Append(res, ev)
+	}
+	slice.Set(res)
+	return nil
+}
+
+// Hit is a document matching a search
+//
+//easyjson:json
+type Hit struct {
+	// Name of the index containing the document
+	Index string `json:"_index"`
+	// Unique identifier of the document
+	ID string `json:"_id"`
+	// Relevance of the document. nil if the hit was sorted on a field other
+	// than _score and track_scores was not set.
+	Score *float64 `json:"_score"`
+	// Original JSON body passed for the document at index time. Use
+	// DecodeSource to decode it.
+	Source dynamic.JSON `json:"_source,omitempty"`
+	// Values of the requested fields, keyed by field name. Each value is an
+	// array.
+	Fields map[string]dynamic.JSON `json:"fields,omitempty"`
+	// Sort values of the hit, used with search_after
+	Sort SortValues `json:"sort,omitempty"`
+	// Highlighted fragments, keyed by field name
+	Highlight map[string][]string `json:"highlight,omitempty"`
+	// Results of inner_hits, keyed by name
+	InnerHits map[string]InnerHitsResult `json:"inner_hits,omitempty"`
+	// Sequence number of the document, if seq_no_primary_term was requested
+	SeqNo *int64 `json:"_seq_no,omitempty"`
+	// Primary term of the document, if seq_no_primary_term was requested
+	PrimaryTerm *int64 `json:"_primary_term,omitempty"`
+	// Version of the document, if version was requested
+	Version *int64 `json:"_version,omitempty"`
+	Routing string `json:"_routing,omitempty"`
+	// Names of the named queries the hit matched
+	MatchedQueries []string `json:"matched_queries,omitempty"`
+	// Identifies the nested object of a nested inner hit
+	Nested *HitNested `json:"_nested,omitempty"`
+	// Explanation of the score computation, if explain was requested
+	Explanation dynamic.JSON `json:"_explanation,omitempty"`
+	// Fields which were ignored at index time
+	Ignored []string `json:"_ignored,omitempty"`
+}
+
+// DecodeSource decodes the _source of h into v
+func (h Hit) DecodeSource(v interface{}) error {
+	if len(h.Source) == 0 || h.Source.IsNull() {
+		return fmt.Errorf("%w <%s>", ErrSourceMissing, h.ID)
+	}
+	return json.Unmarshal(h.Source, v)
+}
+
+// DecodeField decodes the values of the field with the given name into v,
+// which should be a slice
+func (h Hit) DecodeField(name string, v interface{}) error {
+	d, ok := h.Fields[name]
+	if !ok {
+		return fmt.Errorf("%w <%s>", ErrFieldNotFound, name)
+	}
+	return json.Unmarshal(d, v)
+}
+
+// SortValues are the sort values of a hit, which can be passed as the
+// search_after of a subsequent search. Numbers are decoded as json.Number so
+// that long values, such as those of _shard_doc, retain their precision.
+type SortValues []interface{}
+
+func (sv *SortValues) UnmarshalBSON(data []byte) error {
+	return sv.UnmarshalJSON(data)
+}
+
+func (sv *SortValues) UnmarshalJSON(data []byte) error {
+	*sv = nil
+	if dynamic.JSON(data).IsNull() {
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v []interface{}
+	err := dec.Decode(&v)
+	if err != nil {
+		return err
+	}
+	*sv = v
+	return nil
+}
+
+// HitNested identifies the nested object of an inner hit
+//
+//easyjson:json
+type HitNested struct {
+	Field  string     `json:"field"`
+	Offset int        `json:"offset"`
+	Nested *HitNested `json:"_nested,omitempty"`
+}
+
+// InnerHitsResult contains the hits of an inner_hits definition
+//
+//easyjson:json
+type InnerHitsResult struct {
+	Hits Hits `json:"hits"`
+}
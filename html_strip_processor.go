@@ -0,0 +1,127 @@
+package picker
+
+// HTMLStripProcessorParams creates a HTMLStripProcessor, which removes HTML
+// tags from the field. If the field is an array of strings, HTML tags will be
+// removed from all members of the array.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/htmlstrip-processor.html
+type HTMLStripProcessorParams struct {
+	// Field is the string-valued field to remove HTML tags from
+	Field string
+	// TargetField is the field to assign the output to. By default, field is
+	// updated in-place.
+	TargetField string
+	// If IgnoreMissing is true and field does not exist or is null, the
+	// processor quietly exits without modifying the document
+	IgnoreMissing bool
+	// Description of the processor. Useful for describing the purpose of the
+	// processor or its configuration.
+	Description string
+	// Conditionally execute the processor
+	If string
+	// Ignore failures for the processor
+	IgnoreFailure bool
+	// Handle failures for the processor
+	OnFailure Procs
+	// Identifier for the processor. Useful for debugging and metrics.
+	Tag string
+}
+
+func (HTMLStripProcessorParams) Kind() ProcessorKind {
+	return ProcessorKindHTMLStrip
+}
+
+func (p HTMLStripProcessorParams) Processor() (Processor, error) {
+	return p.HTMLStrip()
+}
+
+func (p HTMLStripProcessorParams) HTMLStrip() (*HTMLStripProcessor, error) {
+	proc := &HTMLStripProcessor{}
+	err := proc.SetField(p.Field)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindHTMLStrip)
+	}
+	proc.SetTargetField(p.TargetField)
+	proc.SetIgnoreMissing(p.IgnoreMissing)
+	proc.SetDescription(p.Description)
+	proc.SetIf(p.If)
+	proc.SetIgnoreFailure(p.IgnoreFailure)
+	err = proc.SetOnFailure(p.OnFailure)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindHTMLStrip)
+	}
+	proc.SetTag(p.Tag)
+	return proc, nil
+}
+
+// HTMLStripProcessor removes HTML tags from the field. If the field is an array
+// of strings, HTML tags will be removed from all members of the array.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/htmlstrip-processor.html
+type HTMLStripProcessor struct {
+	fieldParam
+	targetFieldParam
+	ignoreMissingParam
+	processorParams
+}
+
+var _ Processor = (*HTMLStripProcessor)(nil)
+
+func (HTMLStripProcessor) Kind() ProcessorKind {
+	return ProcessorKindHTMLStrip
+}
+
+func (p *HTMLStripProcessor) Processor() (Processor, error) {
+	return p, nil
+}
+
+func (p HTMLStripProcessor) MarshalBSON() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+func (p HTMLStripProcessor) MarshalJSON() ([]byte, error) {
+	return htmlStripProcessor{
+		Field:         p.field,
+		TargetField:   p.targetField,
+		IgnoreMissing: p.ignoreMissing,
+		Description:   p.description,
+		If:            p.ifCond,
+		IgnoreFailure: p.ignoreFailure,
+		OnFailure:     p.onFailure,
+		Tag:           p.tag,
+	}.MarshalJSON()
+}
+
+func (p *HTMLStripProcessor) UnmarshalBSON(data []byte) error {
+	return p.UnmarshalJSON(data)
+}
+
+func (p *HTMLStripProcessor) UnmarshalJSON(data []byte) error {
+	*p = HTMLStripProcessor{}
+	var v htmlStripProcessor
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	p.field = v.Field
+	p.targetField = v.TargetField
+	p.ignoreMissing = v.IgnoreMissing
+	p.description = v.Description
+	p.ifCond = v.If
+	p.ignoreFailure = v.IgnoreFailure
+	p.onFailure = v.OnFailure
+	p.tag = v.Tag
+	return nil
+}
+
+//easyjson:json
+type htmlStripProcessor struct {
+	Field         string     `json:"field,omitempty"`
+	TargetField   string     `json:"target_field,omitempty"`
+	IgnoreMissing bool       `json:"ignore_missing,omitempty"`
+	Description   string     `json:"description,omitempty"`
+	If            string     `json:"if,omitempty"`
+	IgnoreFailure bool       `json:"ignore_failure,omitempty"`
+	OnFailure     Processors `json:"on_failure,omitempty"`
+	Tag           string     `json:"tag,omitempty"`
+}
@@ -0,0 +1,19 @@
+package picker
+
+// ignoreMissingParam is a mixin for processors which can skip documents that
+// do not have the input field
+type ignoreMissingParam struct {
+	ignoreMissing bool
+}
+
+// IgnoreMissing indicates whether documents which do not contain the field,
+// or in which the field is null, are skipped. If false (default), the
+// processor fails on such documents.
+func (i ignoreMissingParam) IgnoreMissing() bool {
+	return i.ignoreMissing
+}
+
+// SetIgnoreMissing sets ignore_missing to v
+func (i *ignoreMissingParam) SetIgnoreMissing(v bool) {
+	i.ignoreMissing = v
+}
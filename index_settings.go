@@ -0,0 +1,1494 @@
+package picker
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chanced/dynamic"
+)
+
+// IndexSettingsParams are the params used to create IndexSettings. Each
+// setting is optional; those which are not set are left to their defaults.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/index-modules.html#index-modules-settings
+type IndexSettingsParams struct {
+	// The number of primary shards that an index should have. Defaults to 1.
+	// This setting can only be set at index creation time. It cannot be changed
+	// on a closed index.
+	NumberOfShards interface{}
+
+	// Number of routing shards used to split an index.
+	//
+	// This setting’s default value depends on the number of primary shards in
+	// the index. The default is designed to allow you to split by factors of 2
+	// up to a maximum of 1024 shards.
+	//
+	// In Elasticsearch 7.0.0 and later versions, this setting affects how
+	// documents are distributed across shards. When reindexing an older index
+	// with custom routing, you must explicitly set
+	// index.number_of_routing_shards to maintain the same document
+	// distribution.
+	NumberOfRoutingShards interface{}
+
+	// Whether or not shards should be checked for corruption before opening.
+	// When corruption is detected, it will prevent the shard from being opened.
+	// Accepts:
+	//
+	//  "false"
+	// (default) Don’t check for corruption when opening a shard.
+	//  "checksum"
+	// Check for physical corruption.
+	//  "true"
+	// Check for both physical and logical corruption. This is much more
+	// expensive in terms of CPU and memory usage.
+	ShardCheckOnStartup string
+
+	// The default value compresses stored data with LZ4 compression, but this
+	// can be set to best_compression which uses DEFLATE for a higher
+	// compression ratio, at the expense of slower stored fields performance. If
+	// you are updating the compression type, the new one will be applied after
+	// segments are merged. Segment merging can be forced using force merge.
+	Codec string
+
+	// The number of shards a custom routing value can go to. Defaults to 1 and
+	// can only be set at index creation time. This value must be less than the
+	// index.number_of_shards unless the index.number_of_shards value is also 1.
+	// See Routing to an index partition for more details about how this setting
+	// is used.
+	RoutingPartitionSize interface{}
+
+	// Indicates whether soft deletes are enabled on the index. Soft deletes can
+	// only be configured at index creation and only on indices created on or
+	// after Elasticsearch 6.5.0. Defaults to true.
+	//
+	// Deprecated
+	SoftDeletesEnabled interface{}
+
+	// The maximum period to retain a shard history retention lease before it is
+	// considered expired. Shard history retention leases ensure that soft
+	// deletes are retained during merges on the Lucene index. If a soft delete
+	// is merged away before it can be replicated to a follower the following
+	// process will fail due to incomplete history on the leader. Defaults to
+	// 12h.
+	SoftDeletesRetentionLeasePeriod string
+
+	// Indicates whether cached filters are pre-loaded for nested queries.
+	// Possible values are true (default) and false.
+	LoadFixedBitsetFiltersEagerly interface{}
+
+	// Indicates whether the index should be hidden by default. Hidden indices
+	// are not returned by default when using a wildcard expression. This
+	// behavior is controlled per request through the use of the
+	// expand_wildcards parameter. Possible values are true and false (default).
+	Hidden interface{}
+
+	// The number of replicas each primary shard has. Defaults to 1.
+	NumberOfReplicas interface{}
+
+	// Auto-expand the number of replicas based on the number of data nodes in
+	// the cluster. Set to a dash delimited lower and upper bound (e.g. 0-5) or
+	// use all for the upper bound (e.g. 0-all). Defaults to false (i.e.
+	// disabled). Note that the auto-expanded number of replicas only takes
+	// allocation filtering rules into account, but ignores any other allocation
+	// rules such as shard allocation awareness and total shards per node, and
+	// this can lead to the cluster health becoming YELLOW if the applicable
+	// rules prevent all the replicas from being allocated.
+	AutoExpandReplicas interface{}
+
+	// How long a shard can not receive a search or get request until it’s
+	// considered search idle. (default is 30s)
+	SearchIdleAfter string
+
+	// How often to perform a refresh operation, which makes recent changes to
+	// the index visible to search. Defaults to 1s. Can be set to -1 to disable
+	// refresh. If this setting is not explicitly set, shards that haven’t seen
+	// search traffic for at least index.search.idle.after seconds will not
+	// receive background refreshes until they receive a search request.
+	// Searches that hit an idle shard where a refresh is pending will wait for
+	// the next background refresh (within 1s). This behavior aims to
+	// automatically optimize bulk indexing in the default case when no searches
+	// are performed. In order to opt out of this behavior an explicit value of
+	// 1s should set as the refresh interval.
+	RefreshInterval string
+
+	// The maximum value of from + size for searches to this index. Defaults to
+	// 10000. Search requests take heap memory and time proportional to from +
+	// size and this limits that memory.
+	MaxResultWindow interface{}
+
+	// The maximum value of from + size for inner hits definition and top hits
+	// aggregations to this index. Defaults to 100. Inner hits and top hits
+	// aggregation take heap memory and time proportional to from + size and
+	// this limits that memory.
+	MaxInnerResultWindow interface{}
+
+	// The maximum value of window_size for rescore requests in searches of this
+	// index. Defaults to index.max_result_window which defaults to 10000.
+	// Search requests take heap memory and time proportional to
+	// max(window_size, from + size) and this limits that memory.
+	MaxRescoreWindow interface{}
+
+	// The maximum number of docvalue_fields that are allowed in a query.
+	// Defaults to 100. Doc-value fields are costly since they might incur a
+	// per-field per-document seek.
+	MaxDocvalueFieldsSearch interface{}
+
+	// The maximum number of script_fields that are allowed in a query. Defaults
+	// to 32.
+	MaxScriptFields interface{}
+
+	// The maximum allowed difference between min_gram and max_gram for
+	// NGramTokenizer and NGramTokenFilter. Defaults to 1.
+	MaxNgramDiff interface{}
+
+	// The maximum allowed difference between max_shingle_size and
+	// min_shingle_size for the shingle token filter. Defaults to 3.
+	MaxShingleDiff interface{}
+
+	// Maximum number of refresh listeners available on each shard of the index.
+	// These listeners are used to implement refresh=wait_for.
+	MaxRefreshListeners interface{}
+
+	// The maximum number of tokens that can be produced using _analyze API.
+	// Defaults to 10000.
+	AnalyzeMaxTokenCount interface{}
+
+	// The maximum number of characters that will be analyzed for a highlight
+	// request. This setting is only applicable when highlighting is requested
+	// on a text that was indexed without offsets or term vectors. Defaults to
+	// 1000000.
+	HighlightMaxAnalyzedOffset interface{}
+
+	// The maximum number of terms that can be used in Terms Query. Defaults to
+	// 65536.
+	MaxTermsCount interface{}
+
+	// The maximum length of regex that can be used in Regexp Query. Defaults to
+	// 1000.
+	MaxRegexLength interface{}
+
+	// (string or array of strings) Wildcard (*) patterns matching one or more
+	// fields. The following query types search these matching fields by
+	// default:
+	//
+	// - More like this
+	//
+	// - Multi-match
+	//
+	// - Query string
+	//
+	// - Simple query string
+	//
+	// Defaults to *, which matches all fields eligible for term-level queries,
+	// excluding metadata fields.
+	QueryDefaultField interface{}
+
+	// Controls shard allocation for this index. It can be set to:
+	//
+	//  "all" // (default) Allows shard allocation for all shards.
+	//  "primaries" // Allows shard allocation only for primary shards.
+	//  "new_primaries" // Allows shard allocation only for newly-created primary shards.
+	//  "none" // No shard allocation is allowed.
+	RoutingAllocationEnable string
+
+	// Enables shard rebalancing for this index. It can be set to:
+	//
+	//  "all" // (default) - Allows shard rebalancing for all shards.
+	//  "primaries" // Allows shard rebalancing only for primary shards.
+	//  "replicas" // Allows shard rebalancing only for replica shards.
+	//  "none" // No shard rebalancing is allowed.
+	RoutingRebalanceEnable string
+
+	// The length of time that a deleted document’s version number remains
+	// available for further versioned operations. Defaults to 60s.
+	GCDeletes interface{}
+
+	// The default ingest node pipeline for this index. Index requests will fail
+	// if the default pipeline is set and the pipeline does not exist. The
+	// default may be overridden using the pipeline parameter. The special
+	// pipeline name _none indicates no ingest pipeline should be run.
+	DefaultPipeline string
+
+	// The final ingest node pipeline for this index. Index requests will fail
+	// if the final pipeline is set and the pipeline does not exist. The final
+	// pipeline always runs after the request pipeline (if specified) and the
+	// default pipeline (if it exists). The special pipeline name _none
+	// indicates no ingest pipeline will run.
+	FinalPipeline string
+
+	// The maximum number of fields in an index. Defaults to 1000.
+	MappingTotalFieldsLimit interface{}
+
+	// The maximum depth for a field, which is measured as the number of inner
+	// objects. Defaults to 20.
+	MappingDepthLimit interface{}
+
+	// The maximum number of distinct nested mappings in an index. Defaults to
+	// 50.
+	MappingNestedFieldsLimit interface{}
+
+	// The maximum number of nested JSON objects that a single document can
+	// contain across all nested types. Defaults to 10000.
+	MappingNestedObjectsLimit interface{}
+
+	// The maximum number of slices allowed per scroll request. Defaults to
+	// 1024.
+	MaxSlicesPerScroll interface{}
+
+	// The priority of the index for recovery after a node restart. Indices
+	// with a higher priority are recovered first.
+	Priority interface{}
+
+	// The number of shard copies that must be active before write operations
+	// proceed. Set to "all" or a positive integer up to the total number of
+	// shard copies (number_of_replicas + 1). Defaults to 1.
+	WriteWaitForActiveShards interface{}
+
+	// Whether or not to fsync and commit the translog after every index,
+	// delete, update, or bulk request. Either "request" (default) or "async".
+	TranslogDurability string
+
+	// How often the translog is fsynced to disk and committed, regardless of
+	// write operations. Defaults to 5s.
+	TranslogSyncInterval string
+
+	// The maximum total size of the translog before a flush is triggered.
+	// Defaults to 512mb.
+	TranslogFlushThresholdSize string
+
+	// Configures the sorting of segments within each shard. Index sorting can
+	// only be configured at index creation.
+	//
+	// https://www.elastic.co/guide/en/elasticsearch/reference/current/index-modules-index-sorting.html
+	Sort *IndexSort
+
+	// Blocks operations on the index
+	//
+	// https://www.elastic.co/guide/en/elasticsearch/reference/current/index-modules-blocks.html
+	Blocks *IndexBlocks
+
+	// The analyzers, normalizers, tokenizers, token filters, and character
+	// filters of the index
+	//
+	// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis.html
+	Analysis *Analysis
+
+	// Settings which are not otherwise covered, such as those of plugins.
+	// Keys may be flattened (e.g. "index.lifecycle.name") or nested
+	// (e.g. {"lifecycle": {"name": "my_policy"}}). Typed params take
+	// precedence.
+	Settings map[string]interface{}
+}
+
+// IndexSettings returns a new IndexSettings from p
+func (p IndexSettingsParams) IndexSettings() (*IndexSettings, error) {
+	s := &IndexSettings{}
+	for k, v := range p.Settings {
+		err := s.Set(k, v)
+		if err != nil {
+			return s, err
+		}
+	}
+	params := []struct {
+		key   string
+		value interface{}
+	}{
+		{"number_of_shards", p.NumberOfShards},
+		{"number_of_routing_shards", p.NumberOfRoutingShards},
+		{"shard.check_on_startup", p.ShardCheckOnStartup},
+		{"codec", p.Codec},
+		{"routing_partition_size", p.RoutingPartitionSize},
+		{"soft_deletes.enabled", p.SoftDeletesEnabled},
+		{"soft_deletes.retention_lease.period", p.SoftDeletesRetentionLeasePeriod},
+		{"load_fixed_bitset_filters_eagerly", p.LoadFixedBitsetFiltersEagerly},
+		{"hidden", p.Hidden},
+		{"number_of_replicas", p.NumberOfReplicas},
+		{"auto_expand_replicas", p.AutoExpandReplicas},
+		{"search.idle.after", p.SearchIdleAfter},
+		{"refresh_interval", p.RefreshInterval},
+		{"max_result_window", p.MaxResultWindow},
+		{"max_inner_result_window", p.MaxInnerResultWindow},
+		{"max_rescore_window", p.MaxRescoreWindow},
+		{"max_docvalue_fields_search", p.MaxDocvalueFieldsSearch},
+		{"max_script_fields", p.MaxScriptFields},
+		{"max_ngram_diff", p.MaxNgramDiff},
+		{"max_shingle_diff", p.MaxShingleDiff},
+		{"max_refresh_listeners", p.MaxRefreshListeners},
+		{"analyze.max_token_count", p.AnalyzeMaxTokenCount},
+		{"highlight.max_analyzed_offset", p.HighlightMaxAnalyzedOffset},
+		{"max_terms_count", p.MaxTermsCount},
+		{"max_regex_length", p.MaxRegexLength},
+		{"query.default_field", p.QueryDefaultField},
+		{"routing.allocation.enable", p.RoutingAllocationEnable},
+		{"routing.rebalance.enable", p.RoutingRebalanceEnable},
+		{"gc_deletes", p.GCDeletes},
+		{"default_pipeline", p.DefaultPipeline},
+		{"final_pipeline", p.FinalPipeline},
+		{"mapping.total_fields.limit", p.MappingTotalFieldsLimit},
+		{"mapping.depth.limit", p.MappingDepthLimit},
+		{"mapping.nested_fields.limit", p.MappingNestedFieldsLimit},
+		{"mapping.nested_objects.limit", p.MappingNestedObjectsLimit},
+		{"max_slices_per_scroll", p.MaxSlicesPerScroll},
+		{"priority", p.Priority},
+		{"write.wait_for_active_shards", p.WriteWaitForActiveShards},
+		{"translog.durability", p.TranslogDurability},
+		{"translog.sync_interval", p.TranslogSyncInterval},
+		{"translog.flush_threshold_size", p.TranslogFlushThresholdSize},
+	}
+	for _, param := range params {
+		if param.value == nil || param.value == "" {
+			continue
+		}
+		err := s.Set(param.key, param.value)
+		if err != nil {
+			return s, err
+		}
+	}
+	if p.Sort != nil {
+		err := s.SetSort(*p.Sort)
+		if err != nil {
+			return s, err
+		}
+	}
+	if p.Blocks != nil {
+		err := s.SetBlocks(*p.Blocks)
+		if err != nil {
+			return s, err
+		}
+	}
+	if p.Analysis != nil {
+		err := s.SetAnalysis(p.Analysis)
+		if err != nil {
+			return s, err
+		}
+	}
+	return s, nil
+}
+
+// NewIndexSettings returns a new IndexSettings from p
+func NewIndexSettings(p IndexSettingsParams) (*IndexSettings, error) {
+	return p.IndexSettings()
+}
+
+// IndexSettings are the settings of an index.
+//
+// Settings are held in their flattened form, without the "index." prefix,
+// (e.g. "routing.allocation.enable") which is how Elasticsearch treats them.
+// Both the flattened and nested forms are accepted when unmarshaling and
+// settings are marshaled in the nested form under "index", matching the
+// output of the get index settings API. Settings which do not have a typed
+// accessor, such as those of plugins, are available through Get and Set and
+// are kept as-is.
+//
+// The analysis settings are the exception; they are held nested under
+// "analysis" as the names of analyzers, tokenizers, and filters may contain
+// '.'.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/index-modules.html
+type IndexSettings struct {
+	settings map[string]dynamic.JSON
+	analysis map[string]interface{}
+}
+
+// Get returns the raw value of the setting with the given key, which may be
+// prefixed with "index."
+func (s IndexSettings) Get(key string) (dynamic.JSON, bool) {
+	key = normalizeIndexSettingKey(key)
+	if isAnalysisSettingKey(key) {
+		return s.getAnalysis(key)
+	}
+	v, ok := s.settings[key]
+	return v, ok
+}
+
+// Set sets the setting with the given key, which may be prefixed with
+// "index.", to v. If v is an object, each of its settings is set relative to
+// key. Settings which have a typed accessor are validated.
+func (s *IndexSettings) Set(key string, v interface{}) error {
+	d, err := json.Marshal(v)
+	if err != nil {
+		return newFieldError(err, key)
+	}
+	flat := map[string]dynamic.JSON{}
+	err = flattenSettings(key, d, flat)
+	if err != nil {
+		return newFieldError(err, key)
+	}
+	return s.setFlat(flat)
+}
+
+// Delete removes the setting with the given key, which may be prefixed with
+// "index.". Nested settings under key are removed as well.
+func (s *IndexSettings) Delete(key string) {
+	key = normalizeIndexSettingKey(key)
+	if key == "analysis" || len(key) == 0 {
+		s.analysis = nil
+	} else if isAnalysisSettingKey(key) {
+		deleteNestedSetting(s.analysis, strings.Split(strings.TrimPrefix(key, "analysis."), "."))
+	}
+	for k := range s.settings {
+		if k == key || strings.HasPrefix(k, key+".") {
+			delete(s.settings, k)
+		}
+	}
+}
+
+// Map returns a copy of the settings in their flattened form, keyed without
+// the "index." prefix. The analysis settings are under "analysis" in their
+// nested form.
+func (s IndexSettings) Map() map[string]dynamic.JSON {
+	res := make(map[string]dynamic.JSON, len(s.settings)+1)
+	for k, v := range s.settings {
+		res[k] = v
+	}
+	if v, ok := s.getAnalysis("analysis"); ok {
+		res["analysis"] = v
+	}
+	return res
+}
+
+// Len returns the number of flattened settings, counting the analysis
+// settings as one
+func (s *IndexSettings) Len() int {
+	if s == nil {
+		return 0
+	}
+	if len(s.analysis) > 0 {
+		return len(s.settings) + 1
+	}
+	return len(s.settings)
+}
+
+// NumberOfShards is the number of primary shards the index has. Defaults to 1.
+func (s IndexSettings) NumberOfShards() int {
+	return s.intValue("number_of_shards", 1)
+}
+
+// SetNumberOfShards sets number_of_shards to v
+func (s *IndexSettings) SetNumberOfShards(v int) error {
+	return s.Set("number_of_shards", v)
+}
+
+// NumberOfRoutingShards is the number of routing shards used to split an
+// index. It returns 0 if it has not been set.
+func (s IndexSettings) NumberOfRoutingShards() int {
+	return s.intValue("number_of_routing_shards", 0)
+}
+
+// SetNumberOfRoutingShards sets number_of_routing_shards to v
+func (s *IndexSettings) SetNumberOfRoutingShards(v int) error {
+	return s.Set("number_of_routing_shards", v)
+}
+
+// ShardCheckOnStartup is whether shards are checked for corruption before
+// opening; one of "false" (default), "checksum", or "true".
+func (s IndexSettings) ShardCheckOnStartup() string {
+	return s.stringValue("shard.check_on_startup", "false")
+}
+
+// SetShardCheckOnStartup sets shard.check_on_startup to v
+func (s *IndexSettings) SetShardCheckOnStartup(v string) error {
+	return s.Set("shard.check_on_startup", v)
+}
+
+// Codec is the compression of stored fields; either "default" or
+// "best_compression".
+func (s IndexSettings) Codec() string {
+	return s.stringValue("codec", "default")
+}
+
+// SetCodec sets codec to v
+func (s *IndexSettings) SetCodec(v string) error {
+	return s.Set("codec", v)
+}
+
+// RoutingPartitionSize is the number of shards a custom routing value can go
+// to. Defaults to 1.
+func (s IndexSettings) RoutingPartitionSize() int {
+	return s.intValue("routing_partition_size", 1)
+}
+
+// SetRoutingPartitionSize sets routing_partition_size to v
+func (s *IndexSettings) SetRoutingPartitionSize(v int) error {
+	return s.Set("routing_partition_size", v)
+}
+
+// SoftDeletesEnabled indicates whether soft deletes are enabled on the index.
+// Defaults to true.
+func (s IndexSettings) SoftDeletesEnabled() bool {
+	return s.boolValue("soft_deletes.enabled", true)
+}
+
+// SetSoftDeletesEnabled sets soft_deletes.enabled to v
+func (s *IndexSettings) SetSoftDeletesEnabled(v bool) error {
+	return s.Set("soft_deletes.enabled", v)
+}
+
+// SoftDeletesRetentionLeasePeriod is the maximum period to retain a shard
+// history retention lease before it is considered expired. Defaults to 12h.
+func (s IndexSettings) SoftDeletesRetentionLeasePeriod() time.Duration {
+	return s.durationValue("soft_deletes.retention_lease.period", 12*time.Hour)
+}
+
+// SetSoftDeletesRetentionLeasePeriod sets soft_deletes.retention_lease.period
+// to v
+func (s *IndexSettings) SetSoftDeletesRetentionLeasePeriod(v time.Duration) error {
+	return s.Set("soft_deletes.retention_lease.period", formatTimeValue(v))
+}
+
+// LoadFixedBitsetFiltersEagerly indicates whether cached filters are
+// pre-loaded for nested queries. Defaults to true.
+func (s IndexSettings) LoadFixedBitsetFiltersEagerly() bool {
+	return s.boolValue("load_fixed_bitset_filters_eagerly", true)
+}
+
+// SetLoadFixedBitsetFiltersEagerly sets load_fixed_bitset_filters_eagerly to
+// v
+func (s *IndexSettings) SetLoadFixedBitsetFiltersEagerly(v bool) error {
+	return s.Set("load_fixed_bitset_filters_eagerly", v)
+}
+
+// Hidden indicates whether the index is hidden by default. Defaults to false.
+func (s IndexSettings) Hidden() bool {
+	return s.boolValue("hidden", false)
+}
+
+// SetHidden sets hidden to v
+func (s *IndexSettings) SetHidden(v bool) error {
+	return s.Set("hidden", v)
+}
+
+// Sort is the sorting of segments within each shard
+func (s IndexSettings) Sort() IndexSort {
+	return IndexSort{
+		Field:   s.stringsValue("sort.field"),
+		Order:   s.stringsValue("sort.order"),
+		Mode:    s.stringsValue("sort.mode"),
+		Missing: s.stringsValue("sort.missing"),
+	}
+}
+
+// SetSort sets sort.field, sort.order, sort.mode, and sort.missing to v after
+// validating it
+func (s *IndexSettings) SetSort(v IndexSort) error {
+	err := v.Validate()
+	if err != nil {
+		return newFieldError(err, "sort")
+	}
+	s.Delete("sort")
+	for key, values := range map[string][]string{
+		"sort.field":   v.Field,
+		"sort.order":   v.Order,
+		"sort.mode":    v.Mode,
+		"sort.missing": v.Missing,
+	} {
+		if len(values) == 0 {
+			continue
+		}
+		err = s.Set(key, values)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NumberOfReplicas is the number of replicas each primary shard has. Defaults
+// to 1.
+func (s IndexSettings) NumberOfReplicas() int {
+	return s.intValue("number_of_replicas", 1)
+}
+
+// SetNumberOfReplicas sets number_of_replicas to v
+func (s *IndexSettings) SetNumberOfReplicas(v int) error {
+	return s.Set("number_of_replicas", v)
+}
+
+// AutoExpandReplicas auto-expands the number of replicas based on the number
+// of data nodes in the cluster, as a dash delimited lower and upper bound
+// (e.g. "0-5" or "0-all"). Defaults to "false", which disables it.
+func (s IndexSettings) AutoExpandReplicas() string {
+	return s.stringValue("auto_expand_replicas", "false")
+}
+
+// SetAutoExpandReplicas sets auto_expand_replicas to v
+func (s *IndexSettings) SetAutoExpandReplicas(v string) error {
+	return s.Set("auto_expand_replicas", v)
+}
+
+// SearchIdleAfter is how long a shard can not receive a search or get request
+// until it is considered search idle. Defaults to 30s.
+func (s IndexSettings) SearchIdleAfter() time.Duration {
+	return s.durationValue("search.idle.after", 30*time.Second)
+}
+
+// SetSearchIdleAfter sets search.idle.after to v
+func (s *IndexSettings) SetSearchIdleAfter(v time.Duration) error {
+	return s.Set("search.idle.after", formatTimeValue(v))
+}
+
+// RefreshInterval is how often to perform a refresh operation. Defaults to 1s.
+// A negative value means refreshing is disabled.
+func (s IndexSettings) RefreshInterval() time.Duration {
+	return s.durationValue("refresh_interval", time.Second)
+}
+
+// SetRefreshInterval sets refresh_interval to v. A negative v disables
+// refreshing.
+func (s *IndexSettings) SetRefreshInterval(v time.Duration) error {
+	if v < 0 {
+		return s.Set("refresh_interval", "-1")
+	}
+	return s.Set("refresh_interval", formatTimeValue(v))
+}
+
+// MaxResultWindow is the maximum value of from + size for searches to the
+// index. Defaults to 10000.
+func (s IndexSettings) MaxResultWindow() int {
+	return s.intValue("max_result_window", 10000)
+}
+
+// SetMaxResultWindow sets max_result_window to v
+func (s *IndexSettings) SetMaxResultWindow(v int) error {
+	return s.Set("max_result_window", v)
+}
+
+// MaxInnerResultWindow is the maximum value of from + size for inner hits and
+// top hits aggregations. Defaults to 100.
+func (s IndexSettings) MaxInnerResultWindow() int {
+	return s.intValue("max_inner_result_window", 100)
+}
+
+// SetMaxInnerResultWindow sets max_inner_result_window to v
+func (s *IndexSettings) SetMaxInnerResultWindow(v int) error {
+	return s.Set("max_inner_result_window", v)
+}
+
+// MaxRescoreWindow is the maximum value of window_size for rescore requests.
+// Defaults to MaxResultWindow.
+func (s IndexSettings) MaxRescoreWindow() int {
+	return s.intValue("max_rescore_window", s.MaxResultWindow())
+}
+
+// SetMaxRescoreWindow sets max_rescore_window to v
+func (s *IndexSettings) SetMaxRescoreWindow(v int) error {
+	return s.Set("max_rescore_window", v)
+}
+
+// MaxDocvalueFieldsSearch is the maximum number of docvalue_fields allowed in
+// a query. Defaults to 100.
+func (s IndexSettings) MaxDocvalueFieldsSearch() int {
+	return s.intValue("max_docvalue_fields_search", 100)
+}
+
+// SetMaxDocvalueFieldsSearch sets max_docvalue_fields_search to v
+func (s *IndexSettings) SetMaxDocvalueFieldsSearch(v int) error {
+	return s.Set("max_docvalue_fields_search", v)
+}
+
+// MaxScriptFields is the maximum number of script_fields allowed in a query.
+// Defaults to 32.
+func (s IndexSettings) MaxScriptFields() int {
+	return s.intValue("max_script_fields", 32)
+}
+
+// SetMaxScriptFields sets max_script_fields to v
+func (s *IndexSettings) SetMaxScriptFields(v int) error {
+	return s.Set("max_script_fields", v)
+}
+
+// MaxNgramDiff is the maximum allowed difference between min_gram and
+// max_gram. Defaults to 1.
+func (s IndexSettings) MaxNgramDiff() int {
+	return s.intValue("max_ngram_diff", 1)
+}
+
+// SetMaxNgramDiff sets max_ngram_diff to v
+func (s *IndexSettings) SetMaxNgramDiff(v int) error {
+	return s.Set("max_ngram_diff", v)
+}
+
+// MaxShingleDiff is the maximum allowed difference between max_shingle_size
+// and min_shingle_size. Defaults to 3.
+func (s IndexSettings) MaxShingleDiff() int {
+	return s.intValue("max_shingle_diff", 3)
+}
+
+// SetMaxShingleDiff sets max_shingle_diff to v
+func (s *IndexSettings) SetMaxShingleDiff(v int) error {
+	return s.Set("max_shingle_diff", v)
+}
+
+// MaxRefreshListeners is the maximum number of refresh listeners available on
+// each shard. Defaults to 1000.
+func (s IndexSettings) MaxRefreshListeners() int {
+	return s.intValue("max_refresh_listeners", 1000)
+}
+
+// SetMaxRefreshListeners sets max_refresh_listeners to v
+func (s *IndexSettings) SetMaxRefreshListeners(v int) error {
+	return s.Set("max_refresh_listeners", v)
+}
+
+// AnalyzeMaxTokenCount is the maximum number of tokens that can be produced
+// using the _analyze API. Defaults to 10000.
+func (s IndexSettings) AnalyzeMaxTokenCount() int {
+	return s.intValue("analyze.max_token_count", 10000)
+}
+
+// SetAnalyzeMaxTokenCount sets analyze.max_token_count to v
+func (s *IndexSettings) SetAnalyzeMaxTokenCount(v int) error {
+	return s.Set("analyze.max_token_count", v)
+}
+
+// HighlightMaxAnalyzedOffset is the maximum number of characters analyzed for
+// a highlight request. Defaults to 1000000.
+func (s IndexSettings) HighlightMaxAnalyzedOffset() int {
+	return s.intValue("highlight.max_analyzed_offset", 1000000)
+}
+
+// SetHighlightMaxAnalyzedOffset sets highlight.max_analyzed_offset to v
+func (s *IndexSettings) SetHighlightMaxAnalyzedOffset(v int) error {
+	return s.Set("highlight.max_analyzed_offset", v)
+}
+
+// MaxTermsCount is the maximum number of terms that can be used in a terms
+// query. Defaults to 65536.
+func (s IndexSettings) MaxTermsCount() int {
+	return s.intValue("max_terms_count", 65536)
+}
+
+// SetMaxTermsCount sets max_terms_count to v
+func (s *IndexSettings) SetMaxTermsCount(v int) error {
+	return s.Set("max_terms_count", v)
+}
+
+// MaxRegexLength is the maximum length of regex that can be used in a regexp
+// query. Defaults to 1000.
+func (s IndexSettings) MaxRegexLength() int {
+	return s.intValue("max_regex_length", 1000)
+}
+
+// SetMaxRegexLength sets max_regex_length to v
+func (s *IndexSettings) SetMaxRegexLength(v int) error {
+	return s.Set("max_regex_length", v)
+}
+
+// QueryDefaultField are the fields searched by default by queries which
+// accept fields. Defaults to ["*"].
+func (s IndexSettings) QueryDefaultField() []string {
+	if v := s.stringsValue("query.default_field"); v != nil {
+		return v
+	}
+	return []string{"*"}
+}
+
+// SetQueryDefaultField sets query.default_field to v
+func (s *IndexSettings) SetQueryDefaultField(v []string) error {
+	return s.Set("query.default_field", v)
+}
+
+// RoutingAllocationEnable controls shard allocation for the index; one of
+// "all" (default), "primaries", "new_primaries", or "none".
+func (s IndexSettings) RoutingAllocationEnable() string {
+	return s.stringValue("routing.allocation.enable", "all")
+}
+
+// SetRoutingAllocationEnable sets routing.allocation.enable to v
+func (s *IndexSettings) SetRoutingAllocationEnable(v string) error {
+	return s.Set("routing.allocation.enable", v)
+}
+
+// RoutingRebalanceEnable controls shard rebalancing for the index; one of
+// "all" (default), "primaries", "replicas", or "none".
+func (s IndexSettings) RoutingRebalanceEnable() string {
+	return s.stringValue("routing.rebalance.enable", "all")
+}
+
+// SetRoutingRebalanceEnable sets routing.rebalance.enable to v
+func (s *IndexSettings) SetRoutingRebalanceEnable(v string) error {
+	return s.Set("routing.rebalance.enable", v)
+}
+
+// GCDeletes is the length of time that a deleted document's version number
+// remains available for further versioned operations. Defaults to 60s.
+func (s IndexSettings) GCDeletes() time.Duration {
+	return s.durationValue("gc_deletes", 60*time.Second)
+}
+
+// SetGCDeletes sets gc_deletes to v
+func (s *IndexSettings) SetGCDeletes(v time.Duration) error {
+	return s.Set("gc_deletes", formatTimeValue(v))
+}
+
+// DefaultPipeline is the default ingest pipeline of the index
+func (s IndexSettings) DefaultPipeline() string {
+	return s.stringValue("default_pipeline", "")
+}
+
+// SetDefaultPipeline sets default_pipeline to v
+func (s *IndexSettings) SetDefaultPipeline(v string) error {
+	return s.Set("default_pipeline", v)
+}
+
+// FinalPipeline is the final ingest pipeline of the index
+func (s IndexSettings) FinalPipeline() string {
+	return s.stringValue("final_pipeline", "")
+}
+
+// SetFinalPipeline sets final_pipeline to v
+func (s *IndexSettings) SetFinalPipeline(v string) error {
+	return s.Set("final_pipeline", v)
+}
+
+// MappingTotalFieldsLimit is the maximum number of fields in the index.
+// Defaults to 1000.
+func (s IndexSettings) MappingTotalFieldsLimit() int {
+	return s.intValue("mapping.total_fields.limit", 1000)
+}
+
+// SetMappingTotalFieldsLimit sets mapping.total_fields.limit to v
+func (s *IndexSettings) SetMappingTotalFieldsLimit(v int) error {
+	return s.Set("mapping.total_fields.limit", v)
+}
+
+// MappingDepthLimit is the maximum depth of a field. Defaults to 20.
+func (s IndexSettings) MappingDepthLimit() int {
+	return s.intValue("mapping.depth.limit", 20)
+}
+
+// SetMappingDepthLimit sets mapping.depth.limit to v
+func (s *IndexSettings) SetMappingDepthLimit(v int) error {
+	return s.Set("mapping.depth.limit", v)
+}
+
+// MappingNestedFieldsLimit is the maximum number of distinct nested mappings.
+// Defaults to 50.
+func (s IndexSettings) MappingNestedFieldsLimit() int {
+	return s.intValue("mapping.nested_fields.limit", 50)
+}
+
+// SetMappingNestedFieldsLimit sets mapping.nested_fields.limit to v
+func (s *IndexSettings) SetMappingNestedFieldsLimit(v int) error {
+	return s.Set("mapping.nested_fields.limit", v)
+}
+
+// MappingNestedObjectsLimit is the maximum number of nested objects a single
+// document can contain. Defaults to 10000.
+func (s IndexSettings) MappingNestedObjectsLimit() int {
+	return s.intValue("mapping.nested_objects.limit", 10000)
+}
+
+// SetMappingNestedObjectsLimit sets mapping.nested_objects.limit to v
+func (s *IndexSettings) SetMappingNestedObjectsLimit(v int) error {
+	return s.Set("mapping.nested_objects.limit", v)
+}
+
+// MaxSlicesPerScroll is the maximum number of slices allowed per scroll
+// request. Defaults to 1024.
+func (s IndexSettings) MaxSlicesPerScroll() int {
+	return s.intValue("max_slices_per_scroll", 1024)
+}
+
+// SetMaxSlicesPerScroll sets max_slices_per_scroll to v
+func (s *IndexSettings) SetMaxSlicesPerScroll(v int) error {
+	return s.Set("max_slices_per_scroll", v)
+}
+
+// Priority is the recovery priority of the index. It returns 0 if it has not
+// been set.
+func (s IndexSettings) Priority() int {
+	return s.intValue("priority", 0)
+}
+
+// SetPriority sets priority to v
+func (s *IndexSettings) SetPriority(v int) error {
+	return s.Set("priority", v)
+}
+
+// WriteWaitForActiveShards is the number of shard copies that must be active
+// before write operations proceed; either "all" or a number. Defaults to "1".
+func (s IndexSettings) WriteWaitForActiveShards() string {
+	return s.stringValue("write.wait_for_active_shards", "1")
+}
+
+// SetWriteWaitForActiveShards sets write.wait_for_active_shards to v
+func (s *IndexSettings) SetWriteWaitForActiveShards(v string) error {
+	return s.Set("write.wait_for_active_shards", v)
+}
+
+// TranslogDurability is whether the translog is committed after every
+// request; either "request" (default) or "async".
+func (s IndexSettings) TranslogDurability() string {
+	return s.stringValue("translog.durability", "request")
+}
+
+// SetTranslogDurability sets translog.durability to v
+func (s *IndexSettings) SetTranslogDurability(v string) error {
+	return s.Set("translog.durability", v)
+}
+
+// TranslogSyncInterval is how often the translog is committed. Defaults to
+// 5s.
+func (s IndexSettings) TranslogSyncInterval() time.Duration {
+	return s.durationValue("translog.sync_interval", 5*time.Second)
+}
+
+// SetTranslogSyncInterval sets translog.sync_interval to v
+func (s *IndexSettings) SetTranslogSyncInterval(v time.Duration) error {
+	return s.Set("translog.sync_interval", formatTimeValue(v))
+}
+
+// TranslogFlushThresholdSize is the maximum size of the translog before a
+// flush is triggered. Defaults to "512mb".
+func (s IndexSettings) TranslogFlushThresholdSize() string {
+	return s.stringValue("translog.flush_threshold_size", "512mb")
+}
+
+// SetTranslogFlushThresholdSize sets translog.flush_threshold_size to v
+func (s *IndexSettings) SetTranslogFlushThresholdSize(v string) error {
+	return s.Set("translog.flush_threshold_size", v)
+}
+
+// Blocks are the operations blocked on the index
+func (s IndexSettings) Blocks() IndexBlocks {
+	return IndexBlocks{
+		ReadOnly:            s.boolPtrValue("blocks.read_only"),
+		ReadOnlyAllowDelete: s.boolPtrValue("blocks.read_only_allow_delete"),
+		Read:                s.boolPtrValue("blocks.read"),
+		Write:               s.boolPtrValue("blocks.write"),
+		Metadata:            s.boolPtrValue("blocks.metadata"),
+	}
+}
+
+// SetBlocks sets the blocks of v which are not nil
+func (s *IndexSettings) SetBlocks(v IndexBlocks) error {
+	for key, b := range map[string]*bool{
+		"blocks.read_only":              v.ReadOnly,
+		"blocks.read_only_allow_delete": v.ReadOnlyAllowDelete,
+		"blocks.read":                   v.Read,
+		"blocks.write":                  v.Write,
+		"blocks.metadata":               v.Metadata,
+	} {
+		if b == nil {
+			continue
+		}
+		err := s.Set(key, *b)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Analysis returns the analysis settings of the index, or nil if there are
+// none
+func (s IndexSettings) Analysis() (*Analysis, error) {
+	if len(s.analysis) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(s.analysis)
+	if err != nil {
+		return nil, err
+	}
+	var a Analysis
+	err = a.UnmarshalJSON(data)
+	if err != nil {
+		return nil, newFieldError(err, "index.analysis")
+	}
+	return &a, nil
+}
+
+// SetAnalysis validates v and replaces the analysis settings of the index
+// with it. Analysis settings can only be changed on a closed index.
+func (s *IndexSettings) SetAnalysis(v *Analysis) error {
+	if v != nil {
+		err := v.Validate()
+		if err != nil {
+			return newFieldError(err, "index.analysis")
+		}
+	}
+	s.Delete("analysis")
+	if v.IsEmpty() {
+		return nil
+	}
+	return s.Set("analysis", v)
+}
+
+func (s IndexSettings) MarshalBSON() ([]byte, error) {
+	return s.MarshalJSON()
+}
+
+// MarshalJSON encodes s in the nested form under "index"
+func (s IndexSettings) MarshalJSON() ([]byte, error) {
+	if len(s.settings) == 0 && len(s.analysis) == 0 {
+		return []byte("{}"), nil
+	}
+	index := unflattenSettings(s.settings)
+	if len(s.analysis) > 0 {
+		index["analysis"] = s.analysis
+	}
+	return json.Marshal(map[string]interface{}{"index": index})
+}
+
+func (s *IndexSettings) UnmarshalBSON(data []byte) error {
+	return s.UnmarshalJSON(data)
+}
+
+// UnmarshalJSON accepts both the flattened and nested forms of settings, with
+// or without the "index." prefix
+func (s *IndexSettings) UnmarshalJSON(data []byte) error {
+	*s = IndexSettings{}
+	d := dynamic.JSON(data)
+	if len(d) == 0 || d.IsNull() {
+		return nil
+	}
+	if !d.IsObject() {
+		return fmt.Errorf("%w; expected an object", ErrInvalidIndexSetting)
+	}
+	flat := map[string]dynamic.JSON{}
+	err := flattenSettings("", d, flat)
+	if err != nil {
+		return err
+	}
+	return s.setFlat(flat)
+}
+
+func (s *IndexSettings) setFlat(flat map[string]dynamic.JSON) error {
+	if s.settings == nil {
+		s.settings = map[string]dynamic.JSON{}
+	}
+	analysis := false
+	for k, v := range flat {
+		k = normalizeIndexSettingKey(k)
+		if len(k) == 0 {
+			continue
+		}
+		if isAnalysisSettingKey(k) {
+			if s.analysis == nil {
+				s.analysis = map[string]interface{}{}
+			}
+			var path []string
+			if k != "analysis" {
+				path = strings.Split(strings.TrimPrefix(k, "analysis."), ".")
+			}
+			err := mergeNestedSetting(s.analysis, path, v)
+			if err != nil {
+				return newFieldError(err, "index."+k)
+			}
+			analysis = true
+			continue
+		}
+		if validate, ok := indexSettingValidators[k]; ok {
+			err := validate(v)
+			if err != nil {
+				return newFieldError(err, "index."+k)
+			}
+		}
+		s.settings[k] = v
+	}
+	if analysis {
+		_, err := s.Analysis()
+		return err
+	}
+	return nil
+}
+
+func (s IndexSettings) getAnalysis(key string) (dynamic.JSON, bool) {
+	if len(s.analysis) == 0 {
+		return nil, false
+	}
+	var v interface{} = s.analysis
+	if key != "analysis" {
+		for _, part := range strings.Split(strings.TrimPrefix(key, "analysis."), ".") {
+			m, ok := v.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+			if v, ok = m[part]; !ok {
+				return nil, false
+			}
+		}
+	}
+	if d, ok := v.(dynamic.JSON); ok {
+		return d, true
+	}
+	d, err := json.Marshal(v)
+	if err != nil {
+		return nil, false
+	}
+	return d, true
+}
+
+func (s IndexSettings) intValue(key string, def int) int {
+	v, ok := s.settings[key]
+	if !ok {
+		return def
+	}
+	n, err := dynamic.NewNumber(v.UnquotedString())
+	if err != nil {
+		return def
+	}
+	if i, ok := n.Int(); ok {
+		return i
+	}
+	return def
+}
+
+func (s IndexSettings) boolPtrValue(key string) *bool {
+	v, ok := s.settings[key]
+	if !ok {
+		return nil
+	}
+	b, err := dynamic.NewBool(v.UnquotedString())
+	if err != nil {
+		return nil
+	}
+	if res, ok := b.Bool(); ok {
+		return &res
+	}
+	return nil
+}
+
+func (s IndexSettings) boolValue(key string, def bool) bool {
+	if b := s.boolPtrValue(key); b != nil {
+		return *b
+	}
+	return def
+}
+
+func (s IndexSettings) durationValue(key string, def time.Duration) time.Duration {
+	v, ok := s.settings[key]
+	if !ok {
+		return def
+	}
+	d, err := parseTimeValue(v.UnquotedString())
+	if err != nil {
+		return def
+	}
+	return d
+}
+
+func (s IndexSettings) stringValue(key string, def string) string {
+	v, ok := s.settings[key]
+	if !ok {
+		return def
+	}
+	if v.IsString() {
+		var str string
+		if err := json.Unmarshal(v, &str); err == nil {
+			return str
+		}
+	}
+	return string(v)
+}
+
+func (s IndexSettings) stringsValue(key string) []string {
+	v, ok := s.settings[key]
+	if !ok {
+		return nil
+	}
+	var res dynamic.StringOrArrayOfStrings
+	if err := json.Unmarshal(v, &res); err != nil {
+		return nil
+	}
+	return []string(res)
+}
+
+// IndexSort configures the sorting of segments within each shard. Each of
+// Order, Mode, and Missing, if set, must have a value for each Field.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/index-modules-index-sorting.html
+type IndexSort struct {
+	// The fields to sort by (Required)
+	Field []string
+	// The order of each field; either "asc" or "desc"
+	Order []string
+	// How to pick the value of multi-valued fields; either "min" or "max"
+	Mode []string
+	// How documents missing the field are sorted; either "_first" or "_last"
+	Missing []string
+}
+
+// Validate checks that the fields are set and each option has a value per
+// field
+func (s IndexSort) Validate() error {
+	if len(s.Field) == 0 {
+		return ErrFieldRequired
+	}
+	for name, v := range map[string][]string{"order": s.Order, "mode": s.Mode, "missing": s.Missing} {
+		if len(v) > 0 && len(v) != len(s.Field) {
+			return fmt.Errorf("%w; sort.%s must have a value for each sort.field", ErrInvalidIndexSetting, name)
+		}
+	}
+	for _, o := range s.Order {
+		order := SortOrder(o)
+		if !order.IsValid() {
+			return fmt.Errorf("%w <%s>", ErrInvalidSortOrder, o)
+		}
+	}
+	return nil
+}
+
+// IndexBlocks are blocks on the operations of an index. Blocks which are nil
+// are left unchanged.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/index-modules-blocks.html
+type IndexBlocks struct {
+	// Makes the index and index metadata read-only
+	ReadOnly *bool
+	// Like ReadOnly but allows deleting the index to free up resources
+	ReadOnlyAllowDelete *bool
+	// Disables read operations
+	Read *bool
+	// Disables data write operations
+	Write *bool
+	// Disables metadata reads and writes
+	Metadata *bool
+}
+
+// normalizeIndexSettingKey strips the "index." prefix from key
+func normalizeIndexSettingKey(key string) string {
+	if key == "index" {
+		return ""
+	}
+	return strings.TrimPrefix(key, "index.")
+}
+
+// isAnalysisSettingKey reports whether the normalized key is, or is within,
+// the analysis settings
+func isAnalysisSettingKey(key string) bool {
+	return key == "analysis" || strings.HasPrefix(key, "analysis.")
+}
+
+// flattenSettings adds each leaf of d to res, keyed by its dotted path
+// relative to prefix. Arrays and empty objects are leaves, as are the
+// analysis settings, whose keys may contain '.'.
+func flattenSettings(prefix string, d dynamic.JSON, res map[string]dynamic.JSON) error {
+	if isAnalysisSettingKey(normalizeIndexSettingKey(prefix)) {
+		res[prefix] = d
+		return nil
+	}
+	if !d.IsObject() {
+		if len(prefix) == 0 {
+			return fmt.Errorf("%w; expected an object", ErrInvalidIndexSetting)
+		}
+		res[prefix] = d
+		return nil
+	}
+	var obj map[string]dynamic.JSON
+	err := json.Unmarshal(d, &obj)
+	if err != nil {
+		return err
+	}
+	if len(obj) == 0 && len(prefix) > 0 {
+		res[prefix] = d
+		return nil
+	}
+	for k, v := range obj {
+		key := k
+		if len(prefix) > 0 {
+			key = prefix + "." + k
+		}
+		err = flattenSettings(key, v, res)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unflattenSettings nests flattened settings by their dotted keys
+func unflattenSettings(flat map[string]dynamic.JSON) map[string]interface{} {
+	root := map[string]interface{}{}
+	for k, v := range flat {
+		node := root
+		parts := strings.Split(k, ".")
+		for i, part := range parts[:len(parts)-1] {
+			child, ok := node[part]
+			if !ok {
+				m := map[string]interface{}{}
+				node[part] = m
+				node = m
+				continue
+			}
+			m, ok := child.(map[string]interface{})
+			if !ok {
+				// a setting already occupies this key; keep the rest of the
+				// key flattened
+				parts = append(parts[:i+1:i+1], strings.Join(parts[i+1:], "."))
+				break
+			}
+			node = m
+		}
+		node[parts[len(parts)-1]] = v
+	}
+	return root
+}
+
+// mergeNestedSetting sets v at path within node. Objects are merged key by key
+// without splitting their keys on '.'.
+func mergeNestedSetting(node map[string]interface{}, path []string, v dynamic.JSON) error {
+	if len(path) > 0 {
+		child, ok := node[path[0]].(map[string]interface{})
+		if !ok {
+			if len(path) == 1 && !v.IsObject() {
+				node[path[0]] = v
+				return nil
+			}
+			child = map[string]interface{}{}
+			node[path[0]] = child
+		}
+		return mergeNestedSetting(child, path[1:], v)
+	}
+	if !v.IsObject() {
+		return fmt.Errorf("%w; expected an object", ErrInvalidIndexSetting)
+	}
+	var obj map[string]dynamic.JSON
+	err := json.Unmarshal(v, &obj)
+	if err != nil {
+		return err
+	}
+	for k, cv := range obj {
+		err = mergeNestedSetting(node, []string{k}, cv)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteNestedSetting removes the setting at path within node
+func deleteNestedSetting(node map[string]interface{}, path []string) {
+	for _, part := range path[:len(path)-1] {
+		child, ok := node[part].(map[string]interface{})
+		if !ok {
+			return
+		}
+		node = child
+	}
+	delete(node, path[len(path)-1])
+}
+
+var indexSettingValidators = map[string]func(dynamic.JSON) error{
+	"number_of_shards":                    validateIntSetting(1),
+	"number_of_routing_shards":            validateIntSetting(1),
+	"shard.check_on_startup":              validateEnumSetting("false", "checksum", "true"),
+	"codec":                               validateStringSetting,
+	"routing_partition_size":              validateIntSetting(1),
+	"soft_deletes.enabled":                validateBoolSetting,
+	"soft_deletes.retention_lease.period": validateTimeSetting,
+	"load_fixed_bitset_filters_eagerly":   validateBoolSetting,
+	"hidden":                              validateBoolSetting,
+	"sort.field":                          validateStringsSetting,
+	"sort.order":                          validateStringsSetting,
+	"sort.mode":                           validateStringsSetting,
+	"sort.missing":                        validateStringsSetting,
+	"number_of_replicas":                  validateIntSetting(0),
+	"auto_expand_replicas":                validateStringSetting,
+	"search.idle.after":                   validateTimeSetting,
+	"refresh_interval":                    validateTimeSetting,
+	"max_result_window":                   validateIntSetting(1),
+	"max_inner_result_window":             validateIntSetting(1),
+	"max_rescore_window":                  validateIntSetting(1),
+	"max_docvalue_fields_search":          validateIntSetting(0),
+	"max_script_fields":                   validateIntSetting(0),
+	"max_ngram_diff":                      validateIntSetting(0),
+	"max_shingle_diff":                    validateIntSetting(0),
+	"max_refresh_listeners":               validateIntSetting(0),
+	"analyze.max_token_count":             validateIntSetting(1),
+	"highlight.max_analyzed_offset":       validateIntSetting(1),
+	"max_terms_count":                     validateIntSetting(1),
+	"max_regex_length":                    validateIntSetting(1),
+	"query.default_field":                 validateStringsSetting,
+	"routing.allocation.enable":           validateEnumSetting("all", "primaries", "new_primaries", "none"),
+	"routing.rebalance.enable":            validateEnumSetting("all", "primaries", "replicas", "none"),
+	"gc_deletes":                          validateTimeSetting,
+	"default_pipeline":                    validateStringSetting,
+	"final_pipeline":                      validateStringSetting,
+	"mapping.total_fields.limit":          validateIntSetting(0),
+	"mapping.depth.limit":                 validateIntSetting(1),
+	"mapping.nested_fields.limit":         validateIntSetting(0),
+	"mapping.nested_objects.limit":        validateIntSetting(0),
+	"max_slices_per_scroll":               validateIntSetting(1),
+	"priority":                            validateIntSetting(0),
+	"write.wait_for_active_shards":        validateStringSetting,
+	"translog.durability":                 validateEnumSetting("request", "async"),
+	"translog.sync_interval":              validateTimeSetting,
+	"translog.flush_threshold_size":       validateStringSetting,
+	"blocks.read_only":                    validateBoolSetting,
+	"blocks.read_only_allow_delete":       validateBoolSetting,
+	"blocks.read":                         validateBoolSetting,
+	"blocks.write":                        validateBoolSetting,
+	"blocks.metadata":                     validateBoolSetting,
+}
+
+func validateIntSetting(min int) func(dynamic.JSON) error {
+	return func(d dynamic.JSON) error {
+		n, err := dynamic.NewNumber(d.UnquotedString())
+		if err != nil {
+			return fmt.Errorf("%w; expected an integer, received <%s>", ErrInvalidIndexSetting, d)
+		}
+		i, ok := n.Int()
+		if !ok {
+			return fmt.Errorf("%w; expected an integer, received <%s>", ErrInvalidIndexSetting, d)
+		}
+		if i < min {
+			return fmt.Errorf("%w; must be greater than or equal to %d, received <%d>", ErrInvalidIndexSetting, min, i)
+		}
+		return nil
+	}
+}
+
+func validateBoolSetting(d dynamic.JSON) error {
+	b, err := dynamic.NewBool(d.UnquotedString())
+	if err != nil {
+		return fmt.Errorf("%w; expected a bool, received <%s>", ErrInvalidIndexSetting, d)
+	}
+	if _, ok := b.Bool(); !ok {
+		return fmt.Errorf("%w; expected a bool, received <%s>", ErrInvalidIndexSetting, d)
+	}
+	return nil
+}
+
+func validateTimeSetting(d dynamic.JSON) error {
+	if d.IsObject() || d.IsArray() {
+		return fmt.Errorf("%w; expected a time value, received <%s>", ErrInvalidIndexSetting, d)
+	}
+	_, err := parseTimeValue(d.UnquotedString())
+	return err
+}
+
+func validateStringSetting(d dynamic.JSON) error {
+	if d.IsObject() || d.IsArray() {
+		return fmt.Errorf("%w; expected a string, received <%s>", ErrInvalidIndexSetting, d)
+	}
+	return nil
+}
+
+func validateStringsSetting(d dynamic.JSON) error {
+	var v dynamic.StringOrArrayOfStrings
+	err := json.Unmarshal(d, &v)
+	if err != nil {
+		return fmt.Errorf("%w; expected a string or an array of strings, received <%s>", ErrInvalidIndexSetting, d)
+	}
+	return nil
+}
+
+func validateEnumSetting(values ...string) func(dynamic.JSON) error {
+	return func(d dynamic.JSON) error {
+		err := validateStringSetting(d)
+		if err != nil {
+			return err
+		}
+		v := d.UnquotedString()
+		for _, value := range values {
+			if v == value {
+				return nil
+			}
+		}
+		return fmt.Errorf("%w; expected one of %s, received <%s>", ErrInvalidIndexSetting, strings.Join(values, ", "), v)
+	}
+}
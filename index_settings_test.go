@@ -0,0 +1,218 @@
+package picker_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chanced/cmpjson"
+	"github.com/chanced/picker"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexSettings(t *testing.T) {
+	assert := require.New(t)
+	// as returned by the get index settings API
+	data := []byte(`{
+		"settings": {
+			"index": {
+				"number_of_shards": "3",
+				"number_of_replicas": "2",
+				"refresh_interval": "-1",
+				"codec": "best_compression",
+				"routing": { "allocation": { "enable": "primaries", "include": { "_tier_preference": "data_content" } } },
+				"soft_deletes": { "enabled": "true" },
+				"max_result_window": "50000",
+				"sort": { "field": ["date", "username"], "order": ["desc", "asc"] },
+				"blocks": { "write": "true" },
+				"lifecycle": { "name": "my_policy" },
+				"provided_name": "my-index-000001",
+				"uuid": "aMgeyQmGQqKB4_8e2_2qBA",
+				"creation_date": "1617909581219",
+				"version": { "created": "7120099" }
+			}
+		}
+	}`)
+	var i picker.Index
+	err := json.Unmarshal(data, &i)
+	assert.NoError(err)
+	s := i.Settings
+	assert.NotNil(s)
+	assert.Equal(3, s.NumberOfShards())
+	assert.Equal(2, s.NumberOfReplicas())
+	assert.True(s.RefreshInterval() < 0)
+	assert.Equal("best_compression", s.Codec())
+	assert.Equal("primaries", s.RoutingAllocationEnable())
+	assert.True(s.SoftDeletesEnabled())
+	assert.Equal(50000, s.MaxResultWindow())
+	assert.Equal(50000, s.MaxRescoreWindow())
+	assert.Equal([]string{"date", "username"}, s.Sort().Field)
+	assert.Equal([]string{"desc", "asc"}, s.Sort().Order)
+	assert.True(*s.Blocks().Write)
+	assert.Nil(s.Blocks().Read)
+	lifecycle, ok := s.Get("index.lifecycle.name")
+	assert.True(ok)
+	assert.Equal(`"my_policy"`, string(lifecycle))
+
+	// defaults
+	assert.Equal(time.Second*30, s.SearchIdleAfter())
+	assert.Equal([]string{"*"}, s.QueryDefaultField())
+	assert.False(s.Hidden())
+
+	id, err := json.Marshal(i)
+	assert.NoError(err)
+	expected := []byte(`{
+		"mappings": { "properties": null },
+		"settings": {
+			"index": {
+				"number_of_shards": "3",
+				"number_of_replicas": "2",
+				"refresh_interval": "-1",
+				"codec": "best_compression",
+				"routing": { "allocation": { "enable": "primaries", "include": { "_tier_preference": "data_content" } } },
+				"soft_deletes": { "enabled": "true" },
+				"max_result_window": "50000",
+				"sort": { "field": ["date", "username"], "order": ["desc", "asc"] },
+				"blocks": { "write": "true" },
+				"lifecycle": { "name": "my_policy" },
+				"provided_name": "my-index-000001",
+				"uuid": "aMgeyQmGQqKB4_8e2_2qBA",
+				"creation_date": "1617909581219",
+				"version": { "created": "7120099" }
+			}
+		}
+	}`)
+	assert.True(cmpjson.Equal(expected, id), cmpjson.Diff(expected, id))
+
+	// flattened keys, with and without the index prefix
+	var flat picker.IndexSettings
+	err = json.Unmarshal([]byte(`{
+		"index.number_of_shards": 3,
+		"number_of_replicas": 2,
+		"index.routing.allocation.enable": "primaries",
+		"index": { "refresh_interval": "30s" }
+	}`), &flat)
+	assert.NoError(err)
+	assert.Equal(3, flat.NumberOfShards())
+	assert.Equal(2, flat.NumberOfReplicas())
+	assert.Equal("primaries", flat.RoutingAllocationEnable())
+	assert.Equal(30*time.Second, flat.RefreshInterval())
+	fd, err := json.Marshal(flat)
+	assert.NoError(err)
+	expectedFlat := []byte(`{
+		"index": {
+			"number_of_shards": 3,
+			"number_of_replicas": 2,
+			"routing": { "allocation": { "enable": "primaries" } },
+			"refresh_interval": "30s"
+		}
+	}`)
+	assert.True(cmpjson.Equal(expectedFlat, fd), cmpjson.Diff(expectedFlat, fd))
+
+	err = json.Unmarshal([]byte(`{"index.number_of_shards": "three"}`), &flat)
+	assert.True(errors.Is(err, picker.ErrInvalidIndexSetting))
+	err = json.Unmarshal([]byte(`{"routing.allocation.enable": "some"}`), &flat)
+	assert.True(errors.Is(err, picker.ErrInvalidIndexSetting))
+}
+
+func TestNewIndexSettings(t *testing.T) {
+	assert := require.New(t)
+	readOnly := true
+	i, err := picker.NewIndex(picker.IndexParams{
+		Mappings: picker.Mappings{
+			Properties: picker.FieldMap{"date": picker.DateFieldParams{}},
+		},
+		Settings: picker.IndexSettingsParams{
+			NumberOfShards:   3,
+			NumberOfReplicas: 0,
+			RefreshInterval:  "5s",
+			Sort: &picker.IndexSort{
+				Field: []string{"date"},
+				Order: []string{"desc"},
+			},
+			Blocks: &picker.IndexBlocks{ReadOnly: &readOnly},
+			Settings: map[string]interface{}{
+				"index.lifecycle.name": "my_policy",
+				"analysis": map[string]interface{}{
+					"analyzer": map[string]interface{}{
+						"my_analyzer": map[string]interface{}{"type": "standard"},
+					},
+				},
+			},
+		},
+	})
+	assert.NoError(err)
+	assert.Equal(0, i.Settings.NumberOfReplicas())
+	id, err := json.Marshal(i)
+	assert.NoError(err)
+	expected := []byte(`{
+		"mappings": { "properties": { "date": { "type": "date" } } },
+		"settings": {
+			"index": {
+				"number_of_shards": 3,
+				"number_of_replicas": 0,
+				"refresh_interval": "5s",
+				"sort": { "field": ["date"], "order": ["desc"] },
+				"blocks": { "read_only": true },
+				"lifecycle": { "name": "my_policy" },
+				"analysis": { "analyzer": { "my_analyzer": { "type": "standard" } } }
+			}
+		}
+	}`)
+	assert.True(cmpjson.Equal(expected, id), cmpjson.Diff(expected, id))
+
+	s := picker.IndexSettings{}
+	assert.NoError(s.SetRefreshInterval(-1))
+	assert.True(s.RefreshInterval() < 0)
+	v, _ := s.Get("refresh_interval")
+	assert.Equal(`"-1"`, string(v))
+	err = s.SetNumberOfShards(0)
+	assert.True(errors.Is(err, picker.ErrInvalidIndexSetting))
+	err = s.SetSort(picker.IndexSort{Field: []string{"a", "b"}, Order: []string{"asc"}})
+	assert.True(errors.Is(err, picker.ErrInvalidIndexSetting))
+	s.Delete("index.refresh_interval")
+	assert.Equal(0, s.Len())
+
+	_, err = picker.NewIndex(picker.IndexParams{
+		Settings: picker.IndexSettingsParams{NumberOfReplicas: -1},
+	})
+	assert.True(errors.Is(err, picker.ErrInvalidIndexSetting))
+}
+
+func TestIndexSettingsDottedAnalysisNames(t *testing.T) {
+	assert := require.New(t)
+	data := []byte(`{
+		"index": {
+			"number_of_shards": 1,
+			"analysis": {
+				"analyzer": { "my.analyzer": { "type": "custom", "tokenizer": "standard" } }
+			}
+		}
+	}`)
+	var s picker.IndexSettings
+	err := json.Unmarshal(data, &s)
+	assert.NoError(err)
+	a, err := s.Analysis()
+	assert.NoError(err)
+	assert.True(a.HasAnalyzer("my.analyzer"))
+	v, ok := s.Get("index.analysis.analyzer")
+	assert.True(ok)
+	assert.True(cmpjson.Equal([]byte(`{"my.analyzer": { "type": "custom", "tokenizer": "standard" }}`), v), string(v))
+	assert.Equal(2, s.Len())
+
+	sd, err := json.Marshal(s)
+	assert.NoError(err)
+	assert.True(cmpjson.Equal(data, sd), cmpjson.Diff(data, sd))
+
+	assert.NoError(s.SetAnalysis(a))
+	sd, err = json.Marshal(s)
+	assert.NoError(err)
+	assert.True(cmpjson.Equal(data, sd), cmpjson.Diff(data, sd))
+
+	s.Delete("analysis")
+	assert.Equal(1, s.Len())
+	a, err = s.Analysis()
+	assert.NoError(err)
+	assert.Nil(a)
+}
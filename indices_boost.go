@@ -0,0 +1,121 @@
+package picker
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/chanced/dynamic"
+)
+
+// IndexBoost boosts the _score of documents from indices matching Index,
+// which may be an index name, alias, or wildcard pattern
+type IndexBoost struct {
+	Index string
+	Boost float64
+}
+
+// IndicesBoost are the ordered boosts applied to the _score of documents
+// based on the index they are from. When an index matches more than one
+// entry, the first is used, which is why the array form is preferred for
+// aliases and wildcard patterns.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-multiple-indices.html#index-boost
+type IndicesBoost []IndexBoost
+
+func (ib IndicesBoost) MarshalBSON() ([]byte, error) {
+	return ib.MarshalJSON()
+}
+
+// MarshalJSON encodes ib in the array form, e.g. [{"my-index":1.4}]
+func (ib IndicesBoost) MarshalJSON() ([]byte, error) {
+	res := make([]dynamic.JSONObject, len(ib))
+	for i, b := range ib {
+		n, err := json.Marshal(b.Boost)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = dynamic.JSONObject{b.Index: n}
+	}
+	return json.Marshal(res)
+}
+
+// marshalObject encodes ib in the object form, e.g. {"my-index":1.4},
+// retaining the order of ib
+func (ib IndicesBoost) marshalObject() ([]byte, error) {
+	buf := bytes.Buffer{}
+	buf.WriteByte('{')
+	for i, b := range ib {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(b.Index)
+		if err != nil {
+			return nil, err
+		}
+		n, err := json.Marshal(b.Boost)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(n)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func (ib *IndicesBoost) UnmarshalBSON(data []byte) error {
+	return ib.UnmarshalJSON(data)
+}
+
+// UnmarshalJSON accepts both the array form, [{"my-index":1.4}], and the
+// object form, {"my-index":1.4}
+func (ib *IndicesBoost) UnmarshalJSON(data []byte) error {
+	_, err := ib.unmarshal(data)
+	return err
+}
+
+// unmarshal decodes data into ib, returning whether data was in the object
+// form
+func (ib *IndicesBoost) unmarshal(data []byte) (bool, error) {
+	*ib = nil
+	d := dynamic.JSON(data)
+	if d.IsNull() {
+		return false, nil
+	}
+	if d.IsObject() {
+		keys, obj, err := unmarshalOrderedObject(data)
+		if err != nil {
+			return true, err
+		}
+		for _, k := range keys {
+			var boost float64
+			err = json.Unmarshal(obj[k], &boost)
+			if err != nil {
+				return true, newFieldError(err, k)
+			}
+			*ib = append(*ib, IndexBoost{Index: k, Boost: boost})
+		}
+		return true, nil
+	}
+	var arr []dynamic.JSON
+	err := json.Unmarshal(data, &arr)
+	if err != nil {
+		return false, err
+	}
+	for _, v := range arr {
+		keys, obj, err := unmarshalOrderedObject(v)
+		if err != nil {
+			return false, err
+		}
+		for _, k := range keys {
+			var boost float64
+			err = json.Unmarshal(obj[k], &boost)
+			if err != nil {
+				return false, newFieldError(err, k)
+			}
+			*ib = append(*ib, IndexBoost{Index: k, Boost: boost})
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,124 @@
+package picker
+
+// InferenceAggParams creates a InferenceAgg, a parent pipeline aggregation that
+// loads a pre-trained model and performs inference on the collated result
+// fields from the parent bucket aggregation.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-inference-bucket-aggregation.html
+type InferenceAggParams struct {
+	// Map of script variables to the buckets_path of the metric they reference
+	// (Required)
+	BucketsPath map[string]string
+	// The ID or alias for the trained model (Required)
+	ModelID string
+	// Contains the inference type and its options, e.g. {"regression": {...}}
+	// or {"classification": {...}}
+	InferenceConfig map[string]interface{}
+	Meta            map[string]interface{}
+}
+
+func (InferenceAggParams) Kind() AggKind {
+	return AggKindInferenceBucket
+}
+
+func (p InferenceAggParams) Clause() (AggClause, error) {
+	return p.Inference()
+}
+
+func (p InferenceAggParams) Inference() (*InferenceAgg, error) {
+	a := &InferenceAgg{}
+	err := a.SetBucketsPath(p.BucketsPath)
+	if err != nil {
+		return a, newAggError(err, AggKindInferenceBucket)
+	}
+	err = a.SetModelID(p.ModelID)
+	if err != nil {
+		return a, newAggError(err, AggKindInferenceBucket)
+	}
+	a.SetInferenceConfig(p.InferenceConfig)
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// InferenceAgg is a parent pipeline aggregation that loads a pre-trained model
+// and performs inference on the collated result fields from the parent bucket
+// aggregation.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-inference-bucket-aggregation.html
+type InferenceAgg struct {
+	bucketsPathsParam
+	modelID         string
+	inferenceConfig map[string]interface{}
+	noAggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*InferenceAgg)(nil)
+
+func (InferenceAgg) Kind() AggKind {
+	return AggKindInferenceBucket
+}
+
+func (a *InferenceAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// ModelID is the ID or alias for the trained model
+func (a InferenceAgg) ModelID() string {
+	return a.modelID
+}
+
+// SetModelID sets model_id to v
+func (a *InferenceAgg) SetModelID(v string) error {
+	if len(v) == 0 {
+		return ErrModelIDRequired
+	}
+	a.modelID = v
+	return nil
+}
+
+// InferenceConfig contains the inference type and its options
+func (a InferenceAgg) InferenceConfig() map[string]interface{} {
+	return a.inferenceConfig
+}
+
+// SetInferenceConfig sets inference_config to v
+func (a *InferenceAgg) SetInferenceConfig(v map[string]interface{}) {
+	a.inferenceConfig = v
+}
+
+func (a InferenceAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a InferenceAgg) MarshalJSON() ([]byte, error) {
+	return inferenceAgg{
+		BucketsPath:     a.bucketsPath,
+		ModelID:         a.modelID,
+		InferenceConfig: a.inferenceConfig,
+	}.MarshalJSON()
+}
+
+func (a *InferenceAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *InferenceAgg) UnmarshalJSON(data []byte) error {
+	*a = InferenceAgg{}
+	p := inferenceAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.bucketsPath = p.BucketsPath
+	a.modelID = p.ModelID
+	a.inferenceConfig = p.InferenceConfig
+	return nil
+}
+
+//easyjson:json
+type inferenceAgg struct {
+	BucketsPath     map[string]string      `json:"buckets_path"`
+	ModelID         string                 `json:"model_id"`
+	InferenceConfig map[string]interface{} `json:"inference_config,omitempty"`
+}
@@ -0,0 +1,173 @@
+package picker
+
+// InferenceProcessorParams creates a InferenceProcessor, which uses a
+// pre-trained data frame analytics model or a model deployed for natural
+// language processing tasks to infer against the data that is being ingested in
+// the pipeline.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/inference-processor.html
+type InferenceProcessorParams struct {
+	// ModelID is the ID or alias for the trained model
+	ModelID string
+	// TargetField is the field added to incoming documents to contain results
+	// objects. Defaults to "ml.inference.<processor_tag>".
+	TargetField string
+	// FieldMap maps the document field names to the known field names of the
+	// model. This mapping takes precedence over any default mappings provided
+	// in the model configuration.
+	FieldMap map[string]string
+	// InferenceConfig contains the inference type and its options
+	InferenceConfig map[string]interface{}
+	// Description of the processor. Useful for describing the purpose of the
+	// processor or its configuration.
+	Description string
+	// Conditionally execute the processor
+	If string
+	// Ignore failures for the processor
+	IgnoreFailure bool
+	// Handle failures for the processor
+	OnFailure Procs
+	// Identifier for the processor. Useful for debugging and metrics.
+	Tag string
+}
+
+func (InferenceProcessorParams) Kind() ProcessorKind {
+	return ProcessorKindInference
+}
+
+func (p InferenceProcessorParams) Processor() (Processor, error) {
+	return p.Inference()
+}
+
+func (p InferenceProcessorParams) Inference() (*InferenceProcessor, error) {
+	proc := &InferenceProcessor{}
+	err := proc.SetModelID(p.ModelID)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindInference)
+	}
+	proc.SetTargetField(p.TargetField)
+	proc.SetFieldMap(p.FieldMap)
+	proc.SetInferenceConfig(p.InferenceConfig)
+	proc.SetDescription(p.Description)
+	proc.SetIf(p.If)
+	proc.SetIgnoreFailure(p.IgnoreFailure)
+	err = proc.SetOnFailure(p.OnFailure)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindInference)
+	}
+	proc.SetTag(p.Tag)
+	return proc, nil
+}
+
+// InferenceProcessor uses a pre-trained data frame analytics model or a model
+// deployed for natural language processing tasks to infer against the data that
+// is being ingested in the pipeline.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/inference-processor.html
+type InferenceProcessor struct {
+	modelID string
+	targetFieldParam
+	fieldMap        map[string]string
+	inferenceConfig map[string]interface{}
+	processorParams
+}
+
+var _ Processor = (*InferenceProcessor)(nil)
+
+func (InferenceProcessor) Kind() ProcessorKind {
+	return ProcessorKindInference
+}
+
+func (p *InferenceProcessor) Processor() (Processor, error) {
+	return p, nil
+}
+
+// ModelID is the ID or alias for the trained model
+func (p InferenceProcessor) ModelID() string {
+	return p.modelID
+}
+
+// SetModelID sets model_id to v
+func (p *InferenceProcessor) SetModelID(v string) error {
+	if len(v) == 0 {
+		return ErrModelIDRequired
+	}
+	p.modelID = v
+	return nil
+}
+
+// FieldMap maps the document field names to the known field names of the model.
+// This mapping takes precedence over any default mappings provided in the model
+// configuration.
+func (p InferenceProcessor) FieldMap() map[string]string {
+	return p.fieldMap
+}
+
+// SetFieldMap sets field_map to v
+func (p *InferenceProcessor) SetFieldMap(v map[string]string) {
+	p.fieldMap = v
+}
+
+// InferenceConfig contains the inference type and its options
+func (p InferenceProcessor) InferenceConfig() map[string]interface{} {
+	return p.inferenceConfig
+}
+
+// SetInferenceConfig sets inference_config to v
+func (p *InferenceProcessor) SetInferenceConfig(v map[string]interface{}) {
+	p.inferenceConfig = v
+}
+
+func (p InferenceProcessor) MarshalBSON() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+func (p InferenceProcessor) MarshalJSON() ([]byte, error) {
+	return inferenceProcessor{
+		ModelID:         p.modelID,
+		TargetField:     p.targetField,
+		FieldMap:        p.fieldMap,
+		InferenceConfig: p.inferenceConfig,
+		Description:     p.description,
+		If:              p.ifCond,
+		IgnoreFailure:   p.ignoreFailure,
+		OnFailure:       p.onFailure,
+		Tag:             p.tag,
+	}.MarshalJSON()
+}
+
+func (p *InferenceProcessor) UnmarshalBSON(data []byte) error {
+	return p.UnmarshalJSON(data)
+}
+
+func (p *InferenceProcessor) UnmarshalJSON(data []byte) error {
+	*p = InferenceProcessor{}
+	var v inferenceProcessor
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	p.modelID = v.ModelID
+	p.targetField = v.TargetField
+	p.fieldMap = v.FieldMap
+	p.inferenceConfig = v.InferenceConfig
+	p.description = v.Description
+	p.ifCond = v.If
+	p.ignoreFailure = v.IgnoreFailure
+	p.onFailure = v.OnFailure
+	p.tag = v.Tag
+	return nil
+}
+
+//easyjson:json
+type inferenceProcessor struct {
+	ModelID         string                 `json:"model_id,omitempty"`
+	TargetField     string                 `json:"target_field,omitempty"`
+	FieldMap        map[string]string      `json:"field_map,omitempty"`
+	InferenceConfig map[string]interface{} `json:"inference_config,omitempty"`
+	Description     string                 `json:"description,omitempty"`
+	If              string                 `json:"if,omitempty"`
+	IgnoreFailure   bool                   `json:"ignore_failure,omitempty"`
+	OnFailure       Processors             `json:"on_failure,omitempty"`
+	Tag             string                 `json:"tag,omitempty"`
+}
@@ -0,0 +1,300 @@
+package picker
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// IngestPipelineParams creates an IngestPipeline
+//
+//	picker.IngestPipelineParams{
+//	    Description: "normalizes logs",
+//	    Processors: picker.Procs{
+//	        picker.LowercaseProcessorParams{Field: "log.level"},
+//	        picker.PipelineProcessorParams{Name: "geoip-enrich"},
+//	    },
+//	}
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/put-pipeline-api.html
+type IngestPipelineParams struct {
+	// Description of the ingest pipeline
+	Description string
+	// Processors used to perform transformations on documents before indexing.
+	// Processors run sequentially in the order specified.
+	Processors Procs
+	// OnFailure are the processors to run immediately after a processor
+	// failure. Each processor supports a processor-level on_failure value. If
+	// a processor without an on_failure value fails, Elasticsearch uses this
+	// pipeline-level parameter as a fallback.
+	OnFailure Procs
+	// Version number used by external systems to track ingest pipelines. A
+	// Version of 0 is omitted.
+	Version int
+	// Meta is optional metadata about the ingest pipeline. May have any
+	// contents. This map is not automatically generated by Elasticsearch.
+	Meta map[string]interface{}
+}
+
+func (p IngestPipelineParams) IngestPipeline() (*IngestPipeline, error) {
+	i := &IngestPipeline{}
+	i.SetDescription(p.Description)
+	err := i.SetProcessors(p.Processors)
+	if err != nil {
+		return i, newFieldError(err, "processors")
+	}
+	err = i.SetOnFailure(p.OnFailure)
+	if err != nil {
+		return i, newFieldError(err, "on_failure")
+	}
+	i.SetVersion(p.Version)
+	i.SetMeta(p.Meta)
+	return i, nil
+}
+
+func NewIngestPipeline(params IngestPipelineParams) (*IngestPipeline, error) {
+	return params.IngestPipeline()
+}
+
+// IngestPipeline is a series of processors which transform documents before
+// they are indexed.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/ingest.html
+type IngestPipeline struct {
+	description string
+	processors  Processors
+	onFailure   Processors
+	version     int
+	meta        map[string]interface{}
+}
+
+// Description of the ingest pipeline
+func (i IngestPipeline) Description() string {
+	return i.description
+}
+
+// SetDescription sets the description of the ingest pipeline to v
+func (i *IngestPipeline) SetDescription(v string) {
+	i.description = v
+}
+
+// Processors used to perform transformations on documents before indexing
+func (i IngestPipeline) Processors() Processors {
+	return i.processors
+}
+
+// SetProcessors sets the processors of the ingest pipeline to v
+func (i *IngestPipeline) SetProcessors(v Processorset) error {
+	if v == nil {
+		i.processors = nil
+		return nil
+	}
+	procs, err := v.Processors()
+	if err != nil {
+		return err
+	}
+	i.processors = procs
+	return nil
+}
+
+// OnFailure are the processors to run immediately after a processor failure
+// when the processor does not define its own on_failure
+func (i IngestPipeline) OnFailure() Processors {
+	return i.onFailure
+}
+
+// SetOnFailure sets on_failure to v
+func (i *IngestPipeline) SetOnFailure(v Processorset) error {
+	if v == nil {
+		i.onFailure = nil
+		return nil
+	}
+	procs, err := v.Processors()
+	if err != nil {
+		return err
+	}
+	if len(procs) == 0 {
+		procs = nil
+	}
+	i.onFailure = procs
+	return nil
+}
+
+// Version number used by external systems to track ingest pipelines
+func (i IngestPipeline) Version() int {
+	return i.version
+}
+
+// SetVersion sets the version of the ingest pipeline to v
+func (i *IngestPipeline) SetVersion(v int) {
+	i.version = v
+}
+
+// Meta is optional metadata about the ingest pipeline
+func (i IngestPipeline) Meta() map[string]interface{} {
+	return i.meta
+}
+
+// SetMeta sets _meta to v
+func (i *IngestPipeline) SetMeta(v map[string]interface{}) {
+	if len(v) == 0 {
+		i.meta = nil
+		return
+	}
+	i.meta = v
+}
+
+// Validate checks that the name of each pipeline processor of i, including
+// those of on_failure and foreach processors, references a pipeline of
+// pipelines. Names which are template snippets are not checked.
+func (i IngestPipeline) Validate(pipelines IngestPipelines) error {
+	return i.walkPipelineRefs(func(path, name string) error {
+		if _, ok := pipelines[name]; !ok {
+			return newFieldError(fmt.Errorf("%w <%s>", ErrPipelineNotFound, name), path)
+		}
+		return nil
+	})
+}
+
+// walkPipelineRefs calls fn with the path and name of each pipeline processor
+// of i which does not use a template snippet for its name
+func (i IngestPipeline) walkPipelineRefs(fn func(path, name string) error) error {
+	var walk func(path string, procs Processors) error
+	walk = func(path string, procs Processors) error {
+		for n, proc := range procs {
+			p := path + "[" + strconv.Itoa(n) + "]"
+			switch v := proc.(type) {
+			case *PipelineProcessor:
+				if !strings.Contains(v.Name(), "{{") {
+					err := fn(p, v.Name())
+					if err != nil {
+						return err
+					}
+				}
+			case *ForeachProcessor:
+				if v.ItemProcessor() != nil {
+					err := walk(p+"->processor", Processors{v.ItemProcessor()})
+					if err != nil {
+						return err
+					}
+				}
+			}
+			err := walk(p+"->on_failure", proc.OnFailure())
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	err := walk("processors", i.processors)
+	if err != nil {
+		return err
+	}
+	return walk("on_failure", i.onFailure)
+}
+
+func (i IngestPipeline) Encode() (*bytes.Buffer, error) {
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	err := encoder.Encode(i)
+	return buf, err
+}
+
+func (i IngestPipeline) MarshalBSON() ([]byte, error) {
+	return i.MarshalJSON()
+}
+
+func (i IngestPipeline) MarshalJSON() ([]byte, error) {
+	procs := i.processors
+	if procs == nil {
+		procs = Processors{}
+	}
+	return ingestPipeline{
+		Description: i.description,
+		Processors:  procs,
+		OnFailure:   i.onFailure,
+		Version:     i.version,
+		Meta:        i.meta,
+	}.MarshalJSON()
+}
+
+func (i *IngestPipeline) UnmarshalBSON(data []byte) error {
+	return i.UnmarshalJSON(data)
+}
+
+func (i *IngestPipeline) UnmarshalJSON(data []byte) error {
+	*i = IngestPipeline{}
+	var p ingestPipeline
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	i.description = p.Description
+	i.processors = p.Processors
+	i.onFailure = p.OnFailure
+	i.version = p.Version
+	i.SetMeta(p.Meta)
+	return nil
+}
+
+//easyjson:json
+type ingestPipeline struct {
+	Description string                 `json:"description,omitempty"`
+	Processors  Processors             `json:"processors"`
+	OnFailure   Processors             `json:"on_failure,omitempty"`
+	Version     int                    `json:"version,omitempty"`
+	Meta        map[string]interface{} `json:"_meta,omitempty"`
+}
+
+// IngestPipelines are ingest pipelines keyed by ID, as returned by the get
+// pipeline API
+type IngestPipelines map[string]*IngestPipeline
+
+// Validate checks that each pipeline processor of p references a pipeline of
+// p and that pipelines do not reference each other in a cycle
+func (p IngestPipelines) Validate() error {
+	for id, pipeline := range p {
+		if pipeline == nil {
+			continue
+		}
+		err := pipeline.Validate(p)
+		if err != nil {
+			return newFieldError(err, id)
+		}
+	}
+	const (
+		visiting = iota + 1
+		visited
+	)
+	state := make(map[string]int, len(p))
+	var visit func(id string, trail []string) error
+	visit = func(id string, trail []string) error {
+		switch state[id] {
+		case visiting:
+			return fmt.Errorf("%w; %s", ErrPipelineCycle, strings.Join(append(trail, id), " -> "))
+		case visited:
+			return nil
+		}
+		state[id] = visiting
+		trail = append(trail, id)
+		if pipeline := p[id]; pipeline != nil {
+			err := pipeline.walkPipelineRefs(func(_, name string) error {
+				return visit(name, trail)
+			})
+			if err != nil {
+				return err
+			}
+		}
+		state[id] = visited
+		return nil
+	}
+	for id := range p {
+		err := visit(id, nil)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
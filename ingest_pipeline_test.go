@@ -0,0 +1,103 @@
+package picker_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/chanced/cmpjson"
+	"github.com/chanced/picker"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIngestPipeline(t *testing.T) {
+	assert := require.New(t)
+	data := []byte(`{
+		"description": "normalizes logs",
+		"processors": [
+			{ "lowercase": { "field": "log.level" } },
+			{ "pipeline": { "name": "geoip-enrich", "if": "ctx.source?.ip != null" } }
+		],
+		"on_failure": [
+			{ "set": { "field": "_index", "value": "failed-{{ _index }}" } }
+		],
+		"version": 3,
+		"_meta": { "owner": "logging" }
+	}`)
+	var p picker.IngestPipeline
+	err := json.Unmarshal(data, &p)
+	assert.NoError(err)
+	assert.Equal("normalizes logs", p.Description())
+	assert.Len(p.Processors(), 2)
+	assert.Len(p.OnFailure(), 1)
+	assert.Equal(3, p.Version())
+	assert.Equal(map[string]interface{}{"owner": "logging"}, p.Meta())
+	res, err := json.Marshal(p)
+	assert.NoError(err)
+	assert.True(cmpjson.Equal(data, res), cmpjson.Diff(data, res))
+
+	p2, err := picker.NewIngestPipeline(picker.IngestPipelineParams{
+		Description: "normalizes logs",
+		Processors: picker.Procs{
+			picker.LowercaseProcessorParams{Field: "log.level"},
+			picker.PipelineProcessorParams{Name: "geoip-enrich", If: "ctx.source?.ip != null"},
+		},
+		OnFailure: picker.Procs{
+			picker.SetProcessorParams{Field: "_index", Value: "failed-{{ _index }}"},
+		},
+		Version: 3,
+		Meta:    map[string]interface{}{"owner": "logging"},
+	})
+	assert.NoError(err)
+	buf, err := p2.Encode()
+	assert.NoError(err)
+	assert.True(cmpjson.Equal(data, buf.Bytes()), cmpjson.Diff(data, buf.Bytes()))
+
+	_, err = picker.NewIngestPipeline(picker.IngestPipelineParams{
+		Processors: picker.Procs{picker.RenameProcessorParams{Field: "a"}},
+	})
+	assert.True(errors.Is(err, picker.ErrTargetFieldRequired))
+
+	empty, err := json.Marshal(picker.IngestPipeline{})
+	assert.NoError(err)
+	assert.JSONEq(`{"processors":[]}`, string(empty))
+}
+
+func TestIngestPipelinesValidate(t *testing.T) {
+	assert := require.New(t)
+	logs, err := picker.NewIngestPipeline(picker.IngestPipelineParams{
+		Processors: picker.Procs{
+			picker.PipelineProcessorParams{Name: "geoip-enrich"},
+			picker.PipelineProcessorParams{Name: "{{ service.name }}-pipeline"},
+			picker.ForeachProcessorParams{
+				Field:         "events",
+				ItemProcessor: picker.PipelineProcessorParams{Name: "event"},
+			},
+		},
+	})
+	assert.NoError(err)
+	geoip, err := picker.NewIngestPipeline(picker.IngestPipelineParams{
+		Processors: picker.Procs{picker.GeoIPProcessorParams{Field: "source.ip"}},
+	})
+	assert.NoError(err)
+
+	pipelines := picker.IngestPipelines{"logs": logs, "geoip-enrich": geoip}
+	err = pipelines.Validate()
+	assert.True(errors.Is(err, picker.ErrPipelineNotFound))
+	var fe *picker.FieldError
+	assert.True(errors.As(err, &fe))
+	assert.Equal("logs->processors[2]->processor[0]", fe.Field)
+
+	event, err := picker.NewIngestPipeline(picker.IngestPipelineParams{
+		Processors: picker.Procs{picker.DropProcessorParams{If: "ctx.event == null"}},
+	})
+	assert.NoError(err)
+	pipelines["event"] = event
+	assert.NoError(pipelines.Validate())
+	assert.NoError(logs.Validate(pipelines))
+
+	err = geoip.SetOnFailure(picker.Procs{picker.PipelineProcessorParams{Name: "logs"}})
+	assert.NoError(err)
+	err = pipelines.Validate()
+	assert.True(errors.Is(err, picker.ErrPipelineCycle))
+}
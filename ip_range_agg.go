@@ -0,0 +1,148 @@
+package picker
+
+// IPRange is a range used by the ip_range aggregation. Either Mask or one or
+// both of From and To should be set.
+//easyjson:json
+type IPRange struct {
+	Key  string `json:"key,omitempty"`
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+	// Mask is a CIDR mask, such as "10.0.0.0/25"
+	Mask string `json:"mask,omitempty"`
+}
+
+// IPRangeAggParams creates an IPRangeAgg, a range aggregation dedicated to IP
+// values.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-iprange-aggregation.html
+type IPRangeAggParams struct {
+	// The field to aggregate on. Either Field or Script is required.
+	Field   string
+	Script  *Script
+	Missing interface{}
+	// The ranges of the buckets (Required)
+	Ranges []IPRange
+	// If true, buckets are returned as a hash rather than an array, keyed by
+	// the bucket key.
+	Keyed bool
+	// Sub-aggregations
+	Aggregations Aggs
+	Meta         map[string]interface{}
+}
+
+func (IPRangeAggParams) Kind() AggKind {
+	return AggKindIPPange
+}
+
+func (p IPRangeAggParams) Clause() (AggClause, error) {
+	return p.IPRange()
+}
+
+func (p IPRangeAggParams) IPRange() (*IPRangeAgg, error) {
+	a := &IPRangeAgg{}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	err := a.checkValueSource()
+	if err != nil {
+		return a, newAggError(err, AggKindIPPange)
+	}
+	a.SetMissing(p.Missing)
+	err = a.SetRanges(p.Ranges)
+	if err != nil {
+		return a, newAggError(err, AggKindIPPange)
+	}
+	a.SetKeyed(p.Keyed)
+	err = a.SetAggregations(p.Aggregations)
+	if err != nil {
+		return a, newAggError(err, AggKindIPPange)
+	}
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// IPRangeAgg is a range aggregation dedicated to IP values.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-iprange-aggregation.html
+type IPRangeAgg struct {
+	valueSourceParam
+	ranges []IPRange
+	keyed  bool
+	aggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*IPRangeAgg)(nil)
+
+func (IPRangeAgg) Kind() AggKind {
+	return AggKindIPPange
+}
+
+func (a *IPRangeAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// Ranges of the buckets
+func (a IPRangeAgg) Ranges() []IPRange {
+	return a.ranges
+}
+
+// SetRanges sets the ranges to v. At least one range is required.
+func (a *IPRangeAgg) SetRanges(v []IPRange) error {
+	if len(v) == 0 {
+		return ErrRangesRequired
+	}
+	a.ranges = v
+	return nil
+}
+
+// Keyed indicates whether buckets are returned as a hash rather than an array
+func (a IPRangeAgg) Keyed() bool {
+	return a.keyed
+}
+
+// SetKeyed sets keyed to v
+func (a *IPRangeAgg) SetKeyed(v bool) {
+	a.keyed = v
+}
+
+func (a IPRangeAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a IPRangeAgg) MarshalJSON() ([]byte, error) {
+	return ipRangeAgg{
+		Field:   a.field,
+		Script:  a.script,
+		Missing: a.missing,
+		Ranges:  a.ranges,
+		Keyed:   a.keyed,
+	}.MarshalJSON()
+}
+
+func (a *IPRangeAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *IPRangeAgg) UnmarshalJSON(data []byte) error {
+	*a = IPRangeAgg{}
+	p := ipRangeAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	a.SetMissing(p.Missing)
+	a.ranges = p.Ranges
+	a.SetKeyed(p.Keyed)
+	return nil
+}
+
+//easyjson:json
+type ipRangeAgg struct {
+	Field   string      `json:"field,omitempty"`
+	Script  *Script     `json:"script,omitempty"`
+	Missing interface{} `json:"missing,omitempty"`
+	Ranges  []IPRange   `json:"ranges"`
+	Keyed   bool        `json:"keyed,omitempty"`
+}
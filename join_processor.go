@@ -0,0 +1,142 @@
+package picker
+
+// JoinProcessorParams creates a JoinProcessor, which joins each element of an
+// array into a single string using a separator character between each element.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/join-processor.html
+type JoinProcessorParams struct {
+	// Field is the field containing array values to join
+	Field string
+	// Separator is the separator character
+	Separator string
+	// TargetField is the field to assign the output to. By default, field is
+	// updated in-place.
+	TargetField string
+	// Description of the processor. Useful for describing the purpose of the
+	// processor or its configuration.
+	Description string
+	// Conditionally execute the processor
+	If string
+	// Ignore failures for the processor
+	IgnoreFailure bool
+	// Handle failures for the processor
+	OnFailure Procs
+	// Identifier for the processor. Useful for debugging and metrics.
+	Tag string
+}
+
+func (JoinProcessorParams) Kind() ProcessorKind {
+	return ProcessorKindJoin
+}
+
+func (p JoinProcessorParams) Processor() (Processor, error) {
+	return p.Join()
+}
+
+func (p JoinProcessorParams) Join() (*JoinProcessor, error) {
+	proc := &JoinProcessor{}
+	err := proc.SetField(p.Field)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindJoin)
+	}
+	err = proc.SetSeparator(p.Separator)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindJoin)
+	}
+	proc.SetTargetField(p.TargetField)
+	proc.SetDescription(p.Description)
+	proc.SetIf(p.If)
+	proc.SetIgnoreFailure(p.IgnoreFailure)
+	err = proc.SetOnFailure(p.OnFailure)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindJoin)
+	}
+	proc.SetTag(p.Tag)
+	return proc, nil
+}
+
+// JoinProcessor joins each element of an array into a single string using a
+// separator character between each element.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/join-processor.html
+type JoinProcessor struct {
+	fieldParam
+	separator string
+	targetFieldParam
+	processorParams
+}
+
+var _ Processor = (*JoinProcessor)(nil)
+
+func (JoinProcessor) Kind() ProcessorKind {
+	return ProcessorKindJoin
+}
+
+func (p *JoinProcessor) Processor() (Processor, error) {
+	return p, nil
+}
+
+// Separator is the separator character
+func (p JoinProcessor) Separator() string {
+	return p.separator
+}
+
+// SetSeparator sets separator to v
+func (p *JoinProcessor) SetSeparator(v string) error {
+	if len(v) == 0 {
+		return ErrSeparatorRequired
+	}
+	p.separator = v
+	return nil
+}
+
+func (p JoinProcessor) MarshalBSON() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+func (p JoinProcessor) MarshalJSON() ([]byte, error) {
+	return joinProcessor{
+		Field:         p.field,
+		Separator:     p.separator,
+		TargetField:   p.targetField,
+		Description:   p.description,
+		If:            p.ifCond,
+		IgnoreFailure: p.ignoreFailure,
+		OnFailure:     p.onFailure,
+		Tag:           p.tag,
+	}.MarshalJSON()
+}
+
+func (p *JoinProcessor) UnmarshalBSON(data []byte) error {
+	return p.UnmarshalJSON(data)
+}
+
+func (p *JoinProcessor) UnmarshalJSON(data []byte) error {
+	*p = JoinProcessor{}
+	var v joinProcessor
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	p.field = v.Field
+	p.separator = v.Separator
+	p.targetField = v.TargetField
+	p.description = v.Description
+	p.ifCond = v.If
+	p.ignoreFailure = v.IgnoreFailure
+	p.onFailure = v.OnFailure
+	p.tag = v.Tag
+	return nil
+}
+
+//easyjson:json
+type joinProcessor struct {
+	Field         string     `json:"field,omitempty"`
+	Separator     string     `json:"separator,omitempty"`
+	TargetField   string     `json:"target_field,omitempty"`
+	Description   string     `json:"description,omitempty"`
+	If            string     `json:"if,omitempty"`
+	IgnoreFailure bool       `json:"ignore_failure,omitempty"`
+	OnFailure     Processors `json:"on_failure,omitempty"`
+	Tag           string     `json:"tag,omitempty"`
+}
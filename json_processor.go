@@ -0,0 +1,174 @@
+package picker
+
+// JSONProcessorParams creates a JSONProcessor, which converts a JSON string
+// into a structured JSON object.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/json-processor.html
+type JSONProcessorParams struct {
+	// Field is the field to be parsed
+	Field string
+	// TargetField is the field to assign the output to. By default, field is
+	// updated in-place.
+	TargetField string
+	// AddToRoot indicates whether the JSON is added to the root of the
+	// document. Cannot be set with target_field.
+	AddToRoot bool
+	// AddToRootConflictStrategy determines how conflicting fields are handled
+	// when add_to_root is true. Either "replace" (default) or "merge".
+	AddToRootConflictStrategy string
+	// AllowDuplicateKeys indicates whether the JSON may contain duplicate keys.
+	// If true, the last encountered value for a duplicate key wins.
+	AllowDuplicateKeys bool
+	// Description of the processor. Useful for describing the purpose of the
+	// processor or its configuration.
+	Description string
+	// Conditionally execute the processor
+	If string
+	// Ignore failures for the processor
+	IgnoreFailure bool
+	// Handle failures for the processor
+	OnFailure Procs
+	// Identifier for the processor. Useful for debugging and metrics.
+	Tag string
+}
+
+func (JSONProcessorParams) Kind() ProcessorKind {
+	return ProcessorKindJSON
+}
+
+func (p JSONProcessorParams) Processor() (Processor, error) {
+	return p.JSON()
+}
+
+func (p JSONProcessorParams) JSON() (*JSONProcessor, error) {
+	proc := &JSONProcessor{}
+	err := proc.SetField(p.Field)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindJSON)
+	}
+	proc.SetTargetField(p.TargetField)
+	proc.SetAddToRoot(p.AddToRoot)
+	proc.SetAddToRootConflictStrategy(p.AddToRootConflictStrategy)
+	proc.SetAllowDuplicateKeys(p.AllowDuplicateKeys)
+	proc.SetDescription(p.Description)
+	proc.SetIf(p.If)
+	proc.SetIgnoreFailure(p.IgnoreFailure)
+	err = proc.SetOnFailure(p.OnFailure)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindJSON)
+	}
+	proc.SetTag(p.Tag)
+	return proc, nil
+}
+
+// JSONProcessor converts a JSON string into a structured JSON object.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/json-processor.html
+type JSONProcessor struct {
+	fieldParam
+	targetFieldParam
+	addToRoot                 bool
+	addToRootConflictStrategy string
+	allowDuplicateKeys        bool
+	processorParams
+}
+
+var _ Processor = (*JSONProcessor)(nil)
+
+func (JSONProcessor) Kind() ProcessorKind {
+	return ProcessorKindJSON
+}
+
+func (p *JSONProcessor) Processor() (Processor, error) {
+	return p, nil
+}
+
+// AddToRoot indicates whether the JSON is added to the root of the document.
+// Cannot be set with target_field.
+func (p JSONProcessor) AddToRoot() bool {
+	return p.addToRoot
+}
+
+// SetAddToRoot sets add_to_root to v
+func (p *JSONProcessor) SetAddToRoot(v bool) {
+	p.addToRoot = v
+}
+
+// AddToRootConflictStrategy determines how conflicting fields are handled when
+// add_to_root is true. Either "replace" (default) or "merge".
+func (p JSONProcessor) AddToRootConflictStrategy() string {
+	return p.addToRootConflictStrategy
+}
+
+// SetAddToRootConflictStrategy sets add_to_root_conflict_strategy to v
+func (p *JSONProcessor) SetAddToRootConflictStrategy(v string) {
+	p.addToRootConflictStrategy = v
+}
+
+// AllowDuplicateKeys indicates whether the JSON may contain duplicate keys. If
+// true, the last encountered value for a duplicate key wins.
+func (p JSONProcessor) AllowDuplicateKeys() bool {
+	return p.allowDuplicateKeys
+}
+
+// SetAllowDuplicateKeys sets allow_duplicate_keys to v
+func (p *JSONProcessor) SetAllowDuplicateKeys(v bool) {
+	p.allowDuplicateKeys = v
+}
+
+func (p JSONProcessor) MarshalBSON() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+func (p JSONProcessor) MarshalJSON() ([]byte, error) {
+	return jsonProcessor{
+		Field:                     p.field,
+		TargetField:               p.targetField,
+		AddToRoot:                 p.addToRoot,
+		AddToRootConflictStrategy: p.addToRootConflictStrategy,
+		AllowDuplicateKeys:        p.allowDuplicateKeys,
+		Description:               p.description,
+		If:                        p.ifCond,
+		IgnoreFailure:             p.ignoreFailure,
+		OnFailure:                 p.onFailure,
+		Tag:                       p.tag,
+	}.MarshalJSON()
+}
+
+func (p *JSONProcessor) UnmarshalBSON(data []byte) error {
+	return p.UnmarshalJSON(data)
+}
+
+func (p *JSONProcessor) UnmarshalJSON(data []byte) error {
+	*p = JSONProcessor{}
+	var v jsonProcessor
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	p.field = v.Field
+	p.targetField = v.TargetField
+	p.addToRoot = v.AddToRoot
+	p.addToRootConflictStrategy = v.AddToRootConflictStrategy
+	p.allowDuplicateKeys = v.AllowDuplicateKeys
+	p.description = v.Description
+	p.ifCond = v.If
+	p.ignoreFailure = v.IgnoreFailure
+	p.onFailure = v.OnFailure
+	p.tag = v.Tag
+	return nil
+}
+
+//easyjson:json
+type jsonProcessor struct {
+	Field                     string     `json:"field,omitempty"`
+	TargetField               string     `json:"target_field,omitempty"`
+	AddToRoot                 bool       `json:"add_to_root,omitempty"`
+	AddToRootConflictStrategy string     `json:"add_to_root_conflict_strategy,omitempty"`
+	AllowDuplicateKeys        bool       `json:"allow_duplicate_keys,omitempty"`
+	Description               string     `json:"description,omitempty"`
+	If                        string     `json:"if,omitempty"`
+	IgnoreFailure             bool       `json:"ignore_failure,omitempty"`
+	OnFailure                 Processors `json:"on_failure,omitempty"`
+	Tag                       string     `json:"tag,omitempty"`
+}
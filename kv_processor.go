@@ -0,0 +1,282 @@
+package picker
+
+// KVProcessorParams creates a KVProcessor, which automatically parses messages
+// (or specific event fields) which are of the foo=bar variety.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/kv-processor.html
+type KVProcessorParams struct {
+	// Field is the field to be parsed. Supports template snippets.
+	Field string
+	// FieldSplit is the regex pattern to use for splitting key-value pairs
+	FieldSplit string
+	// ValueSplit is the regex pattern to use for splitting the key from the
+	// value within a key-value pair
+	ValueSplit string
+	// TargetField is the field to insert the extracted keys into. Defaults to
+	// the root of the document. Supports template snippets.
+	TargetField string
+	// IncludeKeys is a list of keys to filter and insert into document.
+	// Defaults to including all keys.
+	IncludeKeys []string
+	// ExcludeKeys is a list of keys to exclude from document
+	ExcludeKeys []string
+	// If IgnoreMissing is true and field does not exist or is null, the
+	// processor quietly exits without modifying the document
+	IgnoreMissing bool
+	// Prefix to be added to extracted keys
+	Prefix string
+	// TrimKey is a string of characters to trim from extracted keys
+	TrimKey string
+	// TrimValue is a string of characters to trim from extracted values
+	TrimValue string
+	// StripBrackets indicates whether brackets ( (), <>, [] ) and quotes ( ', "
+	// ) are stripped from extracted values
+	StripBrackets bool
+	// Description of the processor. Useful for describing the purpose of the
+	// processor or its configuration.
+	Description string
+	// Conditionally execute the processor
+	If string
+	// Ignore failures for the processor
+	IgnoreFailure bool
+	// Handle failures for the processor
+	OnFailure Procs
+	// Identifier for the processor. Useful for debugging and metrics.
+	Tag string
+}
+
+func (KVProcessorParams) Kind() ProcessorKind {
+	return ProcessorKindKV
+}
+
+func (p KVProcessorParams) Processor() (Processor, error) {
+	return p.KV()
+}
+
+func (p KVProcessorParams) KV() (*KVProcessor, error) {
+	proc := &KVProcessor{}
+	err := proc.SetField(p.Field)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindKV)
+	}
+	err = proc.SetFieldSplit(p.FieldSplit)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindKV)
+	}
+	err = proc.SetValueSplit(p.ValueSplit)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindKV)
+	}
+	proc.SetTargetField(p.TargetField)
+	proc.SetIncludeKeys(p.IncludeKeys)
+	proc.SetExcludeKeys(p.ExcludeKeys)
+	proc.SetIgnoreMissing(p.IgnoreMissing)
+	proc.SetPrefix(p.Prefix)
+	proc.SetTrimKey(p.TrimKey)
+	proc.SetTrimValue(p.TrimValue)
+	proc.SetStripBrackets(p.StripBrackets)
+	proc.SetDescription(p.Description)
+	proc.SetIf(p.If)
+	proc.SetIgnoreFailure(p.IgnoreFailure)
+	err = proc.SetOnFailure(p.OnFailure)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindKV)
+	}
+	proc.SetTag(p.Tag)
+	return proc, nil
+}
+
+// KVProcessor automatically parses messages (or specific event fields) which
+// are of the foo=bar variety.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/kv-processor.html
+type KVProcessor struct {
+	fieldParam
+	fieldSplit string
+	valueSplit string
+	targetFieldParam
+	includeKeys []string
+	excludeKeys []string
+	ignoreMissingParam
+	prefix        string
+	trimKey       string
+	trimValue     string
+	stripBrackets bool
+	processorParams
+}
+
+var _ Processor = (*KVProcessor)(nil)
+
+func (KVProcessor) Kind() ProcessorKind {
+	return ProcessorKindKV
+}
+
+func (p *KVProcessor) Processor() (Processor, error) {
+	return p, nil
+}
+
+// FieldSplit is the regex pattern to use for splitting key-value pairs
+func (p KVProcessor) FieldSplit() string {
+	return p.fieldSplit
+}
+
+// SetFieldSplit sets field_split to v
+func (p *KVProcessor) SetFieldSplit(v string) error {
+	if len(v) == 0 {
+		return ErrFieldSplitRequired
+	}
+	p.fieldSplit = v
+	return nil
+}
+
+// ValueSplit is the regex pattern to use for splitting the key from the value
+// within a key-value pair
+func (p KVProcessor) ValueSplit() string {
+	return p.valueSplit
+}
+
+// SetValueSplit sets value_split to v
+func (p *KVProcessor) SetValueSplit(v string) error {
+	if len(v) == 0 {
+		return ErrValueSplitRequired
+	}
+	p.valueSplit = v
+	return nil
+}
+
+// IncludeKeys is a list of keys to filter and insert into document. Defaults to
+// including all keys.
+func (p KVProcessor) IncludeKeys() []string {
+	return p.includeKeys
+}
+
+// SetIncludeKeys sets include_keys to v
+func (p *KVProcessor) SetIncludeKeys(v []string) {
+	p.includeKeys = v
+}
+
+// ExcludeKeys is a list of keys to exclude from document
+func (p KVProcessor) ExcludeKeys() []string {
+	return p.excludeKeys
+}
+
+// SetExcludeKeys sets exclude_keys to v
+func (p *KVProcessor) SetExcludeKeys(v []string) {
+	p.excludeKeys = v
+}
+
+// Prefix to be added to extracted keys
+func (p KVProcessor) Prefix() string {
+	return p.prefix
+}
+
+// SetPrefix sets prefix to v
+func (p *KVProcessor) SetPrefix(v string) {
+	p.prefix = v
+}
+
+// TrimKey is a string of characters to trim from extracted keys
+func (p KVProcessor) TrimKey() string {
+	return p.trimKey
+}
+
+// SetTrimKey sets trim_key to v
+func (p *KVProcessor) SetTrimKey(v string) {
+	p.trimKey = v
+}
+
+// TrimValue is a string of characters to trim from extracted values
+func (p KVProcessor) TrimValue() string {
+	return p.trimValue
+}
+
+// SetTrimValue sets trim_value to v
+func (p *KVProcessor) SetTrimValue(v string) {
+	p.trimValue = v
+}
+
+// StripBrackets indicates whether brackets ( (), <>, [] ) and quotes ( ', " )
+// are stripped from extracted values
+func (p KVProcessor) StripBrackets() bool {
+	return p.stripBrackets
+}
+
+// SetStripBrackets sets strip_brackets to v
+func (p *KVProcessor) SetStripBrackets(v bool) {
+	p.stripBrackets = v
+}
+
+func (p KVProcessor) MarshalBSON() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+func (p KVProcessor) MarshalJSON() ([]byte, error) {
+	return kvProcessor{
+		Field:         p.field,
+		FieldSplit:    p.fieldSplit,
+		ValueSplit:    p.valueSplit,
+		TargetField:   p.targetField,
+		IncludeKeys:   p.includeKeys,
+		ExcludeKeys:   p.excludeKeys,
+		IgnoreMissing: p.ignoreMissing,
+		Prefix:        p.prefix,
+		TrimKey:       p.trimKey,
+		TrimValue:     p.trimValue,
+		StripBrackets: p.stripBrackets,
+		Description:   p.description,
+		If:            p.ifCond,
+		IgnoreFailure: p.ignoreFailure,
+		OnFailure:     p.onFailure,
+		Tag:           p.tag,
+	}.MarshalJSON()
+}
+
+func (p *KVProcessor) UnmarshalBSON(data []byte) error {
+	return p.UnmarshalJSON(data)
+}
+
+func (p *KVProcessor) UnmarshalJSON(data []byte) error {
+	*p = KVProcessor{}
+	var v kvProcessor
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	p.field = v.Field
+	p.fieldSplit = v.FieldSplit
+	p.valueSplit = v.ValueSplit
+	p.targetField = v.TargetField
+	p.includeKeys = v.IncludeKeys
+	p.excludeKeys = v.ExcludeKeys
+	p.ignoreMissing = v.IgnoreMissing
+	p.prefix = v.Prefix
+	p.trimKey = v.TrimKey
+	p.trimValue = v.TrimValue
+	p.stripBrackets = v.StripBrackets
+	p.description = v.Description
+	p.ifCond = v.If
+	p.ignoreFailure = v.IgnoreFailure
+	p.onFailure = v.OnFailure
+	p.tag = v.Tag
+	return nil
+}
+
+//easyjson:json
+type kvProcessor struct {
+	Field         string     `json:"field,omitempty"`
+	FieldSplit    string     `json:"field_split,omitempty"`
+	ValueSplit    string     `json:"value_split,omitempty"`
+	TargetField   string     `json:"target_field,omitempty"`
+	IncludeKeys   []string   `json:"include_keys,omitempty"`
+	ExcludeKeys   []string   `json:"exclude_keys,omitempty"`
+	IgnoreMissing bool       `json:"ignore_missing,omitempty"`
+	Prefix        string     `json:"prefix,omitempty"`
+	TrimKey       string     `json:"trim_key,omitempty"`
+	TrimValue     string     `json:"trim_value,omitempty"`
+	StripBrackets bool       `json:"strip_brackets,omitempty"`
+	Description   string     `json:"description,omitempty"`
+	If            string     `json:"if,omitempty"`
+	IgnoreFailure bool       `json:"ignore_failure,omitempty"`
+	OnFailure     Processors `json:"on_failure,omitempty"`
+	Tag           string     `json:"tag,omitempty"`
+}
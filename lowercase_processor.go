@@ -0,0 +1,127 @@
+package picker
+
+// LowercaseProcessorParams creates a LowercaseProcessor, which converts a
+// string to its lowercase equivalent. If the field is an array of strings, all
+// members of the array will be converted.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/lowercase-processor.html
+type LowercaseProcessorParams struct {
+	// Field is the field to make lowercase
+	Field string
+	// TargetField is the field to assign the output to. By default, field is
+	// updated in-place.
+	TargetField string
+	// If IgnoreMissing is true and field does not exist or is null, the
+	// processor quietly exits without modifying the document
+	IgnoreMissing bool
+	// Description of the processor. Useful for describing the purpose of the
+	// processor or its configuration.
+	Description string
+	// Conditionally execute the processor
+	If string
+	// Ignore failures for the processor
+	IgnoreFailure bool
+	// Handle failures for the processor
+	OnFailure Procs
+	// Identifier for the processor. Useful for debugging and metrics.
+	Tag string
+}
+
+func (LowercaseProcessorParams) Kind() ProcessorKind {
+	return ProcessorKindLowercase
+}
+
+func (p LowercaseProcessorParams) Processor() (Processor, error) {
+	return p.Lowercase()
+}
+
+func (p LowercaseProcessorParams) Lowercase() (*LowercaseProcessor, error) {
+	proc := &LowercaseProcessor{}
+	err := proc.SetField(p.Field)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindLowercase)
+	}
+	proc.SetTargetField(p.TargetField)
+	proc.SetIgnoreMissing(p.IgnoreMissing)
+	proc.SetDescription(p.Description)
+	proc.SetIf(p.If)
+	proc.SetIgnoreFailure(p.IgnoreFailure)
+	err = proc.SetOnFailure(p.OnFailure)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindLowercase)
+	}
+	proc.SetTag(p.Tag)
+	return proc, nil
+}
+
+// LowercaseProcessor converts a string to its lowercase equivalent. If the
+// field is an array of strings, all members of the array will be converted.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/lowercase-processor.html
+type LowercaseProcessor struct {
+	fieldParam
+	targetFieldParam
+	ignoreMissingParam
+	processorParams
+}
+
+var _ Processor = (*LowercaseProcessor)(nil)
+
+func (LowercaseProcessor) Kind() ProcessorKind {
+	return ProcessorKindLowercase
+}
+
+func (p *LowercaseProcessor) Processor() (Processor, error) {
+	return p, nil
+}
+
+func (p LowercaseProcessor) MarshalBSON() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+func (p LowercaseProcessor) MarshalJSON() ([]byte, error) {
+	return lowercaseProcessor{
+		Field:         p.field,
+		TargetField:   p.targetField,
+		IgnoreMissing: p.ignoreMissing,
+		Description:   p.description,
+		If:            p.ifCond,
+		IgnoreFailure: p.ignoreFailure,
+		OnFailure:     p.onFailure,
+		Tag:           p.tag,
+	}.MarshalJSON()
+}
+
+func (p *LowercaseProcessor) UnmarshalBSON(data []byte) error {
+	return p.UnmarshalJSON(data)
+}
+
+func (p *LowercaseProcessor) UnmarshalJSON(data []byte) error {
+	*p = LowercaseProcessor{}
+	var v lowercaseProcessor
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	p.field = v.Field
+	p.targetField = v.TargetField
+	p.ignoreMissing = v.IgnoreMissing
+	p.description = v.Description
+	p.ifCond = v.If
+	p.ignoreFailure = v.IgnoreFailure
+	p.onFailure = v.OnFailure
+	p.tag = v.Tag
+	return nil
+}
+
+//easyjson:json
+type lowercaseProcessor struct {
+	Field         string     `json:"field,omitempty"`
+	TargetField   string     `json:"target_field,omitempty"`
+	IgnoreMissing bool       `json:"ignore_missing,omitempty"`
+	Description   string     `json:"description,omitempty"`
+	If            string     `json:"if,omitempty"`
+	IgnoreFailure bool       `json:"ignore_failure,omitempty"`
+	OnFailure     Processors `json:"on_failure,omitempty"`
+	Tag           string     `json:"tag,omitempty"`
+}
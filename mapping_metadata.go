@@ -0,0 +1,256 @@
+package picker
+
+import (
+	"encoding/json"
+
+	"github.com/chanced/dynamic"
+)
+
+// SourceMappingParams are the params of the _source metadata field
+type SourceMappingParams struct {
+	// Enabled determines whether the original JSON document body is stored.
+	// Defaults to true.
+	Enabled interface{} `json:"enabled,omitempty"`
+	// Includes are the paths of fields to include in the stored _source
+	Includes []string `json:"includes,omitempty"`
+	// Excludes are the paths of fields to exclude from the stored _source
+	Excludes []string `json:"excludes,omitempty"`
+}
+
+func (p SourceMappingParams) SourceMapping() (*SourceMapping, error) {
+	s := &SourceMapping{}
+	err := s.SetEnabled(p.Enabled)
+	if err != nil {
+		return s, newFieldError(err, "_source")
+	}
+	s.SetIncludes(p.Includes)
+	s.SetExcludes(p.Excludes)
+	return s, nil
+}
+
+// SourceMapping is the _source metadata field, which contains the original
+// JSON document body that was passed at index time.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/mapping-source-field.html
+type SourceMapping struct {
+	enabledParam
+	includes []string
+	excludes []string
+}
+
+// Includes are the paths of fields to include in the stored _source
+func (s SourceMapping) Includes() []string {
+	return s.includes
+}
+
+// SetIncludes sets the includes of _source to v
+func (s *SourceMapping) SetIncludes(v []string) {
+	s.includes = v
+}
+
+// Excludes are the paths of fields to exclude from the stored _source
+func (s SourceMapping) Excludes() []string {
+	return s.excludes
+}
+
+// SetExcludes sets the excludes of _source to v
+func (s *SourceMapping) SetExcludes(v []string) {
+	s.excludes = v
+}
+
+func (s SourceMapping) MarshalBSON() ([]byte, error) {
+	return s.MarshalJSON()
+}
+
+func (s SourceMapping) MarshalJSON() ([]byte, error) {
+	return json.Marshal(SourceMappingParams{
+		Enabled:  s.enabled.Value(),
+		Includes: s.includes,
+		Excludes: s.excludes,
+	})
+}
+
+func (s *SourceMapping) UnmarshalBSON(data []byte) error {
+	return s.UnmarshalJSON(data)
+}
+
+func (s *SourceMapping) UnmarshalJSON(data []byte) error {
+	var p SourceMappingParams
+	err := json.Unmarshal(data, &p)
+	if err != nil {
+		return err
+	}
+	v, err := p.SourceMapping()
+	*s = *v
+	return err
+}
+
+// RoutingMappingParams are the params of the _routing metadata field
+type RoutingMappingParams struct {
+	// Required determines whether a custom routing value is required for all
+	// document operations. Defaults to false.
+	Required interface{} `json:"required,omitempty"`
+}
+
+func (p RoutingMappingParams) RoutingMapping() (*RoutingMapping, error) {
+	r := &RoutingMapping{}
+	err := r.SetRequired(p.Required)
+	if err != nil {
+		return r, newFieldError(err, "_routing")
+	}
+	return r, nil
+}
+
+// RoutingMapping is the _routing metadata field. A document is routed to a
+// particular shard in an index by its _id or, if set, a custom routing value.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/mapping-routing-field.html
+type RoutingMapping struct {
+	required dynamic.Bool
+}
+
+// Required determines whether a custom routing value is required for all
+// document operations. Defaults to false.
+func (r RoutingMapping) Required() bool {
+	if b, ok := r.required.Bool(); ok {
+		return b
+	}
+	return false
+}
+
+// SetRequired sets required to v
+func (r *RoutingMapping) SetRequired(v interface{}) error {
+	return r.required.Set(v)
+}
+
+func (r RoutingMapping) MarshalBSON() ([]byte, error) {
+	return r.MarshalJSON()
+}
+
+func (r RoutingMapping) MarshalJSON() ([]byte, error) {
+	return json.Marshal(RoutingMappingParams{Required: r.required.Value()})
+}
+
+func (r *RoutingMapping) UnmarshalBSON(data []byte) error {
+	return r.UnmarshalJSON(data)
+}
+
+func (r *RoutingMapping) UnmarshalJSON(data []byte) error {
+	var p RoutingMappingParams
+	err := json.Unmarshal(data, &p)
+	if err != nil {
+		return err
+	}
+	v, err := p.RoutingMapping()
+	*r = *v
+	return err
+}
+
+// FieldNamesMappingParams are the params of the _field_names metadata field
+type FieldNamesMappingParams struct {
+	// Enabled is deprecated and will be removed in a future version
+	Enabled interface{} `json:"enabled,omitempty"`
+}
+
+func (p FieldNamesMappingParams) FieldNamesMapping() (*FieldNamesMapping, error) {
+	f := &FieldNamesMapping{}
+	err := f.SetEnabled(p.Enabled)
+	if err != nil {
+		return f, newFieldError(err, "_field_names")
+	}
+	return f, nil
+}
+
+// FieldNamesMapping is the _field_names metadata field, which indexes the
+// names of every field in a document that contains any value other than
+// null.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/mapping-field-names-field.html
+type FieldNamesMapping struct {
+	enabledParam
+}
+
+func (f FieldNamesMapping) MarshalBSON() ([]byte, error) {
+	return f.MarshalJSON()
+}
+
+func (f FieldNamesMapping) MarshalJSON() ([]byte, error) {
+	return json.Marshal(FieldNamesMappingParams{Enabled: f.enabled.Value()})
+}
+
+func (f *FieldNamesMapping) UnmarshalBSON(data []byte) error {
+	return f.UnmarshalJSON(data)
+}
+
+func (f *FieldNamesMapping) UnmarshalJSON(data []byte) error {
+	var p FieldNamesMappingParams
+	err := json.Unmarshal(data, &p)
+	if err != nil {
+		return err
+	}
+	v, err := p.FieldNamesMapping()
+	*f = *v
+	return err
+}
+
+// DataStreamTimestampMappingParams are the params of the
+// _data_stream_timestamp metadata field
+type DataStreamTimestampMappingParams struct {
+	// Enabled determines whether documents must have a @timestamp field.
+	// Defaults to false.
+	Enabled interface{} `json:"enabled,omitempty"`
+}
+
+func (p DataStreamTimestampMappingParams) DataStreamTimestampMapping() (*DataStreamTimestampMapping, error) {
+	d := &DataStreamTimestampMapping{}
+	err := d.SetEnabled(p.Enabled)
+	if err != nil {
+		return d, newFieldError(err, "_data_stream_timestamp")
+	}
+	return d, nil
+}
+
+// DataStreamTimestampMapping is the _data_stream_timestamp metadata field of
+// the backing indices of data streams
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/mapping-data-stream-timestamp-field.html
+type DataStreamTimestampMapping struct {
+	enabled dynamic.Bool
+}
+
+// Enabled determines whether documents must have a @timestamp field.
+// Defaults to false.
+func (d DataStreamTimestampMapping) Enabled() bool {
+	if b, ok := d.enabled.Bool(); ok {
+		return b
+	}
+	return false
+}
+
+// SetEnabled sets enabled to v
+func (d *DataStreamTimestampMapping) SetEnabled(v interface{}) error {
+	return d.enabled.Set(v)
+}
+
+func (d DataStreamTimestampMapping) MarshalBSON() ([]byte, error) {
+	return d.MarshalJSON()
+}
+
+func (d DataStreamTimestampMapping) MarshalJSON() ([]byte, error) {
+	return json.Marshal(DataStreamTimestampMappingParams{Enabled: d.enabled.Value()})
+}
+
+func (d *DataStreamTimestampMapping) UnmarshalBSON(data []byte) error {
+	return d.UnmarshalJSON(data)
+}
+
+func (d *DataStreamTimestampMapping) UnmarshalJSON(data []byte) error {
+	var p DataStreamTimestampMappingParams
+	err := json.Unmarshal(data, &p)
+	if err != nil {
+		return err
+	}
+	v, err := p.DataStreamTimestampMapping()
+	*d = *v
+	return err
+}
@@ -1,19 +1,100 @@
 package picker
 
-import "encoding/json"
+import (
+	"encoding/json"
+
+	"github.com/chanced/dynamic"
+)
 
 type mappings struct {
-	Properties Fields `json:"properties"`
+	Dynamic             Dynamic                     `json:"dynamic,omitempty"`
+	DateDetection       interface{}                 `json:"date_detection,omitempty"`
+	DynamicDateFormats  []string                    `json:"dynamic_date_formats,omitempty"`
+	NumericDetection    interface{}                 `json:"numeric_detection,omitempty"`
+	Source              *SourceMapping              `json:"_source,omitempty"`
+	Routing             *RoutingMapping             `json:"_routing,omitempty"`
+	FieldNames          *FieldNamesMapping          `json:"_field_names,omitempty"`
+	DataStreamTimestamp *DataStreamTimestampMapping `json:"_data_stream_timestamp,omitempty"`
+	Meta                map[string]interface{}      `json:"_meta,omitempty"`
+	DynamicTemplates    DynamicTemplates            `json:"dynamic_templates,omitempty"`
+	Runtime             RuntimeMappings             `json:"runtime,omitempty"`
+	Properties          Fields                      `json:"properties"`
 }
 
 type Mappings struct {
-	Properties FieldMap `json:"properties"`
+	// Dynamic determines whether new fields are added to the mapping
+	// dynamically
+	Dynamic Dynamic `json:"dynamic,omitempty"`
+	// DateDetection determines whether string fields are checked to see if
+	// their contents match any of the date patterns of DynamicDateFormats.
+	// Defaults to true.
+	DateDetection interface{} `json:"date_detection,omitempty"`
+	// DynamicDateFormats are the date patterns used by date detection.
+	DynamicDateFormats []string `json:"dynamic_date_formats,omitempty"`
+	// NumericDetection determines whether strings which contain numbers are
+	// mapped as numeric fields. Defaults to false.
+	NumericDetection interface{} `json:"numeric_detection,omitempty"`
+	// Source configures the _source metadata field
+	Source *SourceMappingParams `json:"_source,omitempty"`
+	// Routing configures the _routing metadata field
+	Routing *RoutingMappingParams `json:"_routing,omitempty"`
+	// FieldNames configures the _field_names metadata field
+	FieldNames *FieldNamesMappingParams `json:"_field_names,omitempty"`
+	// DataStreamTimestamp configures the _data_stream_timestamp metadata field
+	DataStreamTimestamp *DataStreamTimestampMappingParams `json:"_data_stream_timestamp,omitempty"`
+	// Meta is custom metadata of the mapping. It is not used by Elasticsearch.
+	Meta map[string]interface{} `json:"_meta,omitempty"`
+	// DynamicTemplates are applied, in order, to dynamically added fields
+	DynamicTemplates []DynamicTemplater `json:"dynamic_templates,omitempty"`
+	// Runtime fields of the mappings, which are evaluated at query time
+	Runtime    RuntimeMappings `json:"runtime,omitempty"`
+	Properties FieldMap        `json:"properties"`
 }
 
 func (m Mappings) FieldMappings() (FieldMappings, error) {
 	merr := MappingError{}
 	fm := FieldMappings{
-		Properties: make(Fields, len(m.Properties)),
+		DynamicDateFormats: m.DynamicDateFormats,
+		Meta:               m.Meta,
+		Runtime:            m.Runtime,
+		Properties:         make(Fields, len(m.Properties)),
+	}
+	err := m.Dynamic.Validate()
+	if err != nil {
+		merr.Append(newFieldError(err, "dynamic"))
+	}
+	fm.Dynamic = m.Dynamic
+	err = fm.DateDetection.Set(m.DateDetection)
+	if err != nil {
+		merr.Append(newFieldError(err, "date_detection"))
+	}
+	err = fm.NumericDetection.Set(m.NumericDetection)
+	if err != nil {
+		merr.Append(newFieldError(err, "numeric_detection"))
+	}
+	if m.Source != nil {
+		fm.Source, err = m.Source.SourceMapping()
+		if err != nil {
+			merr.Append(err)
+		}
+	}
+	if m.Routing != nil {
+		fm.Routing, err = m.Routing.RoutingMapping()
+		if err != nil {
+			merr.Append(err)
+		}
+	}
+	if m.FieldNames != nil {
+		fm.FieldNames, err = m.FieldNames.FieldNamesMapping()
+		if err != nil {
+			merr.Append(err)
+		}
+	}
+	if m.DataStreamTimestamp != nil {
+		fm.DataStreamTimestamp, err = m.DataStreamTimestamp.DataStreamTimestampMapping()
+		if err != nil {
+			merr.Append(err)
+		}
 	}
 	for field, v := range m.Properties {
 		f, err := v.Field()
@@ -23,11 +104,108 @@ func (m Mappings) FieldMappings() (FieldMappings, error) {
 		}
 		fm.Properties[field] = f
 	}
+	if len(m.DynamicTemplates) > 0 {
+		fm.DynamicTemplates = make(DynamicTemplates, 0, len(m.DynamicTemplates))
+	}
+	for _, v := range m.DynamicTemplates {
+		if v == nil {
+			continue
+		}
+		t, err := v.DynamicTemplate()
+		if err != nil {
+			merr.Append(err)
+			continue
+		}
+		fm.DynamicTemplates = append(fm.DynamicTemplates, t)
+	}
+	err = m.Runtime.Validate()
+	if err != nil {
+		merr.Append(newFieldError(err, "runtime"))
+	}
+	err = m.Runtime.ValidateShadowing(fm.Properties)
+	if err != nil {
+		merr.Append(newFieldError(err, "runtime"))
+	}
 	return fm, merr.ErrorOrNil()
 }
 
+// FieldMappings are the mappings of an index
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/mapping.html
 type FieldMappings struct {
-	Properties Fields `json:"properties"`
+	Dynamic             Dynamic
+	DateDetection       dynamic.Bool
+	DynamicDateFormats  []string
+	NumericDetection    dynamic.Bool
+	Source              *SourceMapping
+	Routing             *RoutingMapping
+	FieldNames          *FieldNamesMapping
+	DataStreamTimestamp *DataStreamTimestampMapping
+	Meta                map[string]interface{}
+	DynamicTemplates    DynamicTemplates
+	Runtime             RuntimeMappings
+	Properties          Fields
+}
+
+func (fm FieldMappings) MarshalBSON() ([]byte, error) {
+	return fm.MarshalJSON()
+}
+
+func (fm FieldMappings) MarshalJSON() ([]byte, error) {
+	return json.Marshal(mappings{
+		Dynamic:             fm.Dynamic,
+		DateDetection:       fm.DateDetection.Value(),
+		DynamicDateFormats:  fm.DynamicDateFormats,
+		NumericDetection:    fm.NumericDetection.Value(),
+		Source:              fm.Source,
+		Routing:             fm.Routing,
+		FieldNames:          fm.FieldNames,
+		DataStreamTimestamp: fm.DataStreamTimestamp,
+		Meta:                fm.Meta,
+		DynamicTemplates:    fm.DynamicTemplates,
+		Runtime:             fm.Runtime,
+		Properties:          fm.Properties,
+	})
+}
+
+func (fm *FieldMappings) UnmarshalBSON(data []byte) error {
+	return fm.UnmarshalJSON(data)
+}
+
+func (fm *FieldMappings) UnmarshalJSON(data []byte) error {
+	*fm = FieldMappings{}
+	var v mappings
+	err := json.Unmarshal(data, &v)
+	if err != nil {
+		return err
+	}
+	err = v.Dynamic.Validate()
+	if err != nil {
+		return newFieldError(err, "dynamic")
+	}
+	err = fm.DateDetection.Set(v.DateDetection)
+	if err != nil {
+		return newFieldError(err, "date_detection")
+	}
+	err = fm.NumericDetection.Set(v.NumericDetection)
+	if err != nil {
+		return newFieldError(err, "numeric_detection")
+	}
+	err = v.Runtime.Validate()
+	if err != nil {
+		return newFieldError(err, "runtime")
+	}
+	fm.Dynamic = v.Dynamic
+	fm.DynamicDateFormats = v.DynamicDateFormats
+	fm.Source = v.Source
+	fm.Routing = v.Routing
+	fm.FieldNames = v.FieldNames
+	fm.DataStreamTimestamp = v.DataStreamTimestamp
+	fm.Meta = v.Meta
+	fm.DynamicTemplates = v.DynamicTemplates
+	fm.Runtime = v.Runtime
+	fm.Properties = v.Properties
+	return nil
 }
 
 func (m *Mappings) UnmarshalBSON(data []byte) error {
@@ -36,19 +214,48 @@ func (m *Mappings) UnmarshalBSON(data []byte) error {
 
 func (m *Mappings) UnmarshalJSON(data []byte) error {
 	*m = Mappings{}
-	var v mappings
-	err := json.Unmarshal(data, &v)
+	var v FieldMappings
+	err := v.UnmarshalJSON(data)
 	if err != nil {
 		return err
 	}
+	m.Dynamic = v.Dynamic
+	m.DateDetection = v.DateDetection.Value()
+	m.DynamicDateFormats = v.DynamicDateFormats
+	m.NumericDetection = v.NumericDetection.Value()
+	if v.Source != nil {
+		m.Source = &SourceMappingParams{
+			Enabled:  v.Source.enabled.Value(),
+			Includes: v.Source.includes,
+			Excludes: v.Source.excludes,
+		}
+	}
+	if v.Routing != nil {
+		m.Routing = &RoutingMappingParams{Required: v.Routing.required.Value()}
+	}
+	if v.FieldNames != nil {
+		m.FieldNames = &FieldNamesMappingParams{Enabled: v.FieldNames.enabled.Value()}
+	}
+	if v.DataStreamTimestamp != nil {
+		m.DataStreamTimestamp = &DataStreamTimestampMappingParams{Enabled: v.DataStreamTimestamp.enabled.Value()}
+	}
+	m.Meta = v.Meta
+	m.Runtime = v.Runtime
 	m.Properties = v.Properties.FieldMap()
+	for _, t := range v.DynamicTemplates {
+		m.DynamicTemplates = append(m.DynamicTemplates, t)
+	}
 	return nil
 }
 
-func (m *Mappings) MarshalJSON() ([]byte, error) {
-	f, err := m.Properties.Fields()
+func (m Mappings) MarshalBSON() ([]byte, error) {
+	return m.MarshalJSON()
+}
+
+func (m Mappings) MarshalJSON() ([]byte, error) {
+	fm, err := m.FieldMappings()
 	if err != nil {
 		return nil, err
 	}
-	return json.Marshal(f)
+	return fm.MarshalJSON()
 }
@@ -1,16 +1,95 @@
 package picker_test
 
 import (
+	"encoding/json"
 	"testing"
 
+	"github.com/chanced/cmpjson"
+	"github.com/chanced/picker"
 	"github.com/stretchr/testify/require"
 )
 
 func TestMappings(t *testing.T) {
 	assert := require.New(t)
-	_ = assert
-	// m := picker.NewMappings()
-	// a := picker.NewAliasField()
-	// m.Properties.AddField("alias", a)
+	data := []byte(`{
+		"mappings": {
+		  "dynamic": "strict",
+		  "date_detection": false,
+		  "dynamic_date_formats": ["MM/dd/yyyy"],
+		  "numeric_detection": true,
+		  "_source": {
+			"includes": ["*.count", "meta.*"],
+			"excludes": ["meta.description"]
+		  },
+		  "_routing": { "required": true },
+		  "_field_names": { "enabled": false },
+		  "_data_stream_timestamp": { "enabled": true },
+		  "_meta": {
+			"class": "MyApp::User",
+			"version": { "min": "1.0", "max": "1.3" }
+		  },
+		  "properties": {
+			"message": { "type": "text" }
+		  }
+		}
+	  }`)
+	i, err := picker.NewIndex(picker.IndexParams{Mappings: picker.Mappings{
+		Dynamic:            picker.DynamicStrict,
+		DateDetection:      false,
+		DynamicDateFormats: []string{"MM/dd/yyyy"},
+		NumericDetection:   true,
+		Source: &picker.SourceMappingParams{
+			Includes: []string{"*.count", "meta.*"},
+			Excludes: []string{"meta.description"},
+		},
+		Routing:             &picker.RoutingMappingParams{Required: true},
+		FieldNames:          &picker.FieldNamesMappingParams{Enabled: false},
+		DataStreamTimestamp: &picker.DataStreamTimestampMappingParams{Enabled: true},
+		Meta: map[string]interface{}{
+			"class":   "MyApp::User",
+			"version": map[string]interface{}{"min": "1.0", "max": "1.3"},
+		},
+		Properties: picker.FieldMap{"message": picker.TextFieldParams{}},
+	}})
+	assert.NoError(err)
+	ixd, err := i.MarshalJSON()
+	assert.NoError(err)
+	assert.True(cmpjson.Equal(data, ixd), cmpjson.Diff(data, ixd))
 
+	i2 := picker.Index{}
+	err = i2.UnmarshalJSON(data)
+	assert.NoError(err)
+	fm := i2.Mappings
+	assert.Equal(picker.DynamicStrict, fm.Dynamic)
+	assert.True(fm.DateDetection.IsFalse())
+	assert.True(fm.NumericDetection.IsTrue())
+	assert.True(fm.Source.Enabled())
+	assert.Equal([]string{"meta.description"}, fm.Source.Excludes())
+	assert.True(fm.Routing.Required())
+	assert.False(fm.FieldNames.Enabled())
+	assert.True(fm.DataStreamTimestamp.Enabled())
+	assert.Equal("MyApp::User", fm.Meta["class"])
+	i2d, err := i2.MarshalJSON()
+	assert.NoError(err)
+	assert.True(cmpjson.Equal(data, i2d), cmpjson.Diff(data, i2d))
+
+	md := []byte(`{"dynamic":"runtime","_source":{"enabled":false},"properties":{}}`)
+	var m picker.Mappings
+	err = json.Unmarshal(md, &m)
+	assert.NoError(err)
+	assert.Equal(picker.DynamicRuntime, m.Dynamic)
+	assert.Equal(false, m.Source.Enabled)
+	md2, err := json.Marshal(m)
+	assert.NoError(err)
+	assert.True(cmpjson.Equal(md, md2), cmpjson.Diff(md, md2))
+
+	var fm2 picker.FieldMappings
+	err = json.Unmarshal([]byte(`{"dynamic":false}`), &fm2)
+	assert.NoError(err)
+	assert.Equal(picker.DynamicFalse, fm2.Dynamic)
+	err = json.Unmarshal([]byte(`{"dynamic":"sometimes"}`), &fm2)
+	assert.ErrorIs(err, picker.ErrInvalidDynamic)
+
+	_, err = picker.Mappings{Dynamic: "sometimes"}.FieldMappings()
+	assert.ErrorIs(err, picker.ErrInvalidDynamic)
 }
@@ -0,0 +1,105 @@
+package picker
+
+import "encoding/json"
+
+type MatchOnlyTextFieldParams struct {
+	// Multi-fields allow the same string value to be indexed in multiple ways
+	// for different purposes, such as one field for search and a multi-field
+	// for sorting and aggregations.
+	Fields FieldMap `json:"fields,omitempty"`
+	// Metadata about the field.
+	Meta Meta `json:"meta,omitempty"`
+}
+
+func (MatchOnlyTextFieldParams) Type() FieldType {
+	return FieldTypeMatchOnlyText
+}
+
+func (p MatchOnlyTextFieldParams) Field() (Field, error) {
+	return p.MatchOnlyText()
+}
+
+func (p MatchOnlyTextFieldParams) MatchOnlyText() (*MatchOnlyTextField, error) {
+	f := &MatchOnlyTextField{}
+	e := &MappingError{}
+	err := f.SetFields(p.Fields)
+	if err != nil {
+		e.Append(err)
+	}
+	err = f.SetMeta(p.Meta)
+	if err != nil {
+		e.Append(err)
+	}
+	return f, e.ErrorOrNil()
+}
+
+func NewMatchOnlyTextField(params MatchOnlyTextFieldParams) (*MatchOnlyTextField, error) {
+	return params.MatchOnlyText()
+}
+
+// A MatchOnlyTextField is a variant of text that trades scoring and efficiency
+// of positional queries for space efficiency. This field effectively stores
+// data the same way as a text field that only indexes documents
+// (index_options: docs) and disables norms (norms: false). Term queries
+// perform as fast if not faster as on text fields, however queries that need
+// positions such as the match_phrase query perform slower as they need to
+// look at the _source document to verify whether a phrase matches. All
+// queries return constant scores that are equal to 1.0.
+//
+// Analysis is not configurable: text is always analyzed with the default
+// analyzer.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/text.html#match-only-text-field-type
+type MatchOnlyTextField struct {
+	fieldsParam
+	metaParam
+}
+
+func (m *MatchOnlyTextField) Field() (Field, error) {
+	return m, nil
+}
+
+func (MatchOnlyTextField) Type() FieldType {
+	return FieldTypeMatchOnlyText
+}
+
+func (m MatchOnlyTextField) MarshalBSON() ([]byte, error) {
+	return m.MarshalJSON()
+}
+
+func (m MatchOnlyTextField) MarshalJSON() ([]byte, error) {
+	return json.Marshal(matchOnlyTextField{
+		Fields: m.fields,
+		Meta:   m.meta,
+		Type:   m.Type(),
+	})
+}
+
+func (m *MatchOnlyTextField) UnmarshalBSON(data []byte) error {
+	return m.UnmarshalJSON(data)
+}
+
+func (m *MatchOnlyTextField) UnmarshalJSON(data []byte) error {
+	// multi-fields are decoded as Fields, which unmarshals each field by
+	// its type, rather than the FieldMap of the params
+	var p matchOnlyTextField
+	err := json.Unmarshal(data, &p)
+	if err != nil {
+		return err
+	}
+	n, err := MatchOnlyTextFieldParams{
+		Meta: p.Meta,
+	}.MatchOnlyText()
+	if err != nil {
+		return err
+	}
+	err = n.SetFields(p.Fields)
+	*m = *n
+	return err
+}
+
+type matchOnlyTextField struct {
+	Fields Fields    `json:"fields,omitempty"`
+	Meta   Meta      `json:"meta,omitempty"`
+	Type   FieldType `json:"type"`
+}
@@ -0,0 +1,38 @@
+package picker_test
+
+import (
+	"testing"
+
+	"github.com/chanced/cmpjson"
+	"github.com/chanced/picker"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchOnlyTextField(t *testing.T) {
+	assert := require.New(t)
+	data := []byte(`{
+		"mappings": {
+		  "properties": {
+			"message": {
+			  "type": "match_only_text",
+			  "fields": {
+				"keyword": { "type": "keyword" }
+			  }
+			}
+		  }
+		}
+	  }`)
+	i, err := picker.NewIndex(picker.IndexParams{Mappings: picker.Mappings{
+		Properties: picker.FieldMap{"message": picker.MatchOnlyTextFieldParams{
+			Fields: picker.FieldMap{"keyword": picker.KeywordFieldParams{}},
+		}},
+	}})
+	assert.NoError(err)
+	ixd, err := i.MarshalJSON()
+	assert.NoError(err)
+	assert.True(cmpjson.Equal(data, ixd), cmpjson.Diff(data, ixd))
+	i2 := picker.Index{}
+	err = i2.UnmarshalJSON(data)
+	assert.NoError(err)
+	assert.Equal(picker.FieldTypeMatchOnlyText, i2.Mappings.Properties["message"].Type())
+}
@@ -0,0 +1,107 @@
+package picker
+
+// MaxAggParams creates a MaxAgg, a single-value metrics aggregation that keeps
+// track and returns the maximum value among the numeric values extracted from
+// the aggregated documents.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-max-aggregation.html
+type MaxAggParams struct {
+	// The field to aggregate on. Either Field or Script is required.
+	Field string
+	// Script to generate values with. If Field is also set, Script is a value
+	// script.
+	Script *Script
+	// Missing is the value used for documents which do not have a value
+	Missing interface{}
+	// Hint as to the type of value produced by Script
+	ValueType string
+	// Format of the value_as_string in the response
+	Format string
+	Meta   map[string]interface{}
+}
+
+func (MaxAggParams) Kind() AggKind {
+	return AggKindMax
+}
+
+func (p MaxAggParams) Clause() (AggClause, error) {
+	return p.Max()
+}
+
+func (p MaxAggParams) Max() (*MaxAgg, error) {
+	a := &MaxAgg{}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	err := a.checkValueSource()
+	if err != nil {
+		return a, newAggError(err, AggKindMax)
+	}
+	a.SetMissing(p.Missing)
+	a.SetValueType(p.ValueType)
+	a.SetFormat(p.Format)
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// MaxAgg is a single-value metrics aggregation that keeps track and returns the
+// maximum value among the numeric values extracted from the aggregated
+// documents.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-max-aggregation.html
+type MaxAgg struct {
+	valueSourceParam
+	noAggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*MaxAgg)(nil)
+
+func (MaxAgg) Kind() AggKind {
+	return AggKindMax
+}
+
+func (a *MaxAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+func (a MaxAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a MaxAgg) MarshalJSON() ([]byte, error) {
+	return maxAgg{
+		Field:     a.field,
+		Script:    a.script,
+		Missing:   a.missing,
+		ValueType: a.valueType,
+		Format:    a.format,
+	}.MarshalJSON()
+}
+
+func (a *MaxAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *MaxAgg) UnmarshalJSON(data []byte) error {
+	*a = MaxAgg{}
+	p := maxAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	a.SetMissing(p.Missing)
+	a.SetValueType(p.ValueType)
+	a.SetFormat(p.Format)
+	return nil
+}
+
+//easyjson:json
+type maxAgg struct {
+	Field     string      `json:"field,omitempty"`
+	Script    *Script     `json:"script,omitempty"`
+	Missing   interface{} `json:"missing,omitempty"`
+	ValueType string      `json:"value_type,omitempty"`
+	Format    string      `json:"format,omitempty"`
+}
@@ -0,0 +1,99 @@
+package picker
+
+// MaxBucketAggParams creates a MaxBucketAgg, a sibling pipeline aggregation
+// which identifies the bucket(s) with the maximum value of a specified metric
+// in a sibling aggregation and outputs both the value and the key(s) of the
+// bucket(s). The specified metric must be numeric and the sibling aggregation
+// must be a multi-bucket aggregation.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-max-bucket-aggregation.html
+type MaxBucketAggParams struct {
+	// Path to the buckets to aggregate (Required)
+	BucketsPath string
+	// Policy to apply when gaps are found in the data. Defaults to "skip".
+	GapPolicy GapPolicy
+	// Format of the value_as_string in the response
+	Format string
+	Meta   map[string]interface{}
+}
+
+func (MaxBucketAggParams) Kind() AggKind {
+	return AggKindMaxBucket
+}
+
+func (p MaxBucketAggParams) Clause() (AggClause, error) {
+	return p.MaxBucket()
+}
+
+func (p MaxBucketAggParams) MaxBucket() (*MaxBucketAgg, error) {
+	a := &MaxBucketAgg{}
+	err := a.SetBucketsPath(p.BucketsPath)
+	if err != nil {
+		return a, newAggError(err, AggKindMaxBucket)
+	}
+	err = a.SetGapPolicy(p.GapPolicy)
+	if err != nil {
+		return a, newAggError(err, AggKindMaxBucket)
+	}
+	a.SetFormat(p.Format)
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// MaxBucketAgg is a sibling pipeline aggregation which identifies the bucket(s)
+// with the maximum value of a specified metric in a sibling aggregation.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-max-bucket-aggregation.html
+type MaxBucketAgg struct {
+	bucketsPathParam
+	gapPolicyParam
+	pipelineFormatParam
+	noAggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*MaxBucketAgg)(nil)
+
+func (MaxBucketAgg) Kind() AggKind {
+	return AggKindMaxBucket
+}
+
+func (a *MaxBucketAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+func (a MaxBucketAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a MaxBucketAgg) MarshalJSON() ([]byte, error) {
+	return maxBucketAgg{
+		BucketsPath: a.bucketsPath,
+		GapPolicy:   a.gapPolicy,
+		Format:      a.format,
+	}.MarshalJSON()
+}
+
+func (a *MaxBucketAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *MaxBucketAgg) UnmarshalJSON(data []byte) error {
+	*a = MaxBucketAgg{}
+	p := maxBucketAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.bucketsPath = p.BucketsPath
+	a.gapPolicy = p.GapPolicy
+	a.SetFormat(p.Format)
+	return nil
+}
+
+//easyjson:json
+type maxBucketAgg struct {
+	BucketsPath string    `json:"buckets_path"`
+	GapPolicy   GapPolicy `json:"gap_policy,omitempty"`
+	Format      string    `json:"format,omitempty"`
+}
@@ -0,0 +1,126 @@
+package picker
+
+const DefaultMedianAbsoluteDeviationCompression = 1000
+
+// MedianAbsoluteDeviationAggParams creates a MedianAbsoluteDeviationAgg, a
+// single-value aggregation that approximates the median absolute deviation of
+// its search results. Median absolute deviation is a measure of variability. It
+// is a robust statistic, meaning that it is useful for describing data that may
+// have outliers, or may not be normally distributed.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-median-absolute-deviation-aggregation.html
+type MedianAbsoluteDeviationAggParams struct {
+	// The field to aggregate on. Either Field or Script is required.
+	Field string
+	// Script to generate values with. If Field is also set, Script is a value
+	// script.
+	Script *Script
+	// Missing is the value used for documents which do not have a value
+	Missing interface{}
+	// Format of the value_as_string in the response
+	Format string
+	// The tradeoff between resource usage and accuracy of the TDigest used to
+	// approximate the median. Defaults to 1000.
+	Compression float64
+	Meta        map[string]interface{}
+}
+
+func (MedianAbsoluteDeviationAggParams) Kind() AggKind {
+	return AggKindMedianAbsoluteDeviation
+}
+
+func (p MedianAbsoluteDeviationAggParams) Clause() (AggClause, error) {
+	return p.MedianAbsoluteDeviation()
+}
+
+func (p MedianAbsoluteDeviationAggParams) MedianAbsoluteDeviation() (*MedianAbsoluteDeviationAgg, error) {
+	a := &MedianAbsoluteDeviationAgg{}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	err := a.checkValueSource()
+	if err != nil {
+		return a, newAggError(err, AggKindMedianAbsoluteDeviation)
+	}
+	a.SetMissing(p.Missing)
+	a.SetFormat(p.Format)
+	a.SetCompression(p.Compression)
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// MedianAbsoluteDeviationAgg is a single-value aggregation that approximates
+// the median absolute deviation of its search results.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-median-absolute-deviation-aggregation.html
+type MedianAbsoluteDeviationAgg struct {
+	valueSourceParam
+	compression float64
+	noAggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*MedianAbsoluteDeviationAgg)(nil)
+
+func (MedianAbsoluteDeviationAgg) Kind() AggKind {
+	return AggKindMedianAbsoluteDeviation
+}
+
+func (a *MedianAbsoluteDeviationAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// Compression is the tradeoff between resource usage and accuracy. Defaults to
+// 1000.
+func (a MedianAbsoluteDeviationAgg) Compression() float64 {
+	if a.compression == 0 {
+		return DefaultMedianAbsoluteDeviationCompression
+	}
+	return a.compression
+}
+
+// SetCompression sets compression to v
+func (a *MedianAbsoluteDeviationAgg) SetCompression(v float64) {
+	a.compression = v
+}
+
+func (a MedianAbsoluteDeviationAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a MedianAbsoluteDeviationAgg) MarshalJSON() ([]byte, error) {
+	return medianAbsoluteDeviationAgg{
+		Field:       a.field,
+		Script:      a.script,
+		Missing:     a.missing,
+		Format:      a.format,
+		Compression: a.compression,
+	}.MarshalJSON()
+}
+
+func (a *MedianAbsoluteDeviationAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *MedianAbsoluteDeviationAgg) UnmarshalJSON(data []byte) error {
+	*a = MedianAbsoluteDeviationAgg{}
+	p := medianAbsoluteDeviationAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	a.SetMissing(p.Missing)
+	a.SetFormat(p.Format)
+	a.compression = p.Compression
+	return nil
+}
+
+//easyjson:json
+type medianAbsoluteDeviationAgg struct {
+	Field       string      `json:"field,omitempty"`
+	Script      *Script     `json:"script,omitempty"`
+	Missing     interface{} `json:"missing,omitempty"`
+	Format      string      `json:"format,omitempty"`
+	Compression float64     `json:"compression,omitempty"`
+}
@@ -0,0 +1,229 @@
+package picker
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/chanced/dynamic"
+)
+
+// ValueAggResult is the result of a single-value metrics or pipeline
+// aggregation, such as avg, sum, cardinality, or derivative.
+//
+//easyjson:json
+type ValueAggResult struct {
+	// Value is nil if the aggregation did not have a value, such as the avg of
+	// an empty set of documents
+	Value         *float64 `json:"value"`
+	ValueAsString string   `json:"value_as_string,omitempty"`
+	// Keys are the keys of the bucket(s) with the maximum or minimum value of
+	// max_bucket and min_bucket results
+	Keys []string `json:"keys,omitempty"`
+	// NormalizedValue is set for derivative results with a unit
+	NormalizedValue *float64               `json:"normalized_value,omitempty"`
+	Meta            map[string]interface{} `json:"meta,omitempty"`
+	kind            AggKind
+}
+
+func (r ValueAggResult) Kind() AggKind {
+	return r.kind
+}
+
+func (r *ValueAggResult) decodeAggResult(agg AggClause, data dynamic.JSON) error {
+	err := r.UnmarshalJSON(data)
+	r.kind = agg.Kind()
+	return err
+}
+
+// StatsAggResult is the result of a stats or stats_bucket aggregation
+//
+//easyjson:json
+type StatsAggResult struct {
+	Count       int64                  `json:"count"`
+	Min         *float64               `json:"min"`
+	Max         *float64               `json:"max"`
+	Avg         *float64               `json:"avg"`
+	Sum         float64                `json:"sum"`
+	MinAsString string                 `json:"min_as_string,omitempty"`
+	MaxAsString string                 `json:"max_as_string,omitempty"`
+	AvgAsString string                 `json:"avg_as_string,omitempty"`
+	SumAsString string                 `json:"sum_as_string,omitempty"`
+	Meta        map[string]interface{} `json:"meta,omitempty"`
+	kind        AggKind
+}
+
+func (r StatsAggResult) Kind() AggKind {
+	return r.kind
+}
+
+func (r *StatsAggResult) decodeAggResult(agg AggClause, data dynamic.JSON) error {
+	err := r.UnmarshalJSON(data)
+	r.kind = agg.Kind()
+	return err
+}
+
+// StdDeviationBounds are the intervals of plus/minus sigma standard deviations
+// from the mean of an extended_stats result
+//
+//easyjson:json
+type StdDeviationBounds struct {
+	Upper           *float64 `json:"upper"`
+	Lower           *float64 `json:"lower"`
+	UpperPopulation *float64 `json:"upper_population"`
+	LowerPopulation *float64 `json:"lower_population"`
+	UpperSampling   *float64 `json:"upper_sampling"`
+	LowerSampling   *float64 `json:"lower_sampling"`
+}
+
+// ExtendedStatsAggResult is the result of an extended_stats or
+// extended_stats_bucket aggregation
+//
+//easyjson:json
+type ExtendedStatsAggResult struct {
+	Count                  int64                  `json:"count"`
+	Min                    *float64               `json:"min"`
+	Max                    *float64               `json:"max"`
+	Avg                    *float64               `json:"avg"`
+	Sum                    float64                `json:"sum"`
+	SumOfSquares           *float64               `json:"sum_of_squares"`
+	Variance               *float64               `json:"variance"`
+	VariancePopulation     *float64               `json:"variance_population"`
+	VarianceSampling       *float64               `json:"variance_sampling"`
+	StdDeviation           *float64               `json:"std_deviation"`
+	StdDeviationPopulation *float64               `json:"std_deviation_population"`
+	StdDeviationSampling   *float64               `json:"std_deviation_sampling"`
+	StdDeviationBounds     StdDeviationBounds     `json:"std_deviation_bounds"`
+	Meta                   map[string]interface{} `json:"meta,omitempty"`
+	kind                   AggKind
+}
+
+func (r ExtendedStatsAggResult) Kind() AggKind {
+	return r.kind
+}
+
+func (r *ExtendedStatsAggResult) decodeAggResult(agg AggClause, data dynamic.JSON) error {
+	err := r.UnmarshalJSON(data)
+	r.kind = agg.Kind()
+	return err
+}
+
+// PercentileValue is a single value of a PercentilesAggResult
+type PercentileValue struct {
+	// Key is the percentile for percentiles results or the value for
+	// percentile_ranks results
+	Key           float64
+	Value         *float64
+	ValueAsString string
+}
+
+// PercentilesAggResult is the result of a percentiles, percentile_ranks,
+// percentiles_bucket, or moving_percentiles aggregation.
+type PercentilesAggResult struct {
+	// Values are the computed percentiles in the order of the response
+	Values []PercentileValue
+	Meta   map[string]interface{}
+	kind   AggKind
+}
+
+func (r PercentilesAggResult) Kind() AggKind {
+	return r.kind
+}
+
+// Value returns the value for key, if it exists
+func (r PercentilesAggResult) Value(key float64) (float64, bool) {
+	for _, v := range r.Values {
+		if v.Key == key && v.Value != nil {
+			return *v.Value, true
+		}
+	}
+	return 0, false
+}
+
+func (r *PercentilesAggResult) decodeAggResult(agg AggClause, data dynamic.JSON) error {
+	r.kind = agg.Kind()
+	p := percentilesAggResult{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	r.Meta = p.Meta
+	switch {
+	case p.Values.IsArray():
+		var values []percentileValue
+		err = json.Unmarshal(p.Values, &values)
+		if err != nil {
+			return err
+		}
+		r.Values = make([]PercentileValue, len(values))
+		for i, v := range values {
+			r.Values[i] = PercentileValue(v)
+		}
+	case p.Values.IsObject():
+		keys, values, err := unmarshalOrderedObject(p.Values)
+		if err != nil {
+			return err
+		}
+		strs := map[string]string{}
+		for _, k := range keys {
+			if strings.HasSuffix(k, "_as_string") {
+				var s string
+				err = json.Unmarshal(values[k], &s)
+				if err != nil {
+					return err
+				}
+				strs[strings.TrimSuffix(k, "_as_string")] = s
+			}
+		}
+		r.Values = make([]PercentileValue, 0, len(keys)-len(strs))
+		for _, k := range keys {
+			if strings.HasSuffix(k, "_as_string") {
+				continue
+			}
+			key, err := strconv.ParseFloat(k, 64)
+			if err != nil {
+				return err
+			}
+			var value *float64
+			err = json.Unmarshal(values[k], &value)
+			if err != nil {
+				return err
+			}
+			r.Values = append(r.Values, PercentileValue{
+				Key:           key,
+				Value:         value,
+				ValueAsString: strs[k],
+			})
+		}
+	}
+	return nil
+}
+
+//easyjson:json
+type percentilesAggResult struct {
+	Values dynamic.JSON           `json:"values"`
+	Meta   map[string]interface{} `json:"meta"`
+}
+
+//easyjson:json
+type percentileValue struct {
+	Key           float64  `json:"key"`
+	Value         *float64 `json:"value"`
+	ValueAsString string   `json:"value_as_string"`
+}
+
+// TopHitsAggResult is the result of a top_hits aggregation
+//
+//easyjson:json
+type TopHitsAggResult struct {
+	Hits Hits                   `json:"hits"`
+	Meta map[string]interface{} `json:"meta,omitempty"`
+}
+
+func (TopHitsAggResult) Kind() AggKind {
+	return AggKindTopHits
+}
+
+func (r *TopHitsAggResult) decodeAggResult(agg AggClause, data dynamic.JSON) error {
+	return r.UnmarshalJSON(data)
+}
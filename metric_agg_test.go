@@ -0,0 +1,87 @@
+package picker_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/chanced/cmpjson"
+	"github.com/chanced/picker"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricAggs(t *testing.T) {
+	assert := require.New(t)
+	data := []byte(`{
+		"avg_grade": { "avg": { "field": "grade", "missing": 10 } },
+		"total": { "sum": { "script": { "source": "doc.price.value * 2" } } },
+		"grades_stats": { "extended_stats": { "field": "grade", "sigma": 3 } },
+		"load_time_outlier": {
+			"percentiles": {
+				"field": "load_time",
+				"percents": [95, 99, 99.9],
+				"keyed": false,
+				"hdr": { "number_of_significant_value_digits": 3 }
+			}
+		},
+		"load_time_ranks": { "percentile_ranks": { "field": "load_time", "values": [500, 600] } },
+		"type_count": { "cardinality": { "field": "type", "precision_threshold": 100 } },
+		"weighted_grade": {
+			"weighted_avg": {
+				"value": { "field": "grade" },
+				"weight": { "field": "weight", "missing": 3 }
+			}
+		},
+		"startup_time_ttest": {
+			"t_test": {
+				"a": { "field": "startup_time_before" },
+				"b": { "field": "startup_time_after", "filter": { "term": { "group": { "value": "B" } } } },
+				"type": "paired"
+			}
+		},
+		"tm": {
+			"top_metrics": {
+				"metrics": [{ "field": "m" }, { "field": "i" }],
+				"sort": [{ "s": { "order": "desc" } }],
+				"size": 3
+			}
+		},
+		"message_stats": { "string_stats": { "field": "message.keyword", "show_distribution": true } },
+		"sales_per_month": { "rate": { "unit": "month", "mode": "value_count" } }
+	}`)
+	var aggs picker.Aggregations
+	err := json.Unmarshal(data, &aggs)
+	assert.NoError(err)
+	pct := aggs.Get("load_time_outlier").(*picker.PercentilesAgg)
+	assert.False(pct.Keyed())
+	assert.Equal(3, pct.HDR().NumberOfSignificantValueDigits)
+	assert.Equal([]float64{95, 99, 99.9}, pct.Percents())
+	ttest := aggs.Get("startup_time_ttest").(*picker.TTestAgg)
+	assert.Equal(picker.TTestTypePaired, ttest.Type())
+	assert.NotNil(ttest.B().Filter)
+	tm := aggs.Get("tm").(*picker.TopMetricsAgg)
+	assert.Equal([]string{"m", "i"}, tm.Metrics())
+	assert.Equal(float64(3), aggs.Get("grades_stats").(*picker.ExtendedStatsAgg).Sigma())
+
+	sd, err := json.Marshal(aggs)
+	assert.NoError(err)
+	assert.True(cmpjson.Equal(data, sd), cmpjson.Diff(data, sd))
+
+	_, err = picker.AvgAggParams{}.Avg()
+	assert.True(errors.Is(err, picker.ErrFieldOrScriptRequired))
+	_, err = picker.PercentileRanksAggParams{Field: "load_time"}.PercentileRanks()
+	assert.True(errors.Is(err, picker.ErrValuesRequired))
+	_, err = picker.PercentilesAggParams{
+		Field:   "load_time",
+		TDigest: &picker.TDigest{Compression: 200},
+		HDR:     &picker.HDR{NumberOfSignificantValueDigits: 3},
+	}.Percentiles()
+	assert.True(errors.Is(err, picker.ErrMultiplePercentilesMethods))
+	_, err = picker.WeightedAvgAggParams{Value: picker.WeightedAvgValue{Field: "grade"}}.WeightedAvg()
+	assert.True(errors.Is(err, picker.ErrWeightRequired))
+	_, err = picker.TopMetricsAggParams{Metrics: []string{"m"}}.TopMetrics()
+	assert.True(errors.Is(err, picker.ErrSortRequired))
+
+	err = json.Unmarshal([]byte(`{"avg_grade":{"avg":{"field":"grade"},"aggs":{"x":{"max":{"field":"y"}}}}}`), &aggs)
+	assert.True(errors.Is(err, picker.ErrSubAggsNotSupported))
+}
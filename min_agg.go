@@ -0,0 +1,106 @@
+package picker
+
+// MinAggParams creates a MinAgg, a single-value metrics aggregation that keeps
+// track and returns the minimum value among numeric values extracted from the
+// aggregated documents.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-min-aggregation.html
+type MinAggParams struct {
+	// The field to aggregate on. Either Field or Script is required.
+	Field string
+	// Script to generate values with. If Field is also set, Script is a value
+	// script.
+	Script *Script
+	// Missing is the value used for documents which do not have a value
+	Missing interface{}
+	// Hint as to the type of value produced by Script
+	ValueType string
+	// Format of the value_as_string in the response
+	Format string
+	Meta   map[string]interface{}
+}
+
+func (MinAggParams) Kind() AggKind {
+	return AggKindMin
+}
+
+func (p MinAggParams) Clause() (AggClause, error) {
+	return p.Min()
+}
+
+func (p MinAggParams) Min() (*MinAgg, error) {
+	a := &MinAgg{}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	err := a.checkValueSource()
+	if err != nil {
+		return a, newAggError(err, AggKindMin)
+	}
+	a.SetMissing(p.Missing)
+	a.SetValueType(p.ValueType)
+	a.SetFormat(p.Format)
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// MinAgg is a single-value metrics aggregation that keeps track and returns the
+// minimum value among numeric values extracted from the aggregated documents.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-min-aggregation.html
+type MinAgg struct {
+	valueSourceParam
+	noAggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*MinAgg)(nil)
+
+func (MinAgg) Kind() AggKind {
+	return AggKindMin
+}
+
+func (a *MinAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+func (a MinAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a MinAgg) MarshalJSON() ([]byte, error) {
+	return minAgg{
+		Field:     a.field,
+		Script:    a.script,
+		Missing:   a.missing,
+		ValueType: a.valueType,
+		Format:    a.format,
+	}.MarshalJSON()
+}
+
+func (a *MinAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *MinAgg) UnmarshalJSON(data []byte) error {
+	*a = MinAgg{}
+	p := minAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	a.SetMissing(p.Missing)
+	a.SetValueType(p.ValueType)
+	a.SetFormat(p.Format)
+	return nil
+}
+
+//easyjson:json
+type minAgg struct {
+	Field     string      `json:"field,omitempty"`
+	Script    *Script     `json:"script,omitempty"`
+	Missing   interface{} `json:"missing,omitempty"`
+	ValueType string      `json:"value_type,omitempty"`
+	Format    string      `json:"format,omitempty"`
+}
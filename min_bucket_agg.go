@@ -0,0 +1,99 @@
+package picker
+
+// MinBucketAggParams creates a MinBucketAgg, a sibling pipeline aggregation
+// which identifies the bucket(s) with the minimum value of a specified metric
+// in a sibling aggregation and outputs both the value and the key(s) of the
+// bucket(s). The specified metric must be numeric and the sibling aggregation
+// must be a multi-bucket aggregation.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-min-bucket-aggregation.html
+type MinBucketAggParams struct {
+	// Path to the buckets to aggregate (Required)
+	BucketsPath string
+	// Policy to apply when gaps are found in the data. Defaults to "skip".
+	GapPolicy GapPolicy
+	// Format of the value_as_string in the response
+	Format string
+	Meta   map[string]interface{}
+}
+
+func (MinBucketAggParams) Kind() AggKind {
+	return AggKindMinBucket
+}
+
+func (p MinBucketAggParams) Clause() (AggClause, error) {
+	return p.MinBucket()
+}
+
+func (p MinBucketAggParams) MinBucket() (*MinBucketAgg, error) {
+	a := &MinBucketAgg{}
+	err := a.SetBucketsPath(p.BucketsPath)
+	if err != nil {
+		return a, newAggError(err, AggKindMinBucket)
+	}
+	err = a.SetGapPolicy(p.GapPolicy)
+	if err != nil {
+		return a, newAggError(err, AggKindMinBucket)
+	}
+	a.SetFormat(p.Format)
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// MinBucketAgg is a sibling pipeline aggregation which identifies the bucket(s)
+// with the minimum value of a specified metric in a sibling aggregation.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-min-bucket-aggregation.html
+type MinBucketAgg struct {
+	bucketsPathParam
+	gapPolicyParam
+	pipelineFormatParam
+	noAggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*MinBucketAgg)(nil)
+
+func (MinBucketAgg) Kind() AggKind {
+	return AggKindMinBucket
+}
+
+func (a *MinBucketAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+func (a MinBucketAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a MinBucketAgg) MarshalJSON() ([]byte, error) {
+	return minBucketAgg{
+		BucketsPath: a.bucketsPath,
+		GapPolicy:   a.gapPolicy,
+		Format:      a.format,
+	}.MarshalJSON()
+}
+
+func (a *MinBucketAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *MinBucketAgg) UnmarshalJSON(data []byte) error {
+	*a = MinBucketAgg{}
+	p := minBucketAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.bucketsPath = p.BucketsPath
+	a.gapPolicy = p.GapPolicy
+	a.SetFormat(p.Format)
+	return nil
+}
+
+//easyjson:json
+type minBucketAgg struct {
+	BucketsPath string    `json:"buckets_path"`
+	GapPolicy   GapPolicy `json:"gap_policy,omitempty"`
+	Format      string    `json:"format,omitempty"`
+}
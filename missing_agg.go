@@ -0,0 +1,88 @@
+package picker
+
+// MissingAggParams creates a MissingAgg, a field data based single bucket
+// aggregation, that creates a bucket of all documents in the current document
+// set context that are missing a field value (effectively, missing a field or
+// having the configured NULL value set).
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-missing-aggregation.html
+type MissingAggParams struct {
+	// The field to check for missing values (Required)
+	Field string
+	// Sub-aggregations
+	Aggregations Aggs
+	Meta         map[string]interface{}
+}
+
+func (MissingAggParams) Kind() AggKind {
+	return AggKindMissing
+}
+
+func (p MissingAggParams) Clause() (AggClause, error) {
+	return p.Missing()
+}
+
+func (p MissingAggParams) Missing() (*MissingAgg, error) {
+	a := &MissingAgg{}
+	err := a.SetField(p.Field)
+	if err != nil {
+		return a, newAggError(err, AggKindMissing)
+	}
+	err = a.SetAggregations(p.Aggregations)
+	if err != nil {
+		return a, newAggError(err, AggKindMissing)
+	}
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// MissingAgg is a field data based single bucket aggregation that creates a
+// bucket of all documents in the current document set context that are missing
+// a field value.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-missing-aggregation.html
+type MissingAgg struct {
+	fieldParam
+	aggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*MissingAgg)(nil)
+
+func (MissingAgg) Kind() AggKind {
+	return AggKindMissing
+}
+
+func (a *MissingAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+func (a MissingAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a MissingAgg) MarshalJSON() ([]byte, error) {
+	return missingAgg{
+		Field: a.field,
+	}.MarshalJSON()
+}
+
+func (a *MissingAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *MissingAgg) UnmarshalJSON(data []byte) error {
+	*a = MissingAgg{}
+	p := missingAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.field = p.Field
+	return nil
+}
+
+//easyjson:json
+type missingAgg struct {
+	Field string `json:"field"`
+}
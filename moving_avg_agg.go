@@ -0,0 +1,189 @@
+package picker
+
+// MovingAvgAggParams creates a MovingAvgAgg, a parent pipeline aggregation
+// which slides a window across the data and emits the average value of that
+// window.
+//
+// Deprecated: moving_avg was removed in Elasticsearch 8.0. Use
+// MovingFnAggParams instead.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/7.x/search-aggregations-pipeline-movavg-aggregation.html
+type MovingAvgAggParams struct {
+	// Path to the buckets to aggregate (Required)
+	BucketsPath string
+	// Policy to apply when gaps are found in the data. Defaults to "skip".
+	GapPolicy GapPolicy
+	// Format of the value_as_string in the response
+	Format string
+	// The moving average weighting model to use, e.g. "simple", "linear",
+	// "ewma", "holt", or "holt_winters". Defaults to "simple".
+	Model string
+	// The size of window to "slide" across the histogram. Defaults to 5.
+	Window int
+	// The number of predictions to append to the end of the series
+	Predict int
+	// If true, the parameters of the model are optimized
+	Minimize bool
+	// Model specific settings, e.g. alpha, beta, gamma, period, etc.
+	Settings map[string]interface{}
+	Meta     map[string]interface{}
+}
+
+func (MovingAvgAggParams) Kind() AggKind {
+	return AggKindMovingAverage
+}
+
+func (p MovingAvgAggParams) Clause() (AggClause, error) {
+	return p.MovingAvg()
+}
+
+func (p MovingAvgAggParams) MovingAvg() (*MovingAvgAgg, error) {
+	a := &MovingAvgAgg{}
+	err := a.SetBucketsPath(p.BucketsPath)
+	if err != nil {
+		return a, newAggError(err, AggKindMovingAverage)
+	}
+	err = a.SetGapPolicy(p.GapPolicy)
+	if err != nil {
+		return a, newAggError(err, AggKindMovingAverage)
+	}
+	a.SetFormat(p.Format)
+	a.SetModel(p.Model)
+	a.SetWindow(p.Window)
+	a.SetPredict(p.Predict)
+	a.SetMinimize(p.Minimize)
+	a.SetSettings(p.Settings)
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// MovingAvgAgg is a parent pipeline aggregation which slides a window across
+// the data and emits the average value of that window.
+//
+// Deprecated: moving_avg was removed in Elasticsearch 8.0. Use MovingFnAgg
+// instead.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/7.x/search-aggregations-pipeline-movavg-aggregation.html
+type MovingAvgAgg struct {
+	bucketsPathParam
+	gapPolicyParam
+	pipelineFormatParam
+	model    string
+	window   int
+	predict  int
+	minimize bool
+	settings map[string]interface{}
+	noAggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*MovingAvgAgg)(nil)
+
+func (MovingAvgAgg) Kind() AggKind {
+	return AggKindMovingAverage
+}
+
+func (a *MovingAvgAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// Model is the moving average weighting model to use
+func (a MovingAvgAgg) Model() string {
+	return a.model
+}
+
+// SetModel sets model to v
+func (a *MovingAvgAgg) SetModel(v string) {
+	a.model = v
+}
+
+// Window is the size of the window to "slide" across the histogram
+func (a MovingAvgAgg) Window() int {
+	return a.window
+}
+
+// SetWindow sets window to v
+func (a *MovingAvgAgg) SetWindow(v int) {
+	a.window = v
+}
+
+// Predict is the number of predictions to append to the end of the series
+func (a MovingAvgAgg) Predict() int {
+	return a.predict
+}
+
+// SetPredict sets predict to v
+func (a *MovingAvgAgg) SetPredict(v int) {
+	a.predict = v
+}
+
+// Minimize indicates whether the parameters of the model are optimized
+func (a MovingAvgAgg) Minimize() bool {
+	return a.minimize
+}
+
+// SetMinimize sets minimize to v
+func (a *MovingAvgAgg) SetMinimize(v bool) {
+	a.minimize = v
+}
+
+// Settings are the model specific settings
+func (a MovingAvgAgg) Settings() map[string]interface{} {
+	return a.settings
+}
+
+// SetSettings sets settings to v
+func (a *MovingAvgAgg) SetSettings(v map[string]interface{}) {
+	a.settings = v
+}
+
+func (a MovingAvgAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a MovingAvgAgg) MarshalJSON() ([]byte, error) {
+	return movingAvgAgg{
+		BucketsPath: a.bucketsPath,
+		GapPolicy:   a.gapPolicy,
+		Format:      a.format,
+		Model:       a.model,
+		Window:      a.window,
+		Predict:     a.predict,
+		Minimize:    a.minimize,
+		Settings:    a.settings,
+	}.MarshalJSON()
+}
+
+func (a *MovingAvgAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *MovingAvgAgg) UnmarshalJSON(data []byte) error {
+	*a = MovingAvgAgg{}
+	p := movingAvgAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.bucketsPath = p.BucketsPath
+	a.gapPolicy = p.GapPolicy
+	a.SetFormat(p.Format)
+	a.model = p.Model
+	a.window = p.Window
+	a.predict = p.Predict
+	a.minimize = p.Minimize
+	a.settings = p.Settings
+	return nil
+}
+
+//easyjson:json
+type movingAvgAgg struct {
+	BucketsPath string                 `json:"buckets_path"`
+	GapPolicy   GapPolicy              `json:"gap_policy,omitempty"`
+	Format      string                 `json:"format,omitempty"`
+	Model       string                 `json:"model,omitempty"`
+	Window      int                    `json:"window,omitempty"`
+	Predict     int                    `json:"predict,omitempty"`
+	Minimize    bool                   `json:"minimize,omitempty"`
+	Settings    map[string]interface{} `json:"settings,omitempty"`
+}
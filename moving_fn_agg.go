@@ -0,0 +1,156 @@
+package picker
+
+// MovingFnAggParams creates a MovingFnAgg, a parent pipeline aggregation which
+// slides a window across the data, executing a script over the values within
+// the window. The enclosing aggregation must be a histogram or date_histogram.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-movfn-aggregation.html
+type MovingFnAggParams struct {
+	// Path to the buckets to aggregate (Required)
+	BucketsPath string
+	// The size of window to "slide" across the histogram (Required)
+	Window int
+	// The script that should be executed on each window of data (Required)
+	Script string
+	// Policy to apply when gaps are found in the data. Defaults to "skip".
+	GapPolicy GapPolicy
+	// Shift of window position. Defaults to 0.
+	Shift int
+	Meta  map[string]interface{}
+}
+
+func (MovingFnAggParams) Kind() AggKind {
+	return AggKindMovingFunction
+}
+
+func (p MovingFnAggParams) Clause() (AggClause, error) {
+	return p.MovingFn()
+}
+
+func (p MovingFnAggParams) MovingFn() (*MovingFnAgg, error) {
+	a := &MovingFnAgg{}
+	err := a.SetBucketsPath(p.BucketsPath)
+	if err != nil {
+		return a, newAggError(err, AggKindMovingFunction)
+	}
+	err = a.SetWindow(p.Window)
+	if err != nil {
+		return a, newAggError(err, AggKindMovingFunction)
+	}
+	err = a.SetScript(p.Script)
+	if err != nil {
+		return a, newAggError(err, AggKindMovingFunction)
+	}
+	err = a.SetGapPolicy(p.GapPolicy)
+	if err != nil {
+		return a, newAggError(err, AggKindMovingFunction)
+	}
+	a.SetShift(p.Shift)
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// MovingFnAgg is a parent pipeline aggregation which slides a window across the
+// data, executing a script over the values within the window.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-movfn-aggregation.html
+type MovingFnAgg struct {
+	bucketsPathParam
+	gapPolicyParam
+	window int
+	script string
+	shift  int
+	noAggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*MovingFnAgg)(nil)
+
+func (MovingFnAgg) Kind() AggKind {
+	return AggKindMovingFunction
+}
+
+func (a *MovingFnAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// Window is the size of the window to "slide" across the histogram
+func (a MovingFnAgg) Window() int {
+	return a.window
+}
+
+// SetWindow sets window to v
+func (a *MovingFnAgg) SetWindow(v int) error {
+	if v <= 0 {
+		return ErrWindowRequired
+	}
+	a.window = v
+	return nil
+}
+
+// Script is the script executed on each window of data, e.g.
+// "MovingFunctions.unweightedAvg(values)"
+func (a MovingFnAgg) Script() string {
+	return a.script
+}
+
+// SetScript sets script to v
+func (a *MovingFnAgg) SetScript(v string) error {
+	if len(v) == 0 {
+		return ErrScriptRequired
+	}
+	a.script = v
+	return nil
+}
+
+// Shift is the shift of the window position
+func (a MovingFnAgg) Shift() int {
+	return a.shift
+}
+
+// SetShift sets shift to v
+func (a *MovingFnAgg) SetShift(v int) {
+	a.shift = v
+}
+
+func (a MovingFnAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a MovingFnAgg) MarshalJSON() ([]byte, error) {
+	return movingFnAgg{
+		BucketsPath: a.bucketsPath,
+		Window:      a.window,
+		Script:      a.script,
+		GapPolicy:   a.gapPolicy,
+		Shift:       a.shift,
+	}.MarshalJSON()
+}
+
+func (a *MovingFnAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *MovingFnAgg) UnmarshalJSON(data []byte) error {
+	*a = MovingFnAgg{}
+	p := movingFnAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.bucketsPath = p.BucketsPath
+	a.window = p.Window
+	a.script = p.Script
+	a.gapPolicy = p.GapPolicy
+	a.shift = p.Shift
+	return nil
+}
+
+//easyjson:json
+type movingFnAgg struct {
+	BucketsPath string    `json:"buckets_path"`
+	Window      int       `json:"window"`
+	Script      string    `json:"script"`
+	GapPolicy   GapPolicy `json:"gap_policy,omitempty"`
+	Shift       int       `json:"shift,omitempty"`
+}
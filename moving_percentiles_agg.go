@@ -0,0 +1,122 @@
+package picker
+
+// MovingPercentilesAggParams creates a MovingPercentilesAgg, a parent pipeline
+// aggregation which slides a window across the data and emits the percentiles
+// of that window. The referenced metric must be a percentiles aggregation and
+// the enclosing aggregation must be a histogram or date_histogram.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-moving-percentiles-aggregation.html
+type MovingPercentilesAggParams struct {
+	// Path to the buckets to aggregate (Required)
+	BucketsPath string
+	// The size of window to "slide" across the histogram (Required)
+	Window int
+	// Shift of window position. Defaults to 0.
+	Shift int
+	Meta  map[string]interface{}
+}
+
+func (MovingPercentilesAggParams) Kind() AggKind {
+	return AggKindMovingPercentiles
+}
+
+func (p MovingPercentilesAggParams) Clause() (AggClause, error) {
+	return p.MovingPercentiles()
+}
+
+func (p MovingPercentilesAggParams) MovingPercentiles() (*MovingPercentilesAgg, error) {
+	a := &MovingPercentilesAgg{}
+	err := a.SetBucketsPath(p.BucketsPath)
+	if err != nil {
+		return a, newAggError(err, AggKindMovingPercentiles)
+	}
+	err = a.SetWindow(p.Window)
+	if err != nil {
+		return a, newAggError(err, AggKindMovingPercentiles)
+	}
+	a.SetShift(p.Shift)
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// MovingPercentilesAgg is a parent pipeline aggregation which slides a window
+// across the data and emits the percentiles of that window.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-moving-percentiles-aggregation.html
+type MovingPercentilesAgg struct {
+	bucketsPathParam
+	window int
+	shift  int
+	noAggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*MovingPercentilesAgg)(nil)
+
+func (MovingPercentilesAgg) Kind() AggKind {
+	return AggKindMovingPercentiles
+}
+
+func (a *MovingPercentilesAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// Window is the size of the window to "slide" across the histogram
+func (a MovingPercentilesAgg) Window() int {
+	return a.window
+}
+
+// SetWindow sets window to v
+func (a *MovingPercentilesAgg) SetWindow(v int) error {
+	if v <= 0 {
+		return ErrWindowRequired
+	}
+	a.window = v
+	return nil
+}
+
+// Shift is the shift of the window position
+func (a MovingPercentilesAgg) Shift() int {
+	return a.shift
+}
+
+// SetShift sets shift to v
+func (a *MovingPercentilesAgg) SetShift(v int) {
+	a.shift = v
+}
+
+func (a MovingPercentilesAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a MovingPercentilesAgg) MarshalJSON() ([]byte, error) {
+	return movingPercentilesAgg{
+		BucketsPath: a.bucketsPath,
+		Window:      a.window,
+		Shift:       a.shift,
+	}.MarshalJSON()
+}
+
+func (a *MovingPercentilesAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *MovingPercentilesAgg) UnmarshalJSON(data []byte) error {
+	*a = MovingPercentilesAgg{}
+	p := movingPercentilesAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.bucketsPath = p.BucketsPath
+	a.window = p.Window
+	a.shift = p.Shift
+	return nil
+}
+
+//easyjson:json
+type movingPercentilesAgg struct {
+	BucketsPath string `json:"buckets_path"`
+	Window      int    `json:"window"`
+	Shift       int    `json:"shift,omitempty"`
+}
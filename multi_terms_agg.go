@@ -0,0 +1,282 @@
+package picker
+
+import (
+	"fmt"
+
+	"github.com/chanced/dynamic"
+)
+
+// MultiTerm is a term source of a multi_terms aggregation.
+//
+//easyjson:json
+type MultiTerm struct {
+	// The field to retrieve terms from (Required)
+	Field string `json:"field"`
+	// Missing is the value used for documents which do not have a value
+	Missing interface{} `json:"missing,omitempty"`
+}
+
+// MultiTermsAggParams creates a MultiTermsAgg, a multi-bucket value source
+// based aggregation where buckets are dynamically built - one per unique set
+// of values.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-multi-terms-aggregation.html
+type MultiTermsAggParams struct {
+	// The fields to generate composite terms from (Required)
+	Terms []MultiTerm
+	// The number of term buckets to return. Defaults to 10.
+	Size int
+	// The number of terms each shard should return.
+	ShardSize int
+	// If true, returns an upper bound of the error on the doc_count for each
+	// term.
+	ShowTermDocCountError bool
+	// The order of the buckets. Defaults to descending _count.
+	Order BucketOrder
+	// Only return terms that match more than the configured number of hits.
+	// Defaults to 1.
+	MinDocCount interface{}
+	// The minimum number of documents a term must be present in on a shard to
+	// be considered for the final results.
+	ShardMinDocCount interface{}
+	CollectMode      CollectMode
+	// Sub-aggregations
+	Aggregations Aggs
+	Meta         map[string]interface{}
+}
+
+func (MultiTermsAggParams) Kind() AggKind {
+	return AggKindMultiTerms
+}
+
+func (p MultiTermsAggParams) Clause() (AggClause, error) {
+	return p.MultiTerms()
+}
+
+func (p MultiTermsAggParams) MultiTerms() (*MultiTermsAgg, error) {
+	a := &MultiTermsAgg{}
+	err := a.SetTerms(p.Terms)
+	if err != nil {
+		return a, newAggError(err, AggKindMultiTerms)
+	}
+	a.SetSize(p.Size)
+	a.SetShardSize(p.ShardSize)
+	a.SetShowTermDocCountError(p.ShowTermDocCountError)
+	err = a.SetOrder(p.Order)
+	if err != nil {
+		return a, newAggError(err, AggKindMultiTerms)
+	}
+	err = a.SetMinDocCount(p.MinDocCount)
+	if err != nil {
+		return a, newAggError(err, AggKindMultiTerms)
+	}
+	err = a.SetShardMinDocCount(p.ShardMinDocCount)
+	if err != nil {
+		return a, newAggError(err, AggKindMultiTerms)
+	}
+	err = a.SetCollectMode(p.CollectMode)
+	if err != nil {
+		return a, newAggError(err, AggKindMultiTerms)
+	}
+	err = a.SetAggregations(p.Aggregations)
+	if err != nil {
+		return a, newAggError(err, AggKindMultiTerms)
+	}
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// MultiTermsAgg is a multi-bucket value source based aggregation where buckets
+// are dynamically built - one per unique set of values.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-multi-terms-aggregation.html
+type MultiTermsAgg struct {
+	terms                 []MultiTerm
+	size                  int
+	shardSize             int
+	showTermDocCountError bool
+	order                 BucketOrder
+	minDocCount           dynamic.Number
+	shardMinDocCount      dynamic.Number
+	collectMode           CollectMode
+	aggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*MultiTermsAgg)(nil)
+
+func (MultiTermsAgg) Kind() AggKind {
+	return AggKindMultiTerms
+}
+
+func (a *MultiTermsAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// Terms are the fields composite terms are generated from
+func (a MultiTermsAgg) Terms() []MultiTerm {
+	return a.terms
+}
+
+// SetTerms sets the terms to v. At least one term is required and each term
+// must have a field.
+func (a *MultiTermsAgg) SetTerms(v []MultiTerm) error {
+	if len(v) == 0 {
+		return ErrTermsRequired
+	}
+	for _, t := range v {
+		if len(t.Field) == 0 {
+			return ErrFieldRequired
+		}
+	}
+	a.terms = v
+	return nil
+}
+
+// Size is the number of term buckets to return. Defaults to 10.
+func (a MultiTermsAgg) Size() int {
+	if a.size == 0 {
+		return DefaultTermsAggSize
+	}
+	return a.size
+}
+
+// SetSize sets the number of term buckets to return to v
+func (a *MultiTermsAgg) SetSize(v int) {
+	a.size = v
+}
+
+// ShardSize is the number of terms each shard returns.
+func (a MultiTermsAgg) ShardSize() int {
+	return a.shardSize
+}
+
+// SetShardSize sets the shard_size to v
+func (a *MultiTermsAgg) SetShardSize(v int) {
+	a.shardSize = v
+}
+
+// ShowTermDocCountError indicates whether an upper bound of the error on the
+// doc_count is returned for each term.
+func (a MultiTermsAgg) ShowTermDocCountError() bool {
+	return a.showTermDocCountError
+}
+
+// SetShowTermDocCountError sets show_term_doc_count_error to v
+func (a *MultiTermsAgg) SetShowTermDocCountError(v bool) {
+	a.showTermDocCountError = v
+}
+
+// Order of the buckets. Defaults to descending _count.
+func (a MultiTermsAgg) Order() BucketOrder {
+	return a.order
+}
+
+// SetOrder sets the order of the buckets to v
+func (a *MultiTermsAgg) SetOrder(v BucketOrder) error {
+	err := v.Validate()
+	if err != nil {
+		return err
+	}
+	a.order = v
+	return nil
+}
+
+// MinDocCount is the minimum number of hits a term must match to be returned.
+// Defaults to 1.
+func (a MultiTermsAgg) MinDocCount() int {
+	if i, ok := a.minDocCount.Int(); ok {
+		return i
+	}
+	return DefaultTermsAggMinDocCount
+}
+
+// SetMinDocCount sets min_doc_count to v
+func (a *MultiTermsAgg) SetMinDocCount(v interface{}) error {
+	return a.minDocCount.Set(v)
+}
+
+// ShardMinDocCount is the minimum number of documents a term must be present in
+// on a shard to be considered for the final results.
+func (a MultiTermsAgg) ShardMinDocCount() int {
+	if i, ok := a.shardMinDocCount.Int(); ok {
+		return i
+	}
+	return 0
+}
+
+// SetShardMinDocCount sets shard_min_doc_count to v
+func (a *MultiTermsAgg) SetShardMinDocCount(v interface{}) error {
+	return a.shardMinDocCount.Set(v)
+}
+
+// CollectMode determines how child aggregations are calculated.
+func (a MultiTermsAgg) CollectMode() CollectMode {
+	return a.collectMode
+}
+
+// SetCollectMode sets collect_mode to v
+func (a *MultiTermsAgg) SetCollectMode(v CollectMode) error {
+	if !v.IsValid() {
+		return fmt.Errorf("%w <%s>", ErrInvalidCollectMode, v)
+	}
+	a.collectMode = v
+	return nil
+}
+
+func (a MultiTermsAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a MultiTermsAgg) MarshalJSON() ([]byte, error) {
+	return multiTermsAgg{
+		Terms:                 a.terms,
+		Size:                  a.size,
+		ShardSize:             a.shardSize,
+		ShowTermDocCountError: a.showTermDocCountError,
+		Order:                 a.order,
+		MinDocCount:           a.minDocCount.Value(),
+		ShardMinDocCount:      a.shardMinDocCount.Value(),
+		CollectMode:           a.collectMode,
+	}.MarshalJSON()
+}
+
+func (a *MultiTermsAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *MultiTermsAgg) UnmarshalJSON(data []byte) error {
+	*a = MultiTermsAgg{}
+	p := multiTermsAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.terms = p.Terms
+	a.SetSize(p.Size)
+	a.SetShardSize(p.ShardSize)
+	a.SetShowTermDocCountError(p.ShowTermDocCountError)
+	a.order = p.Order
+	err = a.SetMinDocCount(p.MinDocCount)
+	if err != nil {
+		return err
+	}
+	err = a.SetShardMinDocCount(p.ShardMinDocCount)
+	if err != nil {
+		return err
+	}
+	a.collectMode = p.CollectMode
+	return nil
+}
+
+//easyjson:json
+type multiTermsAgg struct {
+	Terms                 []MultiTerm `json:"terms"`
+	Size                  int         `json:"size,omitempty"`
+	ShardSize             int         `json:"shard_size,omitempty"`
+	ShowTermDocCountError bool        `json:"show_term_doc_count_error,omitempty"`
+	Order                 BucketOrder `json:"order,omitempty"`
+	MinDocCount           interface{} `json:"min_doc_count,omitempty"`
+	ShardMinDocCount      interface{} `json:"shard_min_doc_count,omitempty"`
+	CollectMode           CollectMode `json:"collect_mode,omitempty"`
+}
@@ -0,0 +1,93 @@
+package picker
+
+import "encoding/json"
+
+type Murmur3FieldParams struct {
+	// Whether the field value should be stored and retrievable separately from
+	// the _source field. Accepts true or false (default).
+	Store interface{} `json:"store,omitempty"`
+	// Metadata about the field.
+	Meta Meta `json:"meta,omitempty"`
+}
+
+func (Murmur3FieldParams) Type() FieldType {
+	return FieldTypeMurmur3
+}
+
+func (p Murmur3FieldParams) Field() (Field, error) {
+	return p.Murmur3()
+}
+
+func (p Murmur3FieldParams) Murmur3() (*Murmur3Field, error) {
+	f := &Murmur3Field{}
+	e := &MappingError{}
+	err := f.SetStore(p.Store)
+	if err != nil {
+		e.Append(err)
+	}
+	err = f.SetMeta(p.Meta)
+	if err != nil {
+		e.Append(err)
+	}
+	return f, e.ErrorOrNil()
+}
+
+func NewMurmur3Field(params Murmur3FieldParams) (*Murmur3Field, error) {
+	return params.Murmur3()
+}
+
+// A Murmur3Field computes hashes of field values at index-time and stores them
+// in the index. It is most commonly used as a multi-field of a keyword field
+// so that the cardinality aggregation can run on the hashes rather than the
+// values, which may be faster on fields with large values or high
+// cardinality.
+//
+// ! Requires the mapper-murmur3 plugin
+//
+// https://www.elastic.co/guide/en/elasticsearch/plugins/current/mapper-murmur3.html
+type Murmur3Field struct {
+	storeParam
+	metaParam
+}
+
+func (m *Murmur3Field) Field() (Field, error) {
+	return m, nil
+}
+
+func (Murmur3Field) Type() FieldType {
+	return FieldTypeMurmur3
+}
+
+func (m Murmur3Field) MarshalBSON() ([]byte, error) {
+	return m.MarshalJSON()
+}
+
+func (m Murmur3Field) MarshalJSON() ([]byte, error) {
+	return json.Marshal(murmur3Field{
+		Store: m.store.Value(),
+		Meta:  m.meta,
+		Type:  m.Type(),
+	})
+}
+
+func (m *Murmur3Field) UnmarshalBSON(data []byte) error {
+	return m.UnmarshalJSON(data)
+}
+
+func (m *Murmur3Field) UnmarshalJSON(data []byte) error {
+	var p Murmur3FieldParams
+	err := json.Unmarshal(data, &p)
+	if err != nil {
+		return err
+	}
+	n, err := p.Murmur3()
+	*m = *n
+	return err
+}
+
+//easyjson:json
+type murmur3Field struct {
+	Store interface{} `json:"store,omitempty"`
+	Meta  Meta        `json:"meta,omitempty"`
+	Type  FieldType   `json:"type"`
+}
@@ -3,6 +3,8 @@ package picker_test
 import (
 	"testing"
 
+	"github.com/chanced/cmpjson"
+	"github.com/chanced/picker"
 	"github.com/stretchr/testify/require"
 )
 
@@ -12,23 +14,24 @@ func TestMurmur3Field(t *testing.T) {
       "mappings": {
         "properties": {
             "murmur3": {
-                "type": "murmur3"
+                "type": "murmur3",
+                "store": true
            }
         }
      }
   }`)
-	_ = data
-	_ = assert
-	// i, err := picker.NewIndex(picker.IndexParams{Mappings: picker.Mappings{
-	// 	Properties: picker.FieldMap{
-	// 		"murmur3": picker.Murmur3FieldParams{}},
-	// }})
-	// assert.NoError(err)
-	// ixd, err := i.MarshalJSON()
-	// assert.NoError(err)
-	// assert.True(cmpjson.Equal(data, ixd), cmpjson.Diff(data, ixd))
-	// i2 := picker.Index{}
-	// err = i2.UnmarshalJSON(data)
-	// assert.NoError(err)
-
+	i, err := picker.NewIndex(picker.IndexParams{Mappings: picker.Mappings{
+		Properties: picker.FieldMap{
+			"murmur3": picker.Murmur3FieldParams{Store: true}},
+	}})
+	assert.NoError(err)
+	ixd, err := i.MarshalJSON()
+	assert.NoError(err)
+	assert.True(cmpjson.Equal(data, ixd), cmpjson.Diff(data, ixd))
+	i2 := picker.Index{}
+	err = i2.UnmarshalJSON(data)
+	assert.NoError(err)
+	m, ok := i2.Mappings.Properties["murmur3"].(*picker.Murmur3Field)
+	assert.True(ok)
+	assert.True(m.Store())
 }
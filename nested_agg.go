@@ -0,0 +1,99 @@
+package picker
+
+// NestedAggParams creates a NestedAgg, a special single bucket aggregation that
+// enables aggregating nested documents.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-nested-aggregation.html
+type NestedAggParams struct {
+	// The path of the nested documents within the top level documents (Required)
+	Path string
+	// Sub-aggregations
+	Aggregations Aggs
+	Meta         map[string]interface{}
+}
+
+func (NestedAggParams) Kind() AggKind {
+	return AggKindNested
+}
+
+func (p NestedAggParams) Clause() (AggClause, error) {
+	return p.Nested()
+}
+
+func (p NestedAggParams) Nested() (*NestedAgg, error) {
+	a := &NestedAgg{}
+	err := a.SetPath(p.Path)
+	if err != nil {
+		return a, newAggError(err, AggKindNested)
+	}
+	err = a.SetAggregations(p.Aggregations)
+	if err != nil {
+		return a, newAggError(err, AggKindNested)
+	}
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// NestedAgg is a special single bucket aggregation that enables aggregating
+// nested documents.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-nested-aggregation.html
+type NestedAgg struct {
+	path string
+	aggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*NestedAgg)(nil)
+
+func (NestedAgg) Kind() AggKind {
+	return AggKindNested
+}
+
+func (a *NestedAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// Path of the nested documents within the top level documents
+func (a NestedAgg) Path() string {
+	return a.path
+}
+
+// SetPath sets the path to v
+func (a *NestedAgg) SetPath(v string) error {
+	if len(v) == 0 {
+		return ErrPathRequired
+	}
+	a.path = v
+	return nil
+}
+
+func (a NestedAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a NestedAgg) MarshalJSON() ([]byte, error) {
+	return nestedAgg{
+		Path: a.path,
+	}.MarshalJSON()
+}
+
+func (a *NestedAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *NestedAgg) UnmarshalJSON(data []byte) error {
+	*a = NestedAgg{}
+	p := nestedAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.path = p.Path
+	return nil
+}
+
+//easyjson:json
+type nestedAgg struct {
+	Path string `json:"path"`
+}
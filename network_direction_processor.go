@@ -0,0 +1,220 @@
+package picker
+
+import "github.com/chanced/dynamic"
+
+// NetworkDirectionProcessorParams creates a NetworkDirectionProcessor, which
+// calculates the network direction given a source IP address, destination IP
+// address, and a list of internal networks.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/network-direction-processor.html
+type NetworkDirectionProcessorParams struct {
+	// SourceIP is the field containing the source IP address. Defaults to
+	// "source.ip".
+	SourceIP string
+	// DestinationIP is the field containing the destination IP address.
+	// Defaults to "destination.ip".
+	DestinationIP string
+	// TargetField is the output field for the network direction. Defaults to
+	// "network.direction".
+	TargetField string
+	// InternalNetworks is a list of internal networks. Supports IPv4 and IPv6
+	// addresses and ranges in CIDR notation as well as named ranges, such as
+	// "private". Either InternalNetworks or InternalNetworksField is required.
+	InternalNetworks []string
+	// InternalNetworksField is a field on the given document to read the
+	// internal_networks configuration from
+	InternalNetworksField string
+	// If IgnoreMissing is true, the processor quietly exits without modifying
+	// the document when any of the fields are missing. Defaults to true.
+	IgnoreMissing interface{}
+	// Description of the processor. Useful for describing the purpose of the
+	// processor or its configuration.
+	Description string
+	// Conditionally execute the processor
+	If string
+	// Ignore failures for the processor
+	IgnoreFailure bool
+	// Handle failures for the processor
+	OnFailure Procs
+	// Identifier for the processor. Useful for debugging and metrics.
+	Tag string
+}
+
+func (NetworkDirectionProcessorParams) Kind() ProcessorKind {
+	return ProcessorKindNetworkDirection
+}
+
+func (p NetworkDirectionProcessorParams) Processor() (Processor, error) {
+	return p.NetworkDirection()
+}
+
+func (p NetworkDirectionProcessorParams) NetworkDirection() (*NetworkDirectionProcessor, error) {
+	proc := &NetworkDirectionProcessor{}
+	if len(p.InternalNetworks) == 0 && len(p.InternalNetworksField) == 0 {
+		return proc, newProcessorError(ErrInternalNetworksRequired, ProcessorKindNetworkDirection)
+	}
+	proc.SetSourceIP(p.SourceIP)
+	proc.SetDestinationIP(p.DestinationIP)
+	proc.SetTargetField(p.TargetField)
+	proc.SetInternalNetworks(p.InternalNetworks)
+	proc.SetInternalNetworksField(p.InternalNetworksField)
+	err := proc.SetIgnoreMissing(p.IgnoreMissing)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindNetworkDirection)
+	}
+	proc.SetDescription(p.Description)
+	proc.SetIf(p.If)
+	proc.SetIgnoreFailure(p.IgnoreFailure)
+	err = proc.SetOnFailure(p.OnFailure)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindNetworkDirection)
+	}
+	proc.SetTag(p.Tag)
+	return proc, nil
+}
+
+// NetworkDirectionProcessor calculates the network direction given a source IP
+// address, destination IP address, and a list of internal networks.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/network-direction-processor.html
+type NetworkDirectionProcessor struct {
+	sourceIP      string
+	destinationIP string
+	targetFieldParam
+	internalNetworks      []string
+	internalNetworksField string
+	ignoreMissing         dynamic.Bool
+	processorParams
+}
+
+var _ Processor = (*NetworkDirectionProcessor)(nil)
+
+func (NetworkDirectionProcessor) Kind() ProcessorKind {
+	return ProcessorKindNetworkDirection
+}
+
+func (p *NetworkDirectionProcessor) Processor() (Processor, error) {
+	return p, nil
+}
+
+// SourceIP is the field containing the source IP address. Defaults to
+// "source.ip".
+func (p NetworkDirectionProcessor) SourceIP() string {
+	return p.sourceIP
+}
+
+// SetSourceIP sets source_ip to v
+func (p *NetworkDirectionProcessor) SetSourceIP(v string) {
+	p.sourceIP = v
+}
+
+// DestinationIP is the field containing the destination IP address. Defaults to
+// "destination.ip".
+func (p NetworkDirectionProcessor) DestinationIP() string {
+	return p.destinationIP
+}
+
+// SetDestinationIP sets destination_ip to v
+func (p *NetworkDirectionProcessor) SetDestinationIP(v string) {
+	p.destinationIP = v
+}
+
+// InternalNetworks is a list of internal networks. Supports IPv4 and IPv6
+// addresses and ranges in CIDR notation as well as named ranges, such as
+// "private". Either InternalNetworks or InternalNetworksField is required.
+func (p NetworkDirectionProcessor) InternalNetworks() []string {
+	return p.internalNetworks
+}
+
+// SetInternalNetworks sets internal_networks to v
+func (p *NetworkDirectionProcessor) SetInternalNetworks(v []string) {
+	p.internalNetworks = v
+}
+
+// InternalNetworksField is a field on the given document to read the
+// internal_networks configuration from
+func (p NetworkDirectionProcessor) InternalNetworksField() string {
+	return p.internalNetworksField
+}
+
+// SetInternalNetworksField sets internal_networks_field to v
+func (p *NetworkDirectionProcessor) SetInternalNetworksField(v string) {
+	p.internalNetworksField = v
+}
+
+// If IgnoreMissing is true, the processor quietly exits without modifying the
+// document when any of the fields are missing. Defaults to true.
+func (p NetworkDirectionProcessor) IgnoreMissing() bool {
+	if v, ok := p.ignoreMissing.Bool(); ok {
+		return v
+	}
+	return true
+}
+
+// SetIgnoreMissing sets ignore_missing to v
+func (p *NetworkDirectionProcessor) SetIgnoreMissing(v interface{}) error {
+	return p.ignoreMissing.Set(v)
+}
+
+func (p NetworkDirectionProcessor) MarshalBSON() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+func (p NetworkDirectionProcessor) MarshalJSON() ([]byte, error) {
+	return networkDirectionProcessor{
+		SourceIP:              p.sourceIP,
+		DestinationIP:         p.destinationIP,
+		TargetField:           p.targetField,
+		InternalNetworks:      p.internalNetworks,
+		InternalNetworksField: p.internalNetworksField,
+		IgnoreMissing:         p.ignoreMissing.Value(),
+		Description:           p.description,
+		If:                    p.ifCond,
+		IgnoreFailure:         p.ignoreFailure,
+		OnFailure:             p.onFailure,
+		Tag:                   p.tag,
+	}.MarshalJSON()
+}
+
+func (p *NetworkDirectionProcessor) UnmarshalBSON(data []byte) error {
+	return p.UnmarshalJSON(data)
+}
+
+func (p *NetworkDirectionProcessor) UnmarshalJSON(data []byte) error {
+	*p = NetworkDirectionProcessor{}
+	var v networkDirectionProcessor
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	p.sourceIP = v.SourceIP
+	p.destinationIP = v.DestinationIP
+	p.targetField = v.TargetField
+	p.internalNetworks = v.InternalNetworks
+	p.internalNetworksField = v.InternalNetworksField
+	err = p.ignoreMissing.Set(v.IgnoreMissing)
+	if err != nil {
+		return err
+	}
+	p.description = v.Description
+	p.ifCond = v.If
+	p.ignoreFailure = v.IgnoreFailure
+	p.onFailure = v.OnFailure
+	p.tag = v.Tag
+	return nil
+}
+
+//easyjson:json
+type networkDirectionProcessor struct {
+	SourceIP              string      `json:"source_ip,omitempty"`
+	DestinationIP         string      `json:"destination_ip,omitempty"`
+	TargetField           string      `json:"target_field,omitempty"`
+	InternalNetworks      []string    `json:"internal_networks,omitempty"`
+	InternalNetworksField string      `json:"internal_networks_field,omitempty"`
+	IgnoreMissing         interface{} `json:"ignore_missing,omitempty"`
+	Description           string      `json:"description,omitempty"`
+	If                    string      `json:"if,omitempty"`
+	IgnoreFailure         bool        `json:"ignore_failure,omitempty"`
+	OnFailure             Processors  `json:"on_failure,omitempty"`
+	Tag                   string      `json:"tag,omitempty"`
+}
@@ -0,0 +1,159 @@
+package picker
+
+import "fmt"
+
+// NormalizeMethod is the method used by a normalize aggregation to rescale
+// values
+type NormalizeMethod string
+
+const (
+	// NormalizeMethodRescale01 rescales the data such that the minimum number
+	// is zero, and the maximum number is 1, with the rest normalized linearly
+	// in-between.
+	NormalizeMethodRescale01 NormalizeMethod = "rescale_0_1"
+	// NormalizeMethodRescale0100 rescales the data such that the minimum
+	// number is zero, and the maximum number is 100, with the rest normalized
+	// linearly in-between.
+	NormalizeMethodRescale0100 NormalizeMethod = "rescale_0_100"
+	// NormalizeMethodPercentOfSum normalizes each value so that it represents
+	// a percentage of the total sum it attributes to.
+	NormalizeMethodPercentOfSum NormalizeMethod = "percent_of_sum"
+	// NormalizeMethodMean normalizes such that each value is normalized by how
+	// much it differs from the average.
+	NormalizeMethodMean NormalizeMethod = "mean"
+	// NormalizeMethodZScore normalizes such that each value represents how far
+	// it is from the mean relative to the standard deviation
+	NormalizeMethodZScore NormalizeMethod = "z-score"
+	// NormalizeMethodSoftmax normalizes such that each value is exponentiated
+	// and relative to the sum of the exponents of the original values.
+	NormalizeMethodSoftmax NormalizeMethod = "softmax"
+)
+
+func (nm NormalizeMethod) String() string {
+	return string(nm)
+}
+
+// IsValid returns true if nm is a known NormalizeMethod
+func (nm NormalizeMethod) IsValid() bool {
+	switch nm {
+	case NormalizeMethodRescale01, NormalizeMethodRescale0100,
+		NormalizeMethodPercentOfSum, NormalizeMethodMean,
+		NormalizeMethodZScore, NormalizeMethodSoftmax:
+		return true
+	}
+	return false
+}
+
+// NormalizeAggParams creates a NormalizeAgg, a parent pipeline aggregation
+// which calculates the specific normalized/rescaled value for a specific bucket
+// value. Values that cannot be normalized, will be skipped using the skip gap
+// policy.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-normalize-aggregation.html
+type NormalizeAggParams struct {
+	// Path to the buckets to aggregate (Required)
+	BucketsPath string
+	// The specific method to apply (Required)
+	Method NormalizeMethod
+	// Format of the value_as_string in the response
+	Format string
+	Meta   map[string]interface{}
+}
+
+func (NormalizeAggParams) Kind() AggKind {
+	return AggKindNormalize
+}
+
+func (p NormalizeAggParams) Clause() (AggClause, error) {
+	return p.Normalize()
+}
+
+func (p NormalizeAggParams) Normalize() (*NormalizeAgg, error) {
+	a := &NormalizeAgg{}
+	err := a.SetBucketsPath(p.BucketsPath)
+	if err != nil {
+		return a, newAggError(err, AggKindNormalize)
+	}
+	err = a.SetMethod(p.Method)
+	if err != nil {
+		return a, newAggError(err, AggKindNormalize)
+	}
+	a.SetFormat(p.Format)
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// NormalizeAgg is a parent pipeline aggregation which calculates the specific
+// normalized/rescaled value for a specific bucket value.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-normalize-aggregation.html
+type NormalizeAgg struct {
+	bucketsPathParam
+	pipelineFormatParam
+	method NormalizeMethod
+	noAggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*NormalizeAgg)(nil)
+
+func (NormalizeAgg) Kind() AggKind {
+	return AggKindNormalize
+}
+
+func (a *NormalizeAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// Method is the specific method to apply
+func (a NormalizeAgg) Method() NormalizeMethod {
+	return a.method
+}
+
+// SetMethod sets method to v
+func (a *NormalizeAgg) SetMethod(v NormalizeMethod) error {
+	if len(v) == 0 {
+		return ErrMethodRequired
+	}
+	if !v.IsValid() {
+		return fmt.Errorf("%w <%s>", ErrInvalidNormalizeMethod, v)
+	}
+	a.method = v
+	return nil
+}
+
+func (a NormalizeAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a NormalizeAgg) MarshalJSON() ([]byte, error) {
+	return normalizeAgg{
+		BucketsPath: a.bucketsPath,
+		Method:      a.method,
+		Format:      a.format,
+	}.MarshalJSON()
+}
+
+func (a *NormalizeAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *NormalizeAgg) UnmarshalJSON(data []byte) error {
+	*a = NormalizeAgg{}
+	p := normalizeAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.bucketsPath = p.BucketsPath
+	a.method = p.Method
+	a.SetFormat(p.Format)
+	return nil
+}
+
+//easyjson:json
+type normalizeAgg struct {
+	BucketsPath string          `json:"buckets_path"`
+	Method      NormalizeMethod `json:"method"`
+	Format      string          `json:"format,omitempty"`
+}
@@ -0,0 +1,100 @@
+package picker
+
+// ParentAggParams creates a ParentAgg, a special single bucket aggregation that
+// selects parent documents that have the specified type, as defined in a join
+// field.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-parent-aggregation.html
+type ParentAggParams struct {
+	// The child type whose parents should be selected (Required)
+	Type string
+	// Sub-aggregations
+	Aggregations Aggs
+	Meta         map[string]interface{}
+}
+
+func (ParentAggParams) Kind() AggKind {
+	return AggKindParent
+}
+
+func (p ParentAggParams) Clause() (AggClause, error) {
+	return p.Parent()
+}
+
+func (p ParentAggParams) Parent() (*ParentAgg, error) {
+	a := &ParentAgg{}
+	err := a.SetType(p.Type)
+	if err != nil {
+		return a, newAggError(err, AggKindParent)
+	}
+	err = a.SetAggregations(p.Aggregations)
+	if err != nil {
+		return a, newAggError(err, AggKindParent)
+	}
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// ParentAgg is a special single bucket aggregation that selects parent
+// documents that have the specified type, as defined in a join field.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-parent-aggregation.html
+type ParentAgg struct {
+	typ string
+	aggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*ParentAgg)(nil)
+
+func (ParentAgg) Kind() AggKind {
+	return AggKindParent
+}
+
+func (a *ParentAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// Type is the child type whose parents should be selected
+func (a ParentAgg) Type() string {
+	return a.typ
+}
+
+// SetType sets the type to v
+func (a *ParentAgg) SetType(v string) error {
+	if len(v) == 0 {
+		return ErrTypeRequired
+	}
+	a.typ = v
+	return nil
+}
+
+func (a ParentAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a ParentAgg) MarshalJSON() ([]byte, error) {
+	return parentAgg{
+		Type: a.typ,
+	}.MarshalJSON()
+}
+
+func (a *ParentAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *ParentAgg) UnmarshalJSON(data []byte) error {
+	*a = ParentAgg{}
+	p := parentAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.typ = p.Type
+	return nil
+}
+
+//easyjson:json
+type parentAgg struct {
+	Type string `json:"type"`
+}
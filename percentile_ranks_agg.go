@@ -0,0 +1,207 @@
+package picker
+
+import "github.com/chanced/dynamic"
+
+// PercentileRanksAggParams creates a PercentileRanksAgg, a multi-value metrics
+// aggregation that calculates one or more percentile ranks over numeric values
+// extracted from the aggregated documents.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-percentile-rank-aggregation.html
+type PercentileRanksAggParams struct {
+	// The field to aggregate on. Either Field or Script is required.
+	Field string
+	// Script to generate values with. If Field is also set, Script is a value
+	// script.
+	Script *Script
+	// Missing is the value used for documents which do not have a value
+	Missing interface{}
+	// Hint as to the type of value produced by Script
+	ValueType string
+	// Format of the value_as_string in the response
+	Format string
+	// The values to calculate percentile ranks for (Required)
+	Values []float64
+	// If false, percentiles are returned as an array rather than a hash keyed
+	// by the percentile. Defaults to true.
+	Keyed interface{}
+	// Configures the TDigest algorithm, the default method. Only one of TDigest
+	// or HDR can be set.
+	TDigest *TDigest
+	// Configures the High Dynamic Range Histogram algorithm. Only one of
+	// TDigest or HDR can be set.
+	HDR  *HDR
+	Meta map[string]interface{}
+}
+
+func (PercentileRanksAggParams) Kind() AggKind {
+	return AggKindPercentileRanks
+}
+
+func (p PercentileRanksAggParams) Clause() (AggClause, error) {
+	return p.PercentileRanks()
+}
+
+func (p PercentileRanksAggParams) PercentileRanks() (*PercentileRanksAgg, error) {
+	a := &PercentileRanksAgg{}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	err := a.checkValueSource()
+	if err != nil {
+		return a, newAggError(err, AggKindPercentileRanks)
+	}
+	a.SetMissing(p.Missing)
+	a.SetValueType(p.ValueType)
+	a.SetFormat(p.Format)
+	err = a.SetValues(p.Values)
+	if err != nil {
+		return a, newAggError(err, AggKindPercentileRanks)
+	}
+	err = a.SetKeyed(p.Keyed)
+	if err != nil {
+		return a, newAggError(err, AggKindPercentileRanks)
+	}
+	if p.TDigest != nil && p.HDR != nil {
+		return a, newAggError(ErrMultiplePercentilesMethods, AggKindPercentileRanks)
+	}
+	a.SetTDigest(p.TDigest)
+	a.SetHDR(p.HDR)
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// PercentileRanksAgg is a multi-value metrics aggregation that calculates one
+// or more percentile ranks over numeric values extracted from the aggregated
+// documents.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-percentile-rank-aggregation.html
+type PercentileRanksAgg struct {
+	valueSourceParam
+	values  []float64
+	keyed   dynamic.Bool
+	tdigest *TDigest
+	hdr     *HDR
+	noAggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*PercentileRanksAgg)(nil)
+
+func (PercentileRanksAgg) Kind() AggKind {
+	return AggKindPercentileRanks
+}
+
+func (a *PercentileRanksAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// Values are the values percentile ranks are calculated for
+func (a PercentileRanksAgg) Values() []float64 {
+	return a.values
+}
+
+// SetValues sets values to v
+func (a *PercentileRanksAgg) SetValues(v []float64) error {
+	if len(v) == 0 {
+		return ErrValuesRequired
+	}
+	a.values = v
+	return nil
+}
+
+// Keyed indicates whether percentiles are returned as a hash rather than an
+// array. Defaults to true.
+func (a PercentileRanksAgg) Keyed() bool {
+	if v, ok := a.keyed.Bool(); ok {
+		return v
+	}
+	return true
+}
+
+// SetKeyed sets keyed to v
+func (a *PercentileRanksAgg) SetKeyed(v interface{}) error {
+	return a.keyed.Set(v)
+}
+
+// TDigest configures the TDigest algorithm used to calculate percentiles
+func (a PercentileRanksAgg) TDigest() *TDigest {
+	return a.tdigest
+}
+
+// SetTDigest sets tdigest to v
+func (a *PercentileRanksAgg) SetTDigest(v *TDigest) {
+	a.tdigest = v
+	if v != nil {
+		a.hdr = nil
+	}
+}
+
+// HDR configures the High Dynamic Range Histogram algorithm used to calculate
+// percentiles
+func (a PercentileRanksAgg) HDR() *HDR {
+	return a.hdr
+}
+
+// SetHDR sets hdr to v
+func (a *PercentileRanksAgg) SetHDR(v *HDR) {
+	a.hdr = v
+	if v != nil {
+		a.tdigest = nil
+	}
+}
+
+func (a PercentileRanksAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a PercentileRanksAgg) MarshalJSON() ([]byte, error) {
+	return percentileRanksAgg{
+		Field:     a.field,
+		Script:    a.script,
+		Missing:   a.missing,
+		ValueType: a.valueType,
+		Format:    a.format,
+		Values:    a.values,
+		Keyed:     a.keyed.Value(),
+		TDigest:   a.tdigest,
+		HDR:       a.hdr,
+	}.MarshalJSON()
+}
+
+func (a *PercentileRanksAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *PercentileRanksAgg) UnmarshalJSON(data []byte) error {
+	*a = PercentileRanksAgg{}
+	p := percentileRanksAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	a.SetMissing(p.Missing)
+	a.SetValueType(p.ValueType)
+	a.SetFormat(p.Format)
+	a.values = p.Values
+	err = a.keyed.Set(p.Keyed)
+	if err != nil {
+		return err
+	}
+	a.tdigest = p.TDigest
+	a.hdr = p.HDR
+	return nil
+}
+
+//easyjson:json
+type percentileRanksAgg struct {
+	Field     string      `json:"field,omitempty"`
+	Script    *Script     `json:"script,omitempty"`
+	Missing   interface{} `json:"missing,omitempty"`
+	ValueType string      `json:"value_type,omitempty"`
+	Format    string      `json:"format,omitempty"`
+	Values    []float64   `json:"values"`
+	Keyed     interface{} `json:"keyed,omitempty"`
+	TDigest   *TDigest    `json:"tdigest,omitempty"`
+	HDR       *HDR        `json:"hdr,omitempty"`
+}
@@ -0,0 +1,231 @@
+package picker
+
+import "github.com/chanced/dynamic"
+
+// DefaultPercents are the percentiles calculated by a percentiles aggregation
+// when none are provided.
+var DefaultPercents = []float64{1, 5, 25, 50, 75, 95, 99}
+
+// TDigest configures the TDigest algorithm used by the percentiles and
+// percentile_ranks aggregations.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-percentile-aggregation.html#search-aggregations-metrics-percentile-aggregation-compression
+//
+//easyjson:json
+type TDigest struct {
+	// Compression controls memory usage and approximation error. Defaults to
+	// 100.
+	Compression float64 `json:"compression,omitempty"`
+}
+
+// HDR configures the High Dynamic Range Histogram algorithm used by the
+// percentiles and percentile_ranks aggregations.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-percentile-aggregation.html#_hdr_histogram
+//
+//easyjson:json
+type HDR struct {
+	// NumberOfSignificantValueDigits specifies the resolution of values for
+	// the histogram in number of significant digits.
+	NumberOfSignificantValueDigits int `json:"number_of_significant_value_digits"`
+}
+
+// PercentilesAggParams creates a PercentilesAgg, a multi-value metrics
+// aggregation that calculates one or more percentiles over numeric values
+// extracted from the aggregated documents.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-percentile-aggregation.html
+type PercentilesAggParams struct {
+	// The field to aggregate on. Either Field or Script is required.
+	Field string
+	// Script to generate values with. If Field is also set, Script is a value
+	// script.
+	Script *Script
+	// Missing is the value used for documents which do not have a value
+	Missing interface{}
+	// Hint as to the type of value produced by Script
+	ValueType string
+	// Format of the value_as_string in the response
+	Format string
+	// The percentiles to calculate. Defaults to [1, 5, 25, 50, 75, 95, 99].
+	Percents []float64
+	// If false, percentiles are returned as an array rather than a hash keyed
+	// by the percentile. Defaults to true.
+	Keyed interface{}
+	// Configures the TDigest algorithm, the default method. Only one of TDigest
+	// or HDR can be set.
+	TDigest *TDigest
+	// Configures the High Dynamic Range Histogram algorithm. Only one of
+	// TDigest or HDR can be set.
+	HDR  *HDR
+	Meta map[string]interface{}
+}
+
+func (PercentilesAggParams) Kind() AggKind {
+	return AggKindPercentiles
+}
+
+func (p PercentilesAggParams) Clause() (AggClause, error) {
+	return p.Percentiles()
+}
+
+func (p PercentilesAggParams) Percentiles() (*PercentilesAgg, error) {
+	a := &PercentilesAgg{}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	err := a.checkValueSource()
+	if err != nil {
+		return a, newAggError(err, AggKindPercentiles)
+	}
+	a.SetMissing(p.Missing)
+	a.SetValueType(p.ValueType)
+	a.SetFormat(p.Format)
+	a.SetPercents(p.Percents)
+	err = a.SetKeyed(p.Keyed)
+	if err != nil {
+		return a, newAggError(err, AggKindPercentiles)
+	}
+	if p.TDigest != nil && p.HDR != nil {
+		return a, newAggError(ErrMultiplePercentilesMethods, AggKindPercentiles)
+	}
+	a.SetTDigest(p.TDigest)
+	a.SetHDR(p.HDR)
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// PercentilesAgg is a multi-value metrics aggregation that calculates one or
+// more percentiles over numeric values extracted from the aggregated documents.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-percentile-aggregation.html
+type PercentilesAgg struct {
+	valueSourceParam
+	percents []float64
+	keyed    dynamic.Bool
+	tdigest  *TDigest
+	hdr      *HDR
+	noAggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*PercentilesAgg)(nil)
+
+func (PercentilesAgg) Kind() AggKind {
+	return AggKindPercentiles
+}
+
+func (a *PercentilesAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// Percents are the percentiles to calculate. Defaults to [1, 5, 25, 50, 75, 95,
+// 99].
+func (a PercentilesAgg) Percents() []float64 {
+	if len(a.percents) == 0 {
+		return DefaultPercents
+	}
+	return a.percents
+}
+
+// SetPercents sets percents to v
+func (a *PercentilesAgg) SetPercents(v []float64) {
+	a.percents = v
+}
+
+// Keyed indicates whether percentiles are returned as a hash rather than an
+// array. Defaults to true.
+func (a PercentilesAgg) Keyed() bool {
+	if v, ok := a.keyed.Bool(); ok {
+		return v
+	}
+	return true
+}
+
+// SetKeyed sets keyed to v
+func (a *PercentilesAgg) SetKeyed(v interface{}) error {
+	return a.keyed.Set(v)
+}
+
+// TDigest configures the TDigest algorithm used to calculate percentiles
+func (a PercentilesAgg) TDigest() *TDigest {
+	return a.tdigest
+}
+
+// SetTDigest sets tdigest to v
+func (a *PercentilesAgg) SetTDigest(v *TDigest) {
+	a.tdigest = v
+	if v != nil {
+		a.hdr = nil
+	}
+}
+
+// HDR configures the High Dynamic Range Histogram algorithm used to calculate
+// percentiles
+func (a PercentilesAgg) HDR() *HDR {
+	return a.hdr
+}
+
+// SetHDR sets hdr to v
+func (a *PercentilesAgg) SetHDR(v *HDR) {
+	a.hdr = v
+	if v != nil {
+		a.tdigest = nil
+	}
+}
+
+func (a PercentilesAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a PercentilesAgg) MarshalJSON() ([]byte, error) {
+	return percentilesAgg{
+		Field:     a.field,
+		Script:    a.script,
+		Missing:   a.missing,
+		ValueType: a.valueType,
+		Format:    a.format,
+		Percents:  a.percents,
+		Keyed:     a.keyed.Value(),
+		TDigest:   a.tdigest,
+		HDR:       a.hdr,
+	}.MarshalJSON()
+}
+
+func (a *PercentilesAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *PercentilesAgg) UnmarshalJSON(data []byte) error {
+	*a = PercentilesAgg{}
+	p := percentilesAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	a.SetMissing(p.Missing)
+	a.SetValueType(p.ValueType)
+	a.SetFormat(p.Format)
+	a.percents = p.Percents
+	err = a.keyed.Set(p.Keyed)
+	if err != nil {
+		return err
+	}
+	a.tdigest = p.TDigest
+	a.hdr = p.HDR
+	return nil
+}
+
+//easyjson:json
+type percentilesAgg struct {
+	Field     string      `json:"field,omitempty"`
+	Script    *Script     `json:"script,omitempty"`
+	Missing   interface{} `json:"missing,omitempty"`
+	ValueType string      `json:"value_type,omitempty"`
+	Format    string      `json:"format,omitempty"`
+	Percents  []float64   `json:"percents,omitempty"`
+	Keyed     interface{} `json:"keyed,omitempty"`
+	TDigest   *TDigest    `json:"tdigest,omitempty"`
+	HDR       *HDR        `json:"hdr,omitempty"`
+}
@@ -0,0 +1,149 @@
+package picker
+
+import "github.com/chanced/dynamic"
+
+// PercentilesBucketAggParams creates a PercentilesBucketAgg, a sibling pipeline
+// aggregation which calculates percentiles across all bucket of a specified
+// metric in a sibling aggregation.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-percentiles-bucket-aggregation.html
+type PercentilesBucketAggParams struct {
+	// Path to the buckets to aggregate (Required)
+	BucketsPath string
+	// Policy to apply when gaps are found in the data. Defaults to "skip".
+	GapPolicy GapPolicy
+	// Format of the value_as_string in the response
+	Format string
+	// The list of percentiles to calculate. Defaults to [1, 5, 25, 50, 75, 95,
+	// 99].
+	Percents []float64
+	// If false, percentiles are returned as an array rather than a hash keyed
+	// by the percentile. Defaults to true.
+	Keyed interface{}
+	Meta  map[string]interface{}
+}
+
+func (PercentilesBucketAggParams) Kind() AggKind {
+	return AggKindPercentilesBucket
+}
+
+func (p PercentilesBucketAggParams) Clause() (AggClause, error) {
+	return p.PercentilesBucket()
+}
+
+func (p PercentilesBucketAggParams) PercentilesBucket() (*PercentilesBucketAgg, error) {
+	a := &PercentilesBucketAgg{}
+	err := a.SetBucketsPath(p.BucketsPath)
+	if err != nil {
+		return a, newAggError(err, AggKindPercentilesBucket)
+	}
+	err = a.SetGapPolicy(p.GapPolicy)
+	if err != nil {
+		return a, newAggError(err, AggKindPercentilesBucket)
+	}
+	a.SetFormat(p.Format)
+	a.SetPercents(p.Percents)
+	err = a.SetKeyed(p.Keyed)
+	if err != nil {
+		return a, newAggError(err, AggKindPercentilesBucket)
+	}
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// PercentilesBucketAgg is a sibling pipeline aggregation which calculates
+// percentiles across all bucket of a specified metric in a sibling aggregation.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-percentiles-bucket-aggregation.html
+type PercentilesBucketAgg struct {
+	bucketsPathParam
+	gapPolicyParam
+	pipelineFormatParam
+	percents []float64
+	keyed    dynamic.Bool
+	noAggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*PercentilesBucketAgg)(nil)
+
+func (PercentilesBucketAgg) Kind() AggKind {
+	return AggKindPercentilesBucket
+}
+
+func (a *PercentilesBucketAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// Percents are the percentiles to calculate. Defaults to [1, 5, 25, 50, 75, 95,
+// 99].
+func (a PercentilesBucketAgg) Percents() []float64 {
+	if len(a.percents) == 0 {
+		return DefaultPercents
+	}
+	return a.percents
+}
+
+// SetPercents sets percents to v
+func (a *PercentilesBucketAgg) SetPercents(v []float64) {
+	a.percents = v
+}
+
+// Keyed indicates whether percentiles are returned as a hash rather than an
+// array. Defaults to true.
+func (a PercentilesBucketAgg) Keyed() bool {
+	if v, ok := a.keyed.Bool(); ok {
+		return v
+	}
+	return true
+}
+
+// SetKeyed sets keyed to v
+func (a *PercentilesBucketAgg) SetKeyed(v interface{}) error {
+	return a.keyed.Set(v)
+}
+
+func (a PercentilesBucketAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a PercentilesBucketAgg) MarshalJSON() ([]byte, error) {
+	return percentilesBucketAgg{
+		BucketsPath: a.bucketsPath,
+		GapPolicy:   a.gapPolicy,
+		Format:      a.format,
+		Percents:    a.percents,
+		Keyed:       a.keyed.Value(),
+	}.MarshalJSON()
+}
+
+func (a *PercentilesBucketAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *PercentilesBucketAgg) UnmarshalJSON(data []byte) error {
+	*a = PercentilesBucketAgg{}
+	p := percentilesBucketAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.bucketsPath = p.BucketsPath
+	a.gapPolicy = p.GapPolicy
+	a.SetFormat(p.Format)
+	a.percents = p.Percents
+	err = a.keyed.Set(p.Keyed)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+//easyjson:json
+type percentilesBucketAgg struct {
+	BucketsPath string      `json:"buckets_path"`
+	GapPolicy   GapPolicy   `json:"gap_policy,omitempty"`
+	Format      string      `json:"format,omitempty"`
+	Percents    []float64   `json:"percents,omitempty"`
+	Keyed       interface{} `json:"keyed,omitempty"`
+}
@@ -0,0 +1,353 @@
+package picker
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/chanced/dynamic"
+)
+
+// PhraseSuggester adds additional logic on top of the TermSuggester to select
+// entire corrected phrases instead of individual tokens weighted based on
+// ngram-language models.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-suggesters.html#phrase-suggester
+//
+//easyjson:json
+type PhraseSuggester struct {
+	// The name of the field used to do n-gram lookups for the language model
+	// (Required)
+	Field string `json:"field"`
+	// Sets max size of the n-grams (shingles) in the field.
+	GramSize int `json:"gram_size,omitempty"`
+	// The likelihood of a term being misspelled even if the term exists in
+	// the dictionary. Defaults to 0.95.
+	RealWordErrorLikelihood float64 `json:"real_word_error_likelihood,omitempty"`
+	// The confidence level defines a factor applied to the input phrases
+	// score which is used as a threshold for other suggest candidates.
+	// Defaults to 1.0.
+	Confidence *float64 `json:"confidence,omitempty"`
+	// The maximum percentage of the terms considered to be misspellings in
+	// order to form a correction. Defaults to 1.0.
+	MaxErrors float64 `json:"max_errors,omitempty"`
+	// The separator that is used to separate terms in the bigram field.
+	Separator string `json:"separator,omitempty"`
+	// The number of candidates that are generated for each individual query
+	// term. Defaults to 5.
+	Size int `json:"size,omitempty"`
+	// The analyzer to analyze the suggest text with. Defaults to the search
+	// analyzer of the suggest field.
+	Analyzer string `json:"analyzer,omitempty"`
+	// Sets the maximum number of suggested terms to be retrieved from each
+	// individual shard. Defaults to 5.
+	ShardSize int `json:"shard_size,omitempty"`
+	// Sets up suggestion highlighting. If not provided then no highlighted
+	// field is returned.
+	Highlight *PhraseSuggestHighlight `json:"highlight,omitempty"`
+	// Checks each suggestion against the specified query to prune suggestions
+	// for which no matching docs exist in the index.
+	Collate *PhraseSuggestCollate `json:"collate,omitempty"`
+	// Candidate generators used to produce a list of possible terms per term
+	// in the given text.
+	DirectGenerators []DirectGenerator `json:"direct_generator,omitempty"`
+	// The smoothing model to balance weight between infrequent grams (grams
+	// (shingles) are not existing in the index) and frequent grams (appear at
+	// least once in the index).
+	Smoothing *PhraseSmoothing `json:"smoothing,omitempty"`
+}
+
+// Validate checks that the field is set and the options are valid
+func (s PhraseSuggester) Validate() error {
+	if len(s.Field) == 0 {
+		return ErrFieldRequired
+	}
+	for i, g := range s.DirectGenerators {
+		err := g.Validate()
+		if err != nil {
+			return newFieldError(err, fmt.Sprintf("direct_generator[%d]", i))
+		}
+	}
+	if s.Collate != nil && s.Collate.Query == nil {
+		return newFieldError(ErrQueryRequired, "collate")
+	}
+	if s.Smoothing != nil {
+		err := s.Smoothing.Validate()
+		if err != nil {
+			return newFieldError(err, "smoothing")
+		}
+	}
+	return nil
+}
+
+// PhraseSuggestHighlight sets up highlighting of phrase suggestions
+//
+//easyjson:json
+type PhraseSuggestHighlight struct {
+	PreTag  string `json:"pre_tag"`
+	PostTag string `json:"post_tag"`
+}
+
+// PhraseSuggestCollate checks each suggestion against Query to prune
+// suggestions for which no matching docs exist in the index. The suggestion
+// is available to the query as the {{suggestion}} template variable.
+//
+//	picker.PhraseSuggestCollate{
+//	    Query: &picker.QueryParams{
+//	        Match: picker.MatchQueryParams{Field: "title", Query: "{{suggestion}}"},
+//	    },
+//	    Prune: true,
+//	}
+type PhraseSuggestCollate struct {
+	// The query to run for each suggestion (Required)
+	Query Querier
+	// Additional parameters of the query template
+	Params map[string]interface{}
+	// If true, all suggestions are returned with a collate_match of true or
+	// false, rather than only those which matched.
+	Prune bool
+}
+
+func (c PhraseSuggestCollate) MarshalBSON() ([]byte, error) {
+	return c.MarshalJSON()
+}
+
+func (c PhraseSuggestCollate) MarshalJSON() ([]byte, error) {
+	if c.Query == nil {
+		return nil, ErrQueryRequired
+	}
+	q, err := c.Query.Query()
+	if err != nil {
+		return nil, newFieldError(err, "query")
+	}
+	source, err := q.MarshalJSON()
+	if err != nil {
+		return nil, newFieldError(err, "query")
+	}
+	return phraseSuggestCollate{
+		Query:  collateQuery{Source: source},
+		Params: c.Params,
+		Prune:  c.Prune,
+	}.MarshalJSON()
+}
+
+func (c *PhraseSuggestCollate) UnmarshalBSON(data []byte) error {
+	return c.UnmarshalJSON(data)
+}
+
+// UnmarshalJSON decodes the query source, which can be either a query
+// object or a string containing one.
+func (c *PhraseSuggestCollate) UnmarshalJSON(data []byte) error {
+	*c = PhraseSuggestCollate{}
+	var v phraseSuggestCollate
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	source := v.Query.Source
+	if source.IsString() {
+		var str string
+		err = json.Unmarshal(source, &str)
+		if err != nil {
+			return newFieldError(err, "query")
+		}
+		source = dynamic.JSON(str)
+	}
+	q := &Query{}
+	err = q.UnmarshalJSON(source)
+	if err != nil {
+		return newFieldError(err, "query")
+	}
+	c.Query = q
+	c.Params = v.Params
+	c.Prune = v.Prune
+	return nil
+}
+
+//easyjson:json
+type phraseSuggestCollate struct {
+	Query  collateQuery           `json:"query"`
+	Params map[string]interface{} `json:"params,omitempty"`
+	Prune  bool                   `json:"prune,omitempty"`
+}
+
+//easyjson:json
+type collateQuery struct {
+	Source dynamic.JSON `json:"source"`
+}
+
+// DirectGenerator is a candidate generator of a PhraseSuggester. It produces
+// a list of possible terms per term in the given text, similar to a
+// TermSuggester.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-suggesters.html#_direct_generators
+//
+//easyjson:json
+type DirectGenerator struct {
+	// The field to fetch the candidate suggestions from (Required)
+	Field string `json:"field"`
+	// The maximum corrections to be returned per suggest text token.
+	Size int `json:"size,omitempty"`
+	// Controls what suggestions are included on the suggestions generated on
+	// each shard.
+	SuggestMode SuggestMode `json:"suggest_mode,omitempty"`
+	// The maximum edit distance candidate suggestions can have in order to be
+	// considered as a suggestion. Can only be a value between 1 and 2.
+	MaxEdits int `json:"max_edits,omitempty"`
+	// The number of minimal prefix characters that must match in order be a
+	// candidate suggestions. Defaults to 1.
+	PrefixLength *int `json:"prefix_length,omitempty"`
+	// The minimum length a suggest text term must have in order to be
+	// included. Defaults to 4.
+	MinWordLength int `json:"min_word_length,omitempty"`
+	// A factor that is used to multiply with the shards_size in order to
+	// inspect more candidate spelling corrections on the shard level.
+	MaxInspections int `json:"max_inspections,omitempty"`
+	// The minimal threshold in number of documents a suggestion should appear
+	// in.
+	MinDocFreq float64 `json:"min_doc_freq,omitempty"`
+	// The maximum threshold in number of documents in which a suggest text
+	// token can exist in order to be included.
+	MaxTermFreq float64 `json:"max_term_freq,omitempty"`
+	// A filter (analyzer) that is applied to each of the tokens passed to this
+	// candidate generator.
+	PreFilter string `json:"pre_filter,omitempty"`
+	// A filter (analyzer) that is applied to each of the generated tokens
+	// before they are passed to the actual phrase scorer.
+	PostFilter string `json:"post_filter,omitempty"`
+}
+
+// Validate checks that the field is set and the options are valid
+func (g DirectGenerator) Validate() error {
+	if len(g.Field) == 0 {
+		return ErrFieldRequired
+	}
+	if err := g.SuggestMode.Validate(); err != nil {
+		return err
+	}
+	return validateMaxEdits(g.MaxEdits)
+}
+
+// PhraseSmoothing is the smoothing model of a PhraseSuggester. Exactly one of
+// StupidBackoff, Laplace, or LinearInterpolation must be set.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-suggesters.html#_smoothing_models
+type PhraseSmoothing struct {
+	StupidBackoff       *StupidBackoffSmoothing
+	Laplace             *LaplaceSmoothing
+	LinearInterpolation *LinearInterpolationSmoothing
+}
+
+// StupidBackoffSmoothing is a simple backoff model that backs off to lower
+// order n-gram models if the higher order count is 0 and discounts the lower
+// order n-gram model by a constant factor. This is the default.
+//
+//easyjson:json
+type StupidBackoffSmoothing struct {
+	// The constant factor. Defaults to 0.4.
+	Discount float64 `json:"discount,omitempty"`
+}
+
+// LaplaceSmoothing uses additive smoothing where a constant (typically 1.0
+// or smaller) is added to all counts to balance weights.
+//
+//easyjson:json
+type LaplaceSmoothing struct {
+	// The constant added to all counts. Defaults to 0.5.
+	Alpha float64 `json:"alpha,omitempty"`
+}
+
+// LinearInterpolationSmoothing takes the weighted mean of the unigrams,
+// bigrams, and trigrams based on user supplied weights (lambdas). The
+// lambdas must add up to 1.
+//
+//easyjson:json
+type LinearInterpolationSmoothing struct {
+	TrigramLambda float64 `json:"trigram_lambda"`
+	BigramLambda  float64 `json:"bigram_lambda"`
+	UnigramLambda float64 `json:"unigram_lambda"`
+}
+
+// Kind returns the name of the smoothing model, e.g. "laplace"
+func (s PhraseSmoothing) Kind() string {
+	switch {
+	case s.StupidBackoff != nil:
+		return "stupid_backoff"
+	case s.Laplace != nil:
+		return "laplace"
+	case s.LinearInterpolation != nil:
+		return "linear_interpolation"
+	}
+	return ""
+}
+
+// Validate checks that exactly one smoothing model is set and, for
+// LinearInterpolation, that the lambdas add up to 1.
+func (s PhraseSmoothing) Validate() error {
+	n := 0
+	for _, set := range []bool{s.StupidBackoff != nil, s.Laplace != nil, s.LinearInterpolation != nil} {
+		if set {
+			n++
+		}
+	}
+	if n == 0 {
+		return ErrSmoothingModelRequired
+	}
+	if n > 1 {
+		return ErrMultipleSmoothingModels
+	}
+	if li := s.LinearInterpolation; li != nil {
+		sum := li.TrigramLambda + li.BigramLambda + li.UnigramLambda
+		if math.Abs(sum-1) > 1e-9 {
+			return fmt.Errorf("%w; received <%v>", ErrInvalidSmoothingLambdas, sum)
+		}
+	}
+	return nil
+}
+
+func (s PhraseSmoothing) MarshalBSON() ([]byte, error) {
+	return s.MarshalJSON()
+}
+
+func (s PhraseSmoothing) MarshalJSON() ([]byte, error) {
+	var body dynamic.JSON
+	var err error
+	switch {
+	case s.StupidBackoff != nil:
+		body, err = s.StupidBackoff.MarshalJSON()
+	case s.Laplace != nil:
+		body, err = s.Laplace.MarshalJSON()
+	case s.LinearInterpolation != nil:
+		body, err = s.LinearInterpolation.MarshalJSON()
+	default:
+		return nil, ErrSmoothingModelRequired
+	}
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(dynamic.JSONObject{s.Kind(): body})
+}
+
+func (s *PhraseSmoothing) UnmarshalBSON(data []byte) error {
+	return s.UnmarshalJSON(data)
+}
+
+func (s *PhraseSmoothing) UnmarshalJSON(data []byte) error {
+	*s = PhraseSmoothing{}
+	kind, body, err := unmarshalField(data)
+	if err != nil {
+		return err
+	}
+	switch kind {
+	case "stupid_backoff":
+		s.StupidBackoff = &StupidBackoffSmoothing{}
+		return s.StupidBackoff.UnmarshalJSON(body)
+	case "laplace":
+		s.Laplace = &LaplaceSmoothing{}
+		return s.Laplace.UnmarshalJSON(body)
+	case "linear_interpolation":
+		s.LinearInterpolation = &LinearInterpolationSmoothing{}
+		return s.LinearInterpolation.UnmarshalJSON(body)
+	}
+	return fmt.Errorf("%w <%s>", ErrUnsupportedType, kind)
+}
@@ -0,0 +1,245 @@
+package picker
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GapPolicy determines how a pipeline aggregation handles gaps in the data,
+// such as buckets which are empty or are missing the referenced metric.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline.html#gap-policy
+type GapPolicy string
+
+const (
+	// GapPolicySkip treats missing data as if the bucket does not exist. It
+	// will skip the bucket and continue calculating using the next available
+	// value. This is the default.
+	GapPolicySkip GapPolicy = "skip"
+	// GapPolicyInsertZeros replaces missing values with a zero (0) and
+	// pipeline aggregation computation will proceed as normal.
+	GapPolicyInsertZeros GapPolicy = "insert_zeros"
+	// GapPolicyKeepValues is similar to skip, except if the metric provides a
+	// non-null, non-NaN value this value is used, otherwise the empty bucket is
+	// skipped.
+	GapPolicyKeepValues GapPolicy = "keep_values"
+)
+
+func (gp GapPolicy) String() string {
+	return string(gp)
+}
+
+// IsValid returns true if gp is empty or a known GapPolicy
+func (gp GapPolicy) IsValid() bool {
+	switch gp {
+	case "", GapPolicySkip, GapPolicyInsertZeros, GapPolicyKeepValues:
+		return true
+	}
+	return false
+}
+
+// bucketsPather is implemented by pipeline aggregations which reference other
+// aggregations, typically through buckets_path.
+type bucketsPather interface {
+	bucketsPaths() []string
+}
+
+// bucketsPathParam is a mixin for pipeline aggregations which accept a single
+// buckets_path
+type bucketsPathParam struct {
+	bucketsPath string
+}
+
+// BucketsPath is the path to the buckets to aggregate.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline.html#buckets-path-syntax
+func (b bucketsPathParam) BucketsPath() string {
+	return b.bucketsPath
+}
+
+// SetBucketsPath sets buckets_path to v
+func (b *bucketsPathParam) SetBucketsPath(v string) error {
+	if len(v) == 0 {
+		return ErrBucketsPathRequired
+	}
+	b.bucketsPath = v
+	return nil
+}
+
+func (b bucketsPathParam) bucketsPaths() []string {
+	return []string{b.bucketsPath}
+}
+
+// bucketsPathsParam is a mixin for pipeline aggregations which map script
+// variables to buckets_paths, such as bucket_script and bucket_selector
+type bucketsPathsParam struct {
+	bucketsPath map[string]string
+}
+
+// BucketsPath is a map of script variables to the buckets_path of the metric
+// they reference.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline.html#buckets-path-syntax
+func (b bucketsPathsParam) BucketsPath() map[string]string {
+	return b.bucketsPath
+}
+
+// SetBucketsPath sets buckets_path to v
+func (b *bucketsPathsParam) SetBucketsPath(v map[string]string) error {
+	if len(v) == 0 {
+		return ErrBucketsPathRequired
+	}
+	for k, p := range v {
+		if len(p) == 0 {
+			return newFieldError(ErrBucketsPathRequired, k)
+		}
+	}
+	b.bucketsPath = v
+	return nil
+}
+
+func (b bucketsPathsParam) bucketsPaths() []string {
+	paths := make([]string, 0, len(b.bucketsPath))
+	for _, p := range b.bucketsPath {
+		paths = append(paths, p)
+	}
+	return paths
+}
+
+// gapPolicyParam is a mixin for pipeline aggregations which accept gap_policy
+type gapPolicyParam struct {
+	gapPolicy GapPolicy
+}
+
+// GapPolicy is the policy to apply when gaps are found in the data. Defaults
+// to "skip".
+func (g gapPolicyParam) GapPolicy() GapPolicy {
+	if len(g.gapPolicy) == 0 {
+		return GapPolicySkip
+	}
+	return g.gapPolicy
+}
+
+// SetGapPolicy sets gap_policy to v
+func (g *gapPolicyParam) SetGapPolicy(v GapPolicy) error {
+	if !v.IsValid() {
+		return fmt.Errorf("%w <%s>", ErrInvalidGapPolicy, v)
+	}
+	g.gapPolicy = v
+	return nil
+}
+
+// pipelineFormatParam is a mixin for pipeline aggregations which accept a
+// format for the value_as_string of the output
+type pipelineFormatParam struct {
+	format string
+}
+
+// Format is the format to apply to the value_as_string of the output
+func (f pipelineFormatParam) Format() string {
+	return f.format
+}
+
+// SetFormat sets format to v
+func (f *pipelineFormatParam) SetFormat(v string) {
+	f.format = v
+}
+
+// checkBucketsPaths walks aggs, resolving the buckets_path of each pipeline
+// aggregation, including the sort paths of bucket_sort, against its sibling
+// aggregations. Elasticsearch resolves buckets_path relative to the level of
+// the pipeline aggregation and can not reach the aggregations enclosing it.
+// The returned error is an *AggError with the full path of the offending
+// aggregation.
+func checkBucketsPaths(aggs Aggregations) error {
+	for name, agg := range aggs {
+		if agg == nil {
+			continue
+		}
+		if p, ok := agg.(bucketsPather); ok {
+			for _, path := range p.bucketsPaths() {
+				err := resolveBucketsPath(aggs, path)
+				if err != nil {
+					return newAggError(err, agg.Kind(), name)
+				}
+			}
+		}
+		err := checkBucketsPaths(agg.Aggregations())
+		if err != nil {
+			return newAggError(err, agg.Kind(), name)
+		}
+	}
+	return nil
+}
+
+// resolveBucketsPath checks that path references an aggregation within aggs.
+//
+//	AGG_SEPARATOR       =  `>` ;
+//	METRIC_SEPARATOR    =  `.` ;
+//	AGG_NAME            =  <the name of the aggregation> ;
+//	METRIC              =  <the name of the metric (in case of multi-value metrics aggregation)> ;
+//	MULTIBUCKET_KEY     =  `[<KEY_NAME>]`
+//	PATH                =  <AGG_NAME><MULTIBUCKET_KEY>? (<AGG_SEPARATOR>, <AGG_NAME> )* ( <METRIC_SEPARATOR>, <METRIC> ) ;
+func resolveBucketsPath(aggs Aggregations, path string) error {
+	if len(path) == 0 {
+		return ErrBucketsPathRequired
+	}
+	segments := splitBucketsPath(path)
+	for i, seg := range segments {
+		name := seg
+		if j := strings.IndexByte(name, '['); j >= 0 {
+			name = name[:j]
+		}
+		last := i == len(segments)-1
+		if last && isSpecialBucketsPath(name) {
+			return nil
+		}
+		agg, ok := aggs[name]
+		if !ok && last {
+			// the final segment may reference a metric of a multi-value
+			// aggregation, e.g. "the_stats.avg"
+			for j := 0; !ok && j < len(name); j++ {
+				if name[j] == '.' {
+					agg, ok = aggs[name[:j]]
+				}
+			}
+		}
+		if !ok || agg == nil {
+			return fmt.Errorf("%w <%s>; aggregation <%s> not found", ErrInvalidBucketsPath, path, name)
+		}
+		aggs = agg.Aggregations()
+	}
+	return nil
+}
+
+// splitBucketsPath splits path on '>', ignoring any which are within a
+// multi-bucket key
+func splitBucketsPath(path string) []string {
+	var segments []string
+	depth := 0
+	start := 0
+	for i := 0; i < len(path); i++ {
+		switch path[i] {
+		case '[':
+			depth++
+		case ']':
+			if depth > 0 {
+				depth--
+			}
+		case '>':
+			if depth == 0 {
+				segments = append(segments, path[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(segments, path[start:])
+}
+
+func isSpecialBucketsPath(name string) bool {
+	switch name {
+	case "_count", "_key", "_bucket_count":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,257 @@
+package picker_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/chanced/cmpjson"
+	"github.com/chanced/picker"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipelineAggs(t *testing.T) {
+	assert := require.New(t)
+	data := []byte(`{
+		"aggs": {
+			"sales_per_month": {
+				"date_histogram": { "field": "date", "calendar_interval": "month" },
+				"aggs": {
+					"sales": { "sum": { "field": "price" } },
+					"sales_stats": { "stats": { "field": "price" } },
+					"sales_deriv": { "derivative": { "buckets_path": "sales", "unit": "day" } },
+					"cumulative_sales": { "cumulative_sum": { "buckets_path": "sales" } },
+					"the_movfn": {
+						"moving_fn": {
+							"buckets_path": "sales",
+							"window": 10,
+							"script": "MovingFunctions.unweightedAvg(values)"
+						}
+					},
+					"avg_price": {
+						"bucket_script": {
+							"buckets_path": { "total": "sales", "count": "_count", "max": "sales_stats.max" },
+							"script": { "source": "params.total / params.count" },
+							"gap_policy": "insert_zeros"
+						}
+					},
+					"sales_bucket_sort": {
+						"bucket_sort": {
+							"sort": [{ "sales": { "order": "desc" } }],
+							"size": 3
+						}
+					}
+				}
+			},
+			"avg_monthly_sales": {
+				"avg_bucket": { "buckets_path": "sales_per_month>sales", "format": "#,##0.00" }
+			},
+			"percentiles_monthly_sales": {
+				"percentiles_bucket": { "buckets_path": "sales_per_month>sales", "percents": [25, 50, 75], "keyed": false }
+			}
+		}
+	}`)
+	var s picker.Search
+	err := json.Unmarshal(data, &s)
+	assert.NoError(err)
+	aggs := s.Aggregations()
+	avg, ok := aggs.Get("avg_monthly_sales").(*picker.AvgBucketAgg)
+	assert.True(ok)
+	assert.Equal("sales_per_month>sales", avg.BucketsPath())
+	assert.Equal(picker.GapPolicySkip, avg.GapPolicy())
+	pct, ok := aggs.Get("percentiles_monthly_sales").(*picker.PercentilesBucketAgg)
+	assert.True(ok)
+	assert.False(pct.Keyed())
+	monthly := aggs.Get("sales_per_month").Aggregations()
+	script, ok := monthly.Get("avg_price").(*picker.BucketScriptAgg)
+	assert.True(ok)
+	assert.Equal("_count", script.BucketsPath()["count"])
+	assert.Equal(picker.GapPolicyInsertZeros, script.GapPolicy())
+	movfn, ok := monthly.Get("the_movfn").(*picker.MovingFnAgg)
+	assert.True(ok)
+	assert.Equal(10, movfn.Window())
+
+	sd, err := json.Marshal(s)
+	assert.NoError(err)
+	assert.True(cmpjson.Equal(data, sd), cmpjson.Diff(data, sd))
+
+	s2, err := picker.NewSearch(picker.SearchParams{
+		Aggregations: picker.Aggs{
+			"sales_per_month": picker.DateHistogramAggParams{
+				Field:            "date",
+				CalendarInterval: "month",
+				Aggregations: picker.Aggs{
+					"sales":            picker.SumAggParams{Field: "price"},
+					"sales_stats":      picker.StatsAggParams{Field: "price"},
+					"sales_deriv":      picker.DerivativeAggParams{BucketsPath: "sales", Unit: "day"},
+					"cumulative_sales": picker.CumulativeSumAggParams{BucketsPath: "sales"},
+					"the_movfn": picker.MovingFnAggParams{
+						BucketsPath: "sales",
+						Window:      10,
+						Script:      "MovingFunctions.unweightedAvg(values)",
+					},
+					"avg_price": picker.BucketScriptAggParams{
+						BucketsPath: map[string]string{"total": "sales", "count": "_count", "max": "sales_stats.max"},
+						Script:      &picker.Script{Source: "params.total / params.count"},
+						GapPolicy:   picker.GapPolicyInsertZeros,
+					},
+					"sales_bucket_sort": picker.BucketSortAggParams{
+						Sort: picker.Sort{{Field: "sales", Order: picker.SortOrderDescending}},
+						Size: 3,
+					},
+				},
+			},
+			"avg_monthly_sales": picker.AvgBucketAggParams{
+				BucketsPath: "sales_per_month>sales",
+				Format:      "#,##0.00",
+			},
+			"percentiles_monthly_sales": picker.PercentilesBucketAggParams{
+				BucketsPath: "sales_per_month>sales",
+				Percents:    []float64{25, 50, 75},
+				Keyed:       false,
+			},
+		},
+	})
+	assert.NoError(err)
+	sd2, err := json.Marshal(s2)
+	assert.NoError(err)
+	assert.True(cmpjson.Equal(data, sd2), cmpjson.Diff(data, sd2))
+}
+
+func TestPipelineAggBucketsPathErrors(t *testing.T) {
+	assert := require.New(t)
+	_, err := picker.NewSearch(picker.SearchParams{
+		Aggregations: picker.Aggs{
+			"sales_per_month": picker.DateHistogramAggParams{
+				Field:            "date",
+				CalendarInterval: "month",
+				Aggregations: picker.Aggs{
+					"sales":       picker.SumAggParams{Field: "price"},
+					"sales_deriv": picker.DerivativeAggParams{BucketsPath: "sale"},
+				},
+			},
+		},
+	})
+	assert.True(errors.Is(err, picker.ErrInvalidBucketsPath))
+	var aggErr *picker.AggError
+	assert.True(errors.As(err, &aggErr))
+	assert.Equal("sales_per_month>sales_deriv", aggErr.Path)
+	assert.Equal(picker.AggKindDerivative, aggErr.Kind)
+
+	_, err = picker.NewSearch(picker.SearchParams{
+		Aggregations: picker.Aggs{
+			"sales_per_month": picker.DateHistogramAggParams{
+				Field:            "date",
+				CalendarInterval: "month",
+				Aggregations: picker.Aggs{
+					"sales": picker.SumAggParams{Field: "price"},
+				},
+			},
+			"max_monthly_sales": picker.MaxBucketAggParams{BucketsPath: "sales_per_month>revenue"},
+		},
+	})
+	assert.True(errors.Is(err, picker.ErrInvalidBucketsPath))
+	assert.True(errors.As(err, &aggErr))
+	assert.Equal("max_monthly_sales", aggErr.Path)
+
+	_, err = picker.NewSearch(picker.SearchParams{
+		Aggregations: picker.Aggs{
+			"sales_per_month": picker.DateHistogramAggParams{
+				Field:            "date",
+				CalendarInterval: "month",
+				Aggregations: picker.Aggs{
+					"sales_bucket_sort": picker.BucketSortAggParams{
+						Sort: picker.Sort{{Field: "total_sales"}},
+					},
+				},
+			},
+		},
+	})
+	assert.True(errors.Is(err, picker.ErrInvalidBucketsPath))
+	assert.True(errors.As(err, &aggErr))
+	assert.Equal("sales_per_month>sales_bucket_sort", aggErr.Path)
+
+	_, err = picker.NewSearch(picker.SearchParams{
+		Aggregations: picker.Aggs{
+			"max_monthly_sales": picker.MaxBucketAggParams{},
+		},
+	})
+	assert.True(errors.Is(err, picker.ErrBucketsPathRequired))
+
+	_, err = picker.NewSearch(picker.SearchParams{
+		Aggregations: picker.Aggs{
+			"deriv": picker.DerivativeAggParams{BucketsPath: "_count", GapPolicy: "ignore"},
+		},
+	})
+	assert.True(errors.Is(err, picker.ErrInvalidGapPolicy))
+}
+
+func TestPipelineAggParentBucketsPaths(t *testing.T) {
+	assert := require.New(t)
+	_, err := picker.NewSearch(picker.SearchParams{
+		Aggregations: picker.Aggs{
+			"sales_per_month": picker.DateHistogramAggParams{
+				Field:            "date",
+				CalendarInterval: "month",
+				Aggregations: picker.Aggs{
+					"sales": picker.SumAggParams{Field: "price"},
+					"by_type": picker.TermsAggParams{
+						Field: "type",
+						Aggregations: picker.Aggs{
+							"share": picker.BucketScriptAggParams{
+								BucketsPath: map[string]string{"total": "sales_per_month>sales"},
+								Script:      &picker.Script{Source: "params.total"},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	assert.True(errors.Is(err, picker.ErrInvalidBucketsPath))
+	var aggErr *picker.AggError
+	assert.True(errors.As(err, &aggErr))
+	assert.Equal("sales_per_month>by_type>share", aggErr.Path)
+
+	_, err = picker.NewSearch(picker.SearchParams{
+		Aggregations: picker.Aggs{
+			"sales_per_month": picker.DateHistogramAggParams{
+				Field:            "date",
+				CalendarInterval: "month",
+				Aggregations: picker.Aggs{
+					"sales": picker.SumAggParams{Field: "price"},
+					"by_type": picker.TermsAggParams{
+						Field: "type",
+						Aggregations: picker.Aggs{
+							"type_bucket_sort": picker.BucketSortAggParams{
+								Sort: picker.Sort{{Field: "sales", Order: picker.SortOrderDescending}},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	assert.True(errors.Is(err, picker.ErrInvalidBucketsPath))
+	assert.True(errors.As(err, &aggErr))
+	assert.Equal("sales_per_month>by_type>type_bucket_sort", aggErr.Path)
+
+	data := []byte(`{
+		"aggs": {
+			"sales_per_month": {
+				"date_histogram": { "field": "date", "calendar_interval": "month" },
+				"aggs": {
+					"sales": { "sum": { "field": "price" } },
+					"sales_bucket_sort": { "bucket_sort": { "sort": [{ "total_sales": { "order": "desc" } }] } }
+				}
+			}
+		}
+	}`)
+	var s picker.Search
+	err = json.Unmarshal(data, &s)
+	assert.NoError(err)
+	err = s.SetAggregations(s.Aggregations())
+	assert.True(errors.Is(err, picker.ErrInvalidBucketsPath))
+	assert.True(errors.As(err, &aggErr))
+	assert.Equal("sales_per_month>sales_bucket_sort", aggErr.Path)
+}
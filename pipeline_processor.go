@@ -0,0 +1,142 @@
+package picker
+
+// PipelineProcessorParams creates a PipelineProcessor, which executes another
+// pipeline.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/pipeline-processor.html
+type PipelineProcessorParams struct {
+	// Name of the pipeline to execute. Supports template snippets.
+	Name string
+	// IgnoreMissingPipeline indicates whether the processor ignores missing
+	// pipelines instead of failing
+	IgnoreMissingPipeline bool
+	// Description of the processor. Useful for describing the purpose of the
+	// processor or its configuration.
+	Description string
+	// Conditionally execute the processor
+	If string
+	// Ignore failures for the processor
+	IgnoreFailure bool
+	// Handle failures for the processor
+	OnFailure Procs
+	// Identifier for the processor. Useful for debugging and metrics.
+	Tag string
+}
+
+func (PipelineProcessorParams) Kind() ProcessorKind {
+	return ProcessorKindPipeline
+}
+
+func (p PipelineProcessorParams) Processor() (Processor, error) {
+	return p.Pipeline()
+}
+
+func (p PipelineProcessorParams) Pipeline() (*PipelineProcessor, error) {
+	proc := &PipelineProcessor{}
+	err := proc.SetName(p.Name)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindPipeline)
+	}
+	proc.SetIgnoreMissingPipeline(p.IgnoreMissingPipeline)
+	proc.SetDescription(p.Description)
+	proc.SetIf(p.If)
+	proc.SetIgnoreFailure(p.IgnoreFailure)
+	err = proc.SetOnFailure(p.OnFailure)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindPipeline)
+	}
+	proc.SetTag(p.Tag)
+	return proc, nil
+}
+
+// PipelineProcessor executes another pipeline.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/pipeline-processor.html
+type PipelineProcessor struct {
+	name                  string
+	ignoreMissingPipeline bool
+	processorParams
+}
+
+var _ Processor = (*PipelineProcessor)(nil)
+
+func (PipelineProcessor) Kind() ProcessorKind {
+	return ProcessorKindPipeline
+}
+
+func (p *PipelineProcessor) Processor() (Processor, error) {
+	return p, nil
+}
+
+// Name of the pipeline to execute. Supports template snippets.
+func (p PipelineProcessor) Name() string {
+	return p.name
+}
+
+// SetName sets name to v
+func (p *PipelineProcessor) SetName(v string) error {
+	if len(v) == 0 {
+		return ErrPipelineNameRequired
+	}
+	p.name = v
+	return nil
+}
+
+// IgnoreMissingPipeline indicates whether the processor ignores missing
+// pipelines instead of failing
+func (p PipelineProcessor) IgnoreMissingPipeline() bool {
+	return p.ignoreMissingPipeline
+}
+
+// SetIgnoreMissingPipeline sets ignore_missing_pipeline to v
+func (p *PipelineProcessor) SetIgnoreMissingPipeline(v bool) {
+	p.ignoreMissingPipeline = v
+}
+
+func (p PipelineProcessor) MarshalBSON() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+func (p PipelineProcessor) MarshalJSON() ([]byte, error) {
+	return pipelineProcessor{
+		Name:                  p.name,
+		IgnoreMissingPipeline: p.ignoreMissingPipeline,
+		Description:           p.description,
+		If:                    p.ifCond,
+		IgnoreFailure:         p.ignoreFailure,
+		OnFailure:             p.onFailure,
+		Tag:                   p.tag,
+	}.MarshalJSON()
+}
+
+func (p *PipelineProcessor) UnmarshalBSON(data []byte) error {
+	return p.UnmarshalJSON(data)
+}
+
+func (p *PipelineProcessor) UnmarshalJSON(data []byte) error {
+	*p = PipelineProcessor{}
+	var v pipelineProcessor
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	p.name = v.Name
+	p.ignoreMissingPipeline = v.IgnoreMissingPipeline
+	p.description = v.Description
+	p.ifCond = v.If
+	p.ignoreFailure = v.IgnoreFailure
+	p.onFailure = v.OnFailure
+	p.tag = v.Tag
+	return nil
+}
+
+//easyjson:json
+type pipelineProcessor struct {
+	Name                  string     `json:"name,omitempty"`
+	IgnoreMissingPipeline bool       `json:"ignore_missing_pipeline,omitempty"`
+	Description           string     `json:"description,omitempty"`
+	If                    string     `json:"if,omitempty"`
+	IgnoreFailure         bool       `json:"ignore_failure,omitempty"`
+	OnFailure             Processors `json:"on_failure,omitempty"`
+	Tag                   string     `json:"tag,omitempty"`
+}
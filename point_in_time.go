@@ -29,10 +29,44 @@ import (
 // index privilege for the alias’s concrete indices.
 //
 // https://www.elastic.co/guide/en/elasticsearch/reference/current/point-in-time-api.html
-//easyjson:json
 type PointInTime struct {
-	ID        string     `bson:"id" json:"id"`
-	KeepAlive *time.Time `bson:"keep_alive,omitempty" json:"keep_alive,omitempty"`
+	ID string `bson:"id" json:"id"`
+	// KeepAlive extends the time to live of the point in time with each
+	// request that uses it. It is formatted as a time value, e.g. "1m".
+	KeepAlive time.Duration `bson:"keep_alive,omitempty" json:"keep_alive,omitempty"`
+}
+
+func (pit PointInTime) MarshalBSON() ([]byte, error) {
+	return pit.MarshalJSON()
+}
+
+func (pit PointInTime) MarshalJSON() ([]byte, error) {
+	v := pointInTime{ID: pit.ID}
+	if pit.KeepAlive > 0 {
+		v.KeepAlive = formatTimeValue(pit.KeepAlive)
+	}
+	return v.MarshalJSON()
+}
+
+func (pit *PointInTime) UnmarshalBSON(data []byte) error {
+	return pit.UnmarshalJSON(data)
+}
+
+func (pit *PointInTime) UnmarshalJSON(data []byte) error {
+	*pit = PointInTime{}
+	var v pointInTime
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	pit.ID = v.ID
+	if len(v.KeepAlive) > 0 {
+		pit.KeepAlive, err = parseTimeValue(v.KeepAlive)
+		if err != nil {
+			return newFieldError(err, "keep_alive")
+		}
+	}
+	return nil
 }
 
 func (pit *PointInTime) Encode() (*bytes.Buffer, error) {
@@ -50,9 +84,12 @@ func (pit *PointInTime) Clone() *PointInTime {
 	if pit.ID != "" {
 		n.ID = pit.ID
 	}
-	if pit.KeepAlive != nil {
-		t := *pit.KeepAlive
-		n.KeepAlive = &t
-	}
+	n.KeepAlive = pit.KeepAlive
 	return &n
 }
+
+//easyjson:json
+type pointInTime struct {
+	ID        string `json:"id"`
+	KeepAlive string `json:"keep_alive,omitempty"`
+}
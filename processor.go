@@ -1,19 +1,239 @@
 package picker
 
-// Processor is a standin until I can get around to writing out all the various
-// processors
-type Processor interface {
-	Kind() ProcessorKind
-}
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
 
-// not sure how to deal with this silly naming convetion
+	"github.com/chanced/dynamic"
+)
 
+// Processorer is implemented by processor params as well as processors
+// themselves. Calling Processor returns the validated Processor.
 type Processorer interface {
+	Kind() ProcessorKind
 	Processor() (Processor, error)
 }
 
+// Processor is a validated ingest processor.
+//
+// Each processor of an ingest pipeline runs sequentially, making specific
+// changes to incoming documents.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/processors.html
+type Processor interface {
+	Processorer
+	// Description of the processor. Useful for describing the purpose of the
+	// processor or its configuration.
+	Description() string
+	SetDescription(v string)
+	// If is the condition, a painless script, under which the processor is
+	// run
+	If() string
+	SetIf(v string)
+	// IgnoreFailure indicates whether failures of the processor are ignored
+	IgnoreFailure() bool
+	SetIgnoreFailure(v bool)
+	// OnFailure are the processors run when the processor fails
+	OnFailure() Processors
+	SetOnFailure(v Processorset) error
+	// Tag is an identifier for the processor. Useful for debugging and
+	// metrics.
+	Tag() string
+	SetTag(v string)
+	json.Marshaler
+	json.Unmarshaler
+}
+
+// Processorset is implemented by Procs and Processors
+type Processorset interface {
+	Processors() (Processors, error)
+}
+
+// Procs is a list of Processorers. It is used in params to define processors.
+//
+//	picker.Procs{
+//	    picker.SetProcessorParams{Field: "status", Value: "active"},
+//	    picker.RenameProcessorParams{Field: "provider", TargetField: "cloud.provider"},
+//	}
+type Procs []Processorer
+
+// Processors returns the validated Processors of p
+func (p Procs) Processors() (Processors, error) {
+	res := make(Processors, 0, len(p))
+	for _, v := range p {
+		if v == nil {
+			continue
+		}
+		proc, err := v.Processor()
+		if err != nil {
+			return res, newProcessorError(err, v.Kind())
+		}
+		res = append(res, proc)
+	}
+	return res, nil
+}
+
+// Processors is a list of validated Processor
 type Processors []Processor
 
-func (ps Processors) UnmarshalJSON([]byte) error {
-	panic("not impl")
+// Processors returns p, satisfying Processorset
+func (p Processors) Processors() (Processors, error) {
+	return p, nil
+}
+
+func (p Processors) MarshalBSON() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+// MarshalJSON encodes each processor as an object keyed by its kind
+func (p Processors) MarshalJSON() ([]byte, error) {
+	data := make([]dynamic.JSON, 0, len(p))
+	for _, proc := range p {
+		if proc == nil {
+			continue
+		}
+		d, err := marshalProcessor(proc)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, d)
+	}
+	return json.Marshal(data)
+}
+
+func (p *Processors) UnmarshalBSON(data []byte) error {
+	return p.UnmarshalJSON(data)
+}
+
+func (p *Processors) UnmarshalJSON(data []byte) error {
+	*p = Processors{}
+	if len(data) == 0 || dynamic.JSON(data).IsNull() {
+		return nil
+	}
+	var raw []dynamic.JSON
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+	for _, d := range raw {
+		proc, err := unmarshalProcessor(d)
+		if err != nil {
+			return err
+		}
+		*p = append(*p, proc)
+	}
+	return nil
+}
+
+func marshalProcessor(p Processor) (dynamic.JSON, error) {
+	body, err := p.MarshalJSON()
+	if err != nil {
+		return nil, newProcessorError(err, p.Kind())
+	}
+	return json.Marshal(dynamic.JSONObject{p.Kind().String(): body})
+}
+
+func unmarshalProcessor(data dynamic.JSON) (Processor, error) {
+	var obj dynamic.JSONObject
+	err := json.Unmarshal(data, &obj)
+	if err != nil {
+		return nil, err
+	}
+	if len(obj) == 0 {
+		return nil, ErrProcessorKindRequired
+	}
+	if len(obj) > 1 {
+		keys := make([]string, 0, len(obj))
+		for key := range obj {
+			keys = append(keys, key)
+		}
+		return nil, fmt.Errorf("%w; found <%s>", ErrMultipleProcessorKinds, strings.Join(keys, ">, <"))
+	}
+	var proc Processor
+	for key, d := range obj {
+		handler, ok := processorHandlers[ProcessorKind(key)]
+		if !ok {
+			return nil, fmt.Errorf("%w <%s>", ErrUnsupportedType, key)
+		}
+		proc = handler()
+		err = proc.UnmarshalJSON(d)
+		if err != nil {
+			return proc, newProcessorError(err, proc.Kind())
+		}
+	}
+	return proc, nil
+}
+
+// processorParams is a mixin that adds the options shared by all processors
+type processorParams struct {
+	description   string
+	ifCond        string
+	ignoreFailure bool
+	onFailure     Processors
+	tag           string
+}
+
+// Description of the processor. Useful for describing the purpose of the
+// processor or its configuration.
+func (p processorParams) Description() string {
+	return p.description
+}
+
+// SetDescription sets the description of the processor to v
+func (p *processorParams) SetDescription(v string) {
+	p.description = v
+}
+
+// If is the condition, a painless script, under which the processor is run
+func (p processorParams) If() string {
+	return p.ifCond
+}
+
+// SetIf sets the condition of the processor to v
+func (p *processorParams) SetIf(v string) {
+	p.ifCond = v
+}
+
+// IgnoreFailure indicates whether failures of the processor are ignored
+func (p processorParams) IgnoreFailure() bool {
+	return p.ignoreFailure
+}
+
+// SetIgnoreFailure sets ignore_failure to v
+func (p *processorParams) SetIgnoreFailure(v bool) {
+	p.ignoreFailure = v
+}
+
+// OnFailure are the processors run when the processor fails
+func (p processorParams) OnFailure() Processors {
+	return p.onFailure
+}
+
+// SetOnFailure sets on_failure to v
+func (p *processorParams) SetOnFailure(v Processorset) error {
+	if v == nil {
+		p.onFailure = nil
+		return nil
+	}
+	procs, err := v.Processors()
+	if err != nil {
+		return err
+	}
+	if len(procs) == 0 {
+		p.onFailure = nil
+		return nil
+	}
+	p.onFailure = procs
+	return nil
+}
+
+// Tag is an identifier for the processor. Useful for debugging and metrics.
+func (p processorParams) Tag() string {
+	return p.tag
+}
+
+// SetTag sets the tag of the processor to v
+func (p *processorParams) SetTag(v string) {
+	p.tag = v
 }
@@ -1,11 +1,21 @@
 package picker
 
+// ProcessorKind is the type of an ingest processor
 type ProcessorKind string
 
+func (k ProcessorKind) String() string {
+	return string(k)
+}
+
+func (k ProcessorKind) IsValid() bool {
+	_, ok := processorHandlers[k]
+	return ok
+}
+
 const (
 	ProcessorKindAppend           ProcessorKind = "append"
 	ProcessorKindBytes            ProcessorKind = "bytes"
-	ProcessorKindCircle           ProcessorKind = "cirlce"
+	ProcessorKindCircle           ProcessorKind = "circle"
 	ProcessorKindCommunityID      ProcessorKind = "community_id"
 	ProcessorKindConvert          ProcessorKind = "convert"
 	ProcessorKindCSV              ProcessorKind = "csv"
@@ -43,4 +53,43 @@ const (
 	ProcessorKindUserAgent        ProcessorKind = "user_agent"
 )
 
-var processorHandlers = map[ProcessorKind]func() Processor{}
+var processorHandlers = map[ProcessorKind]func() Processor{
+	ProcessorKindAppend:           func() Processor { return &AppendProcessor{} },
+	ProcessorKindBytes:            func() Processor { return &BytesProcessor{} },
+	ProcessorKindCircle:           func() Processor { return &CircleProcessor{} },
+	ProcessorKindCommunityID:      func() Processor { return &CommunityIDProcessor{} },
+	ProcessorKindConvert:          func() Processor { return &ConvertProcessor{} },
+	ProcessorKindCSV:              func() Processor { return &CSVProcessor{} },
+	ProcessorKindDate:             func() Processor { return &DateProcessor{} },
+	ProcessorKindDateIndexName:    func() Processor { return &DateIndexNameProcessor{} },
+	ProcessorKindDissect:          func() Processor { return &DissectProcessor{} },
+	ProcessorKindDotExpander:      func() Processor { return &DotExpanderProcessor{} },
+	ProcessorKindDrop:             func() Processor { return &DropProcessor{} },
+	ProcessorKindEnrich:           func() Processor { return &EnrichProcessor{} },
+	ProcessorKindFail:             func() Processor { return &FailProcessor{} },
+	ProcessorKindFingerprint:      func() Processor { return &FingerprintProcessor{} },
+	ProcessorKindForeach:          func() Processor { return &ForeachProcessor{} },
+	ProcessorKindGeoIP:            func() Processor { return &GeoIPProcessor{} },
+	ProcessorKindGrok:             func() Processor { return &GrokProcessor{} },
+	ProcessorKindGsub:             func() Processor { return &GsubProcessor{} },
+	ProcessorKindHTMLStrip:        func() Processor { return &HTMLStripProcessor{} },
+	ProcessorKindInference:        func() Processor { return &InferenceProcessor{} },
+	ProcessorKindJoin:             func() Processor { return &JoinProcessor{} },
+	ProcessorKindJSON:             func() Processor { return &JSONProcessor{} },
+	ProcessorKindKV:               func() Processor { return &KVProcessor{} },
+	ProcessorKindLowercase:        func() Processor { return &LowercaseProcessor{} },
+	ProcessorKindNetworkDirection: func() Processor { return &NetworkDirectionProcessor{} },
+	ProcessorKindPipeline:         func() Processor { return &PipelineProcessor{} },
+	ProcessorKindRemove:           func() Processor { return &RemoveProcessor{} },
+	ProcessorKindRename:           func() Processor { return &RenameProcessor{} },
+	ProcessorKindScript:           func() Processor { return &ScriptProcessor{} },
+	ProcessorKindSet:              func() Processor { return &SetProcessor{} },
+	ProcessorKindSetSecurityUser:  func() Processor { return &SetSecurityUserProcessor{} },
+	ProcessorKindSort:             func() Processor { return &SortProcessor{} },
+	ProcessorKindSplit:            func() Processor { return &SplitProcessor{} },
+	ProcessorKindTrim:             func() Processor { return &TrimProcessor{} },
+	ProcessorKindUppercase:        func() Processor { return &UppercaseProcessor{} },
+	ProcessorKindURLDecode:        func() Processor { return &URLDecodeProcessor{} },
+	ProcessorKindURIParts:         func() Processor { return &URIPartsProcessor{} },
+	ProcessorKindUserAgent:        func() Processor { return &UserAgentProcessor{} },
+}
@@ -0,0 +1,134 @@
+package picker_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/chanced/cmpjson"
+	"github.com/chanced/picker"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessors(t *testing.T) {
+	assert := require.New(t)
+	data := []byte(`[
+		{ "set": { "field": "_source.status", "value": "active", "override": false, "tag": "status" } },
+		{ "rename": { "field": "provider", "target_field": "cloud.provider", "ignore_missing": true } },
+		{ "remove": { "field": ["user_agent", "tmp"] } },
+		{ "remove": { "field": "debug", "if": "ctx.debug == null" } },
+		{
+			"grok": {
+				"field": "message",
+				"patterns": ["%{IP:client} %{WORD:method} %{URIPATHPARAM:request}"],
+				"pattern_definitions": { "FAVORITE_DOG": "beagle" }
+			}
+		},
+		{ "date": { "field": "initial_date", "target_field": "timestamp", "formats": ["dd/MM/yyyy HH:mm:ss"], "timezone": "Europe/Amsterdam" } },
+		{ "convert": { "field": "id", "type": "integer" } },
+		{
+			"foreach": {
+				"field": "values",
+				"processor": { "uppercase": { "field": "_ingest._value" } }
+			}
+		},
+		{ "circle": { "field": "circle", "error_distance": 28.0, "shape_type": "geo_shape" } },
+		{ "script": { "lang": "painless", "source": "ctx['_index'] = ctx['lang'] + '-' + params['dataset'];", "params": { "dataset": "catalog" } } },
+		{ "pipeline": { "name": "inner-pipeline" } },
+		{
+			"json": {
+				"field": "string_source",
+				"target_field": "json_target",
+				"on_failure": [
+					{ "set": { "field": "error.message", "value": "{{ _ingest.on_failure_message }}" } }
+				]
+			}
+		}
+	]`)
+	var procs picker.Processors
+	err := json.Unmarshal(data, &procs)
+	assert.NoError(err)
+	assert.Len(procs, 12)
+
+	set, ok := procs[0].(*picker.SetProcessor)
+	assert.True(ok)
+	assert.Equal("_source.status", set.Field())
+	assert.Equal("active", set.Value())
+	assert.False(set.Override())
+	assert.Equal("status", set.Tag())
+
+	rename := procs[1].(*picker.RenameProcessor)
+	assert.Equal("cloud.provider", rename.TargetField())
+	assert.True(rename.IgnoreMissing())
+
+	assert.Equal([]string{"user_agent", "tmp"}, procs[2].(*picker.RemoveProcessor).Field())
+	remove := procs[3].(*picker.RemoveProcessor)
+	assert.Equal([]string{"debug"}, remove.Field())
+	assert.Equal("ctx.debug == null", remove.If())
+
+	grok := procs[4].(*picker.GrokProcessor)
+	assert.Equal(map[string]string{"FAVORITE_DOG": "beagle"}, grok.PatternDefinitions())
+
+	foreach := procs[7].(*picker.ForeachProcessor)
+	assert.Equal(picker.ProcessorKindUppercase, foreach.ItemProcessor().Kind())
+	assert.Equal("_ingest._value", foreach.ItemProcessor().(*picker.UppercaseProcessor).Field())
+
+	circle := procs[8].(*picker.CircleProcessor)
+	assert.Equal(picker.ProcessorKind("circle"), circle.Kind())
+	assert.Equal(28.0, circle.ErrorDistance())
+
+	j := procs[11].(*picker.JSONProcessor)
+	assert.Len(j.OnFailure(), 1)
+	assert.Equal(picker.ProcessorKindSet, j.OnFailure()[0].Kind())
+
+	res, err := json.Marshal(procs)
+	assert.NoError(err)
+	assert.True(cmpjson.Equal(data, res), cmpjson.Diff(data, res))
+}
+
+func TestProcessorParams(t *testing.T) {
+	assert := require.New(t)
+	procs, err := picker.Procs{
+		picker.SetProcessorParams{Field: "count", Value: 1, Override: false},
+		picker.ForeachProcessorParams{
+			Field:         "tags",
+			ItemProcessor: picker.TrimProcessorParams{Field: "_ingest._value"},
+		},
+		picker.RemoveProcessorParams{Field: []string{"tmp"}, IgnoreMissing: true},
+		picker.DropProcessorParams{
+			If:        "ctx.network?.name == 'Guest'",
+			OnFailure: picker.Procs{picker.FailProcessorParams{Message: "could not drop"}},
+		},
+	}.Processors()
+	assert.NoError(err)
+	expected := []byte(`[
+		{ "set": { "field": "count", "value": 1, "override": false } },
+		{ "foreach": { "field": "tags", "processor": { "trim": { "field": "_ingest._value" } } } },
+		{ "remove": { "field": "tmp", "ignore_missing": true } },
+		{ "drop": { "if": "ctx.network?.name == 'Guest'", "on_failure": [{ "fail": { "message": "could not drop" } }] } }
+	]`)
+	data, err := json.Marshal(procs)
+	assert.NoError(err)
+	assert.True(cmpjson.Equal(expected, data), cmpjson.Diff(expected, data))
+
+	_, err = picker.SetProcessorParams{Field: "count"}.Set()
+	assert.True(errors.Is(err, picker.ErrValueOrCopyFromRequired))
+	_, err = picker.RenameProcessorParams{Field: "provider"}.Rename()
+	assert.True(errors.Is(err, picker.ErrTargetFieldRequired))
+	_, err = picker.ForeachProcessorParams{Field: "values"}.Foreach()
+	assert.True(errors.Is(err, picker.ErrProcessorRequired))
+
+	_, err = picker.Procs{
+		picker.ForeachProcessorParams{Field: "values", ItemProcessor: picker.GrokProcessorParams{Field: "_ingest._value"}},
+	}.Processors()
+	assert.True(errors.Is(err, picker.ErrPatternsRequired))
+	var pe *picker.ProcessorError
+	assert.True(errors.As(err, &pe))
+	assert.Equal(picker.ProcessorKindGrok, pe.Kind)
+
+	var decoded picker.Processors
+	err = json.Unmarshal([]byte(`[{ "set": { "field": "a", "value": 1 }, "rename": { "field": "b" } }]`), &decoded)
+	assert.True(errors.Is(err, picker.ErrMultipleProcessorKinds))
+	err = json.Unmarshal([]byte(`[{ "not_a_processor": {} }]`), &decoded)
+	assert.True(errors.Is(err, picker.ErrUnsupportedType))
+}
@@ -0,0 +1,164 @@
+package picker
+
+// AggRange is a range used by range, date_range, and geo_distance
+// aggregations. From is inclusive while To is exclusive. Either From, To, or
+// both should be set.
+//easyjson:json
+type AggRange struct {
+	// Key is an optional key for the bucket. If not set, a key is generated
+	// from From and To.
+	Key  string      `json:"key,omitempty"`
+	From interface{} `json:"from,omitempty"`
+	To   interface{} `json:"to,omitempty"`
+}
+
+func checkAggRanges(ranges []AggRange) error {
+	if len(ranges) == 0 {
+		return ErrRangesRequired
+	}
+	return nil
+}
+
+// RangeAggParams creates a RangeAgg, a multi-bucket value source based
+// aggregation that enables the user to define a set of ranges - each
+// representing a bucket.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-range-aggregation.html
+type RangeAggParams struct {
+	// The field to aggregate on. Either Field or Script is required.
+	Field   string
+	Script  *Script
+	Missing interface{}
+	Format  string
+	// The ranges of the buckets (Required)
+	Ranges []AggRange
+	// If true, buckets are returned as a hash rather than an array, keyed by
+	// the bucket key.
+	Keyed bool
+	// Sub-aggregations
+	Aggregations Aggs
+	Meta         map[string]interface{}
+}
+
+func (RangeAggParams) Kind() AggKind {
+	return AggKindRange
+}
+
+func (p RangeAggParams) Clause() (AggClause, error) {
+	return p.Range()
+}
+
+func (p RangeAggParams) Range() (*RangeAgg, error) {
+	a := &RangeAgg{}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	err := a.checkValueSource()
+	if err != nil {
+		return a, newAggError(err, AggKindRange)
+	}
+	a.SetMissing(p.Missing)
+	a.SetFormat(p.Format)
+	err = a.SetRanges(p.Ranges)
+	if err != nil {
+		return a, newAggError(err, AggKindRange)
+	}
+	a.SetKeyed(p.Keyed)
+	err = a.SetAggregations(p.Aggregations)
+	if err != nil {
+		return a, newAggError(err, AggKindRange)
+	}
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// RangeAgg is a multi-bucket value source based aggregation that enables the
+// user to define a set of ranges - each representing a bucket.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-range-aggregation.html
+type RangeAgg struct {
+	valueSourceParam
+	ranges []AggRange
+	keyed  bool
+	aggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*RangeAgg)(nil)
+
+func (RangeAgg) Kind() AggKind {
+	return AggKindRange
+}
+
+func (a *RangeAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// Ranges of the buckets
+func (a RangeAgg) Ranges() []AggRange {
+	return a.ranges
+}
+
+// SetRanges sets the ranges to v. At least one range is required.
+func (a *RangeAgg) SetRanges(v []AggRange) error {
+	err := checkAggRanges(v)
+	if err != nil {
+		return err
+	}
+	a.ranges = v
+	return nil
+}
+
+// Keyed indicates whether buckets are returned as a hash rather than an array
+func (a RangeAgg) Keyed() bool {
+	return a.keyed
+}
+
+// SetKeyed sets keyed to v
+func (a *RangeAgg) SetKeyed(v bool) {
+	a.keyed = v
+}
+
+func (a RangeAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a RangeAgg) MarshalJSON() ([]byte, error) {
+	return rangeAgg{
+		Field:   a.field,
+		Script:  a.script,
+		Missing: a.missing,
+		Format:  a.format,
+		Ranges:  a.ranges,
+		Keyed:   a.keyed,
+	}.MarshalJSON()
+}
+
+func (a *RangeAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *RangeAgg) UnmarshalJSON(data []byte) error {
+	*a = RangeAgg{}
+	p := rangeAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	a.SetMissing(p.Missing)
+	a.SetFormat(p.Format)
+	a.ranges = p.Ranges
+	a.SetKeyed(p.Keyed)
+	return nil
+}
+
+//easyjson:json
+type rangeAgg struct {
+	Field   string      `json:"field,omitempty"`
+	Script  *Script     `json:"script,omitempty"`
+	Missing interface{} `json:"missing,omitempty"`
+	Format  string      `json:"format,omitempty"`
+	Ranges  []AggRange  `json:"ranges"`
+	Keyed   bool        `json:"keyed,omitempty"`
+}
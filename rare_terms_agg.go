@@ -0,0 +1,205 @@
+package picker
+
+import "fmt"
+
+const DefaultRareTermsMaxDocCount = 1
+
+// RareTermsAggParams creates a RareTermsAgg, a multi-bucket value source based
+// aggregation which finds "rare" terms — terms that are at the long-tail of
+// the distribution and are not frequent.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-rare-terms-aggregation.html
+type RareTermsAggParams struct {
+	// The field to find rare terms in (Required)
+	Field string
+	// Missing is the value used for documents which do not have a value
+	Missing interface{}
+	// The maximum number of documents a term should appear in. Must be between
+	// 1 and 100. Defaults to 1.
+	MaxDocCount int
+	// The precision of the internal CuckooFilters. Smaller precision leads to
+	// better approximation, but higher memory usage. Defaults to 0.001.
+	Precision float64
+	// Filter which terms buckets are created for.
+	Include *TermsInclude
+	// Filter which terms buckets are not created for.
+	Exclude *TermsInclude
+	// Sub-aggregations
+	Aggregations Aggs
+	Meta         map[string]interface{}
+}
+
+func (RareTermsAggParams) Kind() AggKind {
+	return AggKindRareTerms
+}
+
+func (p RareTermsAggParams) Clause() (AggClause, error) {
+	return p.RareTerms()
+}
+
+func (p RareTermsAggParams) RareTerms() (*RareTermsAgg, error) {
+	a := &RareTermsAgg{}
+	err := a.SetField(p.Field)
+	if err != nil {
+		return a, newAggError(err, AggKindRareTerms)
+	}
+	a.SetMissing(p.Missing)
+	err = a.SetMaxDocCount(p.MaxDocCount)
+	if err != nil {
+		return a, newAggError(err, AggKindRareTerms)
+	}
+	a.SetPrecision(p.Precision)
+	a.SetInclude(p.Include)
+	err = a.SetExclude(p.Exclude)
+	if err != nil {
+		return a, newAggError(err, AggKindRareTerms)
+	}
+	err = a.SetAggregations(p.Aggregations)
+	if err != nil {
+		return a, newAggError(err, AggKindRareTerms)
+	}
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// RareTermsAgg is a multi-bucket value source based aggregation which finds
+// "rare" terms — terms that are at the long-tail of the distribution and are
+// not frequent.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-rare-terms-aggregation.html
+type RareTermsAgg struct {
+	fieldParam
+	missing     interface{}
+	maxDocCount int
+	precision   float64
+	include     *TermsInclude
+	exclude     *TermsInclude
+	aggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*RareTermsAgg)(nil)
+
+func (RareTermsAgg) Kind() AggKind {
+	return AggKindRareTerms
+}
+
+func (a *RareTermsAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// Missing is the value used for documents which do not have a value
+func (a RareTermsAgg) Missing() interface{} {
+	return a.missing
+}
+
+// SetMissing sets missing to v
+func (a *RareTermsAgg) SetMissing(v interface{}) {
+	a.missing = v
+}
+
+// MaxDocCount is the maximum number of documents a term should appear in.
+// Defaults to 1.
+func (a RareTermsAgg) MaxDocCount() int {
+	if a.maxDocCount == 0 {
+		return DefaultRareTermsMaxDocCount
+	}
+	return a.maxDocCount
+}
+
+// SetMaxDocCount sets max_doc_count to v. v must be between 1 and 100, or 0
+// to use the default.
+func (a *RareTermsAgg) SetMaxDocCount(v int) error {
+	if v < 0 || v > 100 {
+		return fmt.Errorf("%w; received %d", ErrInvalidMaxDocCount, v)
+	}
+	a.maxDocCount = v
+	return nil
+}
+
+// Precision of the internal CuckooFilters. Defaults to 0.001.
+func (a RareTermsAgg) Precision() float64 {
+	return a.precision
+}
+
+// SetPrecision sets precision to v
+func (a *RareTermsAgg) SetPrecision(v float64) {
+	a.precision = v
+}
+
+// Include filters which terms buckets are created for.
+func (a RareTermsAgg) Include() *TermsInclude {
+	return a.include
+}
+
+// SetInclude sets include to v
+func (a *RareTermsAgg) SetInclude(v *TermsInclude) {
+	if v.IsEmpty() {
+		a.include = nil
+		return
+	}
+	a.include = v
+}
+
+// Exclude filters which terms buckets are not created for.
+func (a RareTermsAgg) Exclude() *TermsInclude {
+	return a.exclude
+}
+
+// SetExclude sets exclude to v. Partitioning is not supported by exclude.
+func (a *RareTermsAgg) SetExclude(v *TermsInclude) error {
+	if v.IsEmpty() {
+		a.exclude = nil
+		return nil
+	}
+	if v.isPartitioned() {
+		return ErrInvalidExclude
+	}
+	a.exclude = v
+	return nil
+}
+
+func (a RareTermsAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a RareTermsAgg) MarshalJSON() ([]byte, error) {
+	return rareTermsAgg{
+		Field:       a.field,
+		Missing:     a.missing,
+		MaxDocCount: a.maxDocCount,
+		Precision:   a.precision,
+		Include:     a.include,
+		Exclude:     a.exclude,
+	}.MarshalJSON()
+}
+
+func (a *RareTermsAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *RareTermsAgg) UnmarshalJSON(data []byte) error {
+	*a = RareTermsAgg{}
+	p := rareTermsAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.field = p.Field
+	a.SetMissing(p.Missing)
+	a.maxDocCount = p.MaxDocCount
+	a.SetPrecision(p.Precision)
+	a.SetInclude(p.Include)
+	a.exclude = p.Exclude
+	return nil
+}
+
+//easyjson:json
+type rareTermsAgg struct {
+	Field       string        `json:"field"`
+	Missing     interface{}   `json:"missing,omitempty"`
+	MaxDocCount int           `json:"max_doc_count,omitempty"`
+	Precision   float64       `json:"precision,omitempty"`
+	Include     *TermsInclude `json:"include,omitempty"`
+	Exclude     *TermsInclude `json:"exclude,omitempty"`
+}
@@ -0,0 +1,180 @@
+package picker
+
+import "fmt"
+
+// RateMode determines how a rate aggregation calculates its value
+type RateMode string
+
+const (
+	RateModeUnspecified RateMode = ""
+	// RateModeSum calculates the sum of all values of the field
+	RateModeSum RateMode = "sum"
+	// RateModeValueCount uses the number of values in the field
+	RateModeValueCount RateMode = "value_count"
+)
+
+func (m RateMode) String() string {
+	return string(m)
+}
+
+func (m RateMode) IsValid() bool {
+	switch m {
+	case RateModeUnspecified, RateModeSum, RateModeValueCount:
+		return true
+	}
+	return false
+}
+
+// RateAggParams creates a RateAgg, a metrics aggregation which can only be
+// used inside of a date_histogram and calculates a rate of documents or a
+// field in each date_histogram bucket.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-rate-aggregation.html
+type RateAggParams struct {
+	// The field to calculate the rate of. If Field and Script are both
+	// omitted, the rate of documents is calculated.
+	Field  string
+	Script *Script
+	// The calendar unit the rate is calculated in, such as "month" or "year".
+	// Defaults to the calendar_interval of the parent date_histogram.
+	Unit string
+	// Either "sum" (default) or "value_count"
+	Mode RateMode
+	Meta map[string]interface{}
+}
+
+func (RateAggParams) Kind() AggKind {
+	return AggKindRate
+}
+
+func (p RateAggParams) Clause() (AggClause, error) {
+	return p.Rate()
+}
+
+func (p RateAggParams) Rate() (*RateAgg, error) {
+	a := &RateAgg{}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	a.SetUnit(p.Unit)
+	err := a.SetMode(p.Mode)
+	if err != nil {
+		return a, newAggError(err, AggKindRate)
+	}
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// RateAgg is a metrics aggregation which can only be used inside of a
+// date_histogram and calculates a rate of documents or a field in each
+// date_histogram bucket.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-rate-aggregation.html
+type RateAgg struct {
+	field  string
+	script *Script
+	unit   string
+	mode   RateMode
+	noAggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*RateAgg)(nil)
+
+func (RateAgg) Kind() AggKind {
+	return AggKindRate
+}
+
+func (a *RateAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// Field is the field the rate is calculated for. If empty, the rate of
+// documents is calculated.
+func (a RateAgg) Field() string {
+	return a.field
+}
+
+// SetField sets field to v
+func (a *RateAgg) SetField(v string) {
+	a.field = v
+}
+
+// Script provides the values the rate is calculated for
+func (a RateAgg) Script() *Script {
+	return a.script
+}
+
+// SetScript sets script to v
+func (a *RateAgg) SetScript(v *Script) {
+	if v.IsEmpty() {
+		a.script = nil
+		return
+	}
+	a.script = v
+}
+
+// Unit is the calendar unit the rate is calculated in
+func (a RateAgg) Unit() string {
+	return a.unit
+}
+
+// SetUnit sets unit to v
+func (a *RateAgg) SetUnit(v string) {
+	a.unit = v
+}
+
+// Mode is either "sum" (default) or "value_count"
+func (a RateAgg) Mode() RateMode {
+	if len(a.mode) == 0 {
+		return RateModeSum
+	}
+	return a.mode
+}
+
+// SetMode sets mode to v
+func (a *RateAgg) SetMode(v RateMode) error {
+	if !v.IsValid() {
+		return fmt.Errorf("%w <%s>", ErrInvalidRateMode, v)
+	}
+	a.mode = v
+	return nil
+}
+
+func (a RateAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a RateAgg) MarshalJSON() ([]byte, error) {
+	return rateAgg{
+		Field:  a.field,
+		Script: a.script,
+		Unit:   a.unit,
+		Mode:   a.mode,
+	}.MarshalJSON()
+}
+
+func (a *RateAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *RateAgg) UnmarshalJSON(data []byte) error {
+	*a = RateAgg{}
+	p := rateAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	a.SetUnit(p.Unit)
+	a.mode = p.Mode
+	return nil
+}
+
+//easyjson:json
+type rateAgg struct {
+	Field  string   `json:"field,omitempty"`
+	Script *Script  `json:"script,omitempty"`
+	Unit   string   `json:"unit,omitempty"`
+	Mode   RateMode `json:"mode,omitempty"`
+}
@@ -0,0 +1,149 @@
+package picker
+
+import (
+	"encoding/json"
+
+	"github.com/chanced/dynamic"
+)
+
+// RemoveProcessorParams creates a RemoveProcessor, which removes existing
+// fields. If one field doesn't exist, an exception will be thrown.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/remove-processor.html
+type RemoveProcessorParams struct {
+	// Field are the fields to be removed. Supports template snippets.
+	Field []string
+	// If IgnoreMissing is true and field does not exist or is null, the
+	// processor quietly exits without modifying the document
+	IgnoreMissing bool
+	// Description of the processor. Useful for describing the purpose of the
+	// processor or its configuration.
+	Description string
+	// Conditionally execute the processor
+	If string
+	// Ignore failures for the processor
+	IgnoreFailure bool
+	// Handle failures for the processor
+	OnFailure Procs
+	// Identifier for the processor. Useful for debugging and metrics.
+	Tag string
+}
+
+func (RemoveProcessorParams) Kind() ProcessorKind {
+	return ProcessorKindRemove
+}
+
+func (p RemoveProcessorParams) Processor() (Processor, error) {
+	return p.Remove()
+}
+
+func (p RemoveProcessorParams) Remove() (*RemoveProcessor, error) {
+	proc := &RemoveProcessor{}
+	err := proc.SetField(p.Field)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindRemove)
+	}
+	proc.SetIgnoreMissing(p.IgnoreMissing)
+	proc.SetDescription(p.Description)
+	proc.SetIf(p.If)
+	proc.SetIgnoreFailure(p.IgnoreFailure)
+	err = proc.SetOnFailure(p.OnFailure)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindRemove)
+	}
+	proc.SetTag(p.Tag)
+	return proc, nil
+}
+
+// RemoveProcessor removes existing fields. If one field doesn't exist, an
+// exception will be thrown.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/remove-processor.html
+type RemoveProcessor struct {
+	field []string
+	ignoreMissingParam
+	processorParams
+}
+
+var _ Processor = (*RemoveProcessor)(nil)
+
+func (RemoveProcessor) Kind() ProcessorKind {
+	return ProcessorKindRemove
+}
+
+func (p *RemoveProcessor) Processor() (Processor, error) {
+	return p, nil
+}
+
+// Field are the fields to be removed. Supports template snippets.
+func (p RemoveProcessor) Field() []string {
+	return p.field
+}
+
+// SetField sets field to v
+func (p *RemoveProcessor) SetField(v []string) error {
+	if len(v) == 0 {
+		return ErrFieldRequired
+	}
+	p.field = v
+	return nil
+}
+
+func (p RemoveProcessor) MarshalBSON() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+func (p RemoveProcessor) MarshalJSON() ([]byte, error) {
+	field, err := marshalStringOrArrayOfStrings(p.field)
+	if err != nil {
+		return nil, err
+	}
+	return removeProcessor{
+		Field:         field,
+		IgnoreMissing: p.ignoreMissing,
+		Description:   p.description,
+		If:            p.ifCond,
+		IgnoreFailure: p.ignoreFailure,
+		OnFailure:     p.onFailure,
+		Tag:           p.tag,
+	}.MarshalJSON()
+}
+
+func (p *RemoveProcessor) UnmarshalBSON(data []byte) error {
+	return p.UnmarshalJSON(data)
+}
+
+func (p *RemoveProcessor) UnmarshalJSON(data []byte) error {
+	*p = RemoveProcessor{}
+	var v removeProcessor
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	if len(v.Field) > 0 {
+		var field dynamic.StringOrArrayOfStrings
+		err = json.Unmarshal(v.Field, &field)
+		if err != nil {
+			return err
+		}
+		p.field = field
+	}
+	p.ignoreMissing = v.IgnoreMissing
+	p.description = v.Description
+	p.ifCond = v.If
+	p.ignoreFailure = v.IgnoreFailure
+	p.onFailure = v.OnFailure
+	p.tag = v.Tag
+	return nil
+}
+
+//easyjson:json
+type removeProcessor struct {
+	Field         dynamic.JSON `json:"field,omitempty"`
+	IgnoreMissing bool         `json:"ignore_missing,omitempty"`
+	Description   string       `json:"description,omitempty"`
+	If            string       `json:"if,omitempty"`
+	IgnoreFailure bool         `json:"ignore_failure,omitempty"`
+	OnFailure     Processors   `json:"on_failure,omitempty"`
+	Tag           string       `json:"tag,omitempty"`
+}
@@ -0,0 +1,143 @@
+package picker
+
+// RenameProcessorParams creates a RenameProcessor, which renames an existing
+// field. If the field doesn't exist or the new name is already used, an
+// exception will be thrown.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/rename-processor.html
+type RenameProcessorParams struct {
+	// Field is the field to be renamed. Supports template snippets.
+	Field string
+	// TargetField is the new name of the field. Supports template snippets.
+	TargetField string
+	// If IgnoreMissing is true and field does not exist or is null, the
+	// processor quietly exits without modifying the document
+	IgnoreMissing bool
+	// Description of the processor. Useful for describing the purpose of the
+	// processor or its configuration.
+	Description string
+	// Conditionally execute the processor
+	If string
+	// Ignore failures for the processor
+	IgnoreFailure bool
+	// Handle failures for the processor
+	OnFailure Procs
+	// Identifier for the processor. Useful for debugging and metrics.
+	Tag string
+}
+
+func (RenameProcessorParams) Kind() ProcessorKind {
+	return ProcessorKindRename
+}
+
+func (p RenameProcessorParams) Processor() (Processor, error) {
+	return p.Rename()
+}
+
+func (p RenameProcessorParams) Rename() (*RenameProcessor, error) {
+	proc := &RenameProcessor{}
+	err := proc.SetField(p.Field)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindRename)
+	}
+	err = proc.SetTargetField(p.TargetField)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindRename)
+	}
+	proc.SetIgnoreMissing(p.IgnoreMissing)
+	proc.SetDescription(p.Description)
+	proc.SetIf(p.If)
+	proc.SetIgnoreFailure(p.IgnoreFailure)
+	err = proc.SetOnFailure(p.OnFailure)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindRename)
+	}
+	proc.SetTag(p.Tag)
+	return proc, nil
+}
+
+// RenameProcessor renames an existing field. If the field doesn't exist or the
+// new name is already used, an exception will be thrown.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/rename-processor.html
+type RenameProcessor struct {
+	fieldParam
+	targetField string
+	ignoreMissingParam
+	processorParams
+}
+
+var _ Processor = (*RenameProcessor)(nil)
+
+func (RenameProcessor) Kind() ProcessorKind {
+	return ProcessorKindRename
+}
+
+func (p *RenameProcessor) Processor() (Processor, error) {
+	return p, nil
+}
+
+// TargetField is the new name of the field. Supports template snippets.
+func (p RenameProcessor) TargetField() string {
+	return p.targetField
+}
+
+// SetTargetField sets target_field to v
+func (p *RenameProcessor) SetTargetField(v string) error {
+	if len(v) == 0 {
+		return ErrTargetFieldRequired
+	}
+	p.targetField = v
+	return nil
+}
+
+func (p RenameProcessor) MarshalBSON() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+func (p RenameProcessor) MarshalJSON() ([]byte, error) {
+	return renameProcessor{
+		Field:         p.field,
+		TargetField:   p.targetField,
+		IgnoreMissing: p.ignoreMissing,
+		Description:   p.description,
+		If:            p.ifCond,
+		IgnoreFailure: p.ignoreFailure,
+		OnFailure:     p.onFailure,
+		Tag:           p.tag,
+	}.MarshalJSON()
+}
+
+func (p *RenameProcessor) UnmarshalBSON(data []byte) error {
+	return p.UnmarshalJSON(data)
+}
+
+func (p *RenameProcessor) UnmarshalJSON(data []byte) error {
+	*p = RenameProcessor{}
+	var v renameProcessor
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	p.field = v.Field
+	p.targetField = v.TargetField
+	p.ignoreMissing = v.IgnoreMissing
+	p.description = v.Description
+	p.ifCond = v.If
+	p.ignoreFailure = v.IgnoreFailure
+	p.onFailure = v.OnFailure
+	p.tag = v.Tag
+	return nil
+}
+
+//easyjson:json
+type renameProcessor struct {
+	Field         string     `json:"field,omitempty"`
+	TargetField   string     `json:"target_field,omitempty"`
+	IgnoreMissing bool       `json:"ignore_missing,omitempty"`
+	Description   string     `json:"description,omitempty"`
+	If            string     `json:"if,omitempty"`
+	IgnoreFailure bool       `json:"ignore_failure,omitempty"`
+	OnFailure     Processors `json:"on_failure,omitempty"`
+	Tag           string     `json:"tag,omitempty"`
+}
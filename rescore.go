@@ -0,0 +1,344 @@
+package picker
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/chanced/dynamic"
+)
+
+// RescorerKindQuery is the kind of QueryRescorer
+const RescorerKindQuery = "query"
+
+// RescorerHandlers is a map of rescorer kinds to funcs which return a Rescorer
+// of that kind, used when unmarshaling a Rescore. Rescorers provided by
+// plugins can be registered here; rescorers which are not registered are
+// unmarshaled as a *RawRescorer.
+var RescorerHandlers = map[string]func() Rescorer{
+	RescorerKindQuery: func() Rescorer { return &QueryRescorer{} },
+}
+
+// Rescorer rescores the top hits of a search. QueryRescorer is the only
+// rescorer built into Elasticsearch; other rescorers, such as the
+// learning_to_rank rescorer of the LTR plugin, can either implement Rescorer
+// or use RawRescorer.
+//
+// A Rescorer can optionally implement Validate() error, which is called when
+// the Rescore is set on a Search.
+type Rescorer interface {
+	// Kind is the key of the rescorer within the rescore, e.g. "query"
+	Kind() string
+	json.Marshaler
+	json.Unmarshaler
+}
+
+// RescoreScoreMode controls the way the scores of the original query and the
+// rescore query are combined
+type RescoreScoreMode string
+
+const (
+	RescoreScoreModeUnspecified RescoreScoreMode = ""
+	// RescoreScoreModeTotal adds the original score and the rescore query
+	// score. This is the default.
+	RescoreScoreModeTotal RescoreScoreMode = "total"
+	// RescoreScoreModeMultiply multiplies the original score by the rescore
+	// query score.
+	RescoreScoreModeMultiply RescoreScoreMode = "multiply"
+	// RescoreScoreModeAvg averages the original score and the rescore query
+	// score.
+	RescoreScoreModeAvg RescoreScoreMode = "avg"
+	// RescoreScoreModeMax takes the max of the original score and the rescore
+	// query score.
+	RescoreScoreModeMax RescoreScoreMode = "max"
+	// RescoreScoreModeMin takes the min of the original score and the rescore
+	// query score.
+	RescoreScoreModeMin RescoreScoreMode = "min"
+)
+
+func (sm RescoreScoreMode) String() string {
+	return string(sm)
+}
+
+func (sm RescoreScoreMode) Validate() error {
+	switch sm {
+	case RescoreScoreModeUnspecified, RescoreScoreModeTotal, RescoreScoreModeMultiply,
+		RescoreScoreModeAvg, RescoreScoreModeMax, RescoreScoreModeMin:
+		return nil
+	}
+	return fmt.Errorf("%w <%s>", ErrInvalidRescoreScoreMode, sm)
+}
+
+// Rescores are executed in order, each rescoring the results of the
+// previous.
+type Rescores []Rescore
+
+// Validate checks each Rescore
+func (r Rescores) Validate() error {
+	for i, v := range r {
+		err := v.Validate()
+		if err != nil {
+			return newFieldError(err, fmt.Sprintf("[%d]", i))
+		}
+	}
+	return nil
+}
+
+func (r Rescores) MarshalBSON() ([]byte, error) {
+	return r.MarshalJSON()
+}
+
+// MarshalJSON encodes r as an object if it contains a single Rescore,
+// otherwise as an array
+func (r Rescores) MarshalJSON() ([]byte, error) {
+	if len(r) == 1 {
+		return r[0].MarshalJSON()
+	}
+	return json.Marshal([]Rescore(r))
+}
+
+func (r *Rescores) UnmarshalBSON(data []byte) error {
+	return r.UnmarshalJSON(data)
+}
+
+// UnmarshalJSON accepts either a single rescore object or an array of them
+func (r *Rescores) UnmarshalJSON(data []byte) error {
+	*r = nil
+	d := dynamic.JSON(data)
+	if len(d) == 0 || d.IsNull() {
+		return nil
+	}
+	if d.IsArray() {
+		var v []Rescore
+		err := json.Unmarshal(d, &v)
+		*r = v
+		return err
+	}
+	var v Rescore
+	err := v.UnmarshalJSON(d)
+	if err != nil {
+		return err
+	}
+	*r = Rescores{v}
+	return nil
+}
+
+// Rescore can help to improve precision by reordering just the top (eg 100 -
+// 500) documents returned by the query and post_filter phases, using a
+// secondary (usually more costly) algorithm, instead of applying the costly
+// algorithm to all documents in the index.
+//
+// Rescore can not be combined with a sort other than _score.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/filter-search-results.html#rescore
+type Rescore struct {
+	// The number of docs which will be examined on each shard. Defaults to
+	// 10.
+	WindowSize int
+	// The rescorer (Required)
+	Rescorer Rescorer
+}
+
+// Validate checks that the rescorer is set and, if it implements Validate,
+// that it is valid.
+func (r Rescore) Validate() error {
+	if r.WindowSize < 0 {
+		return fmt.Errorf("%w <%d>", ErrInvalidWindowSize, r.WindowSize)
+	}
+	if r.Rescorer == nil {
+		return ErrRescorerRequired
+	}
+	if v, ok := r.Rescorer.(interface{ Validate() error }); ok {
+		return v.Validate()
+	}
+	return nil
+}
+
+func (r Rescore) MarshalBSON() ([]byte, error) {
+	return r.MarshalJSON()
+}
+
+func (r Rescore) MarshalJSON() ([]byte, error) {
+	if r.Rescorer == nil {
+		return nil, ErrRescorerRequired
+	}
+	body, err := r.Rescorer.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	obj := dynamic.JSONObject{r.Rescorer.Kind(): body}
+	if r.WindowSize != 0 {
+		obj["window_size"], err = json.Marshal(r.WindowSize)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(obj)
+}
+
+func (r *Rescore) UnmarshalBSON(data []byte) error {
+	return r.UnmarshalJSON(data)
+}
+
+func (r *Rescore) UnmarshalJSON(data []byte) error {
+	*r = Rescore{}
+	var obj dynamic.JSONObject
+	err := json.Unmarshal(data, &obj)
+	if err != nil {
+		return err
+	}
+	for k, d := range obj {
+		if k == "window_size" {
+			err = json.Unmarshal(d, &r.WindowSize)
+			if err != nil {
+				return newFieldError(err, k)
+			}
+			continue
+		}
+		if r.Rescorer != nil {
+			return fmt.Errorf("%w; found <%s> and <%s>", ErrMultipleRescorers, r.Rescorer.Kind(), k)
+		}
+		var rescorer Rescorer
+		if handler, ok := RescorerHandlers[k]; ok {
+			rescorer = handler()
+		} else {
+			rescorer = &RawRescorer{Name: k}
+		}
+		err = rescorer.UnmarshalJSON(d)
+		if err != nil {
+			return newFieldError(err, k)
+		}
+		r.Rescorer = rescorer
+	}
+	return nil
+}
+
+// QueryRescorer executes a second query only on the top hits returned by the
+// query and post_filter phases.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/filter-search-results.html#query-rescorer
+type QueryRescorer struct {
+	// The query to rescore the top hits with (Required)
+	RescoreQuery Querier
+	// The weight of the original query. Defaults to 1.
+	QueryWeight *float64
+	// The weight of the rescore query. Defaults to 1.
+	RescoreQueryWeight *float64
+	// Controls the way the scores are combined. Defaults to "total".
+	ScoreMode RescoreScoreMode
+}
+
+func (QueryRescorer) Kind() string {
+	return RescorerKindQuery
+}
+
+// Validate checks that the rescore query is set and the score mode is valid
+func (qr QueryRescorer) Validate() error {
+	if qr.RescoreQuery == nil {
+		return ErrQueryRequired
+	}
+	return qr.ScoreMode.Validate()
+}
+
+func (qr QueryRescorer) MarshalBSON() ([]byte, error) {
+	return qr.MarshalJSON()
+}
+
+func (qr QueryRescorer) MarshalJSON() ([]byte, error) {
+	if qr.RescoreQuery == nil {
+		return nil, ErrQueryRequired
+	}
+	q, err := qr.RescoreQuery.Query()
+	if err != nil {
+		return nil, newFieldError(err, "rescore_query")
+	}
+	return queryRescorer{
+		RescoreQuery:       q,
+		QueryWeight:        qr.QueryWeight,
+		RescoreQueryWeight: qr.RescoreQueryWeight,
+		ScoreMode:          qr.ScoreMode,
+	}.MarshalJSON()
+}
+
+func (qr *QueryRescorer) UnmarshalBSON(data []byte) error {
+	return qr.UnmarshalJSON(data)
+}
+
+func (qr *QueryRescorer) UnmarshalJSON(data []byte) error {
+	*qr = QueryRescorer{}
+	var v queryRescorer
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	if v.RescoreQuery != nil {
+		qr.RescoreQuery = v.RescoreQuery
+	}
+	qr.QueryWeight = v.QueryWeight
+	qr.RescoreQueryWeight = v.RescoreQueryWeight
+	qr.ScoreMode = v.ScoreMode
+	return nil
+}
+
+//easyjson:json
+type queryRescorer struct {
+	RescoreQuery       *Query           `json:"rescore_query"`
+	QueryWeight        *float64         `json:"query_weight,omitempty"`
+	RescoreQueryWeight *float64         `json:"rescore_query_weight,omitempty"`
+	ScoreMode          RescoreScoreMode `json:"score_mode,omitempty"`
+}
+
+// RawRescorer is a rescorer which is kept as-is. It is used for rescorers
+// which are not registered in RescorerHandlers and can be used to send
+// rescorers provided by plugins.
+//
+//	picker.Rescore{
+//	    WindowSize: 100,
+//	    Rescorer: &picker.RawRescorer{
+//	        Name: "learning_to_rank",
+//	        Data: dynamic.JSON(`{"model": "my_model", "params": {"keywords": "rambo"}}`),
+//	    },
+//	}
+type RawRescorer struct {
+	// The kind of the rescorer (Required)
+	Name string
+	// The body of the rescorer
+	Data dynamic.JSON
+}
+
+func (r RawRescorer) Kind() string {
+	return r.Name
+}
+
+// Validate checks that Name is set
+func (r RawRescorer) Validate() error {
+	if len(r.Name) == 0 {
+		return ErrRescorerNameRequired
+	}
+	return nil
+}
+
+func (r RawRescorer) MarshalJSON() ([]byte, error) {
+	if len(r.Data) == 0 {
+		return []byte("{}"), nil
+	}
+	return r.Data, nil
+}
+
+func (r *RawRescorer) UnmarshalJSON(data []byte) error {
+	r.Data = append(dynamic.JSON(nil), data...)
+	return nil
+}
+
+// checkRescoreSort mirrors the check Elasticsearch performs when a search
+// has both rescore and sort. The only sort permitted is _score in descending
+// order.
+func checkRescoreSort(r Rescores, s Sort) error {
+	if len(r) == 0 || len(s) == 0 {
+		return nil
+	}
+	if len(s) == 1 && s[0].Field == "_score" && (s[0].Order == "" || strings.EqualFold(s[0].Order.String(), SortOrderDescending)) {
+		return nil
+	}
+	return ErrRescoreWithSort
+}
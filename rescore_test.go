@@ -0,0 +1,166 @@
+package picker_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/chanced/cmpjson"
+	"github.com/chanced/dynamic"
+	"github.com/chanced/picker"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRescore(t *testing.T) {
+	assert := require.New(t)
+	data := []byte(`{
+		"query": { "match": { "message": { "query": "the quick brown" } } },
+		"rescore": [
+			{
+				"window_size": 100,
+				"query": {
+					"rescore_query": { "match_phrase": { "message": { "query": "the quick brown" } } },
+					"query_weight": 0.7,
+					"rescore_query_weight": 1.2
+				}
+			},
+			{
+				"window_size": 10,
+				"query": {
+					"score_mode": "multiply",
+					"rescore_query": { "term": { "user.id": { "value": "kimchy" } } }
+				}
+			},
+			{
+				"window_size": 50,
+				"learning_to_rank": { "model": "my_model", "params": { "keywords": "rambo" } }
+			}
+		]
+	}`)
+	var s picker.Search
+	err := json.Unmarshal(data, &s)
+	assert.NoError(err)
+	assert.Empty(s.Passthrough())
+	rescore := s.Rescore()
+	assert.Len(rescore, 3)
+	assert.Equal(100, rescore[0].WindowSize)
+	qr, ok := rescore[0].Rescorer.(*picker.QueryRescorer)
+	assert.True(ok)
+	assert.Equal(0.7, *qr.QueryWeight)
+	assert.Equal(1.2, *qr.RescoreQueryWeight)
+	assert.NotNil(qr.RescoreQuery)
+	qr, ok = rescore[1].Rescorer.(*picker.QueryRescorer)
+	assert.True(ok)
+	assert.Equal(picker.RescoreScoreModeMultiply, qr.ScoreMode)
+	raw, ok := rescore[2].Rescorer.(*picker.RawRescorer)
+	assert.True(ok)
+	assert.Equal("learning_to_rank", raw.Kind())
+	assert.NoError(rescore.Validate())
+
+	sd, err := json.Marshal(s)
+	assert.NoError(err)
+	assert.True(cmpjson.Equal(data, sd), cmpjson.Diff(data, sd))
+
+	// a single rescore is encoded as an object
+	s2, err := picker.NewSearch(picker.SearchParams{
+		Rescore: picker.Rescores{{
+			WindowSize: 50,
+			Rescorer: &picker.QueryRescorer{
+				RescoreQuery: &picker.QueryParams{
+					Term: picker.TermQueryParams{Field: "user.id", Value: "kimchy"},
+				},
+				ScoreMode: picker.RescoreScoreModeMax,
+			},
+		}},
+		Sort: picker.Sort{{Field: "_score"}},
+	})
+	assert.NoError(err)
+	sd2, err := json.Marshal(s2)
+	assert.NoError(err)
+	expected := []byte(`{
+		"sort": [{ "_score": {} }],
+		"rescore": {
+			"window_size": 50,
+			"query": {
+				"rescore_query": { "term": { "user.id": { "value": "kimchy" } } },
+				"score_mode": "max"
+			}
+		}
+	}`)
+	assert.True(cmpjson.Equal(expected, sd2), cmpjson.Diff(expected, sd2))
+
+	var s3 picker.Search
+	err = json.Unmarshal(expected, &s3)
+	assert.NoError(err)
+	assert.Len(s3.Rescore(), 1)
+	assert.Equal(picker.RescoreScoreModeMax, s3.Rescore()[0].Rescorer.(*picker.QueryRescorer).ScoreMode)
+}
+
+func TestRescoreValidation(t *testing.T) {
+	assert := require.New(t)
+	rescore := picker.Rescores{{
+		Rescorer: &picker.QueryRescorer{
+			RescoreQuery: &picker.QueryParams{
+				Term: picker.TermQueryParams{Field: "user.id", Value: "kimchy"},
+			},
+		},
+	}}
+
+	_, err := picker.NewSearch(picker.SearchParams{
+		Rescore: rescore,
+		Sort:    picker.Sort{{Field: "date", Order: picker.SortOrderDescending}},
+	})
+	assert.True(errors.Is(err, picker.ErrRescoreWithSort))
+
+	_, err = picker.NewSearch(picker.SearchParams{
+		Rescore: rescore,
+		Sort:    picker.Sort{{Field: "_score", Order: picker.SortOrderAscending}},
+	})
+	assert.True(errors.Is(err, picker.ErrRescoreWithSort))
+
+	s, err := picker.NewSearch(picker.SearchParams{
+		Rescore: rescore,
+		Sort:    picker.Sort{{Field: "_score", Order: picker.SortOrderDescending}},
+	})
+	assert.NoError(err)
+	err = s.SetSort(picker.Sort{{Field: "_score"}, {Field: "date"}})
+	assert.True(errors.Is(err, picker.ErrRescoreWithSort))
+	assert.Len(s.Sort(), 1)
+
+	s, err = picker.NewSearch(picker.SearchParams{
+		Sort: picker.Sort{{Field: "date"}},
+	})
+	assert.NoError(err)
+	err = s.SetRescore(rescore)
+	assert.True(errors.Is(err, picker.ErrRescoreWithSort))
+	assert.Empty(s.Rescore())
+
+	err = s.SetRescore(picker.Rescores{{WindowSize: 10}})
+	assert.True(errors.Is(err, picker.ErrRescorerRequired))
+	var fe *picker.FieldError
+	assert.True(errors.As(err, &fe))
+	assert.Equal("rescore->[0]", fe.Field)
+
+	var r picker.Rescore
+	err = json.Unmarshal([]byte(`{
+		"query": { "rescore_query": { "term": { "user.id": { "value": "kimchy" } } } },
+		"learning_to_rank": { "model": "my_model" }
+	}`), &r)
+	assert.True(errors.Is(err, picker.ErrMultipleRescorers))
+
+	err = picker.Rescores{{WindowSize: 10}}.Validate()
+	assert.True(errors.Is(err, picker.ErrRescorerRequired))
+	err = picker.Rescores{{WindowSize: -1, Rescorer: &picker.QueryRescorer{}}}.Validate()
+	assert.True(errors.Is(err, picker.ErrInvalidWindowSize))
+	err = picker.Rescores{{Rescorer: &picker.QueryRescorer{}}}.Validate()
+	assert.True(errors.Is(err, picker.ErrQueryRequired))
+	err = picker.Rescores{{Rescorer: &picker.QueryRescorer{
+		RescoreQuery: &picker.QueryParams{
+			Term: picker.TermQueryParams{Field: "user.id", Value: "kimchy"},
+		},
+		ScoreMode: "sum",
+	}}}.Validate()
+	assert.True(errors.Is(err, picker.ErrInvalidRescoreScoreMode))
+	err = picker.Rescores{{Rescorer: &picker.RawRescorer{Data: dynamic.JSON(`{}`)}}}.Validate()
+	assert.True(errors.Is(err, picker.ErrRescorerNameRequired))
+}
@@ -0,0 +1,102 @@
+package picker
+
+// ReverseNestedAggParams creates a ReverseNestedAgg, a special single bucket
+// aggregation that enables aggregating on parent docs from nested documents.
+// Effectively this aggregation can break out of the nested block structure and
+// link to other nested structures or the root document, which allows nesting
+// other aggregations that aren’t part of the nested object in a nested
+// aggregation.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-reverse-nested-aggregation.html
+type ReverseNestedAggParams struct {
+	// Defines to what nested object field should be joined back. The default
+	// is empty, which means that it joins back to the root / main document
+	// level.
+	Path string
+	// Sub-aggregations
+	Aggregations Aggs
+	Meta         map[string]interface{}
+}
+
+func (ReverseNestedAggParams) Kind() AggKind {
+	return AggKindReverseNested
+}
+
+func (p ReverseNestedAggParams) Clause() (AggClause, error) {
+	return p.ReverseNested()
+}
+
+func (p ReverseNestedAggParams) ReverseNested() (*ReverseNestedAgg, error) {
+	a := &ReverseNestedAgg{}
+	a.SetPath(p.Path)
+	err := a.SetAggregations(p.Aggregations)
+	if err != nil {
+		return a, newAggError(err, AggKindReverseNested)
+	}
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// ReverseNestedAgg is a special single bucket aggregation that enables
+// aggregating on parent docs from nested documents. Effectively this
+// aggregation can break out of the nested block structure and link to other
+// nested structures or the root document, which allows nesting other
+// aggregations that aren’t part of the nested object in a nested aggregation.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-reverse-nested-aggregation.html
+type ReverseNestedAgg struct {
+	path string
+	aggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*ReverseNestedAgg)(nil)
+
+func (ReverseNestedAgg) Kind() AggKind {
+	return AggKindReverseNested
+}
+
+func (a *ReverseNestedAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// Path defines to what nested object field should be joined back. An empty
+// path joins back to the root document.
+func (a ReverseNestedAgg) Path() string {
+	return a.path
+}
+
+// SetPath sets the path to v
+func (a *ReverseNestedAgg) SetPath(v string) {
+	a.path = v
+}
+
+func (a ReverseNestedAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a ReverseNestedAgg) MarshalJSON() ([]byte, error) {
+	return reverseNestedAgg{
+		Path: a.path,
+	}.MarshalJSON()
+}
+
+func (a *ReverseNestedAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *ReverseNestedAgg) UnmarshalJSON(data []byte) error {
+	*a = ReverseNestedAgg{}
+	p := reverseNestedAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.path = p.Path
+	return nil
+}
+
+//easyjson:json
+type reverseNestedAgg struct {
+	Path string `json:"path,omitempty"`
+}
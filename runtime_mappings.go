@@ -1,20 +1,224 @@
 package picker
 
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
 type RuntimeMappingKind string
 
 const (
-	RMTBoolean  RuntimeMappingKind = "boolean"
-	RMTDate     RuntimeMappingKind = "date"
-	RMTDouble   RuntimeMappingKind = "double"
-	RMTGeoPoint RuntimeMappingKind = "geo_point"
-	RMTIP       RuntimeMappingKind = "ip"
-	RMTKeyword  RuntimeMappingKind = "keyword"
-	RMTLong     RuntimeMappingKind = "long"
+	RMTBoolean   RuntimeMappingKind = "boolean"
+	RMTComposite RuntimeMappingKind = "composite"
+	RMTDate      RuntimeMappingKind = "date"
+	RMTDouble    RuntimeMappingKind = "double"
+	RMTGeoPoint  RuntimeMappingKind = "geo_point"
+	RMTIP        RuntimeMappingKind = "ip"
+	RMTKeyword   RuntimeMappingKind = "keyword"
+	RMTLong      RuntimeMappingKind = "long"
+	RMTLookup    RuntimeMappingKind = "lookup"
 )
 
+func (k RuntimeMappingKind) String() string {
+	return string(k)
+}
+
+func (k RuntimeMappingKind) IsValid() bool {
+	switch k {
+	case RMTBoolean, RMTComposite, RMTDate, RMTDouble, RMTGeoPoint, RMTIP, RMTKeyword, RMTLong, RMTLookup:
+		return true
+	}
+	return false
+}
+
+// runtimeFieldTypes are the types of indexed fields each kind of runtime
+// field can shadow
+var runtimeFieldTypes = map[RuntimeMappingKind][]FieldType{
+	RMTBoolean: {FieldTypeBoolean},
+	RMTDate:    {FieldTypeDate, FieldTypeDateNanos},
+	RMTDouble: {
+		FieldTypeDouble, FieldTypeFloat, FieldTypeHalfFloat, FieldTypeScaledFloat,
+		FieldTypeLong, FieldTypeInteger, FieldTypeShort, FieldTypeByte, FieldTypeUnsignedLong,
+	},
+	RMTGeoPoint: {FieldTypeGeoPoint},
+	RMTIP:       {FieldTypeIP},
+	RMTKeyword:  {FieldTypeKeyword, FieldTypeConstant, FieldTypeWildcardKeyword},
+	RMTLong: {
+		FieldTypeLong, FieldTypeInteger, FieldTypeShort, FieldTypeByte, FieldTypeUnsignedLong,
+	},
+}
+
+// RuntimeMappingField is a runtime field, which is evaluated at query time.
+// Runtime fields can be defined in the runtime section of the mappings of an
+// index or in the runtime_mappings of a search request.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/runtime.html
 type RuntimeMappingField struct {
-	Kind   RuntimeMappingKind `bson:"type" json:"type"`
-	Script string             `bson:"script,omitempty" json:"script,omitempty"`
+	// Kind is the type of the runtime field (Required)
+	Kind RuntimeMappingKind `bson:"type" json:"type"`
+	// Script which emits the values of the field. If a runtime field does not
+	// have a script, the value of the field with the same name in _source is
+	// used. Required for composite fields.
+	Script *Script `bson:"script,omitempty" json:"script,omitempty"`
+	// Format of date runtime fields
+	Format string `bson:"format,omitempty" json:"format,omitempty"`
+	// Fields emitted by the script of composite runtime fields. The fields
+	// only have a type.
+	Fields RuntimeMappings `bson:"fields,omitempty" json:"fields,omitempty"`
+	// OnScriptError determines whether the entire query fails ("fail",
+	// default) or the document is ignored ("continue") when the script throws
+	// an error
+	OnScriptError string `bson:"on_script_error,omitempty" json:"on_script_error,omitempty"`
+	// TargetIndex is the index lookup fields retrieve documents from
+	TargetIndex string `bson:"target_index,omitempty" json:"target_index,omitempty"`
+	// InputField is the field whose values are used to find documents in the
+	// TargetIndex of lookup fields
+	InputField string `bson:"input_field,omitempty" json:"input_field,omitempty"`
+	// TargetField is the field of the TargetIndex matched against the values
+	// of the InputField of lookup fields
+	TargetField string `bson:"target_field,omitempty" json:"target_field,omitempty"`
+	// FetchFields are the fields of documents in the TargetIndex returned by
+	// lookup fields
+	FetchFields []string `bson:"fetch_fields,omitempty" json:"fetch_fields,omitempty"`
+}
+
+func (f RuntimeMappingField) MarshalBSON() ([]byte, error) {
+	return f.MarshalJSON()
 }
 
+// MarshalJSON encodes f, using the shorthand string form for scripts which
+// only have a source
+func (f RuntimeMappingField) MarshalJSON() ([]byte, error) {
+	type field RuntimeMappingField
+	v := struct {
+		field
+		Script interface{} `json:"script,omitempty"`
+	}{field: field(f)}
+	if f.Script != nil {
+		v.Script = f.Script
+		if len(f.Script.Lang) == 0 && f.Script.Params == nil {
+			v.Script = f.Script.Source
+		}
+	}
+	return json.Marshal(v)
+}
+
+// Validate checks that the kind of f is valid and that f only has the params
+// of its kind
+func (f RuntimeMappingField) Validate() error {
+	if !f.Kind.IsValid() {
+		return fmt.Errorf("%w <%s>", ErrInvalidRuntimeType, f.Kind)
+	}
+	if len(f.Format) > 0 && f.Kind != RMTDate {
+		return fmt.Errorf("%w; format is only supported by date runtime fields", ErrInvalidRuntimeParam)
+	}
+	if len(f.Fields) > 0 && f.Kind != RMTComposite {
+		return fmt.Errorf("%w; fields are only supported by composite runtime fields", ErrInvalidRuntimeParam)
+	}
+	lookup := len(f.TargetIndex) > 0 || len(f.InputField) > 0 || len(f.TargetField) > 0 || len(f.FetchFields) > 0
+	if lookup && f.Kind != RMTLookup {
+		return fmt.Errorf("%w; target_index, input_field, target_field, and fetch_fields are only supported by lookup runtime fields", ErrInvalidRuntimeParam)
+	}
+	if len(f.OnScriptError) > 0 && f.OnScriptError != "fail" && f.OnScriptError != "continue" {
+		return fmt.Errorf("%w; on_script_error must be \"fail\" or \"continue\"", ErrInvalidRuntimeParam)
+	}
+	if f.Script != nil && len(f.Script.Source) == 0 {
+		return fmt.Errorf("%w; script source is required", ErrScriptRequired)
+	}
+	switch f.Kind {
+	case RMTComposite:
+		if f.Script.IsEmpty() {
+			return fmt.Errorf("%w; composite runtime fields require a script", ErrScriptRequired)
+		}
+		if len(f.Fields) == 0 {
+			return fmt.Errorf("%w; composite runtime fields require fields", ErrFieldRequired)
+		}
+		for name, sub := range f.Fields {
+			if len(name) == 0 {
+				return ErrFieldRequired
+			}
+			if sub.Kind == RMTComposite || sub.Kind == RMTLookup {
+				return newFieldError(fmt.Errorf("%w; fields of composite runtime fields can not be of type <%s>", ErrInvalidRuntimeType, sub.Kind), name)
+			}
+			if sub.Script != nil {
+				return newFieldError(fmt.Errorf("%w; fields of composite runtime fields can not have a script", ErrInvalidRuntimeParam), name)
+			}
+			err := sub.Validate()
+			if err != nil {
+				return newFieldError(err, name)
+			}
+		}
+	case RMTLookup:
+		if f.Script != nil {
+			return fmt.Errorf("%w; lookup runtime fields can not have a script", ErrInvalidRuntimeParam)
+		}
+		if len(f.TargetIndex) == 0 || len(f.InputField) == 0 || len(f.TargetField) == 0 || len(f.FetchFields) == 0 {
+			return fmt.Errorf("%w; lookup runtime fields require target_index, input_field, target_field, and fetch_fields", ErrInvalidRuntimeParam)
+		}
+	}
+	return nil
+}
+
+// RuntimeMappings are runtime fields keyed by their dot-delimited name
 type RuntimeMappings map[string]RuntimeMappingField
+
+// Validate checks that each runtime field of rm is valid
+func (rm RuntimeMappings) Validate() error {
+	for name, f := range rm {
+		if len(name) == 0 {
+			return ErrFieldRequired
+		}
+		err := f.Validate()
+		if err != nil {
+			return newFieldError(err, name)
+		}
+	}
+	return nil
+}
+
+// ValidateShadowing checks that each runtime field of rm which shadows an
+// indexed field of properties is of a compatible type. For example, a long
+// runtime field may shadow an integer field but not a keyword field. The
+// fields of composite runtime fields are checked by their full path.
+func (rm RuntimeMappings) ValidateShadowing(properties Fields) error {
+	merr := MappingError{}
+	for name, f := range rm {
+		if f.Kind == RMTComposite {
+			for sub, sf := range f.Fields {
+				err := validateRuntimeShadowing(name+"."+sub, sf.Kind, properties)
+				if err != nil {
+					merr.Append(err)
+				}
+			}
+			continue
+		}
+		err := validateRuntimeShadowing(name, f.Kind, properties)
+		if err != nil {
+			merr.Append(err)
+		}
+	}
+	return merr.ErrorOrNil()
+}
+
+func validateRuntimeShadowing(path string, kind RuntimeMappingKind, properties Fields) error {
+	field, ok := properties.Lookup(path)
+	if !ok {
+		return nil
+	}
+	types, ok := runtimeFieldTypes[kind]
+	if !ok {
+		return nil
+	}
+	for _, t := range types {
+		if field.Type() == t {
+			return nil
+		}
+	}
+	strs := make([]string, len(types))
+	for i, t := range types {
+		strs[i] = string(t)
+	}
+	return newFieldError(fmt.Errorf("%w; %s runtime field shadows field of type <%s>; expected one of [%s]",
+		ErrIncompatibleRuntimeField, kind, field.Type(), strings.Join(strs, ", ")), path)
+}
@@ -0,0 +1,138 @@
+package picker_test
+
+import (
+	"testing"
+
+	"github.com/chanced/cmpjson"
+	"github.com/chanced/picker"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuntimeMappings(t *testing.T) {
+	assert := require.New(t)
+	data := []byte(`{
+		"mappings": {
+		  "runtime": {
+			"day_of_week": {
+			  "type": "keyword",
+			  "script": "emit(doc['@timestamp'].value.dayOfWeekEnum.getDisplayName(TextStyle.FULL, Locale.ROOT))"
+			},
+			"http": {
+			  "type": "composite",
+			  "script": {
+				"source": "emit(grok(params.pattern).extract(doc['message'].value))",
+				"params": { "pattern": "%{COMMONAPACHELOG}" },
+				"lang": "painless"
+			  },
+			  "fields": {
+				"clientip": { "type": "ip" },
+				"response": { "type": "long" }
+			  }
+			},
+			"timestamp": {
+			  "type": "date",
+			  "format": "yyyy-MM-dd",
+			  "on_script_error": "continue"
+			}
+		  },
+		  "properties": {
+			"@timestamp": { "type": "date" },
+			"message": { "type": "text" }
+		  }
+		}
+	  }`)
+	i, err := picker.NewIndex(picker.IndexParams{Mappings: picker.Mappings{
+		Runtime: picker.RuntimeMappings{
+			"day_of_week": {
+				Kind: picker.RMTKeyword,
+				Script: &picker.Script{
+					Source: "emit(doc['@timestamp'].value.dayOfWeekEnum.getDisplayName(TextStyle.FULL, Locale.ROOT))",
+				},
+			},
+			"http": {
+				Kind: picker.RMTComposite,
+				Script: &picker.Script{
+					Source: "emit(grok(params.pattern).extract(doc['message'].value))",
+					Params: map[string]interface{}{"pattern": "%{COMMONAPACHELOG}"},
+					Lang:   "painless",
+				},
+				Fields: picker.RuntimeMappings{
+					"clientip": {Kind: picker.RMTIP},
+					"response": {Kind: picker.RMTLong},
+				},
+			},
+			"timestamp": {
+				Kind:          picker.RMTDate,
+				Format:        "yyyy-MM-dd",
+				OnScriptError: "continue",
+			},
+		},
+		Properties: picker.FieldMap{
+			"@timestamp": picker.DateFieldParams{},
+			"message":    picker.TextFieldParams{},
+		},
+	}})
+	assert.NoError(err)
+	ixd, err := i.MarshalJSON()
+	assert.NoError(err)
+	assert.True(cmpjson.Equal(data, ixd), cmpjson.Diff(data, ixd))
+
+	i2 := picker.Index{}
+	err = i2.UnmarshalJSON(data)
+	assert.NoError(err)
+	http := i2.Mappings.Runtime["http"]
+	assert.Equal(picker.RMTComposite, http.Kind)
+	assert.Equal("painless", http.Script.Lang)
+	assert.Equal(picker.RMTIP, http.Fields["clientip"].Kind)
+	assert.Equal("yyyy-MM-dd", i2.Mappings.Runtime["timestamp"].Format)
+	i2d, err := i2.MarshalJSON()
+	assert.NoError(err)
+	assert.True(cmpjson.Equal(data, i2d), cmpjson.Diff(data, i2d))
+
+	_, err = picker.Mappings{
+		Runtime: picker.RuntimeMappings{"message": {Kind: picker.RMTLong}},
+		Properties: picker.FieldMap{
+			"message": picker.KeywordFieldParams{},
+		},
+	}.FieldMappings()
+	assert.ErrorIs(err, picker.ErrIncompatibleRuntimeField)
+
+	_, err = picker.Mappings{
+		Runtime: picker.RuntimeMappings{"http": {
+			Kind:   picker.RMTComposite,
+			Script: &picker.Script{Source: "emit(params._source)"},
+			Fields: picker.RuntimeMappings{"response": {Kind: picker.RMTKeyword}},
+		}},
+		Properties: picker.FieldMap{"http": picker.ObjectFieldParams{Properties: picker.FieldMap{
+			"response": picker.IntegerFieldParams{},
+		}}},
+	}.FieldMappings()
+	assert.ErrorIs(err, picker.ErrIncompatibleRuntimeField)
+
+	_, err = picker.Mappings{
+		Runtime: picker.RuntimeMappings{"http": {Kind: picker.RMTComposite}},
+	}.FieldMappings()
+	assert.ErrorIs(err, picker.ErrScriptRequired)
+
+	_, err = picker.Mappings{
+		Runtime: picker.RuntimeMappings{"count": {Kind: picker.RMTLong, Format: "yyyy"}},
+	}.FieldMappings()
+	assert.ErrorIs(err, picker.ErrInvalidRuntimeParam)
+
+	_, err = picker.NewSearch(picker.SearchParams{
+		RuntimeMappings: picker.RuntimeMappings{"count": {Kind: "integer"}},
+	})
+	assert.ErrorIs(err, picker.ErrInvalidRuntimeType)
+
+	s, err := picker.NewSearch(picker.SearchParams{
+		RuntimeMappings: picker.RuntimeMappings{"day": {
+			Kind:   picker.RMTKeyword,
+			Script: &picker.Script{Source: "emit(params.day)", Params: map[string]interface{}{"day": "monday"}},
+		}},
+	})
+	assert.NoError(err)
+	sd, err := s.MarshalJSON()
+	assert.NoError(err)
+	expected := []byte(`{"runtime_mappings":{"day":{"type":"keyword","script":{"source":"emit(params.day)","params":{"day":"monday"}}}}}`)
+	assert.True(cmpjson.Equal(expected, sd), cmpjson.Diff(expected, sd))
+}
@@ -0,0 +1,99 @@
+package picker
+
+const DefaultSamplerShardSize = 100
+
+// SamplerAggParams creates a SamplerAgg, a filtering aggregation used to limit
+// any sub aggregations' processing to a sample of the top-scoring documents.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-sampler-aggregation.html
+type SamplerAggParams struct {
+	// Limits how many top-scoring documents are collected in the sample
+	// processed on each shard. Defaults to 100.
+	ShardSize int
+	// Sub-aggregations
+	Aggregations Aggs
+	Meta         map[string]interface{}
+}
+
+func (SamplerAggParams) Kind() AggKind {
+	return AggKindSampler
+}
+
+func (p SamplerAggParams) Clause() (AggClause, error) {
+	return p.Sampler()
+}
+
+func (p SamplerAggParams) Sampler() (*SamplerAgg, error) {
+	a := &SamplerAgg{}
+	a.SetShardSize(p.ShardSize)
+	err := a.SetAggregations(p.Aggregations)
+	if err != nil {
+		return a, newAggError(err, AggKindSampler)
+	}
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// SamplerAgg is a filtering aggregation used to limit any sub aggregations'
+// processing to a sample of the top-scoring documents.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-sampler-aggregation.html
+type SamplerAgg struct {
+	shardSize int
+	aggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*SamplerAgg)(nil)
+
+func (SamplerAgg) Kind() AggKind {
+	return AggKindSampler
+}
+
+func (a *SamplerAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// ShardSize limits how many top-scoring documents are collected in the sample
+// processed on each shard. Defaults to 100.
+func (a SamplerAgg) ShardSize() int {
+	if a.shardSize == 0 {
+		return DefaultSamplerShardSize
+	}
+	return a.shardSize
+}
+
+// SetShardSize sets shard_size to v
+func (a *SamplerAgg) SetShardSize(v int) {
+	a.shardSize = v
+}
+
+func (a SamplerAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a SamplerAgg) MarshalJSON() ([]byte, error) {
+	return samplerAgg{
+		ShardSize: a.shardSize,
+	}.MarshalJSON()
+}
+
+func (a *SamplerAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *SamplerAgg) UnmarshalJSON(data []byte) error {
+	*a = SamplerAgg{}
+	p := samplerAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.SetShardSize(p.ShardSize)
+	return nil
+}
+
+//easyjson:json
+type samplerAgg struct {
+	ShardSize int `json:"shard_size,omitempty"`
+}
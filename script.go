@@ -7,9 +7,30 @@ import (
 )
 
 type Script struct {
-	Lang   string
-	Source string
-	Params interface{}
+	Lang   string      `json:"lang,omitempty"`
+	Source string      `json:"source"`
+	Params interface{} `json:"params,omitempty"`
+}
+
+// UnmarshalJSON accepts either a script object or a string, which is
+// shorthand for the source of the script
+func (s *Script) UnmarshalJSON(data []byte) error {
+	*s = Script{}
+	d := dynamic.JSON(data)
+	if d.IsNull() {
+		return nil
+	}
+	if d.IsString() {
+		return json.Unmarshal(data, &s.Source)
+	}
+	type script Script
+	var v script
+	err := json.Unmarshal(data, &v)
+	if err != nil {
+		return err
+	}
+	*s = Script(v)
+	return nil
 }
 
 func (s *Script) Clear() {
@@ -0,0 +1,42 @@
+package picker
+
+import "fmt"
+
+// ScriptFields are fields computed by scripts for each hit, keyed by the name
+// of the field in the hits.fields property of the response.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-fields.html#script-fields
+type ScriptFields map[string]ScriptField
+
+// Validate checks that each ScriptField has a script
+func (sf ScriptFields) Validate() error {
+	for name, f := range sf {
+		if len(name) == 0 {
+			return ErrFieldRequired
+		}
+		err := f.Validate()
+		if err != nil {
+			return newFieldError(err, name)
+		}
+	}
+	return nil
+}
+
+// ScriptField is a field computed by a script for each hit
+//
+//easyjson:json
+type ScriptField struct {
+	// The script which computes the value of the field (Required)
+	Script Script `json:"script"`
+	// If true, errors which occur while evaluating the script are ignored and
+	// the field is omitted from the hit.
+	IgnoreFailure bool `json:"ignore_failure,omitempty"`
+}
+
+// Validate checks that the script is set
+func (f ScriptField) Validate() error {
+	if f.Script.IsEmpty() {
+		return fmt.Errorf("%w; script source is required", ErrScriptRequired)
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package picker
+
+// ScriptProcessorParams creates a ScriptProcessor, which runs an inline or
+// stored script on incoming documents. The script runs in the ingest context.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/script-processor.html
+type ScriptProcessorParams struct {
+	// Lang is the script language. Defaults to "painless".
+	Lang string
+	// ID of a stored script. If Source is not specified, ID is required.
+	ID string
+	// Source is an inline script. If ID is not specified, Source is required.
+	Source string
+	// Params are the parameters of the script
+	Params map[string]interface{}
+	// Description of the processor. Useful for describing the purpose of the
+	// processor or its configuration.
+	Description string
+	// Conditionally execute the processor
+	If string
+	// Ignore failures for the processor
+	IgnoreFailure bool
+	// Handle failures for the processor
+	OnFailure Procs
+	// Identifier for the processor. Useful for debugging and metrics.
+	Tag string
+}
+
+func (ScriptProcessorParams) Kind() ProcessorKind {
+	return ProcessorKindScript
+}
+
+func (p ScriptProcessorParams) Processor() (Processor, error) {
+	return p.Script()
+}
+
+func (p ScriptProcessorParams) Script() (*ScriptProcessor, error) {
+	proc := &ScriptProcessor{}
+	if len(p.ID) == 0 && len(p.Source) == 0 {
+		return proc, newProcessorError(ErrScriptRequired, ProcessorKindScript)
+	}
+	proc.SetLang(p.Lang)
+	proc.SetID(p.ID)
+	proc.SetSource(p.Source)
+	proc.SetParams(p.Params)
+	proc.SetDescription(p.Description)
+	proc.SetIf(p.If)
+	proc.SetIgnoreFailure(p.IgnoreFailure)
+	err := proc.SetOnFailure(p.OnFailure)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindScript)
+	}
+	proc.SetTag(p.Tag)
+	return proc, nil
+}
+
+// ScriptProcessor runs an inline or stored script on incoming documents. The
+// script runs in the ingest context.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/script-processor.html
+type ScriptProcessor struct {
+	lang   string
+	id     string
+	source string
+	params map[string]interface{}
+	processorParams
+}
+
+var _ Processor = (*ScriptProcessor)(nil)
+
+func (ScriptProcessor) Kind() ProcessorKind {
+	return ProcessorKindScript
+}
+
+func (p *ScriptProcessor) Processor() (Processor, error) {
+	return p, nil
+}
+
+// Lang is the script language. Defaults to "painless".
+func (p ScriptProcessor) Lang() string {
+	return p.lang
+}
+
+// SetLang sets lang to v
+func (p *ScriptProcessor) SetLang(v string) {
+	p.lang = v
+}
+
+// ID of a stored script. If Source is not specified, ID is required.
+func (p ScriptProcessor) ID() string {
+	return p.id
+}
+
+// SetID sets id to v
+func (p *ScriptProcessor) SetID(v string) {
+	p.id = v
+}
+
+// Source is an inline script. If ID is not specified, Source is required.
+func (p ScriptProcessor) Source() string {
+	return p.source
+}
+
+// SetSource sets source to v
+func (p *ScriptProcessor) SetSource(v string) {
+	p.source = v
+}
+
+// Params are the parameters of the script
+func (p ScriptProcessor) Params() map[string]interface{} {
+	return p.params
+}
+
+// SetParams sets params to v
+func (p *ScriptProcessor) SetParams(v map[string]interface{}) {
+	p.params = v
+}
+
+func (p ScriptProcessor) MarshalBSON() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+func (p ScriptProcessor) MarshalJSON() ([]byte, error) {
+	return scriptProcessor{
+		Lang:          p.lang,
+		ID:            p.id,
+		Source:        p.source,
+		Params:        p.params,
+		Description:   p.description,
+		If:            p.ifCond,
+		IgnoreFailure: p.ignoreFailure,
+		OnFailure:     p.onFailure,
+		Tag:           p.tag,
+	}.MarshalJSON()
+}
+
+func (p *ScriptProcessor) UnmarshalBSON(data []byte) error {
+	return p.UnmarshalJSON(data)
+}
+
+func (p *ScriptProcessor) UnmarshalJSON(data []byte) error {
+	*p = ScriptProcessor{}
+	var v scriptProcessor
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	p.lang = v.Lang
+	p.id = v.ID
+	p.source = v.Source
+	p.params = v.Params
+	p.description = v.Description
+	p.ifCond = v.If
+	p.ignoreFailure = v.IgnoreFailure
+	p.onFailure = v.OnFailure
+	p.tag = v.Tag
+	return nil
+}
+
+//easyjson:json
+type scriptProcessor struct {
+	Lang          string                 `json:"lang,omitempty"`
+	ID            string                 `json:"id,omitempty"`
+	Source        string                 `json:"source,omitempty"`
+	Params        map[string]interface{} `json:"params,omitempty"`
+	Description   string                 `json:"description,omitempty"`
+	If            string                 `json:"if,omitempty"`
+	IgnoreFailure bool                   `json:"ignore_failure,omitempty"`
+	OnFailure     Processors             `json:"on_failure,omitempty"`
+	Tag           string                 `json:"tag,omitempty"`
+}
@@ -2,6 +2,7 @@ package picker
 
 import (
 	"bytes"
+	"fmt"
 	"time"
 
 	"encoding/json"
@@ -17,9 +18,13 @@ var (
 
 // SearchParams are the initial params passed to NewSearch
 type SearchParams struct {
-	Query        Querier
-	Sort         Sort
-	Aggregations map[string]interface{}
+	Query Querier
+	Sort  Sort
+	// Aggregations summarize your data as metrics, statistics, or other
+	// analytics. (Optional)
+	//
+	// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations.html
+	Aggregations Aggs
 	// Array of wildcard (*) patterns. The request returns doc values for field
 	// names matching these patterns in the hits.fields property of the response
 	// (Optional) .
@@ -51,8 +56,9 @@ type SearchParams struct {
 	// parameter. (Optional)
 	From int
 
-	// Boosts the _score of documents from specified indices (Optional).
-	IndicesBoost map[string]float64
+	// Boosts the _score of documents from specified indices. The first entry
+	// which matches an index is used. (Optional)
+	IndicesBoost IndicesBoost
 
 	// Minimum _score for matching documents. Documents with a lower _score are
 	// not included in the search results (Optional).
@@ -76,11 +82,15 @@ type SearchParams struct {
 	// By default, you cannot page through more than 10,000 hits using the from
 	// and size parameters. To page through more hits, use the search_after
 	// parameter.
+	//
+	// A Size of 0 is left to the default. To only return aggregations or
+	// suggestions, call SetSize(0) on the Search.
 	Size int
 
 	// Indicates which source fields are returned for matching documents. These
 	// fields are returned in the hits._source property of the search response.
-	// Defaults to true. (Optional)
+	// Defaults to true. See SearchSource.SetValue for accepted values.
+	// (Optional)
 	Source interface{}
 
 	// Stats groups to associate with the picker. Each group maintains a
@@ -102,7 +112,56 @@ type SearchParams struct {
 	// If true, returns document version as part of a hit. Defaults to false. (Optional)
 	Version bool
 
-	Highlight interface{}
+	// Highlight enables you to get highlighted snippets from one or more
+	// fields in your search results. (Optional)
+	Highlight *Highlight
+
+	// SearchAfter retrieves the next page of hits using the sort values of the
+	// last hit of the previous page. Use it with a PointInTime to page through
+	// more than 10,000 hits. (Optional)
+	SearchAfter SortValues
+
+	// Collapse collapses search results based on field values. (Optional)
+	Collapse *Collapse
+
+	// Suggest suggests similar looking terms based on a provided text.
+	// (Optional)
+	Suggest *Suggest
+
+	// Rescore reorders the top hits returned by the query and post_filter
+	// phases using a secondary algorithm. Rescore can not be combined with a
+	// sort other than _score. (Optional)
+	Rescore Rescores
+
+	// ScriptFields are fields computed by scripts for each hit. (Optional)
+	ScriptFields ScriptFields
+
+	// StoredFields are the stored fields to return for each hit. Use
+	// "_none_" to disable stored fields, including _id and _source.
+	// (Optional)
+	StoredFields []string
+
+	// TrackTotalHits is either a bool or an integer threshold up to which the
+	// total number of hits is counted accurately. Defaults to 10,000. See
+	// TrackTotalHits.SetValue for accepted values. (Optional)
+	TrackTotalHits interface{}
+
+	// If true, calculates and returns document scores, even if the scores are
+	// not used for sorting. (Optional)
+	TrackScores bool
+
+	// PostFilter filters the hits after aggregations are calculated.
+	// (Optional)
+	PostFilter Querier
+
+	// If true, returns detailed timing information about the execution of
+	// the individual components of the search. (Optional)
+	Profile bool
+
+	// SearchType controls how distributed term frequencies are calculated for
+	// relevance scoring. It is a request parameter and is not included in the
+	// body. (Optional)
+	SearchType SearchType
 }
 
 func NewSearch(p SearchParams) (*Search, error) {
@@ -115,18 +174,20 @@ func NewSearch(p SearchParams) (*Search, error) {
 		indicesBoost:     p.IndicesBoost,
 		minScore:         p.MinScore,
 		pointInTime:      p.PointInTime,
-		runtimeMappings:  p.RuntimeMappings,
 		seqNoPrimaryTerm: p.SeqNoPrimaryTerm,
 		stats:            p.Stats,
 		terminateAfter:   p.TerminateAfter,
 		timeout:          p.Timeout,
 		version:          p.Version,
-		source:           p.Source,
+		searchAfter:      p.SearchAfter,
+		storedFields:     p.StoredFields,
+		trackScores:      p.TrackScores,
+		profile:          p.Profile,
 	}
 	if p.Size != 0 {
 		err := s.SetSize(p.Size)
 		if err != nil {
-			return s, err
+			return s, newFieldError(err, "size")
 		}
 	}
 	if p.Query != nil {
@@ -138,28 +199,94 @@ func NewSearch(p SearchParams) (*Search, error) {
 			s.query = q
 		}
 	}
+	err := s.SetAggregations(p.Aggregations)
+	if err != nil {
+		return s, err
+	}
+	err = s.SetSource(p.Source)
+	if err != nil {
+		return s, newFieldError(err, "_source")
+	}
+	err = s.SetScriptFields(p.ScriptFields)
+	if err != nil {
+		return s, err
+	}
+	err = s.SetRuntimeMappings(p.RuntimeMappings)
+	if err != nil {
+		return s, err
+	}
+	err = s.SetTrackTotalHits(p.TrackTotalHits)
+	if err != nil {
+		return s, err
+	}
+	err = s.SetPostFilter(p.PostFilter)
+	if err != nil {
+		return s, err
+	}
+	err = s.SetSearchType(p.SearchType)
+	if err != nil {
+		return s, err
+	}
+	err = s.SetHighlight(p.Highlight)
+	if err != nil {
+		return s, err
+	}
+	err = s.SetCollapse(p.Collapse)
+	if err != nil {
+		return s, err
+	}
+	err = s.SetSuggest(p.Suggest)
+	if err != nil {
+		return s, err
+	}
+	err = s.SetSort(p.Sort)
+	if err != nil {
+		return s, err
+	}
+	err = s.SetRescore(p.Rescore)
+	if err != nil {
+		return s, err
+	}
 	return s, nil
 }
 
 type Search struct {
 	// Defines the search definition using the Query DSL. (Optional)
-	query            *Query // query
-	aggregations     map[string]interface{}
-	docValueFields   SearchFields       // docvalue_fields
-	fields           SearchFields       // fields
-	explain          bool               // explain
-	from             int                // from
-	indicesBoost     map[string]float64 // indices_boost
-	minScore         float64            // min_score
-	pointInTime      *PointInTime       // pit
-	runtimeMappings  RuntimeMappings    // runtime_mappings
-	seqNoPrimaryTerm bool               // seq_no_primary_term
-	size             dynamic.Number     // size
-	source           interface{}        // _source
-	stats            []string           // stats
-	terminateAfter   int                // terminate_after
-	timeout          time.Duration      // timeout
-	version          bool               // version
+	query            *Query          // query
+	sort             Sort            // sort
+	sortRaw          dynamic.JSON    // sort as it was given
+	sortRawFor       []byte          // sort, encoded, when sortRaw was given
+	aggregations     Aggregations    // aggs
+	aggregationsKey  bool            // aggs was given as "aggregations"
+	docValueFields   SearchFields    // docvalue_fields
+	fields           SearchFields    // fields
+	explain          bool            // explain
+	from             int             // from
+	indicesBoost     IndicesBoost    // indices_boost
+	indicesBoostObj  bool            // indices_boost was given in the object form
+	minScore         float64         // min_score
+	pointInTime      *PointInTime    // pit
+	runtimeMappings  RuntimeMappings // runtime_mappings
+	seqNoPrimaryTerm bool            // seq_no_primary_term
+	size             dynamic.Number  // size
+	source           *SearchSource   // _source
+	stats            []string        // stats
+	terminateAfter   int             // terminate_after
+	timeout          time.Duration   // timeout
+	version          bool            // version
+	highlight        *Highlight      // highlight
+	searchAfter      SortValues      // search_after
+	collapse         *Collapse       // collapse
+	suggest          *Suggest        // suggest
+	rescore          Rescores        // rescore
+	scriptFields     ScriptFields    // script_fields
+	storedFields     []string        // stored_fields
+	trackTotalHits   *TrackTotalHits // track_total_hits
+	trackScores      bool            // track_scores
+	postFilter       *Query          // post_filter
+	profile          bool            // profile
+	searchType       SearchType      // search_type (request parameter)
+	passthrough      map[string]dynamic.JSON
 }
 
 var zeroSearch = &Search{}
@@ -175,187 +302,163 @@ func (s *Search) UnmarshalJSON(data []byte) (err error) {
 	if err != nil {
 		return err
 	}
-
-	// for k, d := range m {
-	// 	switch k {
-	// 	case "query":
-	// 		var q Query
-	// 		err = json.Unmarshal(d, &q)
-	// 		s.query = &q
-	// 	case "aggs":
-	// 		var a map[string]interface{}
-	// 		err = json.Unmarshal(d, &a)
-	// 		s.aggregations = a
-	// 	case "docvalue_fields":
-	// 		var df SearchFields
-	// 		err = json.Unmarshal(d, &df)
-	// 		s.docValueFields = df
-	// 	case "fields":
-	// 		var f SearchFields
-	// 		err = json.Unmarshal(d, &f)
-	// 		s.fields = f
-	// 	case "explain":
-	// 		var b dynamic.Bool
-	// 		b, err = dynamic.NewBool(d.UnquotedString())
-	// 		if v, ok := b.Bool(); ok {
-	// 			s.explain = v
-	// 		}
-	// 	case "from":
-	// 		var i int
-	// 		err = json.Unmarshal(d, &i)
-	// 		s.from = i
-	// 	case "indices_boost":
-	// 		var ib map[string]float64
-	// 		err = json.Unmarshal(d, &ib)
-	// 		s.indicesBoost = ib
-	// 	case "min_score":
-	// 		var i float64
-	// 		err = json.Unmarshal(d, &i)
-	// 		s.minScore = i
-	// 	case "pit":
-
-	// 	}
-	// 	if err != nil {
-	// 		return err
-	// 	}
-	// }
-	if d, ok := m["query"]; ok {
-		q := Query{}
-		err = q.UnmarshalJSON(d)
-		if err != nil {
-			return err
-		}
-		s.query = &q
-	}
-
-	if d, ok := m["aggs"]; ok {
-		var a map[string]interface{}
-		err := json.Unmarshal(d, &a)
-		if err != nil {
-			return err
-		}
-		s.aggregations = a
-	}
-
-	if d, ok := m["docvalue_fields"]; ok {
-		var df SearchFields
-		err = json.Unmarshal(d, &df)
-		if err != nil {
-			return err
-		}
-		s.docValueFields = df
-	}
-	if d, ok := m["fields"]; ok {
-		var f SearchFields
-		err = json.Unmarshal(d, &f)
-		if err != nil {
-			return err
-		}
-		s.fields = f
-	}
-	if d, ok := m["explain"]; ok {
-		b, err := dynamic.NewBool(d.UnquotedString())
-		if err != nil {
-			return err
-		}
-		if v, ok := b.Bool(); ok {
-			s.explain = v
-		}
-	}
-	if d, ok := m["from"]; ok {
-		var i int
-		err := json.Unmarshal(d, &i)
-		if err != nil {
-			return err
-		}
-		s.from = i
-	}
-	if d, ok := m["indices_boost"]; ok {
-		var ib map[string]float64
-		err = json.Unmarshal(d, &ib)
-		if err != nil {
-			return err
-		}
-		s.indicesBoost = ib
-	}
-	if d, ok := m["min_score"]; ok {
-		var i float64
-		err = json.Unmarshal(d, &i)
-		if err != nil {
-			return err
-		}
-		s.minScore = i
-	}
-	if d, ok := m["pit"]; ok {
-		var pit PointInTime
-		err = json.Unmarshal(d, &pit)
-		if err != nil {
-			return err
-		}
-		s.pointInTime = &pit
-	}
-	if d, ok := m["runtime_mappings"]; ok {
-		var r RuntimeMappings
-		err = json.Unmarshal(d, &r)
-		if err != nil {
-			return err
-		}
-		s.runtimeMappings = r
-	}
-	if d, ok := m["seq_no_primary_term"]; ok {
-		var b bool
-		err = json.Unmarshal(d, &b)
-		if err != nil {
-			return err
-		}
-	}
-	if d, ok := m["size"]; ok {
-		n, err := dynamic.NewNumber(d.UnquotedString())
-		if err != nil {
-			return err
-		}
-		s.size = n
-	}
-
-	if d, ok := m["_source"]; ok {
-		var v interface{}
-		err = json.Unmarshal(d, &v)
-		if err != nil {
-			return err
+	if _, ok := m["aggs"]; ok {
+		if _, ok := m["aggregations"]; ok {
+			return fmt.Errorf("%w; body contains both \"aggs\" and \"aggregations\"", ErrMultipleAggregationKeys)
 		}
-		s.source = &v
 	}
-	if d, ok := m["stats"]; ok {
-		var v []string
-		err = json.Unmarshal(d, &v)
-		if err != nil {
-			return err
+	for k, d := range m {
+		switch k {
+		case "query":
+			q := Query{}
+			err = q.UnmarshalJSON(d)
+			s.query = &q
+		case "sort":
+			var v Sort
+			err = json.Unmarshal(d, &v)
+			s.sort = v
+			if err == nil {
+				s.sortRaw = d
+				s.sortRawFor, err = json.Marshal(v)
+			}
+		case "aggs", "aggregations":
+			var a Aggregations
+			err = a.UnmarshalJSON(d)
+			s.aggregations = a
+			s.aggregationsKey = k == "aggregations"
+		case "docvalue_fields":
+			var df SearchFields
+			err = json.Unmarshal(d, &df)
+			s.docValueFields = df
+		case "fields":
+			var f SearchFields
+			err = json.Unmarshal(d, &f)
+			s.fields = f
+		case "explain":
+			var b dynamic.Bool
+			b, err = dynamic.NewBool(d.UnquotedString())
+			if v, ok := b.Bool(); ok {
+				s.explain = v
+			}
+		case "from":
+			var n dynamic.Number
+			n, err = dynamic.NewNumber(d.UnquotedString())
+			if i, ok := n.Int(); ok {
+				s.from = i
+			}
+		case "indices_boost":
+			var ib IndicesBoost
+			s.indicesBoostObj, err = ib.unmarshal(d)
+			s.indicesBoost = ib
+		case "min_score":
+			var n dynamic.Number
+			n, err = dynamic.NewNumber(d.UnquotedString())
+			if f, ok := n.Float64(); ok {
+				s.minScore = f
+			}
+		case "pit":
+			var pit PointInTime
+			err = json.Unmarshal(d, &pit)
+			s.pointInTime = &pit
+		case "runtime_mappings":
+			var r RuntimeMappings
+			err = json.Unmarshal(d, &r)
+			s.runtimeMappings = r
+		case "seq_no_primary_term":
+			var b dynamic.Bool
+			b, err = dynamic.NewBool(d.UnquotedString())
+			if v, ok := b.Bool(); ok {
+				s.seqNoPrimaryTerm = v
+			}
+		case "size":
+			s.size, err = dynamic.NewNumber(d.UnquotedString())
+		case "_source":
+			var v SearchSource
+			err = v.UnmarshalJSON(d)
+			s.source = &v
+		case "stats":
+			var v []string
+			err = json.Unmarshal(d, &v)
+			s.stats = v
+		case "terminate_after":
+			var n dynamic.Number
+			n, err = dynamic.NewNumber(d.UnquotedString())
+			if i, ok := n.Int(); ok {
+				s.terminateAfter = i
+			}
+		case "timeout":
+			s.timeout, err = parseTimeValue(d.UnquotedString())
+		case "version":
+			var b dynamic.Bool
+			b, err = dynamic.NewBool(d.UnquotedString())
+			if v, ok := b.Bool(); ok {
+				s.version = v
+			}
+		case "highlight":
+			var h Highlight
+			err = h.UnmarshalJSON(d)
+			s.highlight = &h
+		case "search_after":
+			var v SortValues
+			err = v.UnmarshalJSON(d)
+			s.searchAfter = v
+		case "collapse":
+			var c Collapse
+			err = c.UnmarshalJSON(d)
+			s.collapse = &c
+		case "suggest":
+			var v Suggest
+			err = v.UnmarshalJSON(d)
+			s.suggest = &v
+		case "rescore":
+			var v Rescores
+			err = v.UnmarshalJSON(d)
+			s.rescore = v
+		case "script_fields":
+			var v ScriptFields
+			err = json.Unmarshal(d, &v)
+			s.scriptFields = v
+		case "stored_fields":
+			var v dynamic.StringOrArrayOfStrings
+			err = json.Unmarshal(d, &v)
+			s.storedFields = []string(v)
+		case "track_total_hits":
+			var v TrackTotalHits
+			err = v.UnmarshalJSON(d)
+			if !v.IsEmpty() {
+				s.trackTotalHits = &v
+			}
+		case "track_scores":
+			var b dynamic.Bool
+			b, err = dynamic.NewBool(d.UnquotedString())
+			if v, ok := b.Bool(); ok {
+				s.trackScores = v
+			}
+		case "post_filter":
+			q := Query{}
+			err = q.UnmarshalJSON(d)
+			s.postFilter = &q
+		case "profile":
+			var b dynamic.Bool
+			b, err = dynamic.NewBool(d.UnquotedString())
+			if v, ok := b.Bool(); ok {
+				s.profile = v
+			}
+		case "search_type":
+			// search_type is a request parameter but it is accepted here so
+			// that it is not passed through to the body
+			var v string
+			err = json.Unmarshal(d, &v)
+			s.searchType = SearchType(v)
+		default:
+			if s.passthrough == nil {
+				s.passthrough = map[string]dynamic.JSON{}
+			}
+			s.passthrough[k] = d
 		}
-		s.stats = v
-	}
-	if d, ok := m["terminate_after"]; ok {
-		var i int
-		err = json.Unmarshal(d, &i)
 		if err != nil {
-			return err
+			return newFieldError(err, k)
 		}
-		s.terminateAfter = i
-	}
-	if d, ok := m["timeout"]; ok {
-		var v time.Duration
-		err = json.Unmarshal(d, &v)
-		if err != nil {
-			return err
-		}
-		s.timeout = v
-	}
-	if d, ok := m["version"]; ok {
-		var v bool
-		err = json.Unmarshal(d, &v)
-		if err != nil {
-			return err
-		}
-		s.version = v
 	}
 	return nil
 }
@@ -375,11 +478,15 @@ func (s Search) MarshalJSON() ([]byte, error) {
 		data["docvalue_fields"] = b
 	}
 	if len(s.aggregations) > 0 {
-		aggs, err := json.Marshal(s.aggregations)
+		aggs, err := s.aggregations.MarshalJSON()
 		if err != nil {
 			return nil, err
 		}
-		data["aggs"] = aggs
+		if s.aggregationsKey {
+			data["aggregations"] = aggs
+		} else {
+			data["aggs"] = aggs
+		}
 	}
 	if len(s.fields) > 0 {
 		b, err := json.Marshal(s.fields)
@@ -400,7 +507,13 @@ func (s Search) MarshalJSON() ([]byte, error) {
 		data["from"] = n.Bytes()
 	}
 	if len(s.indicesBoost) > 0 {
-		b, err := json.Marshal(s.indicesBoost)
+		var b []byte
+		var err error
+		if s.indicesBoostObj {
+			b, err = s.indicesBoost.marshalObject()
+		} else {
+			b, err = s.indicesBoost.MarshalJSON()
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -431,7 +544,7 @@ func (s Search) MarshalJSON() ([]byte, error) {
 		data["seq_no_primary_term"] = trueBytes
 	}
 
-	if i, ok := s.size.Int(); ok && i != 10 && i != 0 {
+	if s.size.HasValue() {
 		data["size"] = s.size.Bytes()
 	}
 	if s.source != nil {
@@ -453,11 +566,101 @@ func (s Search) MarshalJSON() ([]byte, error) {
 		data["terminate_after"] = n.Bytes()
 	}
 	if s.timeout > 0 {
-		data["timeout"] = append([]byte{'"'}, append([]byte(s.timeout.String()), '"')...)
+		b, err := json.Marshal(formatTimeValue(s.timeout))
+		if err != nil {
+			return nil, err
+		}
+		data["timeout"] = b
 	}
 	if s.version {
 		data["version"] = trueBytes
 	}
+	if len(s.sort) > 0 {
+		b, err := json.Marshal(s.sort)
+		if err != nil {
+			return nil, err
+		}
+		// keep the form the sort was given in, such as "_score" or
+		// {"post_date": "desc"}, unless it has since changed
+		if len(s.sortRaw) > 0 && bytes.Equal(b, s.sortRawFor) {
+			b = s.sortRaw
+		}
+		data["sort"] = b
+	}
+	if s.highlight != nil {
+		b, err := s.highlight.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		data["highlight"] = b
+	}
+	if len(s.searchAfter) > 0 {
+		b, err := json.Marshal(s.searchAfter)
+		if err != nil {
+			return nil, err
+		}
+		data["search_after"] = b
+	}
+	if s.collapse != nil {
+		b, err := s.collapse.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		data["collapse"] = b
+	}
+	if s.suggest != nil {
+		b, err := s.suggest.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		data["suggest"] = b
+	}
+	if len(s.rescore) > 0 {
+		b, err := s.rescore.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		data["rescore"] = b
+	}
+	if len(s.scriptFields) > 0 {
+		b, err := json.Marshal(s.scriptFields)
+		if err != nil {
+			return nil, err
+		}
+		data["script_fields"] = b
+	}
+	if len(s.storedFields) > 0 {
+		b, err := json.Marshal(s.storedFields)
+		if err != nil {
+			return nil, err
+		}
+		data["stored_fields"] = b
+	}
+	if !s.trackTotalHits.IsEmpty() {
+		b, err := s.trackTotalHits.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		data["track_total_hits"] = b
+	}
+	if s.trackScores {
+		data["track_scores"] = trueBytes
+	}
+	if s.postFilter != nil && !s.postFilter.IsEmpty() {
+		b, err := json.Marshal(s.postFilter)
+		if err != nil {
+			return nil, err
+		}
+		data["post_filter"] = b
+	}
+	if s.profile {
+		data["profile"] = trueBytes
+	}
+	for k, v := range s.passthrough {
+		if _, exists := data[k]; !exists {
+			data[k] = v
+		}
+	}
 	return json.Marshal(data)
 }
 
@@ -484,6 +687,44 @@ func (s *Search) SetDocValueFields(v SearchFields) *Search {
 	return s
 }
 
+// Aggregations summarize your data as metrics, statistics, or other analytics.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations.html
+func (s *Search) Aggregations() Aggregations {
+	if s.aggregations == nil {
+		s.aggregations = Aggregations{}
+	}
+	return s.aggregations
+}
+
+// SetAggregations sets the aggregations of the search to v
+func (s *Search) SetAggregations(v Aggregationset) error {
+	if v == nil {
+		s.aggregations = nil
+		return nil
+	}
+	aggs, err := v.Aggregations()
+	if err != nil {
+		return err
+	}
+	if len(aggs) == 0 {
+		s.aggregations = nil
+		return nil
+	}
+	err = checkBucketsPaths(aggs)
+	if err != nil {
+		return err
+	}
+	s.aggregations = aggs
+	return nil
+}
+
+// DecodeAggregations decodes data, the aggregations section of a search
+// response, into AggResults typed according to the aggregations of s.
+func (s *Search) DecodeAggregations(data []byte) (AggResults, error) {
+	return s.aggregations.DecodeResults(data)
+}
+
 // Fields allows for retrieving a list of document fields in the search
 // response. It consults both the document _source and the index mappings to
 // return each value in a standardized way that matches its mapping type. By
@@ -536,17 +777,15 @@ func (s *Search) SetFrom(v int) {
 	s.from = v
 }
 
-// IndicesBoost buusts the _score of documents from specified indices
-func (s *Search) IndicesBoost() map[string]float64 {
-	if s.indicesBoost == nil {
-		s.indicesBoost = map[string]float64{}
-	}
+// IndicesBoost boosts the _score of documents from specified indices
+func (s *Search) IndicesBoost() IndicesBoost {
 	return s.indicesBoost
 }
 
-// SetIndicesBoost sets IndicesBoostValue to v
-func (s *Search) SetIndicesBoost(v map[string]float64) {
+// SetIndicesBoost sets indices_boost to v, which is encoded in the array form
+func (s *Search) SetIndicesBoost(v IndicesBoost) {
 	s.indicesBoost = v
+	s.indicesBoostObj = false
 }
 
 // MinScore is the minimum _score for matching documents. Documents with a lower
@@ -588,14 +827,14 @@ func (s Search) PITID() string {
 	return s.PointInTimeID()
 }
 
-func (s Search) PointInTimeKeepAlive() *time.Time {
+func (s Search) PointInTimeKeepAlive() time.Duration {
 	pit := s.PointInTime()
 	if pit == nil {
-		return nil
+		return 0
 	}
 	return pit.KeepAlive
 }
-func (s Search) PITKeepAlive() *time.Time {
+func (s Search) PITKeepAlive() time.Duration {
 	return s.PointInTimeKeepAlive()
 }
 
@@ -629,8 +868,19 @@ func (s Search) RuntimeMappings() RuntimeMappings {
 	return s.runtimeMappings
 }
 
-func (s *Search) SetRuntimeMappings(v RuntimeMappings) {
+// SetRuntimeMappings sets runtime_mappings to v after validating each
+// runtime field
+func (s *Search) SetRuntimeMappings(v RuntimeMappings) error {
+	if len(v) == 0 {
+		s.runtimeMappings = nil
+		return nil
+	}
+	err := v.Validate()
+	if err != nil {
+		return newFieldError(err, "runtime_mappings")
+	}
 	s.runtimeMappings = v
+	return nil
 }
 
 // SeqNoPrimaryTerm https://www.elastic.co/guide/en/elasticsearch/reference/current/optimistic-concurrency-control.html
@@ -653,43 +903,41 @@ func (s Search) Size() int {
 	return DefaultSize
 }
 
+// SetSize sets size to v. The size is included in the body, even if it is 0
+// or the default, until it is cleared with ClearSize.
 func (s *Search) SetSize(v int) error {
 	return s.size.Set(v)
 }
 
+// ClearSize removes size from the body so that the default is used
+func (s *Search) ClearSize() {
+	s.size.Clear()
+}
+
 // Source indicates which source fields are returned for matching documents.
 // These fields are returned in the hits._source property of the search
 // response. Defaults to true.
-func (s Search) Source() interface{} {
+func (s Search) Source() *SearchSource {
 	return s.source
 }
 
-// SetSource sets the value of Source
+// SetSource sets _source to v. See SearchSource.SetValue for accepted values.
 func (s *Search) SetSource(v interface{}) error {
-	//
-	// The options are:
-	//  picker.Source, *picker.Source,
-	//  string, []string,
-	//  dynamic.StringOrArrayOfStrings, *dynamic.StringOrArrayOfStrings,
-	//  picker.SourceSpecifications
-	//  *picker.SourceSpecifications
-	//  bool, *bool
-	//  nil
-	// Note, "true" || "false" get parsed as boolean
-	s.source = v
+	if v == nil {
+		s.source = nil
+		return nil
+	}
+	src := &SearchSource{}
+	err := src.SetValue(v)
+	if err != nil {
+		return err
+	}
+	if src.IsEmpty() {
+		s.source = nil
+		return nil
+	}
+	s.source = src
 	return nil
-	// switch t := v.(type) {
-	// case *SearchSource:
-	// 	ts := *t
-	// 	s.source = &ts
-	// 	return nil
-	// case SearchSource:
-	// 	s.source = &t
-	// 	return nil
-	// default:
-	// 	s.source = &SearchSource{}
-	// 	return s.source.SetValue(v)
-	// }
 }
 
 // Stats groups to associate with the picker. Each group maintains a statistics
@@ -731,6 +979,261 @@ func (s *Search) SetTimeout(v time.Duration) *Search {
 	return s
 }
 
+// Sort determines how the hits are sorted. Defaults to _score.
+func (s Search) Sort() Sort {
+	return s.sort
+}
+
+// SetSort sets sort to v after validating it. An error is returned if rescore
+// is set and v sorts on anything other than _score.
+func (s *Search) SetSort(v Sort) error {
+	err := v.Validate()
+	if err != nil {
+		return newFieldError(err, "sort")
+	}
+	err = checkRescoreSort(s.rescore, v)
+	if err != nil {
+		return newFieldError(err, "sort")
+	}
+	s.sort = v
+	return nil
+}
+
+// Highlight of the matching hits
+func (s Search) Highlight() *Highlight {
+	return s.highlight
+}
+
+// SetHighlight sets highlight to v after validating it
+func (s *Search) SetHighlight(v *Highlight) error {
+	if v == nil {
+		s.highlight = nil
+		return nil
+	}
+	err := v.Validate()
+	if err != nil {
+		return newFieldError(err, "highlight")
+	}
+	s.highlight = v
+	return nil
+}
+
+// SearchAfter is the sort values of the last hit of the previous page. The
+// next page of hits starts after them.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/paginate-search-results.html#search-after
+func (s Search) SearchAfter() SortValues {
+	return s.searchAfter
+}
+
+// SetSearchAfter sets search_after to v
+func (s *Search) SetSearchAfter(v SortValues) {
+	s.searchAfter = v
+}
+
+// Collapse collapses search results based on field values
+func (s Search) Collapse() *Collapse {
+	return s.collapse
+}
+
+// SetCollapse sets collapse to v after validating it
+func (s *Search) SetCollapse(v *Collapse) error {
+	if v == nil {
+		s.collapse = nil
+		return nil
+	}
+	err := v.Validate()
+	if err != nil {
+		return newFieldError(err, "collapse")
+	}
+	s.collapse = v
+	return nil
+}
+
+// Suggest suggests similar looking terms based on a provided text
+func (s Search) Suggest() *Suggest {
+	return s.suggest
+}
+
+// SetSuggest sets suggest to v after validating it
+func (s *Search) SetSuggest(v *Suggest) error {
+	if v == nil {
+		s.suggest = nil
+		return nil
+	}
+	err := v.Validate()
+	if err != nil {
+		return newFieldError(err, "suggest")
+	}
+	s.suggest = v
+	return nil
+}
+
+// Rescore reorders the top hits returned by the query and post_filter phases
+func (s Search) Rescore() Rescores {
+	return s.rescore
+}
+
+// SetRescore sets rescore to v after validating it. An error is returned if
+// sort is set to anything other than _score.
+func (s *Search) SetRescore(v Rescores) error {
+	if len(v) == 0 {
+		s.rescore = nil
+		return nil
+	}
+	err := v.Validate()
+	if err != nil {
+		return newFieldError(err, "rescore")
+	}
+	err = checkRescoreSort(v, s.sort)
+	if err != nil {
+		return newFieldError(err, "rescore")
+	}
+	s.rescore = v
+	return nil
+}
+
+// ScriptFields are fields computed by scripts for each hit
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-fields.html#script-fields
+func (s Search) ScriptFields() ScriptFields {
+	return s.scriptFields
+}
+
+// SetScriptFields sets script_fields to v after validating it
+func (s *Search) SetScriptFields(v ScriptFields) error {
+	if len(v) == 0 {
+		s.scriptFields = nil
+		return nil
+	}
+	err := v.Validate()
+	if err != nil {
+		return newFieldError(err, "script_fields")
+	}
+	s.scriptFields = v
+	return nil
+}
+
+// StoredFields are the stored fields to return for each hit
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-fields.html#stored-fields
+func (s Search) StoredFields() []string {
+	return s.storedFields
+}
+
+// SetStoredFields sets stored_fields to v
+func (s *Search) SetStoredFields(v []string) {
+	s.storedFields = v
+}
+
+// TrackTotalHits controls how the total number of hits is tracked. It is nil
+// unless set, in which case Elasticsearch counts up to 10,000 hits
+// accurately.
+func (s Search) TrackTotalHits() *TrackTotalHits {
+	return s.trackTotalHits
+}
+
+// SetTrackTotalHits sets track_total_hits to v. See TrackTotalHits.SetValue
+// for accepted values.
+func (s *Search) SetTrackTotalHits(v interface{}) error {
+	t := &TrackTotalHits{}
+	err := t.SetValue(v)
+	if err != nil {
+		return newFieldError(err, "track_total_hits")
+	}
+	if t.IsEmpty() {
+		s.trackTotalHits = nil
+		return nil
+	}
+	s.trackTotalHits = t
+	return nil
+}
+
+// TrackScores indicates whether scores are calculated and returned even if
+// they are not used for sorting. Defaults to false.
+func (s Search) TrackScores() bool {
+	return s.trackScores
+}
+
+// SetTrackScores sets track_scores to v
+func (s *Search) SetTrackScores(v bool) {
+	s.trackScores = v
+}
+
+// PostFilter filters the hits after aggregations are calculated
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/filter-search-results.html#post-filter
+func (s Search) PostFilter() *Query {
+	return s.postFilter
+}
+
+// SetPostFilter sets post_filter to v
+func (s *Search) SetPostFilter(v Querier) error {
+	if v == nil {
+		s.postFilter = nil
+		return nil
+	}
+	q, err := v.Query()
+	if err != nil {
+		return newFieldError(err, "post_filter")
+	}
+	if q.IsEmpty() {
+		s.postFilter = nil
+		return nil
+	}
+	s.postFilter = q
+	return nil
+}
+
+// Profile indicates whether detailed timing information about the execution
+// of the search is returned. Defaults to false.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-profile.html
+func (s Search) Profile() bool {
+	return s.profile
+}
+
+// SetProfile sets profile to v
+func (s *Search) SetProfile(v bool) {
+	s.profile = v
+}
+
+// SearchType controls how distributed term frequencies are calculated for
+// relevance scoring.
+//
+// search_type is a request parameter, so it is not included when marshaling
+// Search. It is accepted when unmarshaling.
+func (s Search) SearchType() SearchType {
+	return s.searchType
+}
+
+// SetSearchType sets search_type to v after validating it
+func (s *Search) SetSearchType(v SearchType) error {
+	err := v.Validate()
+	if err != nil {
+		return newFieldError(err, "search_type")
+	}
+	s.searchType = v
+	return nil
+}
+
+// Passthrough contains body parameters which are not otherwise supported by
+// Search. They are kept when unmarshaling and included when marshaling so that
+// nothing is lost in a round trip. Supported parameters take precedence over
+// those in Passthrough.
+func (s Search) Passthrough() map[string]dynamic.JSON {
+	return s.passthrough
+}
+
+// SetPassthrough sets the passthrough parameters to v
+func (s *Search) SetPassthrough(v map[string]dynamic.JSON) {
+	if len(v) == 0 {
+		s.passthrough = nil
+		return
+	}
+	s.passthrough = v
+}
+
 // Version determines whether the document version should be returned as part a
 // hit. Default: false
 func (s Search) Version() bool {
@@ -0,0 +1,164 @@
+package picker
+
+import (
+	"context"
+	"fmt"
+)
+
+// SearchFetchFunc executes search s and returns the body of the response
+type SearchFetchFunc func(ctx context.Context, s *Search) ([]byte, error)
+
+// SearchAfterPager pages through the hits of a search using a point in time
+// and search_after. Before each fetch, search_after is set to the sort values
+// of the last hit of the previous page and the point in time is updated with
+// the pit_id of the previous response.
+//
+// If the sort of the Search does not contain _shard_doc, it is added as the
+// final sort to act as a tiebreaker, which guarantees that every hit is
+// returned exactly once.
+//
+//	pager, err := picker.NewSearchAfterPager(search, fetch)
+//	if err != nil {
+//	    return err
+//	}
+//	for pager.Next(ctx) {
+//	    for _, hit := range pager.Hits() {
+//	        // ...
+//	    }
+//	}
+//	if err := pager.Err(); err != nil {
+//	    return err
+//	}
+//
+// Note that the pager modifies the sort, search_after, and point in time of
+// the Search. The point in time is not closed by the pager; use
+// PointInTimeID to retrieve the most recent ID once paging has finished.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/paginate-search-results.html#search-after
+type SearchAfterPager struct {
+	search   *Search
+	fetch    SearchFetchFunc
+	after    SortValues
+	response *SearchResponse
+	pages    int
+	done     bool
+	err      error
+}
+
+// NewSearchAfterPager returns a SearchAfterPager for s, which must have a
+// PointInTime and must not have a rescore. Paging starts after the
+// search_after of s, if set.
+func NewSearchAfterPager(s *Search, fetch SearchFetchFunc) (*SearchAfterPager, error) {
+	if s == nil {
+		return nil, ErrSearchRequired
+	}
+	if fetch == nil {
+		return nil, ErrFetchRequired
+	}
+	if len(s.PointInTimeID()) == 0 {
+		return nil, ErrPointInTimeRequired
+	}
+	if s.From() > 0 {
+		return nil, fmt.Errorf("%w; received <%d>", ErrSearchAfterFrom, s.From())
+	}
+	if len(s.Rescore()) > 0 {
+		return nil, ErrRescoreWithSearchAfter
+	}
+	hasTiebreaker := false
+	for _, e := range s.Sort() {
+		if e.Field == "_shard_doc" {
+			hasTiebreaker = true
+		}
+	}
+	if !hasTiebreaker {
+		entries := append(Sort{}, s.Sort()...)
+		err := s.SetSort(append(entries, SortEntry{Field: "_shard_doc", Order: SortOrderAscending}))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &SearchAfterPager{
+		search: s,
+		fetch:  fetch,
+		after:  s.SearchAfter(),
+	}, nil
+}
+
+// Next fetches the next page of hits. It returns false when a page without
+// hits is returned or an error occurred, which is available from Err. A page
+// with fewer hits than the size of the search is not considered the last, as
+// a page may be partial.
+func (p *SearchAfterPager) Next(ctx context.Context) bool {
+	if p.done || p.err != nil {
+		return false
+	}
+	if err := ctx.Err(); err != nil {
+		p.err = err
+		return false
+	}
+	p.search.SetSearchAfter(p.after)
+	data, err := p.fetch(ctx, p.search)
+	if err != nil {
+		p.err = err
+		return false
+	}
+	var res SearchResponse
+	err = res.UnmarshalJSON(data)
+	if err != nil {
+		p.err = err
+		return false
+	}
+	if len(res.PITID) > 0 {
+		pit := p.search.PointInTime().Clone()
+		pit.ID = res.PITID
+		p.search.SetPointInTime(pit)
+	}
+	p.pages++
+	p.response = &res
+	hits := res.Hits.Hits
+	if len(hits) == 0 {
+		p.done = true
+		return false
+	}
+	last := hits[len(hits)-1]
+	if len(last.Sort) == 0 {
+		p.err = fmt.Errorf("%w <%s>", ErrSortValuesMissing, last.ID)
+		return false
+	}
+	p.after = last.Sort
+	return true
+}
+
+// Hits are the hits of the current page
+func (p *SearchAfterPager) Hits() []Hit {
+	if p.response == nil {
+		return nil
+	}
+	return p.response.Hits.Hits
+}
+
+// Response is the SearchResponse of the current page
+func (p *SearchAfterPager) Response() *SearchResponse {
+	return p.response
+}
+
+// SearchAfter is the sort values of the last hit of the current page. It can
+// be used to resume paging by setting the SearchAfter of the Search.
+func (p *SearchAfterPager) SearchAfter() SortValues {
+	return p.after
+}
+
+// PointInTimeID is the most recent ID of the point in time
+func (p *SearchAfterPager) PointInTimeID() string {
+	return p.search.PointInTimeID()
+}
+
+// Pages is the number of pages fetched
+func (p *SearchAfterPager) Pages() int {
+	return p.pages
+}
+
+// Err returns the first error encountered while paging
+func (p *SearchAfterPager) Err() error {
+	return p.err
+}
@@ -0,0 +1,145 @@
+package picker_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/chanced/cmpjson"
+	"github.com/chanced/picker"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchAfter(t *testing.T) {
+	assert := require.New(t)
+	data := []byte(`{
+		"size": 2,
+		"pit": { "id": "46ToAwMDaWR5BXV1aWQy", "keep_alive": "1m" },
+		"sort": [{ "@timestamp": { "order": "asc" } }],
+		"search_after": [4098435132000, "FaslK3QBySSL_rrj9zM5", 9007199254740993]
+	}`)
+	var s picker.Search
+	err := json.Unmarshal(data, &s)
+	assert.NoError(err)
+	assert.Equal(time.Minute, s.PointInTimeKeepAlive())
+	assert.Len(s.SearchAfter(), 3)
+	assert.Equal(json.Number("9007199254740993"), s.SearchAfter()[2])
+	sd, err := json.Marshal(s)
+	assert.NoError(err)
+	assert.True(cmpjson.Equal(data, sd), cmpjson.Diff(data, sd))
+}
+
+func TestSearchAfterPager(t *testing.T) {
+	assert := require.New(t)
+	const base = int64(9007199254740993)
+	ids := []string{"a", "b", "c", "d", "e"}
+	s, err := picker.NewSearch(picker.SearchParams{
+		Size:        2,
+		PointInTime: &picker.PointInTime{ID: "pit-0", KeepAlive: time.Minute},
+	})
+	assert.NoError(err)
+
+	var requests []string
+	fetch := func(ctx context.Context, s *picker.Search) ([]byte, error) {
+		body, err := json.Marshal(s)
+		if err != nil {
+			return nil, err
+		}
+		var req struct {
+			Size        int                          `json:"size"`
+			Sort        []map[string]json.RawMessage `json:"sort"`
+			SearchAfter []json.Number                `json:"search_after"`
+			PIT         struct {
+				ID        string `json:"id"`
+				KeepAlive string `json:"keep_alive"`
+			} `json:"pit"`
+		}
+		err = json.Unmarshal(body, &req)
+		if err != nil {
+			return nil, err
+		}
+		if len(req.Sort) != 1 || req.Sort[0]["_shard_doc"] == nil {
+			return nil, fmt.Errorf("unexpected sort: %s", body)
+		}
+		if req.PIT.KeepAlive != "1m" {
+			return nil, fmt.Errorf("unexpected keep_alive: %s", body)
+		}
+		requests = append(requests, req.PIT.ID)
+		start := 0
+		if len(req.SearchAfter) > 0 {
+			n, err := req.SearchAfter[0].Int64()
+			if err != nil {
+				return nil, err
+			}
+			start = int(n-base) + 1
+		}
+		end := start + req.Size
+		if end > len(ids) {
+			end = len(ids)
+		}
+		hits := []map[string]interface{}{}
+		for i, id := range ids[start:end] {
+			hits = append(hits, map[string]interface{}{
+				"_index": "my-index",
+				"_id":    id,
+				"_score": nil,
+				"sort":   []json.Number{json.Number(fmt.Sprint(base + int64(start+i)))},
+			})
+		}
+		return json.Marshal(map[string]interface{}{
+			"took":   1,
+			"pit_id": fmt.Sprintf("pit-%d", len(requests)),
+			"hits":   map[string]interface{}{"hits": hits},
+		})
+	}
+
+	pager, err := picker.NewSearchAfterPager(s, fetch)
+	assert.NoError(err)
+	var seen []string
+	for pager.Next(context.Background()) {
+		for _, hit := range pager.Hits() {
+			seen = append(seen, hit.ID)
+		}
+	}
+	assert.NoError(pager.Err())
+	assert.Equal(ids, seen)
+	assert.Equal([]string{"pit-0", "pit-1", "pit-2", "pit-3"}, requests)
+	assert.Equal(4, pager.Pages())
+	assert.Equal("pit-4", pager.PointInTimeID())
+	assert.Equal(picker.SortValues{json.Number("9007199254740997")}, pager.SearchAfter())
+	assert.False(pager.Next(context.Background()))
+
+	failing := func(ctx context.Context, s *picker.Search) ([]byte, error) {
+		return nil, errors.New("boom")
+	}
+	pager, err = picker.NewSearchAfterPager(s, failing)
+	assert.NoError(err)
+	assert.Len(s.Sort(), 1)
+	assert.False(pager.Next(context.Background()))
+	assert.EqualError(pager.Err(), "boom")
+
+	_, err = picker.NewSearchAfterPager(&picker.Search{}, fetch)
+	assert.True(errors.Is(err, picker.ErrPointInTimeRequired))
+	s.SetFrom(10)
+	_, err = picker.NewSearchAfterPager(s, fetch)
+	assert.True(errors.Is(err, picker.ErrSearchAfterFrom))
+
+	rs, err := picker.NewSearch(picker.SearchParams{
+		PointInTime: &picker.PointInTime{ID: "pit-0"},
+		Rescore: picker.Rescores{{
+			WindowSize: 50,
+			Rescorer: &picker.QueryRescorer{
+				RescoreQuery: &picker.QueryParams{
+					Term: picker.TermQueryParams{Field: "user.id", Value: "kimchy"},
+				},
+			},
+		}},
+	})
+	assert.NoError(err)
+	_, err = picker.NewSearchAfterPager(rs, fetch)
+	assert.True(errors.Is(err, picker.ErrRescoreWithSearchAfter))
+	assert.Empty(rs.Sort())
+}
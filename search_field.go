@@ -20,17 +20,18 @@ type SearchField struct {
 	Format string
 }
 
-type field SearchField
-
 func (f SearchField) MarshalBSON() ([]byte, error) {
 	return f.MarshalJSON()
 }
 
 func (f SearchField) MarshalJSON() ([]byte, error) {
-	if f.Format != "" {
+	if f.Format == "" {
 		return json.Marshal(f.Field)
 	}
-	return json.Marshal(field(f))
+	return json.Marshal(map[string]string{
+		"field":  f.Field,
+		"format": f.Format,
+	})
 }
 
 func (f *SearchField) UnmarshalBSON(data []byte) error {
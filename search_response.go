@@ -0,0 +1,100 @@
+package picker
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/chanced/dynamic"
+)
+
+// SearchResponse is the response of a search request
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-search.html#search-api-response-body
+//
+//easyjson:json
+type SearchResponse struct {
+	// Milliseconds it took Elasticsearch to execute the request
+	Took int64 `json:"took"`
+	// If true, the request timed out before completion; returned results may
+	// be partial or empty.
+	TimedOut bool `json:"timed_out"`
+	// Set to true if the request terminated early, such as when
+	// terminate_after is reached
+	TerminatedEarly *bool `json:"terminated_early,omitempty"`
+	// Contains a count of shards used for the request
+	Shards ShardStats `json:"_shards"`
+	// Contains returned documents and metadata
+	Hits Hits `json:"hits"`
+	// Aggregations contains the results of the aggregations as-is. Use
+	// DecodeAggregations to decode them into AggResults.
+	Aggregations dynamic.JSON `json:"aggregations,omitempty"`
+	// Suggestions of the suggesters of the request, keyed by name
+	Suggest SuggestResults `json:"suggest,omitempty"`
+	// The ID of the point in time, if the request used one
+	PITID string `json:"pit_id,omitempty"`
+	// The ID of the scroll, if the request used scroll
+	ScrollID        string `json:"_scroll_id,omitempty"`
+	NumReducePhases int    `json:"num_reduce_phases,omitempty"`
+}
+
+func DecodeSearchResponse(body io.Reader) (*SearchResponse, error) {
+	dec := json.NewDecoder(body)
+	var res SearchResponse
+	err := dec.Decode(&res)
+	return &res, err
+}
+
+// DecodeAggregations decodes the aggregations of the response into AggResults
+// typed according to the aggregations of s.
+func (r SearchResponse) DecodeAggregations(s *Search) (AggResults, error) {
+	if s == nil {
+		return AggResults{}, nil
+	}
+	return s.DecodeAggregations(r.Aggregations)
+}
+
+// ShardStats contains a count of the shards used for a request
+//
+//easyjson:json
+type ShardStats struct {
+	// Total number of shards that require querying, including unallocated
+	// shards
+	Total int `json:"total"`
+	// Number of shards that executed the request successfully
+	Successful int `json:"successful"`
+	// Number of shards that skipped the request because a lightweight check
+	// helped realize that no documents could possibly match on this shard
+	Skipped int `json:"skipped"`
+	// Number of shards that failed to execute the request
+	Failed   int            `json:"failed"`
+	Failures []ShardFailure `json:"failures,omitempty"`
+}
+
+// ShardFailure is the failure of a shard to execute a request
+//
+//easyjson:json
+type ShardFailure struct {
+	Shard  int        `json:"shard"`
+	Index  string     `json:"index,omitempty"`
+	Node   string     `json:"node,omitempty"`
+	Status string     `json:"status,omitempty"`
+	Reason ErrorCause `json:"reason"`
+}
+
+// ErrorCause describes an error returned by Elasticsearch
+//
+//easyjson:json
+type ErrorCause struct {
+	Type      string       `json:"type"`
+	Reason    string       `json:"reason,omitempty"`
+	CausedBy  *ErrorCause  `json:"caused_by,omitempty"`
+	RootCause []ErrorCause `json:"root_cause,omitempty"`
+}
+
+// Error satisfies the error interface
+func (e ErrorCause) Error() string {
+	if len(e.Reason) == 0 {
+		return e.Type
+	}
+	return e.Type + ": " + e.Reason
+}
@@ -0,0 +1,187 @@
+package picker_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/chanced/picker"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchResponse(t *testing.T) {
+	assert := require.New(t)
+	data := []byte(`{
+		"took": 5,
+		"timed_out": false,
+		"_shards": {
+			"total": 2,
+			"successful": 1,
+			"skipped": 0,
+			"failed": 1,
+			"failures": [{
+				"shard": 1,
+				"index": "movies",
+				"node": "n1",
+				"reason": { "type": "query_shard_exception", "reason": "failed to create query" }
+			}]
+		},
+		"hits": {
+			"total": { "value": 20, "relation": "gte" },
+			"max_score": 1.3,
+			"hits": [
+				{
+					"_index": "movies",
+					"_id": "1",
+					"_score": 1.3,
+					"_seq_no": 4,
+					"_primary_term": 1,
+					"_source": { "title": "Alien", "year": 1979 },
+					"fields": { "year": [1979] },
+					"sort": [1.3, "1"],
+					"highlight": { "title": ["<em>Alien</em>"] },
+					"inner_hits": {
+						"cast": {
+							"hits": {
+								"total": { "value": 1, "relation": "eq" },
+								"max_score": 1.0,
+								"hits": [{
+									"_index": "movies",
+									"_id": "1",
+									"_nested": { "field": "cast", "offset": 0 },
+									"_score": 1.0,
+									"_source": { "name": "Sigourney Weaver" }
+								}]
+							}
+						}
+					}
+				},
+				{
+					"_index": "movies",
+					"_id": "2",
+					"_score": 1.1,
+					"_source": { "title": "Aliens", "year": 1986 }
+				}
+			]
+		},
+		"aggregations": {
+			"years": { "value": 1982.5 }
+		}
+	}`)
+	res, err := picker.DecodeSearchResponse(bytes.NewReader(data))
+	assert.NoError(err)
+	assert.Equal(int64(5), res.Took)
+	assert.Equal(1, res.Shards.Failed)
+	assert.Equal("query_shard_exception: failed to create query", res.Shards.Failures[0].Reason.Error())
+	assert.Equal(int64(20), res.Hits.Total.Value)
+	assert.Equal(picker.HitsTotalRelationGte, res.Hits.Total.Relation)
+	assert.Equal(2, res.Hits.Len())
+
+	hit := res.Hits.Hits[0]
+	assert.Equal(int64(4), *hit.SeqNo)
+	assert.Equal(int64(1), *hit.PrimaryTerm)
+	assert.Equal([]string{"<em>Alien</em>"}, hit.Highlight["title"])
+	assert.Equal("1", hit.Sort[1])
+	var years []int
+	assert.NoError(hit.DecodeField("year", &years))
+	assert.Equal([]int{1979}, years)
+	cast := hit.InnerHits["cast"].Hits
+	assert.Equal("cast", cast.Hits[0].Nested.Field)
+
+	type movie struct {
+		Title string `json:"title"`
+		Year  int    `json:"year"`
+	}
+	var m movie
+	assert.NoError(hit.DecodeSource(&m))
+	assert.Equal(movie{"Alien", 1979}, m)
+
+	var movies []movie
+	assert.NoError(res.Hits.DecodeSources(&movies))
+	assert.Equal([]movie{{"Alien", 1979}, {"Aliens", 1986}}, movies)
+	var ptrs []*movie
+	assert.NoError(res.Hits.DecodeSources(&ptrs))
+	assert.Equal("Aliens", ptrs[1].Title)
+	assert.True(errors.Is(res.Hits.DecodeSources(movies), picker.ErrInvalidSourceTarget))
+	assert.True(errors.Is(picker.Hit{ID: "3"}.DecodeSource(&m), picker.ErrSourceMissing))
+
+	s, err := picker.NewSearch(picker.SearchParams{
+		Aggregations: picker.Aggs{"years": picker.AvgAggParams{Field: "year"}},
+	})
+	assert.NoError(err)
+	aggs, err := res.DecodeAggregations(s)
+	assert.NoError(err)
+	assert.Equal(1982.5, *aggs.Get("years").(*picker.ValueAggResult).Value)
+
+	var legacy picker.SearchResponse
+	err = json.Unmarshal([]byte(`{"hits": {"total": 3, "hits": []}}`), &legacy)
+	assert.NoError(err)
+	assert.Equal(int64(3), legacy.Hits.Total.Value)
+	assert.Equal(picker.HitsTotalRelationEq, legacy.Hits.Total.Relation)
+
+	count, err := picker.DecodeCountResponse(bytes.NewReader([]byte(`{"count": 42, "_shards": {"total": 1, "successful": 1}}`)))
+	assert.NoError(err)
+	assert.Equal(int64(42), count.Count)
+	assert.Equal(1, count.Shards.Successful)
+}
+
+func TestByQueryResponses(t *testing.T) {
+	assert := require.New(t)
+	data := []byte(`{
+		"took": 147,
+		"timed_out": false,
+		"total": 120,
+		"deleted": 119,
+		"batches": 1,
+		"version_conflicts": 1,
+		"noops": 0,
+		"retries": { "bulk": 0, "search": 2 },
+		"throttled_millis": 0,
+		"requests_per_second": -1.0,
+		"throttled_until_millis": 0,
+		"failures": [
+			{
+				"index": "my-index-000001",
+				"id": "1",
+				"cause": { "type": "version_conflict_engine_exception", "reason": "[1]: version conflict" },
+				"status": 409
+			},
+			{
+				"shard": 0,
+				"index": "my-index-000001",
+				"node": "xyz",
+				"reason": { "type": "es_rejected_execution_exception" },
+				"status": 429
+			}
+		]
+	}`)
+	del, err := picker.DecodeDeleteByQueryResponse(bytes.NewReader(data))
+	assert.NoError(err)
+	assert.Equal(int64(147), del.Took)
+	assert.Equal(int64(119), del.Deleted)
+	assert.Equal(int64(2), del.Retries.Search)
+	assert.Equal(-1.0, del.RequestsPerSecond)
+	assert.Len(del.Failures, 2)
+	assert.Equal("1", del.Failures[0].ID)
+	assert.Equal(409, del.Failures[0].Status)
+	assert.Equal("version_conflict_engine_exception", del.Failures[0].Cause.Type)
+	assert.Equal(0, *del.Failures[1].Shard)
+	assert.Equal("es_rejected_execution_exception", del.Failures[1].Reason.Type)
+
+	upd, err := picker.DecodeUpdateByQueryResponse(bytes.NewReader([]byte(`{
+		"took": 12,
+		"total": 3,
+		"updated": 2,
+		"noops": 1,
+		"batches": 1,
+		"requests_per_second": 500.0,
+		"throttled_millis": 4,
+		"failures": []
+	}`)))
+	assert.NoError(err)
+	assert.Equal(int64(2), upd.Updated)
+	assert.Equal(int64(1), upd.Noops)
+	assert.Equal(int64(4), upd.ThrottledMillis)
+	assert.Empty(upd.Failures)
+}
@@ -1,14 +1,47 @@
 package picker
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/chanced/dynamic"
 )
 
 type SourceSpecifications struct {
-	Includes dynamic.StringOrArrayOfStrings
-	Excludes dynamic.StringOrArrayOfStrings
+	Includes dynamic.StringOrArrayOfStrings `json:"includes,omitempty"`
+	Excludes dynamic.StringOrArrayOfStrings `json:"excludes,omitempty"`
+}
+
+func (s SourceSpecifications) MarshalBSON() ([]byte, error) {
+	return s.MarshalJSON()
+}
+
+func (s SourceSpecifications) MarshalJSON() ([]byte, error) {
+	data := dynamic.JSONObject{}
+	if len(s.Includes) > 0 {
+		d, err := marshalStringOrArrayOfStrings(s.Includes)
+		if err != nil {
+			return nil, err
+		}
+		data["includes"] = d
+	}
+	if len(s.Excludes) > 0 {
+		d, err := marshalStringOrArrayOfStrings(s.Excludes)
+		if err != nil {
+			return nil, err
+		}
+		data["excludes"] = d
+	}
+	return json.Marshal(data)
+}
+
+// marshalStringOrArrayOfStrings encodes v as a string if it has a single
+// value, otherwise as an array.
+func marshalStringOrArrayOfStrings(v dynamic.StringOrArrayOfStrings) ([]byte, error) {
+	if len(v) == 1 {
+		return json.Marshal(v[0])
+	}
+	return json.Marshal([]string(v))
 }
 
 type SearchSourceParams struct{}
@@ -68,6 +101,14 @@ func (s *SearchSource) SetValue(v interface{}) error {
 		s.boolean = nil
 		s.wildcardPattern = nil
 		s.specifications = t
+	case SearchSource:
+		*s = t
+	case *SearchSource:
+		if t == nil {
+			*s = SearchSource{}
+			return nil
+		}
+		*s = *t
 	case bool:
 		s.boolean = &t
 		s.wildcardPattern = nil
@@ -97,3 +138,57 @@ func (s SearchSource) Value() interface{} {
 	}
 	return true
 }
+
+// IsEmpty returns true if no value has been set on s
+func (s *SearchSource) IsEmpty() bool {
+	return s == nil || (s.boolean == nil && s.specifications == nil && s.wildcardPattern == nil)
+}
+
+func (s SearchSource) MarshalBSON() ([]byte, error) {
+	return s.MarshalJSON()
+}
+
+func (s SearchSource) MarshalJSON() ([]byte, error) {
+	switch {
+	case s.boolean != nil:
+		return json.Marshal(*s.boolean)
+	case s.specifications != nil:
+		return s.specifications.MarshalJSON()
+	case s.wildcardPattern != nil:
+		return marshalStringOrArrayOfStrings(s.wildcardPattern)
+	}
+	return json.Marshal(true)
+}
+
+func (s *SearchSource) UnmarshalBSON(data []byte) error {
+	return s.UnmarshalJSON(data)
+}
+
+func (s *SearchSource) UnmarshalJSON(data []byte) error {
+	*s = SearchSource{}
+	d := dynamic.JSON(data)
+	switch {
+	case len(d) == 0 || d.IsNull():
+		return nil
+	case d.IsBool():
+		var b bool
+		err := json.Unmarshal(d, &b)
+		if err != nil {
+			return err
+		}
+		return s.SetValue(b)
+	case d.IsString(), d.IsArray():
+		var sas dynamic.StringOrArrayOfStrings
+		err := json.Unmarshal(d, &sas)
+		if err != nil {
+			return err
+		}
+		return s.SetValue(sas)
+	}
+	var spec SourceSpecifications
+	err := json.Unmarshal(d, &spec)
+	if err != nil {
+		return err
+	}
+	return s.SetValue(&spec)
+}
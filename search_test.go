@@ -1,9 +1,211 @@
 package picker_test
 
 import (
+	"encoding/json"
+	"errors"
 	"testing"
+	"time"
+
+	"github.com/chanced/cmpjson"
+	"github.com/chanced/picker"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSearch(t *testing.T) {
+	assert := require.New(t)
+	data := []byte(`{
+		"query": { "term": { "user.id": { "value": "kimchy" } } },
+		"sort": [{ "post_date": { "order": "asc" } }],
+		"aggs": { "tags": { "terms": { "field": "tags" } } },
+		"docvalue_fields": ["user.id", { "field": "date", "format": "epoch_millis" }],
+		"fields": ["title"],
+		"explain": true,
+		"from": 20,
+		"size": 5,
+		"indices_boost": { "my-index-000001": 1.4 },
+		"min_score": 0.5,
+		"pit": { "id": "46ToAwMDaWR5BXV1aWQy" },
+		"runtime_mappings": { "day_of_week": { "type": "keyword", "script": "emit('x')" } },
+		"seq_no_primary_term": true,
+		"_source": ["obj1.*", "obj2.*"],
+		"stats": ["group1"],
+		"terminate_after": 100,
+		"timeout": "90s",
+		"version": true,
+		"unknown_param": { "keep": "me" }
+	}`)
+	var s picker.Search
+	err := json.Unmarshal(data, &s)
+	assert.NoError(err)
+	assert.Equal("post_date", s.Sort()[0].Field)
+	assert.True(s.Explain())
+	assert.Equal(20, s.From())
+	assert.Equal(5, s.Size())
+	assert.Equal(0.5, s.MinScore())
+	assert.Equal("46ToAwMDaWR5BXV1aWQy", s.PITID())
+	assert.True(s.SeqNoPrimaryTerm())
+	assert.Equal(100, s.TerminateAfter())
+	assert.Equal(90*time.Second, s.Timeout())
+	assert.True(s.Version())
+	assert.Contains(s.Passthrough(), "unknown_param")
+
+	sd, err := json.Marshal(s)
+	assert.NoError(err)
+	assert.True(cmpjson.Equal(data, sd), cmpjson.Diff(data, sd))
+
+	s2, err := picker.NewSearch(picker.SearchParams{
+		Sort:    picker.Sort{{Field: "post_date", Order: picker.SortOrderAscending}},
+		Timeout: 2 * time.Minute,
+	})
+	assert.NoError(err)
+	sd2, err := json.Marshal(s2)
+	assert.NoError(err)
+	assert.JSONEq(`{"sort":[{"post_date":{"order":"asc"}}],"timeout":"2m"}`, string(sd2))
+
+	var ib picker.Search
+	err = json.Unmarshal([]byte(`{"indices_boost": [{ "my-alias": 1.4 }, { "my-index*": 1.3 }]}`), &ib)
+	assert.NoError(err)
+	assert.Equal(picker.IndicesBoost{{Index: "my-alias", Boost: 1.4}, {Index: "my-index*", Boost: 1.3}}, ib.IndicesBoost())
+	ibd, err := json.Marshal(ib)
+	assert.NoError(err)
+	assert.JSONEq(`{"indices_boost":[{"my-alias":1.4},{"my-index*":1.3}]}`, string(ibd))
+	err = json.Unmarshal([]byte(`{"indices_boost": { "b": 2, "a": 1 }}`), &ib)
+	assert.NoError(err)
+	assert.Equal("b", ib.IndicesBoost()[0].Index)
+	ibd, err = json.Marshal(ib)
+	assert.NoError(err)
+	assert.Equal(`{"indices_boost":{"b":2,"a":1}}`, string(ibd))
+
+	err = json.Unmarshal([]byte(`{"aggs": { "a": { "terms": { "field": "a" } } }, "aggregations": { "b": { "terms": { "field": "b" } } }}`), &ib)
+	assert.ErrorIs(err, picker.ErrMultipleAggregationKeys)
+
+	var saved picker.Search
+	err = json.Unmarshal([]byte(`{
+		"sort": ["_score", { "post_date": "desc" }],
+		"aggregations": { "tags": { "terms": { "field": "tags" } } }
+	}`), &saved)
+	assert.NoError(err)
+	sd, err = json.Marshal(saved)
+	assert.NoError(err)
+	assert.Equal(`{"aggregations":{"tags":{"terms":{"field":"tags"}}},"sort":["_score",{"post_date":"desc"}]}`, string(sd))
+	saved.Sort()[1].Order = picker.SortOrderAscending
+	sd, err = json.Marshal(saved)
+	assert.NoError(err)
+	assert.JSONEq(`{"aggregations":{"tags":{"terms":{"field":"tags"}}},"sort":[{"_score":{}},{"post_date":{"order":"asc"}}]}`, string(sd))
+
+	var s3 picker.Search
+	err = json.Unmarshal([]byte(`{"size": 0, "aggs": { "tags": { "terms": { "field": "tags" } } }}`), &s3)
+	assert.NoError(err)
+	assert.Equal(0, s3.Size())
+	sd3, err := json.Marshal(s3)
+	assert.NoError(err)
+	assert.JSONEq(`{"size":0,"aggs":{"tags":{"terms":{"field":"tags"}}}}`, string(sd3))
+	s4, err := picker.NewSearch(picker.SearchParams{Size: 0})
+	assert.NoError(err)
+	sd4, err := json.Marshal(s4)
+	assert.NoError(err)
+	assert.JSONEq(`{}`, string(sd4))
+	assert.NoError(s4.SetSize(0))
+	sd4, err = json.Marshal(s4)
+	assert.NoError(err)
+	assert.JSONEq(`{"size":0}`, string(sd4))
+	s4.ClearSize()
+	sd4, err = json.Marshal(s4)
+	assert.NoError(err)
+	assert.JSONEq(`{}`, string(sd4))
+
+	err = json.Unmarshal([]byte(`{"timeout": "10 fortnights"}`), &s)
+	assert.True(errors.Is(err, picker.ErrInvalidTimeValue))
+	var fe *picker.FieldError
+	assert.True(errors.As(err, &fe))
+	assert.Equal("timeout", fe.Field)
+}
+
+func TestSearchOptions(t *testing.T) {
+	assert := require.New(t)
+	data := []byte(`{
+		"query": { "term": { "user.id": { "value": "kimchy" } } },
+		"post_filter": { "term": { "color": { "value": "red" } } },
+		"script_fields": {
+			"test1": { "script": { "lang": "painless", "source": "doc['price'].value * 2" } },
+			"test2": {
+				"script": { "source": "doc['price'].value * params.factor", "params": { "factor": 2.0 } },
+				"ignore_failure": true
+			}
+		},
+		"stored_fields": ["user", "postDate"],
+		"track_total_hits": 100,
+		"track_scores": true,
+		"profile": true,
+		"_source": { "includes": ["obj1.*", "obj2.*"], "excludes": "*.description" },
+		"search_type": "dfs_query_then_fetch"
+	}`)
+	var s picker.Search
+	err := json.Unmarshal(data, &s)
+	assert.NoError(err)
+	assert.Empty(s.Passthrough())
+	assert.Len(s.ScriptFields(), 2)
+	assert.True(s.ScriptFields()["test2"].IgnoreFailure)
+	assert.Equal("doc['price'].value * 2", s.ScriptFields()["test1"].Script.Source)
+	assert.Equal([]string{"user", "postDate"}, s.StoredFields())
+	threshold, ok := s.TrackTotalHits().Threshold()
+	assert.True(ok)
+	assert.Equal(100, threshold)
+	assert.True(s.TrackScores())
+	assert.True(s.Profile())
+	assert.NotNil(s.PostFilter())
+	assert.NotNil(s.Source())
+	assert.Equal(picker.SearchTypeDFSQueryThenFetch, s.SearchType())
+
+	sd, err := json.Marshal(s)
+	assert.NoError(err)
+	// search_type is a request parameter and is not part of the body
+	expected := []byte(`{
+		"query": { "term": { "user.id": { "value": "kimchy" } } },
+		"post_filter": { "term": { "color": { "value": "red" } } },
+		"script_fields": {
+			"test1": { "script": { "lang": "painless", "source": "doc['price'].value * 2" } },
+			"test2": {
+				"script": { "source": "doc['price'].value * params.factor", "params": { "factor": 2.0 } },
+				"ignore_failure": true
+			}
+		},
+		"stored_fields": ["user", "postDate"],
+		"track_total_hits": 100,
+		"track_scores": true,
+		"profile": true,
+		"_source": { "includes": ["obj1.*", "obj2.*"], "excludes": "*.description" }
+	}`)
+	assert.True(cmpjson.Equal(expected, sd), cmpjson.Diff(expected, sd))
+
+	s2, err := picker.NewSearch(picker.SearchParams{
+		PostFilter: &picker.QueryParams{
+			Term: picker.TermQueryParams{Field: "color", Value: "red"},
+		},
+		StoredFields:   []string{"_none_"},
+		TrackTotalHits: true,
+		Source:         false,
+		SearchType:     picker.SearchTypeQueryThenFetch,
+	})
+	assert.NoError(err)
+	sd2, err := json.Marshal(s2)
+	assert.NoError(err)
+	expected2 := []byte(`{
+		"post_filter": { "term": { "color": { "value": "red" } } },
+		"stored_fields": ["_none_"],
+		"track_total_hits": true,
+		"_source": false
+	}`)
+	assert.True(cmpjson.Equal(expected2, sd2), cmpjson.Diff(expected2, sd2))
 
+	_, err = picker.NewSearch(picker.SearchParams{TrackTotalHits: -2})
+	assert.True(errors.Is(err, picker.ErrInvalidTrackTotalHits))
+	_, err = picker.NewSearch(picker.SearchParams{TrackTotalHits: "yes"})
+	assert.True(errors.Is(err, picker.ErrInvalidTrackTotalHits))
+	_, err = picker.NewSearch(picker.SearchParams{SearchType: "scan"})
+	assert.True(errors.Is(err, picker.ErrInvalidSearchType))
+	_, err = picker.NewSearch(picker.SearchParams{
+		ScriptFields: picker.ScriptFields{"test1": {}},
+	})
+	assert.True(errors.Is(err, picker.ErrScriptRequired))
 }
@@ -0,0 +1,34 @@
+package picker
+
+import "fmt"
+
+// SearchType controls how distributed term frequencies are calculated for
+// relevance scoring.
+//
+// search_type is a request parameter rather than part of the request body; it
+// is kept on Search so that it travels with the rest of the request.
+type SearchType string
+
+const (
+	SearchTypeUnspecified SearchType = ""
+	// SearchTypeQueryThenFetch calculates distributed term frequencies locally
+	// for each shard running the search. This is the default and is usually
+	// faster but less accurate.
+	SearchTypeQueryThenFetch SearchType = "query_then_fetch"
+	// SearchTypeDFSQueryThenFetch calculates distributed term frequencies
+	// globally, using information gathered from all shards running the
+	// search. This is more accurate but slower.
+	SearchTypeDFSQueryThenFetch SearchType = "dfs_query_then_fetch"
+)
+
+func (st SearchType) String() string {
+	return string(st)
+}
+
+func (st SearchType) Validate() error {
+	switch st {
+	case SearchTypeUnspecified, SearchTypeQueryThenFetch, SearchTypeDFSQueryThenFetch:
+		return nil
+	}
+	return fmt.Errorf("%w <%s>", ErrInvalidSearchType, st)
+}
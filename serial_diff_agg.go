@@ -0,0 +1,129 @@
+package picker
+
+// DefaultSerialDiffLag is the default lag of a serial_diff aggregation
+const DefaultSerialDiffLag = 1
+
+// SerialDiffAggParams creates a SerialDiffAgg, a pipeline aggregation where
+// values in a time series are subtracted from itself at different time lags or
+// periods.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-serialdiff-aggregation.html
+type SerialDiffAggParams struct {
+	// Path to the buckets to aggregate (Required)
+	BucketsPath string
+	// Policy to apply when gaps are found in the data. Defaults to "skip".
+	GapPolicy GapPolicy
+	// Format of the value_as_string in the response
+	Format string
+	// The historical bucket to subtract from the current value. Must be a
+	// positive, non-zero integer. Defaults to 1.
+	Lag  int
+	Meta map[string]interface{}
+}
+
+func (SerialDiffAggParams) Kind() AggKind {
+	return AggKindSerialDifferencing
+}
+
+func (p SerialDiffAggParams) Clause() (AggClause, error) {
+	return p.SerialDiff()
+}
+
+func (p SerialDiffAggParams) SerialDiff() (*SerialDiffAgg, error) {
+	a := &SerialDiffAgg{}
+	err := a.SetBucketsPath(p.BucketsPath)
+	if err != nil {
+		return a, newAggError(err, AggKindSerialDifferencing)
+	}
+	err = a.SetGapPolicy(p.GapPolicy)
+	if err != nil {
+		return a, newAggError(err, AggKindSerialDifferencing)
+	}
+	a.SetFormat(p.Format)
+	err = a.SetLag(p.Lag)
+	if err != nil {
+		return a, newAggError(err, AggKindSerialDifferencing)
+	}
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// SerialDiffAgg is a pipeline aggregation where values in a time series are
+// subtracted from itself at different time lags or periods.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-serialdiff-aggregation.html
+type SerialDiffAgg struct {
+	bucketsPathParam
+	gapPolicyParam
+	pipelineFormatParam
+	lag int
+	noAggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*SerialDiffAgg)(nil)
+
+func (SerialDiffAgg) Kind() AggKind {
+	return AggKindSerialDifferencing
+}
+
+func (a *SerialDiffAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// Lag is the historical bucket to subtract from the current value. Defaults to
+// 1.
+func (a SerialDiffAgg) Lag() int {
+	if a.lag == 0 {
+		return DefaultSerialDiffLag
+	}
+	return a.lag
+}
+
+// SetLag sets lag to v
+func (a *SerialDiffAgg) SetLag(v int) error {
+	if v < 0 {
+		return ErrInvalidLag
+	}
+	a.lag = v
+	return nil
+}
+
+func (a SerialDiffAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a SerialDiffAgg) MarshalJSON() ([]byte, error) {
+	return serialDiffAgg{
+		BucketsPath: a.bucketsPath,
+		GapPolicy:   a.gapPolicy,
+		Format:      a.format,
+		Lag:         a.lag,
+	}.MarshalJSON()
+}
+
+func (a *SerialDiffAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *SerialDiffAgg) UnmarshalJSON(data []byte) error {
+	*a = SerialDiffAgg{}
+	p := serialDiffAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.bucketsPath = p.BucketsPath
+	a.gapPolicy = p.GapPolicy
+	a.SetFormat(p.Format)
+	a.lag = p.Lag
+	return nil
+}
+
+//easyjson:json
+type serialDiffAgg struct {
+	BucketsPath string    `json:"buckets_path"`
+	GapPolicy   GapPolicy `json:"gap_policy,omitempty"`
+	Format      string    `json:"format,omitempty"`
+	Lag         int       `json:"lag,omitempty"`
+}
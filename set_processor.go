@@ -0,0 +1,223 @@
+package picker
+
+import "github.com/chanced/dynamic"
+
+// SetProcessorParams creates a SetProcessor, which sets one field and
+// associates it with the specified value. If the field already exists, its
+// value will be replaced with the provided one.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/set-processor.html
+type SetProcessorParams struct {
+	// Field is the field to insert, upsert, or update. Supports template
+	// snippets.
+	Field string
+	// Value is the value to be set for the field. Supports template snippets.
+	// Either Value or CopyFrom is required.
+	Value interface{}
+	// CopyFrom is the origin field which will be copied to field. Either Value
+	// or CopyFrom is required.
+	CopyFrom string
+	// Override indicates whether the processor will update fields with
+	// pre-existing non-null-valued field. Defaults to true.
+	Override interface{}
+	// If IgnoreEmptyValue is true and value is a template snippet that
+	// evaluates to null or the empty string, the processor quietly exits
+	// without modifying the document
+	IgnoreEmptyValue bool
+	// MediaType is the media type for encoding value. Applies only when value
+	// is a template snippet. Defaults to "application/json".
+	MediaType string
+	// Description of the processor. Useful for describing the purpose of the
+	// processor or its configuration.
+	Description string
+	// Conditionally execute the processor
+	If string
+	// Ignore failures for the processor
+	IgnoreFailure bool
+	// Handle failures for the processor
+	OnFailure Procs
+	// Identifier for the processor. Useful for debugging and metrics.
+	Tag string
+}
+
+func (SetProcessorParams) Kind() ProcessorKind {
+	return ProcessorKindSet
+}
+
+func (p SetProcessorParams) Processor() (Processor, error) {
+	return p.Set()
+}
+
+func (p SetProcessorParams) Set() (*SetProcessor, error) {
+	proc := &SetProcessor{}
+	if p.Value == nil && len(p.CopyFrom) == 0 {
+		return proc, newProcessorError(ErrValueOrCopyFromRequired, ProcessorKindSet)
+	}
+	err := proc.SetField(p.Field)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindSet)
+	}
+	proc.SetValue(p.Value)
+	proc.SetCopyFrom(p.CopyFrom)
+	err = proc.SetOverride(p.Override)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindSet)
+	}
+	proc.SetIgnoreEmptyValue(p.IgnoreEmptyValue)
+	proc.SetMediaType(p.MediaType)
+	proc.SetDescription(p.Description)
+	proc.SetIf(p.If)
+	proc.SetIgnoreFailure(p.IgnoreFailure)
+	err = proc.SetOnFailure(p.OnFailure)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindSet)
+	}
+	proc.SetTag(p.Tag)
+	return proc, nil
+}
+
+// SetProcessor sets one field and associates it with the specified value. If
+// the field already exists, its value will be replaced with the provided one.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/set-processor.html
+type SetProcessor struct {
+	fieldParam
+	value            interface{}
+	copyFrom         string
+	override         dynamic.Bool
+	ignoreEmptyValue bool
+	mediaType        string
+	processorParams
+}
+
+var _ Processor = (*SetProcessor)(nil)
+
+func (SetProcessor) Kind() ProcessorKind {
+	return ProcessorKindSet
+}
+
+func (p *SetProcessor) Processor() (Processor, error) {
+	return p, nil
+}
+
+// Value is the value to be set for the field. Supports template snippets.
+// Either Value or CopyFrom is required.
+func (p SetProcessor) Value() interface{} {
+	return p.value
+}
+
+// SetValue sets value to v
+func (p *SetProcessor) SetValue(v interface{}) {
+	p.value = v
+}
+
+// CopyFrom is the origin field which will be copied to field. Either Value or
+// CopyFrom is required.
+func (p SetProcessor) CopyFrom() string {
+	return p.copyFrom
+}
+
+// SetCopyFrom sets copy_from to v
+func (p *SetProcessor) SetCopyFrom(v string) {
+	p.copyFrom = v
+}
+
+// Override indicates whether the processor will update fields with pre-existing
+// non-null-valued field. Defaults to true.
+func (p SetProcessor) Override() bool {
+	if v, ok := p.override.Bool(); ok {
+		return v
+	}
+	return true
+}
+
+// SetOverride sets override to v
+func (p *SetProcessor) SetOverride(v interface{}) error {
+	return p.override.Set(v)
+}
+
+// If IgnoreEmptyValue is true and value is a template snippet that evaluates to
+// null or the empty string, the processor quietly exits without modifying the
+// document
+func (p SetProcessor) IgnoreEmptyValue() bool {
+	return p.ignoreEmptyValue
+}
+
+// SetIgnoreEmptyValue sets ignore_empty_value to v
+func (p *SetProcessor) SetIgnoreEmptyValue(v bool) {
+	p.ignoreEmptyValue = v
+}
+
+// MediaType is the media type for encoding value. Applies only when value is a
+// template snippet. Defaults to "application/json".
+func (p SetProcessor) MediaType() string {
+	return p.mediaType
+}
+
+// SetMediaType sets media_type to v
+func (p *SetProcessor) SetMediaType(v string) {
+	p.mediaType = v
+}
+
+func (p SetProcessor) MarshalBSON() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+func (p SetProcessor) MarshalJSON() ([]byte, error) {
+	return setProcessor{
+		Field:            p.field,
+		Value:            p.value,
+		CopyFrom:         p.copyFrom,
+		Override:         p.override.Value(),
+		IgnoreEmptyValue: p.ignoreEmptyValue,
+		MediaType:        p.mediaType,
+		Description:      p.description,
+		If:               p.ifCond,
+		IgnoreFailure:    p.ignoreFailure,
+		OnFailure:        p.onFailure,
+		Tag:              p.tag,
+	}.MarshalJSON()
+}
+
+func (p *SetProcessor) UnmarshalBSON(data []byte) error {
+	return p.UnmarshalJSON(data)
+}
+
+func (p *SetProcessor) UnmarshalJSON(data []byte) error {
+	*p = SetProcessor{}
+	var v setProcessor
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	p.field = v.Field
+	p.value = v.Value
+	p.copyFrom = v.CopyFrom
+	err = p.override.Set(v.Override)
+	if err != nil {
+		return err
+	}
+	p.ignoreEmptyValue = v.IgnoreEmptyValue
+	p.mediaType = v.MediaType
+	p.description = v.Description
+	p.ifCond = v.If
+	p.ignoreFailure = v.IgnoreFailure
+	p.onFailure = v.OnFailure
+	p.tag = v.Tag
+	return nil
+}
+
+//easyjson:json
+type setProcessor struct {
+	Field            string      `json:"field,omitempty"`
+	Value            interface{} `json:"value,omitempty"`
+	CopyFrom         string      `json:"copy_from,omitempty"`
+	Override         interface{} `json:"override,omitempty"`
+	IgnoreEmptyValue bool        `json:"ignore_empty_value,omitempty"`
+	MediaType        string      `json:"media_type,omitempty"`
+	Description      string      `json:"description,omitempty"`
+	If               string      `json:"if,omitempty"`
+	IgnoreFailure    bool        `json:"ignore_failure,omitempty"`
+	OnFailure        Processors  `json:"on_failure,omitempty"`
+	Tag              string      `json:"tag,omitempty"`
+}
@@ -0,0 +1,133 @@
+package picker
+
+// SetSecurityUserProcessorParams creates a SetSecurityUserProcessor, which sets
+// user-related details (such as username, roles, email, full_name, metadata,
+// api_key, realm and authentication_type) from the current authenticated user
+// to the current document by pre-processing the ingest.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/ingest-node-set-security-user-processor.html
+type SetSecurityUserProcessorParams struct {
+	// Field is the field to store the user information into
+	Field string
+	// Properties controls which user related properties are added to the field.
+	// Defaults to all properties.
+	Properties []string
+	// Description of the processor. Useful for describing the purpose of the
+	// processor or its configuration.
+	Description string
+	// Conditionally execute the processor
+	If string
+	// Ignore failures for the processor
+	IgnoreFailure bool
+	// Handle failures for the processor
+	OnFailure Procs
+	// Identifier for the processor. Useful for debugging and metrics.
+	Tag string
+}
+
+func (SetSecurityUserProcessorParams) Kind() ProcessorKind {
+	return ProcessorKindSetSecurityUser
+}
+
+func (p SetSecurityUserProcessorParams) Processor() (Processor, error) {
+	return p.SetSecurityUser()
+}
+
+func (p SetSecurityUserProcessorParams) SetSecurityUser() (*SetSecurityUserProcessor, error) {
+	proc := &SetSecurityUserProcessor{}
+	err := proc.SetField(p.Field)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindSetSecurityUser)
+	}
+	proc.SetProperties(p.Properties)
+	proc.SetDescription(p.Description)
+	proc.SetIf(p.If)
+	proc.SetIgnoreFailure(p.IgnoreFailure)
+	err = proc.SetOnFailure(p.OnFailure)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindSetSecurityUser)
+	}
+	proc.SetTag(p.Tag)
+	return proc, nil
+}
+
+// SetSecurityUserProcessor sets user-related details (such as username, roles,
+// email, full_name, metadata, api_key, realm and authentication_type) from the
+// current authenticated user to the current document by pre-processing the
+// ingest.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/ingest-node-set-security-user-processor.html
+type SetSecurityUserProcessor struct {
+	fieldParam
+	properties []string
+	processorParams
+}
+
+var _ Processor = (*SetSecurityUserProcessor)(nil)
+
+func (SetSecurityUserProcessor) Kind() ProcessorKind {
+	return ProcessorKindSetSecurityUser
+}
+
+func (p *SetSecurityUserProcessor) Processor() (Processor, error) {
+	return p, nil
+}
+
+// Properties controls which user related properties are added to the field.
+// Defaults to all properties.
+func (p SetSecurityUserProcessor) Properties() []string {
+	return p.properties
+}
+
+// SetProperties sets properties to v
+func (p *SetSecurityUserProcessor) SetProperties(v []string) {
+	p.properties = v
+}
+
+func (p SetSecurityUserProcessor) MarshalBSON() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+func (p SetSecurityUserProcessor) MarshalJSON() ([]byte, error) {
+	return setSecurityUserProcessor{
+		Field:         p.field,
+		Properties:    p.properties,
+		Description:   p.description,
+		If:            p.ifCond,
+		IgnoreFailure: p.ignoreFailure,
+		OnFailure:     p.onFailure,
+		Tag:           p.tag,
+	}.MarshalJSON()
+}
+
+func (p *SetSecurityUserProcessor) UnmarshalBSON(data []byte) error {
+	return p.UnmarshalJSON(data)
+}
+
+func (p *SetSecurityUserProcessor) UnmarshalJSON(data []byte) error {
+	*p = SetSecurityUserProcessor{}
+	var v setSecurityUserProcessor
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	p.field = v.Field
+	p.properties = v.Properties
+	p.description = v.Description
+	p.ifCond = v.If
+	p.ignoreFailure = v.IgnoreFailure
+	p.onFailure = v.OnFailure
+	p.tag = v.Tag
+	return nil
+}
+
+//easyjson:json
+type setSecurityUserProcessor struct {
+	Field         string     `json:"field,omitempty"`
+	Properties    []string   `json:"properties,omitempty"`
+	Description   string     `json:"description,omitempty"`
+	If            string     `json:"if,omitempty"`
+	IgnoreFailure bool       `json:"ignore_failure,omitempty"`
+	OnFailure     Processors `json:"on_failure,omitempty"`
+	Tag           string     `json:"tag,omitempty"`
+}
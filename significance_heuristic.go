@@ -0,0 +1,294 @@
+package picker
+
+import (
+	"fmt"
+
+	"github.com/chanced/dynamic"
+)
+
+// SignificanceHeuristicKind is the scoring heuristic used by the
+// significant_terms and significant_text aggregations.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-significantterms-aggregation.html#_parameters_5
+type SignificanceHeuristicKind string
+
+const (
+	SignificanceHeuristicKindUnspecified       SignificanceHeuristicKind = ""
+	SignificanceHeuristicKindJLH               SignificanceHeuristicKind = "jlh"
+	SignificanceHeuristicKindMutualInformation SignificanceHeuristicKind = "mutual_information"
+	SignificanceHeuristicKindChiSquare         SignificanceHeuristicKind = "chi_square"
+	SignificanceHeuristicKindGND               SignificanceHeuristicKind = "gnd"
+	SignificanceHeuristicKindPercentage        SignificanceHeuristicKind = "percentage"
+	SignificanceHeuristicKindScript            SignificanceHeuristicKind = "script_heuristic"
+)
+
+func (k SignificanceHeuristicKind) String() string {
+	return string(k)
+}
+
+func (k SignificanceHeuristicKind) IsValid() bool {
+	switch k {
+	case SignificanceHeuristicKindJLH, SignificanceHeuristicKindMutualInformation,
+		SignificanceHeuristicKindChiSquare, SignificanceHeuristicKindGND,
+		SignificanceHeuristicKindPercentage, SignificanceHeuristicKindScript:
+		return true
+	}
+	return false
+}
+
+// SignificanceHeuristic configures how significant terms are scored. Defaults
+// to JLH when unset.
+type SignificanceHeuristic struct {
+	Kind SignificanceHeuristicKind
+	// IncludeNegatives filters out terms that appear less often in the subset
+	// than outside of it. Applies to mutual_information and chi_square.
+	IncludeNegatives bool
+	// BackgroundIsSuperset should be set to false if a custom background
+	// filter which does not contain the foreground set is used. Applies to
+	// mutual_information, chi_square, and gnd.
+	BackgroundIsSuperset *bool
+	// Script is required for script_heuristic
+	Script *Script
+}
+
+func (h *SignificanceHeuristic) IsEmpty() bool {
+	return h == nil || len(h.Kind) == 0
+}
+
+// Validate returns an error if the heuristic's Kind is invalid or if a
+// script_heuristic is missing its Script.
+func (h *SignificanceHeuristic) Validate() error {
+	if h.IsEmpty() {
+		return nil
+	}
+	if !h.Kind.IsValid() {
+		return fmt.Errorf("%w <%s>", ErrInvalidHeuristic, h.Kind)
+	}
+	if h.Kind == SignificanceHeuristicKindScript && h.Script == nil {
+		return ErrScriptRequired
+	}
+	return nil
+}
+
+func (h *SignificanceHeuristic) params() *significanceHeuristicParams {
+	p := &significanceHeuristicParams{}
+	switch h.Kind {
+	case SignificanceHeuristicKindMutualInformation, SignificanceHeuristicKindChiSquare:
+		p.IncludeNegatives = h.IncludeNegatives
+		p.BackgroundIsSuperset = h.BackgroundIsSuperset
+	case SignificanceHeuristicKindGND:
+		p.BackgroundIsSuperset = h.BackgroundIsSuperset
+	case SignificanceHeuristicKindScript:
+		p.Script = h.Script
+	}
+	return p
+}
+
+// significanceHeuristicFields are the heuristic keys of significant_terms and
+// significant_text. Only one should be set.
+//
+//easyjson:json
+type significanceHeuristicFields struct {
+	JLH               *significanceHeuristicParams `json:"jlh,omitempty"`
+	MutualInformation *significanceHeuristicParams `json:"mutual_information,omitempty"`
+	ChiSquare         *significanceHeuristicParams `json:"chi_square,omitempty"`
+	GND               *significanceHeuristicParams `json:"gnd,omitempty"`
+	Percentage        *significanceHeuristicParams `json:"percentage,omitempty"`
+	ScriptHeuristic   *significanceHeuristicParams `json:"script_heuristic,omitempty"`
+}
+
+func newSignificanceHeuristicFields(h *SignificanceHeuristic) significanceHeuristicFields {
+	f := significanceHeuristicFields{}
+	if h.IsEmpty() {
+		return f
+	}
+	p := h.params()
+	switch h.Kind {
+	case SignificanceHeuristicKindJLH:
+		f.JLH = p
+	case SignificanceHeuristicKindMutualInformation:
+		f.MutualInformation = p
+	case SignificanceHeuristicKindChiSquare:
+		f.ChiSquare = p
+	case SignificanceHeuristicKindGND:
+		f.GND = p
+	case SignificanceHeuristicKindPercentage:
+		f.Percentage = p
+	case SignificanceHeuristicKindScript:
+		f.ScriptHeuristic = p
+	}
+	return f
+}
+
+func (f significanceHeuristicFields) heuristic() *SignificanceHeuristic {
+	var kind SignificanceHeuristicKind
+	var p *significanceHeuristicParams
+	switch {
+	case f.JLH != nil:
+		kind, p = SignificanceHeuristicKindJLH, f.JLH
+	case f.MutualInformation != nil:
+		kind, p = SignificanceHeuristicKindMutualInformation, f.MutualInformation
+	case f.ChiSquare != nil:
+		kind, p = SignificanceHeuristicKindChiSquare, f.ChiSquare
+	case f.GND != nil:
+		kind, p = SignificanceHeuristicKindGND, f.GND
+	case f.Percentage != nil:
+		kind, p = SignificanceHeuristicKindPercentage, f.Percentage
+	case f.ScriptHeuristic != nil:
+		kind, p = SignificanceHeuristicKindScript, f.ScriptHeuristic
+	default:
+		return nil
+	}
+	return &SignificanceHeuristic{
+		Kind:                 kind,
+		IncludeNegatives:     p.IncludeNegatives,
+		BackgroundIsSuperset: p.BackgroundIsSuperset,
+		Script:               p.Script,
+	}
+}
+
+//easyjson:json
+type significanceHeuristicParams struct {
+	IncludeNegatives     bool    `json:"include_negatives,omitempty"`
+	BackgroundIsSuperset *bool   `json:"background_is_superset,omitempty"`
+	Script               *Script `json:"script,omitempty"`
+}
+
+// significanceParam holds the parameters shared by significant_terms and
+// significant_text
+type significanceParam struct {
+	backgroundFilter *Query
+	heuristic        *SignificanceHeuristic
+	size             int
+	shardSize        int
+	minDocCount      dynamic.Number
+	shardMinDocCount dynamic.Number
+	include          *TermsInclude
+	exclude          *TermsInclude
+}
+
+// BackgroundFilter narrows the background set used for statistical
+// comparisons. Defaults to all documents in the index.
+func (s significanceParam) BackgroundFilter() *Query {
+	return s.backgroundFilter
+}
+
+// SetBackgroundFilter sets background_filter to v
+func (s *significanceParam) SetBackgroundFilter(v Querier) error {
+	if v == nil {
+		s.backgroundFilter = nil
+		return nil
+	}
+	q, err := v.Query()
+	if err != nil {
+		return err
+	}
+	if q.IsEmpty() {
+		s.backgroundFilter = nil
+		return nil
+	}
+	s.backgroundFilter = q
+	return nil
+}
+
+// SignificanceHeuristic is the scoring heuristic. Defaults to JLH.
+func (s significanceParam) SignificanceHeuristic() *SignificanceHeuristic {
+	return s.heuristic
+}
+
+// SetSignificanceHeuristic sets the scoring heuristic to v
+func (s *significanceParam) SetSignificanceHeuristic(v *SignificanceHeuristic) error {
+	if v.IsEmpty() {
+		s.heuristic = nil
+		return nil
+	}
+	err := v.Validate()
+	if err != nil {
+		return err
+	}
+	s.heuristic = v
+	return nil
+}
+
+// Size is the number of term buckets to return. Defaults to 10.
+func (s significanceParam) Size() int {
+	if s.size == 0 {
+		return DefaultTermsAggSize
+	}
+	return s.size
+}
+
+// SetSize sets the number of term buckets to return to v
+func (s *significanceParam) SetSize(v int) {
+	s.size = v
+}
+
+// ShardSize is the number of terms each shard returns.
+func (s significanceParam) ShardSize() int {
+	return s.shardSize
+}
+
+// SetShardSize sets the shard_size to v
+func (s *significanceParam) SetShardSize(v int) {
+	s.shardSize = v
+}
+
+// MinDocCount is the minimum number of hits a term must match to be returned.
+// Defaults to 3.
+func (s significanceParam) MinDocCount() int {
+	if i, ok := s.minDocCount.Int(); ok {
+		return i
+	}
+	return DefaultSignificantTermsMinDocCount
+}
+
+// SetMinDocCount sets min_doc_count to v
+func (s *significanceParam) SetMinDocCount(v interface{}) error {
+	return s.minDocCount.Set(v)
+}
+
+// ShardMinDocCount is the minimum number of documents a term must be present in
+// on a shard to be considered for the final results.
+func (s significanceParam) ShardMinDocCount() int {
+	if i, ok := s.shardMinDocCount.Int(); ok {
+		return i
+	}
+	return 0
+}
+
+// SetShardMinDocCount sets shard_min_doc_count to v
+func (s *significanceParam) SetShardMinDocCount(v interface{}) error {
+	return s.shardMinDocCount.Set(v)
+}
+
+// Include filters which terms buckets are created for.
+func (s significanceParam) Include() *TermsInclude {
+	return s.include
+}
+
+// SetInclude sets include to v
+func (s *significanceParam) SetInclude(v *TermsInclude) {
+	if v.IsEmpty() {
+		s.include = nil
+		return
+	}
+	s.include = v
+}
+
+// Exclude filters which terms buckets are not created for.
+func (s significanceParam) Exclude() *TermsInclude {
+	return s.exclude
+}
+
+// SetExclude sets exclude to v. Partitioning is not supported by exclude.
+func (s *significanceParam) SetExclude(v *TermsInclude) error {
+	if v.IsEmpty() {
+		s.exclude = nil
+		return nil
+	}
+	if v.isPartitioned() {
+		return ErrInvalidExclude
+	}
+	s.exclude = v
+	return nil
+}
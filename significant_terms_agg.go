@@ -0,0 +1,177 @@
+package picker
+
+const DefaultSignificantTermsMinDocCount = 3
+
+// SignificantTermsAggParams creates a SignificantTermsAgg, an aggregation that
+// returns interesting or unusual occurrences of terms in a set.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-significantterms-aggregation.html
+type SignificantTermsAggParams struct {
+	// The field to aggregate on (Required)
+	Field string
+	// Narrows the background set used for statistical comparisons. Defaults to
+	// all documents in the index.
+	BackgroundFilter Querier
+	// The scoring heuristic. Defaults to JLH.
+	SignificanceHeuristic *SignificanceHeuristic
+	// The number of term buckets to return. Defaults to 10.
+	Size int
+	// The number of terms each shard should return.
+	ShardSize int
+	// Only return terms that match more than the configured number of hits.
+	// Defaults to 3.
+	MinDocCount interface{}
+	// The minimum number of documents a term must be present in on a shard to
+	// be considered for the final results.
+	ShardMinDocCount interface{}
+	// Filter which terms buckets are created for.
+	Include *TermsInclude
+	// Filter which terms buckets are not created for.
+	Exclude *TermsInclude
+	// Either "map" or "global_ordinals"
+	ExecutionHint string
+	// Sub-aggregations
+	Aggregations Aggs
+	Meta         map[string]interface{}
+}
+
+func (SignificantTermsAggParams) Kind() AggKind {
+	return AggKindSignificantTerms
+}
+
+func (p SignificantTermsAggParams) Clause() (AggClause, error) {
+	return p.SignificantTerms()
+}
+
+func (p SignificantTermsAggParams) SignificantTerms() (*SignificantTermsAgg, error) {
+	a := &SignificantTermsAgg{}
+	err := a.SetField(p.Field)
+	if err != nil {
+		return a, newAggError(err, AggKindSignificantTerms)
+	}
+	err = a.SetBackgroundFilter(p.BackgroundFilter)
+	if err != nil {
+		return a, newAggError(err, AggKindSignificantTerms)
+	}
+	err = a.SetSignificanceHeuristic(p.SignificanceHeuristic)
+	if err != nil {
+		return a, newAggError(err, AggKindSignificantTerms)
+	}
+	a.SetSize(p.Size)
+	a.SetShardSize(p.ShardSize)
+	err = a.SetMinDocCount(p.MinDocCount)
+	if err != nil {
+		return a, newAggError(err, AggKindSignificantTerms)
+	}
+	err = a.SetShardMinDocCount(p.ShardMinDocCount)
+	if err != nil {
+		return a, newAggError(err, AggKindSignificantTerms)
+	}
+	a.SetInclude(p.Include)
+	err = a.SetExclude(p.Exclude)
+	if err != nil {
+		return a, newAggError(err, AggKindSignificantTerms)
+	}
+	a.SetExecutionHint(p.ExecutionHint)
+	err = a.SetAggregations(p.Aggregations)
+	if err != nil {
+		return a, newAggError(err, AggKindSignificantTerms)
+	}
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// SignificantTermsAgg is an aggregation that returns interesting or unusual
+// occurrences of terms in a set.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-significantterms-aggregation.html
+type SignificantTermsAgg struct {
+	fieldParam
+	significanceParam
+	executionHint string
+	aggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*SignificantTermsAgg)(nil)
+
+func (SignificantTermsAgg) Kind() AggKind {
+	return AggKindSignificantTerms
+}
+
+func (a *SignificantTermsAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// ExecutionHint is either "map" or "global_ordinals"
+func (a SignificantTermsAgg) ExecutionHint() string {
+	return a.executionHint
+}
+
+// SetExecutionHint sets execution_hint to v
+func (a *SignificantTermsAgg) SetExecutionHint(v string) {
+	a.executionHint = v
+}
+
+func (a SignificantTermsAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a SignificantTermsAgg) MarshalJSON() ([]byte, error) {
+	return significantTermsAgg{
+		Field:                       a.field,
+		BackgroundFilter:            a.backgroundFilter,
+		significanceHeuristicFields: newSignificanceHeuristicFields(a.heuristic),
+		Size:                        a.size,
+		ShardSize:                   a.shardSize,
+		MinDocCount:                 a.minDocCount.Value(),
+		ShardMinDocCount:            a.shardMinDocCount.Value(),
+		Include:                     a.include,
+		Exclude:                     a.exclude,
+		ExecutionHint:               a.executionHint,
+	}.MarshalJSON()
+}
+
+func (a *SignificantTermsAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *SignificantTermsAgg) UnmarshalJSON(data []byte) error {
+	*a = SignificantTermsAgg{}
+	p := significantTermsAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.field = p.Field
+	a.backgroundFilter = p.BackgroundFilter
+	a.heuristic = p.significanceHeuristicFields.heuristic()
+	a.SetSize(p.Size)
+	a.SetShardSize(p.ShardSize)
+	err = a.SetMinDocCount(p.MinDocCount)
+	if err != nil {
+		return err
+	}
+	err = a.SetShardMinDocCount(p.ShardMinDocCount)
+	if err != nil {
+		return err
+	}
+	a.SetInclude(p.Include)
+	a.exclude = p.Exclude
+	a.SetExecutionHint(p.ExecutionHint)
+	return nil
+}
+
+//easyjson:json
+type significantTermsAgg struct {
+	Field            string `json:"field"`
+	BackgroundFilter *Query `json:"background_filter,omitempty"`
+	significanceHeuristicFields
+	Size             int           `json:"size,omitempty"`
+	ShardSize        int           `json:"shard_size,omitempty"`
+	MinDocCount      interface{}   `json:"min_doc_count,omitempty"`
+	ShardMinDocCount interface{}   `json:"shard_min_doc_count,omitempty"`
+	Include          *TermsInclude `json:"include,omitempty"`
+	Exclude          *TermsInclude `json:"exclude,omitempty"`
+	ExecutionHint    string        `json:"execution_hint,omitempty"`
+}
@@ -0,0 +1,50 @@
+package picker_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/chanced/cmpjson"
+	"github.com/chanced/picker"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignificantTermsAgg(t *testing.T) {
+	assert := require.New(t)
+	data := []byte(`{
+		"significant_terms": {
+			"field": "crime_type",
+			"background_filter": { "term": { "force": { "value": "British Transport Police" } } },
+			"chi_square": { "include_negatives": true, "background_is_superset": false },
+			"min_doc_count": 10
+		}
+	}`)
+	var aggs picker.Aggregations
+	err := json.Unmarshal([]byte(`{"crimes":`+string(data)+`}`), &aggs)
+	assert.NoError(err)
+	a := aggs.Get("crimes").(*picker.SignificantTermsAgg)
+	h := a.SignificanceHeuristic()
+	assert.Equal(picker.SignificanceHeuristicKindChiSquare, h.Kind)
+	assert.True(h.IncludeNegatives)
+	assert.NotNil(h.BackgroundIsSuperset)
+	assert.False(*h.BackgroundIsSuperset)
+	assert.Equal(10, a.MinDocCount())
+	assert.NotNil(a.BackgroundFilter().Term())
+
+	sd, err := json.Marshal(aggs)
+	assert.NoError(err)
+	expected := []byte(`{"crimes":` + string(data) + `}`)
+	assert.True(cmpjson.Equal(expected, sd), cmpjson.Diff(expected, sd))
+
+	_, err = picker.SignificantTermsAggParams{
+		Field:                 "crime_type",
+		SignificanceHeuristic: &picker.SignificanceHeuristic{Kind: picker.SignificanceHeuristicKindScript},
+	}.SignificantTerms()
+	assert.True(errors.Is(err, picker.ErrScriptRequired))
+	_, err = picker.SignificantTermsAggParams{
+		Field:                 "crime_type",
+		SignificanceHeuristic: &picker.SignificanceHeuristic{Kind: "nope"},
+	}.SignificantTerms()
+	assert.True(errors.Is(err, picker.ErrInvalidHeuristic))
+}
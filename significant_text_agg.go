@@ -0,0 +1,196 @@
+package picker
+
+// SignificantTextAggParams creates a SignificantTextAgg, an aggregation that
+// returns interesting or unusual occurrences of free-text terms in a set. It
+// is like the significant terms aggregation but differs in that it is
+// designed for use on text fields and does not require field data.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-significanttext-aggregation.html
+type SignificantTextAggParams struct {
+	// The text field to aggregate on (Required)
+	Field string
+	// If true, filters out duplicate or near-duplicate text from the analysis.
+	FilterDuplicateText bool
+	// Fields of the _source to analyze instead of Field, useful when the
+	// indexed field is a copy_to target.
+	SourceFields []string
+	// Narrows the background set used for statistical comparisons. Defaults to
+	// all documents in the index.
+	BackgroundFilter Querier
+	// The scoring heuristic. Defaults to JLH.
+	SignificanceHeuristic *SignificanceHeuristic
+	// The number of term buckets to return. Defaults to 10.
+	Size int
+	// The number of terms each shard should return.
+	ShardSize int
+	// Only return terms that match more than the configured number of hits.
+	// Defaults to 3.
+	MinDocCount interface{}
+	// The minimum number of documents a term must be present in on a shard to
+	// be considered for the final results.
+	ShardMinDocCount interface{}
+	// Filter which terms buckets are created for.
+	Include *TermsInclude
+	// Filter which terms buckets are not created for.
+	Exclude *TermsInclude
+	// Sub-aggregations
+	Aggregations Aggs
+	Meta         map[string]interface{}
+}
+
+func (SignificantTextAggParams) Kind() AggKind {
+	return AggKindSignificantText
+}
+
+func (p SignificantTextAggParams) Clause() (AggClause, error) {
+	return p.SignificantText()
+}
+
+func (p SignificantTextAggParams) SignificantText() (*SignificantTextAgg, error) {
+	a := &SignificantTextAgg{}
+	err := a.SetField(p.Field)
+	if err != nil {
+		return a, newAggError(err, AggKindSignificantText)
+	}
+	a.SetFilterDuplicateText(p.FilterDuplicateText)
+	a.SetSourceFields(p.SourceFields)
+	err = a.SetBackgroundFilter(p.BackgroundFilter)
+	if err != nil {
+		return a, newAggError(err, AggKindSignificantText)
+	}
+	err = a.SetSignificanceHeuristic(p.SignificanceHeuristic)
+	if err != nil {
+		return a, newAggError(err, AggKindSignificantText)
+	}
+	a.SetSize(p.Size)
+	a.SetShardSize(p.ShardSize)
+	err = a.SetMinDocCount(p.MinDocCount)
+	if err != nil {
+		return a, newAggError(err, AggKindSignificantText)
+	}
+	err = a.SetShardMinDocCount(p.ShardMinDocCount)
+	if err != nil {
+		return a, newAggError(err, AggKindSignificantText)
+	}
+	a.SetInclude(p.Include)
+	err = a.SetExclude(p.Exclude)
+	if err != nil {
+		return a, newAggError(err, AggKindSignificantText)
+	}
+	err = a.SetAggregations(p.Aggregations)
+	if err != nil {
+		return a, newAggError(err, AggKindSignificantText)
+	}
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// SignificantTextAgg is an aggregation that returns interesting or unusual
+// occurrences of free-text terms in a set.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-significanttext-aggregation.html
+type SignificantTextAgg struct {
+	fieldParam
+	filterDuplicateText bool
+	sourceFields        []string
+	significanceParam
+	aggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*SignificantTextAgg)(nil)
+
+func (SignificantTextAgg) Kind() AggKind {
+	return AggKindSignificantText
+}
+
+func (a *SignificantTextAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// FilterDuplicateText indicates whether duplicate or near-duplicate text is
+// filtered out of the analysis.
+func (a SignificantTextAgg) FilterDuplicateText() bool {
+	return a.filterDuplicateText
+}
+
+// SetFilterDuplicateText sets filter_duplicate_text to v
+func (a *SignificantTextAgg) SetFilterDuplicateText(v bool) {
+	a.filterDuplicateText = v
+}
+
+// SourceFields are the fields of the _source analyzed instead of Field
+func (a SignificantTextAgg) SourceFields() []string {
+	return a.sourceFields
+}
+
+// SetSourceFields sets source_fields to v
+func (a *SignificantTextAgg) SetSourceFields(v []string) {
+	a.sourceFields = v
+}
+
+func (a SignificantTextAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a SignificantTextAgg) MarshalJSON() ([]byte, error) {
+	return significantTextAgg{
+		Field:                       a.field,
+		FilterDuplicateText:         a.filterDuplicateText,
+		SourceFields:                a.sourceFields,
+		BackgroundFilter:            a.backgroundFilter,
+		significanceHeuristicFields: newSignificanceHeuristicFields(a.heuristic),
+		Size:                        a.size,
+		ShardSize:                   a.shardSize,
+		MinDocCount:                 a.minDocCount.Value(),
+		ShardMinDocCount:            a.shardMinDocCount.Value(),
+		Include:                     a.include,
+		Exclude:                     a.exclude,
+	}.MarshalJSON()
+}
+
+func (a *SignificantTextAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *SignificantTextAgg) UnmarshalJSON(data []byte) error {
+	*a = SignificantTextAgg{}
+	p := significantTextAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.field = p.Field
+	a.SetFilterDuplicateText(p.FilterDuplicateText)
+	a.SetSourceFields(p.SourceFields)
+	a.backgroundFilter = p.BackgroundFilter
+	a.heuristic = p.significanceHeuristicFields.heuristic()
+	a.SetSize(p.Size)
+	a.SetShardSize(p.ShardSize)
+	err = a.SetMinDocCount(p.MinDocCount)
+	if err != nil {
+		return err
+	}
+	err = a.SetShardMinDocCount(p.ShardMinDocCount)
+	if err != nil {
+		return err
+	}
+	a.SetInclude(p.Include)
+	a.exclude = p.Exclude
+	return nil
+}
+
+//easyjson:json
+type significantTextAgg struct {
+	Field               string   `json:"field"`
+	FilterDuplicateText bool     `json:"filter_duplicate_text,omitempty"`
+	SourceFields        []string `json:"source_fields,omitempty"`
+	BackgroundFilter    *Query   `json:"background_filter,omitempty"`
+	significanceHeuristicFields
+	Size             int           `json:"size,omitempty"`
+	ShardSize        int           `json:"shard_size,omitempty"`
+	MinDocCount      interface{}   `json:"min_doc_count,omitempty"`
+	ShardMinDocCount interface{}   `json:"shard_min_doc_count,omitempty"`
+	Include          *TermsInclude `json:"include,omitempty"`
+	Exclude          *TermsInclude `json:"exclude,omitempty"`
+}
@@ -0,0 +1,505 @@
+package picker
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Simulate runs doc through the processors of i, in the manner of the
+// simulate pipeline API, and returns the transformed document. doc is not
+// modified. If the document is dropped, Simulate returns a nil document.
+//
+// Failures are handled as they are by Elasticsearch: failures of processors
+// which set ignore_failure are ignored, a processor's on_failure processors
+// run in place of the failed processor, and the on_failure processors of i
+// run when a processor without its own on_failure fails, after which the
+// remaining processors are skipped. Failures which are not handled are
+// returned as a *ProcessorError.
+//
+// Pipelines are simulated locally and support a subset of Elasticsearch's
+// processors:
+//
+//	append, convert, date, dissect, drop, fail, foreach, gsub, join, json,
+//	kv, lowercase, remove, rename, set, split, trim, uppercase, uri_parts,
+//	urldecode
+//
+// Template snippets only support variables, such as {{field}} and
+// {{{field}}}. Processors of any other kind, processors with an if
+// condition, and templates with sections return ErrSimulationNotSupported.
+func (i IngestPipeline) Simulate(doc map[string]interface{}) (map[string]interface{}, error) {
+	d := newIngestDocument(doc)
+	err := d.runProcessors(i.processors)
+	if err != nil {
+		if len(i.onFailure) == 0 || errors.Is(err, ErrSimulationNotSupported) {
+			return nil, err
+		}
+		err = d.runOnFailure(i.onFailure, err)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if d.dropped {
+		return nil, nil
+	}
+	return d.source, nil
+}
+
+// ingestDocument is a document being run through an ingest pipeline. Fields
+// prefixed with "_ingest." refer to the ingest metadata, such as _value of
+// foreach processors or on_failure_message, while all other fields, including
+// those prefixed with "_source.", refer to the source of the document.
+type ingestDocument struct {
+	source  map[string]interface{}
+	ingest  map[string]interface{}
+	dropped bool
+}
+
+func newIngestDocument(doc map[string]interface{}) *ingestDocument {
+	source, _ := copyIngestValue(doc).(map[string]interface{})
+	if source == nil {
+		source = map[string]interface{}{}
+	}
+	return &ingestDocument{
+		source: source,
+		ingest: map[string]interface{}{
+			"timestamp": time.Now().UTC().Format(time.RFC3339Nano),
+		},
+	}
+}
+
+func (d *ingestDocument) runProcessors(procs Processors) error {
+	for _, proc := range procs {
+		if proc == nil {
+			continue
+		}
+		err := d.runProcessor(proc)
+		if err != nil {
+			return err
+		}
+		if d.dropped {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (d *ingestDocument) runProcessor(proc Processor) error {
+	if len(proc.If()) > 0 {
+		return newProcessorError(fmt.Errorf("%w; if conditions can not be evaluated", ErrSimulationNotSupported), proc.Kind())
+	}
+	err := d.execute(proc)
+	if err == nil {
+		return nil
+	}
+	if len(proc.Tag()) > 0 {
+		err = taggedError{tag: proc.Tag(), err: err}
+	}
+	err = newProcessorError(err, proc.Kind())
+	if errors.Is(err, ErrSimulationNotSupported) {
+		return err
+	}
+	if proc.IgnoreFailure() {
+		return nil
+	}
+	if len(proc.OnFailure()) == 0 {
+		return err
+	}
+	return d.runOnFailure(proc.OnFailure(), err)
+}
+
+// runOnFailure runs procs with the on_failure metadata of err, restoring the
+// previous metadata afterward
+func (d *ingestDocument) runOnFailure(procs Processors, err error) error {
+	keys := []string{"on_failure_message", "on_failure_processor_type", "on_failure_processor_tag"}
+	prev := make(map[string]interface{}, len(keys))
+	for _, key := range keys {
+		if v, ok := d.ingest[key]; ok {
+			prev[key] = v
+		}
+	}
+	msg := err.Error()
+	var pe *ProcessorError
+	if errors.As(err, &pe) {
+		msg = pe.Err.Error()
+		d.ingest["on_failure_processor_type"] = pe.Kind.String()
+	}
+	d.ingest["on_failure_message"] = msg
+	if tag := failedProcessorTag(err); len(tag) > 0 {
+		d.ingest["on_failure_processor_tag"] = tag
+	} else {
+		delete(d.ingest, "on_failure_processor_tag")
+	}
+	err = d.runProcessors(procs)
+	for _, key := range keys {
+		if v, ok := prev[key]; ok {
+			d.ingest[key] = v
+		} else {
+			delete(d.ingest, key)
+		}
+	}
+	return err
+}
+
+// taggedError carries the tag of the processor which failed
+type taggedError struct {
+	tag string
+	err error
+}
+
+func (e taggedError) Error() string {
+	return e.err.Error()
+}
+
+func (e taggedError) Unwrap() error {
+	return e.err
+}
+
+func failedProcessorTag(err error) string {
+	var te taggedError
+	if errors.As(err, &te) {
+		return te.tag
+	}
+	return ""
+}
+
+// root returns the map path refers to along with the remainder of path
+func (d *ingestDocument) root(path string) (map[string]interface{}, string) {
+	switch {
+	case strings.HasPrefix(path, "_ingest."):
+		return d.ingest, strings.TrimPrefix(path, "_ingest.")
+	case strings.HasPrefix(path, "_source."):
+		return d.source, strings.TrimPrefix(path, "_source.")
+	}
+	return d.source, path
+}
+
+// get returns the value of the field at path, which may traverse objects
+// and, by index, arrays
+func (d *ingestDocument) get(path string) (interface{}, error) {
+	if len(path) == 0 {
+		return nil, ErrFieldRequired
+	}
+	root, p := d.root(path)
+	var cur interface{} = root
+	for _, key := range strings.Split(p, ".") {
+		next, ok := ingestChild(cur, key)
+		if !ok {
+			return nil, fmt.Errorf("%w <%s>", ErrFieldNotFound, path)
+		}
+		cur = next
+	}
+	return cur, nil
+}
+
+// has reports whether the field at path exists
+func (d *ingestDocument) has(path string) bool {
+	_, err := d.get(path)
+	return err == nil
+}
+
+// lookup returns the value of the field at path. Missing and null fields are
+// errors unless ignoreMissing is set, in which case ok is false.
+func (d *ingestDocument) lookup(path string, ignoreMissing bool) (v interface{}, ok bool, err error) {
+	v, err = d.get(path)
+	if err == nil && v == nil {
+		err = fmt.Errorf("%w; field <%s> is null", ErrProcessorFailed, path)
+	}
+	if err != nil {
+		if ignoreMissing {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return v, true, nil
+}
+
+// set sets the field at path to value, creating missing objects along the
+// way
+func (d *ingestDocument) set(path string, value interface{}) error {
+	if len(path) == 0 {
+		return ErrFieldRequired
+	}
+	root, p := d.root(path)
+	keys := strings.Split(p, ".")
+	var cur interface{} = root
+	for _, key := range keys[:len(keys)-1] {
+		if m, ok := cur.(map[string]interface{}); ok {
+			if _, exists := m[key]; !exists {
+				m[key] = map[string]interface{}{}
+			}
+		}
+		next, ok := ingestChild(cur, key)
+		if !ok {
+			return fmt.Errorf("%w; can not set <%s> with parent of type %T", ErrProcessorFailed, path, cur)
+		}
+		cur = next
+	}
+	last := keys[len(keys)-1]
+	switch t := cur.(type) {
+	case map[string]interface{}:
+		t[last] = value
+		return nil
+	case []interface{}:
+		i, err := strconv.Atoi(last)
+		if err != nil || i < 0 || i >= len(t) {
+			return fmt.Errorf("%w; index <%s> is out of bounds for <%s>", ErrProcessorFailed, last, path)
+		}
+		t[i] = value
+		return nil
+	}
+	return fmt.Errorf("%w; can not set <%s> with parent of type %T", ErrProcessorFailed, path, cur)
+}
+
+// remove removes the field at path
+func (d *ingestDocument) remove(path string) error {
+	if len(path) == 0 {
+		return ErrFieldRequired
+	}
+	parent, last := "", path
+	if i := strings.LastIndex(path, "."); i > -1 {
+		parent, last = path[:i], path[i+1:]
+	}
+	var cur interface{}
+	if root, p := d.root(path); len(parent) == 0 || len(p) == len(last) {
+		cur = root
+	} else {
+		v, err := d.get(parent)
+		if err != nil {
+			return fmt.Errorf("%w <%s>", ErrFieldNotFound, path)
+		}
+		cur = v
+	}
+	switch t := cur.(type) {
+	case map[string]interface{}:
+		if _, ok := t[last]; ok {
+			delete(t, last)
+			return nil
+		}
+	case []interface{}:
+		i, err := strconv.Atoi(last)
+		if err == nil && i >= 0 && i < len(t) {
+			return d.set(parent, append(t[:i:i], t[i+1:]...))
+		}
+	}
+	return fmt.Errorf("%w <%s>", ErrFieldNotFound, path)
+}
+
+// appendValues appends values to the field at path, converting it to an
+// array if necessary. When allowDuplicates is false, values already present
+// are not appended.
+func (d *ingestDocument) appendValues(path string, values []interface{}, allowDuplicates bool) error {
+	var list []interface{}
+	if v, err := d.get(path); err == nil {
+		if l, ok := v.([]interface{}); ok {
+			list = l
+		} else {
+			list = []interface{}{v}
+		}
+	}
+	for _, v := range values {
+		if !allowDuplicates && containsIngestValue(list, v) {
+			continue
+		}
+		list = append(list, v)
+	}
+	if list == nil {
+		list = []interface{}{}
+	}
+	return d.set(path, list)
+}
+
+func containsIngestValue(list []interface{}, v interface{}) bool {
+	for _, e := range list {
+		if reflect.DeepEqual(e, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func ingestChild(v interface{}, key string) (interface{}, bool) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		c, ok := t[key]
+		return c, ok
+	case []interface{}:
+		i, err := strconv.Atoi(key)
+		if err != nil || i < 0 || i >= len(t) {
+			return nil, false
+		}
+		return t[i], true
+	}
+	return nil, false
+}
+
+// render renders the variables of the template snippet tmpl with the values
+// of d. Values of {{field}} variables are escaped according to mediaType,
+// which defaults to application/json, while values of {{{field}}} are not.
+func (d *ingestDocument) render(tmpl string, mediaType string) (string, error) {
+	if !strings.Contains(tmpl, "{{") {
+		return tmpl, nil
+	}
+	b := strings.Builder{}
+	rest := tmpl
+	for {
+		start := strings.Index(rest, "{{")
+		if start < 0 {
+			b.WriteString(rest)
+			return b.String(), nil
+		}
+		b.WriteString(rest[:start])
+		rest = rest[start:]
+		open, close, escape := "{{", "}}", true
+		if strings.HasPrefix(rest, "{{{") {
+			open, close, escape = "{{{", "}}}", false
+		}
+		end := strings.Index(rest, close)
+		if end < 0 {
+			return "", fmt.Errorf("%w; unclosed template variable in <%s>", ErrProcessorFailed, tmpl)
+		}
+		name := strings.TrimSpace(rest[len(open):end])
+		rest = rest[end+len(close):]
+		if strings.HasPrefix(name, "!") {
+			continue
+		}
+		if strings.HasPrefix(name, "&") {
+			name, escape = strings.TrimSpace(name[1:]), false
+		}
+		if len(name) > 0 && strings.ContainsAny(name[:1], "#^/>=") {
+			return "", fmt.Errorf("%w; template sections are not supported <%s>", ErrSimulationNotSupported, tmpl)
+		}
+		v, err := d.get(name)
+		if err != nil || v == nil {
+			continue
+		}
+		s := ingestString(v)
+		if escape {
+			s = escapeTemplateValue(s, mediaType)
+		}
+		b.WriteString(s)
+	}
+}
+
+// renderValue returns a copy of v with each string rendered as a template
+// snippet
+func (d *ingestDocument) renderValue(v interface{}, mediaType string) (interface{}, error) {
+	switch t := copyIngestValue(v).(type) {
+	case string:
+		return d.render(t, mediaType)
+	case []interface{}:
+		for i, e := range t {
+			r, err := d.renderValue(e, mediaType)
+			if err != nil {
+				return nil, err
+			}
+			t[i] = r
+		}
+		return t, nil
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(t))
+		for key, e := range t {
+			k, err := d.render(key, mediaType)
+			if err != nil {
+				return nil, err
+			}
+			r, err := d.renderValue(e, mediaType)
+			if err != nil {
+				return nil, err
+			}
+			res[k] = r
+		}
+		return res, nil
+	default:
+		return t, nil
+	}
+}
+
+func escapeTemplateValue(s string, mediaType string) string {
+	switch mediaType {
+	case "text/plain":
+		return s
+	case "application/x-www-form-urlencoded":
+		return url.QueryEscape(s)
+	}
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(s); err != nil {
+		return s
+	}
+	res := strings.TrimSpace(buf.String())
+	return res[1 : len(res)-1]
+}
+
+// ingestString formats v as a string
+func ingestString(v interface{}) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case nil:
+		return "null"
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(t), 'f', -1, 32)
+	case map[string]interface{}, []interface{}:
+		data, err := json.Marshal(t)
+		if err != nil {
+			return fmt.Sprint(t)
+		}
+		return string(data)
+	}
+	return fmt.Sprint(v)
+}
+
+// copyIngestValue returns a deep copy of v with slices converted to
+// []interface{} and maps keyed by strings converted to
+// map[string]interface{}
+func copyIngestValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case nil, string, bool, float64, float32, int, int64, int32, json.Number:
+		return v
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(t))
+		for key, e := range t {
+			res[key] = copyIngestValue(e)
+		}
+		return res
+	case []interface{}:
+		res := make([]interface{}, len(t))
+		for i, e := range t {
+			res[i] = copyIngestValue(e)
+		}
+		return res
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return v
+		}
+		res := make([]interface{}, rv.Len())
+		for i := range res {
+			res[i] = copyIngestValue(rv.Index(i).Interface())
+		}
+		return res
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return v
+		}
+		res := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			res[iter.Key().String()] = copyIngestValue(iter.Value().Interface())
+		}
+		return res
+	}
+	return v
+}
@@ -0,0 +1,211 @@
+package picker
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultDateOutputFormat is the format the date processor uses for the
+// parsed date when output_format is not set
+const DefaultDateOutputFormat = "yyyy-MM-dd'T'HH:mm:ss.SSSXXX"
+
+func (d *ingestDocument) executeDate(p *DateProcessor) error {
+	v, err := d.get(p.Field())
+	if err != nil {
+		return err
+	}
+	value := ingestString(v)
+	tz, err := d.render(p.Timezone(), "text/plain")
+	if err != nil {
+		return err
+	}
+	loc, err := dateLocation(tz)
+	if err != nil {
+		return err
+	}
+	outputFormat := p.OutputFormat()
+	if len(outputFormat) == 0 {
+		outputFormat = DefaultDateOutputFormat
+	}
+	output, err := javaTimeLayout(outputFormat)
+	if err != nil {
+		return err
+	}
+	var t time.Time
+	parsed := false
+	for _, format := range p.Formats() {
+		t, err = parseDate(format, value, loc)
+		if err == nil {
+			parsed = true
+			break
+		}
+		if errors.Is(err, ErrSimulationNotSupported) {
+			return err
+		}
+	}
+	if !parsed {
+		return fmt.Errorf("%w; unable to parse date <%s>", ErrProcessorFailed, value)
+	}
+	target := p.TargetField()
+	if len(target) == 0 {
+		target = "@timestamp"
+	}
+	return d.set(target, t.In(loc).Format(output))
+}
+
+// dateLocation returns the location of tz, which is either a time zone ID or
+// an offset such as +01:00. An empty tz is UTC.
+func dateLocation(tz string) (*time.Location, error) {
+	if len(tz) == 0 || tz == "Z" {
+		return time.UTC, nil
+	}
+	if tz[0] == '+' || tz[0] == '-' {
+		for _, layout := range []string{"-07:00", "-0700", "-07"} {
+			if t, err := time.Parse(layout, tz); err == nil {
+				_, offset := t.Zone()
+				return time.FixedZone(tz, offset), nil
+			}
+		}
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, fmt.Errorf("%w; unknown timezone <%s>", ErrProcessorFailed, tz)
+	}
+	return loc, nil
+}
+
+var iso8601Layouts = []string{
+	"2006-01-02T15:04:05.999999999Z07:00",
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999Z07",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04",
+	"2006-01-02T15",
+	"2006-01-02",
+	"2006-01",
+	"2006",
+}
+
+// parseDate parses value with format, which is one of ISO8601, UNIX,
+// UNIX_MS, TAI64N, or a Java time pattern. Values without a time zone are
+// parsed in loc.
+func parseDate(format, value string, loc *time.Location) (time.Time, error) {
+	fail := fmt.Errorf("%w; unable to parse date <%s> with format <%s>", ErrProcessorFailed, value, format)
+	switch format {
+	case "ISO8601":
+		for _, layout := range iso8601Layouts {
+			if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+				return t, nil
+			}
+		}
+		return time.Time{}, fail
+	case "UNIX":
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return time.Time{}, fail
+		}
+		sec, frac := math.Modf(f)
+		return time.Unix(int64(sec), int64(math.Round(frac*1000))*int64(time.Millisecond)), nil
+	case "UNIX_MS":
+		ms, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return time.Time{}, fail
+		}
+		return time.Unix(0, 0).Add(time.Duration(ms) * time.Millisecond), nil
+	case "TAI64N":
+		s := strings.TrimPrefix(value, "@")
+		if len(s) != 24 {
+			return time.Time{}, fail
+		}
+		base, err := strconv.ParseInt(s[1:16], 16, 64)
+		if err != nil {
+			return time.Time{}, fail
+		}
+		nsec, err := strconv.ParseInt(s[16:24], 16, 64)
+		if err != nil {
+			return time.Time{}, fail
+		}
+		return time.Unix(base-10, nsec), nil
+	}
+	layout, err := javaTimeLayout(format)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, err := time.ParseInLocation(layout, value, loc)
+	if err != nil {
+		return time.Time{}, fail
+	}
+	if !strings.Contains(layout, "06") {
+		t = t.AddDate(time.Now().In(loc).Year(), 0, 0)
+	}
+	return t, nil
+}
+
+// javaTimeLayouts maps the letters of Java time patterns, by the number of
+// times they are repeated, to time layouts
+var javaTimeLayouts = map[string]string{
+	"yyyy": "2006", "yy": "06", "y": "2006",
+	"uuuu": "2006", "uu": "06", "u": "2006",
+	"MMMM": "January", "MMM": "Jan", "MM": "01", "M": "1",
+	"dd": "02", "d": "2",
+	"EEEE": "Monday", "EEE": "Mon", "E": "Mon",
+	"HH": "15", "H": "15",
+	"hh": "03", "h": "3",
+	"mm": "04", "m": "4",
+	"ss": "05", "s": "5",
+	"a":   "PM",
+	"XXX": "Z07:00", "XX": "Z0700", "X": "Z07",
+	"xxx": "-07:00", "xx": "-0700", "x": "-07",
+	"ZZZZZ": "-07:00", "ZZZ": "-0700", "ZZ": "-0700", "Z": "-0700",
+	"zzz": "MST", "zz": "MST", "z": "MST",
+}
+
+// javaTimeLayout converts the Java time pattern to a time layout. Patterns
+// with letters that have no equivalent return ErrSimulationNotSupported.
+func javaTimeLayout(pattern string) (string, error) {
+	b := strings.Builder{}
+	for i := 0; i < len(pattern); {
+		c := pattern[i]
+		switch {
+		case c == '\'':
+			end := strings.IndexByte(pattern[i+1:], '\'')
+			if end < 0 {
+				return "", fmt.Errorf("%w; unclosed literal in date format <%s>", ErrProcessorFailed, pattern)
+			}
+			if end == 0 {
+				b.WriteByte('\'')
+			} else {
+				b.WriteString(pattern[i+1 : i+1+end])
+			}
+			i += end + 2
+		case (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+			j := i
+			for j < len(pattern) && pattern[j] == c {
+				j++
+			}
+			letters := pattern[i:j]
+			if c == 'S' {
+				if i == 0 || (pattern[i-1] != '.' && pattern[i-1] != ',') {
+					return "", fmt.Errorf("%w; fraction of second must follow a period or comma <%s>", ErrSimulationNotSupported, pattern)
+				}
+				b.WriteString(strings.Repeat("0", len(letters)))
+			} else {
+				layout, ok := javaTimeLayouts[letters]
+				if !ok {
+					return "", fmt.Errorf("%w; date format <%s> of <%s>", ErrSimulationNotSupported, letters, pattern)
+				}
+				b.WriteString(layout)
+			}
+			i = j
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,176 @@
+package picker
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var dissectKeyRegex = regexp.MustCompile(`%\{([^}]*)\}`)
+
+// dissectKey is a key of a dissect pattern along with the delimiter which
+// follows it
+type dissectKey struct {
+	name       string
+	skip       bool
+	appendKey  bool
+	order      int
+	reference  bool
+	value      bool
+	rightPad   bool
+	delimiter  string
+	appearance int
+}
+
+// dissectPattern is a parsed dissect pattern
+type dissectPattern struct {
+	leading string
+	keys    []dissectKey
+}
+
+func parseDissectPattern(pattern string) (dissectPattern, error) {
+	matches := dissectKeyRegex.FindAllStringSubmatchIndex(pattern, -1)
+	if len(matches) == 0 {
+		return dissectPattern{}, fmt.Errorf("%w; dissect pattern <%s> does not contain any keys", ErrProcessorFailed, pattern)
+	}
+	res := dissectPattern{leading: pattern[:matches[0][0]]}
+	for i, m := range matches {
+		k := dissectKey{name: pattern[m[2]:m[3]], appearance: i}
+		end := len(pattern)
+		if i+1 < len(matches) {
+			end = matches[i+1][0]
+		}
+		k.delimiter = pattern[m[1]:end]
+		if len(k.delimiter) == 0 && i+1 < len(matches) {
+			return dissectPattern{}, fmt.Errorf("%w; dissect pattern <%s> has keys without a delimiter between them", ErrProcessorFailed, pattern)
+		}
+		if strings.HasSuffix(k.name, "->") {
+			k.rightPad = true
+			k.name = strings.TrimSuffix(k.name, "->")
+		}
+		switch {
+		case strings.HasPrefix(k.name, "+"):
+			k.appendKey = true
+			k.name = k.name[1:]
+			if j := strings.LastIndex(k.name, "/"); j > -1 {
+				n, err := strconv.Atoi(k.name[j+1:])
+				if err != nil {
+					return dissectPattern{}, fmt.Errorf("%w; invalid append order in dissect key <%s>", ErrProcessorFailed, k.name)
+				}
+				k.order, k.name = n, k.name[:j]
+			}
+		case strings.HasPrefix(k.name, "?"):
+			k.skip = true
+		case strings.HasPrefix(k.name, "*"):
+			k.reference = true
+			k.name = k.name[1:]
+		case strings.HasPrefix(k.name, "&"):
+			k.value = true
+			k.name = k.name[1:]
+		case len(k.name) == 0:
+			k.skip = true
+		}
+		res.keys = append(res.keys, k)
+	}
+	return res, nil
+}
+
+// match returns the fields dissected from s
+func (p dissectPattern) match(s string, appendSeparator string) (map[string]string, error) {
+	fail := fmt.Errorf("%w; unable to find match for dissect pattern <%s>", ErrProcessorFailed, s)
+	if !strings.HasPrefix(s, p.leading) {
+		return nil, fail
+	}
+	rest := s[len(p.leading):]
+	values := make([]string, len(p.keys))
+	for i, k := range p.keys {
+		if len(k.delimiter) == 0 {
+			values[i] = rest
+			rest = ""
+			continue
+		}
+		end := strings.Index(rest, k.delimiter)
+		if end < 0 {
+			return nil, fail
+		}
+		values[i] = rest[:end]
+		rest = rest[end+len(k.delimiter):]
+		if k.rightPad {
+			for strings.HasPrefix(rest, k.delimiter) {
+				rest = rest[len(k.delimiter):]
+			}
+		}
+	}
+	res := map[string]string{}
+	references := map[string]string{}
+	refValues := map[string]string{}
+	appends := map[string][]dissectKey{}
+	appendValues := map[int]string{}
+	for i, k := range p.keys {
+		switch {
+		case k.skip:
+		case k.reference:
+			references[k.name] = values[i]
+		case k.value:
+			refValues[k.name] = values[i]
+		case k.appendKey:
+			appends[k.name] = append(appends[k.name], k)
+			appendValues[k.appearance] = values[i]
+		default:
+			if _, ok := appends[k.name]; ok {
+				appendValues[k.appearance] = values[i]
+				appends[k.name] = append(appends[k.name], k)
+			} else {
+				res[k.name] = values[i]
+			}
+		}
+	}
+	for name, keys := range appends {
+		if v, ok := res[name]; ok {
+			delete(res, name)
+			keys = append([]dissectKey{{name: name, appearance: -1}}, keys...)
+			appendValues[-1] = v
+		}
+		for i := 1; i < len(keys); i++ {
+			for j := i; j > 0 && keys[j].order < keys[j-1].order; j-- {
+				keys[j], keys[j-1] = keys[j-1], keys[j]
+			}
+		}
+		parts := make([]string, len(keys))
+		for i, k := range keys {
+			parts[i] = appendValues[k.appearance]
+		}
+		res[name] = strings.Join(parts, appendSeparator)
+	}
+	for name, key := range references {
+		res[key] = refValues[name]
+	}
+	return res, nil
+}
+
+func (d *ingestDocument) executeDissect(p *DissectProcessor) error {
+	pattern, err := parseDissectPattern(p.Pattern())
+	if err != nil {
+		return err
+	}
+	v, ok, err := d.lookup(p.Field(), p.IgnoreMissing())
+	if err != nil || !ok {
+		return err
+	}
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("%w; field <%s> of type %T can not be cast to a string", ErrProcessorFailed, p.Field(), v)
+	}
+	fields, err := pattern.match(s, p.AppendSeparator())
+	if err != nil {
+		return err
+	}
+	for name, value := range fields {
+		err = d.set(name, value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
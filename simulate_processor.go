@@ -0,0 +1,659 @@
+package picker
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// execute runs proc against d without regard to its if condition or failure
+// handling
+func (d *ingestDocument) execute(proc Processor) error {
+	switch p := proc.(type) {
+	case *SetProcessor:
+		return d.executeSet(p)
+	case *AppendProcessor:
+		return d.executeAppend(p)
+	case *RemoveProcessor:
+		return d.executeRemove(p)
+	case *RenameProcessor:
+		return d.executeRename(p)
+	case *LowercaseProcessor:
+		return d.mapStrings(p.Field(), p.TargetField(), p.IgnoreMissing(), func(s string) (interface{}, error) {
+			return strings.ToLower(s), nil
+		})
+	case *UppercaseProcessor:
+		return d.mapStrings(p.Field(), p.TargetField(), p.IgnoreMissing(), func(s string) (interface{}, error) {
+			return strings.ToUpper(s), nil
+		})
+	case *TrimProcessor:
+		return d.mapStrings(p.Field(), p.TargetField(), p.IgnoreMissing(), func(s string) (interface{}, error) {
+			return strings.TrimSpace(s), nil
+		})
+	case *URLDecodeProcessor:
+		return d.mapStrings(p.Field(), p.TargetField(), p.IgnoreMissing(), func(s string) (interface{}, error) {
+			res, err := url.QueryUnescape(s)
+			if err != nil {
+				return nil, fmt.Errorf("%w; %v", ErrProcessorFailed, err)
+			}
+			return res, nil
+		})
+	case *GsubProcessor:
+		return d.executeGsub(p)
+	case *SplitProcessor:
+		return d.executeSplit(p)
+	case *JoinProcessor:
+		return d.executeJoin(p)
+	case *ConvertProcessor:
+		return d.mapValues(p.Field(), p.TargetField(), p.IgnoreMissing(), func(v interface{}) (interface{}, error) {
+			return convertIngestValue(p.Type(), v)
+		})
+	case *JSONProcessor:
+		return d.executeJSON(p)
+	case *KVProcessor:
+		return d.executeKV(p)
+	case *DissectProcessor:
+		return d.executeDissect(p)
+	case *DateProcessor:
+		return d.executeDate(p)
+	case *URIPartsProcessor:
+		return d.executeURIParts(p)
+	case *ForeachProcessor:
+		return d.executeForeach(p)
+	case *DropProcessor:
+		d.dropped = true
+		return nil
+	case *FailProcessor:
+		msg, err := d.render(p.Message(), "text/plain")
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("%w; %s", ErrProcessorFailed, msg)
+	}
+	return ErrSimulationNotSupported
+}
+
+// mapValues sets target, or field if target is empty, to the result of fn
+// applied to the value of field or, for arrays, to each of its values
+func (d *ingestDocument) mapValues(field, target string, ignoreMissing bool, fn func(v interface{}) (interface{}, error)) error {
+	v, ok, err := d.lookup(field, ignoreMissing)
+	if err != nil || !ok {
+		return err
+	}
+	var res interface{}
+	if list, isList := v.([]interface{}); isList {
+		values := make([]interface{}, len(list))
+		for i, e := range list {
+			values[i], err = fn(e)
+			if err != nil {
+				return err
+			}
+		}
+		res = values
+	} else {
+		res, err = fn(v)
+		if err != nil {
+			return err
+		}
+	}
+	if len(target) == 0 {
+		target = field
+	}
+	return d.set(target, res)
+}
+
+// mapStrings is mapValues for processors which only accept strings
+func (d *ingestDocument) mapStrings(field, target string, ignoreMissing bool, fn func(s string) (interface{}, error)) error {
+	return d.mapValues(field, target, ignoreMissing, func(v interface{}) (interface{}, error) {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w; field <%s> of type %T can not be cast to a string", ErrProcessorFailed, field, v)
+		}
+		return fn(s)
+	})
+}
+
+func (d *ingestDocument) executeSet(p *SetProcessor) error {
+	field, err := d.render(p.Field(), "text/plain")
+	if err != nil {
+		return err
+	}
+	if !p.Override() {
+		if v, err := d.get(field); err == nil && v != nil {
+			return nil
+		}
+	}
+	var value interface{}
+	if len(p.CopyFrom()) > 0 {
+		v, err := d.get(p.CopyFrom())
+		if err != nil {
+			return err
+		}
+		value = copyIngestValue(v)
+	} else {
+		value, err = d.renderValue(p.Value(), p.MediaType())
+		if err != nil {
+			return err
+		}
+	}
+	if p.IgnoreEmptyValue() && (value == nil || value == "") {
+		return nil
+	}
+	return d.set(field, value)
+}
+
+func (d *ingestDocument) executeAppend(p *AppendProcessor) error {
+	field, err := d.render(p.Field(), "text/plain")
+	if err != nil {
+		return err
+	}
+	value, err := d.renderValue(p.Value(), p.MediaType())
+	if err != nil {
+		return err
+	}
+	values, ok := value.([]interface{})
+	if !ok {
+		values = []interface{}{value}
+	}
+	return d.appendValues(field, values, p.AllowDuplicates())
+}
+
+func (d *ingestDocument) executeRemove(p *RemoveProcessor) error {
+	for _, f := range p.Field() {
+		field, err := d.render(f, "text/plain")
+		if err != nil {
+			return err
+		}
+		if p.IgnoreMissing() && !d.has(field) {
+			continue
+		}
+		err = d.remove(field)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *ingestDocument) executeRename(p *RenameProcessor) error {
+	field, err := d.render(p.Field(), "text/plain")
+	if err != nil {
+		return err
+	}
+	target, err := d.render(p.TargetField(), "text/plain")
+	if err != nil {
+		return err
+	}
+	v, err := d.get(field)
+	if err != nil {
+		if p.IgnoreMissing() {
+			return nil
+		}
+		return err
+	}
+	if d.has(target) {
+		return fmt.Errorf("%w <%s>", ErrFieldExists, target)
+	}
+	err = d.remove(field)
+	if err != nil {
+		return err
+	}
+	err = d.set(target, v)
+	if err != nil {
+		// restore the field so that the document is left unchanged
+		d.set(field, v)
+		return err
+	}
+	return nil
+}
+
+func (d *ingestDocument) executeGsub(p *GsubProcessor) error {
+	re, err := regexp.Compile(p.Pattern())
+	if err != nil {
+		return fmt.Errorf("%w; %v", ErrProcessorFailed, err)
+	}
+	replacement := javaReplacement(p.Replacement())
+	return d.mapStrings(p.Field(), p.TargetField(), p.IgnoreMissing(), func(s string) (interface{}, error) {
+		return re.ReplaceAllString(s, replacement), nil
+	})
+}
+
+// javaReplacement converts a Java regular expression replacement, which
+// references groups as $1 and escapes with a backslash, to the syntax of
+// regexp.Regexp.Expand
+func javaReplacement(s string) string {
+	b := strings.Builder{}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s):
+			i++
+			if s[i] == '$' {
+				b.WriteString("$$")
+			} else {
+				b.WriteByte(s[i])
+			}
+		case c == '$' && i+1 < len(s) && s[i+1] >= '0' && s[i+1] <= '9':
+			j := i + 1
+			for j < len(s) && s[j] >= '0' && s[j] <= '9' {
+				j++
+			}
+			b.WriteString("${" + s[i+1:j] + "}")
+			i = j - 1
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+func (d *ingestDocument) executeSplit(p *SplitProcessor) error {
+	re, err := regexp.Compile(p.Separator())
+	if err != nil {
+		return fmt.Errorf("%w; %v", ErrProcessorFailed, err)
+	}
+	v, ok, err := d.lookup(p.Field(), p.IgnoreMissing())
+	if err != nil || !ok {
+		return err
+	}
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("%w; field <%s> of type %T can not be cast to a string", ErrProcessorFailed, p.Field(), v)
+	}
+	parts := re.Split(s, -1)
+	if !p.PreserveTrailing() {
+		for len(parts) > 1 && len(parts[len(parts)-1]) == 0 {
+			parts = parts[:len(parts)-1]
+		}
+	}
+	res := make([]interface{}, len(parts))
+	for i, part := range parts {
+		res[i] = part
+	}
+	target := p.TargetField()
+	if len(target) == 0 {
+		target = p.Field()
+	}
+	return d.set(target, res)
+}
+
+func (d *ingestDocument) executeJoin(p *JoinProcessor) error {
+	v, err := d.get(p.Field())
+	if err != nil {
+		return err
+	}
+	list, ok := v.([]interface{})
+	if !ok {
+		return fmt.Errorf("%w; field <%s> of type %T can not be cast to an array", ErrProcessorFailed, p.Field(), v)
+	}
+	parts := make([]string, len(list))
+	for i, e := range list {
+		parts[i] = ingestString(e)
+	}
+	target := p.TargetField()
+	if len(target) == 0 {
+		target = p.Field()
+	}
+	return d.set(target, strings.Join(parts, p.Separator()))
+}
+
+// convertIngestValue converts v to typ in the manner of the convert processor
+func convertIngestValue(typ string, v interface{}) (interface{}, error) {
+	fail := func() (interface{}, error) {
+		return nil, fmt.Errorf("%w; unable to convert <%s> to %s", ErrProcessorFailed, ingestString(v), typ)
+	}
+	s := ingestString(v)
+	if f, ok := v.(float64); ok && f == math.Trunc(f) && !math.IsInf(f, 0) {
+		s = strconv.FormatInt(int64(f), 10)
+	}
+	switch typ {
+	case "integer", "long":
+		bits := 64
+		if typ == "integer" {
+			bits = 32
+		}
+		n, err := parseJavaInt(s, bits)
+		if err != nil {
+			return fail()
+		}
+		if bits == 32 {
+			return int(n), nil
+		}
+		return n, nil
+	case "float":
+		f, err := strconv.ParseFloat(s, 32)
+		if err != nil {
+			return fail()
+		}
+		return float32(f), nil
+	case "double":
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return fail()
+		}
+		return f, nil
+	case "boolean":
+		switch strings.ToLower(s) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+		return fail()
+	case "string":
+		return ingestString(v), nil
+	case "ip":
+		if net.ParseIP(s) == nil {
+			return fail()
+		}
+		return s, nil
+	case "auto":
+		str, ok := v.(string)
+		if !ok {
+			return v, nil
+		}
+		if n, err := parseJavaInt(str, 64); err == nil {
+			if n >= math.MinInt32 && n <= math.MaxInt32 {
+				return int(n), nil
+			}
+			return n, nil
+		}
+		if f, err := strconv.ParseFloat(str, 64); err == nil {
+			return f, nil
+		}
+		switch strings.ToLower(str) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+		return v, nil
+	}
+	return nil, fmt.Errorf("%w; type <%s> is not supported", ErrProcessorFailed, typ)
+}
+
+// parseJavaInt parses s as a decimal or, if prefixed with 0x, hexadecimal
+// integer
+func parseJavaInt(s string, bits int) (int64, error) {
+	neg := strings.HasPrefix(s, "-")
+	digits := strings.TrimPrefix(s, "-")
+	if strings.HasPrefix(digits, "0x") || strings.HasPrefix(digits, "0X") {
+		n, err := strconv.ParseInt(digits[2:], 16, bits)
+		if neg {
+			n = -n
+		}
+		return n, err
+	}
+	return strconv.ParseInt(s, 10, bits)
+}
+
+func (d *ingestDocument) executeJSON(p *JSONProcessor) error {
+	v, err := d.get(p.Field())
+	if err != nil {
+		return err
+	}
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("%w; field <%s> of type %T can not be cast to a string", ErrProcessorFailed, p.Field(), v)
+	}
+	if !p.AllowDuplicateKeys() {
+		err = checkDuplicateKeys(s)
+		if err != nil {
+			return err
+		}
+	}
+	var value interface{}
+	err = json.Unmarshal([]byte(s), &value)
+	if err != nil {
+		return fmt.Errorf("%w; %v", ErrProcessorFailed, err)
+	}
+	if !p.AddToRoot() {
+		target := p.TargetField()
+		if len(target) == 0 {
+			target = p.Field()
+		}
+		return d.set(target, value)
+	}
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("%w; can not add non-object fields to the root of the document", ErrProcessorFailed)
+	}
+	merge := p.AddToRootConflictStrategy() == "merge"
+	for key, e := range obj {
+		if merge {
+			d.source[key] = mergeIngestValues(d.source[key], e)
+		} else {
+			d.source[key] = e
+		}
+	}
+	return nil
+}
+
+// mergeIngestValues recursively merges the objects of src into dst. Values
+// which are not both objects are replaced by src.
+func mergeIngestValues(dst, src interface{}) interface{} {
+	dm, ok := dst.(map[string]interface{})
+	if !ok {
+		return src
+	}
+	sm, ok := src.(map[string]interface{})
+	if !ok {
+		return src
+	}
+	for key, v := range sm {
+		dm[key] = mergeIngestValues(dm[key], v)
+	}
+	return dm
+}
+
+// checkDuplicateKeys returns an error if an object of the JSON of s has
+// duplicate keys
+func checkDuplicateKeys(s string) error {
+	type frame struct {
+		keys      map[string]struct{}
+		expectKey bool
+	}
+	dec := json.NewDecoder(strings.NewReader(s))
+	var stack []*frame
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%w; %v", ErrProcessorFailed, err)
+		}
+		var top *frame
+		if len(stack) > 0 {
+			top = stack[len(stack)-1]
+		}
+		if top != nil && top.keys != nil && top.expectKey {
+			if key, ok := tok.(string); ok {
+				if _, exists := top.keys[key]; exists {
+					return fmt.Errorf("%w; duplicate key <%s>", ErrProcessorFailed, key)
+				}
+				top.keys[key] = struct{}{}
+				top.expectKey = false
+				continue
+			}
+		}
+		if top != nil && top.keys != nil {
+			top.expectKey = true
+		}
+		switch tok {
+		case json.Delim('{'):
+			stack = append(stack, &frame{keys: map[string]struct{}{}, expectKey: true})
+		case json.Delim('['):
+			stack = append(stack, &frame{})
+		case json.Delim('}'), json.Delim(']'):
+			stack = stack[:len(stack)-1]
+		}
+	}
+}
+
+var stripBracketsRegex = regexp.MustCompile(`^[(<"'\[]|[)>"'\]]$`)
+
+func (d *ingestDocument) executeKV(p *KVProcessor) error {
+	fieldSplit, err := regexp.Compile(p.FieldSplit())
+	if err != nil {
+		return fmt.Errorf("%w; %v", ErrProcessorFailed, err)
+	}
+	valueSplit, err := regexp.Compile(p.ValueSplit())
+	if err != nil {
+		return fmt.Errorf("%w; %v", ErrProcessorFailed, err)
+	}
+	v, ok, err := d.lookup(p.Field(), p.IgnoreMissing())
+	if err != nil || !ok {
+		return err
+	}
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("%w; field <%s> of type %T can not be cast to a string", ErrProcessorFailed, p.Field(), v)
+	}
+	include := make(map[string]bool, len(p.IncludeKeys()))
+	for _, key := range p.IncludeKeys() {
+		include[key] = true
+	}
+	exclude := make(map[string]bool, len(p.ExcludeKeys()))
+	for _, key := range p.ExcludeKeys() {
+		exclude[key] = true
+	}
+	prefix := p.Prefix()
+	if len(p.TargetField()) > 0 {
+		prefix = p.TargetField() + "." + prefix
+	}
+	for _, pair := range fieldSplit.Split(s, -1) {
+		kv := valueSplit.Split(pair, 2)
+		if len(kv) != 2 {
+			return fmt.Errorf("%w; field <%s> does not contain value_split <%s>", ErrProcessorFailed, p.Field(), p.ValueSplit())
+		}
+		key := strings.Trim(kv[0], p.TrimKey())
+		if (len(include) > 0 && !include[key]) || exclude[key] {
+			continue
+		}
+		value := strings.Trim(kv[1], p.TrimValue())
+		if p.StripBrackets() {
+			value = stripBracketsRegex.ReplaceAllString(value, "")
+		}
+		if d.has(prefix + key) {
+			err = d.appendValues(prefix+key, []interface{}{value}, true)
+		} else {
+			err = d.set(prefix+key, value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *ingestDocument) executeURIParts(p *URIPartsProcessor) error {
+	v, ok, err := d.lookup(p.Field(), p.IgnoreMissing())
+	if err != nil || !ok {
+		return err
+	}
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("%w; field <%s> of type %T can not be cast to a string", ErrProcessorFailed, p.Field(), v)
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return fmt.Errorf("%w; unable to parse URI <%s>", ErrProcessorFailed, s)
+	}
+	parts := map[string]interface{}{}
+	put := func(key, value string) {
+		if len(value) > 0 {
+			parts[key] = value
+		}
+	}
+	put("scheme", u.Scheme)
+	put("domain", u.Hostname())
+	put("path", u.Path)
+	put("query", u.RawQuery)
+	put("fragment", u.Fragment)
+	if ext := path.Ext(u.Path); len(ext) > 1 {
+		parts["extension"] = ext[1:]
+	}
+	if port := u.Port(); len(port) > 0 {
+		n, err := strconv.Atoi(port)
+		if err != nil {
+			return fmt.Errorf("%w; invalid port <%s>", ErrProcessorFailed, port)
+		}
+		parts["port"] = n
+	}
+	if u.User != nil {
+		put("user_info", u.User.String())
+		put("username", u.User.Username())
+		password, _ := u.User.Password()
+		put("password", password)
+	}
+	if p.KeepOriginal() {
+		parts["original"] = s
+	}
+	target := p.TargetField()
+	if len(target) == 0 {
+		target = "url"
+	}
+	if p.RemoveIfSuccessful() && target != p.Field() {
+		err = d.remove(p.Field())
+		if err != nil {
+			return err
+		}
+	}
+	return d.set(target, parts)
+}
+
+func (d *ingestDocument) executeForeach(p *ForeachProcessor) error {
+	if p.ItemProcessor() == nil {
+		return ErrProcessorRequired
+	}
+	v, ok, err := d.lookup(p.Field(), p.IgnoreMissing())
+	if err != nil || !ok {
+		return err
+	}
+	prevKey, hasPrevKey := d.ingest["_key"]
+	prevValue, hasPrevValue := d.ingest["_value"]
+	defer func() {
+		delete(d.ingest, "_key")
+		delete(d.ingest, "_value")
+		if hasPrevKey {
+			d.ingest["_key"] = prevKey
+		}
+		if hasPrevValue {
+			d.ingest["_value"] = prevValue
+		}
+	}()
+	switch t := v.(type) {
+	case []interface{}:
+		res := make([]interface{}, 0, len(t))
+		for _, e := range t {
+			d.ingest["_value"] = e
+			err = d.runProcessor(p.ItemProcessor())
+			if err != nil {
+				return err
+			}
+			res = append(res, d.ingest["_value"])
+		}
+		return d.set(p.Field(), res)
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(t))
+		for key, e := range t {
+			d.ingest["_key"] = key
+			d.ingest["_value"] = e
+			err = d.runProcessor(p.ItemProcessor())
+			if err != nil {
+				return err
+			}
+			res[ingestString(d.ingest["_key"])] = d.ingest["_value"]
+		}
+		return d.set(p.Field(), res)
+	}
+	return fmt.Errorf("%w; field <%s> of type %T can not be cast to an array or object", ErrProcessorFailed, p.Field(), v)
+}
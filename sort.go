@@ -2,6 +2,7 @@ package picker
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
 
 	"github.com/chanced/dynamic"
@@ -60,70 +61,385 @@ type SortNested struct {
 	MaxChildren int64 `json:"max_children,omitempty"`
 }
 
+// Sort fields which are not document fields
+const (
+	// SortFieldScore sorts by score
+	SortFieldScore = "_score"
+	// SortFieldDoc sorts by index order
+	SortFieldDoc = "_doc"
+	// SortFieldShardDoc sorts by shard and index order. It is the tiebreaker
+	// of point in time searches.
+	SortFieldShardDoc = "_shard_doc"
+	// SortFieldGeoDistance sorts by distance. See SortEntry.GeoDistance.
+	SortFieldGeoDistance = "_geo_distance"
+	// SortFieldScript sorts by the value of a script. See SortEntry.Script.
+	SortFieldScript = "_script"
+)
+
+// Values of SortEntry.Missing which sort documents missing the field first or
+// last. Any other value is used as the sort value of those documents.
+const (
+	SortMissingFirst = "_first"
+	SortMissingLast  = "_last"
+)
+
+// Types of script sorts
+const (
+	ScriptSortTypeNumber = "number"
+	ScriptSortTypeString = "string"
+)
+
+// Sort determines how hits are sorted.
+//
+// Sort accepts each form Elasticsearch does when unmarshaling: a single
+// entry or an array of entries, where each entry is either the name of a
+// field (e.g. "_score"), an object of a field and its order (e.g.
+// {"post_date": "desc"}), or an object of a field and its options.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/sort-search-results.html
 type Sort []SortEntry
 
+// Validate checks each SortEntry
+func (s Sort) Validate() error {
+	for _, e := range s {
+		err := e.Validate()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Sort) UnmarshalBSON(data []byte) error {
+	return s.UnmarshalJSON(data)
+}
+
+// UnmarshalJSON accepts a single entry or an array of entries
+func (s *Sort) UnmarshalJSON(data []byte) error {
+	*s = nil
+	d := dynamic.JSON(data)
+	if len(d) == 0 || d.IsNull() {
+		return nil
+	}
+	if !d.IsArray() {
+		var e SortEntry
+		err := e.UnmarshalJSON(d)
+		if err != nil {
+			return err
+		}
+		*s = Sort{e}
+		return nil
+	}
+	var entries []dynamic.JSON
+	err := json.Unmarshal(d, &entries)
+	if err != nil {
+		return err
+	}
+	res := make(Sort, len(entries))
+	for i, ed := range entries {
+		err = res[i].UnmarshalJSON(ed)
+		if err != nil {
+			return err
+		}
+	}
+	*s = res
+	return nil
+}
+
+// SortEntry is a field and how to sort hits by it.
+//
+// To sort by distance, set GeoDistance. To sort by the value of a script, set
+// Script and Type. Field is not required for either.
 type SortEntry struct {
-	Field       string      `json:"-"`
-	Order       SortOrder   `json:"order,omitempty"`
-	Mode        SortMode    `json:"mode,omitempty"`
-	NumericType string      `json:"numeric_type,omitempty"`
-	Missing     string      `json:"missing,omitempty"`
-	Type        string      `json:"type,omitempty"`
-	Script      *Script     `json:"script,omitempty"`
-	Nested      *SortNested `json:"nested,omitempty"`
+	// The field to sort by, or one of _score, _doc, or _shard_doc
+	Field string
+	// The order of the sort. Defaults to desc when sorting on _score and asc
+	// when sorting on anything else.
+	Order SortOrder
+	// How to pick the value of array or multi-valued fields
+	Mode SortMode
+	// Casts the values of numeric fields to one of "double", "long", "date",
+	// or "date_nanos" so that indices with different mappings can be sorted
+	// together.
+	NumericType string
+	// How documents missing the field are treated: "_first", "_last", or a
+	// custom value which is used as the sort value of those documents.
+	// Defaults to "_last".
+	Missing interface{}
+	// The type used for the sort values of indices where the field is not
+	// mapped. Without it, sorting on an unmapped field fails.
+	UnmappedType string
+	// The format of the sort values of date fields
+	Format string
+	// The type of the sort value of a script sort; either "number" or
+	// "string"
+	Type string
+	// Sorts by the value of the script. Type is required.
+	Script *Script
+	// Sorts by distance to one or more geo points
+	GeoDistance *GeoDistanceSort
+	// Sorts by a field of nested objects
+	Nested *SortNested
 }
 
-func (s SortEntry) MarshalJSON() ([]byte, error) {
+// Key returns the key of the entry within the sort: _geo_distance if
+// GeoDistance is set, _script if Script is set, otherwise Field.
+func (s SortEntry) Key() string {
+	switch {
+	case s.GeoDistance != nil:
+		return SortFieldGeoDistance
+	case s.Script != nil:
+		return SortFieldScript
+	}
+	return s.Field
+}
 
-	b, err := sort{
-		Order:       s.Order,
-		Mode:        s.Mode,
-		NumericType: s.NumericType,
-		Missing:     s.Missing,
-		Type:        s.Type,
-		Script:      s.Script,
-		Nested:      s.Nested,
-	}.MarshalJSON()
+// Validate checks the entry is complete and its options are valid
+func (s SortEntry) Validate() error {
+	key := s.Key()
+	if len(key) == 0 {
+		return ErrFieldRequired
+	}
+	if len(s.Order) > 0 && !s.Order.IsValid() {
+		return newFieldError(fmt.Errorf("%w <%s>", ErrInvalidSortOrder, s.Order), key)
+	}
+	switch key {
+	case SortFieldScript:
+		if s.Script == nil || s.Script.IsEmpty() {
+			return newFieldError(ErrScriptRequired, key)
+		}
+		if s.Type != ScriptSortTypeNumber && s.Type != ScriptSortTypeString {
+			return newFieldError(fmt.Errorf("%w <%s>", ErrInvalidScriptSortType, s.Type), key)
+		}
+	case SortFieldGeoDistance:
+		if s.GeoDistance == nil {
+			return newFieldError(ErrPointsRequired, key)
+		}
+		err := s.GeoDistance.Validate()
+		if err != nil {
+			return newFieldError(err, key)
+		}
+	}
+	return nil
+}
+
+func (s SortEntry) MarshalBSON() ([]byte, error) {
+	return s.MarshalJSON()
+}
+
+// MarshalJSON encodes s as an object of its options keyed by its field. The
+// shorthand forms are only accepted when unmarshaling.
+func (s SortEntry) MarshalJSON() ([]byte, error) {
+	key := s.Key()
+	if len(key) == 0 {
+		return nil, ErrFieldRequired
+	}
+	v := sort{
+		Order:        s.Order,
+		Mode:         s.Mode,
+		NumericType:  s.NumericType,
+		Missing:      s.Missing,
+		UnmappedType: s.UnmappedType,
+		Format:       s.Format,
+		Nested:       s.Nested,
+	}
+	switch key {
+	case SortFieldScript:
+		v.Type = s.Type
+		v.Script = s.Script
+	case SortFieldGeoDistance:
+		v.Unit = s.GeoDistance.Unit
+		v.DistanceType = s.GeoDistance.DistanceType
+		v.IgnoreUnmapped = s.GeoDistance.IgnoreUnmapped
+		v.ValidationMethod = s.GeoDistance.ValidationMethod
+	}
+	b, err := v.MarshalJSON()
 	if err != nil {
 		return nil, err
 	}
-	return dynamic.JSONObject{s.Field: b}.MarshalJSON()
+	if s.GeoDistance != nil {
+		var obj dynamic.JSONObject
+		err = json.Unmarshal(b, &obj)
+		if err != nil {
+			return nil, err
+		}
+		obj[s.GeoDistance.Field], err = s.GeoDistance.marshalPoints()
+		if err != nil {
+			return nil, err
+		}
+		b, err = json.Marshal(obj)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return dynamic.JSONObject{key: b}.MarshalJSON()
+}
+
+func (s *SortEntry) UnmarshalBSON(data []byte) error {
+	return s.UnmarshalJSON(data)
 }
 
+// UnmarshalJSON accepts a bare field, an object of a field and its order, or
+// an object of a field and its options
 func (s *SortEntry) UnmarshalJSON(data []byte) error {
 	*s = SortEntry{}
+	d := dynamic.JSON(data)
+	if d.IsString() {
+		return json.Unmarshal(d, &s.Field)
+	}
 	var obj dynamic.JSONObject
-	err := json.Unmarshal(data, &obj)
+	err := json.Unmarshal(d, &obj)
 	if err != nil {
 		return err
 	}
+	if len(obj) != 1 {
+		return fmt.Errorf("%w; expected a single field, received <%d>", ErrInvalidSort, len(obj))
+	}
 	for k, v := range obj {
 		s.Field = k
+		if v.IsString() {
+			return json.Unmarshal(v, &s.Order)
+		}
 		sv := sort{}
 		err := sv.UnmarshalJSON(v)
 		if err != nil {
-			return err
+			return newFieldError(err, k)
 		}
 		s.Missing = sv.Missing
 		s.Mode = sv.Mode
 		s.Nested = sv.Nested
 		s.NumericType = sv.NumericType
 		s.Order = sv.Order
-		s.Script = sv.Script
-		s.Type = sv.Type
-		return nil
+		s.UnmappedType = sv.UnmappedType
+		s.Format = sv.Format
+		switch k {
+		case SortFieldScript:
+			s.Script = sv.Script
+			s.Type = sv.Type
+		case SortFieldGeoDistance:
+			gd := &GeoDistanceSort{
+				Unit:             sv.Unit,
+				DistanceType:     sv.DistanceType,
+				IgnoreUnmapped:   sv.IgnoreUnmapped,
+				ValidationMethod: sv.ValidationMethod,
+			}
+			err = gd.unmarshalPoints(v)
+			if err != nil {
+				return newFieldError(err, k)
+			}
+			s.GeoDistance = gd
+		}
 	}
-	// should this return an error if there were no keys or let it be an empty obj?
 	return nil
 }
 
+// GeoDistanceSort sorts hits by the distance of a geo_point field to one or
+// more points. When multiple points are provided, the distance to each is
+// combined by the Mode of the SortEntry.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/sort-search-results.html#geo-sorting
+type GeoDistanceSort struct {
+	// The geo_point field (Required)
+	Field string
+	// The points to calculate the distance from, each in any format
+	// accepted by geo_point fields, such as [lon, lat], "lat,lon", a geohash,
+	// or {"lat": lat, "lon": lon}. (Required)
+	Points []interface{}
+	// The unit of the sort values. Defaults to "m".
+	Unit string
+	// How to compute the distance; either "arc" (default) or "plane"
+	DistanceType DistanceType
+	// Whether an unmapped field should be treated as a missing value. Defaults
+	// to false, which causes an error.
+	IgnoreUnmapped bool
+	// How invalid latitudes and longitudes are handled; one of "STRICT"
+	// (default), "IGNORE_MALFORMED", or "COERCE"
+	ValidationMethod ValidationMethod
+}
+
+// Validate checks that the field and at least one point are set
+func (g GeoDistanceSort) Validate() error {
+	if len(g.Field) == 0 {
+		return ErrFieldRequired
+	}
+	if len(g.Points) == 0 {
+		return ErrPointsRequired
+	}
+	switch g.DistanceType {
+	case "", DistanceTypeArc, DistanceTypePlane:
+	default:
+		return fmt.Errorf("%w <%s>", ErrInvalidDistanceType, g.DistanceType)
+	}
+	switch g.ValidationMethod {
+	case "", ValidationMethodStrict, ValidationMethodIgnoreMalformed, ValidationMethodCoerce:
+	default:
+		return fmt.Errorf("%w <%s>", ErrInvalidValidationMethod, g.ValidationMethod)
+	}
+	return nil
+}
+
+// marshalPoints encodes a single point as itself, otherwise an array
+func (g GeoDistanceSort) marshalPoints() ([]byte, error) {
+	if len(g.Points) == 1 {
+		return json.Marshal(g.Points[0])
+	}
+	return json.Marshal(g.Points)
+}
+
+// unmarshalPoints sets the field and points from the only key of data which
+// is not an option
+func (g *GeoDistanceSort) unmarshalPoints(data dynamic.JSON) error {
+	var obj dynamic.JSONObject
+	err := json.Unmarshal(data, &obj)
+	if err != nil {
+		return err
+	}
+	for k, v := range obj {
+		switch k {
+		case "order", "mode", "unit", "distance_type", "ignore_unmapped", "nested",
+			"numeric_type", "missing", "unmapped_type", "format", "validation_method":
+			continue
+		}
+		g.Field = k
+		var points []interface{}
+		if v.IsArray() {
+			err = json.Unmarshal(v, &points)
+			if err != nil {
+				return err
+			}
+			// a single point as [lon, lat]
+			if len(points) > 0 {
+				if _, ok := points[0].(float64); ok {
+					points = []interface{}{points}
+				}
+			}
+		} else {
+			var p interface{}
+			err = json.Unmarshal(v, &p)
+			if err != nil {
+				return err
+			}
+			points = []interface{}{p}
+		}
+		g.Points = points
+		return nil
+	}
+	return ErrPointsRequired
+}
+
 //easyjson:json
 type sort struct {
-	Order       SortOrder   `json:"order,omitempty"`
-	Mode        SortMode    `json:"mode,omitempty"`
-	NumericType string      `json:"numeric_type,omitempty"`
-	Missing     string      `json:"missing,omitempty"`
-	Type        string      `json:"type,omitempty"`
-	Script      *Script     `json:"script,omitempty"`
-	Nested      *SortNested `json:"nested,omitempty"`
+	Order            SortOrder        `json:"order,omitempty"`
+	Mode             SortMode         `json:"mode,omitempty"`
+	NumericType      string           `json:"numeric_type,omitempty"`
+	Missing          interface{}      `json:"missing,omitempty"`
+	UnmappedType     string           `json:"unmapped_type,omitempty"`
+	Format           string           `json:"format,omitempty"`
+	Type             string           `json:"type,omitempty"`
+	Script           *Script          `json:"script,omitempty"`
+	Unit             string           `json:"unit,omitempty"`
+	DistanceType     DistanceType     `json:"distance_type,omitempty"`
+	IgnoreUnmapped   bool             `json:"ignore_unmapped,omitempty"`
+	Nested           *SortNested      `json:"nested,omitempty"`
+	ValidationMethod ValidationMethod `json:"validation_method,omitempty"`
 }
@@ -0,0 +1,142 @@
+package picker
+
+// SortProcessorParams creates a SortProcessor, which sorts the elements of an
+// array ascending or descending.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/sort-processor.html
+type SortProcessorParams struct {
+	// Field is the field to be sorted
+	Field string
+	// Order is the sort order to use. Accepts "asc" or "desc". Defaults to
+	// "asc".
+	Order SortOrder
+	// TargetField is the field to assign the sorted value to. By default, field
+	// is updated in-place.
+	TargetField string
+	// Description of the processor. Useful for describing the purpose of the
+	// processor or its configuration.
+	Description string
+	// Conditionally execute the processor
+	If string
+	// Ignore failures for the processor
+	IgnoreFailure bool
+	// Handle failures for the processor
+	OnFailure Procs
+	// Identifier for the processor. Useful for debugging and metrics.
+	Tag string
+}
+
+func (SortProcessorParams) Kind() ProcessorKind {
+	return ProcessorKindSort
+}
+
+func (p SortProcessorParams) Processor() (Processor, error) {
+	return p.Sort()
+}
+
+func (p SortProcessorParams) Sort() (*SortProcessor, error) {
+	proc := &SortProcessor{}
+	err := proc.SetField(p.Field)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindSort)
+	}
+	err = proc.SetOrder(p.Order)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindSort)
+	}
+	proc.SetTargetField(p.TargetField)
+	proc.SetDescription(p.Description)
+	proc.SetIf(p.If)
+	proc.SetIgnoreFailure(p.IgnoreFailure)
+	err = proc.SetOnFailure(p.OnFailure)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindSort)
+	}
+	proc.SetTag(p.Tag)
+	return proc, nil
+}
+
+// SortProcessor sorts the elements of an array ascending or descending.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/sort-processor.html
+type SortProcessor struct {
+	fieldParam
+	order SortOrder
+	targetFieldParam
+	processorParams
+}
+
+var _ Processor = (*SortProcessor)(nil)
+
+func (SortProcessor) Kind() ProcessorKind {
+	return ProcessorKindSort
+}
+
+func (p *SortProcessor) Processor() (Processor, error) {
+	return p, nil
+}
+
+// Order is the sort order to use. Accepts "asc" or "desc". Defaults to "asc".
+func (p SortProcessor) Order() SortOrder {
+	return p.order
+}
+
+// SetOrder sets order to v
+func (p *SortProcessor) SetOrder(v SortOrder) error {
+	if len(v) > 0 && !v.IsValid() {
+		return ErrInvalidSortOrder
+	}
+	p.order = v
+	return nil
+}
+
+func (p SortProcessor) MarshalBSON() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+func (p SortProcessor) MarshalJSON() ([]byte, error) {
+	return sortProcessor{
+		Field:         p.field,
+		Order:         p.order,
+		TargetField:   p.targetField,
+		Description:   p.description,
+		If:            p.ifCond,
+		IgnoreFailure: p.ignoreFailure,
+		OnFailure:     p.onFailure,
+		Tag:           p.tag,
+	}.MarshalJSON()
+}
+
+func (p *SortProcessor) UnmarshalBSON(data []byte) error {
+	return p.UnmarshalJSON(data)
+}
+
+func (p *SortProcessor) UnmarshalJSON(data []byte) error {
+	*p = SortProcessor{}
+	var v sortProcessor
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	p.field = v.Field
+	p.order = v.Order
+	p.targetField = v.TargetField
+	p.description = v.Description
+	p.ifCond = v.If
+	p.ignoreFailure = v.IgnoreFailure
+	p.onFailure = v.OnFailure
+	p.tag = v.Tag
+	return nil
+}
+
+//easyjson:json
+type sortProcessor struct {
+	Field         string     `json:"field,omitempty"`
+	Order         SortOrder  `json:"order,omitempty"`
+	TargetField   string     `json:"target_field,omitempty"`
+	Description   string     `json:"description,omitempty"`
+	If            string     `json:"if,omitempty"`
+	IgnoreFailure bool       `json:"ignore_failure,omitempty"`
+	OnFailure     Processors `json:"on_failure,omitempty"`
+	Tag           string     `json:"tag,omitempty"`
+}
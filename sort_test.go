@@ -0,0 +1,176 @@
+package picker_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/chanced/cmpjson"
+	"github.com/chanced/picker"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSort(t *testing.T) {
+	assert := require.New(t)
+	data := []byte(`[
+		{ "post_date": { "order": "asc", "format": "strict_date_optional_time_nanos" } },
+		"user",
+		{ "name": "desc" },
+		{ "age": { "order": "desc", "missing": "_last", "unmapped_type": "long" } },
+		{ "price": { "missing": 0, "mode": "avg" } },
+		{
+			"_geo_distance": {
+				"pin.location": [-70, 40],
+				"order": "asc",
+				"unit": "km",
+				"mode": "min",
+				"distance_type": "arc",
+				"ignore_unmapped": true
+			}
+		},
+		{
+			"_geo_distance": {
+				"pin.location": [[-70, 40], [-71, 42]],
+				"order": "asc",
+				"unit": "km"
+			}
+		},
+		{
+			"_script": {
+				"type": "number",
+				"script": { "lang": "painless", "source": "doc['field_name'].value * params.factor", "params": { "factor": 1.1 } },
+				"order": "asc"
+			}
+		},
+		"_score",
+		"_doc"
+	]`)
+	var sort picker.Sort
+	err := json.Unmarshal(data, &sort)
+	assert.NoError(err)
+	assert.Len(sort, 10)
+	assert.NoError(sort.Validate())
+	assert.Equal("user", sort[1].Field)
+	assert.Equal(picker.SortOrder(picker.SortOrderDescending), sort[2].Order)
+	assert.Equal(picker.SortMissingLast, sort[3].Missing)
+	assert.Equal("long", sort[3].UnmappedType)
+	assert.Equal(0.0, sort[4].Missing)
+	geo := sort[5].GeoDistance
+	assert.NotNil(geo)
+	assert.Equal("pin.location", geo.Field)
+	assert.Len(geo.Points, 1)
+	assert.True(geo.IgnoreUnmapped)
+	assert.Len(sort[6].GeoDistance.Points, 2)
+	assert.Equal(picker.SortFieldScript, sort[7].Key())
+	assert.Equal(picker.ScriptSortTypeNumber, sort[7].Type)
+	assert.Equal(picker.SortFieldScore, sort[8].Field)
+
+	sd, err := json.Marshal(sort)
+	assert.NoError(err)
+	expected := []byte(`[
+		{ "post_date": { "order": "asc", "format": "strict_date_optional_time_nanos" } },
+		{ "user": {} },
+		{ "name": { "order": "desc" } },
+		{ "age": { "order": "desc", "missing": "_last", "unmapped_type": "long" } },
+		{ "price": { "missing": 0, "mode": "avg" } },
+		{
+			"_geo_distance": {
+				"pin.location": [-70, 40],
+				"order": "asc",
+				"unit": "km",
+				"mode": "min",
+				"distance_type": "arc",
+				"ignore_unmapped": true
+			}
+		},
+		{
+			"_geo_distance": {
+				"pin.location": [[-70, 40], [-71, 42]],
+				"order": "asc",
+				"unit": "km"
+			}
+		},
+		{
+			"_script": {
+				"type": "number",
+				"script": { "lang": "painless", "source": "doc['field_name'].value * params.factor", "params": { "factor": 1.1 } },
+				"order": "asc"
+			}
+		},
+		{ "_score": {} },
+		{ "_doc": {} }
+	]`)
+	assert.True(cmpjson.Equal(expected, sd), cmpjson.Diff(expected, sd))
+
+	// a single entry is accepted in place of an array
+	var s picker.Search
+	err = json.Unmarshal([]byte(`{"sort": "_score"}`), &s)
+	assert.NoError(err)
+	assert.Equal(picker.Sort{{Field: "_score"}}, s.Sort())
+	err = json.Unmarshal([]byte(`{"sort": { "post_date": "asc" }}`), &s)
+	assert.NoError(err)
+	assert.Equal(picker.Sort{{Field: "post_date", Order: picker.SortOrderAscending}}, s.Sort())
+
+	err = json.Unmarshal([]byte(`{"sort": [{ "a": "asc", "b": "desc" }]}`), &s)
+	assert.True(errors.Is(err, picker.ErrInvalidSort))
+
+	gd := []byte(`[{
+		"_geo_distance": {
+			"pin.location": "drm3btev3e86",
+			"validation_method": "COERCE",
+			"unit": "km"
+		}
+	}]`)
+	var gs picker.Sort
+	err = json.Unmarshal(gd, &gs)
+	assert.NoError(err)
+	assert.Equal(picker.ValidationMethodCoerce, gs[0].GeoDistance.ValidationMethod)
+	assert.NoError(gs.Validate())
+	gsd, err := json.Marshal(gs)
+	assert.NoError(err)
+	assert.True(cmpjson.Equal(gd, gsd), cmpjson.Diff(gd, gsd))
+}
+
+func TestSortValidate(t *testing.T) {
+	assert := require.New(t)
+	_, err := picker.NewSearch(picker.SearchParams{
+		Sort: picker.Sort{{Field: "date", Order: "up"}},
+	})
+	assert.True(errors.Is(err, picker.ErrInvalidSortOrder))
+	err = picker.Sort{{}}.Validate()
+	assert.True(errors.Is(err, picker.ErrFieldRequired))
+	err = picker.Sort{{Script: &picker.Script{Source: "doc['n'].value"}}}.Validate()
+	assert.True(errors.Is(err, picker.ErrInvalidScriptSortType))
+	err = picker.Sort{{GeoDistance: &picker.GeoDistanceSort{Field: "pin.location"}}}.Validate()
+	assert.True(errors.Is(err, picker.ErrPointsRequired))
+	err = picker.Sort{{GeoDistance: &picker.GeoDistanceSort{
+		Field:        "pin.location",
+		Points:       []interface{}{"drm3btev3e86"},
+		DistanceType: "sphere",
+	}}}.Validate()
+	assert.True(errors.Is(err, picker.ErrInvalidDistanceType))
+	err = picker.Sort{{GeoDistance: &picker.GeoDistanceSort{
+		Field:            "pin.location",
+		Points:           []interface{}{"drm3btev3e86"},
+		ValidationMethod: "LENIENT",
+	}}}.Validate()
+	assert.True(errors.Is(err, picker.ErrInvalidValidationMethod))
+
+	sd, err := json.Marshal(picker.Sort{{
+		Order: picker.SortOrderAscending,
+		GeoDistance: &picker.GeoDistanceSort{
+			Field:  "pin.location",
+			Points: []interface{}{"drm3btev3e86", map[string]float64{"lat": 40, "lon": -70}},
+			Unit:   "km",
+		},
+	}})
+	assert.NoError(err)
+	expected := []byte(`[{
+		"_geo_distance": {
+			"pin.location": ["drm3btev3e86", { "lat": 40, "lon": -70 }],
+			"order": "asc",
+			"unit": "km"
+		}
+	}]`)
+	assert.True(cmpjson.Equal(expected, sd), cmpjson.Diff(expected, sd))
+}
@@ -0,0 +1,169 @@
+package picker
+
+// SplitProcessorParams creates a SplitProcessor, which splits a field into an
+// array using a separator character. Only works on string fields.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/split-processor.html
+type SplitProcessorParams struct {
+	// Field is the field to split
+	Field string
+	// Separator is a regex which matches the separator, e.g. "," or "\\s+"
+	Separator string
+	// TargetField is the field to assign the output to. By default, field is
+	// updated in-place.
+	TargetField string
+	// If IgnoreMissing is true and field does not exist or is null, the
+	// processor quietly exits without modifying the document
+	IgnoreMissing bool
+	// PreserveTrailing indicates whether trailing empty fields, if any, are
+	// preserved
+	PreserveTrailing bool
+	// Description of the processor. Useful for describing the purpose of the
+	// processor or its configuration.
+	Description string
+	// Conditionally execute the processor
+	If string
+	// Ignore failures for the processor
+	IgnoreFailure bool
+	// Handle failures for the processor
+	OnFailure Procs
+	// Identifier for the processor. Useful for debugging and metrics.
+	Tag string
+}
+
+func (SplitProcessorParams) Kind() ProcessorKind {
+	return ProcessorKindSplit
+}
+
+func (p SplitProcessorParams) Processor() (Processor, error) {
+	return p.Split()
+}
+
+func (p SplitProcessorParams) Split() (*SplitProcessor, error) {
+	proc := &SplitProcessor{}
+	err := proc.SetField(p.Field)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindSplit)
+	}
+	err = proc.SetSeparator(p.Separator)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindSplit)
+	}
+	proc.SetTargetField(p.TargetField)
+	proc.SetIgnoreMissing(p.IgnoreMissing)
+	proc.SetPreserveTrailing(p.PreserveTrailing)
+	proc.SetDescription(p.Description)
+	proc.SetIf(p.If)
+	proc.SetIgnoreFailure(p.IgnoreFailure)
+	err = proc.SetOnFailure(p.OnFailure)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindSplit)
+	}
+	proc.SetTag(p.Tag)
+	return proc, nil
+}
+
+// SplitProcessor splits a field into an array using a separator character. Only
+// works on string fields.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/split-processor.html
+type SplitProcessor struct {
+	fieldParam
+	separator string
+	targetFieldParam
+	ignoreMissingParam
+	preserveTrailing bool
+	processorParams
+}
+
+var _ Processor = (*SplitProcessor)(nil)
+
+func (SplitProcessor) Kind() ProcessorKind {
+	return ProcessorKindSplit
+}
+
+func (p *SplitProcessor) Processor() (Processor, error) {
+	return p, nil
+}
+
+// Separator is a regex which matches the separator, e.g. "," or "\\s+"
+func (p SplitProcessor) Separator() string {
+	return p.separator
+}
+
+// SetSeparator sets separator to v
+func (p *SplitProcessor) SetSeparator(v string) error {
+	if len(v) == 0 {
+		return ErrSeparatorRequired
+	}
+	p.separator = v
+	return nil
+}
+
+// PreserveTrailing indicates whether trailing empty fields, if any, are
+// preserved
+func (p SplitProcessor) PreserveTrailing() bool {
+	return p.preserveTrailing
+}
+
+// SetPreserveTrailing sets preserve_trailing to v
+func (p *SplitProcessor) SetPreserveTrailing(v bool) {
+	p.preserveTrailing = v
+}
+
+func (p SplitProcessor) MarshalBSON() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+func (p SplitProcessor) MarshalJSON() ([]byte, error) {
+	return splitProcessor{
+		Field:            p.field,
+		Separator:        p.separator,
+		TargetField:      p.targetField,
+		IgnoreMissing:    p.ignoreMissing,
+		PreserveTrailing: p.preserveTrailing,
+		Description:      p.description,
+		If:               p.ifCond,
+		IgnoreFailure:    p.ignoreFailure,
+		OnFailure:        p.onFailure,
+		Tag:              p.tag,
+	}.MarshalJSON()
+}
+
+func (p *SplitProcessor) UnmarshalBSON(data []byte) error {
+	return p.UnmarshalJSON(data)
+}
+
+func (p *SplitProcessor) UnmarshalJSON(data []byte) error {
+	*p = SplitProcessor{}
+	var v splitProcessor
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	p.field = v.Field
+	p.separator = v.Separator
+	p.targetField = v.TargetField
+	p.ignoreMissing = v.IgnoreMissing
+	p.preserveTrailing = v.PreserveTrailing
+	p.description = v.Description
+	p.ifCond = v.If
+	p.ignoreFailure = v.IgnoreFailure
+	p.onFailure = v.OnFailure
+	p.tag = v.Tag
+	return nil
+}
+
+//easyjson:json
+type splitProcessor struct {
+	Field            string     `json:"field,omitempty"`
+	Separator        string     `json:"separator,omitempty"`
+	TargetField      string     `json:"target_field,omitempty"`
+	IgnoreMissing    bool       `json:"ignore_missing,omitempty"`
+	PreserveTrailing bool       `json:"preserve_trailing,omitempty"`
+	Description      string     `json:"description,omitempty"`
+	If               string     `json:"if,omitempty"`
+	IgnoreFailure    bool       `json:"ignore_failure,omitempty"`
+	OnFailure        Processors `json:"on_failure,omitempty"`
+	Tag              string     `json:"tag,omitempty"`
+}
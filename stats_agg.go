@@ -0,0 +1,106 @@
+package picker
+
+// StatsAggParams creates a StatsAgg, a multi-value metrics aggregation that
+// computes stats over numeric values extracted from the aggregated documents.
+// The stats that are returned consist of: min, max, sum, count and avg.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-stats-aggregation.html
+type StatsAggParams struct {
+	// The field to aggregate on. Either Field or Script is required.
+	Field string
+	// Script to generate values with. If Field is also set, Script is a value
+	// script.
+	Script *Script
+	// Missing is the value used for documents which do not have a value
+	Missing interface{}
+	// Hint as to the type of value produced by Script
+	ValueType string
+	// Format of the value_as_string in the response
+	Format string
+	Meta   map[string]interface{}
+}
+
+func (StatsAggParams) Kind() AggKind {
+	return AggKindStats
+}
+
+func (p StatsAggParams) Clause() (AggClause, error) {
+	return p.Stats()
+}
+
+func (p StatsAggParams) Stats() (*StatsAgg, error) {
+	a := &StatsAgg{}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	err := a.checkValueSource()
+	if err != nil {
+		return a, newAggError(err, AggKindStats)
+	}
+	a.SetMissing(p.Missing)
+	a.SetValueType(p.ValueType)
+	a.SetFormat(p.Format)
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// StatsAgg is a multi-value metrics aggregation that computes min, max, sum,
+// count and avg over numeric values extracted from the aggregated documents.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-stats-aggregation.html
+type StatsAgg struct {
+	valueSourceParam
+	noAggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*StatsAgg)(nil)
+
+func (StatsAgg) Kind() AggKind {
+	return AggKindStats
+}
+
+func (a *StatsAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+func (a StatsAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a StatsAgg) MarshalJSON() ([]byte, error) {
+	return statsAgg{
+		Field:     a.field,
+		Script:    a.script,
+		Missing:   a.missing,
+		ValueType: a.valueType,
+		Format:    a.format,
+	}.MarshalJSON()
+}
+
+func (a *StatsAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *StatsAgg) UnmarshalJSON(data []byte) error {
+	*a = StatsAgg{}
+	p := statsAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	a.SetMissing(p.Missing)
+	a.SetValueType(p.ValueType)
+	a.SetFormat(p.Format)
+	return nil
+}
+
+//easyjson:json
+type statsAgg struct {
+	Field     string      `json:"field,omitempty"`
+	Script    *Script     `json:"script,omitempty"`
+	Missing   interface{} `json:"missing,omitempty"`
+	ValueType string      `json:"value_type,omitempty"`
+	Format    string      `json:"format,omitempty"`
+}
@@ -0,0 +1,98 @@
+package picker
+
+// StatsBucketAggParams creates a StatsBucketAgg, a sibling pipeline aggregation
+// which calculates a variety of stats across all bucket of a specified metric
+// in a sibling aggregation. The specified metric must be numeric and the
+// sibling aggregation must be a multi-bucket aggregation.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-stats-bucket-aggregation.html
+type StatsBucketAggParams struct {
+	// Path to the buckets to aggregate (Required)
+	BucketsPath string
+	// Policy to apply when gaps are found in the data. Defaults to "skip".
+	GapPolicy GapPolicy
+	// Format of the value_as_string in the response
+	Format string
+	Meta   map[string]interface{}
+}
+
+func (StatsBucketAggParams) Kind() AggKind {
+	return AggKindStatsBucket
+}
+
+func (p StatsBucketAggParams) Clause() (AggClause, error) {
+	return p.StatsBucket()
+}
+
+func (p StatsBucketAggParams) StatsBucket() (*StatsBucketAgg, error) {
+	a := &StatsBucketAgg{}
+	err := a.SetBucketsPath(p.BucketsPath)
+	if err != nil {
+		return a, newAggError(err, AggKindStatsBucket)
+	}
+	err = a.SetGapPolicy(p.GapPolicy)
+	if err != nil {
+		return a, newAggError(err, AggKindStatsBucket)
+	}
+	a.SetFormat(p.Format)
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// StatsBucketAgg is a sibling pipeline aggregation which calculates a variety
+// of stats across all bucket of a specified metric in a sibling aggregation.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-stats-bucket-aggregation.html
+type StatsBucketAgg struct {
+	bucketsPathParam
+	gapPolicyParam
+	pipelineFormatParam
+	noAggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*StatsBucketAgg)(nil)
+
+func (StatsBucketAgg) Kind() AggKind {
+	return AggKindStatsBucket
+}
+
+func (a *StatsBucketAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+func (a StatsBucketAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a StatsBucketAgg) MarshalJSON() ([]byte, error) {
+	return statsBucketAgg{
+		BucketsPath: a.bucketsPath,
+		GapPolicy:   a.gapPolicy,
+		Format:      a.format,
+	}.MarshalJSON()
+}
+
+func (a *StatsBucketAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *StatsBucketAgg) UnmarshalJSON(data []byte) error {
+	*a = StatsBucketAgg{}
+	p := statsBucketAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.bucketsPath = p.BucketsPath
+	a.gapPolicy = p.GapPolicy
+	a.SetFormat(p.Format)
+	return nil
+}
+
+//easyjson:json
+type statsBucketAgg struct {
+	BucketsPath string    `json:"buckets_path"`
+	GapPolicy   GapPolicy `json:"gap_policy,omitempty"`
+	Format      string    `json:"format,omitempty"`
+}
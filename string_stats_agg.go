@@ -0,0 +1,114 @@
+package picker
+
+// StringStatsAggParams creates a StringStatsAgg, a multi-value metrics
+// aggregation that computes statistics over string values extracted from the
+// aggregated documents.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-string-stats-aggregation.html
+type StringStatsAggParams struct {
+	// The field to aggregate on. Either Field or Script is required.
+	Field string
+	// Script to generate values with. If Field is also set, Script is a value
+	// script.
+	Script *Script
+	// Missing is the value used for documents which do not have a value
+	Missing interface{}
+	// If true, the response includes the probability distribution of all
+	// characters.
+	ShowDistribution bool
+	Meta             map[string]interface{}
+}
+
+func (StringStatsAggParams) Kind() AggKind {
+	return AggKindStringStats
+}
+
+func (p StringStatsAggParams) Clause() (AggClause, error) {
+	return p.StringStats()
+}
+
+func (p StringStatsAggParams) StringStats() (*StringStatsAgg, error) {
+	a := &StringStatsAgg{}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	err := a.checkValueSource()
+	if err != nil {
+		return a, newAggError(err, AggKindStringStats)
+	}
+	a.SetMissing(p.Missing)
+	a.SetShowDistribution(p.ShowDistribution)
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// StringStatsAgg is a multi-value metrics aggregation that computes count,
+// min_length, max_length, avg_length and entropy of string values extracted
+// from the aggregated documents.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-string-stats-aggregation.html
+type StringStatsAgg struct {
+	valueSourceParam
+	showDistribution bool
+	noAggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*StringStatsAgg)(nil)
+
+func (StringStatsAgg) Kind() AggKind {
+	return AggKindStringStats
+}
+
+func (a *StringStatsAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// ShowDistribution indicates whether the probability distribution of all
+// characters is returned.
+func (a StringStatsAgg) ShowDistribution() bool {
+	return a.showDistribution
+}
+
+// SetShowDistribution sets show_distribution to v
+func (a *StringStatsAgg) SetShowDistribution(v bool) {
+	a.showDistribution = v
+}
+
+func (a StringStatsAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a StringStatsAgg) MarshalJSON() ([]byte, error) {
+	return stringStatsAgg{
+		Field:            a.field,
+		Script:           a.script,
+		Missing:          a.missing,
+		ShowDistribution: a.showDistribution,
+	}.MarshalJSON()
+}
+
+func (a *StringStatsAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *StringStatsAgg) UnmarshalJSON(data []byte) error {
+	*a = StringStatsAgg{}
+	p := stringStatsAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	a.SetMissing(p.Missing)
+	a.showDistribution = p.ShowDistribution
+	return nil
+}
+
+//easyjson:json
+type stringStatsAgg struct {
+	Field            string      `json:"field,omitempty"`
+	Script           *Script     `json:"script,omitempty"`
+	Missing          interface{} `json:"missing,omitempty"`
+	ShowDistribution bool        `json:"show_distribution,omitempty"`
+}
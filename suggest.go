@@ -0,0 +1,304 @@
+package picker
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/chanced/dynamic"
+)
+
+// SuggesterKind is the type of a Suggester
+type SuggesterKind string
+
+const (
+	SuggesterKindTerm       SuggesterKind = "term"
+	SuggesterKindPhrase     SuggesterKind = "phrase"
+	SuggesterKindCompletion SuggesterKind = "completion"
+)
+
+func (k SuggesterKind) String() string {
+	return string(k)
+}
+
+// SuggestMode controls which suggestions are included or controls for what
+// suggest text terms, suggestions should be suggested.
+type SuggestMode string
+
+const (
+	SuggestModeUnspecified SuggestMode = ""
+	// SuggestModeMissing only provides suggestions for suggest text terms that
+	// are not in the index. This is the default.
+	SuggestModeMissing SuggestMode = "missing"
+	// SuggestModePopular only suggests suggestions that occur in more docs
+	// than the original suggest text term.
+	SuggestModePopular SuggestMode = "popular"
+	// SuggestModeAlways suggests any matching suggestions based on terms in
+	// the suggest text.
+	SuggestModeAlways SuggestMode = "always"
+)
+
+func (m SuggestMode) String() string {
+	return string(m)
+}
+
+func (m SuggestMode) Validate() error {
+	switch m {
+	case SuggestModeUnspecified, SuggestModeMissing, SuggestModePopular, SuggestModeAlways:
+		return nil
+	}
+	return fmt.Errorf("%w <%s>", ErrInvalidSuggestMode, m)
+}
+
+// Suggest suggests similar looking terms based on a provided text by using a
+// suggester. Each Suggester is keyed by name, which is used as the key of its
+// suggestions in the response.
+//
+//	picker.Suggest{
+//	    Text: "tring out Elasticsearch",
+//	    Suggesters: map[string]picker.Suggester{
+//	        "my-suggest-1": {Term: &picker.TermSuggester{Field: "message"}},
+//	        "my-suggest-2": {Term: &picker.TermSuggester{Field: "user.id"}},
+//	    },
+//	}
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-suggesters.html
+type Suggest struct {
+	// Global suggest text, used by suggesters which do not specify their own
+	// text.
+	Text string
+	// Suggesters, keyed by name (Required)
+	Suggesters map[string]Suggester
+}
+
+// Validate checks that s has at least one suggester and that each suggester
+// is valid.
+func (s Suggest) Validate() error {
+	if len(s.Suggesters) == 0 {
+		return ErrSuggesterRequired
+	}
+	for name, v := range s.Suggesters {
+		err := v.validate(s.Text)
+		if err != nil {
+			return newFieldError(err, name)
+		}
+	}
+	return nil
+}
+
+// ValidateMappings checks that the field of each completion suggester is a
+// CompletionField and that the contexts of the suggester are configured on
+// the field.
+func (s Suggest) ValidateMappings(m Mappings) error {
+	fm, err := m.FieldMappings()
+	if err != nil {
+		return err
+	}
+	for name, v := range s.Suggesters {
+		if v.Completion == nil {
+			continue
+		}
+		err = v.Completion.validateFields(fm.Properties)
+		if err != nil {
+			return newFieldError(err, name)
+		}
+	}
+	return nil
+}
+
+func (s Suggest) MarshalBSON() ([]byte, error) {
+	return s.MarshalJSON()
+}
+
+func (s Suggest) MarshalJSON() ([]byte, error) {
+	obj := make(dynamic.JSONObject, len(s.Suggesters)+1)
+	if len(s.Text) > 0 {
+		b, err := json.Marshal(s.Text)
+		if err != nil {
+			return nil, err
+		}
+		obj["text"] = b
+	}
+	for name, v := range s.Suggesters {
+		b, err := v.MarshalJSON()
+		if err != nil {
+			return nil, newFieldError(err, name)
+		}
+		obj[name] = b
+	}
+	return json.Marshal(obj)
+}
+
+func (s *Suggest) UnmarshalBSON(data []byte) error {
+	return s.UnmarshalJSON(data)
+}
+
+func (s *Suggest) UnmarshalJSON(data []byte) error {
+	*s = Suggest{}
+	var obj dynamic.JSONObject
+	err := json.Unmarshal(data, &obj)
+	if err != nil {
+		return err
+	}
+	for k, d := range obj {
+		if k == "text" {
+			err = json.Unmarshal(d, &s.Text)
+			if err != nil {
+				return newFieldError(err, k)
+			}
+			continue
+		}
+		var v Suggester
+		err = v.UnmarshalJSON(d)
+		if err != nil {
+			return newFieldError(err, k)
+		}
+		if s.Suggesters == nil {
+			s.Suggesters = map[string]Suggester{}
+		}
+		s.Suggesters[k] = v
+	}
+	return nil
+}
+
+// Suggester is a named suggester of Suggest. Exactly one of Term, Phrase, or
+// Completion must be set.
+type Suggester struct {
+	// The text to get suggestions for. Overrides the global text of Suggest.
+	Text string
+	// The prefix to get completion suggestions for. Only valid for completion
+	// suggesters.
+	Prefix string
+	// The regular expression to get completion suggestions for. Only valid
+	// for completion suggesters.
+	Regex      string
+	Term       *TermSuggester
+	Phrase     *PhraseSuggester
+	Completion *CompletionSuggester
+}
+
+// Kind returns the type of the suggester, e.g. "term"
+func (s Suggester) Kind() SuggesterKind {
+	switch {
+	case s.Term != nil:
+		return SuggesterKindTerm
+	case s.Phrase != nil:
+		return SuggesterKindPhrase
+	case s.Completion != nil:
+		return SuggesterKindCompletion
+	}
+	return ""
+}
+
+// Validate checks that s has exactly one valid suggester and the text it
+// requires. Use Suggest.Validate to account for the global suggest text.
+func (s Suggester) Validate() error {
+	return s.validate("")
+}
+
+func (s Suggester) validate(globalText string) error {
+	n := 0
+	var err error
+	if s.Term != nil {
+		n++
+		err = s.Term.Validate()
+	}
+	if s.Phrase != nil {
+		n++
+		err = s.Phrase.Validate()
+	}
+	if s.Completion != nil {
+		n++
+		err = s.Completion.Validate()
+	}
+	if n == 0 {
+		return ErrSuggesterRequired
+	}
+	if n > 1 {
+		return ErrMultipleSuggesters
+	}
+	if err != nil {
+		return err
+	}
+	if s.Completion == nil && (len(s.Prefix) > 0 || len(s.Regex) > 0) {
+		return fmt.Errorf("%w; prefix and regex are only supported by completion suggesters", ErrInvalidSuggestText)
+	}
+	if len(s.Prefix) > 0 && len(s.Regex) > 0 {
+		return fmt.Errorf("%w; prefix and regex are mutually exclusive", ErrInvalidSuggestText)
+	}
+	if len(s.Text) == 0 && len(s.Prefix) == 0 && len(s.Regex) == 0 && len(globalText) == 0 {
+		return ErrSuggestTextRequired
+	}
+	return nil
+}
+
+func (s Suggester) MarshalBSON() ([]byte, error) {
+	return s.MarshalJSON()
+}
+
+func (s Suggester) MarshalJSON() ([]byte, error) {
+	var body dynamic.JSON
+	var err error
+	switch {
+	case s.Term != nil:
+		body, err = s.Term.MarshalJSON()
+	case s.Phrase != nil:
+		body, err = s.Phrase.MarshalJSON()
+	case s.Completion != nil:
+		body, err = s.Completion.MarshalJSON()
+	default:
+		return nil, ErrSuggesterRequired
+	}
+	if err != nil {
+		return nil, err
+	}
+	obj := dynamic.JSONObject{s.Kind().String(): body}
+	texts := map[string]string{"text": s.Text, "prefix": s.Prefix, "regex": s.Regex}
+	for k, v := range texts {
+		if len(v) == 0 {
+			continue
+		}
+		obj[k], err = json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(obj)
+}
+
+func (s *Suggester) UnmarshalBSON(data []byte) error {
+	return s.UnmarshalJSON(data)
+}
+
+func (s *Suggester) UnmarshalJSON(data []byte) error {
+	*s = Suggester{}
+	var obj dynamic.JSONObject
+	err := json.Unmarshal(data, &obj)
+	if err != nil {
+		return err
+	}
+	for k, d := range obj {
+		switch k {
+		case "text":
+			err = json.Unmarshal(d, &s.Text)
+		case "prefix":
+			err = json.Unmarshal(d, &s.Prefix)
+		case "regex":
+			err = json.Unmarshal(d, &s.Regex)
+		case "term":
+			s.Term = &TermSuggester{}
+			err = s.Term.UnmarshalJSON(d)
+		case "phrase":
+			s.Phrase = &PhraseSuggester{}
+			err = s.Phrase.UnmarshalJSON(d)
+		case "completion":
+			s.Completion = &CompletionSuggester{}
+			err = s.Completion.UnmarshalJSON(d)
+		default:
+			err = fmt.Errorf("%w <%s>", ErrUnsupportedType, k)
+		}
+		if err != nil {
+			return newFieldError(err, k)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package picker
+
+import (
+	"strings"
+
+	"github.com/chanced/dynamic"
+)
+
+// SuggestResults are the suggestions of a search response, keyed by the name
+// of the suggester. Each suggester returns an entry per token (term) or per
+// text (phrase, completion).
+type SuggestResults map[string][]SuggestEntry
+
+// Get returns the entries of the suggester with the given name. Names which
+// are prefixed by their type, as is the case when typed_keys is set, are also
+// matched.
+func (r SuggestResults) Get(name string) []SuggestEntry {
+	if v, ok := r[name]; ok {
+		return v
+	}
+	for k, v := range r {
+		if i := strings.IndexByte(k, '#'); i >= 0 && k[i+1:] == name {
+			return v
+		}
+	}
+	return nil
+}
+
+// Options returns the options of all entries of the suggester with the given
+// name.
+func (r SuggestResults) Options(name string) []SuggestOption {
+	var res []SuggestOption
+	for _, e := range r.Get(name) {
+		res = append(res, e.Options...)
+	}
+	return res
+}
+
+// SuggestEntry is the suggestions for a token or text of a suggester
+//
+//easyjson:json
+type SuggestEntry struct {
+	// The token or text suggestions were made for
+	Text   string `json:"text"`
+	Offset int    `json:"offset"`
+	Length int    `json:"length"`
+	// The suggestions
+	Options []SuggestOption `json:"options"`
+}
+
+// SuggestOption is a suggestion of a SuggestEntry. The fields set depend on
+// the type of the suggester.
+type SuggestOption struct {
+	// The suggested text
+	Text string `json:"text"`
+	// The score of the suggestion
+	Score float64 `json:"score"`
+	// The document frequency of the suggested term (term)
+	Freq int `json:"freq,omitempty"`
+	// The highlighted suggested text (phrase)
+	Highlighted string `json:"highlighted,omitempty"`
+	// Whether the collate query matched the suggestion; only set when collate
+	// prune is true (phrase)
+	CollateMatch *bool `json:"collate_match,omitempty"`
+	// The index of the suggested document (completion)
+	Index string `json:"_index,omitempty"`
+	// The ID of the suggested document (completion)
+	ID string `json:"_id,omitempty"`
+	// The source of the suggested document (completion)
+	Source dynamic.JSON `json:"_source,omitempty"`
+	// The contexts of the suggestion (completion)
+	Contexts map[string][]string `json:"contexts,omitempty"`
+}
+
+// DecodeSource decodes the _source of the suggested document into v
+func (o SuggestOption) DecodeSource(v interface{}) error {
+	return Hit{ID: o.ID, Source: o.Source}.DecodeSource(v)
+}
+
+func (o SuggestOption) MarshalJSON() ([]byte, error) {
+	return suggestOption{
+		Text:         o.Text,
+		Score:        &o.Score,
+		Freq:         o.Freq,
+		Highlighted:  o.Highlighted,
+		CollateMatch: o.CollateMatch,
+		Index:        o.Index,
+		ID:           o.ID,
+		Source:       o.Source,
+		Contexts:     o.Contexts,
+	}.MarshalJSON()
+}
+
+// UnmarshalJSON decodes the score from either score (term, phrase) or _score
+// (completion)
+func (o *SuggestOption) UnmarshalJSON(data []byte) error {
+	*o = SuggestOption{}
+	var v suggestOption
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	*o = SuggestOption{
+		Text:         v.Text,
+		Freq:         v.Freq,
+		Highlighted:  v.Highlighted,
+		CollateMatch: v.CollateMatch,
+		Index:        v.Index,
+		ID:           v.ID,
+		Source:       v.Source,
+		Contexts:     v.Contexts,
+	}
+	switch {
+	case v.Score != nil:
+		o.Score = *v.Score
+	case v.DocScore != nil:
+		o.Score = *v.DocScore
+	}
+	return nil
+}
+
+//easyjson:json
+type suggestOption struct {
+	Text         string              `json:"text"`
+	Score        *float64            `json:"score,omitempty"`
+	DocScore     *float64            `json:"_score,omitempty"`
+	Freq         int                 `json:"freq,omitempty"`
+	Highlighted  string              `json:"highlighted,omitempty"`
+	CollateMatch *bool               `json:"collate_match,omitempty"`
+	Index        string              `json:"_index,omitempty"`
+	ID           string              `json:"_id,omitempty"`
+	Source       dynamic.JSON        `json:"_source,omitempty"`
+	Contexts     map[string][]string `json:"contexts,omitempty"`
+}
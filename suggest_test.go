@@ -0,0 +1,303 @@
+package picker_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/chanced/cmpjson"
+	"github.com/chanced/picker"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuggest(t *testing.T) {
+	assert := require.New(t)
+	data := []byte(`{
+		"suggest": {
+			"text": "tring out Elasticsearch",
+			"my-suggest-1": {
+				"term": { "field": "message", "suggest_mode": "popular", "max_edits": 1, "sort": "frequency" }
+			},
+			"simple_phrase": {
+				"text": "noble prize",
+				"phrase": {
+					"field": "title.trigram",
+					"size": 1,
+					"gram_size": 3,
+					"direct_generator": [
+						{ "field": "title.trigram", "suggest_mode": "always" },
+						{ "field": "title.reverse", "suggest_mode": "always", "pre_filter": "reverse", "post_filter": "reverse" }
+					],
+					"smoothing": { "laplace": { "alpha": 0.7 } },
+					"highlight": { "pre_tag": "<em>", "post_tag": "</em>" },
+					"collate": {
+						"query": { "source": { "match": { "title": { "query": "{{suggestion}}" } } } },
+						"prune": true
+					}
+				}
+			},
+			"place_suggestion": {
+				"prefix": "tim",
+				"completion": {
+					"field": "suggest",
+					"size": 10,
+					"skip_duplicates": true,
+					"fuzzy": { "fuzziness": 2 },
+					"contexts": {
+						"place_type": [
+							{ "context": "cafe" },
+							{ "context": "restaurants", "boost": 2 }
+						],
+						"location": [
+							{ "lat": 43.662, "lon": -79.380 }
+						]
+					}
+				}
+			}
+		}
+	}`)
+	var s picker.Search
+	err := json.Unmarshal(data, &s)
+	assert.NoError(err)
+	sg := s.Suggest()
+	assert.NotNil(sg)
+	assert.Empty(s.Passthrough())
+	assert.Equal("tring out Elasticsearch", sg.Text)
+	assert.Len(sg.Suggesters, 3)
+	assert.Equal(picker.SuggesterKindPhrase, sg.Suggesters["simple_phrase"].Kind())
+	phrase := sg.Suggesters["simple_phrase"].Phrase
+	assert.Len(phrase.DirectGenerators, 2)
+	assert.Equal(0.7, phrase.Smoothing.Laplace.Alpha)
+	assert.NotNil(phrase.Collate.Query)
+	completion := sg.Suggesters["place_suggestion"].Completion
+	assert.Equal(2.0, completion.Contexts["place_type"][1].Boost)
+	assert.NoError(sg.Validate())
+
+	sd, err := json.Marshal(s)
+	assert.NoError(err)
+	// bare context values are encoded as such
+	expected := []byte(`{
+		"suggest": {
+			"text": "tring out Elasticsearch",
+			"my-suggest-1": {
+				"term": { "field": "message", "suggest_mode": "popular", "max_edits": 1, "sort": "frequency" }
+			},
+			"simple_phrase": {
+				"text": "noble prize",
+				"phrase": {
+					"field": "title.trigram",
+					"size": 1,
+					"gram_size": 3,
+					"direct_generator": [
+						{ "field": "title.trigram", "suggest_mode": "always" },
+						{ "field": "title.reverse", "suggest_mode": "always", "pre_filter": "reverse", "post_filter": "reverse" }
+					],
+					"smoothing": { "laplace": { "alpha": 0.7 } },
+					"highlight": { "pre_tag": "<em>", "post_tag": "</em>" },
+					"collate": {
+						"query": { "source": { "match": { "title": { "query": "{{suggestion}}" } } } },
+						"prune": true
+					}
+				}
+			},
+			"place_suggestion": {
+				"prefix": "tim",
+				"completion": {
+					"field": "suggest",
+					"size": 10,
+					"skip_duplicates": true,
+					"fuzzy": { "fuzziness": 2 },
+					"contexts": {
+						"place_type": [
+							"cafe",
+							{ "context": "restaurants", "boost": 2 }
+						],
+						"location": [
+							{ "lat": 43.662, "lon": -79.380 }
+						]
+					}
+				}
+			}
+		}
+	}`)
+	assert.True(cmpjson.Equal(expected, sd), cmpjson.Diff(expected, sd))
+
+	s2, err := picker.NewSearch(picker.SearchParams{
+		Suggest: &picker.Suggest{
+			Suggesters: map[string]picker.Suggester{
+				"simple_phrase": {
+					Text: "noble prize",
+					Phrase: &picker.PhraseSuggester{
+						Field: "title.trigram",
+						Collate: &picker.PhraseSuggestCollate{
+							Query: &picker.QueryParams{
+								Match: picker.MatchQueryParams{Field: "title", Query: "{{suggestion}}"},
+							},
+							Params: map[string]interface{}{"field_name": "title"},
+						},
+					},
+				},
+			},
+		},
+	})
+	assert.NoError(err)
+	sd2, err := json.Marshal(s2)
+	assert.NoError(err)
+	expected2 := []byte(`{
+		"suggest": {
+			"simple_phrase": {
+				"text": "noble prize",
+				"phrase": {
+					"field": "title.trigram",
+					"collate": {
+						"query": { "source": { "match": { "title": { "query": "{{suggestion}}" } } } },
+						"params": { "field_name": "title" }
+					}
+				}
+			}
+		}
+	}`)
+	assert.True(cmpjson.Equal(expected2, sd2), cmpjson.Diff(expected2, sd2))
+
+	var collate picker.PhraseSuggestCollate
+	err = json.Unmarshal([]byte(`{"query": {"source": "{\"match\": {\"title\": {\"query\": \"{{suggestion}}\"}}}"}}`), &collate)
+	assert.NoError(err)
+	assert.NotNil(collate.Query)
+
+	_, err = picker.NewSearch(picker.SearchParams{Suggest: &picker.Suggest{}})
+	assert.True(errors.Is(err, picker.ErrSuggesterRequired))
+	err = picker.Suggester{Text: "foo"}.Validate()
+	assert.True(errors.Is(err, picker.ErrSuggesterRequired))
+	err = picker.Suggester{
+		Text:   "foo",
+		Term:   &picker.TermSuggester{Field: "message"},
+		Phrase: &picker.PhraseSuggester{Field: "message"},
+	}.Validate()
+	assert.True(errors.Is(err, picker.ErrMultipleSuggesters))
+	err = picker.Suggester{Term: &picker.TermSuggester{Field: "message"}}.Validate()
+	assert.True(errors.Is(err, picker.ErrSuggestTextRequired))
+	err = picker.Suggester{Prefix: "foo", Term: &picker.TermSuggester{Field: "message"}}.Validate()
+	assert.True(errors.Is(err, picker.ErrInvalidSuggestText))
+	err = picker.Suggester{Text: "foo", Term: &picker.TermSuggester{Field: "message", MaxEdits: 3}}.Validate()
+	assert.True(errors.Is(err, picker.ErrInvalidMaxEdits))
+	err = picker.Suggester{Text: "foo", Phrase: &picker.PhraseSuggester{
+		Field: "title",
+		Smoothing: &picker.PhraseSmoothing{
+			LinearInterpolation: &picker.LinearInterpolationSmoothing{TrigramLambda: 0.5, BigramLambda: 0.5, UnigramLambda: 0.5},
+		},
+	}}.Validate()
+	assert.True(errors.Is(err, picker.ErrInvalidSmoothingLambdas))
+}
+
+func TestSuggestValidateMappings(t *testing.T) {
+	assert := require.New(t)
+	mappings := picker.Mappings{
+		Properties: picker.FieldMap{
+			"suggest": picker.CompletionFieldParams{
+				Contexts: []picker.CompletionContext{
+					{Name: "place_type", Type: picker.CompletionContextTypeCategory, Path: "cat"},
+					{Name: "location", Type: picker.CompletionContextTypeGeo, Precision: 4, Path: "loc"},
+				},
+			},
+			"title": picker.TextFieldParams{},
+		},
+	}
+	sg := picker.Suggest{Suggesters: map[string]picker.Suggester{
+		"place": {
+			Prefix: "tim",
+			Completion: &picker.CompletionSuggester{
+				Field: "suggest",
+				Contexts: map[string][]picker.CompletionContextQuery{
+					"place_type": {{Context: "cafe", Prefix: true}},
+					"location":   {{Context: map[string]interface{}{"lat": 43.6, "lon": -79.3}, Precision: 2}},
+				},
+			},
+		},
+	}}
+	assert.NoError(sg.ValidateMappings(mappings))
+
+	sg.Suggesters["place"].Completion.Contexts["missing"] = []picker.CompletionContextQuery{{Context: "x"}}
+	err := sg.ValidateMappings(mappings)
+	assert.True(errors.Is(err, picker.ErrContextNotFound))
+	delete(sg.Suggesters["place"].Completion.Contexts, "missing")
+
+	sg.Suggesters["place"].Completion.Contexts["place_type"] = []picker.CompletionContextQuery{{Context: "cafe", Precision: 2}}
+	err = sg.ValidateMappings(mappings)
+	assert.True(errors.Is(err, picker.ErrInvalidContextQuery))
+
+	sg.Suggesters["place"].Completion.Field = "title"
+	err = sg.ValidateMappings(mappings)
+	assert.True(errors.Is(err, picker.ErrCompletionFieldRequired))
+
+	_, err = picker.NewCompletionField(picker.CompletionFieldParams{
+		Contexts: []picker.CompletionContext{{Name: "place_type", Type: "place"}},
+	})
+	assert.True(errors.Is(err, picker.ErrInvalidContextType))
+
+	cf, err := picker.NewCompletionField(picker.CompletionFieldParams{
+		Contexts: []picker.CompletionContext{{Name: "place_type", Type: picker.CompletionContextTypeCategory}},
+	})
+	assert.NoError(err)
+	cfd, err := json.Marshal(cf)
+	assert.NoError(err)
+	assert.True(cmpjson.Equal([]byte(`{"type":"completion","contexts":[{"name":"place_type","type":"category"}]}`), cfd), string(cfd))
+}
+
+func TestSuggestResults(t *testing.T) {
+	assert := require.New(t)
+	data := []byte(`{
+		"took": 2,
+		"timed_out": false,
+		"_shards": { "total": 1, "successful": 1, "skipped": 0, "failed": 0 },
+		"hits": { "total": { "value": 0, "relation": "eq" }, "max_score": null, "hits": [] },
+		"suggest": {
+			"term#my-suggest-1": [
+				{ "text": "tring", "offset": 0, "length": 5, "options": [{ "text": "trying", "score": 0.8, "freq": 1 }] },
+				{ "text": "out", "offset": 6, "length": 3, "options": [] }
+			],
+			"simple_phrase": [
+				{
+					"text": "noble prize",
+					"offset": 0,
+					"length": 11,
+					"options": [{ "text": "nobel prize", "highlighted": "<em>nobel</em> prize", "score": 0.48, "collate_match": true }]
+				}
+			],
+			"song-suggest": [
+				{
+					"text": "nir",
+					"offset": 0,
+					"length": 3,
+					"options": [
+						{
+							"text": "Nirvana",
+							"_index": "music",
+							"_id": "1",
+							"_score": 1.0,
+							"_source": { "suggest": ["Nevermind", "Nirvana"] },
+							"contexts": { "place_type": ["cafe"] }
+						}
+					]
+				}
+			]
+		}
+	}`)
+	var res picker.SearchResponse
+	err := json.Unmarshal(data, &res)
+	assert.NoError(err)
+	term := res.Suggest.Get("my-suggest-1")
+	assert.Len(term, 2)
+	assert.Equal(1, term[0].Options[0].Freq)
+	assert.Len(res.Suggest.Options("my-suggest-1"), 1)
+	phrase := res.Suggest.Get("simple_phrase")[0].Options[0]
+	assert.Equal("<em>nobel</em> prize", phrase.Highlighted)
+	assert.True(*phrase.CollateMatch)
+	song := res.Suggest.Options("song-suggest")[0]
+	assert.Equal(1.0, song.Score)
+	assert.Equal([]string{"cafe"}, song.Contexts["place_type"])
+	var src struct {
+		Suggest []string `json:"suggest"`
+	}
+	assert.NoError(song.DecodeSource(&src))
+	assert.Equal([]string{"Nevermind", "Nirvana"}, src.Suggest)
+}
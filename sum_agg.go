@@ -0,0 +1,105 @@
+package picker
+
+// SumAggParams creates a SumAgg, a single-value metrics aggregation that sums
+// up numeric values that are extracted from the aggregated documents.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-sum-aggregation.html
+type SumAggParams struct {
+	// The field to aggregate on. Either Field or Script is required.
+	Field string
+	// Script to generate values with. If Field is also set, Script is a value
+	// script.
+	Script *Script
+	// Missing is the value used for documents which do not have a value
+	Missing interface{}
+	// Hint as to the type of value produced by Script
+	ValueType string
+	// Format of the value_as_string in the response
+	Format string
+	Meta   map[string]interface{}
+}
+
+func (SumAggParams) Kind() AggKind {
+	return AggKindSum
+}
+
+func (p SumAggParams) Clause() (AggClause, error) {
+	return p.Sum()
+}
+
+func (p SumAggParams) Sum() (*SumAgg, error) {
+	a := &SumAgg{}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	err := a.checkValueSource()
+	if err != nil {
+		return a, newAggError(err, AggKindSum)
+	}
+	a.SetMissing(p.Missing)
+	a.SetValueType(p.ValueType)
+	a.SetFormat(p.Format)
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// SumAgg is a single-value metrics aggregation that sums up numeric values that
+// are extracted from the aggregated documents.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-sum-aggregation.html
+type SumAgg struct {
+	valueSourceParam
+	noAggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*SumAgg)(nil)
+
+func (SumAgg) Kind() AggKind {
+	return AggKindSum
+}
+
+func (a *SumAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+func (a SumAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a SumAgg) MarshalJSON() ([]byte, error) {
+	return sumAgg{
+		Field:     a.field,
+		Script:    a.script,
+		Missing:   a.missing,
+		ValueType: a.valueType,
+		Format:    a.format,
+	}.MarshalJSON()
+}
+
+func (a *SumAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *SumAgg) UnmarshalJSON(data []byte) error {
+	*a = SumAgg{}
+	p := sumAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	a.SetMissing(p.Missing)
+	a.SetValueType(p.ValueType)
+	a.SetFormat(p.Format)
+	return nil
+}
+
+//easyjson:json
+type sumAgg struct {
+	Field     string      `json:"field,omitempty"`
+	Script    *Script     `json:"script,omitempty"`
+	Missing   interface{} `json:"missing,omitempty"`
+	ValueType string      `json:"value_type,omitempty"`
+	Format    string      `json:"format,omitempty"`
+}
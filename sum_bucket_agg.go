@@ -0,0 +1,98 @@
+package picker
+
+// SumBucketAggParams creates a SumBucketAgg, a sibling pipeline aggregation
+// which calculates the sum of a specified metric across all buckets in a
+// sibling aggregation. The specified metric must be numeric and the sibling
+// aggregation must be a multi-bucket aggregation.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-sum-bucket-aggregation.html
+type SumBucketAggParams struct {
+	// Path to the buckets to aggregate (Required)
+	BucketsPath string
+	// Policy to apply when gaps are found in the data. Defaults to "skip".
+	GapPolicy GapPolicy
+	// Format of the value_as_string in the response
+	Format string
+	Meta   map[string]interface{}
+}
+
+func (SumBucketAggParams) Kind() AggKind {
+	return AggKindSumBucket
+}
+
+func (p SumBucketAggParams) Clause() (AggClause, error) {
+	return p.SumBucket()
+}
+
+func (p SumBucketAggParams) SumBucket() (*SumBucketAgg, error) {
+	a := &SumBucketAgg{}
+	err := a.SetBucketsPath(p.BucketsPath)
+	if err != nil {
+		return a, newAggError(err, AggKindSumBucket)
+	}
+	err = a.SetGapPolicy(p.GapPolicy)
+	if err != nil {
+		return a, newAggError(err, AggKindSumBucket)
+	}
+	a.SetFormat(p.Format)
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// SumBucketAgg is a sibling pipeline aggregation which calculates the sum of a
+// specified metric across all buckets in a sibling aggregation.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-pipeline-sum-bucket-aggregation.html
+type SumBucketAgg struct {
+	bucketsPathParam
+	gapPolicyParam
+	pipelineFormatParam
+	noAggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*SumBucketAgg)(nil)
+
+func (SumBucketAgg) Kind() AggKind {
+	return AggKindSumBucket
+}
+
+func (a *SumBucketAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+func (a SumBucketAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a SumBucketAgg) MarshalJSON() ([]byte, error) {
+	return sumBucketAgg{
+		BucketsPath: a.bucketsPath,
+		GapPolicy:   a.gapPolicy,
+		Format:      a.format,
+	}.MarshalJSON()
+}
+
+func (a *SumBucketAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *SumBucketAgg) UnmarshalJSON(data []byte) error {
+	*a = SumBucketAgg{}
+	p := sumBucketAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.bucketsPath = p.BucketsPath
+	a.gapPolicy = p.GapPolicy
+	a.SetFormat(p.Format)
+	return nil
+}
+
+//easyjson:json
+type sumBucketAgg struct {
+	BucketsPath string    `json:"buckets_path"`
+	GapPolicy   GapPolicy `json:"gap_policy,omitempty"`
+	Format      string    `json:"format,omitempty"`
+}
@@ -0,0 +1,229 @@
+package picker
+
+import "fmt"
+
+// TTestType is the type of t-test performed by a t_test aggregation
+type TTestType string
+
+const (
+	TTestTypeUnspecified TTestType = ""
+	// TTestTypePaired performs a paired t-test
+	TTestTypePaired TTestType = "paired"
+	// TTestTypeHomoscedastic performs a two-sample equal variance test
+	TTestTypeHomoscedastic TTestType = "homoscedastic"
+	// TTestTypeHeteroscedastic performs a two-sample unequal variance test
+	TTestTypeHeteroscedastic TTestType = "heteroscedastic"
+)
+
+func (t TTestType) String() string {
+	return string(t)
+}
+
+func (t TTestType) IsValid() bool {
+	switch t {
+	case TTestTypeUnspecified, TTestTypePaired, TTestTypeHomoscedastic, TTestTypeHeteroscedastic:
+		return true
+	}
+	return false
+}
+
+// TTestPopulation is one of the two populations compared by a t_test
+// aggregation. Either Field or Script is required.
+type TTestPopulation struct {
+	// The field that values should be extracted from
+	Field string
+	// Script which provides the values
+	Script *Script
+	// Filter narrows the documents of the population. Filters are not
+	// supported by paired t-tests.
+	Filter Querier
+}
+
+func (p TTestPopulation) population() (tTestPopulation, error) {
+	res := tTestPopulation{
+		Field: p.Field,
+	}
+	if !p.Script.IsEmpty() {
+		res.Script = p.Script
+	}
+	if len(res.Field) == 0 && res.Script == nil {
+		return res, ErrFieldOrScriptRequired
+	}
+	if p.Filter != nil {
+		q, err := p.Filter.Query()
+		if err != nil {
+			return res, err
+		}
+		if !q.IsEmpty() {
+			res.Filter = q
+		}
+	}
+	return res, nil
+}
+
+//easyjson:json
+type tTestPopulation struct {
+	Field  string  `json:"field,omitempty"`
+	Script *Script `json:"script,omitempty"`
+	Filter *Query  `json:"filter,omitempty"`
+}
+
+func (p tTestPopulation) population() TTestPopulation {
+	res := TTestPopulation{
+		Field:  p.Field,
+		Script: p.Script,
+	}
+	if p.Filter != nil {
+		res.Filter = p.Filter
+	}
+	return res
+}
+
+// TTestAggParams creates a TTestAgg, a metrics aggregation that performs a
+// statistical hypothesis test in which the test statistic follows a Student’s
+// t-distribution under the null hypothesis on numeric values extracted from
+// the aggregated documents.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-ttest-aggregation.html
+type TTestAggParams struct {
+	// The first population (Required)
+	A TTestPopulation
+	// The second population (Required)
+	B TTestPopulation
+	// The type of test. One of "paired", "homoscedastic", or
+	// "heteroscedastic" (default).
+	Type TTestType
+	Meta map[string]interface{}
+}
+
+func (TTestAggParams) Kind() AggKind {
+	return AggKindTTest
+}
+
+func (p TTestAggParams) Clause() (AggClause, error) {
+	return p.TTest()
+}
+
+func (p TTestAggParams) TTest() (*TTestAgg, error) {
+	a := &TTestAgg{}
+	err := a.SetA(p.A)
+	if err != nil {
+		return a, newAggError(err, AggKindTTest)
+	}
+	err = a.SetB(p.B)
+	if err != nil {
+		return a, newAggError(err, AggKindTTest)
+	}
+	err = a.SetType(p.Type)
+	if err != nil {
+		return a, newAggError(err, AggKindTTest)
+	}
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// TTestAgg is a metrics aggregation that performs a statistical hypothesis
+// test in which the test statistic follows a Student’s t-distribution under the
+// null hypothesis on numeric values extracted from the aggregated documents.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-ttest-aggregation.html
+type TTestAgg struct {
+	a   tTestPopulation
+	b   tTestPopulation
+	typ TTestType
+	noAggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*TTestAgg)(nil)
+
+func (TTestAgg) Kind() AggKind {
+	return AggKindTTest
+}
+
+func (a *TTestAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// A is the first population
+func (a TTestAgg) A() TTestPopulation {
+	return a.a.population()
+}
+
+// SetA sets the first population to v
+func (a *TTestAgg) SetA(v TTestPopulation) error {
+	p, err := v.population()
+	if err != nil {
+		return fmt.Errorf("%w for population <a>", err)
+	}
+	a.a = p
+	return nil
+}
+
+// B is the second population
+func (a TTestAgg) B() TTestPopulation {
+	return a.b.population()
+}
+
+// SetB sets the second population to v
+func (a *TTestAgg) SetB(v TTestPopulation) error {
+	p, err := v.population()
+	if err != nil {
+		return fmt.Errorf("%w for population <b>", err)
+	}
+	a.b = p
+	return nil
+}
+
+// Type of the test. Defaults to "heteroscedastic".
+func (a TTestAgg) Type() TTestType {
+	if len(a.typ) == 0 {
+		return TTestTypeHeteroscedastic
+	}
+	return a.typ
+}
+
+// SetType sets the type of the test to v
+func (a *TTestAgg) SetType(v TTestType) error {
+	if !v.IsValid() {
+		return fmt.Errorf("%w <%s>", ErrInvalidTTestType, v)
+	}
+	a.typ = v
+	return nil
+}
+
+func (a TTestAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a TTestAgg) MarshalJSON() ([]byte, error) {
+	return tTestAgg{
+		A:    a.a,
+		B:    a.b,
+		Type: a.typ,
+	}.MarshalJSON()
+}
+
+func (a *TTestAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *TTestAgg) UnmarshalJSON(data []byte) error {
+	*a = TTestAgg{}
+	p := tTestAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.a = p.A
+	a.b = p.B
+	a.typ = p.Type
+	return nil
+}
+
+//easyjson:json
+type tTestAgg struct {
+	A    tTestPopulation `json:"a"`
+	B    tTestPopulation `json:"b"`
+	Type TTestType       `json:"type,omitempty"`
+}
@@ -0,0 +1,18 @@
+package picker
+
+// targetFieldParam is a mixin for processors which can assign their output to
+// a field other than the input field
+type targetFieldParam struct {
+	targetField string
+}
+
+// TargetField is the field to assign the output to. By default, the input
+// field is updated in-place.
+func (t targetFieldParam) TargetField() string {
+	return t.targetField
+}
+
+// SetTargetField sets target_field to v
+func (t *targetFieldParam) SetTargetField(v string) {
+	t.targetField = v
+}
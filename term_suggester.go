@@ -0,0 +1,140 @@
+package picker
+
+import "fmt"
+
+// SuggestSort defines how suggestions should be sorted per suggest text term
+type SuggestSort string
+
+const (
+	SuggestSortUnspecified SuggestSort = ""
+	// SuggestSortScore sorts by score first, then document frequency and then
+	// the term itself. This is the default.
+	SuggestSortScore SuggestSort = "score"
+	// SuggestSortFrequency sorts by document frequency first, then similarity
+	// score and then the term itself.
+	SuggestSortFrequency SuggestSort = "frequency"
+)
+
+func (s SuggestSort) String() string {
+	return string(s)
+}
+
+func (s SuggestSort) Validate() error {
+	switch s {
+	case SuggestSortUnspecified, SuggestSortScore, SuggestSortFrequency:
+		return nil
+	}
+	return fmt.Errorf("%w <%s>", ErrInvalidSuggestSort, s)
+}
+
+// StringDistance is the string distance implementation used to compare how
+// similar suggested terms are
+type StringDistance string
+
+const (
+	StringDistanceUnspecified StringDistance = ""
+	// StringDistanceInternal is the default, based on damerau_levenshtein but
+	// highly optimized for comparing string distance for terms inside the
+	// index.
+	StringDistanceInternal StringDistance = "internal"
+	// StringDistanceDamerauLevenshtein is a string distance algorithm based on
+	// the Damerau-Levenshtein algorithm.
+	StringDistanceDamerauLevenshtein StringDistance = "damerau_levenshtein"
+	// StringDistanceLevenshtein is a string distance algorithm based on the
+	// Levenshtein edit distance algorithm.
+	StringDistanceLevenshtein StringDistance = "levenshtein"
+	// StringDistanceJaroWinkler is a string distance algorithm based on the
+	// Jaro-Winkler algorithm.
+	StringDistanceJaroWinkler StringDistance = "jaro_winkler"
+	// StringDistanceNGram is a string distance algorithm based on character
+	// n-grams.
+	StringDistanceNGram StringDistance = "ngram"
+)
+
+func (sd StringDistance) String() string {
+	return string(sd)
+}
+
+func (sd StringDistance) Validate() error {
+	switch sd {
+	case StringDistanceUnspecified, StringDistanceInternal,
+		StringDistanceDamerauLevenshtein, StringDistanceLevenshtein,
+		StringDistanceJaroWinkler, StringDistanceNGram:
+		return nil
+	}
+	return fmt.Errorf("%w <%s>", ErrInvalidStringDistance, sd)
+}
+
+// TermSuggester suggests terms based on edit distance. The provided suggest
+// text is analyzed before terms are suggested.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-suggesters.html#term-suggester
+//
+//easyjson:json
+type TermSuggester struct {
+	// The field to fetch the candidate suggestions from (Required)
+	Field string `json:"field"`
+	// The analyzer to analyse the suggest text with. Defaults to the search
+	// analyzer of the suggest field.
+	Analyzer string `json:"analyzer,omitempty"`
+	// The maximum corrections to be returned per suggest text token.
+	Size int `json:"size,omitempty"`
+	// Defines how suggestions should be sorted per suggest text term
+	Sort SuggestSort `json:"sort,omitempty"`
+	// The suggest mode controls what suggestions are included or controls for
+	// what suggest text terms, suggestions should be suggested.
+	SuggestMode SuggestMode `json:"suggest_mode,omitempty"`
+	// Lowercases the suggest text terms after text analysis.
+	Lowercase bool `json:"lowercase_terms,omitempty"`
+	// The maximum edit distance candidate suggestions can have in order to be
+	// considered as a suggestion. Can only be a value between 1 and 2.
+	// Defaults to 2.
+	MaxEdits int `json:"max_edits,omitempty"`
+	// The number of minimal prefix characters that must match in order be a
+	// candidate for suggestions. Defaults to 1.
+	PrefixLength *int `json:"prefix_length,omitempty"`
+	// The minimum length a suggest text term must have in order to be
+	// included. Defaults to 4.
+	MinWordLength int `json:"min_word_length,omitempty"`
+	// Sets the maximum number of suggestions to be retrieved from each
+	// individual shard.
+	ShardSize int `json:"shard_size,omitempty"`
+	// A factor that is used to multiply with the shards_size in order to
+	// inspect more candidate spelling corrections on the shard level.
+	// Defaults to 5.
+	MaxInspections int `json:"max_inspections,omitempty"`
+	// The minimal threshold in number of documents a suggestion should appear
+	// in. This can be specified as an absolute number or as a relative
+	// percentage of number of documents.
+	MinDocFreq float64 `json:"min_doc_freq,omitempty"`
+	// The maximum threshold in number of documents in which a suggest text
+	// token can exist in order to be included.
+	MaxTermFreq float64 `json:"max_term_freq,omitempty"`
+	// Which string distance implementation to use for comparing how similar
+	// suggested terms are.
+	StringDistance StringDistance `json:"string_distance,omitempty"`
+}
+
+// Validate checks that the field is set and the options are valid
+func (s TermSuggester) Validate() error {
+	if len(s.Field) == 0 {
+		return ErrFieldRequired
+	}
+	if err := s.Sort.Validate(); err != nil {
+		return err
+	}
+	if err := s.SuggestMode.Validate(); err != nil {
+		return err
+	}
+	if err := validateMaxEdits(s.MaxEdits); err != nil {
+		return err
+	}
+	return s.StringDistance.Validate()
+}
+
+func validateMaxEdits(v int) error {
+	if v < 0 || v > 2 {
+		return fmt.Errorf("%w <%d>; expected a value between 1 and 2", ErrInvalidMaxEdits, v)
+	}
+	return nil
+}
@@ -0,0 +1,451 @@
+package picker
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/chanced/dynamic"
+)
+
+const (
+	DefaultTermsAggSize        = 10
+	DefaultTermsAggMinDocCount = 1
+)
+
+// CollectMode determines how the child aggregations of a terms aggregation are
+// calculated.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-terms-aggregation.html#search-aggregations-bucket-terms-aggregation-collect
+type CollectMode string
+
+const (
+	CollectModeUnspecified CollectMode = ""
+	// CollectModeDepthFirst expands all branches of the aggregation tree in
+	// one depth-first pass before any pruning occurs.
+	CollectModeDepthFirst CollectMode = "depth_first"
+	// CollectModeBreadthFirst calculates the top buckets first, pruning the
+	// rest, before calculating child aggregations.
+	CollectModeBreadthFirst CollectMode = "breadth_first"
+)
+
+func (cm CollectMode) String() string {
+	return string(cm)
+}
+
+func (cm CollectMode) IsValid() bool {
+	switch cm {
+	case CollectModeUnspecified, CollectModeDepthFirst, CollectModeBreadthFirst:
+		return true
+	}
+	return false
+}
+
+// TermsInclude filters the values for which buckets will be created by a
+// terms-based aggregation.
+//
+// Only one of Pattern, Values, or partitioning (Partition and NumPartitions)
+// should be set. Partitioning is only valid for include.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-terms-aggregation.html#_filtering_values_4
+type TermsInclude struct {
+	// Pattern is a regular expression
+	Pattern string
+	// Values are exact values
+	Values []string
+	// Partition is the partition to return when partitioning terms into
+	// NumPartitions groups.
+	Partition int
+	// NumPartitions is the number of partitions terms should be grouped into.
+	NumPartitions int
+}
+
+func (ti *TermsInclude) IsEmpty() bool {
+	return ti == nil || (len(ti.Pattern) == 0 && len(ti.Values) == 0 && ti.NumPartitions == 0)
+}
+
+func (ti *TermsInclude) isPartitioned() bool {
+	return ti != nil && len(ti.Pattern) == 0 && len(ti.Values) == 0 && ti.NumPartitions > 0
+}
+
+func (ti TermsInclude) MarshalBSON() ([]byte, error) {
+	return ti.MarshalJSON()
+}
+
+func (ti TermsInclude) MarshalJSON() ([]byte, error) {
+	if len(ti.Pattern) > 0 {
+		return json.Marshal(ti.Pattern)
+	}
+	if len(ti.Values) > 0 {
+		return json.Marshal(ti.Values)
+	}
+	if ti.NumPartitions > 0 {
+		return json.Marshal(map[string]int{
+			"partition":      ti.Partition,
+			"num_partitions": ti.NumPartitions,
+		})
+	}
+	return dynamic.Null, nil
+}
+
+func (ti *TermsInclude) UnmarshalBSON(data []byte) error {
+	return ti.UnmarshalJSON(data)
+}
+
+func (ti *TermsInclude) UnmarshalJSON(data []byte) error {
+	*ti = TermsInclude{}
+	d := dynamic.JSON(data)
+	switch {
+	case len(d) == 0 || d.IsNull():
+		return nil
+	case d.IsString():
+		return json.Unmarshal(d, &ti.Pattern)
+	case d.IsArray():
+		return json.Unmarshal(d, &ti.Values)
+	}
+	var p map[string]int
+	err := json.Unmarshal(d, &p)
+	if err != nil {
+		return err
+	}
+	ti.Partition = p["partition"]
+	ti.NumPartitions = p["num_partitions"]
+	return nil
+}
+
+// TermsAggParams creates a TermsAgg, a multi-bucket value source based
+// aggregation where buckets are dynamically built - one per unique value.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-terms-aggregation.html
+type TermsAggParams struct {
+	// The field to aggregate on. Either Field or Script is required.
+	Field string
+	// Script to generate terms with. If Field is also set, Script is a value
+	// script.
+	Script *Script
+	// Missing is the value used for documents which do not have a value
+	Missing interface{}
+	// Hint as to the type of value produced by Script
+	ValueType string
+	Format    string
+	// The number of term buckets to return. Defaults to 10.
+	Size int
+	// The number of terms each shard should return. The higher the value, the
+	// more accurate the results at the cost of overhead. Defaults to
+	// (size * 1.5 + 10).
+	ShardSize int
+	// If true, returns an upper bound of the error on the doc_count for each
+	// term.
+	ShowTermDocCountError bool
+	// The order of the buckets. Defaults to descending _count.
+	Order BucketOrder
+	// Only return terms that match more than the configured number of hits.
+	// Defaults to 1.
+	MinDocCount interface{}
+	// The minimum number of documents a term must be present in on a shard to
+	// be considered for the final results.
+	ShardMinDocCount interface{}
+	// Filter which terms buckets are created for.
+	Include *TermsInclude
+	// Filter which terms buckets are not created for. Partitioning is not
+	// supported.
+	Exclude *TermsInclude
+	// Either "map" or "global_ordinals"
+	ExecutionHint string
+	CollectMode   CollectMode
+	// Sub-aggregations
+	Aggregations Aggs
+	Meta         map[string]interface{}
+}
+
+func (TermsAggParams) Kind() AggKind {
+	return AggKindTerms
+}
+
+func (p TermsAggParams) Clause() (AggClause, error) {
+	return p.Terms()
+}
+
+func (p TermsAggParams) Terms() (*TermsAgg, error) {
+	a := &TermsAgg{}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	err := a.checkValueSource()
+	if err != nil {
+		return a, newAggError(err, AggKindTerms)
+	}
+	a.SetMissing(p.Missing)
+	a.SetValueType(p.ValueType)
+	a.SetFormat(p.Format)
+	a.SetSize(p.Size)
+	a.SetShardSize(p.ShardSize)
+	a.SetShowTermDocCountError(p.ShowTermDocCountError)
+	err = a.SetOrder(p.Order)
+	if err != nil {
+		return a, newAggError(err, AggKindTerms)
+	}
+	err = a.SetMinDocCount(p.MinDocCount)
+	if err != nil {
+		return a, newAggError(err, AggKindTerms)
+	}
+	err = a.SetShardMinDocCount(p.ShardMinDocCount)
+	if err != nil {
+		return a, newAggError(err, AggKindTerms)
+	}
+	a.SetInclude(p.Include)
+	err = a.SetExclude(p.Exclude)
+	if err != nil {
+		return a, newAggError(err, AggKindTerms)
+	}
+	a.SetExecutionHint(p.ExecutionHint)
+	err = a.SetCollectMode(p.CollectMode)
+	if err != nil {
+		return a, newAggError(err, AggKindTerms)
+	}
+	err = a.SetAggregations(p.Aggregations)
+	if err != nil {
+		return a, newAggError(err, AggKindTerms)
+	}
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// TermsAgg is a multi-bucket value source based aggregation where buckets are
+// dynamically built - one per unique value.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-terms-aggregation.html
+type TermsAgg struct {
+	valueSourceParam
+	size                  int
+	shardSize             int
+	showTermDocCountError bool
+	order                 BucketOrder
+	minDocCount           dynamic.Number
+	shardMinDocCount      dynamic.Number
+	include               *TermsInclude
+	exclude               *TermsInclude
+	executionHint         string
+	collectMode           CollectMode
+	aggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*TermsAgg)(nil)
+
+func (TermsAgg) Kind() AggKind {
+	return AggKindTerms
+}
+
+func (a *TermsAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// Size is the number of term buckets to return. Defaults to 10.
+func (a TermsAgg) Size() int {
+	if a.size == 0 {
+		return DefaultTermsAggSize
+	}
+	return a.size
+}
+
+// SetSize sets the number of term buckets to return to v
+func (a *TermsAgg) SetSize(v int) {
+	a.size = v
+}
+
+// ShardSize is the number of terms each shard returns.
+func (a TermsAgg) ShardSize() int {
+	return a.shardSize
+}
+
+// SetShardSize sets the shard_size to v
+func (a *TermsAgg) SetShardSize(v int) {
+	a.shardSize = v
+}
+
+// ShowTermDocCountError indicates whether an upper bound of the error on the
+// doc_count is returned for each term.
+func (a TermsAgg) ShowTermDocCountError() bool {
+	return a.showTermDocCountError
+}
+
+// SetShowTermDocCountError sets show_term_doc_count_error to v
+func (a *TermsAgg) SetShowTermDocCountError(v bool) {
+	a.showTermDocCountError = v
+}
+
+// Order of the buckets. Defaults to descending _count.
+func (a TermsAgg) Order() BucketOrder {
+	return a.order
+}
+
+// SetOrder sets the order of the buckets to v
+func (a *TermsAgg) SetOrder(v BucketOrder) error {
+	err := v.Validate()
+	if err != nil {
+		return err
+	}
+	a.order = v
+	return nil
+}
+
+// MinDocCount is the minimum number of hits a term must match to be returned.
+// Defaults to 1.
+func (a TermsAgg) MinDocCount() int {
+	if i, ok := a.minDocCount.Int(); ok {
+		return i
+	}
+	return DefaultTermsAggMinDocCount
+}
+
+// SetMinDocCount sets min_doc_count to v
+func (a *TermsAgg) SetMinDocCount(v interface{}) error {
+	return a.minDocCount.Set(v)
+}
+
+// ShardMinDocCount is the minimum number of documents a term must be present in
+// on a shard to be considered for the final results.
+func (a TermsAgg) ShardMinDocCount() int {
+	if i, ok := a.shardMinDocCount.Int(); ok {
+		return i
+	}
+	return 0
+}
+
+// SetShardMinDocCount sets shard_min_doc_count to v
+func (a *TermsAgg) SetShardMinDocCount(v interface{}) error {
+	return a.shardMinDocCount.Set(v)
+}
+
+// Include filters which terms buckets are created for.
+func (a TermsAgg) Include() *TermsInclude {
+	return a.include
+}
+
+// SetInclude sets include to v
+func (a *TermsAgg) SetInclude(v *TermsInclude) {
+	if v.IsEmpty() {
+		a.include = nil
+		return
+	}
+	a.include = v
+}
+
+// Exclude filters which terms buckets are not created for.
+func (a TermsAgg) Exclude() *TermsInclude {
+	return a.exclude
+}
+
+// SetExclude sets exclude to v. Partitioning is not supported by exclude.
+func (a *TermsAgg) SetExclude(v *TermsInclude) error {
+	if v.IsEmpty() {
+		a.exclude = nil
+		return nil
+	}
+	if v.isPartitioned() {
+		return ErrInvalidExclude
+	}
+	a.exclude = v
+	return nil
+}
+
+// ExecutionHint is either "map" or "global_ordinals"
+func (a TermsAgg) ExecutionHint() string {
+	return a.executionHint
+}
+
+// SetExecutionHint sets execution_hint to v
+func (a *TermsAgg) SetExecutionHint(v string) {
+	a.executionHint = v
+}
+
+// CollectMode determines how child aggregations are calculated.
+func (a TermsAgg) CollectMode() CollectMode {
+	return a.collectMode
+}
+
+// SetCollectMode sets collect_mode to v
+func (a *TermsAgg) SetCollectMode(v CollectMode) error {
+	if !v.IsValid() {
+		return fmt.Errorf("%w <%s>", ErrInvalidCollectMode, v)
+	}
+	a.collectMode = v
+	return nil
+}
+
+func (a TermsAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a TermsAgg) MarshalJSON() ([]byte, error) {
+	return termsAgg{
+		Field:                 a.field,
+		Script:                a.script,
+		Missing:               a.missing,
+		ValueType:             a.valueType,
+		Format:                a.format,
+		Size:                  a.size,
+		ShardSize:             a.shardSize,
+		ShowTermDocCountError: a.showTermDocCountError,
+		Order:                 a.order,
+		MinDocCount:           a.minDocCount.Value(),
+		ShardMinDocCount:      a.shardMinDocCount.Value(),
+		Include:               a.include,
+		Exclude:               a.exclude,
+		ExecutionHint:         a.executionHint,
+		CollectMode:           a.collectMode,
+	}.MarshalJSON()
+}
+
+func (a *TermsAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *TermsAgg) UnmarshalJSON(data []byte) error {
+	*a = TermsAgg{}
+	p := termsAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	a.SetMissing(p.Missing)
+	a.SetValueType(p.ValueType)
+	a.SetFormat(p.Format)
+	a.SetSize(p.Size)
+	a.SetShardSize(p.ShardSize)
+	a.SetShowTermDocCountError(p.ShowTermDocCountError)
+	a.order = p.Order
+	err = a.SetMinDocCount(p.MinDocCount)
+	if err != nil {
+		return err
+	}
+	err = a.SetShardMinDocCount(p.ShardMinDocCount)
+	if err != nil {
+		return err
+	}
+	a.SetInclude(p.Include)
+	a.exclude = p.Exclude
+	a.SetExecutionHint(p.ExecutionHint)
+	a.collectMode = p.CollectMode
+	return nil
+}
+
+//easyjson:json
+type termsAgg struct {
+	Field                 string        `json:"field,omitempty"`
+	Script                *Script       `json:"script,omitempty"`
+	Missing               interface{}   `json:"missing,omitempty"`
+	ValueType             string        `json:"value_type,omitempty"`
+	Format                string        `json:"format,omitempty"`
+	Size                  int           `json:"size,omitempty"`
+	ShardSize             int           `json:"shard_size,omitempty"`
+	ShowTermDocCountError bool          `json:"show_term_doc_count_error,omitempty"`
+	Order                 BucketOrder   `json:"order,omitempty"`
+	MinDocCount           interface{}   `json:"min_doc_count,omitempty"`
+	ShardMinDocCount      interface{}   `json:"shard_min_doc_count,omitempty"`
+	Include               *TermsInclude `json:"include,omitempty"`
+	Exclude               *TermsInclude `json:"exclude,omitempty"`
+	ExecutionHint         string        `json:"execution_hint,omitempty"`
+	CollectMode           CollectMode   `json:"collect_mode,omitempty"`
+}
@@ -0,0 +1,66 @@
+package picker_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/chanced/cmpjson"
+	"github.com/chanced/picker"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTermsAgg(t *testing.T) {
+	assert := require.New(t)
+	data := []byte(`{
+		"aggs": {
+			"tags": {
+				"terms": {
+					"field": "tags",
+					"min_doc_count": 0,
+					"include": ".*sport.*",
+					"exclude": ["water_sport", "motor_sport"],
+					"order": [{ "avg_play_count": "desc" }, { "_key": "asc" }],
+					"collect_mode": "breadth_first"
+				},
+				"aggs": {
+					"avg_play_count": { "terms": { "field": "play_count" } }
+				}
+			},
+			"partitioned": {
+				"terms": {
+					"script": { "source": "doc['account_id'].value", "lang": "painless" },
+					"include": { "partition": 0, "num_partitions": 20 }
+				}
+			}
+		}
+	}`)
+	var s picker.Search
+	err := json.Unmarshal(data, &s)
+	assert.NoError(err)
+	tags := s.Aggregations().Get("tags").(*picker.TermsAgg)
+	assert.Equal(0, tags.MinDocCount())
+	assert.Equal(".*sport.*", tags.Include().Pattern)
+	assert.Equal([]string{"water_sport", "motor_sport"}, tags.Exclude().Values)
+	assert.Len(tags.Order(), 2)
+	assert.Equal(picker.CollectModeBreadthFirst, tags.CollectMode())
+	partitioned := s.Aggregations().Get("partitioned").(*picker.TermsAgg)
+	assert.Equal(20, partitioned.Include().NumPartitions)
+	assert.Equal("painless", partitioned.Script().Lang)
+	assert.Equal(picker.DefaultTermsAggSize, partitioned.Size())
+	assert.Equal(picker.DefaultTermsAggMinDocCount, partitioned.MinDocCount())
+
+	sd, err := json.Marshal(s)
+	assert.NoError(err)
+	assert.True(cmpjson.Equal(data, sd), cmpjson.Diff(data, sd))
+
+	_, err = picker.TermsAggParams{}.Terms()
+	assert.True(errors.Is(err, picker.ErrFieldOrScriptRequired))
+	_, err = picker.TermsAggParams{
+		Field:   "tags",
+		Exclude: &picker.TermsInclude{Partition: 1, NumPartitions: 2},
+	}.Terms()
+	assert.True(errors.Is(err, picker.ErrInvalidExclude))
+	_, err = picker.TermsAggParams{Field: "tags", CollectMode: "sideways"}.Terms()
+	assert.True(errors.Is(err, picker.ErrInvalidCollectMode))
+}
@@ -0,0 +1,1275 @@
+package picker
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/chanced/dynamic"
+	"github.com/tidwall/gjson"
+)
+
+// TokenFilterType is the type of a TokenFilter
+type TokenFilterType string
+
+func (t TokenFilterType) String() string {
+	return string(t)
+}
+
+const (
+	TokenFilterTypeLowercase          TokenFilterType = "lowercase"
+	TokenFilterTypeUppercase          TokenFilterType = "uppercase"
+	TokenFilterTypeASCIIFolding       TokenFilterType = "asciifolding"
+	TokenFilterTypeStop               TokenFilterType = "stop"
+	TokenFilterTypeStemmer            TokenFilterType = "stemmer"
+	TokenFilterTypeSynonym            TokenFilterType = "synonym"
+	TokenFilterTypeSynonymGraph       TokenFilterType = "synonym_graph"
+	TokenFilterTypeShingle            TokenFilterType = "shingle"
+	TokenFilterTypeWordDelimiter      TokenFilterType = "word_delimiter"
+	TokenFilterTypeWordDelimiterGraph TokenFilterType = "word_delimiter_graph"
+	TokenFilterTypeEdgeNGram          TokenFilterType = "edge_ngram"
+	TokenFilterTypeNGram              TokenFilterType = "ngram"
+	TokenFilterTypeLength             TokenFilterType = "length"
+	TokenFilterTypeTruncate           TokenFilterType = "truncate"
+	TokenFilterTypeUnique             TokenFilterType = "unique"
+	TokenFilterTypeTrim               TokenFilterType = "trim"
+	TokenFilterTypeReverse            TokenFilterType = "reverse"
+	TokenFilterTypePorterStem         TokenFilterType = "porter_stem"
+	TokenFilterTypeKStem              TokenFilterType = "kstem"
+	TokenFilterTypeApostrophe         TokenFilterType = "apostrophe"
+	TokenFilterTypeDecimalDigit       TokenFilterType = "decimal_digit"
+	TokenFilterTypeFlattenGraph       TokenFilterType = "flatten_graph"
+	TokenFilterTypeRemoveDuplicates   TokenFilterType = "remove_duplicates"
+	TokenFilterTypeKeywordRepeat      TokenFilterType = "keyword_repeat"
+	TokenFilterTypeClassic            TokenFilterType = "classic"
+	TokenFilterTypeCJKWidth           TokenFilterType = "cjk_width"
+	TokenFilterTypeElision            TokenFilterType = "elision"
+	TokenFilterTypeKeywordMarker      TokenFilterType = "keyword_marker"
+	TokenFilterTypePatternReplace     TokenFilterType = "pattern_replace"
+	TokenFilterTypeSnowball           TokenFilterType = "snowball"
+	TokenFilterTypeStemmerOverride    TokenFilterType = "stemmer_override"
+	TokenFilterTypeCommonGrams        TokenFilterType = "common_grams"
+	TokenFilterTypeMultiplexer        TokenFilterType = "multiplexer"
+	TokenFilterTypeLimit              TokenFilterType = "limit"
+	TokenFilterTypeFingerprint        TokenFilterType = "fingerprint"
+	TokenFilterTypeKeepWords          TokenFilterType = "keep"
+)
+
+// TokenFilterTypeHandlers is a map of token filter types to funcs which return
+// a TokenFilter of that type, used when unmarshaling TokenFilters. Token
+// filters provided by plugins can be registered here; those which are not
+// registered are unmarshaled as a *RawTokenFilter.
+var TokenFilterTypeHandlers = map[TokenFilterType]func() TokenFilter{
+	TokenFilterTypeLowercase:          func() TokenFilter { return &LowercaseTokenFilter{} },
+	TokenFilterTypeUppercase:          func() TokenFilter { return &UppercaseTokenFilter{} },
+	TokenFilterTypeASCIIFolding:       func() TokenFilter { return &ASCIIFoldingTokenFilter{} },
+	TokenFilterTypeStop:               func() TokenFilter { return &StopTokenFilter{} },
+	TokenFilterTypeStemmer:            func() TokenFilter { return &StemmerTokenFilter{} },
+	TokenFilterTypeSynonym:            func() TokenFilter { return &SynonymTokenFilter{} },
+	TokenFilterTypeSynonymGraph:       func() TokenFilter { return &SynonymGraphTokenFilter{} },
+	TokenFilterTypeShingle:            func() TokenFilter { return &ShingleTokenFilter{} },
+	TokenFilterTypeWordDelimiter:      func() TokenFilter { return &WordDelimiterTokenFilter{} },
+	TokenFilterTypeWordDelimiterGraph: func() TokenFilter { return &WordDelimiterGraphTokenFilter{} },
+	TokenFilterTypeEdgeNGram:          func() TokenFilter { return &EdgeNGramTokenFilter{} },
+	TokenFilterTypeNGram:              func() TokenFilter { return &NGramTokenFilter{} },
+	TokenFilterTypeLength:             func() TokenFilter { return &LengthTokenFilter{} },
+	TokenFilterTypeTruncate:           func() TokenFilter { return &TruncateTokenFilter{} },
+	TokenFilterTypeUnique:             func() TokenFilter { return &UniqueTokenFilter{} },
+	TokenFilterTypeTrim:               func() TokenFilter { return &TrimTokenFilter{} },
+	TokenFilterTypeReverse:            func() TokenFilter { return &ReverseTokenFilter{} },
+	TokenFilterTypePorterStem:         func() TokenFilter { return &PorterStemTokenFilter{} },
+	TokenFilterTypeKStem:              func() TokenFilter { return &KStemTokenFilter{} },
+	TokenFilterTypeApostrophe:         func() TokenFilter { return &ApostropheTokenFilter{} },
+	TokenFilterTypeDecimalDigit:       func() TokenFilter { return &DecimalDigitTokenFilter{} },
+	TokenFilterTypeFlattenGraph:       func() TokenFilter { return &FlattenGraphTokenFilter{} },
+	TokenFilterTypeRemoveDuplicates:   func() TokenFilter { return &RemoveDuplicatesTokenFilter{} },
+	TokenFilterTypeKeywordRepeat:      func() TokenFilter { return &KeywordRepeatTokenFilter{} },
+	TokenFilterTypeClassic:            func() TokenFilter { return &ClassicTokenFilter{} },
+	TokenFilterTypeCJKWidth:           func() TokenFilter { return &CJKWidthTokenFilter{} },
+	TokenFilterTypeElision:            func() TokenFilter { return &ElisionTokenFilter{} },
+	TokenFilterTypeKeywordMarker:      func() TokenFilter { return &KeywordMarkerTokenFilter{} },
+	TokenFilterTypePatternReplace:     func() TokenFilter { return &PatternReplaceTokenFilter{} },
+	TokenFilterTypeSnowball:           func() TokenFilter { return &SnowballTokenFilter{} },
+	TokenFilterTypeStemmerOverride:    func() TokenFilter { return &StemmerOverrideTokenFilter{} },
+	TokenFilterTypeCommonGrams:        func() TokenFilter { return &CommonGramsTokenFilter{} },
+	TokenFilterTypeMultiplexer:        func() TokenFilter { return &MultiplexerTokenFilter{} },
+	TokenFilterTypeLimit:              func() TokenFilter { return &LimitTokenFilter{} },
+	TokenFilterTypeFingerprint:        func() TokenFilter { return &FingerprintTokenFilter{} },
+	TokenFilterTypeKeepWords:          func() TokenFilter { return &KeepWordsTokenFilter{} },
+}
+
+// TokenFilter accepts a stream of tokens from a tokenizer and can modify
+// tokens (eg lowercasing), delete tokens (eg remove stopwords) or add tokens
+// (eg synonyms).
+//
+// A TokenFilter can optionally implement Validate() error.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-tokenfilters.html
+type TokenFilter interface {
+	// Type is the type of the token filter, e.g. "lowercase"
+	Type() TokenFilterType
+	json.Marshaler
+	json.Unmarshaler
+}
+
+// TokenFilters are custom token filters, keyed by name
+type TokenFilters map[string]TokenFilter
+
+// Validate checks each TokenFilter which implements Validate
+func (m TokenFilters) Validate() error {
+	for name, v := range m {
+		if v == nil {
+			return newFieldError(ErrMissingType, name)
+		}
+		if vv, ok := v.(interface{ Validate() error }); ok {
+			if err := vv.Validate(); err != nil {
+				return newFieldError(err, name)
+			}
+		}
+	}
+	return nil
+}
+
+func (m TokenFilters) MarshalBSON() ([]byte, error) {
+	return m.MarshalJSON()
+}
+
+func (m TokenFilters) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]TokenFilter(m))
+}
+
+func (m *TokenFilters) UnmarshalBSON(data []byte) error {
+	return m.UnmarshalJSON(data)
+}
+
+func (m *TokenFilters) UnmarshalJSON(data []byte) error {
+	*m = nil
+	var obj dynamic.JSONObject
+	err := json.Unmarshal(data, &obj)
+	if err != nil {
+		return err
+	}
+	if len(obj) == 0 {
+		return nil
+	}
+	res := make(TokenFilters, len(obj))
+	for name, d := range obj {
+		var v TokenFilter
+		err = UnmarshalTokenFilterJSON(d, &v)
+		if err != nil {
+			return newFieldError(err, name)
+		}
+		res[name] = v
+	}
+	*m = res
+	return nil
+}
+
+// UnmarshalTokenFilterJSON decodes data into the TokenFilter registered in
+// TokenFilterTypeHandlers for its type, or a *RawTokenFilter if the type is unknown.
+func UnmarshalTokenFilterJSON(data []byte, v *TokenFilter) error {
+	t := gjson.GetBytes(data, "type").String()
+	if len(t) == 0 {
+		return fmt.Errorf("%w; can not unmarshal token filter", ErrMissingType)
+	}
+	var res TokenFilter
+	if handler, ok := TokenFilterTypeHandlers[TokenFilterType(t)]; ok {
+		res = handler()
+	} else {
+		res = &RawTokenFilter{TokenFilterType: TokenFilterType(t)}
+	}
+	err := res.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	*v = res
+	return nil
+}
+
+// RawTokenFilter is a token filter which is kept as-is. It is used for token filters which
+// are not registered in TokenFilterTypeHandlers, such as those provided by plugins.
+type RawTokenFilter struct {
+	// The type of the token filter (Required)
+	TokenFilterType TokenFilterType
+	// The settings of the token filter, other than type
+	Data dynamic.JSON
+}
+
+func (f RawTokenFilter) Type() TokenFilterType {
+	return f.TokenFilterType
+}
+
+// Validate checks that the type is set
+func (f RawTokenFilter) Validate() error {
+	if len(f.TokenFilterType) == 0 {
+		return ErrMissingType
+	}
+	return nil
+}
+
+func (f RawTokenFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(f.TokenFilterType), f.Data)
+}
+
+func (f *RawTokenFilter) UnmarshalJSON(data []byte) error {
+	f.TokenFilterType = TokenFilterType(gjson.GetBytes(data, "type").String())
+	f.Data = append(dynamic.JSON(nil), data...)
+	return nil
+}
+
+// LowercaseTokenFilter changes token text to lowercase.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-lowercase-tokenfilter.html
+type LowercaseTokenFilter struct {
+	// Language-specific lowercase token filter to use, either "greek",
+	// "irish", or "turkish"
+	Language string `json:"language,omitempty"`
+}
+
+func (LowercaseTokenFilter) Type() TokenFilterType {
+	return TokenFilterTypeLowercase
+}
+
+func (f LowercaseTokenFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(f.Type()), lowercaseTokenFilter(f))
+}
+
+func (f *LowercaseTokenFilter) UnmarshalJSON(data []byte) error {
+	var v lowercaseTokenFilter
+	err := unmarshalAnalysisComponent(data, &v)
+	*f = LowercaseTokenFilter(v)
+	return err
+}
+
+//easyjson:json
+type lowercaseTokenFilter LowercaseTokenFilter
+
+// UppercaseTokenFilter changes token text to uppercase.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-uppercase-tokenfilter.html
+type UppercaseTokenFilter struct{}
+
+func (UppercaseTokenFilter) Type() TokenFilterType {
+	return TokenFilterTypeUppercase
+}
+
+func (f UppercaseTokenFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(f.Type()), uppercaseTokenFilter(f))
+}
+
+func (f *UppercaseTokenFilter) UnmarshalJSON(data []byte) error {
+	var v uppercaseTokenFilter
+	err := unmarshalAnalysisComponent(data, &v)
+	*f = UppercaseTokenFilter(v)
+	return err
+}
+
+//easyjson:json
+type uppercaseTokenFilter UppercaseTokenFilter
+
+// ASCIIFoldingTokenFilter converts alphabetic, numeric, and symbolic characters
+// that are not in the Basic Latin Unicode block to their ASCII equivalent, if
+// one exists.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-asciifolding-tokenfilter.html
+type ASCIIFoldingTokenFilter struct {
+	// If true, emits the original token as well. Defaults to false.
+	PreserveOriginal *bool `json:"preserve_original,omitempty"`
+}
+
+func (ASCIIFoldingTokenFilter) Type() TokenFilterType {
+	return TokenFilterTypeASCIIFolding
+}
+
+func (f ASCIIFoldingTokenFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(f.Type()), asciiFoldingTokenFilter(f))
+}
+
+func (f *ASCIIFoldingTokenFilter) UnmarshalJSON(data []byte) error {
+	var v asciiFoldingTokenFilter
+	err := unmarshalAnalysisComponent(data, &v)
+	*f = ASCIIFoldingTokenFilter(v)
+	return err
+}
+
+//easyjson:json
+type asciiFoldingTokenFilter ASCIIFoldingTokenFilter
+
+// StopTokenFilter removes stop words from a token stream.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-stop-tokenfilter.html
+type StopTokenFilter struct {
+	// A pre-defined stop words list, such as _english_, or an array of stop
+	// words. Defaults to _english_.
+	Stopwords Stopwords `json:"stopwords,omitempty"`
+	// The path to a file containing stop words
+	StopwordsPath string `json:"stopwords_path,omitempty"`
+	// If true, matching is case insensitive. Defaults to false.
+	IgnoreCase *bool `json:"ignore_case,omitempty"`
+	// If true, the last token of a stream is removed if it is a stop word.
+	// Defaults to true.
+	RemoveTrailing *bool `json:"remove_trailing,omitempty"`
+}
+
+func (StopTokenFilter) Type() TokenFilterType {
+	return TokenFilterTypeStop
+}
+
+func (f StopTokenFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(f.Type()), stopTokenFilter(f))
+}
+
+func (f *StopTokenFilter) UnmarshalJSON(data []byte) error {
+	var v stopTokenFilter
+	err := unmarshalAnalysisComponent(data, &v)
+	*f = StopTokenFilter(v)
+	return err
+}
+
+//easyjson:json
+type stopTokenFilter StopTokenFilter
+
+// StemmerTokenFilter provides algorithmic stemming for several languages.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-stemmer-tokenfilter.html
+type StemmerTokenFilter struct {
+	// The language-dependent stemming algorithm used to stem tokens. Defaults
+	// to "english".
+	Language string `json:"language,omitempty"`
+}
+
+func (StemmerTokenFilter) Type() TokenFilterType {
+	return TokenFilterTypeStemmer
+}
+
+func (f StemmerTokenFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(f.Type()), stemmerTokenFilter(f))
+}
+
+func (f *StemmerTokenFilter) UnmarshalJSON(data []byte) error {
+	var v stemmerTokenFilter
+	err := unmarshalAnalysisComponent(data, &v)
+	*f = StemmerTokenFilter(v)
+	return err
+}
+
+//easyjson:json
+type stemmerTokenFilter StemmerTokenFilter
+
+// SynonymTokenFilter handles synonyms during the analysis process.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-synonym-tokenfilter.html
+type SynonymTokenFilter struct {
+	// The synonyms, in Solr or WordNet format
+	Synonyms []string `json:"synonyms,omitempty"`
+	// The path to a file containing synonyms
+	SynonymsPath string `json:"synonyms_path,omitempty"`
+	// The name of a synonyms set
+	SynonymsSet string `json:"synonyms_set,omitempty"`
+	// The format of the synonyms, either "solr" (default) or "wordnet"
+	Format string `json:"format,omitempty"`
+	// Whether equivalent synonyms are expanded to all of each other. Defaults to
+	// true.
+	Expand *bool `json:"expand,omitempty"`
+	// If true, ignores exceptions while parsing the synonym configuration.
+	// Defaults to false.
+	Lenient *bool `json:"lenient,omitempty"`
+	// If true, the synonyms can be reloaded with the reload search analyzers
+	// API. Defaults to false.
+	Updateable *bool `json:"updateable,omitempty"`
+}
+
+func (SynonymTokenFilter) Type() TokenFilterType {
+	return TokenFilterTypeSynonym
+}
+
+// Validate checks that either Synonyms, SynonymsPath, or SynonymsSet is set
+func (f SynonymTokenFilter) Validate() error {
+	if len(f.Synonyms) == 0 && len(f.SynonymsPath) == 0 && len(f.SynonymsSet) == 0 {
+		return ErrSynonymsRequired
+	}
+	return nil
+}
+
+func (f SynonymTokenFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(f.Type()), synonymTokenFilter(f))
+}
+
+func (f *SynonymTokenFilter) UnmarshalJSON(data []byte) error {
+	var v synonymTokenFilter
+	err := unmarshalAnalysisComponent(data, &v)
+	*f = SynonymTokenFilter(v)
+	return err
+}
+
+//easyjson:json
+type synonymTokenFilter SynonymTokenFilter
+
+// SynonymGraphTokenFilter handles synonyms, including multi-word synonyms,
+// correctly during the analysis process. It is designed to be used in search
+// analyzers only.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-synonym-graph-tokenfilter.html
+type SynonymGraphTokenFilter SynonymTokenFilter
+
+func (SynonymGraphTokenFilter) Type() TokenFilterType {
+	return TokenFilterTypeSynonymGraph
+}
+
+// Validate checks that either Synonyms, SynonymsPath, or SynonymsSet is set
+func (f SynonymGraphTokenFilter) Validate() error {
+	return SynonymTokenFilter(f).Validate()
+}
+
+func (f SynonymGraphTokenFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(f.Type()), synonymTokenFilter(f))
+}
+
+func (f *SynonymGraphTokenFilter) UnmarshalJSON(data []byte) error {
+	var v synonymTokenFilter
+	err := unmarshalAnalysisComponent(data, &v)
+	*f = SynonymGraphTokenFilter(v)
+	return err
+}
+
+// ShingleTokenFilter adds shingles, or word n-grams, to a token stream by
+// concatenating adjacent tokens.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-shingle-tokenfilter.html
+type ShingleTokenFilter struct {
+	// Minimum number of tokens to concatenate. Defaults to 2.
+	MinShingleSize int `json:"min_shingle_size,omitempty"`
+	// Maximum number of tokens to concatenate. Defaults to 2.
+	MaxShingleSize int `json:"max_shingle_size,omitempty"`
+	// If true, the output includes the original input tokens. Defaults to
+	// true.
+	OutputUnigrams *bool `json:"output_unigrams,omitempty"`
+	// If true, the output includes the original input tokens only if no
+	// shingles are produced. Defaults to false.
+	OutputUnigramsIfNoShingles *bool `json:"output_unigrams_if_no_shingles,omitempty"`
+	// The separator used to concatenate adjacent tokens. Defaults to a space.
+	TokenSeparator *string `json:"token_separator,omitempty"`
+	// The string used in shingles as a replacement for empty positions that do
+	// not contain a token. Defaults to an underscore.
+	FillerToken *string `json:"filler_token,omitempty"`
+}
+
+func (ShingleTokenFilter) Type() TokenFilterType {
+	return TokenFilterTypeShingle
+}
+
+// Validate checks that MinShingleSize is not greater than MaxShingleSize
+func (f ShingleTokenFilter) Validate() error {
+	if f.MaxShingleSize != 0 && f.MinShingleSize > f.MaxShingleSize {
+		return fmt.Errorf("%w; min_shingle_size <%d> is greater than max_shingle_size <%d>", ErrInvalidShingleSize, f.MinShingleSize, f.MaxShingleSize)
+	}
+	return nil
+}
+
+func (f ShingleTokenFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(f.Type()), shingleTokenFilter(f))
+}
+
+func (f *ShingleTokenFilter) UnmarshalJSON(data []byte) error {
+	var v shingleTokenFilter
+	err := unmarshalAnalysisComponent(data, &v)
+	*f = ShingleTokenFilter(v)
+	return err
+}
+
+//easyjson:json
+type shingleTokenFilter ShingleTokenFilter
+
+// WordDelimiterTokenFilter splits tokens at non-alphanumeric characters.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-word-delimiter-tokenfilter.html
+type WordDelimiterTokenFilter struct {
+	// If true, includes tokens made of alphabetical characters in the output.
+	// Defaults to true.
+	GenerateWordParts *bool `json:"generate_word_parts,omitempty"`
+	// If true, includes tokens made of numeric characters in the output.
+	// Defaults to true.
+	GenerateNumberParts *bool `json:"generate_number_parts,omitempty"`
+	// If true, produces tokens for chains of alphabetical characters
+	// separated by delimiters. Defaults to false.
+	CatenateWords *bool `json:"catenate_words,omitempty"`
+	// If true, produces tokens for chains of numeric characters separated by
+	// delimiters. Defaults to false.
+	CatenateNumbers *bool `json:"catenate_numbers,omitempty"`
+	// If true, produces tokens for chains of alphanumeric characters separated
+	// by delimiters. Defaults to false.
+	CatenateAll *bool `json:"catenate_all,omitempty"`
+	// If true, splits tokens at letter case transitions. Defaults to true.
+	SplitOnCaseChange *bool `json:"split_on_case_change,omitempty"`
+	// If true, splits tokens at letter-number transitions. Defaults to true.
+	SplitOnNumerics *bool `json:"split_on_numerics,omitempty"`
+	// If true, removes the English possessive ('s) from the end of each token.
+	// Defaults to true.
+	StemEnglishPossessive *bool `json:"stem_english_possessive,omitempty"`
+	// If true, emits the original token as well. Defaults to false.
+	PreserveOriginal *bool `json:"preserve_original,omitempty"`
+	// If true, the offsets of split or catenated tokens are adjusted to match
+	// the original text. Only supported by word_delimiter_graph. Defaults to
+	// true.
+	AdjustOffsets *bool `json:"adjust_offsets,omitempty"`
+	// If true, skips tokens with a keyword attribute. Only supported by
+	// word_delimiter_graph. Defaults to false.
+	IgnoreKeywords *bool `json:"ignore_keywords,omitempty"`
+	// Tokens which are not split
+	ProtectedWords []string `json:"protected_words,omitempty"`
+	// The path to a file containing tokens which are not split
+	ProtectedWordsPath string `json:"protected_words_path,omitempty"`
+	// Custom type mappings for characters, e.g. "# => ALPHA"
+	TypeTable []string `json:"type_table,omitempty"`
+	// The path to a file containing custom type mappings for characters
+	TypeTablePath string `json:"type_table_path,omitempty"`
+}
+
+func (WordDelimiterTokenFilter) Type() TokenFilterType {
+	return TokenFilterTypeWordDelimiter
+}
+
+func (f WordDelimiterTokenFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(f.Type()), wordDelimiterTokenFilter(f))
+}
+
+func (f *WordDelimiterTokenFilter) UnmarshalJSON(data []byte) error {
+	var v wordDelimiterTokenFilter
+	err := unmarshalAnalysisComponent(data, &v)
+	*f = WordDelimiterTokenFilter(v)
+	return err
+}
+
+//easyjson:json
+type wordDelimiterTokenFilter WordDelimiterTokenFilter
+
+// WordDelimiterGraphTokenFilter splits tokens at non-alphanumeric characters,
+// producing a token graph which is correctly handled by multi-position
+// queries.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-word-delimiter-graph-tokenfilter.html
+type WordDelimiterGraphTokenFilter WordDelimiterTokenFilter
+
+func (WordDelimiterGraphTokenFilter) Type() TokenFilterType {
+	return TokenFilterTypeWordDelimiterGraph
+}
+
+func (f WordDelimiterGraphTokenFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(f.Type()), wordDelimiterTokenFilter(f))
+}
+
+func (f *WordDelimiterGraphTokenFilter) UnmarshalJSON(data []byte) error {
+	var v wordDelimiterTokenFilter
+	err := unmarshalAnalysisComponent(data, &v)
+	*f = WordDelimiterGraphTokenFilter(v)
+	return err
+}
+
+// EdgeNGramTokenFilter forms an n-gram of a specified length from the
+// beginning of a token.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-edgengram-tokenfilter.html
+type EdgeNGramTokenFilter struct {
+	// Minimum length of characters in a gram. Defaults to 1.
+	MinGram int `json:"min_gram,omitempty"`
+	// Maximum length of characters in a gram. Defaults to 2.
+	MaxGram int `json:"max_gram,omitempty"`
+	// If true, emits the original token as well. Defaults to false.
+	PreserveOriginal *bool `json:"preserve_original,omitempty"`
+	// Deprecated. Indicates whether to truncate tokens from the "front" or
+	// "back". Defaults to "front".
+	Side string `json:"side,omitempty"`
+}
+
+func (EdgeNGramTokenFilter) Type() TokenFilterType {
+	return TokenFilterTypeEdgeNGram
+}
+
+func (f EdgeNGramTokenFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(f.Type()), edgeNGramTokenFilter(f))
+}
+
+func (f *EdgeNGramTokenFilter) UnmarshalJSON(data []byte) error {
+	var v edgeNGramTokenFilter
+	err := unmarshalAnalysisComponent(data, &v)
+	*f = EdgeNGramTokenFilter(v)
+	return err
+}
+
+//easyjson:json
+type edgeNGramTokenFilter EdgeNGramTokenFilter
+
+// NGramTokenFilter forms n-grams of specified lengths from a token.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-ngram-tokenfilter.html
+type NGramTokenFilter struct {
+	// Minimum length of characters in a gram. Defaults to 1.
+	MinGram int `json:"min_gram,omitempty"`
+	// Maximum length of characters in a gram. Defaults to 2.
+	MaxGram int `json:"max_gram,omitempty"`
+	// If true, emits the original token as well. Defaults to false.
+	PreserveOriginal *bool `json:"preserve_original,omitempty"`
+}
+
+func (NGramTokenFilter) Type() TokenFilterType {
+	return TokenFilterTypeNGram
+}
+
+func (f NGramTokenFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(f.Type()), ngramTokenFilter(f))
+}
+
+func (f *NGramTokenFilter) UnmarshalJSON(data []byte) error {
+	var v ngramTokenFilter
+	err := unmarshalAnalysisComponent(data, &v)
+	*f = NGramTokenFilter(v)
+	return err
+}
+
+//easyjson:json
+type ngramTokenFilter NGramTokenFilter
+
+// LengthTokenFilter removes tokens shorter or longer than specified character
+// lengths.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-length-tokenfilter.html
+type LengthTokenFilter struct {
+	// Minimum character length of a token. Defaults to 0.
+	Min int `json:"min,omitempty"`
+	// Maximum character length of a token. Defaults to 2^31-1.
+	Max int `json:"max,omitempty"`
+}
+
+func (LengthTokenFilter) Type() TokenFilterType {
+	return TokenFilterTypeLength
+}
+
+func (f LengthTokenFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(f.Type()), lengthTokenFilter(f))
+}
+
+func (f *LengthTokenFilter) UnmarshalJSON(data []byte) error {
+	var v lengthTokenFilter
+	err := unmarshalAnalysisComponent(data, &v)
+	*f = LengthTokenFilter(v)
+	return err
+}
+
+//easyjson:json
+type lengthTokenFilter LengthTokenFilter
+
+// TruncateTokenFilter truncates tokens that exceed a specified character
+// limit.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-truncate-tokenfilter.html
+type TruncateTokenFilter struct {
+	// Character limit for each token. Defaults to 10.
+	Length int `json:"length,omitempty"`
+}
+
+func (TruncateTokenFilter) Type() TokenFilterType {
+	return TokenFilterTypeTruncate
+}
+
+func (f TruncateTokenFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(f.Type()), truncateTokenFilter(f))
+}
+
+func (f *TruncateTokenFilter) UnmarshalJSON(data []byte) error {
+	var v truncateTokenFilter
+	err := unmarshalAnalysisComponent(data, &v)
+	*f = TruncateTokenFilter(v)
+	return err
+}
+
+//easyjson:json
+type truncateTokenFilter TruncateTokenFilter
+
+// UniqueTokenFilter removes duplicate tokens from a stream.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-unique-tokenfilter.html
+type UniqueTokenFilter struct {
+	// If true, only removes duplicate tokens in the same position. Defaults to
+	// false.
+	OnlyOnSamePosition *bool `json:"only_on_same_position,omitempty"`
+}
+
+func (UniqueTokenFilter) Type() TokenFilterType {
+	return TokenFilterTypeUnique
+}
+
+func (f UniqueTokenFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(f.Type()), uniqueTokenFilter(f))
+}
+
+func (f *UniqueTokenFilter) UnmarshalJSON(data []byte) error {
+	var v uniqueTokenFilter
+	err := unmarshalAnalysisComponent(data, &v)
+	*f = UniqueTokenFilter(v)
+	return err
+}
+
+//easyjson:json
+type uniqueTokenFilter UniqueTokenFilter
+
+// TrimTokenFilter removes leading and trailing whitespace from each token.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-trim-tokenfilter.html
+type TrimTokenFilter struct{}
+
+func (TrimTokenFilter) Type() TokenFilterType {
+	return TokenFilterTypeTrim
+}
+
+func (f TrimTokenFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(f.Type()), trimTokenFilter(f))
+}
+
+func (f *TrimTokenFilter) UnmarshalJSON(data []byte) error {
+	var v trimTokenFilter
+	err := unmarshalAnalysisComponent(data, &v)
+	*f = TrimTokenFilter(v)
+	return err
+}
+
+//easyjson:json
+type trimTokenFilter TrimTokenFilter
+
+// ReverseTokenFilter reverses each token in a stream.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-reverse-tokenfilter.html
+type ReverseTokenFilter struct{}
+
+func (ReverseTokenFilter) Type() TokenFilterType {
+	return TokenFilterTypeReverse
+}
+
+func (f ReverseTokenFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(f.Type()), reverseTokenFilter(f))
+}
+
+func (f *ReverseTokenFilter) UnmarshalJSON(data []byte) error {
+	var v reverseTokenFilter
+	err := unmarshalAnalysisComponent(data, &v)
+	*f = ReverseTokenFilter(v)
+	return err
+}
+
+//easyjson:json
+type reverseTokenFilter ReverseTokenFilter
+
+// PorterStemTokenFilter provides algorithmic stemming for the English
+// language, based on the Porter stemming algorithm.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-porterstem-tokenfilter.html
+type PorterStemTokenFilter struct{}
+
+func (PorterStemTokenFilter) Type() TokenFilterType {
+	return TokenFilterTypePorterStem
+}
+
+func (f PorterStemTokenFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(f.Type()), porterStemTokenFilter(f))
+}
+
+func (f *PorterStemTokenFilter) UnmarshalJSON(data []byte) error {
+	var v porterStemTokenFilter
+	err := unmarshalAnalysisComponent(data, &v)
+	*f = PorterStemTokenFilter(v)
+	return err
+}
+
+//easyjson:json
+type porterStemTokenFilter PorterStemTokenFilter
+
+// KStemTokenFilter provides KStem-based stemming for the English language.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-kstem-tokenfilter.html
+type KStemTokenFilter struct{}
+
+func (KStemTokenFilter) Type() TokenFilterType {
+	return TokenFilterTypeKStem
+}
+
+func (f KStemTokenFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(f.Type()), kStemTokenFilter(f))
+}
+
+func (f *KStemTokenFilter) UnmarshalJSON(data []byte) error {
+	var v kStemTokenFilter
+	err := unmarshalAnalysisComponent(data, &v)
+	*f = KStemTokenFilter(v)
+	return err
+}
+
+//easyjson:json
+type kStemTokenFilter KStemTokenFilter
+
+// ApostropheTokenFilter strips all characters after an apostrophe, including
+// the apostrophe itself.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-apostrophe-tokenfilter.html
+type ApostropheTokenFilter struct{}
+
+func (ApostropheTokenFilter) Type() TokenFilterType {
+	return TokenFilterTypeApostrophe
+}
+
+func (f ApostropheTokenFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(f.Type()), apostropheTokenFilter(f))
+}
+
+func (f *ApostropheTokenFilter) UnmarshalJSON(data []byte) error {
+	var v apostropheTokenFilter
+	err := unmarshalAnalysisComponent(data, &v)
+	*f = ApostropheTokenFilter(v)
+	return err
+}
+
+//easyjson:json
+type apostropheTokenFilter ApostropheTokenFilter
+
+// DecimalDigitTokenFilter converts all digits in the Unicode Decimal_Number
+// General Category to 0-9.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-decimal-digit-tokenfilter.html
+type DecimalDigitTokenFilter struct{}
+
+func (DecimalDigitTokenFilter) Type() TokenFilterType {
+	return TokenFilterTypeDecimalDigit
+}
+
+func (f DecimalDigitTokenFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(f.Type()), decimalDigitTokenFilter(f))
+}
+
+func (f *DecimalDigitTokenFilter) UnmarshalJSON(data []byte) error {
+	var v decimalDigitTokenFilter
+	err := unmarshalAnalysisComponent(data, &v)
+	*f = DecimalDigitTokenFilter(v)
+	return err
+}
+
+//easyjson:json
+type decimalDigitTokenFilter DecimalDigitTokenFilter
+
+// FlattenGraphTokenFilter flattens a token graph produced by a graph token
+// filter, such as synonym_graph or word_delimiter_graph.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-flatten-graph-tokenfilter.html
+type FlattenGraphTokenFilter struct{}
+
+func (FlattenGraphTokenFilter) Type() TokenFilterType {
+	return TokenFilterTypeFlattenGraph
+}
+
+func (f FlattenGraphTokenFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(f.Type()), flattenGraphTokenFilter(f))
+}
+
+func (f *FlattenGraphTokenFilter) UnmarshalJSON(data []byte) error {
+	var v flattenGraphTokenFilter
+	err := unmarshalAnalysisComponent(data, &v)
+	*f = FlattenGraphTokenFilter(v)
+	return err
+}
+
+//easyjson:json
+type flattenGraphTokenFilter FlattenGraphTokenFilter
+
+// RemoveDuplicatesTokenFilter removes duplicate tokens in the same position.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-remove-duplicates-tokenfilter.html
+type RemoveDuplicatesTokenFilter struct{}
+
+func (RemoveDuplicatesTokenFilter) Type() TokenFilterType {
+	return TokenFilterTypeRemoveDuplicates
+}
+
+func (f RemoveDuplicatesTokenFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(f.Type()), removeDuplicatesTokenFilter(f))
+}
+
+func (f *RemoveDuplicatesTokenFilter) UnmarshalJSON(data []byte) error {
+	var v removeDuplicatesTokenFilter
+	err := unmarshalAnalysisComponent(data, &v)
+	*f = RemoveDuplicatesTokenFilter(v)
+	return err
+}
+
+//easyjson:json
+type removeDuplicatesTokenFilter RemoveDuplicatesTokenFilter
+
+// KeywordRepeatTokenFilter outputs a keyword version of each token in a
+// stream, followed by the non-keyword version.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-keyword-repeat-tokenfilter.html
+type KeywordRepeatTokenFilter struct{}
+
+func (KeywordRepeatTokenFilter) Type() TokenFilterType {
+	return TokenFilterTypeKeywordRepeat
+}
+
+func (f KeywordRepeatTokenFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(f.Type()), keywordRepeatTokenFilter(f))
+}
+
+func (f *KeywordRepeatTokenFilter) UnmarshalJSON(data []byte) error {
+	var v keywordRepeatTokenFilter
+	err := unmarshalAnalysisComponent(data, &v)
+	*f = KeywordRepeatTokenFilter(v)
+	return err
+}
+
+//easyjson:json
+type keywordRepeatTokenFilter KeywordRepeatTokenFilter
+
+// ClassicTokenFilter performs optional post-processing of terms generated by
+// the classic tokenizer.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-classic-tokenfilter.html
+type ClassicTokenFilter struct{}
+
+func (ClassicTokenFilter) Type() TokenFilterType {
+	return TokenFilterTypeClassic
+}
+
+func (f ClassicTokenFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(f.Type()), classicTokenFilter(f))
+}
+
+func (f *ClassicTokenFilter) UnmarshalJSON(data []byte) error {
+	var v classicTokenFilter
+	err := unmarshalAnalysisComponent(data, &v)
+	*f = ClassicTokenFilter(v)
+	return err
+}
+
+//easyjson:json
+type classicTokenFilter ClassicTokenFilter
+
+// CJKWidthTokenFilter normalizes width differences in CJK (Chinese, Japanese,
+// and Korean) characters.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-cjk-width-tokenfilter.html
+type CJKWidthTokenFilter struct{}
+
+func (CJKWidthTokenFilter) Type() TokenFilterType {
+	return TokenFilterTypeCJKWidth
+}
+
+func (f CJKWidthTokenFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(f.Type()), cjkWidthTokenFilter(f))
+}
+
+func (f *CJKWidthTokenFilter) UnmarshalJSON(data []byte) error {
+	var v cjkWidthTokenFilter
+	err := unmarshalAnalysisComponent(data, &v)
+	*f = CJKWidthTokenFilter(v)
+	return err
+}
+
+//easyjson:json
+type cjkWidthTokenFilter CJKWidthTokenFilter
+
+// ElisionTokenFilter removes specified elisions from the beginning of tokens.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-elision-tokenfilter.html
+type ElisionTokenFilter struct {
+	// Elisions to remove
+	Articles []string `json:"articles,omitempty"`
+	// The path to a file containing elisions to remove
+	ArticlesPath string `json:"articles_path,omitempty"`
+	// If true, elision matching is case insensitive. Defaults to false.
+	ArticlesCase *bool `json:"articles_case,omitempty"`
+}
+
+func (ElisionTokenFilter) Type() TokenFilterType {
+	return TokenFilterTypeElision
+}
+
+func (f ElisionTokenFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(f.Type()), elisionTokenFilter(f))
+}
+
+func (f *ElisionTokenFilter) UnmarshalJSON(data []byte) error {
+	var v elisionTokenFilter
+	err := unmarshalAnalysisComponent(data, &v)
+	*f = ElisionTokenFilter(v)
+	return err
+}
+
+//easyjson:json
+type elisionTokenFilter ElisionTokenFilter
+
+// KeywordMarkerTokenFilter marks specified tokens as keywords, which are not
+// stemmed.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-keyword-marker-tokenfilter.html
+type KeywordMarkerTokenFilter struct {
+	// If true, matching is case insensitive. Defaults to false.
+	IgnoreCase *bool `json:"ignore_case,omitempty"`
+	// Keywords which are not stemmed
+	Keywords []string `json:"keywords,omitempty"`
+	// The path to a file containing keywords
+	KeywordsPath string `json:"keywords_path,omitempty"`
+	// A Java regular expression used to match tokens which are not stemmed
+	KeywordsPattern string `json:"keywords_pattern,omitempty"`
+}
+
+func (KeywordMarkerTokenFilter) Type() TokenFilterType {
+	return TokenFilterTypeKeywordMarker
+}
+
+func (f KeywordMarkerTokenFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(f.Type()), keywordMarkerTokenFilter(f))
+}
+
+func (f *KeywordMarkerTokenFilter) UnmarshalJSON(data []byte) error {
+	var v keywordMarkerTokenFilter
+	err := unmarshalAnalysisComponent(data, &v)
+	*f = KeywordMarkerTokenFilter(v)
+	return err
+}
+
+//easyjson:json
+type keywordMarkerTokenFilter KeywordMarkerTokenFilter
+
+// PatternReplaceTokenFilter uses a regular expression to match and replace
+// token substrings.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-pattern_replace-tokenfilter.html
+type PatternReplaceTokenFilter struct {
+	// A Java regular expression (Required)
+	Pattern string `json:"pattern"`
+	// The replacement substring. Defaults to an empty string.
+	Replacement string `json:"replacement,omitempty"`
+	// If true, all substrings matching the pattern are replaced. Defaults to
+	// true.
+	All *bool `json:"all,omitempty"`
+}
+
+func (PatternReplaceTokenFilter) Type() TokenFilterType {
+	return TokenFilterTypePatternReplace
+}
+
+func (f PatternReplaceTokenFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(f.Type()), patternReplaceTokenFilter(f))
+}
+
+func (f *PatternReplaceTokenFilter) UnmarshalJSON(data []byte) error {
+	var v patternReplaceTokenFilter
+	err := unmarshalAnalysisComponent(data, &v)
+	*f = PatternReplaceTokenFilter(v)
+	return err
+}
+
+//easyjson:json
+type patternReplaceTokenFilter PatternReplaceTokenFilter
+
+// SnowballTokenFilter stems words using a Snowball-generated stemmer.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-snowball-tokenfilter.html
+type SnowballTokenFilter struct {
+	// The language of the stemmer, e.g. "English"
+	Language string `json:"language,omitempty"`
+}
+
+func (SnowballTokenFilter) Type() TokenFilterType {
+	return TokenFilterTypeSnowball
+}
+
+func (f SnowballTokenFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(f.Type()), snowballTokenFilter(f))
+}
+
+func (f *SnowballTokenFilter) UnmarshalJSON(data []byte) error {
+	var v snowballTokenFilter
+	err := unmarshalAnalysisComponent(data, &v)
+	*f = SnowballTokenFilter(v)
+	return err
+}
+
+//easyjson:json
+type snowballTokenFilter SnowballTokenFilter
+
+// StemmerOverrideTokenFilter overrides stemming algorithms by applying a
+// custom mapping, then protecting these terms from being modified by
+// stemmers.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-stemmer-override-tokenfilter.html
+type StemmerOverrideTokenFilter struct {
+	// Mapping rules, e.g. "running, runs => run"
+	Rules []string `json:"rules,omitempty"`
+	// The path to a file containing mapping rules
+	RulesPath string `json:"rules_path,omitempty"`
+}
+
+func (StemmerOverrideTokenFilter) Type() TokenFilterType {
+	return TokenFilterTypeStemmerOverride
+}
+
+func (f StemmerOverrideTokenFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(f.Type()), stemmerOverrideTokenFilter(f))
+}
+
+func (f *StemmerOverrideTokenFilter) UnmarshalJSON(data []byte) error {
+	var v stemmerOverrideTokenFilter
+	err := unmarshalAnalysisComponent(data, &v)
+	*f = StemmerOverrideTokenFilter(v)
+	return err
+}
+
+//easyjson:json
+type stemmerOverrideTokenFilter StemmerOverrideTokenFilter
+
+// CommonGramsTokenFilter generates bigrams for a specified set of common
+// words.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-common-grams-tokenfilter.html
+type CommonGramsTokenFilter struct {
+	// Words for which bigrams are generated
+	CommonWords []string `json:"common_words,omitempty"`
+	// The path to a file containing common words
+	CommonWordsPath string `json:"common_words_path,omitempty"`
+	// If true, matching is case insensitive. Defaults to false.
+	IgnoreCase *bool `json:"ignore_case,omitempty"`
+	// If true, excludes unigrams of common words and the common words
+	// themselves from the output. Defaults to false.
+	QueryMode *bool `json:"query_mode,omitempty"`
+}
+
+func (CommonGramsTokenFilter) Type() TokenFilterType {
+	return TokenFilterTypeCommonGrams
+}
+
+func (f CommonGramsTokenFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(f.Type()), commonGramsTokenFilter(f))
+}
+
+func (f *CommonGramsTokenFilter) UnmarshalJSON(data []byte) error {
+	var v commonGramsTokenFilter
+	err := unmarshalAnalysisComponent(data, &v)
+	*f = CommonGramsTokenFilter(v)
+	return err
+}
+
+//easyjson:json
+type commonGramsTokenFilter CommonGramsTokenFilter
+
+// MultiplexerTokenFilter emits multiple tokens at the same position, each
+// version of the token having been run through a different filter.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-multiplexer-tokenfilter.html
+type MultiplexerTokenFilter struct {
+	// Filters to apply. Each filter may be a comma-delimited string of
+	// filters.
+	Filters []string `json:"filters,omitempty"`
+	// If true, emits the original token as well. Defaults to true.
+	PreserveOriginal *bool `json:"preserve_original,omitempty"`
+}
+
+func (MultiplexerTokenFilter) Type() TokenFilterType {
+	return TokenFilterTypeMultiplexer
+}
+
+func (f MultiplexerTokenFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(f.Type()), multiplexerTokenFilter(f))
+}
+
+func (f *MultiplexerTokenFilter) UnmarshalJSON(data []byte) error {
+	var v multiplexerTokenFilter
+	err := unmarshalAnalysisComponent(data, &v)
+	*f = MultiplexerTokenFilter(v)
+	return err
+}
+
+//easyjson:json
+type multiplexerTokenFilter MultiplexerTokenFilter
+
+// LimitTokenFilter limits the number of output tokens.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-limit-tokenfilter.html
+type LimitTokenFilter struct {
+	// Maximum number of tokens to keep. Defaults to 1.
+	MaxTokenCount int `json:"max_token_count,omitempty"`
+	// If true, the limit filter exhausts the token stream, even if the max
+	// token count has been reached. Defaults to false.
+	ConsumeAllTokens *bool `json:"consume_all_tokens,omitempty"`
+}
+
+func (LimitTokenFilter) Type() TokenFilterType {
+	return TokenFilterTypeLimit
+}
+
+func (f LimitTokenFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(f.Type()), limitTokenFilter(f))
+}
+
+func (f *LimitTokenFilter) UnmarshalJSON(data []byte) error {
+	var v limitTokenFilter
+	err := unmarshalAnalysisComponent(data, &v)
+	*f = LimitTokenFilter(v)
+	return err
+}
+
+//easyjson:json
+type limitTokenFilter LimitTokenFilter
+
+// FingerprintTokenFilter sorts and removes duplicate tokens from a token
+// stream, then concatenates the stream into a single output token.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-fingerprint-tokenfilter.html
+type FingerprintTokenFilter struct {
+	// Maximum character length of the output token. Defaults to 255.
+	MaxOutputSize int `json:"max_output_size,omitempty"`
+	// Character used to concatenate the tokens. Defaults to a space.
+	Separator string `json:"separator,omitempty"`
+}
+
+func (FingerprintTokenFilter) Type() TokenFilterType {
+	return TokenFilterTypeFingerprint
+}
+
+func (f FingerprintTokenFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(f.Type()), fingerprintTokenFilter(f))
+}
+
+func (f *FingerprintTokenFilter) UnmarshalJSON(data []byte) error {
+	var v fingerprintTokenFilter
+	err := unmarshalAnalysisComponent(data, &v)
+	*f = FingerprintTokenFilter(v)
+	return err
+}
+
+//easyjson:json
+type fingerprintTokenFilter FingerprintTokenFilter
+
+// KeepWordsTokenFilter keeps only tokens contained in a specified word list.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-keep-words-tokenfilter.html
+type KeepWordsTokenFilter struct {
+	// Words to keep
+	KeepWords []string `json:"keep_words,omitempty"`
+	// The path to a file containing words to keep
+	KeepWordsPath string `json:"keep_words_path,omitempty"`
+	// If true, lowercases all keep words. Defaults to false.
+	KeepWordsCase *bool `json:"keep_words_case,omitempty"`
+}
+
+func (KeepWordsTokenFilter) Type() TokenFilterType {
+	return TokenFilterTypeKeepWords
+}
+
+func (f KeepWordsTokenFilter) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(f.Type()), keepWordsTokenFilter(f))
+}
+
+func (f *KeepWordsTokenFilter) UnmarshalJSON(data []byte) error {
+	var v keepWordsTokenFilter
+	err := unmarshalAnalysisComponent(data, &v)
+	*f = KeepWordsTokenFilter(v)
+	return err
+}
+
+//easyjson:json
+type keepWordsTokenFilter KeepWordsTokenFilter
@@ -0,0 +1,609 @@
+package picker
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/chanced/dynamic"
+	"github.com/tidwall/gjson"
+)
+
+// TokenizerType is the type of a Tokenizer
+type TokenizerType string
+
+func (t TokenizerType) String() string {
+	return string(t)
+}
+
+const (
+	TokenizerTypeStandard           TokenizerType = "standard"
+	TokenizerTypeClassic            TokenizerType = "classic"
+	TokenizerTypeUAXURLEmail        TokenizerType = "uax_url_email"
+	TokenizerTypeWhitespace         TokenizerType = "whitespace"
+	TokenizerTypeLetter             TokenizerType = "letter"
+	TokenizerTypeLowercase          TokenizerType = "lowercase"
+	TokenizerTypeThai               TokenizerType = "thai"
+	TokenizerTypeKeyword            TokenizerType = "keyword"
+	TokenizerTypeNGram              TokenizerType = "ngram"
+	TokenizerTypeEdgeNGram          TokenizerType = "edge_ngram"
+	TokenizerTypePattern            TokenizerType = "pattern"
+	TokenizerTypeSimplePattern      TokenizerType = "simple_pattern"
+	TokenizerTypeSimplePatternSplit TokenizerType = "simple_pattern_split"
+	TokenizerTypeCharGroup          TokenizerType = "char_group"
+	TokenizerTypePathHierarchy      TokenizerType = "path_hierarchy"
+)
+
+// TokenizerTypeHandlers is a map of tokenizer types to funcs which return a
+// Tokenizer of that type, used when unmarshaling Tokenizers. Tokenizers
+// provided by plugins can be registered here; those which are not registered
+// are unmarshaled as a *RawTokenizer.
+var TokenizerTypeHandlers = map[TokenizerType]func() Tokenizer{
+	TokenizerTypeStandard:           func() Tokenizer { return &StandardTokenizer{} },
+	TokenizerTypeClassic:            func() Tokenizer { return &ClassicTokenizer{} },
+	TokenizerTypeUAXURLEmail:        func() Tokenizer { return &UAXURLEmailTokenizer{} },
+	TokenizerTypeWhitespace:         func() Tokenizer { return &WhitespaceTokenizer{} },
+	TokenizerTypeLetter:             func() Tokenizer { return &LetterTokenizer{} },
+	TokenizerTypeLowercase:          func() Tokenizer { return &LowercaseTokenizer{} },
+	TokenizerTypeThai:               func() Tokenizer { return &ThaiTokenizer{} },
+	TokenizerTypeKeyword:            func() Tokenizer { return &KeywordTokenizer{} },
+	TokenizerTypeNGram:              func() Tokenizer { return &NGramTokenizer{} },
+	TokenizerTypeEdgeNGram:          func() Tokenizer { return &EdgeNGramTokenizer{} },
+	TokenizerTypePattern:            func() Tokenizer { return &PatternTokenizer{} },
+	TokenizerTypeSimplePattern:      func() Tokenizer { return &SimplePatternTokenizer{} },
+	TokenizerTypeSimplePatternSplit: func() Tokenizer { return &SimplePatternSplitTokenizer{} },
+	TokenizerTypeCharGroup:          func() Tokenizer { return &CharGroupTokenizer{} },
+	TokenizerTypePathHierarchy:      func() Tokenizer { return &PathHierarchyTokenizer{} },
+}
+
+// Tokenizer receives a stream of characters, breaks it up into individual
+// tokens (usually individual words), and outputs a stream of tokens.
+//
+// A Tokenizer can optionally implement Validate() error.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-tokenizers.html
+type Tokenizer interface {
+	// Type is the type of the tokenizer, e.g. "standard"
+	Type() TokenizerType
+	json.Marshaler
+	json.Unmarshaler
+}
+
+// Tokenizers are custom tokenizers, keyed by name
+type Tokenizers map[string]Tokenizer
+
+// Validate checks each Tokenizer which implements Validate
+func (m Tokenizers) Validate() error {
+	for name, v := range m {
+		if v == nil {
+			return newFieldError(ErrMissingType, name)
+		}
+		if vv, ok := v.(interface{ Validate() error }); ok {
+			if err := vv.Validate(); err != nil {
+				return newFieldError(err, name)
+			}
+		}
+	}
+	return nil
+}
+
+func (m Tokenizers) MarshalBSON() ([]byte, error) {
+	return m.MarshalJSON()
+}
+
+func (m Tokenizers) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]Tokenizer(m))
+}
+
+func (m *Tokenizers) UnmarshalBSON(data []byte) error {
+	return m.UnmarshalJSON(data)
+}
+
+func (m *Tokenizers) UnmarshalJSON(data []byte) error {
+	*m = nil
+	var obj dynamic.JSONObject
+	err := json.Unmarshal(data, &obj)
+	if err != nil {
+		return err
+	}
+	if len(obj) == 0 {
+		return nil
+	}
+	res := make(Tokenizers, len(obj))
+	for name, d := range obj {
+		var v Tokenizer
+		err = UnmarshalTokenizerJSON(d, &v)
+		if err != nil {
+			return newFieldError(err, name)
+		}
+		res[name] = v
+	}
+	*m = res
+	return nil
+}
+
+// UnmarshalTokenizerJSON decodes data into the Tokenizer registered in
+// TokenizerTypeHandlers for its type, or a *RawTokenizer if the type is unknown.
+func UnmarshalTokenizerJSON(data []byte, v *Tokenizer) error {
+	t := gjson.GetBytes(data, "type").String()
+	if len(t) == 0 {
+		return fmt.Errorf("%w; can not unmarshal tokenizer", ErrMissingType)
+	}
+	var res Tokenizer
+	if handler, ok := TokenizerTypeHandlers[TokenizerType(t)]; ok {
+		res = handler()
+	} else {
+		res = &RawTokenizer{TokenizerType: TokenizerType(t)}
+	}
+	err := res.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	*v = res
+	return nil
+}
+
+// RawTokenizer is a tokenizer which is kept as-is. It is used for tokenizers which
+// are not registered in TokenizerTypeHandlers, such as those provided by plugins.
+type RawTokenizer struct {
+	// The type of the tokenizer (Required)
+	TokenizerType TokenizerType
+	// The settings of the tokenizer, other than type
+	Data dynamic.JSON
+}
+
+func (t RawTokenizer) Type() TokenizerType {
+	return t.TokenizerType
+}
+
+// Validate checks that the type is set
+func (t RawTokenizer) Validate() error {
+	if len(t.TokenizerType) == 0 {
+		return ErrMissingType
+	}
+	return nil
+}
+
+func (t RawTokenizer) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(t.TokenizerType), t.Data)
+}
+
+func (t *RawTokenizer) UnmarshalJSON(data []byte) error {
+	t.TokenizerType = TokenizerType(gjson.GetBytes(data, "type").String())
+	t.Data = append(dynamic.JSON(nil), data...)
+	return nil
+}
+
+// StandardTokenizer provides grammar based tokenization (based on the Unicode
+// Text Segmentation algorithm) and works well for most languages.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-standard-tokenizer.html
+type StandardTokenizer struct {
+	// The maximum token length. Tokens which exceed it are split. Defaults to 255.
+	MaxTokenLength int `json:"max_token_length,omitempty"`
+}
+
+func (StandardTokenizer) Type() TokenizerType {
+	return TokenizerTypeStandard
+}
+
+func (t StandardTokenizer) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(t.Type()), standardTokenizer(t))
+}
+
+func (t *StandardTokenizer) UnmarshalJSON(data []byte) error {
+	var v standardTokenizer
+	err := unmarshalAnalysisComponent(data, &v)
+	*t = StandardTokenizer(v)
+	return err
+}
+
+//easyjson:json
+type standardTokenizer StandardTokenizer
+
+// ClassicTokenizer is a grammar based tokenizer that is good for English
+// language documents.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-classic-tokenizer.html
+type ClassicTokenizer struct {
+	// The maximum token length. Tokens which exceed it are split. Defaults to 255.
+	MaxTokenLength int `json:"max_token_length,omitempty"`
+}
+
+func (ClassicTokenizer) Type() TokenizerType {
+	return TokenizerTypeClassic
+}
+
+func (t ClassicTokenizer) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(t.Type()), classicTokenizer(t))
+}
+
+func (t *ClassicTokenizer) UnmarshalJSON(data []byte) error {
+	var v classicTokenizer
+	err := unmarshalAnalysisComponent(data, &v)
+	*t = ClassicTokenizer(v)
+	return err
+}
+
+//easyjson:json
+type classicTokenizer ClassicTokenizer
+
+// UAXURLEmailTokenizer is like the StandardTokenizer except that it recognises
+// URLs and email addresses as single tokens.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-uaxurlemail-tokenizer.html
+type UAXURLEmailTokenizer struct {
+	// The maximum token length. Tokens which exceed it are split. Defaults to 255.
+	MaxTokenLength int `json:"max_token_length,omitempty"`
+}
+
+func (UAXURLEmailTokenizer) Type() TokenizerType {
+	return TokenizerTypeUAXURLEmail
+}
+
+func (t UAXURLEmailTokenizer) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(t.Type()), uaxurlEmailTokenizer(t))
+}
+
+func (t *UAXURLEmailTokenizer) UnmarshalJSON(data []byte) error {
+	var v uaxurlEmailTokenizer
+	err := unmarshalAnalysisComponent(data, &v)
+	*t = UAXURLEmailTokenizer(v)
+	return err
+}
+
+//easyjson:json
+type uaxurlEmailTokenizer UAXURLEmailTokenizer
+
+// WhitespaceTokenizer breaks text into terms whenever it encounters a
+// whitespace character.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-whitespace-tokenizer.html
+type WhitespaceTokenizer struct {
+	// The maximum token length. Tokens which exceed it are split. Defaults to 255.
+	MaxTokenLength int `json:"max_token_length,omitempty"`
+}
+
+func (WhitespaceTokenizer) Type() TokenizerType {
+	return TokenizerTypeWhitespace
+}
+
+func (t WhitespaceTokenizer) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(t.Type()), whitespaceTokenizer(t))
+}
+
+func (t *WhitespaceTokenizer) UnmarshalJSON(data []byte) error {
+	var v whitespaceTokenizer
+	err := unmarshalAnalysisComponent(data, &v)
+	*t = WhitespaceTokenizer(v)
+	return err
+}
+
+//easyjson:json
+type whitespaceTokenizer WhitespaceTokenizer
+
+// LetterTokenizer breaks text into terms whenever it encounters a character
+// which is not a letter.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-letter-tokenizer.html
+type LetterTokenizer struct{}
+
+func (LetterTokenizer) Type() TokenizerType {
+	return TokenizerTypeLetter
+}
+
+func (t LetterTokenizer) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(t.Type()), letterTokenizer(t))
+}
+
+func (t *LetterTokenizer) UnmarshalJSON(data []byte) error {
+	var v letterTokenizer
+	err := unmarshalAnalysisComponent(data, &v)
+	*t = LetterTokenizer(v)
+	return err
+}
+
+//easyjson:json
+type letterTokenizer LetterTokenizer
+
+// LowercaseTokenizer, like the LetterTokenizer, breaks text into terms
+// whenever it encounters a character which is not a letter, but it also
+// lowercases all terms.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-lowercase-tokenizer.html
+type LowercaseTokenizer struct{}
+
+func (LowercaseTokenizer) Type() TokenizerType {
+	return TokenizerTypeLowercase
+}
+
+func (t LowercaseTokenizer) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(t.Type()), lowercaseTokenizer(t))
+}
+
+func (t *LowercaseTokenizer) UnmarshalJSON(data []byte) error {
+	var v lowercaseTokenizer
+	err := unmarshalAnalysisComponent(data, &v)
+	*t = LowercaseTokenizer(v)
+	return err
+}
+
+//easyjson:json
+type lowercaseTokenizer LowercaseTokenizer
+
+// ThaiTokenizer segments Thai text into words, using the Thai segmentation
+// algorithm included with Java.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-thai-tokenizer.html
+type ThaiTokenizer struct{}
+
+func (ThaiTokenizer) Type() TokenizerType {
+	return TokenizerTypeThai
+}
+
+func (t ThaiTokenizer) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(t.Type()), thaiTokenizer(t))
+}
+
+func (t *ThaiTokenizer) UnmarshalJSON(data []byte) error {
+	var v thaiTokenizer
+	err := unmarshalAnalysisComponent(data, &v)
+	*t = ThaiTokenizer(v)
+	return err
+}
+
+//easyjson:json
+type thaiTokenizer ThaiTokenizer
+
+// KeywordTokenizer is a "noop" tokenizer that accepts whatever text it is
+// given and outputs the exact same text as a single term.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-keyword-tokenizer.html
+type KeywordTokenizer struct {
+	// The number of characters read into the term buffer in a single pass.
+	// Defaults to 256.
+	BufferSize int `json:"buffer_size,omitempty"`
+}
+
+func (KeywordTokenizer) Type() TokenizerType {
+	return TokenizerTypeKeyword
+}
+
+func (t KeywordTokenizer) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(t.Type()), keywordTokenizer(t))
+}
+
+func (t *KeywordTokenizer) UnmarshalJSON(data []byte) error {
+	var v keywordTokenizer
+	err := unmarshalAnalysisComponent(data, &v)
+	*t = KeywordTokenizer(v)
+	return err
+}
+
+//easyjson:json
+type keywordTokenizer KeywordTokenizer
+
+// NGramTokenizer first breaks text down into words whenever it encounters one
+// of a list of specified characters, then it emits N-grams of each word of the
+// specified length.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-ngram-tokenizer.html
+type NGramTokenizer struct {
+	// Minimum length of characters in a gram. Defaults to 1.
+	MinGram int `json:"min_gram,omitempty"`
+	// Maximum length of characters in a gram. Defaults to 2.
+	MaxGram int `json:"max_gram,omitempty"`
+	// Character classes that should be included in a token. Characters which
+	// do not belong to the classes are split on. Defaults to [] (keep all
+	// characters).
+	TokenChars []TokenChar `json:"token_chars,omitempty"`
+	// Custom characters that should be treated as part of a token when
+	// TokenChars includes TokenCharCustom.
+	CustomTokenChars string `json:"custom_token_chars,omitempty"`
+}
+
+func (NGramTokenizer) Type() TokenizerType {
+	return TokenizerTypeNGram
+}
+
+// Validate checks that MinGram is not greater than MaxGram
+func (t NGramTokenizer) Validate() error {
+	if t.MaxGram != 0 && t.MinGram > t.MaxGram {
+		return fmt.Errorf("%w; min_gram <%d> is greater than max_gram <%d>", ErrInvalidGramSize, t.MinGram, t.MaxGram)
+	}
+	return nil
+}
+
+func (t NGramTokenizer) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(t.Type()), ngramTokenizer(t))
+}
+
+func (t *NGramTokenizer) UnmarshalJSON(data []byte) error {
+	var v ngramTokenizer
+	err := unmarshalAnalysisComponent(data, &v)
+	*t = NGramTokenizer(v)
+	return err
+}
+
+//easyjson:json
+type ngramTokenizer NGramTokenizer
+
+// EdgeNGramTokenizer first breaks text down into words whenever it encounters
+// one of a list of specified characters, then it emits N-grams of each word
+// where the start of the N-gram is anchored to the beginning of the word.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-edgengram-tokenizer.html
+type EdgeNGramTokenizer NGramTokenizer
+
+func (EdgeNGramTokenizer) Type() TokenizerType {
+	return TokenizerTypeEdgeNGram
+}
+
+// Validate checks that MinGram is not greater than MaxGram
+func (t EdgeNGramTokenizer) Validate() error {
+	return NGramTokenizer(t).Validate()
+}
+
+func (t EdgeNGramTokenizer) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(t.Type()), ngramTokenizer(t))
+}
+
+func (t *EdgeNGramTokenizer) UnmarshalJSON(data []byte) error {
+	var v ngramTokenizer
+	err := unmarshalAnalysisComponent(data, &v)
+	*t = EdgeNGramTokenizer(v)
+	return err
+}
+
+// PatternTokenizer uses a regular expression to either split text into terms
+// whenever it matches a word separator, or to capture matching text as terms.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-pattern-tokenizer.html
+type PatternTokenizer struct {
+	// A Java regular expression. Defaults to \W+.
+	Pattern string `json:"pattern,omitempty"`
+	// Java regular expression flags, pipe-separated, e.g. "CASE_INSENSITIVE|COMMENTS".
+	Flags string `json:"flags,omitempty"`
+	// Which capture group to extract as tokens. Defaults to -1 (split).
+	Group *int `json:"group,omitempty"`
+}
+
+func (PatternTokenizer) Type() TokenizerType {
+	return TokenizerTypePattern
+}
+
+func (t PatternTokenizer) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(t.Type()), patternTokenizer(t))
+}
+
+func (t *PatternTokenizer) UnmarshalJSON(data []byte) error {
+	var v patternTokenizer
+	err := unmarshalAnalysisComponent(data, &v)
+	*t = PatternTokenizer(v)
+	return err
+}
+
+//easyjson:json
+type patternTokenizer PatternTokenizer
+
+// SimplePatternTokenizer uses a Lucene regular expression to capture matching
+// text as terms.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-simplepattern-tokenizer.html
+type SimplePatternTokenizer struct {
+	// A Lucene regular expression. Defaults to an empty string.
+	Pattern string `json:"pattern,omitempty"`
+}
+
+func (SimplePatternTokenizer) Type() TokenizerType {
+	return TokenizerTypeSimplePattern
+}
+
+func (t SimplePatternTokenizer) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(t.Type()), simplePatternTokenizer(t))
+}
+
+func (t *SimplePatternTokenizer) UnmarshalJSON(data []byte) error {
+	var v simplePatternTokenizer
+	err := unmarshalAnalysisComponent(data, &v)
+	*t = SimplePatternTokenizer(v)
+	return err
+}
+
+//easyjson:json
+type simplePatternTokenizer SimplePatternTokenizer
+
+// SimplePatternSplitTokenizer uses a Lucene regular expression to split the
+// input into terms at pattern matches.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-simplepatternsplit-tokenizer.html
+type SimplePatternSplitTokenizer struct {
+	// A Lucene regular expression. Defaults to an empty string.
+	Pattern string `json:"pattern,omitempty"`
+}
+
+func (SimplePatternSplitTokenizer) Type() TokenizerType {
+	return TokenizerTypeSimplePatternSplit
+}
+
+func (t SimplePatternSplitTokenizer) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(t.Type()), simplePatternSplitTokenizer(t))
+}
+
+func (t *SimplePatternSplitTokenizer) UnmarshalJSON(data []byte) error {
+	var v simplePatternSplitTokenizer
+	err := unmarshalAnalysisComponent(data, &v)
+	*t = SimplePatternSplitTokenizer(v)
+	return err
+}
+
+//easyjson:json
+type simplePatternSplitTokenizer SimplePatternSplitTokenizer
+
+// CharGroupTokenizer breaks text into terms whenever it encounters a character
+// which is in a defined set.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-chargroup-tokenizer.html
+type CharGroupTokenizer struct {
+	// A list of characters to tokenize the string on. Either single characters,
+	// such as "-", or character groups, such as "whitespace", "letter", "digit",
+	// "punctuation" and "symbol".
+	TokenizeOnChars []string `json:"tokenize_on_chars,omitempty"`
+	// The maximum token length. Tokens which exceed it are split. Defaults to 255.
+	MaxTokenLength int `json:"max_token_length,omitempty"`
+}
+
+func (CharGroupTokenizer) Type() TokenizerType {
+	return TokenizerTypeCharGroup
+}
+
+func (t CharGroupTokenizer) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(t.Type()), charGroupTokenizer(t))
+}
+
+func (t *CharGroupTokenizer) UnmarshalJSON(data []byte) error {
+	var v charGroupTokenizer
+	err := unmarshalAnalysisComponent(data, &v)
+	*t = CharGroupTokenizer(v)
+	return err
+}
+
+//easyjson:json
+type charGroupTokenizer CharGroupTokenizer
+
+// PathHierarchyTokenizer takes a hierarchical value like a filesystem path,
+// splits on the path separator, and emits a term for each component in the
+// tree.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/analysis-pathhierarchy-tokenizer.html
+type PathHierarchyTokenizer struct {
+	// The character to use as the path separator. Defaults to /.
+	Delimiter string `json:"delimiter,omitempty"`
+	// An optional replacement character to use for the delimiter. Defaults to
+	// the delimiter.
+	Replacement string `json:"replacement,omitempty"`
+	// The number of characters read into the term buffer in a single pass.
+	// Defaults to 1024.
+	BufferSize int `json:"buffer_size,omitempty"`
+	// If set to true, emits the tokens in reverse order. Defaults to false.
+	Reverse *bool `json:"reverse,omitempty"`
+	// The number of initial tokens to skip. Defaults to 0.
+	Skip int `json:"skip,omitempty"`
+}
+
+func (PathHierarchyTokenizer) Type() TokenizerType {
+	return TokenizerTypePathHierarchy
+}
+
+func (t PathHierarchyTokenizer) MarshalJSON() ([]byte, error) {
+	return marshalAnalysisComponent(string(t.Type()), pathHierarchyTokenizer(t))
+}
+
+func (t *PathHierarchyTokenizer) UnmarshalJSON(data []byte) error {
+	var v pathHierarchyTokenizer
+	err := unmarshalAnalysisComponent(data, &v)
+	*t = PathHierarchyTokenizer(v)
+	return err
+}
+
+//easyjson:json
+type pathHierarchyTokenizer PathHierarchyTokenizer
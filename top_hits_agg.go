@@ -0,0 +1,310 @@
+package picker
+
+const DefaultTopHitsSize = 3
+
+// TopHitsAggParams creates a TopHitsAgg, a top_hits metric aggregator that
+// keeps track of the most relevant document being aggregated. This aggregator
+// is intended to be used as a sub aggregator, so that the top matching
+// documents can be aggregated per bucket.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-top-hits-aggregation.html
+type TopHitsAggParams struct {
+	// The offset from the first result you want to fetch.
+	From int
+	// The maximum number of top matching hits to return per bucket. Defaults
+	// to 3.
+	Size int
+	// How the top matching hits should be sorted. Defaults to the score of
+	// the main query.
+	Sort Sort
+	// Indicates which source fields are returned for the matching hits. See
+	// SearchSource.SetValue for accepted values.
+	Source interface{}
+	// Highlights the matching hits
+	Highlight *Highlight
+	// If true, returns detailed information about score computation as part
+	// of a hit.
+	Explain bool
+	// Doc value fields to return for each hit
+	DocValueFields SearchFields
+	// Fields to return for each hit
+	Fields SearchFields
+	// Stored fields to return for each hit
+	StoredFields []string
+	// If true, returns the document version as part of a hit.
+	Version bool
+	// If true, returns the sequence number and primary term of the last
+	// modification of each hit.
+	SeqNoPrimaryTerm bool
+	// If true, calculates and returns document scores, even if the scores are
+	// not used for sorting.
+	TrackScores bool
+	Meta        map[string]interface{}
+}
+
+func (TopHitsAggParams) Kind() AggKind {
+	return AggKindTopHits
+}
+
+func (p TopHitsAggParams) Clause() (AggClause, error) {
+	return p.TopHits()
+}
+
+func (p TopHitsAggParams) TopHits() (*TopHitsAgg, error) {
+	a := &TopHitsAgg{}
+	a.SetFrom(p.From)
+	a.SetSize(p.Size)
+	a.SetSort(p.Sort)
+	err := a.SetSource(p.Source)
+	if err != nil {
+		return a, newAggError(err, AggKindTopHits)
+	}
+	a.SetHighlight(p.Highlight)
+	a.SetExplain(p.Explain)
+	a.SetDocValueFields(p.DocValueFields)
+	a.SetFields(p.Fields)
+	a.SetStoredFields(p.StoredFields)
+	a.SetVersion(p.Version)
+	a.SetSeqNoPrimaryTerm(p.SeqNoPrimaryTerm)
+	a.SetTrackScores(p.TrackScores)
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// TopHitsAgg is a metric aggregator that keeps track of the most relevant
+// document being aggregated.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-top-hits-aggregation.html
+type TopHitsAgg struct {
+	from             int
+	size             int
+	sort             Sort
+	source           *SearchSource
+	highlight        *Highlight
+	explain          bool
+	docValueFields   SearchFields
+	fields           SearchFields
+	storedFields     []string
+	version          bool
+	seqNoPrimaryTerm bool
+	trackScores      bool
+	noAggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*TopHitsAgg)(nil)
+
+func (TopHitsAgg) Kind() AggKind {
+	return AggKindTopHits
+}
+
+func (a *TopHitsAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// From is the offset from the first result you want to fetch.
+func (a TopHitsAgg) From() int {
+	return a.from
+}
+
+// SetFrom sets from to v
+func (a *TopHitsAgg) SetFrom(v int) {
+	a.from = v
+}
+
+// Size is the maximum number of top matching hits to return per bucket.
+// Defaults to 3.
+func (a TopHitsAgg) Size() int {
+	if a.size == 0 {
+		return DefaultTopHitsSize
+	}
+	return a.size
+}
+
+// SetSize sets size to v
+func (a *TopHitsAgg) SetSize(v int) {
+	a.size = v
+}
+
+// Sort determines how the top matching hits are sorted
+func (a TopHitsAgg) Sort() Sort {
+	return a.sort
+}
+
+// SetSort sets sort to v
+func (a *TopHitsAgg) SetSort(v Sort) {
+	a.sort = v
+}
+
+// Source indicates which source fields are returned for the matching hits
+func (a TopHitsAgg) Source() *SearchSource {
+	return a.source
+}
+
+// SetSource sets _source to v. See SearchSource.SetValue for accepted values.
+func (a *TopHitsAgg) SetSource(v interface{}) error {
+	if v == nil {
+		a.source = nil
+		return nil
+	}
+	s := &SearchSource{}
+	err := s.SetValue(v)
+	if err != nil {
+		return err
+	}
+	if s.IsEmpty() {
+		a.source = nil
+		return nil
+	}
+	a.source = s
+	return nil
+}
+
+// Highlight of the matching hits
+func (a TopHitsAgg) Highlight() *Highlight {
+	return a.highlight
+}
+
+// SetHighlight sets highlight to v
+func (a *TopHitsAgg) SetHighlight(v *Highlight) {
+	a.highlight = v
+}
+
+// Explain indicates whether detailed information about score computation is
+// returned as part of a hit.
+func (a TopHitsAgg) Explain() bool {
+	return a.explain
+}
+
+// SetExplain sets explain to v
+func (a *TopHitsAgg) SetExplain(v bool) {
+	a.explain = v
+}
+
+// DocValueFields are the doc value fields returned for each hit
+func (a TopHitsAgg) DocValueFields() SearchFields {
+	return a.docValueFields
+}
+
+// SetDocValueFields sets docvalue_fields to v
+func (a *TopHitsAgg) SetDocValueFields(v SearchFields) {
+	a.docValueFields = v
+}
+
+// Fields are the fields returned for each hit
+func (a TopHitsAgg) Fields() SearchFields {
+	return a.fields
+}
+
+// SetFields sets fields to v
+func (a *TopHitsAgg) SetFields(v SearchFields) {
+	a.fields = v
+}
+
+// StoredFields are the stored fields returned for each hit
+func (a TopHitsAgg) StoredFields() []string {
+	return a.storedFields
+}
+
+// SetStoredFields sets stored_fields to v
+func (a *TopHitsAgg) SetStoredFields(v []string) {
+	a.storedFields = v
+}
+
+// Version indicates whether the document version is returned as part of a
+// hit.
+func (a TopHitsAgg) Version() bool {
+	return a.version
+}
+
+// SetVersion sets version to v
+func (a *TopHitsAgg) SetVersion(v bool) {
+	a.version = v
+}
+
+// SeqNoPrimaryTerm indicates whether the sequence number and primary term of
+// the last modification of each hit is returned.
+func (a TopHitsAgg) SeqNoPrimaryTerm() bool {
+	return a.seqNoPrimaryTerm
+}
+
+// SetSeqNoPrimaryTerm sets seq_no_primary_term to v
+func (a *TopHitsAgg) SetSeqNoPrimaryTerm(v bool) {
+	a.seqNoPrimaryTerm = v
+}
+
+// TrackScores indicates whether scores are calculated and returned even if
+// they are not used for sorting.
+func (a TopHitsAgg) TrackScores() bool {
+	return a.trackScores
+}
+
+// SetTrackScores sets track_scores to v
+func (a *TopHitsAgg) SetTrackScores(v bool) {
+	a.trackScores = v
+}
+
+func (a TopHitsAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a TopHitsAgg) MarshalJSON() ([]byte, error) {
+	return topHitsAgg{
+		From:             a.from,
+		Size:             a.size,
+		Sort:             a.sort,
+		Source:           a.source,
+		Highlight:        a.highlight,
+		Explain:          a.explain,
+		DocValueFields:   a.docValueFields,
+		Fields:           a.fields,
+		StoredFields:     a.storedFields,
+		Version:          a.version,
+		SeqNoPrimaryTerm: a.seqNoPrimaryTerm,
+		TrackScores:      a.trackScores,
+	}.MarshalJSON()
+}
+
+func (a *TopHitsAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *TopHitsAgg) UnmarshalJSON(data []byte) error {
+	*a = TopHitsAgg{}
+	p := topHitsAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.SetFrom(p.From)
+	a.SetSize(p.Size)
+	a.SetSort(p.Sort)
+	if !p.Source.IsEmpty() {
+		a.source = p.Source
+	}
+	a.SetHighlight(p.Highlight)
+	a.SetExplain(p.Explain)
+	a.SetDocValueFields(p.DocValueFields)
+	a.SetFields(p.Fields)
+	a.SetStoredFields(p.StoredFields)
+	a.SetVersion(p.Version)
+	a.SetSeqNoPrimaryTerm(p.SeqNoPrimaryTerm)
+	a.SetTrackScores(p.TrackScores)
+	return nil
+}
+
+//easyjson:json
+type topHitsAgg struct {
+	From             int           `json:"from,omitempty"`
+	Size             int           `json:"size,omitempty"`
+	Sort             Sort          `json:"sort,omitempty"`
+	Source           *SearchSource `json:"_source,omitempty"`
+	Highlight        *Highlight    `json:"highlight,omitempty"`
+	Explain          bool          `json:"explain,omitempty"`
+	DocValueFields   SearchFields  `json:"docvalue_fields,omitempty"`
+	Fields           SearchFields  `json:"fields,omitempty"`
+	StoredFields     []string      `json:"stored_fields,omitempty"`
+	Version          bool          `json:"version,omitempty"`
+	SeqNoPrimaryTerm bool          `json:"seq_no_primary_term,omitempty"`
+	TrackScores      bool          `json:"track_scores,omitempty"`
+}
@@ -0,0 +1,85 @@
+package picker_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/chanced/cmpjson"
+	"github.com/chanced/picker"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopHitsAgg(t *testing.T) {
+	assert := require.New(t)
+	data := []byte(`{
+		"aggs": {
+			"top_tags": {
+				"terms": { "field": "type", "size": 3 },
+				"aggs": {
+					"top_sales_hits": {
+						"top_hits": {
+							"sort": [{ "date": { "order": "desc" } }],
+							"_source": { "includes": ["date", "price"] },
+							"size": 1,
+							"highlight": {
+								"pre_tags": ["<b>"],
+								"post_tags": ["</b>"],
+								"fields": { "title": { "number_of_fragments": 0 } }
+							},
+							"docvalue_fields": ["date", { "field": "price", "format": "0.00" }]
+						}
+					}
+				}
+			}
+		}
+	}`)
+	var s picker.Search
+	err := json.Unmarshal(data, &s)
+	assert.NoError(err)
+	terms := s.Aggregations().Get("top_tags").(*picker.TermsAgg)
+	th := terms.Aggregations().Get("top_sales_hits").(*picker.TopHitsAgg)
+	assert.Equal(1, th.Size())
+	assert.Len(th.Sort(), 1)
+	assert.Equal("date", th.Sort()[0].Field)
+	assert.NotNil(th.Source())
+	assert.Equal([]string{"<b>"}, th.Highlight().PreTags)
+	assert.Equal(0, *th.Highlight().Fields.Get("title").NumberOfFragments)
+	assert.Equal("0.00", th.DocValueFields()[1].Format)
+	sd, err := json.Marshal(s)
+	assert.NoError(err)
+	assert.True(cmpjson.Equal(data, sd), cmpjson.Diff(data, sd))
+
+	zero := 0
+	s2, err := picker.NewSearch(picker.SearchParams{
+		Aggregations: picker.Aggs{
+			"top_tags": picker.TermsAggParams{
+				Field: "type",
+				Size:  3,
+				Aggregations: picker.Aggs{
+					"top_sales_hits": picker.TopHitsAggParams{
+						Sort:   picker.Sort{{Field: "date", Order: picker.SortOrderDescending}},
+						Source: picker.SourceSpecifications{Includes: []string{"date", "price"}},
+						Size:   1,
+						Highlight: &picker.Highlight{
+							HighlightOptions: picker.HighlightOptions{
+								PreTags:  []string{"<b>"},
+								PostTags: []string{"</b>"},
+							},
+							Fields: picker.HighlightFields{
+								{Field: "title", Options: picker.HighlightOptions{NumberOfFragments: &zero}},
+							},
+						},
+						DocValueFields: picker.SearchFields{
+							{Field: "date"},
+							{Field: "price", Format: "0.00"},
+						},
+					},
+				},
+			},
+		},
+	})
+	assert.NoError(err)
+	sd2, err := json.Marshal(s2)
+	assert.NoError(err)
+	assert.True(cmpjson.Equal(data, sd2), cmpjson.Diff(data, sd2))
+}
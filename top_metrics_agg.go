@@ -0,0 +1,186 @@
+package picker
+
+import (
+	"encoding/json"
+
+	"github.com/chanced/dynamic"
+)
+
+const DefaultTopMetricsSize = 1
+
+// TopMetricsAggParams creates a TopMetricsAgg, an aggregation that selects
+// metrics from the document with the largest or smallest "sort" value.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-top-metrics.html
+type TopMetricsAggParams struct {
+	// The fields to collect metrics from (Required)
+	Metrics []string
+	// How documents are sorted to determine the top (Required)
+	Sort Sort
+	// The number of top documents to return metrics for. Defaults to 1.
+	Size int
+	Meta map[string]interface{}
+}
+
+func (TopMetricsAggParams) Kind() AggKind {
+	return AggKindTopMetrics
+}
+
+func (p TopMetricsAggParams) Clause() (AggClause, error) {
+	return p.TopMetrics()
+}
+
+func (p TopMetricsAggParams) TopMetrics() (*TopMetricsAgg, error) {
+	a := &TopMetricsAgg{}
+	err := a.SetMetrics(p.Metrics)
+	if err != nil {
+		return a, newAggError(err, AggKindTopMetrics)
+	}
+	err = a.SetSort(p.Sort)
+	if err != nil {
+		return a, newAggError(err, AggKindTopMetrics)
+	}
+	a.SetSize(p.Size)
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// TopMetricsAgg is an aggregation that selects metrics from the document with
+// the largest or smallest "sort" value.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-top-metrics.html
+type TopMetricsAgg struct {
+	metrics []string
+	sort    Sort
+	size    int
+	noAggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*TopMetricsAgg)(nil)
+
+func (TopMetricsAgg) Kind() AggKind {
+	return AggKindTopMetrics
+}
+
+func (a *TopMetricsAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// Metrics are the fields metrics are collected from
+func (a TopMetricsAgg) Metrics() []string {
+	return a.metrics
+}
+
+// SetMetrics sets the metric fields to v. At least one field is required.
+func (a *TopMetricsAgg) SetMetrics(v []string) error {
+	if len(v) == 0 {
+		return ErrMetricsRequired
+	}
+	for _, f := range v {
+		if len(f) == 0 {
+			return ErrFieldRequired
+		}
+	}
+	a.metrics = v
+	return nil
+}
+
+// Sort determines how documents are sorted to determine the top
+func (a TopMetricsAgg) Sort() Sort {
+	return a.sort
+}
+
+// SetSort sets sort to v
+func (a *TopMetricsAgg) SetSort(v Sort) error {
+	if len(v) == 0 {
+		return ErrSortRequired
+	}
+	a.sort = v
+	return nil
+}
+
+// Size is the number of top documents to return metrics for. Defaults to 1.
+func (a TopMetricsAgg) Size() int {
+	if a.size == 0 {
+		return DefaultTopMetricsSize
+	}
+	return a.size
+}
+
+// SetSize sets size to v
+func (a *TopMetricsAgg) SetSize(v int) {
+	a.size = v
+}
+
+func (a TopMetricsAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a TopMetricsAgg) MarshalJSON() ([]byte, error) {
+	var metrics dynamic.JSON
+	var err error
+	if len(a.metrics) == 1 {
+		metrics, err = topMetricsField{Field: a.metrics[0]}.MarshalJSON()
+	} else {
+		fields := make([]topMetricsField, len(a.metrics))
+		for i, f := range a.metrics {
+			fields[i] = topMetricsField{Field: f}
+		}
+		metrics, err = json.Marshal(fields)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return topMetricsAgg{
+		Metrics: metrics,
+		Sort:    a.sort,
+		Size:    a.size,
+	}.MarshalJSON()
+}
+
+func (a *TopMetricsAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *TopMetricsAgg) UnmarshalJSON(data []byte) error {
+	*a = TopMetricsAgg{}
+	p := topMetricsAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	if p.Metrics.IsArray() {
+		var fields []topMetricsField
+		err = json.Unmarshal(p.Metrics, &fields)
+		if err != nil {
+			return err
+		}
+		a.metrics = make([]string, len(fields))
+		for i, f := range fields {
+			a.metrics[i] = f.Field
+		}
+	} else if p.Metrics.IsObject() {
+		var f topMetricsField
+		err = f.UnmarshalJSON(p.Metrics)
+		if err != nil {
+			return err
+		}
+		a.metrics = []string{f.Field}
+	}
+	a.sort = p.Sort
+	a.SetSize(p.Size)
+	return nil
+}
+
+//easyjson:json
+type topMetricsField struct {
+	Field string `json:"field"`
+}
+
+//easyjson:json
+type topMetricsAgg struct {
+	Metrics dynamic.JSON `json:"metrics"`
+	Sort    Sort         `json:"sort"`
+	Size    int          `json:"size,omitempty"`
+}
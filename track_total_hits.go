@@ -0,0 +1,141 @@
+package picker
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/chanced/dynamic"
+)
+
+// TrackTotalHits controls how the total number of hits matching the query is
+// tracked. It is either a bool or an integer threshold up to which hits are
+// counted accurately. Defaults to 10,000.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-your-data.html#track-total-hits
+type TrackTotalHits struct {
+	boolean   *bool
+	threshold *int
+}
+
+// SetValue sets the value of TrackTotalHits
+//
+// The options are:
+//
+//	bool
+//	*bool
+//	int
+//	*int
+//	int64
+//	TrackTotalHits
+//	*TrackTotalHits
+//	nil
+//
+// A threshold of -1 disables tracking. SetValue returns an error if v is not
+// one of the types listed above or is a threshold less than -1.
+func (t *TrackTotalHits) SetValue(v interface{}) error {
+	switch tv := v.(type) {
+	case nil:
+		*t = TrackTotalHits{}
+	case bool:
+		*t = TrackTotalHits{boolean: &tv}
+	case *bool:
+		if tv == nil {
+			*t = TrackTotalHits{}
+			return nil
+		}
+		return t.SetValue(*tv)
+	case int:
+		if tv < -1 {
+			return fmt.Errorf("%w; threshold must be greater than or equal to -1, received <%d>", ErrInvalidTrackTotalHits, tv)
+		}
+		*t = TrackTotalHits{threshold: &tv}
+	case *int:
+		if tv == nil {
+			*t = TrackTotalHits{}
+			return nil
+		}
+		return t.SetValue(*tv)
+	case int64:
+		return t.SetValue(int(tv))
+	case TrackTotalHits:
+		*t = tv
+	case *TrackTotalHits:
+		if tv == nil {
+			*t = TrackTotalHits{}
+			return nil
+		}
+		*t = *tv
+	default:
+		return fmt.Errorf("%w; expected a bool or an int, received <%T>", ErrInvalidTrackTotalHits, v)
+	}
+	return nil
+}
+
+// Value returns either a bool, an int, or nil if no value has been set
+func (t TrackTotalHits) Value() interface{} {
+	if t.boolean != nil {
+		return *t.boolean
+	}
+	if t.threshold != nil {
+		return *t.threshold
+	}
+	return nil
+}
+
+// Bool returns the value and true if t is a bool
+func (t TrackTotalHits) Bool() (bool, bool) {
+	if t.boolean == nil {
+		return false, false
+	}
+	return *t.boolean, true
+}
+
+// Threshold returns the value and true if t is an integer threshold
+func (t TrackTotalHits) Threshold() (int, bool) {
+	if t.threshold == nil {
+		return 0, false
+	}
+	return *t.threshold, true
+}
+
+// IsEmpty returns true if no value has been set on t
+func (t *TrackTotalHits) IsEmpty() bool {
+	return t == nil || (t.boolean == nil && t.threshold == nil)
+}
+
+func (t TrackTotalHits) MarshalBSON() ([]byte, error) {
+	return t.MarshalJSON()
+}
+
+func (t TrackTotalHits) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Value())
+}
+
+func (t *TrackTotalHits) UnmarshalBSON(data []byte) error {
+	return t.UnmarshalJSON(data)
+}
+
+func (t *TrackTotalHits) UnmarshalJSON(data []byte) error {
+	*t = TrackTotalHits{}
+	d := dynamic.JSON(data)
+	if len(d) == 0 || d.IsNull() {
+		return nil
+	}
+	if d.IsBool() || d.IsString() {
+		b, err := dynamic.NewBool(d.UnquotedString())
+		if err != nil {
+			return err
+		}
+		if v, ok := b.Bool(); ok {
+			return t.SetValue(v)
+		}
+	}
+	n, err := dynamic.NewNumber(d.UnquotedString())
+	if err != nil {
+		return err
+	}
+	if i, ok := n.Int(); ok {
+		return t.SetValue(i)
+	}
+	return fmt.Errorf("%w <%s>", ErrInvalidTrackTotalHits, d)
+}
@@ -0,0 +1,127 @@
+package picker
+
+// TrimProcessorParams creates a TrimProcessor, which trims whitespace from a
+// field. If the field is an array of strings, all members of the array will be
+// trimmed.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/trim-processor.html
+type TrimProcessorParams struct {
+	// Field is the string-valued field to trim whitespace from
+	Field string
+	// TargetField is the field to assign the output to. By default, field is
+	// updated in-place.
+	TargetField string
+	// If IgnoreMissing is true and field does not exist or is null, the
+	// processor quietly exits without modifying the document
+	IgnoreMissing bool
+	// Description of the processor. Useful for describing the purpose of the
+	// processor or its configuration.
+	Description string
+	// Conditionally execute the processor
+	If string
+	// Ignore failures for the processor
+	IgnoreFailure bool
+	// Handle failures for the processor
+	OnFailure Procs
+	// Identifier for the processor. Useful for debugging and metrics.
+	Tag string
+}
+
+func (TrimProcessorParams) Kind() ProcessorKind {
+	return ProcessorKindTrim
+}
+
+func (p TrimProcessorParams) Processor() (Processor, error) {
+	return p.Trim()
+}
+
+func (p TrimProcessorParams) Trim() (*TrimProcessor, error) {
+	proc := &TrimProcessor{}
+	err := proc.SetField(p.Field)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindTrim)
+	}
+	proc.SetTargetField(p.TargetField)
+	proc.SetIgnoreMissing(p.IgnoreMissing)
+	proc.SetDescription(p.Description)
+	proc.SetIf(p.If)
+	proc.SetIgnoreFailure(p.IgnoreFailure)
+	err = proc.SetOnFailure(p.OnFailure)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindTrim)
+	}
+	proc.SetTag(p.Tag)
+	return proc, nil
+}
+
+// TrimProcessor trims whitespace from a field. If the field is an array of
+// strings, all members of the array will be trimmed.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/trim-processor.html
+type TrimProcessor struct {
+	fieldParam
+	targetFieldParam
+	ignoreMissingParam
+	processorParams
+}
+
+var _ Processor = (*TrimProcessor)(nil)
+
+func (TrimProcessor) Kind() ProcessorKind {
+	return ProcessorKindTrim
+}
+
+func (p *TrimProcessor) Processor() (Processor, error) {
+	return p, nil
+}
+
+func (p TrimProcessor) MarshalBSON() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+func (p TrimProcessor) MarshalJSON() ([]byte, error) {
+	return trimProcessor{
+		Field:         p.field,
+		TargetField:   p.targetField,
+		IgnoreMissing: p.ignoreMissing,
+		Description:   p.description,
+		If:            p.ifCond,
+		IgnoreFailure: p.ignoreFailure,
+		OnFailure:     p.onFailure,
+		Tag:           p.tag,
+	}.MarshalJSON()
+}
+
+func (p *TrimProcessor) UnmarshalBSON(data []byte) error {
+	return p.UnmarshalJSON(data)
+}
+
+func (p *TrimProcessor) UnmarshalJSON(data []byte) error {
+	*p = TrimProcessor{}
+	var v trimProcessor
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	p.field = v.Field
+	p.targetField = v.TargetField
+	p.ignoreMissing = v.IgnoreMissing
+	p.description = v.Description
+	p.ifCond = v.If
+	p.ignoreFailure = v.IgnoreFailure
+	p.onFailure = v.OnFailure
+	p.tag = v.Tag
+	return nil
+}
+
+//easyjson:json
+type trimProcessor struct {
+	Field         string     `json:"field,omitempty"`
+	TargetField   string     `json:"target_field,omitempty"`
+	IgnoreMissing bool       `json:"ignore_missing,omitempty"`
+	Description   string     `json:"description,omitempty"`
+	If            string     `json:"if,omitempty"`
+	IgnoreFailure bool       `json:"ignore_failure,omitempty"`
+	OnFailure     Processors `json:"on_failure,omitempty"`
+	Tag           string     `json:"tag,omitempty"`
+}
@@ -126,27 +126,67 @@ func NewUpdateByQuery(params UpdateByQueryParams) (*UpdateByQuery, error) {
 	return params.UpdateByQuery()
 }
 
+// UpdateByQueryResponse is the response of a update by query request
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/docs-update-by-query.html#docs-update-by-query-api-response-body
+//
+//easyjson:json
 type UpdateByQueryResponse struct {
-	Took                 int64         `json:"took"`
-	TimedOut             bool          `json:"timed_out"`
-	Total                int64         `json:"total"`
-	Updated              int64         `json:"updated"`
-	Deleted              int64         `json:"deleted"`
-	Batches              int64         `json:"batches"`
-	VersionConflicts     int64         `json:"version_conflicts"`
-	Noops                int64         `json:"noops"`
-	Retries              Retries       `json:"retries"`
-	ThrottledMillis      int64         `json:"throttled_millis"`
-	RequestsPerSecond    float64       `json:"requests_per_second"`
-	Failures             []interface{} `json:"failures"`
-	ThrottledUntilMillis int64         `json:"throttled_until_millis"`
-}
-
+	// Milliseconds from start to end of the whole operation
+	Took int64 `json:"took"`
+	// If true, a request executed during the operation timed out
+	TimedOut bool `json:"timed_out"`
+	// The number of documents that were successfully processed
+	Total int64 `json:"total"`
+	// The number of documents that were successfully updated
+	Updated int64 `json:"updated"`
+	// The number of documents that were successfully deleted
+	Deleted int64 `json:"deleted"`
+	// The number of scroll responses pulled back by the operation
+	Batches int64 `json:"batches"`
+	// The number of version conflicts that the operation hit
+	VersionConflicts int64 `json:"version_conflicts"`
+	// The number of documents that were ignored
+	Noops int64 `json:"noops"`
+	// The number of retries attempted by the operation
+	Retries Retries `json:"retries"`
+	// Milliseconds the request slept to conform to requests_per_second
+	ThrottledMillis int64 `json:"throttled_millis"`
+	// The number of requests per second effectively executed during the
+	// operation; -1 if unthrottled
+	RequestsPerSecond float64 `json:"requests_per_second"`
+	// Failures of the operation. Processing stops on the first unrecoverable
+	// failure.
+	Failures []ByQueryFailure `json:"failures"`
+	// Milliseconds until the next throttled request is executed
+	ThrottledUntilMillis int64 `json:"throttled_until_millis"`
+}
+
+// Retries are the number of retries attempted by a by query operation
+//
+//easyjson:json
 type Retries struct {
-	Bulk   int64 `json:"bulk"`
+	// The number of bulk actions retried
+	Bulk int64 `json:"bulk"`
+	// The number of search actions retried
 	Search int64 `json:"search"`
 }
 
+// ByQueryFailure is a failure of a delete or update by query operation. It
+// is either the failure of a bulk action, with Index, ID, Cause and Status,
+// or of a search, with Shard, Index, Node and Reason.
+//
+//easyjson:json
+type ByQueryFailure struct {
+	Index  string      `json:"index,omitempty"`
+	ID     string      `json:"id,omitempty"`
+	Cause  *ErrorCause `json:"cause,omitempty"`
+	Status int         `json:"status,omitempty"`
+	Shard  *int        `json:"shard,omitempty"`
+	Node   string      `json:"node,omitempty"`
+	Reason *ErrorCause `json:"reason,omitempty"`
+}
+
 func DecodeUpdateByQueryResponse(body io.Reader) (*UpdateByQueryResponse, error) {
 	dec := json.NewDecoder(body)
 	var res UpdateByQueryResponse
@@ -0,0 +1,174 @@
+package picker
+
+import "github.com/chanced/dynamic"
+
+// URIPartsProcessorParams creates a URIPartsProcessor, which parses a Uniform
+// Resource Identifier (URI) string and extracts its components as an object.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/uri-parts-processor.html
+type URIPartsProcessorParams struct {
+	// Field is the field containing the URI string
+	Field string
+	// TargetField is the output field for the URI object. Defaults to "url".
+	TargetField string
+	// KeepOriginal indicates whether the original URI is copied to
+	// <target_field>.original. Defaults to true.
+	KeepOriginal interface{}
+	// RemoveIfSuccessful indicates whether field is removed after parsing the
+	// URI string. If parsing fails, the field is not removed.
+	RemoveIfSuccessful bool
+	// If IgnoreMissing is true and field does not exist or is null, the
+	// processor quietly exits without modifying the document
+	IgnoreMissing bool
+	// Description of the processor. Useful for describing the purpose of the
+	// processor or its configuration.
+	Description string
+	// Conditionally execute the processor
+	If string
+	// Ignore failures for the processor
+	IgnoreFailure bool
+	// Handle failures for the processor
+	OnFailure Procs
+	// Identifier for the processor. Useful for debugging and metrics.
+	Tag string
+}
+
+func (URIPartsProcessorParams) Kind() ProcessorKind {
+	return ProcessorKindURIParts
+}
+
+func (p URIPartsProcessorParams) Processor() (Processor, error) {
+	return p.URIParts()
+}
+
+func (p URIPartsProcessorParams) URIParts() (*URIPartsProcessor, error) {
+	proc := &URIPartsProcessor{}
+	err := proc.SetField(p.Field)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindURIParts)
+	}
+	proc.SetTargetField(p.TargetField)
+	err = proc.SetKeepOriginal(p.KeepOriginal)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindURIParts)
+	}
+	proc.SetRemoveIfSuccessful(p.RemoveIfSuccessful)
+	proc.SetIgnoreMissing(p.IgnoreMissing)
+	proc.SetDescription(p.Description)
+	proc.SetIf(p.If)
+	proc.SetIgnoreFailure(p.IgnoreFailure)
+	err = proc.SetOnFailure(p.OnFailure)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindURIParts)
+	}
+	proc.SetTag(p.Tag)
+	return proc, nil
+}
+
+// URIPartsProcessor parses a Uniform Resource Identifier (URI) string and
+// extracts its components as an object.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/uri-parts-processor.html
+type URIPartsProcessor struct {
+	fieldParam
+	targetFieldParam
+	keepOriginal       dynamic.Bool
+	removeIfSuccessful bool
+	ignoreMissingParam
+	processorParams
+}
+
+var _ Processor = (*URIPartsProcessor)(nil)
+
+func (URIPartsProcessor) Kind() ProcessorKind {
+	return ProcessorKindURIParts
+}
+
+func (p *URIPartsProcessor) Processor() (Processor, error) {
+	return p, nil
+}
+
+// KeepOriginal indicates whether the original URI is copied to
+// <target_field>.original. Defaults to true.
+func (p URIPartsProcessor) KeepOriginal() bool {
+	if v, ok := p.keepOriginal.Bool(); ok {
+		return v
+	}
+	return true
+}
+
+// SetKeepOriginal sets keep_original to v
+func (p *URIPartsProcessor) SetKeepOriginal(v interface{}) error {
+	return p.keepOriginal.Set(v)
+}
+
+// RemoveIfSuccessful indicates whether field is removed after parsing the URI
+// string. If parsing fails, the field is not removed.
+func (p URIPartsProcessor) RemoveIfSuccessful() bool {
+	return p.removeIfSuccessful
+}
+
+// SetRemoveIfSuccessful sets remove_if_successful to v
+func (p *URIPartsProcessor) SetRemoveIfSuccessful(v bool) {
+	p.removeIfSuccessful = v
+}
+
+func (p URIPartsProcessor) MarshalBSON() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+func (p URIPartsProcessor) MarshalJSON() ([]byte, error) {
+	return uriPartsProcessor{
+		Field:              p.field,
+		TargetField:        p.targetField,
+		KeepOriginal:       p.keepOriginal.Value(),
+		RemoveIfSuccessful: p.removeIfSuccessful,
+		IgnoreMissing:      p.ignoreMissing,
+		Description:        p.description,
+		If:                 p.ifCond,
+		IgnoreFailure:      p.ignoreFailure,
+		OnFailure:          p.onFailure,
+		Tag:                p.tag,
+	}.MarshalJSON()
+}
+
+func (p *URIPartsProcessor) UnmarshalBSON(data []byte) error {
+	return p.UnmarshalJSON(data)
+}
+
+func (p *URIPartsProcessor) UnmarshalJSON(data []byte) error {
+	*p = URIPartsProcessor{}
+	var v uriPartsProcessor
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	p.field = v.Field
+	p.targetField = v.TargetField
+	err = p.keepOriginal.Set(v.KeepOriginal)
+	if err != nil {
+		return err
+	}
+	p.removeIfSuccessful = v.RemoveIfSuccessful
+	p.ignoreMissing = v.IgnoreMissing
+	p.description = v.Description
+	p.ifCond = v.If
+	p.ignoreFailure = v.IgnoreFailure
+	p.onFailure = v.OnFailure
+	p.tag = v.Tag
+	return nil
+}
+
+//easyjson:json
+type uriPartsProcessor struct {
+	Field              string      `json:"field,omitempty"`
+	TargetField        string      `json:"target_field,omitempty"`
+	KeepOriginal       interface{} `json:"keep_original,omitempty"`
+	RemoveIfSuccessful bool        `json:"remove_if_successful,omitempty"`
+	IgnoreMissing      bool        `json:"ignore_missing,omitempty"`
+	Description        string      `json:"description,omitempty"`
+	If                 string      `json:"if,omitempty"`
+	IgnoreFailure      bool        `json:"ignore_failure,omitempty"`
+	OnFailure          Processors  `json:"on_failure,omitempty"`
+	Tag                string      `json:"tag,omitempty"`
+}
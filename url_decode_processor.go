@@ -0,0 +1,127 @@
+package picker
+
+// URLDecodeProcessorParams creates a URLDecodeProcessor, which URL-decodes a
+// string. If the field is an array of strings, all members of the array will be
+// decoded.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/urldecode-processor.html
+type URLDecodeProcessorParams struct {
+	// Field is the field to decode
+	Field string
+	// TargetField is the field to assign the output to. By default, field is
+	// updated in-place.
+	TargetField string
+	// If IgnoreMissing is true and field does not exist or is null, the
+	// processor quietly exits without modifying the document
+	IgnoreMissing bool
+	// Description of the processor. Useful for describing the purpose of the
+	// processor or its configuration.
+	Description string
+	// Conditionally execute the processor
+	If string
+	// Ignore failures for the processor
+	IgnoreFailure bool
+	// Handle failures for the processor
+	OnFailure Procs
+	// Identifier for the processor. Useful for debugging and metrics.
+	Tag string
+}
+
+func (URLDecodeProcessorParams) Kind() ProcessorKind {
+	return ProcessorKindURLDecode
+}
+
+func (p URLDecodeProcessorParams) Processor() (Processor, error) {
+	return p.URLDecode()
+}
+
+func (p URLDecodeProcessorParams) URLDecode() (*URLDecodeProcessor, error) {
+	proc := &URLDecodeProcessor{}
+	err := proc.SetField(p.Field)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindURLDecode)
+	}
+	proc.SetTargetField(p.TargetField)
+	proc.SetIgnoreMissing(p.IgnoreMissing)
+	proc.SetDescription(p.Description)
+	proc.SetIf(p.If)
+	proc.SetIgnoreFailure(p.IgnoreFailure)
+	err = proc.SetOnFailure(p.OnFailure)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindURLDecode)
+	}
+	proc.SetTag(p.Tag)
+	return proc, nil
+}
+
+// URLDecodeProcessor URL-decodes a string. If the field is an array of strings,
+// all members of the array will be decoded.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/urldecode-processor.html
+type URLDecodeProcessor struct {
+	fieldParam
+	targetFieldParam
+	ignoreMissingParam
+	processorParams
+}
+
+var _ Processor = (*URLDecodeProcessor)(nil)
+
+func (URLDecodeProcessor) Kind() ProcessorKind {
+	return ProcessorKindURLDecode
+}
+
+func (p *URLDecodeProcessor) Processor() (Processor, error) {
+	return p, nil
+}
+
+func (p URLDecodeProcessor) MarshalBSON() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+func (p URLDecodeProcessor) MarshalJSON() ([]byte, error) {
+	return urlDecodeProcessor{
+		Field:         p.field,
+		TargetField:   p.targetField,
+		IgnoreMissing: p.ignoreMissing,
+		Description:   p.description,
+		If:            p.ifCond,
+		IgnoreFailure: p.ignoreFailure,
+		OnFailure:     p.onFailure,
+		Tag:           p.tag,
+	}.MarshalJSON()
+}
+
+func (p *URLDecodeProcessor) UnmarshalBSON(data []byte) error {
+	return p.UnmarshalJSON(data)
+}
+
+func (p *URLDecodeProcessor) UnmarshalJSON(data []byte) error {
+	*p = URLDecodeProcessor{}
+	var v urlDecodeProcessor
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	p.field = v.Field
+	p.targetField = v.TargetField
+	p.ignoreMissing = v.IgnoreMissing
+	p.description = v.Description
+	p.ifCond = v.If
+	p.ignoreFailure = v.IgnoreFailure
+	p.onFailure = v.OnFailure
+	p.tag = v.Tag
+	return nil
+}
+
+//easyjson:json
+type urlDecodeProcessor struct {
+	Field         string     `json:"field,omitempty"`
+	TargetField   string     `json:"target_field,omitempty"`
+	IgnoreMissing bool       `json:"ignore_missing,omitempty"`
+	Description   string     `json:"description,omitempty"`
+	If            string     `json:"if,omitempty"`
+	IgnoreFailure bool       `json:"ignore_failure,omitempty"`
+	OnFailure     Processors `json:"on_failure,omitempty"`
+	Tag           string     `json:"tag,omitempty"`
+}
@@ -0,0 +1,184 @@
+package picker
+
+// UserAgentProcessorParams creates a UserAgentProcessor, which extracts details
+// from the user agent string a browser sends with its web requests.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/user-agent-processor.html
+type UserAgentProcessorParams struct {
+	// Field is the field containing the user agent string
+	Field string
+	// TargetField is the field that will be filled with the user agent details.
+	// Defaults to "user_agent".
+	TargetField string
+	// RegexFile is the name of the file in the config/ingest-user-agent
+	// directory containing the regular expressions for parsing the user agent
+	// string
+	RegexFile string
+	// Properties controls which properties are added to target_field. Defaults
+	// to all properties.
+	Properties []string
+	// ExtractDeviceType indicates whether the device type is extracted from the
+	// user agent string on a best-effort basis
+	ExtractDeviceType bool
+	// If IgnoreMissing is true and field does not exist or is null, the
+	// processor quietly exits without modifying the document
+	IgnoreMissing bool
+	// Description of the processor. Useful for describing the purpose of the
+	// processor or its configuration.
+	Description string
+	// Conditionally execute the processor
+	If string
+	// Ignore failures for the processor
+	IgnoreFailure bool
+	// Handle failures for the processor
+	OnFailure Procs
+	// Identifier for the processor. Useful for debugging and metrics.
+	Tag string
+}
+
+func (UserAgentProcessorParams) Kind() ProcessorKind {
+	return ProcessorKindUserAgent
+}
+
+func (p UserAgentProcessorParams) Processor() (Processor, error) {
+	return p.UserAgent()
+}
+
+func (p UserAgentProcessorParams) UserAgent() (*UserAgentProcessor, error) {
+	proc := &UserAgentProcessor{}
+	err := proc.SetField(p.Field)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindUserAgent)
+	}
+	proc.SetTargetField(p.TargetField)
+	proc.SetRegexFile(p.RegexFile)
+	proc.SetProperties(p.Properties)
+	proc.SetExtractDeviceType(p.ExtractDeviceType)
+	proc.SetIgnoreMissing(p.IgnoreMissing)
+	proc.SetDescription(p.Description)
+	proc.SetIf(p.If)
+	proc.SetIgnoreFailure(p.IgnoreFailure)
+	err = proc.SetOnFailure(p.OnFailure)
+	if err != nil {
+		return proc, newProcessorError(err, ProcessorKindUserAgent)
+	}
+	proc.SetTag(p.Tag)
+	return proc, nil
+}
+
+// UserAgentProcessor extracts details from the user agent string a browser
+// sends with its web requests.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/user-agent-processor.html
+type UserAgentProcessor struct {
+	fieldParam
+	targetFieldParam
+	regexFile         string
+	properties        []string
+	extractDeviceType bool
+	ignoreMissingParam
+	processorParams
+}
+
+var _ Processor = (*UserAgentProcessor)(nil)
+
+func (UserAgentProcessor) Kind() ProcessorKind {
+	return ProcessorKindUserAgent
+}
+
+func (p *UserAgentProcessor) Processor() (Processor, error) {
+	return p, nil
+}
+
+// RegexFile is the name of the file in the config/ingest-user-agent directory
+// containing the regular expressions for parsing the user agent string
+func (p UserAgentProcessor) RegexFile() string {
+	return p.regexFile
+}
+
+// SetRegexFile sets regex_file to v
+func (p *UserAgentProcessor) SetRegexFile(v string) {
+	p.regexFile = v
+}
+
+// Properties controls which properties are added to target_field. Defaults to
+// all properties.
+func (p UserAgentProcessor) Properties() []string {
+	return p.properties
+}
+
+// SetProperties sets properties to v
+func (p *UserAgentProcessor) SetProperties(v []string) {
+	p.properties = v
+}
+
+// ExtractDeviceType indicates whether the device type is extracted from the
+// user agent string on a best-effort basis
+func (p UserAgentProcessor) ExtractDeviceType() bool {
+	return p.extractDeviceType
+}
+
+// SetExtractDeviceType sets extract_device_type to v
+func (p *UserAgentProcessor) SetExtractDeviceType(v bool) {
+	p.extractDeviceType = v
+}
+
+func (p UserAgentProcessor) MarshalBSON() ([]byte, error) {
+	return p.MarshalJSON()
+}
+
+func (p UserAgentProcessor) MarshalJSON() ([]byte, error) {
+	return userAgentProcessor{
+		Field:             p.field,
+		TargetField:       p.targetField,
+		RegexFile:         p.regexFile,
+		Properties:        p.properties,
+		ExtractDeviceType: p.extractDeviceType,
+		IgnoreMissing:     p.ignoreMissing,
+		Description:       p.description,
+		If:                p.ifCond,
+		IgnoreFailure:     p.ignoreFailure,
+		OnFailure:         p.onFailure,
+		Tag:               p.tag,
+	}.MarshalJSON()
+}
+
+func (p *UserAgentProcessor) UnmarshalBSON(data []byte) error {
+	return p.UnmarshalJSON(data)
+}
+
+func (p *UserAgentProcessor) UnmarshalJSON(data []byte) error {
+	*p = UserAgentProcessor{}
+	var v userAgentProcessor
+	err := v.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	p.field = v.Field
+	p.targetField = v.TargetField
+	p.regexFile = v.RegexFile
+	p.properties = v.Properties
+	p.extractDeviceType = v.ExtractDeviceType
+	p.ignoreMissing = v.IgnoreMissing
+	p.description = v.Description
+	p.ifCond = v.If
+	p.ignoreFailure = v.IgnoreFailure
+	p.onFailure = v.OnFailure
+	p.tag = v.Tag
+	return nil
+}
+
+//easyjson:json
+type userAgentProcessor struct {
+	Field             string     `json:"field,omitempty"`
+	TargetField       string     `json:"target_field,omitempty"`
+	RegexFile         string     `json:"regex_file,omitempty"`
+	Properties        []string   `json:"properties,omitempty"`
+	ExtractDeviceType bool       `json:"extract_device_type,omitempty"`
+	IgnoreMissing     bool       `json:"ignore_missing,omitempty"`
+	Description       string     `json:"description,omitempty"`
+	If                string     `json:"if,omitempty"`
+	IgnoreFailure     bool       `json:"ignore_failure,omitempty"`
+	OnFailure         Processors `json:"on_failure,omitempty"`
+	Tag               string     `json:"tag,omitempty"`
+}
@@ -1,6 +1,14 @@
 package picker
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/chanced/dynamic"
 )
 
@@ -15,3 +23,87 @@ func unmarshalField(data []byte) (string, dynamic.JSON, error) {
 	}
 	return "", nil, nil
 }
+
+// unmarshalOrderedObject decodes the JSON object data, returning its keys in
+// the order they appear
+func unmarshalOrderedObject(data []byte) ([]string, dynamic.JSONObject, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	t, err := dec.Token()
+	if err != nil {
+		return nil, nil, err
+	}
+	if d, ok := t.(json.Delim); !ok || d != '{' {
+		return nil, nil, &json.UnmarshalTypeError{Value: fmt.Sprint(t), Type: reflect.TypeOf(dynamic.JSONObject{})}
+	}
+	var keys []string
+	obj := dynamic.JSONObject{}
+	for dec.More() {
+		t, err = dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		key := t.(string)
+		var v dynamic.JSON
+		err = dec.Decode(&v)
+		if err != nil {
+			return nil, nil, err
+		}
+		if _, exists := obj[key]; !exists {
+			keys = append(keys, key)
+		}
+		obj[key] = v
+	}
+	return keys, obj, nil
+}
+
+var timeUnits = []struct {
+	unit string
+	d    time.Duration
+}{
+	{"d", 24 * time.Hour},
+	{"h", time.Hour},
+	{"m", time.Minute},
+	{"s", time.Second},
+	{"ms", time.Millisecond},
+	{"micros", time.Microsecond},
+	{"nanos", time.Nanosecond},
+}
+
+// formatTimeValue formats d as an Elasticsearch time value, using the largest
+// unit which d is a multiple of, e.g. "90s" or "2m"
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/api-conventions.html#time-units
+func formatTimeValue(d time.Duration) string {
+	for _, tu := range timeUnits {
+		if d%tu.d == 0 {
+			return strconv.FormatInt(int64(d/tu.d), 10) + tu.unit
+		}
+	}
+	return strconv.FormatInt(int64(d), 10) + "nanos"
+}
+
+// parseTimeValue parses an Elasticsearch time value, such as "30s" or "1d",
+// into a time.Duration. A value without a unit is treated as milliseconds.
+func parseTimeValue(v string) (time.Duration, error) {
+	v = strings.TrimSpace(v)
+	if len(v) == 0 {
+		return 0, nil
+	}
+	i := strings.IndexFunc(v, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.' && r != '-'
+	})
+	num, unit := v, "ms"
+	if i >= 0 {
+		num, unit = v[:i], v[i:]
+	}
+	f, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w <%s>", ErrInvalidTimeValue, v)
+	}
+	for _, tu := range timeUnits {
+		if tu.unit == unit {
+			return time.Duration(f * float64(tu.d)), nil
+		}
+	}
+	return 0, fmt.Errorf("%w <%s>", ErrInvalidTimeValue, v)
+}
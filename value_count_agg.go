@@ -0,0 +1,94 @@
+package picker
+
+// ValueCountAggParams creates a ValueCountAgg, a single-value metrics
+// aggregation that counts the number of values that are extracted from the
+// aggregated documents.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-valuecount-aggregation.html
+type ValueCountAggParams struct {
+	// The field to aggregate on. Either Field or Script is required.
+	Field string
+	// Script to generate values with. If Field is also set, Script is a value
+	// script.
+	Script *Script
+	// Hint as to the type of value produced by Script
+	ValueType string
+	Meta      map[string]interface{}
+}
+
+func (ValueCountAggParams) Kind() AggKind {
+	return AggKindValueCount
+}
+
+func (p ValueCountAggParams) Clause() (AggClause, error) {
+	return p.ValueCount()
+}
+
+func (p ValueCountAggParams) ValueCount() (*ValueCountAgg, error) {
+	a := &ValueCountAgg{}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	err := a.checkValueSource()
+	if err != nil {
+		return a, newAggError(err, AggKindValueCount)
+	}
+	a.SetValueType(p.ValueType)
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// ValueCountAgg is a single-value metrics aggregation that counts the number of
+// values that are extracted from the aggregated documents.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-valuecount-aggregation.html
+type ValueCountAgg struct {
+	valueSourceParam
+	noAggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*ValueCountAgg)(nil)
+
+func (ValueCountAgg) Kind() AggKind {
+	return AggKindValueCount
+}
+
+func (a *ValueCountAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+func (a ValueCountAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a ValueCountAgg) MarshalJSON() ([]byte, error) {
+	return valueCountAgg{
+		Field:     a.field,
+		Script:    a.script,
+		ValueType: a.valueType,
+	}.MarshalJSON()
+}
+
+func (a *ValueCountAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *ValueCountAgg) UnmarshalJSON(data []byte) error {
+	*a = ValueCountAgg{}
+	p := valueCountAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	a.SetValueType(p.ValueType)
+	return nil
+}
+
+//easyjson:json
+type valueCountAgg struct {
+	Field     string  `json:"field,omitempty"`
+	Script    *Script `json:"script,omitempty"`
+	ValueType string  `json:"value_type,omitempty"`
+}
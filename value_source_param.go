@@ -0,0 +1,79 @@
+package picker
+
+// valueSourceParam is a mixin for aggregations which extract values from
+// either a field or a script.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations.html#run-agg-scripts
+type valueSourceParam struct {
+	field     string
+	script    *Script
+	missing   interface{}
+	valueType string
+	format    string
+}
+
+// Field is the field to extract values from.
+func (vs valueSourceParam) Field() string {
+	return vs.field
+}
+
+// SetField sets the field to extract values from to v
+func (vs *valueSourceParam) SetField(v string) {
+	vs.field = v
+}
+
+// Script is used to generate the values the aggregation uses. If both Field and
+// Script are set, Script is used as a value script, receiving the values of
+// Field as _value.
+func (vs valueSourceParam) Script() *Script {
+	return vs.script
+}
+
+// SetScript sets the script of the aggregation to v
+func (vs *valueSourceParam) SetScript(v *Script) {
+	if v.IsEmpty() {
+		vs.script = nil
+		return
+	}
+	vs.script = v
+}
+
+// Missing defines how documents that are missing a value should be treated. By
+// default they are ignored but it is also possible to treat them as if they
+// had a value.
+func (vs valueSourceParam) Missing() interface{} {
+	return vs.missing
+}
+
+// SetMissing sets the value used for documents without a value to v
+func (vs *valueSourceParam) SetMissing(v interface{}) {
+	vs.missing = v
+}
+
+// ValueType is a hint as to the type of values produced by a script, such as
+// "string", "long", "double", "date" or "ip".
+func (vs valueSourceParam) ValueType() string {
+	return vs.valueType
+}
+
+// SetValueType sets the value_type of the aggregation to v
+func (vs *valueSourceParam) SetValueType(v string) {
+	vs.valueType = v
+}
+
+// Format is the format used to render keys and values in the response
+func (vs valueSourceParam) Format() string {
+	return vs.format
+}
+
+// SetFormat sets the format to v
+func (vs *valueSourceParam) SetFormat(v string) {
+	vs.format = v
+}
+
+func (vs valueSourceParam) checkValueSource() error {
+	if len(vs.field) == 0 && vs.script.IsEmpty() {
+		return ErrFieldOrScriptRequired
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package picker
+
+const DefaultVariableWidthHistogramBuckets = 10
+
+// VariableWidthHistogramAggParams creates a VariableWidthHistogramAgg, a
+// multi-bucket aggregation similar to Histogram. However, the width of each
+// bucket is not specified. Rather, a target number of buckets is provided and
+// bucket intervals are dynamically determined based on the document
+// distribution.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-variablewidthhistogram-aggregation.html
+type VariableWidthHistogramAggParams struct {
+	// The field to aggregate on. Either Field or Script is required.
+	Field  string
+	Script *Script
+	// The target number of buckets. Defaults to 10.
+	Buckets int
+	// The number of buckets that the coordinating node will request from each
+	// shard. Defaults to buckets * 50.
+	ShardSize int
+	// Specifies the number of individual documents that will be stored in
+	// memory on a shard before the initial bucketing algorithm is run.
+	// Defaults to min(10 * shard_size, 50000).
+	InitialBuffer int
+	// Sub-aggregations
+	Aggregations Aggs
+	Meta         map[string]interface{}
+}
+
+func (VariableWidthHistogramAggParams) Kind() AggKind {
+	return AggKindVariableWidthHistogram
+}
+
+func (p VariableWidthHistogramAggParams) Clause() (AggClause, error) {
+	return p.VariableWidthHistogram()
+}
+
+func (p VariableWidthHistogramAggParams) VariableWidthHistogram() (*VariableWidthHistogramAgg, error) {
+	a := &VariableWidthHistogramAgg{}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	err := a.checkValueSource()
+	if err != nil {
+		return a, newAggError(err, AggKindVariableWidthHistogram)
+	}
+	a.SetBuckets(p.Buckets)
+	a.SetShardSize(p.ShardSize)
+	a.SetInitialBuffer(p.InitialBuffer)
+	err = a.SetAggregations(p.Aggregations)
+	if err != nil {
+		return a, newAggError(err, AggKindVariableWidthHistogram)
+	}
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// VariableWidthHistogramAgg is a multi-bucket aggregation similar to Histogram.
+// However, the width of each bucket is not specified. Rather, a target number
+// of buckets is provided and bucket intervals are dynamically determined based
+// on the document distribution.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-variablewidthhistogram-aggregation.html
+type VariableWidthHistogramAgg struct {
+	valueSourceParam
+	buckets       int
+	shardSize     int
+	initialBuffer int
+	aggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*VariableWidthHistogramAgg)(nil)
+
+func (VariableWidthHistogramAgg) Kind() AggKind {
+	return AggKindVariableWidthHistogram
+}
+
+func (a *VariableWidthHistogramAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// Buckets is the target number of buckets. Defaults to 10.
+func (a VariableWidthHistogramAgg) Buckets() int {
+	if a.buckets == 0 {
+		return DefaultVariableWidthHistogramBuckets
+	}
+	return a.buckets
+}
+
+// SetBuckets sets the target number of buckets to v
+func (a *VariableWidthHistogramAgg) SetBuckets(v int) {
+	a.buckets = v
+}
+
+// ShardSize is the number of buckets that the coordinating node will request
+// from each shard.
+func (a VariableWidthHistogramAgg) ShardSize() int {
+	return a.shardSize
+}
+
+// SetShardSize sets shard_size to v
+func (a *VariableWidthHistogramAgg) SetShardSize(v int) {
+	a.shardSize = v
+}
+
+// InitialBuffer is the number of individual documents that will be stored in
+// memory on a shard before the initial bucketing algorithm is run.
+func (a VariableWidthHistogramAgg) InitialBuffer() int {
+	return a.initialBuffer
+}
+
+// SetInitialBuffer sets initial_buffer to v
+func (a *VariableWidthHistogramAgg) SetInitialBuffer(v int) {
+	a.initialBuffer = v
+}
+
+func (a VariableWidthHistogramAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a VariableWidthHistogramAgg) MarshalJSON() ([]byte, error) {
+	return variableWidthHistogramAgg{
+		Field:         a.field,
+		Script:        a.script,
+		Buckets:       a.buckets,
+		ShardSize:     a.shardSize,
+		InitialBuffer: a.initialBuffer,
+	}.MarshalJSON()
+}
+
+func (a *VariableWidthHistogramAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *VariableWidthHistogramAgg) UnmarshalJSON(data []byte) error {
+	*a = VariableWidthHistogramAgg{}
+	p := variableWidthHistogramAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.SetField(p.Field)
+	a.SetScript(p.Script)
+	a.SetBuckets(p.Buckets)
+	a.SetShardSize(p.ShardSize)
+	a.SetInitialBuffer(p.InitialBuffer)
+	return nil
+}
+
+//easyjson:json
+type variableWidthHistogramAgg struct {
+	Field         string  `json:"field,omitempty"`
+	Script        *Script `json:"script,omitempty"`
+	Buckets       int     `json:"buckets,omitempty"`
+	ShardSize     int     `json:"shard_size,omitempty"`
+	InitialBuffer int     `json:"initial_buffer,omitempty"`
+}
@@ -0,0 +1,86 @@
+package picker
+
+import "encoding/json"
+
+type VersionFieldParams struct {
+	// Metadata about the field.
+	Meta Meta `json:"meta,omitempty"`
+}
+
+func (VersionFieldParams) Type() FieldType {
+	return FieldTypeVersion
+}
+
+func (p VersionFieldParams) Field() (Field, error) {
+	return p.Version()
+}
+
+func (p VersionFieldParams) Version() (*VersionField, error) {
+	f := &VersionField{}
+	e := &MappingError{}
+	err := f.SetMeta(p.Meta)
+	if err != nil {
+		e.Append(err)
+	}
+	return f, e.ErrorOrNil()
+}
+
+func NewVersionField(params VersionFieldParams) (*VersionField, error) {
+	return params.Version()
+}
+
+// A VersionField is a specialization of the keyword field for handling
+// software version values and to support specialized precedence rules for
+// them. Precedence is defined following the rules outlined by Semantic
+// Versioning, which for example means that major, minor and patch version
+// parts are sorted numerically (i.e. "2.1.0" < "2.4.1" < "2.11.2") and
+// pre-release versions are sorted before release versions (i.e.
+// "1.0.0-alpha" < "1.0.0").
+//
+// Versions which do not follow Semantic Versioning are indexed as well, but
+// are sorted after valid versions and lexicographically among each other.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/version.html
+type VersionField struct {
+	metaParam
+}
+
+func (v *VersionField) Field() (Field, error) {
+	return v, nil
+}
+
+func (VersionField) Type() FieldType {
+	return FieldTypeVersion
+}
+
+func (v VersionField) MarshalBSON() ([]byte, error) {
+	return v.MarshalJSON()
+}
+
+func (v VersionField) MarshalJSON() ([]byte, error) {
+	return json.Marshal(versionField{
+		Meta: v.meta,
+		Type: v.Type(),
+	})
+}
+
+func (v *VersionField) UnmarshalBSON(data []byte) error {
+	return v.UnmarshalJSON(data)
+}
+
+func (v *VersionField) UnmarshalJSON(data []byte) error {
+	var p VersionFieldParams
+	err := json.Unmarshal(data, &p)
+	if err != nil {
+		return err
+	}
+	n, err := p.Version()
+	*v = *n
+	return err
+}
+
+//easyjson:json
+type versionField struct {
+	Meta Meta      `json:"meta,omitempty"`
+	Type FieldType `json:"type"`
+}
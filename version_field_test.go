@@ -3,6 +3,8 @@ package picker_test
 import (
 	"testing"
 
+	"github.com/chanced/cmpjson"
+	"github.com/chanced/picker"
 	"github.com/stretchr/testify/require"
 )
 
@@ -17,18 +19,16 @@ func TestVersionField(t *testing.T) {
         }
      }
   }`)
-	_ = data
-	_ = assert
-	// i, err := picker.NewIndex(picker.IndexParams{Mappings: picker.Mappings{
-	// 	Properties: picker.FieldMap{
-	// 		"version": picker.VersionFieldParams{}},
-	// }})
-	// assert.NoError(err)
-	// ixd, err := i.MarshalJSON()
-	// assert.NoError(err)
-	// assert.True(cmpjson.Equal(data, ixd), cmpjson.Diff(data, ixd))
-	// i2 := picker.Index{}
-	// err = i2.UnmarshalJSON(data)
-	// assert.NoError(err)
-
+	i, err := picker.NewIndex(picker.IndexParams{Mappings: picker.Mappings{
+		Properties: picker.FieldMap{
+			"version": picker.VersionFieldParams{}},
+	}})
+	assert.NoError(err)
+	ixd, err := i.MarshalJSON()
+	assert.NoError(err)
+	assert.True(cmpjson.Equal(data, ixd), cmpjson.Diff(data, ixd))
+	i2 := picker.Index{}
+	err = i2.UnmarshalJSON(data)
+	assert.NoError(err)
+	assert.Equal(picker.FieldTypeVersion, i2.Mappings.Properties["version"].Type())
 }
@@ -0,0 +1,173 @@
+package picker
+
+// WeightedAvgValue is the source of either the value or the weight of a
+// weighted_avg aggregation. Either Field or Script is required.
+//
+//easyjson:json
+type WeightedAvgValue struct {
+	// The field that values should be extracted from
+	Field string `json:"field,omitempty"`
+	// Script which provides the values
+	Script *Script `json:"script,omitempty"`
+	// The value to use if the field is missing entirely
+	Missing interface{} `json:"missing,omitempty"`
+}
+
+func (v WeightedAvgValue) isEmpty() bool {
+	return len(v.Field) == 0 && v.Script.IsEmpty()
+}
+
+// WeightedAvgAggParams creates a WeightedAvgAgg, a single-value metrics
+// aggregation that computes the weighted average of numeric values that are
+// extracted from the aggregated documents.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-weight-avg-aggregation.html
+type WeightedAvgAggParams struct {
+	// The configuration for the field or script that provides the values
+	// (Required)
+	Value WeightedAvgValue
+	// The configuration for the field or script that provides the weights
+	// (Required)
+	Weight WeightedAvgValue
+	// The numeric response formatter
+	Format string
+	// A hint about the values for pure scripts or unmapped fields
+	ValueType string
+	Meta      map[string]interface{}
+}
+
+func (WeightedAvgAggParams) Kind() AggKind {
+	return AggKindWeightedAvg
+}
+
+func (p WeightedAvgAggParams) Clause() (AggClause, error) {
+	return p.WeightedAvg()
+}
+
+func (p WeightedAvgAggParams) WeightedAvg() (*WeightedAvgAgg, error) {
+	a := &WeightedAvgAgg{}
+	err := a.SetValue(p.Value)
+	if err != nil {
+		return a, newAggError(err, AggKindWeightedAvg)
+	}
+	err = a.SetWeight(p.Weight)
+	if err != nil {
+		return a, newAggError(err, AggKindWeightedAvg)
+	}
+	a.SetFormat(p.Format)
+	a.SetValueType(p.ValueType)
+	a.SetMeta(p.Meta)
+	return a, nil
+}
+
+// WeightedAvgAgg is a single-value metrics aggregation that computes the
+// weighted average of numeric values that are extracted from the aggregated
+// documents.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-weight-avg-aggregation.html
+type WeightedAvgAgg struct {
+	value     WeightedAvgValue
+	weight    WeightedAvgValue
+	format    string
+	valueType string
+	noAggregationsParam
+	aggMetaParam
+}
+
+var _ AggClause = (*WeightedAvgAgg)(nil)
+
+func (WeightedAvgAgg) Kind() AggKind {
+	return AggKindWeightedAvg
+}
+
+func (a *WeightedAvgAgg) Clause() (AggClause, error) {
+	return a, nil
+}
+
+// Value is the configuration for the field or script that provides the values
+func (a WeightedAvgAgg) Value() WeightedAvgValue {
+	return a.value
+}
+
+// SetValue sets the value to v. Either a field or script is required.
+func (a *WeightedAvgAgg) SetValue(v WeightedAvgValue) error {
+	if v.isEmpty() {
+		return ErrValueRequired
+	}
+	a.value = v
+	return nil
+}
+
+// Weight is the configuration for the field or script that provides the
+// weights
+func (a WeightedAvgAgg) Weight() WeightedAvgValue {
+	return a.weight
+}
+
+// SetWeight sets the weight to v. Either a field or script is required.
+func (a *WeightedAvgAgg) SetWeight(v WeightedAvgValue) error {
+	if v.isEmpty() {
+		return ErrWeightRequired
+	}
+	a.weight = v
+	return nil
+}
+
+// Format is the numeric response formatter
+func (a WeightedAvgAgg) Format() string {
+	return a.format
+}
+
+// SetFormat sets format to v
+func (a *WeightedAvgAgg) SetFormat(v string) {
+	a.format = v
+}
+
+// ValueType is a hint about the values for pure scripts or unmapped fields
+func (a WeightedAvgAgg) ValueType() string {
+	return a.valueType
+}
+
+// SetValueType sets value_type to v
+func (a *WeightedAvgAgg) SetValueType(v string) {
+	a.valueType = v
+}
+
+func (a WeightedAvgAgg) MarshalBSON() ([]byte, error) {
+	return a.MarshalJSON()
+}
+
+func (a WeightedAvgAgg) MarshalJSON() ([]byte, error) {
+	return weightedAvgAgg{
+		Value:     a.value,
+		Weight:    a.weight,
+		Format:    a.format,
+		ValueType: a.valueType,
+	}.MarshalJSON()
+}
+
+func (a *WeightedAvgAgg) UnmarshalBSON(data []byte) error {
+	return a.UnmarshalJSON(data)
+}
+
+func (a *WeightedAvgAgg) UnmarshalJSON(data []byte) error {
+	*a = WeightedAvgAgg{}
+	p := weightedAvgAgg{}
+	err := p.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	a.value = p.Value
+	a.weight = p.Weight
+	a.SetFormat(p.Format)
+	a.SetValueType(p.ValueType)
+	return nil
+}
+
+//easyjson:json
+type weightedAvgAgg struct {
+	Value     WeightedAvgValue `json:"value"`
+	Weight    WeightedAvgValue `json:"weight"`
+	Format    string           `json:"format,omitempty"`
+	ValueType string           `json:"value_type,omitempty"`
+}